@@ -49,6 +49,7 @@ func main() {
 		NewBackupCommand(),
 		NewRestoreCommand(),
 		NewStreamCommand(),
+		NewVerifyCommand(),
 		newOperatorCommand(),
 	)
 	// Outputs cmd.Print to stdout.
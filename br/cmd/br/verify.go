@@ -0,0 +1,63 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package main
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/gluetikv"
+	"github.com/pingcap/tidb/br/pkg/task"
+	"github.com/pingcap/tidb/br/pkg/utils"
+	"github.com/pingcap/tidb/br/pkg/version/build"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func runVerifyCommand(command *cobra.Command, cmdName string) error {
+	cfg := task.VerifyConfig{Config: task.Config{LogProgress: HasLogFile()}}
+	if err := cfg.ParseFromFlags(command.Flags()); err != nil {
+		command.SilenceUsage = false
+		return errors.Trace(err)
+	}
+
+	report, err := task.RunVerify(GetDefaultContext(), gluetikv.Glue{}, cmdName, &cfg)
+	if err != nil {
+		log.Error("failed to verify backup", zap.Error(err))
+		return errors.Trace(err)
+	}
+	report.Print(command.OutOrStdout())
+	if !report.Passed() {
+		return errors.Annotatef(berrors.ErrBackupChecksumMismatch,
+			"%d files are missing, %d files are corrupted, %d tables mismatch the checksum",
+			len(report.MissingFiles), len(report.CorruptedFiles), len(report.MismatchedTables))
+	}
+	return nil
+}
+
+// NewVerifyCommand return a verify command.
+func NewVerifyCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "verify",
+		Short: "verify the data files of a backup without restoring it",
+		Long: "verify re-reads every data file of a backup from the storage, checks the sha256 recorded " +
+			"in the backupmeta and recomputes the checksum of every table offline.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		PersistentPreRunE: func(c *cobra.Command, args []string) error {
+			if err := Init(c); err != nil {
+				return errors.Trace(err)
+			}
+			build.LogInfo(build.BR)
+			utils.LogEnvVariables()
+			task.LogArguments(c)
+			return nil
+		},
+		RunE: func(command *cobra.Command, _ []string) error {
+			return runVerifyCommand(command, "Verify")
+		},
+	}
+	task.DefineFilterFlags(command, acceptAllTables, false)
+	task.DefineVerifyFlags(command.Flags())
+	return command
+}
@@ -118,6 +118,9 @@ type ExternalEngineConfig struct {
 	// TotalKVCount can be an estimated value.
 	TotalKVCount int64
 	CheckHotspot bool
+	// MasterKeyFile is the file of the master key which encrypts the files in the storage, the files are not
+	// encrypted if it's empty.
+	MasterKeyFile string
 }
 
 // CheckCtx contains all parameters used in CheckRequirements
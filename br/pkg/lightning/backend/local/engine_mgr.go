@@ -277,6 +277,13 @@ func (em *engineManager) closeEngine(ctx context.Context, cfg *backend.EngineCon
 		if err != nil {
 			return err
 		}
+		if externalCfg.MasterKeyFile != "" {
+			masterKey, err := storage.NewFileMasterKey(externalCfg.MasterKeyFile)
+			if err != nil {
+				return err
+			}
+			store = storage.WithEncryption(store, masterKey)
+		}
 		physical, logical, err := em.GetTS(ctx)
 		if err != nil {
 			return err
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	gmysql "github.com/go-sql-driver/mysql"
@@ -272,6 +273,19 @@ type tidbBackend struct {
 	// view should be the same.
 	onDuplicate string
 	errorMgr    *errormanager.ErrorManager
+	// tableColumns caches the insertable columns of the tables, which are used
+	// to build the ON DUPLICATE KEY UPDATE clause when the data file has no
+	// column names.
+	tableColumns sync.Map // tableName -> []string
+	// uniqueKeys caches the unique keys of the tables, which are used to query
+	// the existing rows overwritten by the "replace-latest" conflict strategy.
+	uniqueKeys sync.Map // tableName -> [][]uniqueKeyColumn
+}
+
+type uniqueKeyColumn struct {
+	name string
+	// prefixLen is the length of the prefix index, 0 means the whole column.
+	prefixLen int
 }
 
 var _ backend.Backend = (*tidbBackend)(nil)
@@ -300,6 +314,11 @@ func NewTiDBBackend(
 			// to record the row
 			onDuplicate = config.ErrorOnDup
 		}
+	case config.ReplaceLatestOnDup:
+		// the batch INSERT ... ON DUPLICATE KEY UPDATE can't tell which rows
+		// are duplicated, so stop batch insert on error and fall back to row by
+		// row insert to count and record the losing rows.
+		onDuplicate = config.ErrorOnDup
 	default:
 		log.FromContext(ctx).Warn("unsupported conflict strategy, overwrite with `error`")
 		onDuplicate = config.ErrorOnDup
@@ -643,6 +662,9 @@ rowLoop:
 type stmtTask struct {
 	rows tidbRows
 	stmt string
+	// conflictQuery queries the existing rows which conflict with the row. It's
+	// only set for the "replace-latest" conflict strategy in row-by-row mode.
+	conflictQuery string
 }
 
 // WriteBatchRowsToDB write rows in batch mode, which will insert multiple rows like this:
@@ -663,7 +685,7 @@ func (be *tidbBackend) WriteBatchRowsToDB(ctx context.Context, tableName string,
 		}
 		insertStmt.WriteString(row.insertStmt)
 	}
-	stmtTasks[0] = stmtTask{rows, insertStmt.String()}
+	stmtTasks[0] = stmtTask{rows: rows, stmt: insertStmt.String()}
 	return be.execStmts(ctx, stmtTasks, tableName, true)
 }
 
@@ -689,12 +711,29 @@ func (be *tidbBackend) WriteRowsToDB(ctx context.Context, tableName string, colu
 		return nil
 	}
 	is := insertStmt.String()
+	var onDupUpdate, conflictQueryPrefix, conflictQuerySuffix string
+	if be.conflictCfg.Strategy == config.ReplaceLatestOnDup {
+		var err error
+		if onDupUpdate, err = be.buildOnDupUpdateClause(ctx, tableName, columnNames); err != nil {
+			return errors.Trace(err)
+		}
+		conflictQueryPrefix, conflictQuerySuffix, err = be.buildConflictQuery(ctx, tableName, columnNames)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	stmtTasks := make([]stmtTask, 0, len(rows))
 	for _, row := range rows {
 		var finalInsertStmt strings.Builder
 		finalInsertStmt.WriteString(is)
 		finalInsertStmt.WriteString(row.insertStmt)
-		stmtTasks = append(stmtTasks, stmtTask{[]tidbRow{row}, finalInsertStmt.String()})
+		finalInsertStmt.WriteString(onDupUpdate)
+		task := stmtTask{rows: []tidbRow{row}, stmt: finalInsertStmt.String()}
+		if len(conflictQueryPrefix) > 0 {
+			// the row is "(v1,v2,...)", which is used as the select list.
+			task.conflictQuery = conflictQueryPrefix + row.insertStmt[1:len(row.insertStmt)-1] + conflictQuerySuffix
+		}
+		stmtTasks = append(stmtTasks, task)
 	}
 	return be.execStmts(ctx, stmtTasks, tableName, false)
 }
@@ -724,6 +763,250 @@ func (be *tidbBackend) buildStmt(tableName string, columnNames []string) *string
 	return &insertStmt
 }
 
+// buildOnDupUpdateClause builds the ON DUPLICATE KEY UPDATE clause of the
+// "replace-latest" conflict strategy, which only overwrites the existing row
+// when the version of the new row isn't older. The version column is assigned
+// last since the assignments are evaluated in order.
+func (be *tidbBackend) buildOnDupUpdateClause(ctx context.Context, tableName string, columnNames []string) (string, error) {
+	if len(columnNames) == 0 {
+		var err error
+		if columnNames, err = be.getTableColumns(ctx, tableName); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	versionCol := ""
+	updateCols := make([]string, 0, len(columnNames))
+	for _, colName := range columnNames {
+		switch {
+		case strings.EqualFold(colName, be.conflictCfg.VersionColumn):
+			versionCol = colName
+		case strings.EqualFold(colName, model.ExtraHandleName.O):
+		default:
+			updateCols = append(updateCols, colName)
+		}
+	}
+	if versionCol == "" {
+		return "", common.ErrInvalidConfig.GenWithStack(
+			"conflict.version-column %s not found in the columns of %s", be.conflictCfg.VersionColumn, tableName)
+	}
+	updateCols = append(updateCols, versionCol)
+
+	var cond strings.Builder
+	cond.WriteString("VALUES(")
+	common.WriteMySQLIdentifier(&cond, versionCol)
+	cond.WriteString(") >= ")
+	common.WriteMySQLIdentifier(&cond, versionCol)
+	cond.WriteString(" OR ")
+	common.WriteMySQLIdentifier(&cond, versionCol)
+	cond.WriteString(" IS NULL")
+
+	var clause strings.Builder
+	clause.WriteString(" ON DUPLICATE KEY UPDATE ")
+	for i, colName := range updateCols {
+		if i != 0 {
+			clause.WriteByte(',')
+		}
+		common.WriteMySQLIdentifier(&clause, colName)
+		clause.WriteString("=IF(")
+		clause.WriteString(cond.String())
+		clause.WriteString(",VALUES(")
+		common.WriteMySQLIdentifier(&clause, colName)
+		clause.WriteString("),")
+		common.WriteMySQLIdentifier(&clause, colName)
+		clause.WriteByte(')')
+	}
+	return clause.String(), nil
+}
+
+// getTableColumns returns the non-generated columns of the table in order.
+func (be *tidbBackend) getTableColumns(ctx context.Context, tableName string) ([]string, error) {
+	if cols, ok := be.tableColumns.Load(tableName); ok {
+		return cols.([]string), nil
+	}
+	rows, err := be.db.QueryContext(ctx, "SHOW COLUMNS FROM "+tableName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer rows.Close()
+	var cols []string
+	for rows.Next() {
+		var (
+			field, extra string
+			ignore       sql.RawBytes
+		)
+		if err := rows.Scan(&field, &ignore, &ignore, &ignore, &ignore, &extra); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if strings.Contains(strings.ToUpper(extra), "GENERATED") {
+			continue
+		}
+		cols = append(cols, field)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	be.tableColumns.Store(tableName, cols)
+	return cols, nil
+}
+
+// buildConflictQuery builds the query of the existing rows which conflict with
+// a row on any unique key, the values of the row should be placed between the
+// returned prefix and suffix. The existing rows are formatted like the rows
+// recorded in the conflict error table. Both are empty if no unique key is
+// written.
+func (be *tidbBackend) buildConflictQuery(ctx context.Context, tableName string, columnNames []string) (prefix, suffix string, err error) {
+	if len(columnNames) == 0 {
+		if columnNames, err = be.getTableColumns(ctx, tableName); err != nil {
+			return "", "", errors.Trace(err)
+		}
+	}
+	uniqueKeys, err := be.getUniqueKeys(ctx, tableName)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	written := make(map[string]struct{}, len(columnNames))
+	for _, colName := range columnNames {
+		written[strings.ToLower(colName)] = struct{}{}
+	}
+	if _, ok := written[model.ExtraHandleName.L]; ok {
+		uniqueKeys = append(uniqueKeys, []uniqueKeyColumn{{name: model.ExtraHandleName.O}})
+	}
+
+	var cond strings.Builder
+keyLoop:
+	for _, key := range uniqueKeys {
+		// the unique keys whose columns are not all written can't be compared.
+		for _, col := range key {
+			if _, ok := written[strings.ToLower(col.name)]; !ok {
+				continue keyLoop
+			}
+		}
+		if cond.Len() > 0 {
+			cond.WriteString(" OR ")
+		}
+		cond.WriteByte('(')
+		for i, col := range key {
+			if i != 0 {
+				cond.WriteString(" AND ")
+			}
+			for j, alias := range []string{"t", "n"} {
+				if j != 0 {
+					cond.WriteByte('=')
+				}
+				if col.prefixLen > 0 {
+					cond.WriteString("LEFT(")
+				}
+				cond.WriteString(alias)
+				cond.WriteByte('.')
+				common.WriteMySQLIdentifier(&cond, col.name)
+				if col.prefixLen > 0 {
+					cond.WriteString(fmt.Sprintf(",%d)", col.prefixLen))
+				}
+			}
+		}
+		cond.WriteByte(')')
+	}
+	if cond.Len() == 0 {
+		return "", "", nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("SELECT CONCAT('(',CONCAT_WS(','")
+	for _, colName := range columnNames {
+		if strings.EqualFold(colName, model.ExtraHandleName.O) {
+			continue
+		}
+		sb.WriteString(",QUOTE(t.")
+		common.WriteMySQLIdentifier(&sb, colName)
+		sb.WriteByte(')')
+	}
+	sb.WriteString("),')') FROM ")
+	sb.WriteString(tableName)
+	// the values of the row are named by a union with an empty select.
+	sb.WriteString(" AS t JOIN (SELECT ")
+	for i, colName := range columnNames {
+		if i != 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString("NULL AS ")
+		common.WriteMySQLIdentifier(&sb, colName)
+	}
+	sb.WriteString(" FROM DUAL WHERE FALSE UNION ALL SELECT ")
+	return sb.String(), ") AS n ON " + cond.String() + " FOR UPDATE", nil
+}
+
+// getUniqueKeys returns the columns of the unique keys of the table, the
+// expression indexes are skipped.
+func (be *tidbBackend) getUniqueKeys(ctx context.Context, tableName string) ([][]uniqueKeyColumn, error) {
+	if keys, ok := be.uniqueKeys.Load(tableName); ok {
+		return keys.([][]uniqueKeyColumn), nil
+	}
+	rows, err := be.db.QueryContext(ctx, "SHOW INDEX FROM "+tableName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer rows.Close()
+	fields, err := rows.Columns()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	fieldIdx := make(map[string]int, len(fields))
+	for i, field := range fields {
+		fieldIdx[strings.ToLower(field)] = i
+	}
+	for _, field := range []string{"non_unique", "key_name", "column_name", "sub_part"} {
+		if _, ok := fieldIdx[field]; !ok {
+			return nil, errors.Errorf("unexpected result of SHOW INDEX, field %s is missing", field)
+		}
+	}
+	var (
+		keys     [][]uniqueKeyColumn
+		keyNames []string
+		skipped  = make(map[string]struct{})
+	)
+	values := make([]sql.NullString, len(fields))
+	dest := make([]any, len(fields))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if values[fieldIdx["non_unique"]].String != "0" {
+			continue
+		}
+		keyName := values[fieldIdx["key_name"]].String
+		colName := values[fieldIdx["column_name"]]
+		if !colName.Valid {
+			skipped[keyName] = struct{}{}
+			continue
+		}
+		col := uniqueKeyColumn{name: colName.String}
+		if subPart := values[fieldIdx["sub_part"]]; subPart.Valid {
+			if col.prefixLen, err = strconv.Atoi(subPart.String); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if len(keyNames) == 0 || keyNames[len(keyNames)-1] != keyName {
+			keyNames = append(keyNames, keyName)
+			keys = append(keys, nil)
+		}
+		keys[len(keys)-1] = append(keys[len(keys)-1], col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([][]uniqueKeyColumn, 0, len(keys))
+	for i, key := range keys {
+		if _, ok := skipped[keyNames[i]]; !ok {
+			result = append(result, key)
+		}
+	}
+	be.uniqueKeys.Store(tableName, result)
+	return result, nil
+}
+
 func (be *tidbBackend) execStmts(ctx context.Context, stmtTasks []stmtTask, tableName string, batch bool) error {
 stmtLoop:
 	for _, stmtTask := range stmtTasks {
@@ -733,7 +1016,21 @@ stmtLoop:
 		)
 		for i := 0; i < writeRowsMaxRetryTimes; i++ {
 			stmt := stmtTask.stmt
-			result, err = be.db.ExecContext(ctx, stmt)
+			if len(stmtTask.conflictQuery) > 0 {
+				var (
+					affected  int64
+					conflicts []string
+				)
+				affected, conflicts, err = be.execReplaceLatest(ctx, stmtTask)
+				if err == nil {
+					if err2 := be.recordReplaceLatest(ctx, tableName, stmtTask.rows[0], affected, conflicts); err2 != nil {
+						return err2
+					}
+					continue stmtLoop
+				}
+			} else {
+				result, err = be.db.ExecContext(ctx, stmt)
+			}
 			if err == nil {
 				affected, err2 := result.RowsAffected()
 				if err2 != nil {
@@ -814,6 +1111,93 @@ stmtLoop:
 	return nil
 }
 
+// execReplaceLatest writes a row of the "replace-latest" conflict strategy by
+// INSERT ... ON DUPLICATE KEY UPDATE in a transaction, the existing rows which
+// conflict with it are queried and locked first, so that the row overwritten
+// by it can be recorded.
+func (be *tidbBackend) execReplaceLatest(ctx context.Context, task stmtTask) (affected int64, conflicts []string, err error) {
+	txn, err := be.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = txn.Rollback()
+		}
+	}()
+	rows, err := txn.QueryContext(ctx, task.conflictQuery)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	for rows.Next() {
+		var row string
+		if err = rows.Scan(&row); err != nil {
+			_ = rows.Close()
+			return 0, nil, errors.Trace(err)
+		}
+		conflicts = append(conflicts, row)
+	}
+	if err = rows.Err(); err != nil {
+		_ = rows.Close()
+		return 0, nil, errors.Trace(err)
+	}
+	if err = rows.Close(); err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	result, err := txn.ExecContext(ctx, task.stmt)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	if affected, err = result.RowsAffected(); err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	return affected, conflicts, errors.Trace(txn.Commit())
+}
+
+// recordReplaceLatest records the losing rows of INSERT ... ON DUPLICATE KEY
+// UPDATE of the "replace-latest" conflict strategy, whose affected rows is 0
+// if the existing row is kept and 2 if the existing row is replaced. The
+// existing rows which conflict with the row are passed in conflicts, they are
+// recorded with the position of the row which replaces them.
+func (be *tidbBackend) recordReplaceLatest(
+	ctx context.Context,
+	tableName string,
+	row tidbRow,
+	affected int64,
+	conflicts []string,
+) error {
+	switch affected {
+	case 0:
+		return be.errorMgr.RecordDuplicate(
+			ctx,
+			log.FromContext(ctx),
+			tableName,
+			row.path,
+			row.offset,
+			"conflicts with an existing row which is kept because the row is not newer",
+			0,
+			row.insertStmt,
+		)
+	case 2:
+		for _, conflict := range conflicts {
+			err := be.errorMgr.RecordDuplicate(
+				ctx,
+				log.FromContext(ctx),
+				tableName,
+				row.path,
+				row.offset,
+				"the existing row is replaced by the row at the offset because its version is older",
+				0,
+				conflict,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func isDupEntryError(err error) bool {
 	merr, ok := errors.Cause(err).(*gmysql.MySQLError)
 	if !ok {
@@ -273,6 +273,174 @@ func TestWriteRowsErrorOnDup(t *testing.T) {
 	require.Nil(t, st)
 }
 
+func encodeVersionedRowsTiDB(t *testing.T, encBuilder encode.EncodingBuilder, tbl table.Table, rows [][2]int64) encode.Rows {
+	dataRows := encBuilder.MakeEmptyRows()
+	dataChecksum := verification.MakeKVChecksum(0, 0, 0)
+	indexRows := encBuilder.MakeEmptyRows()
+	indexChecksum := verification.MakeKVChecksum(0, 0, 0)
+	encoder, err := encBuilder.NewEncoder(context.Background(), &encode.EncodingConfig{
+		Path:   "1.csv",
+		Table:  tbl,
+		Logger: log.L(),
+	})
+	require.NoError(t, err)
+	for i, r := range rows {
+		row, err := encoder.Encode([]types.Datum{types.NewIntDatum(r[0]), types.NewIntDatum(r[1])}, int64(i+1),
+			[]int{0, 1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1}, int64(i))
+		require.NoError(t, err)
+		row.ClassifyAndAppend(&dataRows, &dataChecksum, &indexRows, &indexChecksum)
+	}
+	return dataRows
+}
+
+func TestWriteRowsReplaceLatestOnDup(t *testing.T) {
+	s := createMysqlSuite(t)
+	defer s.TearDownTest(t)
+	ctx := context.Background()
+	const onDupUpdate = " ON DUPLICATE KEY UPDATE " +
+		"`c0`=IF(VALUES(`c1`) >= `c1` OR `c1` IS NULL,VALUES(`c0`),`c0`)," +
+		"`c1`=IF(VALUES(`c1`) >= `c1` OR `c1` IS NULL,VALUES(`c1`),`c1`)"
+
+	cfg := config.NewConfig()
+	cfg.Conflict.Strategy = config.ReplaceLatestOnDup
+	cfg.Conflict.VersionColumn = "C1"
+	cfg.Conflict.Threshold = math.MaxInt64
+	cfg.Conflict.MaxRecordRows = 0
+	cfg.App.TaskInfoSchemaName = "tidb_lightning_errors"
+	be := tidb.NewTiDBBackend(ctx, s.dbHandle, cfg.Conflict, errormanager.New(s.dbHandle, cfg, log.L()))
+	engine, err := backend.MakeEngineManager(be).OpenEngine(ctx, &backend.EngineConfig{}, "`foo`.`bar`", 1)
+	require.NoError(t, err)
+	dataRows := encodeVersionedRowsTiDB(t, s.encBuilder, s.tbl, [][2]int64{{1, 10}, {2, 20}})
+	dupErr := &gmysql.MySQLError{Number: errno.ErrDupEntry, Message: "Duplicate entry '2' for key 'PRIMARY'"}
+	showIndexRows := func() *sqlmock.Rows {
+		// the expression indexes and the non-unique indexes are skipped.
+		return sqlmock.NewRows([]string{"Table", "Non_unique", "Key_name", "Seq_in_index", "Column_name", "Sub_part", "Expression"}).
+			AddRow("bar", 0, "PRIMARY", 1, "c0", nil, nil).
+			AddRow("bar", 0, "uk", 1, "c2", 4, nil).
+			AddRow("bar", 0, "uk_expr", 1, nil, nil, "`c1` + 1").
+			AddRow("bar", 1, "idx", 1, "c1", nil, nil)
+	}
+	conflictQuery := func(values string) string {
+		return "\\QSELECT CONCAT('(',CONCAT_WS(',',QUOTE(t.`c0`),QUOTE(t.`c1`)),')') FROM `foo`.`bar` AS t " +
+			"JOIN (SELECT NULL AS `c0`,NULL AS `c1` FROM DUAL WHERE FALSE UNION ALL SELECT " + values + ") AS n " +
+			"ON (t.`c0`=n.`c0`) FOR UPDATE\\E"
+	}
+
+	// the rows are written in batch if there is no conflict.
+	s.mockDB.
+		ExpectExec("\\QINSERT INTO `foo`.`bar`(`c0`,`c1`) VALUES(1,10),(2,20)\\E").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	writer, err := engine.LocalWriter(ctx, &backend.LocalWriterConfig{TableName: "`foo`.`bar`"})
+	require.NoError(t, err)
+	require.NoError(t, writer.AppendRows(ctx, []string{"c0", "c1"}, dataRows))
+
+	// the batch fails on the conflicts, then the rows are written row by row
+	// with the version compared in ON DUPLICATE KEY UPDATE. The generated
+	// columns are skipped if the data file has no column names.
+	s.mockDB.
+		ExpectExec("\\QINSERT INTO `foo`.`bar` VALUES(1,10),(2,20)\\E").
+		WillReturnError(dupErr)
+	s.mockDB.
+		ExpectQuery("\\QSHOW COLUMNS FROM `foo`.`bar`\\E").
+		WillReturnRows(sqlmock.NewRows([]string{"Field", "Type", "Null", "Key", "Default", "Extra"}).
+			AddRow("c0", "int", "YES", "", nil, "").
+			AddRow("c1", "int", "YES", "", nil, "").
+			AddRow("c2", "int", "YES", "", nil, "VIRTUAL GENERATED"))
+	s.mockDB.ExpectQuery("\\QSHOW INDEX FROM `foo`.`bar`\\E").WillReturnRows(showIndexRows())
+	for _, values := range []string{"1,10", "2,20"} {
+		s.mockDB.ExpectBegin()
+		s.mockDB.ExpectQuery(conflictQuery(values)).WillReturnRows(sqlmock.NewRows([]string{"row"}).AddRow("('2','15')"))
+		s.mockDB.
+			ExpectExec("\\QINSERT INTO `foo`.`bar` VALUES(" + values + ")" + onDupUpdate + "\\E").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		s.mockDB.ExpectCommit()
+	}
+	require.NoError(t, writer.AppendRows(ctx, nil, dataRows))
+	_, err = writer.Close(ctx)
+	require.NoError(t, err)
+
+	// the version column must be written.
+	s.mockDB.
+		ExpectExec("\\QINSERT INTO `foo`.`bar`(`c0`,`c2`) VALUES(1,10),(2,20)\\E").
+		WillReturnError(dupErr)
+	writer, err = engine.LocalWriter(ctx, &backend.LocalWriterConfig{TableName: "`foo`.`bar`"})
+	require.NoError(t, err)
+	err = writer.AppendRows(ctx, []string{"c0", "c2"}, dataRows)
+	require.ErrorContains(t, err, "conflict.version-column C1 not found in the columns of `foo`.`bar`")
+
+	// the losing rows are counted by conflict.threshold.
+	cfg.Conflict.Threshold = 1
+	be = tidb.NewTiDBBackend(ctx, s.dbHandle, cfg.Conflict, errormanager.New(s.dbHandle, cfg, log.L()))
+	engine, err = backend.MakeEngineManager(be).OpenEngine(ctx, &backend.EngineConfig{}, "`foo`.`bar`", 1)
+	require.NoError(t, err)
+	s.mockDB.
+		ExpectExec("\\QINSERT INTO `foo`.`bar`(`c0`,`c1`) VALUES(1,10),(2,20)\\E").
+		WillReturnError(dupErr)
+	s.mockDB.ExpectQuery("\\QSHOW INDEX FROM `foo`.`bar`\\E").WillReturnRows(showIndexRows())
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectQuery(conflictQuery("1,10")).WillReturnRows(sqlmock.NewRows([]string{"row"}).AddRow("('1','15')"))
+	s.mockDB.
+		ExpectExec("\\QINSERT INTO `foo`.`bar`(`c0`,`c1`) VALUES(1,10)" + onDupUpdate + "\\E").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectQuery(conflictQuery("2,20")).WillReturnRows(sqlmock.NewRows([]string{"row"}).AddRow("('2','15')"))
+	s.mockDB.
+		ExpectExec("\\QINSERT INTO `foo`.`bar`(`c0`,`c1`) VALUES(2,20)" + onDupUpdate + "\\E").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mockDB.ExpectCommit()
+	writer, err = engine.LocalWriter(ctx, &backend.LocalWriterConfig{TableName: "`foo`.`bar`"})
+	require.NoError(t, err)
+	err = writer.AppendRows(ctx, []string{"c0", "c1"}, dataRows)
+	require.ErrorContains(t, err, "The number of conflict errors exceeds the threshold configured by `conflict.threshold`: '1'")
+
+	// the conflicts are recorded if conflict.max-record-rows is set.
+	cfg.Conflict.Threshold = math.MaxInt64
+	cfg.Conflict.MaxRecordRows = 10
+	be = tidb.NewTiDBBackend(ctx, s.dbHandle, cfg.Conflict, errormanager.New(s.dbHandle, cfg, log.L()))
+	engine, err = backend.MakeEngineManager(be).OpenEngine(ctx, &backend.EngineConfig{}, "`foo`.`bar`", 1)
+	require.NoError(t, err)
+	dataRows = encodeVersionedRowsTiDB(t, s.encBuilder, s.tbl, [][2]int64{{1, 10}, {2, 20}, {3, 30}})
+	s.mockDB.
+		ExpectExec("\\QINSERT INTO `foo`.`bar`(`c0`,`c1`) VALUES(1,10),(2,20),(3,30)\\E").
+		WillReturnError(dupErr)
+	s.mockDB.ExpectQuery("\\QSHOW INDEX FROM `foo`.`bar`\\E").WillReturnRows(showIndexRows())
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectQuery(conflictQuery("1,10")).WillReturnRows(sqlmock.NewRows([]string{"row"}))
+	s.mockDB.
+		ExpectExec("\\QINSERT INTO `foo`.`bar`(`c0`,`c1`) VALUES(1,10)" + onDupUpdate + "\\E").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mockDB.ExpectCommit()
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectQuery(conflictQuery("2,20")).WillReturnRows(sqlmock.NewRows([]string{"row"}).AddRow("('2','25')"))
+	s.mockDB.
+		ExpectExec("\\QINSERT INTO `foo`.`bar`(`c0`,`c1`) VALUES(2,20)" + onDupUpdate + "\\E").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.ExpectCommit()
+	s.mockDB.
+		ExpectExec("INSERT INTO `tidb_lightning_errors`\\.conflict_records.*").
+		WithArgs(sqlmock.AnyArg(), "`foo`.`bar`", "1.csv", int64(1),
+			"conflicts with an existing row which is kept because the row is not newer", 0, "(2,20)").
+		WillReturnResult(driver.ResultNoRows)
+	// the overwritten row is recorded.
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectQuery(conflictQuery("3,30")).WillReturnRows(sqlmock.NewRows([]string{"row"}).AddRow("('3','15')"))
+	s.mockDB.
+		ExpectExec("\\QINSERT INTO `foo`.`bar`(`c0`,`c1`) VALUES(3,30)" + onDupUpdate + "\\E").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mockDB.ExpectCommit()
+	s.mockDB.
+		ExpectExec("INSERT INTO `tidb_lightning_errors`\\.conflict_records.*").
+		WithArgs(sqlmock.AnyArg(), "`foo`.`bar`", "1.csv", int64(2),
+			"the existing row is replaced by the row at the offset because its version is older", 0, "('3','15')").
+		WillReturnResult(driver.ResultNoRows)
+	writer, err = engine.LocalWriter(ctx, &backend.LocalWriterConfig{TableName: "`foo`.`bar`"})
+	require.NoError(t, err)
+	require.NoError(t, writer.AppendRows(ctx, []string{"c0", "c1"}, dataRows))
+	_, err = writer.Close(ctx)
+	require.NoError(t, err)
+}
+
 // TODO: temporarily disable this test before we fix strict mode
 //
 //nolint:unused,deadcode
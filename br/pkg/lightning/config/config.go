@@ -69,6 +69,9 @@ const (
 	IgnoreOnDup = "ignore"
 	// ErrorOnDup indicates using INSERT INTO to insert data, which would violate PK or UNIQUE constraint
 	ErrorOnDup = "error"
+	// ReplaceLatestOnDup indicates keeping the row with the greatest value of
+	// conflict.version-column among the conflicting rows
+	ReplaceLatestOnDup = "replace-latest"
 
 	// KVWriteBatchSize batch size when write to TiKV.
 	// this is the default value of linux send buffer size(net.ipv4.tcp_wmem) too.
@@ -108,7 +111,7 @@ const (
 )
 
 var (
-	supportedStorageTypes = []string{"file", "local", "s3", "noop", "gcs", "gs"}
+	supportedStorageTypes = []string{"file", "local", "s3", "noop", "gcs", "gs", "sftp", "webdav", "webdavs"}
 
 	defaultFilter = []string{
 		"*.*",
@@ -873,6 +876,8 @@ type MydumperRuntime struct {
 	// DataInvalidCharReplace is the replacement characters for non-compatible characters, which shouldn't duplicate with the separators or line breaks.
 	// Changing the default value will result in increased parsing time. Non-compatible characters do not cause an increase in error.
 	DataInvalidCharReplace string `toml:"data-invalid-char-replace" json:"data-invalid-char-replace"`
+	// EncryptionMasterKeyFile is the file of the master key to decrypt the source files encrypted by Dumpling.
+	EncryptionMasterKeyFile string `toml:"encryption-master-key-file" json:"encryption-master-key-file"`
 }
 
 func (m *MydumperRuntime) adjust() error {
@@ -1324,6 +1329,9 @@ type Conflict struct {
 	Strategy      string `toml:"strategy" json:"strategy"`
 	Threshold     int64  `toml:"threshold" json:"threshold"`
 	MaxRecordRows int64  `toml:"max-record-rows" json:"max-record-rows"`
+	// VersionColumn is the column to compare among the conflicting rows when
+	// Strategy is ReplaceLatestOnDup.
+	VersionColumn string `toml:"version-column" json:"version-column"`
 }
 
 // adjust assigns default values and check illegal values. The arguments must be
@@ -1341,11 +1349,20 @@ func (c *Conflict) adjust(i *TikvImporter, l *Lightning) error {
 	}
 	c.Strategy = strings.ToLower(c.Strategy)
 	switch c.Strategy {
-	case ReplaceOnDup, IgnoreOnDup, ErrorOnDup, "":
+	case ReplaceOnDup, IgnoreOnDup, ErrorOnDup, ReplaceLatestOnDup, "":
 	default:
 		return common.ErrInvalidConfig.GenWithStack(
 			"unsupported `%s` (%s)", strategyConfigFrom, c.Strategy)
 	}
+	if c.Strategy == ReplaceLatestOnDup {
+		if c.VersionColumn == "" {
+			return common.ErrInvalidConfig.GenWithStack(
+				`conflict.version-column must be set when use %s = "%s"`, strategyConfigFrom, ReplaceLatestOnDup)
+		}
+	} else if c.VersionColumn != "" {
+		return common.ErrInvalidConfig.GenWithStack(
+			`conflict.version-column can only be used with conflict.strategy = "%s"`, ReplaceLatestOnDup)
+	}
 	if c.Strategy != "" {
 		if i.ParallelImport && i.Backend == BackendLocal {
 			return common.ErrInvalidConfig.GenWithStack(
@@ -1363,7 +1380,7 @@ func (c *Conflict) adjust(i *TikvImporter, l *Lightning) error {
 		switch c.Strategy {
 		case ErrorOnDup:
 			c.Threshold = 0
-		case IgnoreOnDup, ReplaceOnDup:
+		case IgnoreOnDup, ReplaceOnDup, ReplaceLatestOnDup:
 			c.Threshold = math.MaxInt64
 		case "":
 			c.Threshold = 0
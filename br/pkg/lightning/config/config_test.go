@@ -1298,4 +1298,17 @@ func TestAdjustConflict(t *testing.T) {
 	cfg.Conflict.Threshold = 1
 	cfg.Conflict.MaxRecordRows = 1
 	require.ErrorContains(t, cfg.Conflict.adjust(&cfg.TikvImporter, &cfg.App), `cannot record duplication (conflict.max-record-rows > 0) when use tikv-importer.backend = "tidb" and conflict.strategy = "replace"`)
+
+	cfg.Conflict.Strategy = "Replace-Latest"
+	cfg.Conflict.Threshold = -1
+	cfg.Conflict.MaxRecordRows = -1
+	require.ErrorContains(t, cfg.Conflict.adjust(&cfg.TikvImporter, &cfg.App), `conflict.version-column must be set when use conflict.strategy = "replace-latest"`)
+	cfg.Conflict.VersionColumn = "updated_at"
+	require.NoError(t, cfg.Conflict.adjust(&cfg.TikvImporter, &cfg.App))
+	require.Equal(t, ReplaceLatestOnDup, cfg.Conflict.Strategy)
+	require.Equal(t, int64(math.MaxInt64), cfg.Conflict.Threshold)
+	require.Equal(t, int64(defaultMaxRecordRows), cfg.Conflict.MaxRecordRows)
+
+	cfg.Conflict.Strategy = IgnoreOnDup
+	require.ErrorContains(t, cfg.Conflict.adjust(&cfg.TikvImporter, &cfg.App), `conflict.version-column can only be used with conflict.strategy = "replace-latest"`)
 }
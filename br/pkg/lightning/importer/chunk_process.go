@@ -43,6 +43,7 @@ import (
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/extsort"
+	"github.com/pingcap/tidb/pkg/util/set"
 	"go.uber.org/zap"
 )
 
@@ -109,6 +110,13 @@ func openParser(
 		if err != nil {
 			return nil, err
 		}
+	case mydump.SourceTypeJSONL:
+		parser = mydump.NewJSONLParser(ctx, reader, blockBufSize, ioWorkers)
+	case mydump.SourceTypeAvro:
+		parser, err = mydump.NewAvroParser(ctx, reader)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, errors.Errorf("file '%s' with unknown source type '%s'", chunk.Key.Path, chunk.FileMeta.Type.String())
 	}
@@ -127,11 +135,28 @@ func openParser(
 	}
 	if len(chunk.ColumnPermutation) > 0 {
 		parser.SetColumns(getColumnNames(tblInfo, chunk.ColumnPermutation))
+	} else if chunk.FileMeta.Type == mydump.SourceTypeJSONL {
+		parser.SetColumns(getJSONLColumnNames(tblInfo, chunk.FileMeta.ExtendData))
 	}
 
 	return parser, nil
 }
 
+// getJSONLColumnNames returns the columns that the keys of a JSON-lines file are mapped to. The columns are decided by
+// the target table instead of the first row of the chunk, so the rows can have different keys, the missing keys are
+// imported as NULL and the keys which are not the columns of the table are ignored.
+func getJSONLColumnNames(tableInfo *model.TableInfo, extendData mydump.ExtendColumnData) []string {
+	extendCols := set.NewStringSet(extendData.Columns...)
+	names := make([]string, 0, len(tableInfo.Columns))
+	for _, col := range tableInfo.Columns {
+		if col.Hidden || col.IsGenerated() || extendCols.Exist(col.Name.O) {
+			continue
+		}
+		names = append(names, col.Name.L)
+	}
+	return names
+}
+
 func getColumnNames(tableInfo *model.TableInfo, permutation []int) []string {
 	colIndexes := make([]int, 0, len(permutation))
 	for i := 0; i < len(permutation); i++ {
@@ -344,7 +369,7 @@ func (cr *chunkProcessor) encodeLoop(
 			err = cr.parser.ReadRow()
 			columnNames := cr.parser.Columns()
 			newOffset, rowID = cr.parser.Pos()
-			if cr.chunk.FileMeta.Compression != mydump.CompressionNone || cr.chunk.FileMeta.Type == mydump.SourceTypeParquet {
+			if cr.chunk.FileMeta.Compression != mydump.CompressionNone || cr.chunk.FileMeta.Type.OffsetInRows() {
 				newScannedOffset, scannedOffsetErr = cr.parser.ScannedPos()
 				if scannedOffsetErr != nil {
 					logger.Warn("fail to get data engine ScannedPos, progress may not be accurate",
@@ -494,7 +519,7 @@ func (cr *chunkProcessor) encodeLoop(
 		if m, ok := metric.FromContext(ctx); ok {
 			m.RowEncodeSecondsHistogram.Observe(encodeDur.Seconds())
 			m.RowReadSecondsHistogram.Observe(readDur.Seconds())
-			if cr.chunk.FileMeta.Type == mydump.SourceTypeParquet {
+			if cr.chunk.FileMeta.Type.OffsetInRows() {
 				m.RowReadBytesHistogram.Observe(float64(newScannedOffset - scannedOffset))
 			} else {
 				m.RowReadBytesHistogram.Observe(float64(newOffset - offset))
@@ -711,7 +736,7 @@ func (cr *chunkProcessor) deliverLoop(
 			}
 			delta := highOffset - lowOffset
 			if delta >= 0 {
-				if cr.chunk.FileMeta.Type == mydump.SourceTypeParquet {
+				if cr.chunk.FileMeta.Type.OffsetInRows() {
 					if currRealOffset > startRealOffset {
 						m.BytesCounter.WithLabelValues(metric.StateRestored).Add(float64(currRealOffset - startRealOffset))
 					}
@@ -25,6 +25,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/br/pkg/lightning/backend"
@@ -46,6 +47,8 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/extsort"
 	tmock "github.com/pingcap/tidb/pkg/util/mock"
 	filter "github.com/pingcap/tidb/pkg/util/table-filter"
 	"github.com/stretchr/testify/require"
@@ -292,6 +295,58 @@ func (s *chunkRestoreSuite) TestEncodeLoop() {
 	require.Equal(s.T(), s.cr.chunk.Chunk.EndOffset, kvs[0].offset)
 }
 
+func (s *chunkRestoreSuite) TestEncodeLoopDupIgnoreRows() {
+	ctx := context.Background()
+	kvsCh := make(chan []deliveredKVs, 2)
+	deliverCompleteCh := make(chan deliverResult)
+	kvEncoder, err := kv.NewTableKVEncoder(&encode.EncodingConfig{
+		Table: s.tr.encTable,
+		SessionOptions: encode.SessionOptions{
+			SQLMode:   s.cfg.TiDB.SQLMode,
+			Timestamp: 1234567895,
+		},
+		Logger: log.L(),
+	}, nil)
+	require.NoError(s.T(), err)
+
+	// the losing rows found by the duplicate detection are skipped and
+	// recorded into the conflict error table.
+	dupIgnoreRows, err := extsort.OpenDiskSorter(s.T().TempDir(), nil)
+	require.NoError(s.T(), err)
+	defer dupIgnoreRows.Close()
+	w, err := dupIgnoreRows.NewWriter(ctx)
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), w.Put(common.EncodeIntRowID(19), codec.EncodeVarint(nil, conflictOnHandle)))
+	require.NoError(s.T(), w.Close())
+	require.NoError(s.T(), dupIgnoreRows.Sort(ctx))
+	s.tr.dupIgnoreRows = dupIgnoreRows
+	s.tr.tableInfo.Desired = s.tr.tableInfo.Core
+	defer func() {
+		s.tr.dupIgnoreRows = nil
+		s.tr.tableInfo.Desired = nil
+	}()
+
+	db, mockDB, err := sqlmock.New()
+	require.NoError(s.T(), err)
+	defer db.Close()
+	mockDB.ExpectExec("INSERT INTO `lightning_task_info`\\.conflict_records.*").
+		WithArgs(sqlmock.AnyArg(), s.tr.tableName, s.cr.chunk.Key.Path, int64(36), sqlmock.AnyArg(), int64(19), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	cfg := config.NewConfig()
+	cfg.Conflict.Strategy = config.ReplaceLatestOnDup
+	cfg.Conflict.Threshold = 10
+	cfg.Conflict.MaxRecordRows = 10
+	cfg.App.TaskInfoSchemaName = "lightning_task_info"
+	rc := &Controller{pauser: DeliverPauser, cfg: cfg, errorMgr: errormanager.New(db, cfg, log.L())}
+	_, _, err = s.cr.encodeLoop(ctx, kvsCh, s.tr, s.tr.logger, kvEncoder, deliverCompleteCh, rc)
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), mockDB.ExpectationsWereMet())
+	require.Len(s.T(), kvsCh, 1)
+	kvs := <-kvsCh
+	require.Len(s.T(), kvs, 1)
+	require.Nil(s.T(), kvs[0].kvs)
+}
+
 func (s *chunkRestoreSuite) TestEncodeLoopWithExtendData() {
 	ctx := context.Background()
 	kvsCh := make(chan []deliveredKVs, 2)
@@ -843,3 +898,56 @@ func TestGetColumnsNames(t *testing.T) {
 	require.Equal(t, []string{"c", "_tidb_rowid", "a"}, getColumnNames(tableInfo, []int{2, -1, 0, 1}))
 	require.Equal(t, []string{"_tidb_rowid", "b"}, getColumnNames(tableInfo, []int{-1, 1, -1, 0}))
 }
+
+func TestNewChunkParserJSONL(t *testing.T) {
+	p := parser.New()
+	p.SetSQLMode(mysql.ModeANSIQuotes)
+	node, err := p.ParseOneStmt(`
+	CREATE TABLE "table" (
+		a INT,
+		b INT,
+		c INT,
+		d INT AS (a + 1)
+	)
+`, "", "")
+	require.NoError(t, err)
+	core, err := ddl.BuildTableInfoFromAST(node.(*ast.CreateTableStmt))
+	require.NoError(t, err)
+	core.State = model.StatePublic
+
+	// the keys of the rows are different, the columns are decided by the table instead of the first row
+	fakeDataDir := t.TempDir()
+	store, err := storage.NewLocalStorage(fakeDataDir)
+	require.NoError(t, err)
+	content := []byte(`{"a": 1, "b": 2}` + "\n" + `{"c": 6, "a": 4, "e": 7}` + "\n")
+	require.NoError(t, os.WriteFile(filepath.Join(fakeDataDir, "db.table.1.jsonl"), content, 0o644))
+	chunk := checkpoints.ChunkCheckpoint{
+		Key: checkpoints.ChunkCheckpointKey{Path: "db.table.1.jsonl", Offset: 0},
+		FileMeta: mydump.SourceFileMeta{
+			Path:     "db.table.1.jsonl",
+			Type:     mydump.SourceTypeJSONL,
+			FileSize: int64(len(content)),
+		},
+		Chunk: mydump.Chunk{EndOffset: int64(len(content)), RowIDMax: 2},
+	}
+	ctx := context.Background()
+	cfg := config.NewConfig()
+	parser, err := openParser(ctx, cfg, &chunk, worker.NewPool(ctx, 1, "io"), store, core)
+	require.NoError(t, err)
+	defer parser.Close()
+
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []string{"a", "b", "c"}, parser.Columns())
+	require.Equal(t, []types.Datum{
+		types.NewCollationStringDatum("1", "utf8mb4_bin"),
+		types.NewCollationStringDatum("2", "utf8mb4_bin"),
+		{},
+	}, parser.LastRow().Row)
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{
+		types.NewCollationStringDatum("4", "utf8mb4_bin"),
+		{},
+		types.NewCollationStringDatum("6", "utf8mb4_bin"),
+	}, parser.LastRow().Row)
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+}
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"io"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/br/pkg/lightning/backend/encode"
@@ -103,6 +105,14 @@ func makeDupHandlerConstructor(
 			}
 			return &ignoreOnDup{w: w}, nil
 		}
+	case config.ReplaceLatestOnDup:
+		return func(ctx context.Context) (duplicate.Handler, error) {
+			w, err := sorter.NewWriter(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return &replaceLatestOnDup{replaceOnDup{w: w}}, nil
+		}
 	default:
 		panic(fmt.Sprintf("unexpected on-duplicate strategy: %s", onDup))
 	}
@@ -115,6 +125,7 @@ var (
 	_ duplicate.Handler = &errorOnDup{}
 	_ duplicate.Handler = &replaceOnDup{}
 	_ duplicate.Handler = &ignoreOnDup{}
+	_ duplicate.Handler = &replaceLatestOnDup{}
 )
 
 type errorOnDup struct {
@@ -180,6 +191,30 @@ func (h *replaceOnDup) Close() error {
 	return h.w.Close()
 }
 
+// replaceLatestOnDup keeps the row with the greatest version among the
+// duplicated rows. The keyID is the memcomparable encoded version followed by
+// the rowID, so the last keyID is the latest row, and the later row wins if
+// the versions are equal. Like replaceOnDup, the losing rows written to w are
+// skipped and recorded into the conflict error table when the chunks are
+// encoded.
+type replaceLatestOnDup struct {
+	replaceOnDup
+}
+
+func (h *replaceLatestOnDup) Append(keyID []byte) error {
+	rowID, _, err := codec.DecodeOne(keyID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(h.keyID) > 0 {
+		if err := h.w.Put(h.keyID, h.idxID); err != nil {
+			return err
+		}
+	}
+	h.keyID = append(h.keyID[:0], rowID...)
+	return nil
+}
+
 type ignoreOnDup struct {
 	// All keyIDs except the first one will be written to w.
 	// keyID written to w will be ignored during importing.
@@ -294,6 +329,16 @@ func (d *dupDetector) addKeysByChunk(
 			colPerm[i] = p
 		}
 	}
+	var (
+		versionCol *model.ColumnInfo
+		versionIdx int
+	)
+	if cfg.Conflict.Strategy == config.ReplaceLatestOnDup {
+		versionCol, versionIdx, err = findVersionColumn(tblInfo, colPerm, cfg.Conflict.VersionColumn)
+		if err != nil {
+			return errors.Annotatef(err, "file %s", chunk.Key.Path)
+		}
+	}
 
 	// 3. Simplify table structure and create kv encoder.
 	tblInfo, colPerm = simplifyTable(tblInfo, colPerm)
@@ -321,12 +366,28 @@ func (d *dupDetector) addKeysByChunk(
 		lastRow := parser.LastRow()
 		lastRow.Row = append(lastRow.Row, extendVals...)
 
+		var version []byte
+		if versionCol != nil {
+			datum, err := lastRow.Row[versionIdx].ConvertTo(types.DefaultStmtNoWarningContext, &versionCol.FieldType)
+			if err != nil {
+				return errors.Annotatef(err, "failed to convert version column %s at offset %d", versionCol.Name.O, offset)
+			}
+			version, err = codec.EncodeKey(time.UTC, nil, datum)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+
 		row, err := kvEncoder.Encode(lastRow.Row, lastRow.RowID, colPerm, offset)
 		if err != nil {
 			return errors.Trace(err)
 		}
 		for _, kvPair := range kv.Row2KvPairs(row) {
-			if err := adder.Add(kvPair.Key, kvPair.RowID); err != nil {
+			keyID := kvPair.RowID
+			if version != nil {
+				keyID = append(version[:len(version):len(version)], kvPair.RowID...)
+			}
+			if err := adder.Add(kvPair.Key, keyID); err != nil {
 				kv.ClearRow(row)
 				return err
 			}
@@ -343,6 +404,23 @@ func (d *dupDetector) addKeysByChunk(
 	}
 }
 
+// findVersionColumn finds the version column of the conflict.strategy
+// "replace-latest" and its offset in the parsed row.
+func findVersionColumn(
+	tblInfo *model.TableInfo, colPerm []int, name string,
+) (*model.ColumnInfo, int, error) {
+	col := model.FindColumnInfo(tblInfo.Columns, strings.ToLower(name))
+	if col == nil {
+		return nil, 0, common.ErrInvalidConfig.GenWithStack(
+			"conflict.version-column %s not found in table %s", name, tblInfo.Name.O)
+	}
+	if colPerm[col.Offset] < 0 {
+		return nil, 0, common.ErrInvalidConfig.GenWithStack(
+			"conflict.version-column %s of table %s must be present in the data file", name, tblInfo.Name.O)
+	}
+	return col, colPerm[col.Offset], nil
+}
+
 // simplifyTable simplifies the table structure for duplicate detection.
 // It tries to remove all unused indices and columns and make the table
 // structure as simple as possible.
@@ -18,12 +18,14 @@ import (
 	"context"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/br/pkg/lightning/duplicate"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/dbutil"
 	"github.com/pingcap/tidb/pkg/util/extsort"
@@ -86,6 +88,26 @@ func TestIgnoreOnDup(t *testing.T) {
 	)
 }
 
+func TestReplaceLatestOnDup(t *testing.T) {
+	keyID := func(version int64, rowID string) []byte {
+		b, err := codec.EncodeKey(time.UTC, nil, types.NewIntDatum(version))
+		require.NoError(t, err)
+		return append(b, rowID...)
+	}
+	null, err := codec.EncodeKey(time.UTC, nil, types.Datum{})
+	require.NoError(t, err)
+	runDupHandlerTest(t,
+		func(w extsort.Writer) duplicate.Handler { return &replaceLatestOnDup{replaceOnDup{w: w}} },
+		[]dupRecord{{
+			exampleHandleKey, [][]byte{append(null, "03"...), keyID(1, "02"), keyID(2, "01")}},
+			{exampleIndexKey, [][]byte{keyID(5, "12"), keyID(5, "13"), keyID(7, "11")}}},
+		map[int64][][]byte{
+			conflictOnHandle: {[]byte("02"), []byte("03")},
+			exampleIndexID:   {[]byte("12"), []byte("13")},
+		},
+	)
+}
+
 type dupRecord struct {
 	key    []byte
 	rowIDs [][]byte
@@ -194,3 +216,18 @@ func TestSimplifyTable(t *testing.T) {
 		}
 	}
 }
+
+func TestFindVersionColumn(t *testing.T) {
+	tblInfo, err := dbutil.GetTableInfoBySQL("CREATE TABLE t(id int PRIMARY KEY, v int, updated_at timestamp)", parser.New())
+	require.NoError(t, err)
+
+	col, idx, err := findVersionColumn(tblInfo, []int{2, -1, 0, -1}, "Updated_At")
+	require.NoError(t, err)
+	require.Equal(t, "updated_at", col.Name.O)
+	require.Equal(t, 0, idx)
+
+	_, _, err = findVersionColumn(tblInfo, []int{2, -1, 0, -1}, "v")
+	require.ErrorContains(t, err, "conflict.version-column v of table t must be present in the data file")
+	_, _, err = findVersionColumn(tblInfo, []int{2, -1, 0, -1}, "ts")
+	require.ErrorContains(t, err, "conflict.version-column ts not found in table t")
+}
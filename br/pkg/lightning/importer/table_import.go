@@ -770,11 +770,16 @@ ChunkLoop:
 			break
 		}
 
-		if chunk.FileMeta.Type == mydump.SourceTypeParquet {
-			// TODO: use the compressed size of the chunk to conduct memory control
+		// TODO: use the compressed size of the chunk to conduct memory control
+		switch chunk.FileMeta.Type {
+		case mydump.SourceTypeParquet:
 			if _, err = getChunkCompressedSizeForParquet(ctx, chunk, rc.store); err != nil {
 				return nil, errors.Trace(err)
 			}
+		case mydump.SourceTypeAvro:
+			if _, err = mydump.ReadAvroFileMaxBlockSizeByFile(ctx, rc.store, chunk.FileMeta); err != nil {
+				return nil, errors.Trace(err)
+			}
 		}
 
 		restoreWorker := rc.regionWorkers.Apply()
@@ -838,7 +843,7 @@ ChunkLoop:
 	for _, chunk := range cp.Chunks {
 		totalKVSize += chunk.Checksum.SumSize()
 		totalSQLSize += chunk.UnfinishedSize()
-		if chunk.FileMeta.Type == mydump.SourceTypeParquet {
+		if chunk.FileMeta.Type.OffsetInRows() {
 			logKeyName = "read(rows)"
 		}
 	}
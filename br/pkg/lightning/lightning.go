@@ -512,6 +512,10 @@ func (l *Lightning) run(taskCtx context.Context, taskCfg *config.Config, o *opti
 			return common.NormalizeError(err)
 		}
 	}
+	s, err = mydump.WithSourceEncryption(taskCfg, s)
+	if err != nil {
+		return err
+	}
 
 	// return expectedErr means at least meet one file
 	expectedErr := errors.New("Stop Iter")
@@ -0,0 +1,878 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// Avro object container file, see https://avro.apache.org/docs/1.11.1/specification/#object-container-files
+const (
+	avroMagic          = "Obj\x01"
+	avroSyncSize       = 16
+	avroSchemaKey      = "avro.schema"
+	avroCodecKey       = "avro.codec"
+	avroReadBufferSize = 64 * 1024
+
+	avroCodecNull      = "null"
+	avroCodecDeflate   = "deflate"
+	avroCodecSnappy    = "snappy"
+	avroCodecZstandard = "zstandard"
+)
+
+var errAvroShortBuffer = errors.New("avro data is truncated")
+
+// avroSchema is the parsed schema of avro data, only the attributes used for
+// decoding are kept.
+type avroSchema struct {
+	// Type is one of the primitive or complex type names, or "union".
+	Type        string
+	LogicalType string
+	Fields      []avroField
+	Items       *avroSchema
+	Values      *avroSchema
+	Symbols     []string
+	Size        int
+	Branches    []*avroSchema
+	Precision   int
+	Scale       int
+}
+
+type avroField struct {
+	Name   string
+	Schema *avroSchema
+}
+
+var avroPrimitiveTypes = map[string]struct{}{
+	"null": {}, "boolean": {}, "int": {}, "long": {}, "float": {}, "double": {}, "bytes": {}, "string": {},
+}
+
+// parseAvroSchema parses a JSON encoded avro schema, names contains the named
+// types (record, enum and fixed) defined so far.
+func parseAvroSchema(data json.RawMessage, namespace string, names map[string]*avroSchema) (*avroSchema, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.New("empty avro schema")
+	}
+	switch data[0] {
+	case '"':
+		var name string
+		if err := json.Unmarshal(data, &name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, ok := avroPrimitiveTypes[name]; ok {
+			return &avroSchema{Type: name}, nil
+		}
+		if s, ok := names[name]; ok {
+			return s, nil
+		}
+		if s, ok := names[namespace+"."+name]; ok {
+			return s, nil
+		}
+		return nil, errors.Errorf("unknown avro type '%s'", name)
+	case '[':
+		var branches []json.RawMessage
+		if err := json.Unmarshal(data, &branches); err != nil {
+			return nil, errors.Trace(err)
+		}
+		s := &avroSchema{Type: "union", Branches: make([]*avroSchema, 0, len(branches))}
+		for _, b := range branches {
+			branch, err := parseAvroSchema(b, namespace, names)
+			if err != nil {
+				return nil, err
+			}
+			s.Branches = append(s.Branches, branch)
+		}
+		return s, nil
+	case '{':
+	default:
+		return nil, errors.Errorf("invalid avro schema '%s'", data)
+	}
+
+	var obj struct {
+		Type        json.RawMessage `json:"type"`
+		Name        string          `json:"name"`
+		Namespace   string          `json:"namespace"`
+		LogicalType string          `json:"logicalType"`
+		Fields      []struct {
+			Name string          `json:"name"`
+			Type json.RawMessage `json:"type"`
+		} `json:"fields"`
+		Items     json.RawMessage `json:"items"`
+		Values    json.RawMessage `json:"values"`
+		Symbols   []string        `json:"symbols"`
+		Size      int             `json:"size"`
+		Precision int             `json:"precision"`
+		Scale     int             `json:"scale"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var tp string
+	if err := json.Unmarshal(obj.Type, &tp); err != nil {
+		// the type is a nested schema, such as {"type": {"type": "string"}}
+		return parseAvroSchema(obj.Type, namespace, names)
+	}
+	s := &avroSchema{Type: tp, LogicalType: obj.LogicalType, Precision: obj.Precision, Scale: obj.Scale}
+	registerName := func() {
+		if obj.Namespace != "" {
+			namespace = obj.Namespace
+		}
+		if obj.Name != "" {
+			names[obj.Name] = s
+			if namespace != "" && !strings.Contains(obj.Name, ".") {
+				names[namespace+"."+obj.Name] = s
+			}
+		}
+	}
+	var err error
+	switch tp {
+	case "record", "error":
+		// register the name before parsing the fields to support recursive types
+		registerName()
+		s.Fields = make([]avroField, 0, len(obj.Fields))
+		for _, f := range obj.Fields {
+			field := avroField{Name: f.Name}
+			if field.Schema, err = parseAvroSchema(f.Type, namespace, names); err != nil {
+				return nil, err
+			}
+			s.Fields = append(s.Fields, field)
+		}
+	case "enum":
+		registerName()
+		s.Symbols = obj.Symbols
+	case "fixed":
+		registerName()
+		s.Size = obj.Size
+	case "array":
+		if s.Items, err = parseAvroSchema(obj.Items, namespace, names); err != nil {
+			return nil, err
+		}
+	case "map":
+		if s.Values, err = parseAvroSchema(obj.Values, namespace, names); err != nil {
+			return nil, err
+		}
+	default:
+		if _, ok := avroPrimitiveTypes[tp]; !ok {
+			// a reference to a named type
+			return parseAvroSchema(obj.Type, namespace, names)
+		}
+	}
+	return s, nil
+}
+
+// avroDecoder decodes the binary encoded avro data.
+type avroDecoder struct {
+	buf []byte
+	pos int
+}
+
+func (d *avroDecoder) readLong() (int64, error) {
+	v, n := binary.Varint(d.buf[d.pos:])
+	if n <= 0 {
+		return 0, errAvroShortBuffer
+	}
+	d.pos += n
+	return v, nil
+}
+
+func (d *avroDecoder) readFixed(n int64) ([]byte, error) {
+	if n < 0 || int64(len(d.buf)-d.pos) < n {
+		return nil, errAvroShortBuffer
+	}
+	ret := d.buf[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return ret, nil
+}
+
+func (d *avroDecoder) readBytes() ([]byte, error) {
+	n, err := d.readLong()
+	if err != nil {
+		return nil, err
+	}
+	return d.readFixed(n)
+}
+
+// readBlockCount reads the item count of a block of array or map values.
+func (d *avroDecoder) readBlockCount() (int64, error) {
+	count, err := d.readLong()
+	if err != nil {
+		return 0, err
+	}
+	if count < 0 {
+		// a negative count is followed by the byte size of the block
+		count = -count
+		if _, err = d.readLong(); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// decode decodes a value of the schema. The values of logical types are
+// converted to strings, and the nested values are converted to the types which
+// can be encoded to JSON.
+func (d *avroDecoder) decode(s *avroSchema) (interface{}, error) {
+	switch s.Type {
+	case "null":
+		return nil, nil
+	case "boolean":
+		b, err := d.readFixed(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] != 0, nil
+	case "int", "long":
+		v, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		return avroIntValue(v, s), nil
+	case "float":
+		b, err := d.readFixed(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b))), nil
+	case "double":
+		b, err := d.readFixed(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case "string":
+		b, err := d.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case "bytes", "fixed":
+		var (
+			b   []byte
+			err error
+		)
+		if s.Type == "bytes" {
+			b, err = d.readBytes()
+		} else {
+			b, err = d.readFixed(int64(s.Size))
+		}
+		if err != nil {
+			return nil, err
+		}
+		if s.LogicalType == "decimal" {
+			if len(b) == 0 {
+				return "0", nil
+			}
+			// binaryToDecimalStr modifies the input
+			return binaryToDecimalStr(append([]byte(nil), b...), s.Scale), nil
+		}
+		return append([]byte(nil), b...), nil
+	case "enum":
+		idx, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		if idx < 0 || idx >= int64(len(s.Symbols)) {
+			return nil, errors.Errorf("invalid avro enum index %d", idx)
+		}
+		return s.Symbols[idx], nil
+	case "union":
+		idx, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		if idx < 0 || idx >= int64(len(s.Branches)) {
+			return nil, errors.Errorf("invalid avro union index %d", idx)
+		}
+		return d.decode(s.Branches[idx])
+	case "record", "error":
+		m := make(map[string]interface{}, len(s.Fields))
+		for _, f := range s.Fields {
+			v, err := d.decodeNested(f.Schema)
+			if err != nil {
+				return nil, err
+			}
+			m[f.Name] = v
+		}
+		return m, nil
+	case "array":
+		arr := make([]interface{}, 0)
+		for {
+			count, err := d.readBlockCount()
+			if err != nil {
+				return nil, err
+			}
+			if count == 0 {
+				return arr, nil
+			}
+			for i := int64(0); i < count; i++ {
+				v, err := d.decodeNested(s.Items)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, v)
+			}
+		}
+	case "map":
+		m := make(map[string]interface{})
+		for {
+			count, err := d.readBlockCount()
+			if err != nil {
+				return nil, err
+			}
+			if count == 0 {
+				return m, nil
+			}
+			for i := int64(0); i < count; i++ {
+				k, err := d.readBytes()
+				if err != nil {
+					return nil, err
+				}
+				v, err := d.decodeNested(s.Values)
+				if err != nil {
+					return nil, err
+				}
+				m[string(k)] = v
+			}
+		}
+	default:
+		return nil, errors.Errorf("unsupported avro type '%s'", s.Type)
+	}
+}
+
+// decodeNested decodes a value nested in a record, array or map. Binary values
+// are converted to strings to be encoded as JSON strings.
+func (d *avroDecoder) decodeNested(s *avroSchema) (interface{}, error) {
+	v, err := d.decode(s)
+	if b, ok := v.([]byte); ok {
+		return string(b), err
+	}
+	return v, err
+}
+
+// avroIntValue converts the int and long values of logical types to strings.
+func avroIntValue(v int64, s *avroSchema) interface{} {
+	switch s.LogicalType {
+	case "date":
+		return time.Unix(v*secPerDay, 0).UTC().Format(time.DateOnly)
+	case "time-millis":
+		return time.UnixMilli(v).UTC().Format("15:04:05.999999")
+	case "time-micros":
+		return time.UnixMicro(v).UTC().Format("15:04:05.999999")
+	case "timestamp-millis":
+		return time.UnixMilli(v).UTC().Format(utcTimeLayout)
+	case "timestamp-micros":
+		return time.UnixMicro(v).UTC().Format(utcTimeLayout)
+	case "local-timestamp-millis":
+		return time.UnixMilli(v).UTC().Format(timeLayout)
+	case "local-timestamp-micros":
+		return time.UnixMicro(v).UTC().Format(timeLayout)
+	default:
+		return v
+	}
+}
+
+// setDatumByAvro converts a decoded avro value to Datum.
+func setDatumByAvro(d *types.Datum, v interface{}) error {
+	switch x := v.(type) {
+	case nil:
+		*d = types.Datum{}
+	case bool:
+		if x {
+			d.SetUint64(1)
+		} else {
+			d.SetUint64(0)
+		}
+	case int64:
+		d.SetInt64(x)
+	case float64:
+		d.SetFloat64(x)
+	case string:
+		d.SetString(x, "utf8mb4_bin")
+	case []byte:
+		d.SetBytes(x)
+	default:
+		// records, arrays and maps are imported as JSON text
+		content, err := json.Marshal(x)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		d.SetString(string(content), "utf8mb4_bin")
+	}
+	return nil
+}
+
+// AvroParser parses an avro object container file for import.
+// The top level schema of the file must be a record, and each field of the
+// record is mapped to a column. When the column list is set by SetColumns, the
+// fields are mapped to the columns by name, the fields not in the list are
+// ignored and the columns without fields are NULL. Like parquet, the position
+// of the parser is the row count it has handled.
+// It implements the Parser interface.
+type AvroParser struct {
+	reader  ReadSeekCloser
+	br      *bufio.Reader
+	schema  *avroSchema
+	columns []string
+	// columnIndexes[i] is the index in the row of the i-th field, -1 means the
+	// field is ignored. nil means the fields are in the same order as the row.
+	columnIndexes []int
+	codec         string
+	sync          []byte
+	zstdDec       *zstd.Decoder
+	blockBuf      []byte
+
+	block       avroDecoder
+	blockRemain int64
+	readRows    int64
+	lastRow     Row
+	logger      log.Logger
+}
+
+// NewAvroParser creates an avro parser, the header of the file is read.
+func NewAvroParser(
+	ctx context.Context,
+	r storage.ReadSeekCloser,
+) (*AvroParser, error) {
+	pp := &AvroParser{
+		reader: r,
+		br:     bufio.NewReaderSize(r, avroReadBufferSize),
+		logger: log.FromContext(ctx),
+	}
+	if err := pp.readHeader(); err != nil {
+		return nil, err
+	}
+	return pp, nil
+}
+
+func (pp *AvroParser) readHeader() error {
+	magic := make([]byte, len(avroMagic))
+	if _, err := io.ReadFull(pp.br, magic); err != nil {
+		return errors.Annotate(err, "read avro magic")
+	}
+	if string(magic) != avroMagic {
+		return errors.New("not an avro object container file")
+	}
+
+	meta := make(map[string][]byte)
+	for {
+		count, err := binary.ReadVarint(pp.br)
+		if err != nil {
+			return errors.Annotate(err, "read avro file metadata")
+		}
+		if count == 0 {
+			break
+		}
+		if count < 0 {
+			count = -count
+			if _, err = binary.ReadVarint(pp.br); err != nil {
+				return errors.Annotate(err, "read avro file metadata")
+			}
+		}
+		for i := int64(0); i < count; i++ {
+			key, err := pp.readHeaderBytes()
+			if err != nil {
+				return err
+			}
+			value, err := pp.readHeaderBytes()
+			if err != nil {
+				return err
+			}
+			meta[string(key)] = value
+		}
+	}
+	pp.sync = make([]byte, avroSyncSize)
+	if _, err := io.ReadFull(pp.br, pp.sync); err != nil {
+		return errors.Annotate(err, "read avro sync marker")
+	}
+
+	schema, err := parseAvroSchema(meta[avroSchemaKey], "", make(map[string]*avroSchema))
+	if err != nil {
+		return errors.Annotate(err, "parse avro schema")
+	}
+	if schema.Type != "record" {
+		return errors.Errorf("the schema of avro file should be a record, got '%s'", schema.Type)
+	}
+	pp.schema = schema
+	pp.columns = make([]string, 0, len(schema.Fields))
+	for _, f := range schema.Fields {
+		pp.columns = append(pp.columns, strings.ToLower(f.Name))
+	}
+
+	pp.codec = string(meta[avroCodecKey])
+	switch pp.codec {
+	case "", avroCodecNull, avroCodecDeflate, avroCodecSnappy:
+	case avroCodecZstandard:
+		if pp.zstdDec, err = zstd.NewReader(nil); err != nil {
+			return errors.Trace(err)
+		}
+	default:
+		return errors.Errorf("unsupported avro codec '%s'", pp.codec)
+	}
+	return nil
+}
+
+func (pp *AvroParser) readHeaderBytes() ([]byte, error) {
+	n, err := binary.ReadVarint(pp.br)
+	if err != nil {
+		return nil, errors.Annotate(err, "read avro file metadata")
+	}
+	if n < 0 {
+		return nil, errors.Errorf("invalid avro bytes length %d", n)
+	}
+	b := make([]byte, n)
+	if _, err = io.ReadFull(pp.br, b); err != nil {
+		return nil, errors.Annotate(err, "read avro file metadata")
+	}
+	return b, nil
+}
+
+// readBlockHeader reads the row count and the byte size of the next block,
+// it returns io.EOF if there are no more blocks.
+func (pp *AvroParser) readBlockHeader() (count int64, size int64, err error) {
+	count, err = binary.ReadVarint(pp.br)
+	if err != nil {
+		if err == io.EOF {
+			return 0, 0, io.EOF
+		}
+		return 0, 0, errors.Trace(err)
+	}
+	if size, err = binary.ReadVarint(pp.br); err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	if count < 0 || size < 0 {
+		return 0, 0, errors.Errorf("invalid avro block, count: %d, size: %d", count, size)
+	}
+	return count, size, nil
+}
+
+// readBlockBody reads and decompresses the data of a block.
+func (pp *AvroParser) readBlockBody(count, size int64) error {
+	if int64(cap(pp.blockBuf)) < size+avroSyncSize {
+		pp.blockBuf = make([]byte, size+avroSyncSize)
+	}
+	buf := pp.blockBuf[:size+avroSyncSize]
+	if _, err := io.ReadFull(pp.br, buf); err != nil {
+		return errors.Annotate(err, "read avro block")
+	}
+	if !bytes.Equal(buf[size:], pp.sync) {
+		return errors.New("invalid avro sync marker")
+	}
+	data := buf[:size]
+	switch pp.codec {
+	case avroCodecDeflate:
+		content, err := io.ReadAll(flate.NewReader(bytes.NewReader(data)))
+		if err != nil {
+			return errors.Annotate(err, "decompress avro block")
+		}
+		data = content
+	case avroCodecSnappy:
+		// the compressed data is followed by the 4-byte CRC32 checksum of the uncompressed data
+		if len(data) < 4 {
+			return errAvroShortBuffer
+		}
+		content, err := snappy.Decode(nil, data[:len(data)-4])
+		if err != nil {
+			return errors.Annotate(err, "decompress avro block")
+		}
+		if crc32.ChecksumIEEE(content) != binary.BigEndian.Uint32(data[len(data)-4:]) {
+			return errors.New("avro block checksum mismatch")
+		}
+		data = content
+	case avroCodecZstandard:
+		content, err := pp.zstdDec.DecodeAll(data, nil)
+		if err != nil {
+			return errors.Annotate(err, "decompress avro block")
+		}
+		data = content
+	}
+	pp.block = avroDecoder{buf: data}
+	pp.blockRemain = count
+	return nil
+}
+
+// skipBlockBody skips the data of a block without decoding it.
+func (pp *AvroParser) skipBlockBody(size int64) error {
+	skip := size + avroSyncSize
+	if skip <= int64(pp.br.Buffered()) {
+		_, err := pp.br.Discard(int(skip))
+		return errors.Trace(err)
+	}
+	cur, err := pp.reader.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err = pp.reader.Seek(cur-int64(pp.br.Buffered())+skip, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	pp.br.Reset(pp.reader)
+	return nil
+}
+
+// readRowValues decodes the next row in the current block. If row is nil, the
+// values are discarded.
+func (pp *AvroParser) readRowValues(row []types.Datum) error {
+	for i, f := range pp.schema.Fields {
+		v, err := pp.block.decode(f.Schema)
+		if err != nil {
+			return errors.Annotatef(err, "decode avro field '%s'", f.Name)
+		}
+		if row == nil {
+			continue
+		}
+		idx := i
+		if pp.columnIndexes != nil {
+			if idx = pp.columnIndexes[i]; idx < 0 {
+				continue
+			}
+		}
+		if err = setDatumByAvro(&row[idx], v); err != nil {
+			return err
+		}
+	}
+	pp.blockRemain--
+	pp.readRows++
+	return nil
+}
+
+// Pos returns the currently row number of the avro file.
+func (pp *AvroParser) Pos() (pos int64, rowID int64) {
+	return pp.readRows, pp.lastRow.RowID
+}
+
+// SetPos sets the position in an avro file, the blocks before the position
+// are skipped without decoding.
+// It implements the Parser interface.
+func (pp *AvroParser) SetPos(pos int64, rowID int64) error {
+	if pos < pp.readRows {
+		return errors.Errorf("avro parser can't seek back, current pos: %d, required pos: %d", pp.readRows, pos)
+	}
+	for pp.readRows < pos {
+		if pp.blockRemain == 0 {
+			count, size, err := pp.readBlockHeader()
+			if err != nil {
+				return err
+			}
+			if pp.readRows+count <= pos {
+				if err = pp.skipBlockBody(size); err != nil {
+					return err
+				}
+				pp.readRows += count
+				continue
+			}
+			if err = pp.readBlockBody(count, size); err != nil {
+				return err
+			}
+		}
+		if err := pp.readRowValues(nil); err != nil {
+			return err
+		}
+	}
+	pp.lastRow.RowID = rowID
+	return nil
+}
+
+// ScannedPos implements the Parser interface.
+// For avro it's the avro file's reader current position.
+func (pp *AvroParser) ScannedPos() (int64, error) {
+	return pp.reader.Seek(0, io.SeekCurrent)
+}
+
+// Close closes the avro file of the parser.
+// It implements the Parser interface.
+func (pp *AvroParser) Close() error {
+	if pp.zstdDec != nil {
+		pp.zstdDec.Close()
+	}
+	return pp.reader.Close()
+}
+
+// ReadRow reads a row in the avro file by the parser.
+// It implements the Parser interface.
+func (pp *AvroParser) ReadRow() error {
+	pp.lastRow.RowID++
+	pp.lastRow.Length = 0
+	for pp.blockRemain == 0 {
+		count, size, err := pp.readBlockHeader()
+		if err != nil {
+			return err
+		}
+		if err = pp.readBlockBody(count, size); err != nil {
+			return err
+		}
+	}
+
+	length := len(pp.columns)
+	if cap(pp.lastRow.Row) < length {
+		pp.lastRow.Row = make([]types.Datum, length)
+	} else {
+		pp.lastRow.Row = pp.lastRow.Row[:length]
+	}
+	if pp.columnIndexes != nil {
+		for i := range pp.lastRow.Row {
+			pp.lastRow.Row[i] = types.Datum{}
+		}
+	}
+	start := pp.block.pos
+	if err := pp.readRowValues(pp.lastRow.Row); err != nil {
+		return err
+	}
+	pp.lastRow.Length = pp.block.pos - start
+	return nil
+}
+
+// LastRow gets the last row parsed by the parser.
+// It implements the Parser interface.
+func (pp *AvroParser) LastRow() Row {
+	return pp.lastRow
+}
+
+// RecycleRow implements the Parser interface.
+func (*AvroParser) RecycleRow(_ Row) {
+}
+
+// Columns returns the _lower-case_ column names corresponding to values in
+// the LastRow.
+func (pp *AvroParser) Columns() []string {
+	return pp.columns
+}
+
+// SetColumns set restored column names to parser
+func (pp *AvroParser) SetColumns(columns []string) {
+	indexes := make(map[string]int, len(columns))
+	for i, col := range columns {
+		indexes[strings.ToLower(col)] = i
+	}
+	pp.columns = columns
+	pp.columnIndexes = make([]int, len(pp.schema.Fields))
+	for i, f := range pp.schema.Fields {
+		idx, ok := indexes[strings.ToLower(f.Name)]
+		if !ok {
+			idx = -1
+		}
+		pp.columnIndexes[i] = idx
+	}
+}
+
+// SetLogger sets the logger used in the parser.
+// It implements the Parser interface.
+func (pp *AvroParser) SetLogger(l log.Logger) {
+	pp.logger = l
+}
+
+// SetRowID sets the rowID in an avro file.
+// It implements the Parser interface.
+func (pp *AvroParser) SetRowID(rowID int64) {
+	pp.lastRow.RowID = rowID
+}
+
+// countRows counts the rows of the remaining blocks without decoding them.
+func (pp *AvroParser) countRows() (int64, error) {
+	rows := pp.readRows + pp.blockRemain
+	err := pp.scanBlocks(func(count, _ int64) {
+		rows += count
+	})
+	return rows, err
+}
+
+// maxBlockSize returns the largest compressed size of the remaining blocks
+// without decoding them.
+func (pp *AvroParser) maxBlockSize() (int64, error) {
+	var maxSize int64
+	err := pp.scanBlocks(func(_, size int64) {
+		maxSize = max(maxSize, size)
+	})
+	return maxSize, err
+}
+
+// scanBlocks skips the remaining blocks and calls fn with the row count and the
+// byte size of each block.
+func (pp *AvroParser) scanBlocks(fn func(count, size int64)) error {
+	for {
+		count, size, err := pp.readBlockHeader()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if err = pp.skipBlockBody(size); err != nil {
+			return err
+		}
+		fn(count, size)
+	}
+}
+
+// ReadAvroFileRowCountByFile reads the avro file row count through fileMeta.
+// Only the headers of the blocks are read.
+func ReadAvroFileRowCountByFile(
+	ctx context.Context,
+	store storage.ExternalStorage,
+	fileMeta SourceFileMeta,
+) (int64, error) {
+	parser, err := openAvroParser(ctx, store, fileMeta)
+	if err != nil {
+		return 0, err
+	}
+	//nolint: errcheck
+	defer parser.Close()
+	return parser.countRows()
+}
+
+// ReadAvroFileMaxBlockSizeByFile reads the largest compressed block size of the
+// avro file through fileMeta. Only the headers of the blocks are read.
+func ReadAvroFileMaxBlockSizeByFile(
+	ctx context.Context,
+	store storage.ExternalStorage,
+	fileMeta SourceFileMeta,
+) (int64, error) {
+	parser, err := openAvroParser(ctx, store, fileMeta)
+	if err != nil {
+		return 0, err
+	}
+	//nolint: errcheck
+	defer parser.Close()
+	return parser.maxBlockSize()
+}
+
+func openAvroParser(ctx context.Context, store storage.ExternalStorage, fileMeta SourceFileMeta) (*AvroParser, error) {
+	r, err := store.Open(ctx, fileMeta.Path, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	parser, err := NewAvroParser(ctx, r)
+	if err != nil {
+		_ = r.Close()
+		return nil, errors.Trace(err)
+	}
+	return parser, nil
+}
@@ -0,0 +1,287 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testAvroSchema = `{
+  "type": "record", "name": "user", "namespace": "test",
+  "fields": [
+    {"name": "ID", "type": "long"},
+    {"name": "name", "type": ["null", "string"]},
+    {"name": "score", "type": "double"},
+    {"name": "birthday", "type": {"type": "int", "logicalType": "date"}},
+    {"name": "created", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+    {"name": "amount", "type": {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}},
+    {"name": "active", "type": "boolean"},
+    {"name": "color", "type": {"type": "enum", "name": "color", "symbols": ["RED", "GREEN"]}},
+    {"name": "attrs", "type": {"type": "record", "name": "attr", "fields": [
+      {"name": "tags", "type": {"type": "array", "items": "string"}},
+      {"name": "props", "type": {"type": "map", "values": "int"}}
+    ]}},
+    {"name": "raw", "type": {"type": "fixed", "name": "md5", "size": 2}}
+  ]
+}`
+
+type avroTestEncoder struct {
+	bytes.Buffer
+}
+
+func (e *avroTestEncoder) long(v int64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], v)
+	e.Write(buf[:n])
+}
+
+func (e *avroTestEncoder) bytes(b []byte) {
+	e.long(int64(len(b)))
+	e.Write(b)
+}
+
+// encodeTestAvroRow encodes a row of testAvroSchema, the row i has the values
+// derived from i.
+func encodeTestAvroRow(e *avroTestEncoder, i int64) {
+	e.long(i)
+	if i%2 == 0 {
+		e.long(1)
+		e.bytes([]byte("name"))
+	} else {
+		e.long(0)
+	}
+	var f [8]byte
+	binary.LittleEndian.PutUint64(f[:], math.Float64bits(float64(i)+0.5))
+	e.Write(f[:])
+	// 2000-01-01
+	e.long(10957)
+	// 2000-01-01 00:00:01.5
+	e.long(946684801500)
+	// -1.28
+	e.bytes([]byte{0xff, 0x80})
+	e.WriteByte(byte(i % 2))
+	e.long(i % 2)
+	e.long(2)
+	e.bytes([]byte("a"))
+	e.bytes([]byte("b"))
+	e.long(0)
+	e.long(1)
+	e.bytes([]byte("k"))
+	e.long(i)
+	e.long(0)
+	e.Write([]byte{0x01, 0x02})
+}
+
+// writeTestAvroFile writes an avro object container file with the blocks,
+// each element of blocks is the row count of the block.
+func writeTestAvroFile(t *testing.T, path string, codec string, blocks []int) {
+	sync := []byte("0123456789abcdef")
+	var file avroTestEncoder
+	file.WriteString(avroMagic)
+	file.long(2)
+	file.bytes([]byte(avroSchemaKey))
+	file.bytes([]byte(testAvroSchema))
+	file.bytes([]byte(avroCodecKey))
+	file.bytes([]byte(codec))
+	file.long(0)
+	file.Write(sync)
+
+	var rowID int64
+	for _, count := range blocks {
+		var block avroTestEncoder
+		for i := 0; i < count; i++ {
+			encodeTestAvroRow(&block, rowID)
+			rowID++
+		}
+		data := block.Bytes()
+		switch codec {
+		case avroCodecDeflate:
+			var buf bytes.Buffer
+			w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+			require.NoError(t, err)
+			_, err = w.Write(data)
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+			data = buf.Bytes()
+		case avroCodecSnappy:
+			checksum := crc32.ChecksumIEEE(data)
+			data = snappy.Encode(nil, data)
+			data = binary.BigEndian.AppendUint32(data, checksum)
+		case avroCodecZstandard:
+			enc, err := zstd.NewWriter(nil)
+			require.NoError(t, err)
+			data = enc.EncodeAll(data, nil)
+			require.NoError(t, enc.Close())
+		}
+		file.long(int64(count))
+		file.bytes(data)
+		file.Write(sync)
+	}
+	require.NoError(t, os.WriteFile(path, file.Bytes(), 0o644))
+}
+
+func verifyTestAvroRow(t *testing.T, row Row, i int64) {
+	require.Equal(t, i+1, row.RowID)
+	name := types.NewDatum(nil)
+	if i%2 == 0 {
+		name = types.NewCollationStringDatum("name", "utf8mb4_bin")
+	}
+	color := "RED"
+	if i%2 == 1 {
+		color = "GREEN"
+	}
+	require.Equal(t, []types.Datum{
+		types.NewIntDatum(i),
+		name,
+		types.NewFloat64Datum(float64(i) + 0.5),
+		types.NewCollationStringDatum("2000-01-01", "utf8mb4_bin"),
+		types.NewCollationStringDatum("2000-01-01 00:00:01.5Z", "utf8mb4_bin"),
+		types.NewCollationStringDatum("-1.28", "utf8mb4_bin"),
+		types.NewUintDatum(uint64(i % 2)),
+		types.NewCollationStringDatum(color, "utf8mb4_bin"),
+		types.NewCollationStringDatum(`{"props":{"k":`+strconv.FormatInt(i, 10)+`},"tags":["a","b"]}`, "utf8mb4_bin"),
+		types.NewBytesDatum([]byte{0x01, 0x02}),
+	}, row.Row)
+}
+
+func TestAvroParser(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for _, codec := range []string{"", avroCodecNull, avroCodecDeflate, avroCodecSnappy, avroCodecZstandard} {
+		name := "test." + codec + ".avro"
+		writeTestAvroFile(t, filepath.Join(dir, name), codec, []int{3, 0, 5, 2})
+
+		r, err := store.Open(ctx, name, nil)
+		require.NoError(t, err)
+		parser, err := NewAvroParser(ctx, r)
+		require.NoError(t, err)
+		require.Equal(t, []string{"id", "name", "score", "birthday", "created", "amount", "active", "color", "attrs", "raw"},
+			parser.Columns())
+
+		for i := int64(0); i < 10; i++ {
+			require.NoError(t, parser.ReadRow())
+			verifyTestAvroRow(t, parser.LastRow(), i)
+			pos, rowID := parser.Pos()
+			require.Equal(t, i+1, pos)
+			require.Equal(t, i+1, rowID)
+		}
+		require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+		require.NoError(t, parser.Close())
+
+		// skip the rows in the first blocks
+		r, err = store.Open(ctx, name, nil)
+		require.NoError(t, err)
+		parser, err = NewAvroParser(ctx, r)
+		require.NoError(t, err)
+		require.NoError(t, parser.SetPos(4, 4))
+		require.NoError(t, parser.ReadRow())
+		verifyTestAvroRow(t, parser.LastRow(), 4)
+		require.NoError(t, parser.SetPos(8, 8))
+		require.NoError(t, parser.ReadRow())
+		verifyTestAvroRow(t, parser.LastRow(), 8)
+		require.Error(t, parser.SetPos(2, 2))
+		require.NoError(t, parser.Close())
+
+		rows, err := ReadAvroFileRowCountByFile(ctx, store, SourceFileMeta{Path: name})
+		require.NoError(t, err)
+		require.Equal(t, int64(10), rows)
+
+		maxSize, err := ReadAvroFileMaxBlockSizeByFile(ctx, store, SourceFileMeta{Path: name})
+		require.NoError(t, err)
+		r, err = store.Open(ctx, name, nil)
+		require.NoError(t, err)
+		parser, err = NewAvroParser(ctx, r)
+		require.NoError(t, err)
+		var sizes []int64
+		require.NoError(t, parser.scanBlocks(func(_, size int64) {
+			sizes = append(sizes, size)
+		}))
+		require.NoError(t, parser.Close())
+		require.Len(t, sizes, 4)
+		require.Equal(t, slices.Max(sizes), maxSize)
+	}
+}
+
+func TestAvroParserSetColumns(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+	writeTestAvroFile(t, filepath.Join(dir, "test.avro"), avroCodecNull, []int{2})
+
+	r, err := store.Open(ctx, "test.avro", nil)
+	require.NoError(t, err)
+	parser, err := NewAvroParser(ctx, r)
+	require.NoError(t, err)
+	defer parser.Close()
+
+	parser.SetColumns([]string{"score", "not_exist", "id"})
+	require.Equal(t, []string{"score", "not_exist", "id"}, parser.Columns())
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{types.NewFloat64Datum(0.5), {}, types.NewIntDatum(0)}, parser.LastRow().Row)
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{types.NewFloat64Datum(1.5), {}, types.NewIntDatum(1)}, parser.LastRow().Row)
+}
+
+func TestAvroParserError(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.avro"), []byte("PAR1"), 0o644))
+	r, err := store.Open(ctx, "invalid.avro", nil)
+	require.NoError(t, err)
+	_, err = NewAvroParser(ctx, r)
+	require.ErrorContains(t, err, "not an avro object container file")
+	require.NoError(t, r.Close())
+
+	writeTestAvroFile(t, filepath.Join(dir, "test.avro"), "bzip2", []int{1})
+	r, err = store.Open(ctx, "test.avro", nil)
+	require.NoError(t, err)
+	_, err = NewAvroParser(ctx, r)
+	require.ErrorContains(t, err, "unsupported avro codec 'bzip2'")
+	require.NoError(t, r.Close())
+
+	_, err = parseAvroSchema([]byte(`{"type": "record", "name": "r", "fields": [{"name": "a", "type": "unknown"}]}`),
+		"", make(map[string]*avroSchema))
+	require.ErrorContains(t, err, "unknown avro type 'unknown'")
+	// recursive types are supported
+	s, err := parseAvroSchema([]byte(`{"type": "record", "name": "node", "namespace": "ns", "fields": [
+		{"name": "next", "type": ["null", "ns.node"]}]}`), "", make(map[string]*avroSchema))
+	require.NoError(t, err)
+	require.Same(t, s, s.Fields[0].Schema.Branches[1])
+}
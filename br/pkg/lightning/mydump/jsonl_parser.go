@@ -0,0 +1,241 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/log"
+	"github.com/pingcap/tidb/br/pkg/lightning/metric"
+	"github.com/pingcap/tidb/br/pkg/lightning/worker"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// JSONLParser is a parser of JSON-lines (also known as NDJSON) files, where
+// each line is a JSON object representing a row. The keys of the object are
+// mapped to the columns. The column list is decided by the keys of the first
+// row read by the parser unless SetColumns is called, keys missing in the later
+// rows are imported as NULL, and nested objects and arrays are kept as JSON
+// text, so they can be imported into JSON columns. When the column list is set
+// by SetColumns, the keys not in the list are ignored. Lightning and IMPORT
+// INTO set the columns by the target table, so the rows of a file don't need to
+// have the same keys.
+// It implements the Parser interface.
+type JSONLParser struct {
+	blockParser
+
+	// columnIndexes maps the lower-case column names to the index in the row.
+	columnIndexes map[string]int
+	// ignoreUnknownKeys is true if the columns are set by SetColumns.
+	ignoreUnknownKeys bool
+}
+
+// NewJSONLParser creates a JSON-lines parser.
+func NewJSONLParser(
+	ctx context.Context,
+	reader ReadSeekCloser,
+	blockBufSize int64,
+	ioWorkers *worker.Pool,
+) *JSONLParser {
+	metrics, _ := metric.FromContext(ctx)
+	return &JSONLParser{
+		blockParser: makeBlockParser(reader, blockBufSize, ioWorkers, metrics, log.FromContext(ctx)),
+	}
+}
+
+// SetColumns sets the restored column names to the parser.
+func (parser *JSONLParser) SetColumns(columns []string) {
+	parser.blockParser.SetColumns(columns)
+	parser.columnIndexes = nil
+	parser.ignoreUnknownKeys = true
+}
+
+// ReadUntilTerminator seeks the file until the end of the current line, and
+// returns the content of the line and the file offset beyond the line.
+func (parser *JSONLParser) ReadUntilTerminator() ([]byte, int64, error) {
+	line, err := parser.readLine()
+	return line, parser.pos, err
+}
+
+// readLine reads the content until the next '\n', the returned content
+// contains the '\n'.
+func (parser *JSONLParser) readLine() ([]byte, error) {
+	if index := bytes.IndexByte(parser.buf, '\n'); index >= 0 {
+		ret := parser.buf[:index+1]
+		parser.buf = parser.buf[index+1:]
+		parser.pos += int64(index + 1)
+		return ret, nil
+	}
+
+	// not found in parser.buf, need allocate and loop.
+	var buf []byte
+	for {
+		buf = append(buf, parser.buf...)
+		if len(buf) > LargestEntryLimit {
+			return buf, errors.New("size of row cannot exceed the max value of txn-entry-size-limit")
+		}
+		parser.buf = nil
+		if err := parser.readBlock(); err != nil || len(parser.buf) == 0 {
+			if err == nil {
+				err = io.EOF
+			}
+			parser.pos += int64(len(buf))
+			return buf, errors.Trace(err)
+		}
+		if index := bytes.IndexByte(parser.buf, '\n'); index >= 0 {
+			buf = append(buf, parser.buf[:index+1]...)
+			parser.buf = parser.buf[index+1:]
+			parser.pos += int64(len(buf))
+			return buf, nil
+		}
+	}
+}
+
+// ReadRow reads a row from the datafile.
+func (parser *JSONLParser) ReadRow() error {
+	row := &parser.lastRow
+	row.Length = 0
+	row.RowID++
+
+	var line []byte
+	for {
+		var err error
+		line, err = parser.readLine()
+		if err != nil && (errors.Cause(err) != io.EOF || len(bytes.TrimSpace(line)) == 0) {
+			return err
+		}
+		// skip the empty lines
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			break
+		}
+	}
+
+	keys, values, err := splitJSONObject(line)
+	if err != nil {
+		parser.logSyntaxError()
+		return errors.Annotatef(err, "syntax error at offset %d", parser.pos)
+	}
+	if parser.columns == nil {
+		parser.columns = make([]string, 0, len(keys))
+		for _, key := range keys {
+			parser.columns = append(parser.columns, strings.ToLower(key))
+		}
+	}
+	if parser.columnIndexes == nil {
+		parser.columnIndexes = make(map[string]int, len(parser.columns))
+		for i, col := range parser.columns {
+			parser.columnIndexes[col] = i
+		}
+	}
+
+	row.Row = parser.acquireDatumSlice()
+	if cap(row.Row) >= len(parser.columns) {
+		row.Row = row.Row[:len(parser.columns)]
+	} else {
+		row.Row = make([]types.Datum, len(parser.columns))
+	}
+	for i := range row.Row {
+		row.Row[i] = types.Datum{}
+	}
+	for i, key := range keys {
+		idx, ok := parser.columnIndexes[strings.ToLower(key)]
+		if !ok {
+			if parser.ignoreUnknownKeys {
+				continue
+			}
+			return errors.Errorf("unknown key '%s' at offset %d, the keys of a JSON-lines file should be the same as the first row %v",
+				key, parser.pos, parser.columns)
+		}
+		row.Length += len(values[i])
+		if err := setDatumByJSON(&row.Row[idx], values[i]); err != nil {
+			return errors.Annotatef(err, "invalid value of key '%s' at offset %d", key, parser.pos)
+		}
+	}
+	return nil
+}
+
+// splitJSONObject splits a JSON object into keys and raw values, the keys are
+// in the order of the document.
+func splitJSONObject(content []byte) (keys []string, values []json.RawMessage, err error) {
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, nil, errors.Errorf("row should be a JSON object, got %v", tok)
+	}
+	seen := make(map[string]struct{})
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		//nolint: forcetypeassert
+		key := tok.(string)
+		if _, ok := seen[strings.ToLower(key)]; ok {
+			return nil, nil, errors.Errorf("duplicate key '%s'", key)
+		}
+		seen[strings.ToLower(key)] = struct{}{}
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	// consume the '}'
+	if _, err = dec.Token(); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if _, err = dec.Token(); err != io.EOF {
+		return nil, nil, errors.New("unexpected content after the JSON object")
+	}
+	return keys, values, nil
+}
+
+// setDatumByJSON converts a JSON value to Datum. Numbers are kept as strings to
+// avoid losing precision, nested objects and arrays are kept as JSON text.
+func setDatumByJSON(d *types.Datum, value json.RawMessage) error {
+	switch value[0] {
+	case 'n':
+		d.SetNull()
+	case 't':
+		d.SetUint64(1)
+	case 'f':
+		d.SetUint64(0)
+	case '"':
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return errors.Trace(err)
+		}
+		d.SetString(s, "utf8mb4_bin")
+	case '{', '[':
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, value); err != nil {
+			return errors.Trace(err)
+		}
+		d.SetString(buf.String(), "utf8mb4_bin")
+	default:
+		d.SetString(string(value), "utf8mb4_bin")
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/config"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLParser(t *testing.T) {
+	input := `{"ID": 1, "name": "alice", "score": 12.50, "tags": ["a", "b"], "ok": true}
+{"id": 2, "name": "bob\nsmith", "score": null, "tags": {"k": [1, 2]}, "ok": false}
+
+  {"name": "carol", "id": 3}
+{"id": 18446744073709551615}`
+	parser := mydump.NewJSONLParser(context.Background(), mydump.NewStringReader(input), int64(config.ReadBlockSize), nil)
+	defer parser.Close()
+
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []string{"id", "name", "score", "tags", "ok"}, parser.Columns())
+	require.Equal(t, mydump.Row{
+		RowID: 1,
+		Row: []types.Datum{
+			types.NewStringDatum("1"),
+			types.NewStringDatum("alice"),
+			types.NewStringDatum("12.50"),
+			types.NewStringDatum(`["a","b"]`),
+			types.NewUintDatum(1),
+		},
+		Length: 1 + 7 + 5 + 10 + 4,
+	}, parser.LastRow())
+	pos, rowID := parser.Pos()
+	require.Equal(t, int64(strings.IndexByte(input, '\n')+1), pos)
+	require.Equal(t, int64(1), rowID)
+
+	require.NoError(t, parser.ReadRow())
+	row := parser.LastRow()
+	require.Equal(t, int64(2), row.RowID)
+	require.Equal(t, []types.Datum{
+		types.NewStringDatum("2"),
+		types.NewStringDatum("bob\nsmith"),
+		{},
+		types.NewStringDatum(`{"k":[1,2]}`),
+		types.NewUintDatum(0),
+	}, row.Row)
+	parser.RecycleRow(row)
+
+	// empty lines are skipped, and the missing keys are NULL
+	require.NoError(t, parser.ReadRow())
+	row = parser.LastRow()
+	require.Equal(t, int64(3), row.RowID)
+	require.Equal(t, []types.Datum{types.NewStringDatum("3"), types.NewStringDatum("carol"), {}, {}, {}}, row.Row)
+	parser.RecycleRow(row)
+
+	// the last line without new line, and the big numbers keep the precision
+	require.NoError(t, parser.ReadRow())
+	row = parser.LastRow()
+	require.Equal(t, types.NewStringDatum("18446744073709551615"), row.Row[0])
+	pos, _ = parser.Pos()
+	require.Equal(t, int64(len(input)), pos)
+
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+}
+
+func TestJSONLParserSetColumns(t *testing.T) {
+	input := "{\"a\": 1, \"b\": \"x\", \"c\": 3}\n{\"c\": 6, \"a\": 4, \"d\": 5}\n"
+	parser := mydump.NewJSONLParser(context.Background(), mydump.NewStringReader(input), int64(config.ReadBlockSize), nil)
+	defer parser.Close()
+	parser.SetColumns([]string{"c", "b", "a"})
+
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{types.NewStringDatum("3"), types.NewStringDatum("x"), types.NewStringDatum("1")}, parser.LastRow().Row)
+	// the keys not in the columns are ignored
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{types.NewStringDatum("6"), {}, types.NewStringDatum("4")}, parser.LastRow().Row)
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+}
+
+func TestJSONLParserError(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		err   string
+	}{
+		{"{\"a\": 1}\n{\"b\": 2}\n", "unknown key 'b'"},
+		{"{\"a\": 1}\n[1, 2]\n", "row should be a JSON object"},
+		{"{\"a\": 1}\n{\"a\": 1, \"A\": 2}\n", "duplicate key 'A'"},
+		{"{\"a\": 1}\n{\"a\": 1} {\"a\": 2}\n", "unexpected content after the JSON object"},
+		{"{\"a\": 1}\n{\"a\": \n", "syntax error"},
+	} {
+		parser := mydump.NewJSONLParser(context.Background(), mydump.NewStringReader(tc.input), int64(config.ReadBlockSize), nil)
+		require.NoError(t, parser.ReadRow())
+		require.ErrorContains(t, parser.ReadRow(), tc.err, tc.input)
+		require.NoError(t, parser.Close())
+	}
+}
+
+func TestJSONLParserSetPos(t *testing.T) {
+	input := "{\"a\": 1}\n{\"a\": 2}\n{\"a\": 3}\n"
+	parser := mydump.NewJSONLParser(context.Background(), mydump.NewStringReader(input), int64(config.ReadBlockSize), nil)
+	defer parser.Close()
+
+	require.NoError(t, parser.SetPos(9, 1))
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, mydump.Row{RowID: 2, Row: []types.Datum{types.NewStringDatum("2")}, Length: 1}, parser.LastRow())
+
+	content, pos, err := parser.ReadUntilTerminator()
+	require.NoError(t, err)
+	require.Equal(t, "{\"a\": 3}\n", string(content))
+	require.Equal(t, int64(len(input)), pos)
+}
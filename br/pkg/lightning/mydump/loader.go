@@ -95,7 +95,7 @@ type SourceFileMeta struct {
 	// WARNING: variables below are not persistent
 	ExtendData ExtendColumnData
 	RealSize   int64
-	Rows       int64 // only for parquet
+	Rows       int64 // only for parquet and avro
 }
 
 // NewMDTableMeta creates an Mydumper table meta with specified character set.
@@ -213,10 +213,25 @@ func NewMyDumpLoader(ctx context.Context, cfg *config.Config, opts ...MDLoaderSe
 	if err != nil {
 		return nil, common.NormalizeError(err)
 	}
+	if s, err = WithSourceEncryption(cfg, s); err != nil {
+		return nil, err
+	}
 
 	return NewMyDumpLoaderWithStore(ctx, cfg, s, opts...)
 }
 
+// WithSourceEncryption wraps the storage of the source files to decrypt them if the master key file is specified.
+func WithSourceEncryption(cfg *config.Config, s storage.ExternalStorage) (storage.ExternalStorage, error) {
+	if cfg.Mydumper.EncryptionMasterKeyFile == "" {
+		return s, nil
+	}
+	masterKey, err := storage.NewFileMasterKey(cfg.Mydumper.EncryptionMasterKeyFile)
+	if err != nil {
+		return nil, common.ErrInvalidConfig.Wrap(err).GenWithStack("invalid mydumper.encryption-master-key-file")
+	}
+	return storage.WithEncryption(s, masterKey), nil
+}
+
 // NewMyDumpLoaderWithStore constructs a MyDumper loader with the provided external storage that scanns the data source and constructs a set of metadatas.
 func NewMyDumpLoaderWithStore(ctx context.Context, cfg *config.Config,
 	store storage.ExternalStorage, opts ...MDLoaderSetupOption) (*MDLoader, error) {
@@ -477,7 +492,7 @@ func (s *mdLoaderSetup) constructFileInfo(ctx context.Context, path string, size
 		s.tableSchemas = append(s.tableSchemas, info)
 	case SourceTypeViewSchema:
 		s.viewSchemas = append(s.viewSchemas, info)
-	case SourceTypeSQL, SourceTypeCSV:
+	case SourceTypeSQL, SourceTypeCSV, SourceTypeJSONL:
 		if info.FileMeta.Compression != CompressionNone {
 			compressRatio, err2 := SampleFileCompressRatio(ctx, info.FileMeta, s.loader.GetStore())
 			if err2 != nil {
@@ -497,6 +512,8 @@ func (s *mdLoaderSetup) constructFileInfo(ctx context.Context, path string, size
 			info.FileMeta.RealSize = parquestDataSize
 		}
 		s.tableDatas = append(s.tableDatas, info)
+	case SourceTypeAvro:
+		s.tableDatas = append(s.tableDatas, info)
 	}
 
 	logger.Debug("file route result", zap.String("schema", res.Schema),
@@ -22,6 +22,7 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -111,6 +112,33 @@ func TestLoader(t *testing.T) {
 	}
 }
 
+func TestWithSourceEncryption(t *testing.T) {
+	ctx := context.Background()
+	s := newTestMydumpLoaderSuite(t)
+	local, err := storage.NewLocalStorage(s.sourceDir)
+	require.NoError(t, err)
+
+	// the storage is not wrapped without the master key file
+	store, err := md.WithSourceEncryption(s.cfg, local)
+	require.NoError(t, err)
+	require.Equal(t, local, store)
+
+	s.cfg.Mydumper.EncryptionMasterKeyFile = filepath.Join(t.TempDir(), "master.key")
+	_, err = md.WithSourceEncryption(s.cfg, local)
+	require.ErrorContains(t, err, "invalid mydumper.encryption-master-key-file")
+
+	require.NoError(t, os.WriteFile(s.cfg.Mydumper.EncryptionMasterKeyFile, []byte(strings.Repeat("ab", 32)), 0o600))
+	store, err = md.WithSourceEncryption(s.cfg, local)
+	require.NoError(t, err)
+	require.NoError(t, store.WriteFile(ctx, "db.tbl.sql", []byte("INSERT INTO tbl VALUES (1);")))
+	raw, err := local.ReadFile(ctx, "db.tbl.sql")
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), "INSERT INTO")
+	data, err := store.ReadFile(ctx, "db.tbl.sql")
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO tbl VALUES (1);", string(data))
+}
+
 func TestEmptyDB(t *testing.T) {
 	s := newTestMydumpLoaderSuite(t)
 	_, err := md.NewMyDumpLoader(context.Background(), s.cfg)
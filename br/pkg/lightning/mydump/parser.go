@@ -665,6 +665,9 @@ func OpenReader(
 	switch {
 	case fileMeta.Type == SourceTypeParquet:
 		reader, err = OpenParquetReader(ctx, store, fileMeta.Path, fileMeta.FileSize)
+	case fileMeta.Type == SourceTypeAvro:
+		// the blocks of avro files are compressed by the codec in the header, so the file is opened as it is
+		reader, err = store.Open(ctx, fileMeta.Path, nil)
 	case fileMeta.Compression != CompressionNone:
 		compressType, err2 := ToStorageCompressType(fileMeta.Compression)
 		if err2 != nil {
@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/pierrec/lz4"
 	. "github.com/pingcap/tidb/br/pkg/lightning/mydump"
 	mockstorage "github.com/pingcap/tidb/br/pkg/mock/storage"
 	"github.com/pingcap/tidb/br/pkg/storage"
@@ -203,3 +204,28 @@ func TestExportStatementCompressed(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []byte("CREATE DATABASE whatever;"), data)
 }
+
+func TestExportStatementLZ4Compressed(t *testing.T) {
+	dir := t.TempDir()
+	fileName := "db-schema-create.sql.lz4"
+	file, err := os.Create(filepath.Join(dir, fileName))
+	require.NoError(t, err)
+
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+
+	lz4File := lz4.NewWriter(file)
+	_, err = lz4File.Write([]byte("CREATE DATABASE whatever;"))
+	require.NoError(t, err)
+	require.NoError(t, lz4File.Close())
+	stat, err := file.Stat()
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	compression := ParseCompressionOnFileExtension(fileName)
+	require.Equal(t, CompressionLZ4, compression)
+	f := FileInfo{FileMeta: SourceFileMeta{Path: fileName, FileSize: stat.Size(), Compression: compression}}
+	data, err := ExportStatement(context.TODO(), store, f, "auto")
+	require.NoError(t, err)
+	require.Equal(t, []byte("CREATE DATABASE whatever;"), data)
+}
@@ -240,6 +240,12 @@ func MakeTableRegions(
 			dataFileSize := info.FileMeta.FileSize
 			if info.FileMeta.Type == SourceTypeParquet {
 				regions, sizes, err = makeParquetFileRegion(egCtx, cfg, info)
+			} else if info.FileMeta.Type == SourceTypeAvro {
+				regions, sizes, err = makeAvroFileRegion(egCtx, cfg, info)
+			} else if info.FileMeta.Type == SourceTypeJSONL && info.FileMeta.Compression == CompressionNone &&
+				dataFileSize > cfg.MaxChunkSize+cfg.MaxChunkSize/largeCSVLowerThresholdRation {
+				// every line of a JSON-lines file is a row, so it can always be split by lines.
+				regions, sizes, err = SplitLargeJSONL(egCtx, cfg, info)
 			} else if info.FileMeta.Type == SourceTypeCSV && cfg.StrictFormat &&
 				info.FileMeta.Compression == CompressionNone &&
 				dataFileSize > cfg.MaxChunkSize+cfg.MaxChunkSize/largeCSVLowerThresholdRation {
@@ -391,6 +397,98 @@ func makeParquetFileRegion(
 	return []*TableRegion{region}, []float64{float64(dataFile.FileMeta.FileSize)}, nil
 }
 
+// makeAvroFileRegion makes a single region for an avro file. Like parquet, the
+// offset of an avro file is the row number.
+func makeAvroFileRegion(
+	ctx context.Context,
+	cfg *DataDivideConfig,
+	dataFile FileInfo,
+) ([]*TableRegion, []float64, error) {
+	numberRows := dataFile.FileMeta.Rows
+	var err error
+	if numberRows <= 0 {
+		numberRows, err = ReadAvroFileRowCountByFile(ctx, cfg.Store, dataFile.FileMeta)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	region := &TableRegion{
+		DB:       cfg.TableMeta.DB,
+		Table:    cfg.TableMeta.Name,
+		FileMeta: dataFile.FileMeta,
+		Chunk: Chunk{
+			Offset:       0,
+			EndOffset:    numberRows,
+			RealOffset:   0,
+			PrevRowIDMax: 0,
+			RowIDMax:     numberRows,
+		},
+	}
+	return []*TableRegion{region}, []float64{float64(dataFile.FileMeta.FileSize)}, nil
+}
+
+// SplitLargeJSONL splits a large JSON-lines file into multiple regions, the size
+// of each regions is specified by `config.MaxRegionSize`. The regions are split
+// at the line boundaries.
+func SplitLargeJSONL(
+	ctx context.Context,
+	cfg *DataDivideConfig,
+	dataFile FileInfo,
+) (regions []*TableRegion, dataFileSizes []float64, err error) {
+	maxRegionSize := cfg.MaxChunkSize
+	dataFileSizes = make([]float64, 0, dataFile.FileMeta.FileSize/maxRegionSize+1)
+	startOffset, endOffset := int64(0), maxRegionSize
+	var prevRowIdxMax int64
+	divisor := int64(cfg.ColumnCnt) + 2
+	for {
+		curRowsCnt := (endOffset - startOffset) / divisor
+		rowIDMax := prevRowIdxMax + curRowsCnt
+		if endOffset != dataFile.FileMeta.FileSize {
+			r, err := cfg.Store.Open(ctx, dataFile.FileMeta.Path, nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			parser := NewJSONLParser(ctx, r, cfg.ReadBlockSize, cfg.IOWorkers)
+			if err = parser.SetPos(endOffset, 0); err != nil {
+				_ = parser.Close()
+				return nil, nil, err
+			}
+			_, pos, err := parser.ReadUntilTerminator()
+			if err != nil {
+				if !errors.ErrorEqual(err, io.EOF) {
+					_ = parser.Close()
+					return nil, nil, err
+				}
+				pos = dataFile.FileMeta.FileSize
+			}
+			endOffset = pos
+			parser.Close()
+		}
+		regions = append(regions,
+			&TableRegion{
+				DB:       cfg.TableMeta.DB,
+				Table:    cfg.TableMeta.Name,
+				FileMeta: dataFile.FileMeta,
+				Chunk: Chunk{
+					Offset:       startOffset,
+					EndOffset:    endOffset,
+					PrevRowIDMax: prevRowIdxMax,
+					RowIDMax:     rowIDMax,
+				},
+			})
+		dataFileSizes = append(dataFileSizes, float64(endOffset-startOffset))
+		prevRowIdxMax = rowIDMax
+		if endOffset == dataFile.FileMeta.FileSize {
+			break
+		}
+		startOffset = endOffset
+		if endOffset += maxRegionSize; endOffset > dataFile.FileMeta.FileSize {
+			endOffset = dataFile.FileMeta.FileSize
+		}
+	}
+	return regions, dataFileSizes, nil
+}
+
 // SplitLargeCSV splits a large csv file into multiple regions, the size of
 // each regions is specified by `config.MaxRegionSize`.
 // Note: We split the file coarsely, thus the format of csv file is needed to be
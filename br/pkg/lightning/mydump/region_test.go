@@ -487,3 +487,43 @@ func TestSplitLargeFileOnlyOneChunk(t *testing.T) {
 		require.Equal(t, columns, regions[i].Chunk.Columns)
 	}
 }
+
+func TestSplitLargeJSONL(t *testing.T) {
+	meta := &MDTableMeta{
+		DB:   "jsonl",
+		Name: "large_jsonl_file",
+	}
+	cfg := &config.Config{
+		Mydumper: config.MydumperRuntime{
+			ReadBlockSize: config.ReadBlockSize,
+			Filter:        []string{"*.*"},
+			MaxRegionSize: 1,
+		},
+	}
+
+	dir := t.TempDir()
+
+	fileName := "test.jsonl"
+	filePath := filepath.Join(dir, fileName)
+
+	content := []byte("{\"a\": 1}\n{\"a\": 22}\n{\"a\": 333}")
+	err := os.WriteFile(filePath, content, 0o644)
+	require.NoError(t, err)
+
+	fileInfo := FileInfo{FileMeta: SourceFileMeta{Path: fileName, Type: SourceTypeJSONL, FileSize: int64(len(content))}}
+	ioWorker := worker.NewPool(context.Background(), 4, "io")
+
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	divideConfig := NewDataDivideConfig(cfg, 1, ioWorker, store, meta)
+
+	offsets := [][]int64{{0, 9}, {9, 19}, {19, 29}}
+
+	regions, _, err := SplitLargeJSONL(context.Background(), divideConfig, fileInfo)
+	require.NoError(t, err)
+	require.Len(t, regions, len(offsets))
+	for i := range offsets {
+		require.Equal(t, offsets[i][0], regions[i].Chunk.Offset)
+		require.Equal(t, offsets[i][1], regions[i].Chunk.EndOffset)
+	}
+}
@@ -34,6 +34,10 @@ const (
 	SourceTypeParquet
 	// SourceTypeViewSchema means this source file is a schema file for the view.
 	SourceTypeViewSchema
+	// SourceTypeJSONL means this source file is a JSON-lines data file.
+	SourceTypeJSONL
+	// SourceTypeAvro means this source file is an avro object container data file.
+	SourceTypeAvro
 )
 
 const (
@@ -49,6 +53,12 @@ const (
 	TypeCSV = "csv"
 	// TypeParquet is the source type value for parquet data file.
 	TypeParquet = "parquet"
+	// TypeJSONL is the source type value for JSON-lines data file.
+	TypeJSONL = "jsonl"
+	// TypeNDJSON is the alias of TypeJSONL.
+	TypeNDJSON = "ndjson"
+	// TypeAvro is the source type value for avro data file.
+	TypeAvro = "avro"
 	// TypeIgnore is the source type value for a ignored data file.
 	TypeIgnore = "ignore"
 )
@@ -82,6 +92,8 @@ func ToStorageCompressType(compression Compression) (storage.CompressType, error
 		return storage.Snappy, nil
 	case CompressionZStd:
 		return storage.Zstd, nil
+	case CompressionLZ4:
+		return storage.LZ4, nil
 	case CompressionNone:
 		return storage.NoCompression, nil
 	default:
@@ -102,6 +114,10 @@ func parseSourceType(t string) (SourceType, error) {
 		return SourceTypeCSV, nil
 	case TypeParquet:
 		return SourceTypeParquet, nil
+	case TypeJSONL, TypeNDJSON:
+		return SourceTypeJSONL, nil
+	case TypeAvro:
+		return SourceTypeAvro, nil
 	case TypeIgnore:
 		return SourceTypeIgnore, nil
 	case ViewSchema:
@@ -123,6 +139,10 @@ func (s SourceType) String() string {
 		return TypeSQL
 	case SourceTypeParquet:
 		return TypeParquet
+	case SourceTypeJSONL:
+		return TypeJSONL
+	case SourceTypeAvro:
+		return TypeAvro
 	case SourceTypeViewSchema:
 		return ViewSchema
 	default:
@@ -130,6 +150,12 @@ func (s SourceType) String() string {
 	}
 }
 
+// OffsetInRows returns whether the offsets of the chunks of this source type
+// are row numbers instead of file offsets, which is true for parquet and avro.
+func (s SourceType) OffsetInRows() bool {
+	return s == SourceTypeParquet || s == SourceTypeAvro
+}
+
 // ParseCompressionOnFileExtension parses the compression type from the file extension.
 func ParseCompressionOnFileExtension(filename string) Compression {
 	fileExt := strings.ToLower(filepath.Ext(filename))
@@ -171,7 +197,7 @@ var defaultFileRouteRules = []*config.FileRouteRule{
 	// ignore *-schema-trigger.sql, *-schema-post.sql files
 	{Pattern: `(?i).*(-schema-trigger|-schema-post)\.sql(?:\.(\w*?))?$`, Type: "ignore"},
 	// ignore backup files
-	{Pattern: `(?i).*\.(sql|csv|parquet)(\.(\w+))?\.(bak|BAK)$`, Type: "ignore"},
+	{Pattern: `(?i).*\.(sql|csv|parquet|jsonl|ndjson|avro)(\.(\w+))?\.(bak|BAK)$`, Type: "ignore"},
 	// db schema create file pattern, matches files like '{schema}-schema-create.sql[.{compress}]'
 	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)-schema-create\.sql(?:\.(\w*?))?$`,
 		Schema: "$1", Table: "", Type: SchemaSchema, Compression: "$2", Unescape: true},
@@ -181,8 +207,8 @@ var defaultFileRouteRules = []*config.FileRouteRule{
 	// view schema create file pattern, matches files like '{schema}.{table}-schema-view.sql[.{compress}]'
 	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)-schema-view\.sql(?:\.(\w*?))?$`,
 		Schema: "$1", Table: "$2", Type: ViewSchema, Compression: "$3", Unescape: true},
-	// source file pattern, matches files like '{schema}.{table}.0001.{sql|csv}[.{compress}]'
-	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet)(?:\.(\w+))?$`,
+	// source file pattern, matches files like '{schema}.{table}.0001.{sql|csv|parquet|jsonl|ndjson|avro}[.{compress}]'
+	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet|jsonl|ndjson|avro)(?:\.(\w+))?$`,
 		Schema: "$1", Table: "$2", Type: "$4", Key: "$3", Compression: "$5", Unescape: true},
 }
 
@@ -347,6 +373,9 @@ func (p regexRouterParser) Parse(r *config.FileRouteRule, logger log.Logger) (*R
 			if result.Type == SourceTypeParquet && compression != CompressionNone {
 				return errors.Errorf("can't support whole compressed parquet file, should compress parquet files by choosing correct parquet compress writer, path: %s", r.Path)
 			}
+			if result.Type == SourceTypeAvro && compression != CompressionNone {
+				return errors.Errorf("can't support whole compressed avro file, should compress avro files by choosing the codec of avro writer, path: %s", r.Path)
+			}
 			result.Compression = compression
 			return nil
 		})
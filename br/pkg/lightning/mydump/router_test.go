@@ -55,6 +55,9 @@ func TestDefaultRouter(t *testing.T) {
 		"/test/123/my_schema.my_table.sql.gz":    {"my_schema", "my_table", "", "gz", "sql"},
 		"my_dir/my_schema.my_table.csv.lzo":      {"my_schema", "my_table", "", "lzo", "csv"},
 		"my_schema.my_table.0001.sql.snappy":     {"my_schema", "my_table", "0001", "snappy", "sql"},
+		"my_schema.my_table.0001.jsonl.gz":       {"my_schema", "my_table", "0001", "gz", "jsonl"},
+		"my_schema.my_table.ndjson":              {"my_schema", "my_table", "", "", "jsonl"},
+		"my_schema.my_table.0001.avro":           {"my_schema", "my_table", "0001", "", "avro"},
 	}
 	for path, fields := range inputOutputMap {
 		res, err := r.Route(path)
@@ -310,3 +313,16 @@ func TestRouteWithCompressedParquet(t *testing.T) {
 	_, err = router.Route(fileName)
 	require.Error(t, err)
 }
+
+func TestRouteWithCompressedAvro(t *testing.T) {
+	router, err := NewDefaultFileRouter(log.L())
+	require.NoError(t, err)
+	_, err = router.Route("myschema.my_table.000.avro.gz")
+	require.ErrorContains(t, err, "can't support whole compressed avro file")
+
+	res, err := router.Route("myschema.my_table.000.ndjson.gz")
+	require.NoError(t, err)
+	require.Equal(t, SourceTypeJSONL, res.Type)
+	require.Equal(t, CompressionGZ, res.Compression)
+	require.Equal(t, TypeJSONL, res.Type.String())
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metautil
+
+import (
+	"github.com/pingcap/errors"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+)
+
+// IsIncrementalBackup returns whether the backup only contains the changes
+// in (StartVersion, EndVersion], i.e. it's taken with `--lastbackupts`.
+func IsIncrementalBackup(meta *backuppb.BackupMeta) bool {
+	return meta.StartVersion != 0 && meta.StartVersion != meta.EndVersion
+}
+
+// CheckIncrementalBackup checks whether the incremental backup `next` can be
+// restored right after the backup `prev`. They should be taken from the same
+// cluster, and `next` should start at the end version of `prev`, otherwise the
+// changes between them are lost or applied twice.
+func CheckIncrementalBackup(prev, next *backuppb.BackupMeta) error {
+	if !IsIncrementalBackup(next) {
+		return errors.Annotatef(berrors.ErrRestoreInvalidBackup,
+			"the backup at %d is not an incremental backup", next.EndVersion)
+	}
+	if prev.IsRawKv || next.IsRawKv {
+		return errors.Annotate(berrors.ErrRestoreInvalidBackup,
+			"raw kv backups can't make up a backup chain")
+	}
+	if prev.ClusterId != next.ClusterId {
+		return errors.Annotatef(berrors.ErrRestoreInvalidBackup,
+			"the backups are taken from different clusters, %d and %d", prev.ClusterId, next.ClusterId)
+	}
+	if prev.ApiVersion != next.ApiVersion {
+		return errors.Annotatef(berrors.ErrRestoreInvalidBackup,
+			"the backups have different api versions, %s and %s", prev.ApiVersion, next.ApiVersion)
+	}
+	if next.StartVersion != prev.EndVersion {
+		return errors.Annotatef(berrors.ErrRestoreInvalidBackup,
+			"the incremental backup (%d, %d] doesn't start at the end of the last backup %d",
+			next.StartVersion, next.EndVersion, prev.EndVersion)
+	}
+	return nil
+}
+
+// CheckBackupChain checks whether the backups make up a chain which can be
+// restored in order: the first one is a full backup, and each of the
+// following ones is an incremental backup based on the one before it.
+func CheckBackupChain(metas []*backuppb.BackupMeta) error {
+	if len(metas) == 0 {
+		return errors.Annotate(berrors.ErrInvalidArgument, "the backup chain is empty")
+	}
+	if IsIncrementalBackup(metas[0]) {
+		return errors.Annotatef(berrors.ErrRestoreInvalidBackup,
+			"the backup chain should start with a full backup, but got the incremental backup (%d, %d]",
+			metas[0].StartVersion, metas[0].EndVersion)
+	}
+	for i := 1; i < len(metas); i++ {
+		if err := CheckIncrementalBackup(metas[i-1], metas[i]); err != nil {
+			return errors.Annotatef(err, "the backup #%d of the chain is invalid", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metautil
+
+import (
+	"testing"
+
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckBackupChain(t *testing.T) {
+	full := &backuppb.BackupMeta{ClusterId: 1, EndVersion: 100}
+	incr1 := &backuppb.BackupMeta{ClusterId: 1, StartVersion: 100, EndVersion: 200}
+	incr2 := &backuppb.BackupMeta{ClusterId: 1, StartVersion: 200, EndVersion: 300}
+
+	require.False(t, IsIncrementalBackup(full))
+	require.False(t, IsIncrementalBackup(&backuppb.BackupMeta{StartVersion: 100, EndVersion: 100}))
+	require.True(t, IsIncrementalBackup(incr1))
+
+	require.NoError(t, CheckBackupChain([]*backuppb.BackupMeta{full}))
+	require.NoError(t, CheckBackupChain([]*backuppb.BackupMeta{full, incr1, incr2}))
+
+	for _, tc := range []struct {
+		chain []*backuppb.BackupMeta
+		err   string
+	}{
+		{nil, "the backup chain is empty"},
+		{[]*backuppb.BackupMeta{incr1, incr2}, "should start with a full backup"},
+		{[]*backuppb.BackupMeta{full, full}, "the backup at 100 is not an incremental backup"},
+		{[]*backuppb.BackupMeta{full, incr2}, "doesn't start at the end of the last backup 100"},
+		{[]*backuppb.BackupMeta{full, incr2, incr1}, "doesn't start at the end of the last backup 100"},
+		{
+			[]*backuppb.BackupMeta{full, {ClusterId: 2, StartVersion: 100, EndVersion: 200}},
+			"the backups are taken from different clusters, 1 and 2",
+		},
+		{
+			[]*backuppb.BackupMeta{full, {ClusterId: 1, StartVersion: 100, EndVersion: 200, ApiVersion: kvrpcpb.APIVersion_V2}},
+			"the backups have different api versions",
+		},
+		{
+			[]*backuppb.BackupMeta{full, {ClusterId: 1, StartVersion: 100, EndVersion: 200, IsRawKv: true}},
+			"raw kv backups can't make up a backup chain",
+		},
+	} {
+		require.ErrorContains(t, CheckBackupChain(tc.chain), tc.err)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package restore
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/pingcap/errors"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+)
+
+// ChainedBackup is one of the backups of a backup chain, which is made up of a
+// full backup and the incremental backups based on it.
+type ChainedBackup struct {
+	// Storage is the storage url of the backup.
+	Storage string
+	Meta    *backuppb.BackupMeta
+}
+
+// SortBackupChain sorts the backups into the order they should be restored,
+// that is the full backup first and then the incremental backups by their
+// backup ts, and checks whether they make up a valid chain.
+// Restoring an incremental backup executes its DDL jobs before restoring its
+// data, so the schema changes between the backups are replayed in order too.
+func SortBackupChain(backups []ChainedBackup) error {
+	slices.SortStableFunc(backups, func(a, b ChainedBackup) int {
+		return cmp.Compare(a.Meta.EndVersion, b.Meta.EndVersion)
+	})
+	metas := make([]*backuppb.BackupMeta, 0, len(backups))
+	for _, backup := range backups {
+		metas = append(metas, backup.Meta)
+	}
+	return errors.Trace(metautil.CheckBackupChain(metas))
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package restore_test
+
+import (
+	"testing"
+
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/restore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortBackupChain(t *testing.T) {
+	backups := []restore.ChainedBackup{
+		{Storage: "local:///incr2", Meta: &backuppb.BackupMeta{ClusterId: 1, StartVersion: 200, EndVersion: 300}},
+		{Storage: "local:///full", Meta: &backuppb.BackupMeta{ClusterId: 1, EndVersion: 100}},
+		{Storage: "local:///incr1", Meta: &backuppb.BackupMeta{ClusterId: 1, StartVersion: 100, EndVersion: 200}},
+	}
+	require.NoError(t, restore.SortBackupChain(backups))
+	storages := make([]string, 0, len(backups))
+	for _, backup := range backups {
+		storages = append(storages, backup.Storage)
+	}
+	require.Equal(t, []string{"local:///full", "local:///incr1", "local:///incr2"}, storages)
+
+	// a gap in the chain
+	err := restore.SortBackupChain([]restore.ChainedBackup{
+		{Storage: "local:///incr2", Meta: &backuppb.BackupMeta{ClusterId: 1, StartVersion: 200, EndVersion: 300}},
+		{Storage: "local:///full", Meta: &backuppb.BackupMeta{ClusterId: 1, EndVersion: 100}},
+	})
+	require.ErrorIs(t, err, berrors.ErrRestoreInvalidBackup)
+
+	// no full backup
+	err = restore.SortBackupChain([]restore.ChainedBackup{
+		{Storage: "local:///incr1", Meta: &backuppb.BackupMeta{ClusterId: 1, StartVersion: 100, EndVersion: 200}},
+		{Storage: "local:///incr2", Meta: &backuppb.BackupMeta{ClusterId: 1, StartVersion: 200, EndVersion: 300}},
+	})
+	require.ErrorIs(t, err, berrors.ErrRestoreInvalidBackup)
+}
@@ -0,0 +1,395 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package restore
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/errors"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/log"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+	"github.com/pingcap/tidb/br/pkg/utils"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	filter "github.com/pingcap/tidb/pkg/util/table-filter"
+	"go.uber.org/zap"
+)
+
+// RenameRule restores the tables matched by a table filter pattern into
+// another database or as another table.
+type RenameRule struct {
+	rule    string
+	pattern filter.Filter
+	// Schema is the target database name, empty means keeping the original name.
+	Schema string
+	// Table is the target table name, empty means keeping the original name.
+	Table string
+}
+
+// ParseRenameRule parses a rename rule in the form of `pattern:target`. The
+// pattern is a table filter pattern, such as `db.t` or `db.t_*`. The target is
+// `schema.table`, `schema.*` or `schema`, the `*` or the omitted table name
+// means keeping the table names. The names can be quoted by backticks.
+func ParseRenameRule(rule string) (*RenameRule, error) {
+	sep := indexUnquoted(rule, ':')
+	if sep < 0 {
+		return nil, errors.Annotatef(berrors.ErrInvalidArgument,
+			"rename rule %q should be in the form of `pattern:target`", rule)
+	}
+	pattern, target := strings.TrimSpace(rule[:sep]), strings.TrimSpace(rule[sep+1:])
+	if len(pattern) == 0 || pattern[0] == '!' || pattern[0] == '@' {
+		return nil, errors.Annotatef(berrors.ErrInvalidArgument,
+			"invalid pattern %q of rename rule %q", pattern, rule)
+	}
+	f, err := filter.Parse([]string{pattern})
+	if err != nil {
+		return nil, errors.Annotatef(berrors.ErrInvalidArgument,
+			"invalid pattern of rename rule %q: %v", rule, err)
+	}
+	names, err := splitQualifiedName(target)
+	if err != nil || len(names) > 2 || len(names[0]) == 0 {
+		return nil, errors.Annotatef(berrors.ErrInvalidArgument,
+			"invalid target %q of rename rule %q", target, rule)
+	}
+	r := &RenameRule{
+		rule:    rule,
+		pattern: filter.CaseInsensitive(f),
+		Schema:  names[0],
+	}
+	if len(names) == 2 && names[1] != "*" {
+		if len(names[1]) == 0 {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument,
+				"invalid target %q of rename rule %q", target, rule)
+		}
+		r.Table = names[1]
+	}
+	return r, nil
+}
+
+// ParseRenameRules parses the rename rules, see ParseRenameRule for the format.
+func ParseRenameRules(rules []string) ([]*RenameRule, error) {
+	renameRules := make([]*RenameRule, 0, len(rules))
+	for _, rule := range rules {
+		r, err := ParseRenameRule(rule)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		renameRules = append(renameRules, r)
+	}
+	return renameRules, nil
+}
+
+// String implements fmt.Stringer.
+func (r *RenameRule) String() string {
+	return r.rule
+}
+
+func (r *RenameRule) targetName(schema, table string) (string, string) {
+	if len(r.Table) > 0 {
+		table = r.Table
+	}
+	return r.Schema, table
+}
+
+// RenameTables applies the rename rules to the databases and tables to be
+// restored, the first matched rule of a table wins. The table IDs are kept, so
+// the rewrite rules generated from the created tables still rewrite the keys
+// of the backed up tables into the renamed ones. The returned databases are
+// the ones to be created, a database without any table to restore is renamed
+// by the first rule matching its name and keeping the table names.
+func RenameTables(
+	dbs []*metautil.Database,
+	tables []*metautil.Table,
+	rules []*RenameRule,
+) ([]*metautil.Database, []*metautil.Table, error) {
+	if len(rules) == 0 {
+		return dbs, tables, nil
+	}
+
+	newDBs := make([]*metautil.Database, 0, len(dbs))
+	dbByName := make(map[string]*metautil.Database, len(dbs))
+	getDB := func(src *model.DBInfo, name string) *metautil.Database {
+		if db, ok := dbByName[strings.ToLower(name)]; ok {
+			return db
+		}
+		info := src
+		if src.Name.O != name {
+			info = src.Clone()
+			info.Name = model.NewCIStr(name)
+		}
+		db := &metautil.Database{Info: info}
+		dbByName[info.Name.L] = db
+		newDBs = append(newDBs, db)
+		return db
+	}
+
+	hasTable := make(map[string]bool, len(dbs))
+	for _, table := range tables {
+		hasTable[table.DB.Name.L] = true
+	}
+	for _, db := range dbs {
+		if hasTable[db.Info.Name.L] {
+			continue
+		}
+		name := db.Info.Name.O
+		if dbName, ok := utils.GetSysDBName(db.Info.Name); !(ok && utils.IsSysDB(dbName)) {
+			for _, rule := range rules {
+				if len(rule.Table) == 0 && rule.pattern.MatchSchema(name) {
+					name = rule.Schema
+					break
+				}
+			}
+		}
+		getDB(db.Info, name)
+	}
+
+	newTables := make([]*metautil.Table, 0, len(tables))
+	renamedFrom := make(map[UniqueTableName]UniqueTableName, len(tables))
+	for _, table := range tables {
+		schema, name := table.DB.Name.O, table.Info.Name.O
+		var matched *RenameRule
+		if dbName, ok := utils.GetSysDBName(table.DB.Name); !(ok && utils.IsSysDB(dbName)) {
+			for _, rule := range rules {
+				if rule.pattern.MatchTable(schema, name) {
+					matched = rule
+					break
+				}
+			}
+		}
+		newSchema, newName := schema, name
+		if matched != nil {
+			newSchema, newName = matched.targetName(schema, name)
+		}
+
+		target := UniqueTableName{DB: strings.ToLower(newSchema), Table: strings.ToLower(newName)}
+		if from, ok := renamedFrom[target]; ok {
+			return nil, nil, errors.Annotatef(berrors.ErrInvalidArgument,
+				"both %s and %s are restored as %s",
+				utils.EncloseDBAndTable(from.DB, from.Table),
+				utils.EncloseDBAndTable(schema, name),
+				utils.EncloseDBAndTable(newSchema, newName))
+		}
+		renamedFrom[target] = UniqueTableName{DB: schema, Table: name}
+
+		db := getDB(table.DB, newSchema)
+		if matched == nil {
+			newTables = append(newTables, table)
+			db.Tables = append(db.Tables, table)
+			continue
+		}
+
+		log.Info("rename table during restore",
+			zap.Stringer("rule", matched),
+			zap.String("from", utils.EncloseDBAndTable(schema, name)),
+			zap.String("to", utils.EncloseDBAndTable(newSchema, newName)))
+		newTable := *table
+		newTable.DB = db.Info
+		if name != newName {
+			newTable.Info = table.Info.Clone()
+			newTable.Info.Name = model.NewCIStr(newName)
+		}
+		if table.Stats != nil {
+			stats := *table.Stats
+			stats.DatabaseName = newSchema
+			stats.TableName = newName
+			newTable.Stats = &stats
+		}
+		newTables = append(newTables, &newTable)
+		db.Tables = append(db.Tables, &newTable)
+	}
+	return newDBs, newTables, nil
+}
+
+// ParsePartitionFilter parses the partitions to restore, each rule is in the
+// form of `db.table:p0,p1`. The names are case-insensitive.
+func ParsePartitionFilter(rules []string) (map[UniqueTableName][]string, error) {
+	partitions := make(map[UniqueTableName][]string, len(rules))
+	for _, rule := range rules {
+		sep := indexUnquoted(rule, ':')
+		if sep < 0 {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument,
+				"partition rule %q should be in the form of `db.table:p0,p1`", rule)
+		}
+		names, err := splitQualifiedName(strings.TrimSpace(rule[:sep]))
+		if err != nil || len(names) != 2 || len(names[0]) == 0 || len(names[1]) == 0 {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument,
+				"invalid table name of partition rule %q", rule)
+		}
+		name := UniqueTableName{DB: strings.ToLower(names[0]), Table: strings.ToLower(names[1])}
+		for _, part := range strings.Split(rule[sep+1:], ",") {
+			part = strings.ToLower(strings.Trim(strings.TrimSpace(part), "`"))
+			if len(part) == 0 {
+				return nil, errors.Annotatef(berrors.ErrInvalidArgument,
+					"empty partition name in partition rule %q", rule)
+			}
+			partitions[name] = append(partitions[name], part)
+		}
+	}
+	return partitions, nil
+}
+
+// FilterPartitions keeps only the selected partitions of the tables. The other
+// partitions are removed from the table definition to create, and their files
+// are dropped so they are never downloaded. As the checksum and statistics in
+// the backup are of the whole table, they are skipped for the filtered tables.
+// The returned files are the files to restore of all the tables.
+func FilterPartitions(
+	tables []*metautil.Table,
+	partitions map[UniqueTableName][]string,
+) ([]*metautil.Table, []*backuppb.File, error) {
+	newTables := make([]*metautil.Table, 0, len(tables))
+	files := make([]*backuppb.File, 0)
+	found := make(map[UniqueTableName]struct{}, len(partitions))
+	for _, table := range tables {
+		name := UniqueTableName{DB: table.DB.Name.L, Table: table.Info.Name.L}
+		if dbName, ok := utils.GetSysDBName(table.DB.Name); ok {
+			name.DB = strings.ToLower(dbName)
+		}
+		selected, ok := partitions[name]
+		if !ok {
+			newTables = append(newTables, table)
+			files = append(files, table.Files...)
+			continue
+		}
+		found[name] = struct{}{}
+		newTable, err := filterTablePartitions(table, selected)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		newTables = append(newTables, newTable)
+		files = append(files, newTable.Files...)
+	}
+	for name := range partitions {
+		if _, ok := found[name]; !ok {
+			return nil, nil, errors.Annotatef(berrors.ErrInvalidArgument,
+				"table %s of the partition rule isn't restored", utils.EncloseDBAndTable(name.DB, name.Table))
+		}
+	}
+	return newTables, files, nil
+}
+
+func filterTablePartitions(table *metautil.Table, selected []string) (*metautil.Table, error) {
+	tableName := utils.EncloseDBAndTable(table.DB.Name.O, table.Info.Name.O)
+	pi := table.Info.Partition
+	if pi == nil {
+		return nil, errors.Annotatef(berrors.ErrInvalidArgument, "table %s isn't partitioned", tableName)
+	}
+	if pi.Type != model.PartitionTypeRange && pi.Type != model.PartitionTypeList {
+		return nil, errors.Annotatef(berrors.ErrInvalidArgument,
+			"only the partitions of RANGE or LIST partitioned tables can be selected, but table %s is %s partitioned",
+			tableName, pi.Type)
+	}
+	for _, idx := range table.Info.Indices {
+		if idx.Global {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument,
+				"the partitions of table %s can't be selected since it has the global index %s", tableName, idx.Name)
+		}
+	}
+
+	selectedSet := make(map[string]struct{}, len(selected))
+	for _, part := range selected {
+		selectedSet[part] = struct{}{}
+	}
+	info := table.Info.Clone()
+	info.Partition.Definitions = info.Partition.Definitions[:0]
+	physicalIDs := map[int64]struct{}{table.Info.ID: {}}
+	for _, def := range pi.Definitions {
+		if _, ok := selectedSet[def.Name.L]; !ok {
+			continue
+		}
+		delete(selectedSet, def.Name.L)
+		info.Partition.Definitions = append(info.Partition.Definitions, def.Clone())
+		physicalIDs[def.ID] = struct{}{}
+	}
+	if len(selectedSet) > 0 {
+		missing := make([]string, 0, len(selectedSet))
+		for part := range selectedSet {
+			missing = append(missing, part)
+		}
+		slices.Sort(missing)
+		return nil, errors.Annotatef(berrors.ErrInvalidArgument,
+			"partition %s doesn't exist in table %s", strings.Join(missing, ","), tableName)
+	}
+	if info.Partition.Num > 0 {
+		info.Partition.Num = uint64(len(info.Partition.Definitions))
+	}
+
+	newTable := *table
+	newTable.Info = info
+	newTable.Files = make([]*backuppb.File, 0, len(table.Files))
+	for _, file := range table.Files {
+		if _, ok := physicalIDs[tablecodec.DecodeTableID(file.GetStartKey())]; ok {
+			newTable.Files = append(newTable.Files, file)
+		}
+	}
+	newTable.Crc64Xor, newTable.TotalKvs, newTable.TotalBytes = 0, 0, 0
+	newTable.Stats, newTable.StatsFileIndexes = nil, nil
+	log.Info("restore the selected partitions of the table, the checksum and statistics are skipped",
+		zap.String("table", tableName),
+		zap.Strings("partitions", selected),
+		zap.Int("files", len(newTable.Files)),
+		zap.Int("total files", len(table.Files)))
+	return &newTable, nil
+}
+
+// indexUnquoted returns the index of the first sep out of backticks, or -1.
+func indexUnquoted(s string, sep byte) int {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '`':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitQualifiedName splits a name like "db.t" or "`d.b`.`t`" by the dots
+// out of backticks, and unquotes the parts.
+func splitQualifiedName(name string) ([]string, error) {
+	names := make([]string, 0, 2)
+	for {
+		var part string
+		if strings.HasPrefix(name, "`") {
+			var sb strings.Builder
+			i := 1
+			for ; i < len(name); i++ {
+				if name[i] == '`' {
+					if i+1 < len(name) && name[i+1] == '`' {
+						sb.WriteByte('`')
+						i++
+						continue
+					}
+					break
+				}
+				sb.WriteByte(name[i])
+			}
+			if i >= len(name) {
+				return nil, fmt.Errorf("unclosed backtick in %q", name)
+			}
+			part, name = sb.String(), name[i+1:]
+			if len(name) > 0 && name[0] != '.' {
+				return nil, fmt.Errorf("unexpected %q after the quoted name", name)
+			}
+		} else {
+			dot := strings.IndexByte(name, '.')
+			if dot < 0 {
+				dot = len(name)
+			}
+			part, name = name[:dot], name[dot:]
+		}
+		names = append(names, part)
+		if len(name) == 0 {
+			return names, nil
+		}
+		name = name[1:]
+	}
+}
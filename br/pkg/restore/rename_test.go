@@ -0,0 +1,203 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package restore_test
+
+import (
+	"testing"
+
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+	"github.com/pingcap/tidb/br/pkg/restore"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/statistics/handle/util"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRenameRule(t *testing.T) {
+	cases := []struct {
+		rule   string
+		schema string
+		table  string
+	}{
+		{"db.t:db_restored.t_20260101", "db_restored", "t_20260101"},
+		{"db.*:db_restored.*", "db_restored", ""},
+		{"db.*: db_restored", "db_restored", ""},
+		{"db.t_*:`db:restored`.`t.1`", "db:restored", "t.1"},
+		{"`db:x`.t:`a``b`.c", "a`b", "c"},
+	}
+	for _, c := range cases {
+		rule, err := restore.ParseRenameRule(c.rule)
+		require.NoError(t, err, c.rule)
+		require.Equal(t, c.schema, rule.Schema, c.rule)
+		require.Equal(t, c.table, rule.Table, c.rule)
+		require.Equal(t, c.rule, rule.String())
+	}
+
+	for _, rule := range []string{
+		"db.t",
+		"db.t:",
+		":db.t",
+		"!db.t:db2.t",
+		"db.t:a.b.c",
+		"db.t:a.",
+		"db.t:`a",
+		"db.t:`a`b",
+		"db:db2",
+	} {
+		_, err := restore.ParseRenameRule(rule)
+		require.Error(t, err, rule)
+	}
+}
+
+func newTestTable(db *model.DBInfo, id int64, name string, partitions ...string) *metautil.Table {
+	info := &model.TableInfo{ID: id, Name: model.NewCIStr(name)}
+	files := []*backuppb.File{{
+		Name:     name,
+		StartKey: tablecodec.EncodeTablePrefix(id),
+		EndKey:   tablecodec.EncodeTablePrefix(id),
+	}}
+	if len(partitions) > 0 {
+		info.Partition = &model.PartitionInfo{Type: model.PartitionTypeRange, Enable: true}
+		for i, part := range partitions {
+			partID := id + int64(i) + 1
+			info.Partition.Definitions = append(info.Partition.Definitions, model.PartitionDefinition{
+				ID:   partID,
+				Name: model.NewCIStr(part),
+			})
+			files = append(files, &backuppb.File{
+				Name:     name + "_" + part,
+				StartKey: tablecodec.EncodeTablePrefix(partID),
+				EndKey:   tablecodec.EncodeTablePrefix(partID),
+			})
+		}
+	}
+	return &metautil.Table{
+		DB:       db,
+		Info:     info,
+		Files:    files,
+		Crc64Xor: 1,
+		TotalKvs: 1,
+		Stats:    &util.JSONTable{DatabaseName: db.Name.O, TableName: name},
+	}
+}
+
+func TestRenameTables(t *testing.T) {
+	db1 := &model.DBInfo{ID: 1, Name: model.NewCIStr("db1"), Charset: "utf8mb4"}
+	db2 := &model.DBInfo{ID: 2, Name: model.NewCIStr("db2")}
+	db3 := &model.DBInfo{ID: 3, Name: model.NewCIStr("db3")}
+	t1 := newTestTable(db1, 10, "t1")
+	t2 := newTestTable(db1, 20, "t2")
+	t3 := newTestTable(db2, 30, "t3")
+	dbs := []*metautil.Database{
+		{Info: db1, Tables: []*metautil.Table{t1, t2}},
+		{Info: db2, Tables: []*metautil.Table{t3}},
+		{Info: db3},
+	}
+	tables := []*metautil.Table{t1, t2, t3}
+
+	rules, err := restore.ParseRenameRules([]string{
+		"DB1.T1:db1_restored.t1_20260101",
+		"db1.*:db1_restored.*",
+		"db3.*:db3_restored",
+	})
+	require.NoError(t, err)
+	newDBs, newTables, err := restore.RenameTables(dbs, tables, rules)
+	require.NoError(t, err)
+
+	names := make(map[string][]string)
+	for _, db := range newDBs {
+		for _, table := range db.Tables {
+			require.Same(t, db.Info, table.DB)
+			names[db.Info.Name.O] = append(names[db.Info.Name.O], table.Info.Name.O)
+		}
+		if len(db.Tables) == 0 {
+			names[db.Info.Name.O] = nil
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"db1_restored": {"t1_20260101", "t2"},
+		"db2":          {"t3"},
+		"db3_restored": nil,
+	}, names)
+	require.Len(t, newTables, 3)
+
+	// the IDs are kept and the backed up tables aren't changed.
+	require.Equal(t, int64(10), newTables[0].Info.ID)
+	require.Equal(t, "db1_restored", newTables[0].Stats.DatabaseName)
+	require.Equal(t, "t1_20260101", newTables[0].Stats.TableName)
+	require.Equal(t, "utf8mb4", newTables[0].DB.Charset)
+	require.Equal(t, "t1", t1.Info.Name.O)
+	require.Equal(t, "db1", t1.DB.Name.O)
+	require.Equal(t, "db1", t1.Stats.DatabaseName)
+	require.Same(t, t2.Info, newTables[1].Info)
+	require.Same(t, t3, newTables[2])
+
+	// two tables can't be restored as the same one.
+	rules, err = restore.ParseRenameRules([]string{"db1.*:db2.t3"})
+	require.NoError(t, err)
+	_, _, err = restore.RenameTables(dbs, tables, rules)
+	require.ErrorContains(t, err, "both `db1`.`t1` and `db1`.`t2` are restored as `db2`.`t3`")
+	rules, err = restore.ParseRenameRules([]string{"db1.t1:db2.*"})
+	require.NoError(t, err)
+	_, _, err = restore.RenameTables(dbs, tables, rules)
+	require.NoError(t, err)
+	rules, err = restore.ParseRenameRules([]string{"db1.t1:db2.T3"})
+	require.NoError(t, err)
+	_, _, err = restore.RenameTables(dbs, tables, rules)
+	require.ErrorContains(t, err, "both `db1`.`t1` and `db2`.`t3` are restored as `db2`.`t3`")
+}
+
+func TestFilterPartitions(t *testing.T) {
+	db := &model.DBInfo{ID: 1, Name: model.NewCIStr("db")}
+	t1 := newTestTable(db, 10, "t1", "p0", "p1", "p2")
+	t2 := newTestTable(db, 20, "t2")
+	tables := []*metautil.Table{t1, t2}
+
+	partitions, err := restore.ParsePartitionFilter([]string{"DB.T1:P0, `p2`"})
+	require.NoError(t, err)
+	newTables, files, err := restore.FilterPartitions(tables, partitions)
+	require.NoError(t, err)
+	require.Len(t, newTables, 2)
+	require.Same(t, t2, newTables[1])
+
+	filtered := newTables[0]
+	require.Len(t, filtered.Info.Partition.Definitions, 2)
+	require.Equal(t, "p0", filtered.Info.Partition.Definitions[0].Name.O)
+	require.Equal(t, "p2", filtered.Info.Partition.Definitions[1].Name.O)
+	require.True(t, filtered.NoChecksum())
+	require.Nil(t, filtered.Stats)
+	fileNames := make([]string, 0, len(files))
+	for _, file := range files {
+		fileNames = append(fileNames, file.Name)
+	}
+	require.Equal(t, []string{"t1", "t1_p0", "t1_p2", "t2"}, fileNames)
+	// the backed up table isn't changed.
+	require.Len(t, t1.Info.Partition.Definitions, 3)
+	require.Len(t, t1.Files, 4)
+	require.False(t, t1.NoChecksum())
+
+	for rule, msg := range map[string]string{
+		"db.t1:p3":    "partition p3 doesn't exist in table `db`.`t1`",
+		"db.t2:p0":    "table `db`.`t2` isn't partitioned",
+		"db.t3:p0":    "table `db`.`t3` of the partition rule isn't restored",
+		"db.t1":       "should be in the form of",
+		"t1:p0":       "invalid table name",
+		"db.t1:p0,,":  "empty partition name",
+		"db.t1.x:p0":  "invalid table name",
+		"`db.t1:p0":   "should be in the form of",
+		"db.`t1`x:p0": "invalid table name",
+	} {
+		partitions, err := restore.ParsePartitionFilter([]string{rule})
+		if err == nil {
+			_, _, err = restore.FilterPartitions(tables, partitions)
+		}
+		require.ErrorContains(t, err, msg, rule)
+	}
+
+	t1.Info.Partition.Type = model.PartitionTypeHash
+	partitions, err = restore.ParsePartitionFilter([]string{"db.t1:p0"})
+	require.NoError(t, err)
+	_, _, err = restore.FilterPartitions(tables, partitions)
+	require.ErrorContains(t, err, "only the partitions of RANGE or LIST partitioned tables can be selected")
+}
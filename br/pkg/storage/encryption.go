@@ -0,0 +1,507 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pingcap/errors"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+)
+
+// The layout of a file encrypted by the storage returned by WithEncryption:
+//
+//	magic (4 bytes) | version (1 byte) | segment size (4 bytes) |
+//	length of the wrapped data key (2 bytes) | wrapped data key |
+//	segment 0 | segment 1 | ... | segment N
+//
+// Every file is encrypted by its own random data key, which is wrapped by the
+// master key and stored in the header. The content is split into segments of
+// the segment size, each segment is sealed by AES-256-GCM with the nonce made
+// up of the segment index and a flag marking the last segment, so the segments
+// can't be reordered or truncated without being detected. The last segment is
+// always written even if it's empty.
+const (
+	encryptionMagic   = "TENC"
+	encryptionVersion = 1
+	// encryptionFixedHeaderSize is the size of the header before the wrapped
+	// data key.
+	encryptionFixedHeaderSize = len(encryptionMagic) + 1 + 4 + 2
+	encryptionSegmentSize     = 64 * 1024
+	encryptionDataKeySize     = 32
+	encryptionTagSize         = 16
+	encryptionNonceSize       = 12
+)
+
+// MasterKey wraps and unwraps the data keys of the encrypted files.
+type MasterKey interface {
+	// WrapKey encrypts the data key.
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts the data key wrapped by WrapKey.
+	UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+type fileMasterKey struct {
+	aead cipher.AEAD
+}
+
+// NewFileMasterKey creates the master key from a file containing a 256 bits
+// key in hex, which is the same format as the master key file of TiKV.
+func NewFileMasterKey(path string) (MasterKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "failed to read the master key file: %v", err)
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "the master key should be in hex: %v", err)
+	}
+	return NewMemMasterKey(key)
+}
+
+// NewMemMasterKey creates the master key from a 256 bits key.
+func NewMemMasterKey(key []byte) (MasterKey, error) {
+	if len(key) != encryptionDataKeySize {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig,
+			"the master key should be %d bytes, but got %d bytes", encryptionDataKeySize, len(key))
+	}
+	aead, err := newAESGCM(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &fileMasterKey{aead: aead}, nil
+}
+
+// WrapKey implements MasterKey.
+func (k *fileMasterKey) WrapKey(_ context.Context, dataKey []byte) ([]byte, error) {
+	nonce := make([]byte, encryptionNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return k.aead.Seal(nonce, nonce, dataKey, nil), nil
+}
+
+// UnwrapKey implements MasterKey.
+func (k *fileMasterKey) UnwrapKey(_ context.Context, wrapped []byte) ([]byte, error) {
+	if len(wrapped) < encryptionNonceSize {
+		return nil, errors.Annotate(berrors.ErrStorageUnknown, "the wrapped data key is too short")
+	}
+	dataKey, err := k.aead.Open(nil, wrapped[:encryptionNonceSize], wrapped[encryptionNonceSize:], nil)
+	if err != nil {
+		return nil, errors.Annotate(berrors.ErrStorageUnknown, "failed to unwrap the data key, the master key may be wrong")
+	}
+	return dataKey, nil
+}
+
+// KMSClient is the client of a key management service, which encrypts and
+// decrypts small data by the keys kept in the service.
+type KMSClient interface {
+	Encrypt(ctx context.Context, keyID string, plaintext []byte) ([]byte, error)
+	Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error)
+}
+
+type kmsMasterKey struct {
+	client KMSClient
+	keyID  string
+}
+
+// NewKMSMasterKey creates the master key kept in the key management service.
+func NewKMSMasterKey(client KMSClient, keyID string) MasterKey {
+	return &kmsMasterKey{client: client, keyID: keyID}
+}
+
+// WrapKey implements MasterKey.
+func (k *kmsMasterKey) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	wrapped, err := k.client.Encrypt(ctx, k.keyID, dataKey)
+	return wrapped, errors.Trace(err)
+}
+
+// UnwrapKey implements MasterKey.
+func (k *kmsMasterKey) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	dataKey, err := k.client.Decrypt(ctx, k.keyID, wrapped)
+	return dataKey, errors.Trace(err)
+}
+
+func newAESGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	return aead, errors.Trace(err)
+}
+
+func segmentNonce(index int64, last bool) []byte {
+	nonce := make([]byte, encryptionNonceSize)
+	binary.BigEndian.PutUint64(nonce, uint64(index))
+	if last {
+		nonce[encryptionNonceSize-1] = 1
+	}
+	return nonce
+}
+
+// encryptionHeader is the parsed header of an encrypted file.
+type encryptionHeader struct {
+	size        int64
+	segmentSize int64
+	aead        cipher.AEAD
+}
+
+func (h *encryptionHeader) encryptedSegmentSize() int64 {
+	return h.segmentSize + encryptionTagSize
+}
+
+// plainSize returns the size of the content of the encrypted file.
+func (h *encryptionHeader) plainSize(fileSize int64) (int64, error) {
+	n := fileSize - h.size
+	segments := n / h.encryptedSegmentSize()
+	rem := n % h.encryptedSegmentSize()
+	if rem == 0 {
+		if segments == 0 {
+			return 0, errors.Annotate(berrors.ErrStorageUnknown, "the encrypted file is truncated")
+		}
+		return segments * h.segmentSize, nil
+	}
+	if rem < encryptionTagSize {
+		return 0, errors.Annotate(berrors.ErrStorageUnknown, "the encrypted file is truncated")
+	}
+	return segments*h.segmentSize + rem - encryptionTagSize, nil
+}
+
+type withEncryption struct {
+	ExternalStorage
+	masterKey MasterKey
+}
+
+// WithEncryption returns an ExternalStorage which encrypts the files written
+// to the inner storage with per-file data keys wrapped by the master key, and
+// decrypts them when reading.
+//
+// Note that the sizes reported by WalkDir are the sizes of the encrypted
+// files, use GetFileSize of the reader returned by Open to get the real size.
+func WithEncryption(inner ExternalStorage, masterKey MasterKey) ExternalStorage {
+	return &withEncryption{
+		ExternalStorage: inner,
+		masterKey:       masterKey,
+	}
+}
+
+// newHeader generates a data key and returns the encoded header.
+func (w *withEncryption) newHeader(ctx context.Context) ([]byte, cipher.AEAD, error) {
+	dataKey := make([]byte, encryptionDataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	wrapped, err := w.masterKey.WrapKey(ctx, dataKey)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "failed to wrap the data key")
+	}
+	if len(wrapped) > 0xffff {
+		return nil, nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "the wrapped data key is too long: %d bytes", len(wrapped))
+	}
+	aead, err := newAESGCM(dataKey)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	header := make([]byte, 0, encryptionFixedHeaderSize+len(wrapped))
+	header = append(header, encryptionMagic...)
+	header = append(header, encryptionVersion)
+	header = binary.BigEndian.AppendUint32(header, encryptionSegmentSize)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(wrapped)))
+	header = append(header, wrapped...)
+	return header, aead, nil
+}
+
+// readHeader reads and parses the header from the reader.
+func (w *withEncryption) readHeader(ctx context.Context, r io.Reader) (*encryptionHeader, error) {
+	fixed := make([]byte, encryptionFixedHeaderSize)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		return nil, errors.Annotate(berrors.ErrStorageUnknown, "failed to read the header of the encrypted file")
+	}
+	if string(fixed[:len(encryptionMagic)]) != encryptionMagic {
+		return nil, errors.Annotate(berrors.ErrStorageUnknown, "the file is not encrypted")
+	}
+	if version := fixed[len(encryptionMagic)]; version != encryptionVersion {
+		return nil, errors.Annotatef(berrors.ErrStorageUnknown, "unsupported encryption version %d", version)
+	}
+	segmentSize := binary.BigEndian.Uint32(fixed[len(encryptionMagic)+1:])
+	if segmentSize == 0 {
+		return nil, errors.Annotate(berrors.ErrStorageUnknown, "invalid segment size 0 of the encrypted file")
+	}
+	wrapped := make([]byte, binary.BigEndian.Uint16(fixed[len(encryptionMagic)+5:]))
+	if _, err := io.ReadFull(r, wrapped); err != nil {
+		return nil, errors.Annotate(berrors.ErrStorageUnknown, "failed to read the header of the encrypted file")
+	}
+	dataKey, err := w.masterKey.UnwrapKey(ctx, wrapped)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	aead, err := newAESGCM(dataKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &encryptionHeader{
+		size:        int64(encryptionFixedHeaderSize + len(wrapped)),
+		segmentSize: int64(segmentSize),
+		aead:        aead,
+	}, nil
+}
+
+// WriteFile implements ExternalStorage.
+func (w *withEncryption) WriteFile(ctx context.Context, name string, data []byte) error {
+	header, aead, err := w.newHeader(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	segments := max((len(data)+encryptionSegmentSize-1)/encryptionSegmentSize, 1)
+	buf := make([]byte, 0, len(header)+len(data)+segments*encryptionTagSize)
+	buf = append(buf, header...)
+	for i := 0; i < segments; i++ {
+		segment := data[i*encryptionSegmentSize : min((i+1)*encryptionSegmentSize, len(data))]
+		buf = aead.Seal(buf, segmentNonce(int64(i), i == segments-1), segment, nil)
+	}
+	return w.ExternalStorage.WriteFile(ctx, name, buf)
+}
+
+// ReadFile implements ExternalStorage.
+func (w *withEncryption) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	data, err := w.ExternalStorage.ReadFile(ctx, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	header, err := w.readHeader(ctx, bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Annotatef(err, "failed to decrypt %s", name)
+	}
+	plainSize, err := header.plainSize(int64(len(data)))
+	if err != nil {
+		return nil, errors.Annotatef(err, "failed to decrypt %s", name)
+	}
+	plain := make([]byte, 0, plainSize)
+	data = data[header.size:]
+	for i := int64(0); len(data) > 0; i++ {
+		n := min(int64(len(data)), header.encryptedSegmentSize())
+		last := n == int64(len(data))
+		plain, err = header.aead.Open(plain, segmentNonce(i, last), data[:n], nil)
+		if err != nil {
+			return nil, errors.Annotatef(berrors.ErrStorageUnknown, "failed to decrypt %s, the file may be corrupted", name)
+		}
+		data = data[n:]
+	}
+	return plain, nil
+}
+
+// Open implements ExternalStorage.
+func (w *withEncryption) Open(ctx context.Context, path string, o *ReaderOption) (ExternalFileReader, error) {
+	inner, err := w.ExternalStorage.Open(ctx, path, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r, err := w.newDecryptReader(ctx, inner, o)
+	if err != nil {
+		_ = inner.Close()
+		return nil, errors.Annotatef(err, "failed to decrypt %s", path)
+	}
+	return r, nil
+}
+
+func (w *withEncryption) newDecryptReader(ctx context.Context, inner ExternalFileReader, o *ReaderOption) (*decryptReader, error) {
+	header, err := w.readHeader(ctx, inner)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	fileSize, err := inner.GetFileSize()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	plainSize, err := header.plainSize(fileSize)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r := &decryptReader{
+		inner:     inner,
+		innerPos:  header.size,
+		header:    header,
+		plainSize: plainSize,
+		endPos:    plainSize,
+		segment:   -1,
+	}
+	if o != nil {
+		if o.StartOffset != nil {
+			r.pos = *o.StartOffset
+		}
+		if o.EndOffset != nil {
+			r.endPos = min(*o.EndOffset, plainSize)
+		}
+	}
+	return r, nil
+}
+
+// Create implements ExternalStorage.
+func (w *withEncryption) Create(ctx context.Context, name string, o *WriterOption) (ExternalFileWriter, error) {
+	header, aead, err := w.newHeader(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	writer, err := w.ExternalStorage.Create(ctx, name, o)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err = writer.Write(ctx, header); err != nil {
+		_ = writer.Close(ctx)
+		return nil, errors.Trace(err)
+	}
+	return &encryptWriter{
+		writer: writer,
+		aead:   aead,
+		buf:    make([]byte, 0, encryptionSegmentSize),
+	}, nil
+}
+
+// encryptWriter buffers a segment and seals it when the next segment begins,
+// because the last segment is sealed differently.
+type encryptWriter struct {
+	writer ExternalFileWriter
+	aead   cipher.AEAD
+	buf    []byte
+	index  int64
+	sealed []byte
+}
+
+func (w *encryptWriter) flushSegment(ctx context.Context, last bool) error {
+	w.sealed = w.aead.Seal(w.sealed[:0], segmentNonce(w.index, last), w.buf, nil)
+	w.index++
+	w.buf = w.buf[:0]
+	_, err := w.writer.Write(ctx, w.sealed)
+	return errors.Trace(err)
+}
+
+// Write implements ExternalFileWriter.
+func (w *encryptWriter) Write(ctx context.Context, p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		if len(w.buf) == encryptionSegmentSize {
+			if err := w.flushSegment(ctx, false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(w.buf[len(w.buf):encryptionSegmentSize], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+// Close implements ExternalFileWriter.
+func (w *encryptWriter) Close(ctx context.Context) error {
+	if err := w.flushSegment(ctx, true); err != nil {
+		return err
+	}
+	return errors.Trace(w.writer.Close(ctx))
+}
+
+// decryptReader decrypts the content of an encrypted file, it only reads and
+// decrypts the segments covering the read range, so Seek is cheap.
+type decryptReader struct {
+	inner ExternalFileReader
+	// innerPos is the position of the inner reader.
+	innerPos  int64
+	header    *encryptionHeader
+	plainSize int64
+
+	pos    int64
+	endPos int64
+
+	// segment is the index of the decrypted segment in plain, -1 means none.
+	segment int64
+	plain   []byte
+	sealed  []byte
+}
+
+// loadSegment reads and decrypts the segment.
+func (r *decryptReader) loadSegment(index int64) error {
+	offset := r.header.size + index*r.header.encryptedSegmentSize()
+	if r.innerPos != offset {
+		if _, err := r.inner.Seek(offset, io.SeekStart); err != nil {
+			return errors.Trace(err)
+		}
+		r.innerPos = offset
+	}
+	plainLen := min(r.header.segmentSize, r.plainSize-index*r.header.segmentSize)
+	last := (index+1)*r.header.segmentSize >= r.plainSize
+	if cap(r.sealed) < int(plainLen)+encryptionTagSize {
+		r.sealed = make([]byte, 0, r.header.encryptedSegmentSize())
+	}
+	r.sealed = r.sealed[:plainLen+encryptionTagSize]
+	n, err := io.ReadFull(r.inner, r.sealed)
+	r.innerPos += int64(n)
+	if err != nil {
+		return errors.Annotatef(err, "failed to read the segment %d of the encrypted file", index)
+	}
+	r.plain, err = r.header.aead.Open(r.plain[:0], segmentNonce(index, last), r.sealed, nil)
+	if err != nil {
+		r.segment = -1
+		return errors.Annotatef(berrors.ErrStorageUnknown, "failed to decrypt the segment %d, the file may be corrupted", index)
+	}
+	r.segment = index
+	return nil
+}
+
+// Read implements io.Reader.
+func (r *decryptReader) Read(p []byte) (int, error) {
+	if r.pos >= r.endPos {
+		return 0, io.EOF
+	}
+	index := r.pos / r.header.segmentSize
+	if index != r.segment {
+		if err := r.loadSegment(index); err != nil {
+			return 0, err
+		}
+	}
+	start := r.pos - index*r.header.segmentSize
+	end := min(int64(len(r.plain)), r.endPos-index*r.header.segmentSize)
+	n := copy(p, r.plain[start:end])
+	r.pos += int64(n)
+	return n, nil
+}
+
+// Seek implements io.Seeker.
+func (r *decryptReader) Seek(offset int64, whence int) (int64, error) {
+	var realOffset int64
+	switch whence {
+	case io.SeekStart:
+		realOffset = offset
+	case io.SeekCurrent:
+		realOffset = r.pos + offset
+	case io.SeekEnd:
+		realOffset = r.plainSize + offset
+	default:
+		return 0, errors.Annotatef(berrors.ErrStorageUnknown, "Seek: invalid whence '%d'", whence)
+	}
+	if realOffset < 0 {
+		return 0, errors.Annotatef(berrors.ErrInvalidArgument, "Seek: offset is %d, but length of content is only %d", realOffset, r.plainSize)
+	}
+	r.pos = realOffset
+	return r.pos, nil
+}
+
+// Close implements io.Closer.
+func (r *decryptReader) Close() error {
+	return r.inner.Close()
+}
+
+// GetFileSize implements ExternalFileReader, it returns the size of the
+// decrypted content.
+func (r *decryptReader) GetFileSize() (int64, error) {
+	return r.plainSize, nil
+}
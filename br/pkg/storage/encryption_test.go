@@ -0,0 +1,264 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMasterKey(t *testing.T) MasterKey {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	masterKey, err := NewMemMasterKey(key)
+	require.NoError(t, err)
+	return masterKey
+}
+
+func TestEncryptionWriteAndReadFile(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	local, err := NewLocalStorage(dir)
+	require.NoError(t, err)
+	store := WithEncryption(local, newTestMasterKey(t))
+
+	for _, size := range []int{0, 1, encryptionSegmentSize - 1, encryptionSegmentSize, encryptionSegmentSize + 1, 3*encryptionSegmentSize + 5} {
+		name := fmt.Sprintf("file-%d", size)
+		data := make([]byte, size)
+		_, err = rand.Read(data)
+		require.NoError(t, err)
+		require.NoError(t, store.WriteFile(ctx, name, data))
+
+		// the file is encrypted
+		raw, err := local.ReadFile(ctx, name)
+		require.NoError(t, err)
+		require.Equal(t, encryptionMagic, string(raw[:len(encryptionMagic)]))
+		// a short plaintext may appear in the ciphertext by chance
+		if size >= 16 {
+			require.False(t, bytes.Contains(raw, data))
+		}
+
+		got, err := store.ReadFile(ctx, name)
+		require.NoError(t, err)
+		require.Equal(t, data, got)
+
+		// the file written by WriteFile can be read by Open, and vice versa.
+		r, err := store.Open(ctx, name, nil)
+		require.NoError(t, err)
+		fileSize, err := r.GetFileSize()
+		require.NoError(t, err)
+		require.EqualValues(t, size, fileSize)
+		got, err = io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, data, got)
+		require.NoError(t, r.Close())
+
+		w, err := store.Create(ctx, name+".created", nil)
+		require.NoError(t, err)
+		_, err = w.Write(ctx, data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close(ctx))
+		got, err = store.ReadFile(ctx, name+".created")
+		require.NoError(t, err)
+		require.Equal(t, data, got)
+	}
+
+	// a different master key can't decrypt the files.
+	_, err = WithEncryption(local, newTestMasterKey(t)).ReadFile(ctx, "file-1")
+	require.ErrorContains(t, err, "failed to unwrap the data key")
+	// a plaintext file
+	require.NoError(t, local.WriteFile(ctx, "plain", []byte("plaintext file")))
+	_, err = store.ReadFile(ctx, "plain")
+	require.ErrorContains(t, err, "the file is not encrypted")
+
+	// truncating or tampering the file is detected.
+	name := fmt.Sprintf("file-%d", 3*encryptionSegmentSize+5)
+	raw, err := local.ReadFile(ctx, name)
+	require.NoError(t, err)
+	require.NoError(t, local.WriteFile(ctx, "truncated", raw[:len(raw)-5-encryptionTagSize]))
+	_, err = store.ReadFile(ctx, "truncated")
+	require.ErrorContains(t, err, "the file may be corrupted")
+	raw[len(raw)/2] ^= 1
+	require.NoError(t, local.WriteFile(ctx, "tampered", raw))
+	_, err = store.ReadFile(ctx, "tampered")
+	require.ErrorContains(t, err, "the file may be corrupted")
+	r, err := store.Open(ctx, "tampered", nil)
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	require.ErrorContains(t, err, "the file may be corrupted")
+	require.NoError(t, r.Close())
+}
+
+func TestEncryptionSeekAndRangedOpen(t *testing.T) {
+	ctx := context.Background()
+	local, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	store := WithEncryption(local, newTestMasterKey(t))
+
+	data := make([]byte, 5*encryptionSegmentSize+123)
+	_, err = rand.Read(data)
+	require.NoError(t, err)
+	w, err := store.Create(ctx, "data", nil)
+	require.NoError(t, err)
+	for i := 0; i < len(data); i += 1000 {
+		_, err = w.Write(ctx, data[i:min(i+1000, len(data))])
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close(ctx))
+
+	for _, r := range [][2]int64{
+		{0, 10},
+		{10, encryptionSegmentSize + 10},
+		{encryptionSegmentSize, 2 * encryptionSegmentSize},
+		{3*encryptionSegmentSize - 1, int64(len(data))},
+		{int64(len(data)) - 1, int64(len(data))},
+		{100, 100},
+	} {
+		start, end := r[0], r[1]
+		reader, err := store.Open(ctx, "data", &ReaderOption{StartOffset: &start, EndOffset: &end})
+		require.NoError(t, err)
+		got, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.Equal(t, data[start:end], got, "range [%d, %d)", start, end)
+		require.NoError(t, reader.Close())
+	}
+
+	reader, err := store.Open(ctx, "data", nil)
+	require.NoError(t, err)
+	defer reader.Close()
+	buf := make([]byte, 100)
+	for _, offset := range []int64{4 * encryptionSegmentSize, 10, encryptionSegmentSize - 50} {
+		pos, err := reader.Seek(offset, io.SeekStart)
+		require.NoError(t, err)
+		require.Equal(t, offset, pos)
+		_, err = io.ReadFull(reader, buf)
+		require.NoError(t, err)
+		require.Equal(t, data[offset:offset+100], buf)
+	}
+	pos, err := reader.Seek(-50, io.SeekEnd)
+	require.NoError(t, err)
+	require.EqualValues(t, len(data)-50, pos)
+	got, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, data[len(data)-50:], got)
+	_, err = reader.Seek(-1, io.SeekStart)
+	require.Error(t, err)
+}
+
+func TestEncryptionWithCompression(t *testing.T) {
+	ctx := context.Background()
+	local, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	store := WithCompression(WithEncryption(local, newTestMasterKey(t)), Zstd, DecompressConfig{})
+
+	data := bytes.Repeat([]byte("0123456789"), 100000)
+	w, err := store.Create(ctx, "data.zst", nil)
+	require.NoError(t, err)
+	_, err = w.Write(ctx, data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close(ctx))
+
+	r, err := store.Open(ctx, "data.zst", nil)
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+	require.NoError(t, r.Close())
+}
+
+type mockKMSClient struct {
+	keys map[string]MasterKey
+}
+
+func (c *mockKMSClient) Encrypt(ctx context.Context, keyID string, plaintext []byte) ([]byte, error) {
+	key, ok := c.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found", keyID)
+	}
+	return key.WrapKey(ctx, plaintext)
+}
+
+func (c *mockKMSClient) Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	key, ok := c.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found", keyID)
+	}
+	return key.UnwrapKey(ctx, ciphertext)
+}
+
+func TestMasterKeys(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	local, err := NewLocalStorage(filepath.Join(dir, "data"))
+	require.NoError(t, err)
+
+	key := make([]byte, 32)
+	_, err = rand.Read(key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(dir, "master.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(hex.EncodeToString(key)+"\n"), 0o600))
+	fileKey, err := NewFileMasterKey(keyFile)
+	require.NoError(t, err)
+	require.NoError(t, WithEncryption(local, fileKey).WriteFile(ctx, "file", []byte("file master key")))
+	memKey, err := NewMemMasterKey(key)
+	require.NoError(t, err)
+	data, err := WithEncryption(local, memKey).ReadFile(ctx, "file")
+	require.NoError(t, err)
+	require.Equal(t, "file master key", string(data))
+
+	require.NoError(t, os.WriteFile(keyFile, []byte("not hex"), 0o600))
+	_, err = NewFileMasterKey(keyFile)
+	require.ErrorContains(t, err, "the master key should be in hex")
+	_, err = NewMemMasterKey(key[:16])
+	require.ErrorContains(t, err, "the master key should be 32 bytes")
+
+	kms := &mockKMSClient{keys: map[string]MasterKey{"key-1": newTestMasterKey(t)}}
+	store := WithEncryption(local, NewKMSMasterKey(kms, "key-1"))
+	require.NoError(t, store.WriteFile(ctx, "kms", []byte("kms master key")))
+	data, err = store.ReadFile(ctx, "kms")
+	require.NoError(t, err)
+	require.Equal(t, "kms master key", string(data))
+	err = WithEncryption(local, NewKMSMasterKey(kms, "key-2")).WriteFile(ctx, "kms", []byte("kms master key"))
+	require.ErrorContains(t, err, "key key-2 not found")
+}
+
+type failedHeaderStorage struct {
+	ExternalStorage
+	writer *failedHeaderWriter
+}
+
+func (s *failedHeaderStorage) Create(context.Context, string, *WriterOption) (ExternalFileWriter, error) {
+	return s.writer, nil
+}
+
+type failedHeaderWriter struct {
+	closed bool
+}
+
+func (*failedHeaderWriter) Write(context.Context, []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func (w *failedHeaderWriter) Close(context.Context) error {
+	w.closed = true
+	return nil
+}
+
+func TestEncryptionCreateFailed(t *testing.T) {
+	inner := &failedHeaderStorage{writer: &failedHeaderWriter{}}
+	_, err := WithEncryption(inner, newTestMasterKey(t)).Create(context.Background(), "file", nil)
+	require.ErrorContains(t, err, "write failed")
+	// the inner writer is closed when the header can't be written
+	require.True(t, inner.writer.closed)
+}
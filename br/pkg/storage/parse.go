@@ -3,6 +3,7 @@
 package storage
 
 import (
+	"net"
 	"net/url"
 	"path/filepath"
 	"reflect"
@@ -20,6 +21,8 @@ type BackendOptions struct {
 	S3     S3BackendOptions     `json:"s3" toml:"s3"`
 	GCS    GCSBackendOptions    `json:"gcs" toml:"gcs"`
 	Azblob AzblobBackendOptions `json:"azblob" toml:"azblob"`
+	SFTP   SFTPBackendOptions   `json:"sftp" toml:"sftp"`
+	WebDAV WebDAVBackendOptions `json:"webdav" toml:"webdav"`
 }
 
 // ParseRawURL parse raw url to url object.
@@ -127,6 +130,47 @@ func parseBackend(u *url.URL, rawURL string, options *BackendOptions) (*backuppb
 			return nil, errors.Trace(err)
 		}
 		return &backuppb.StorageBackend{Backend: &backuppb.StorageBackend_AzureBlobStorage{AzureBlobStorage: azblob}}, nil
+
+	case "sftp":
+		if u.Hostname() == "" {
+			return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "please specify the host for sftp in %s", rawURL)
+		}
+		endpoint := u.Host
+		if u.Port() == "" {
+			endpoint = net.JoinHostPort(u.Hostname(), sftpDefaultPort)
+		}
+		sftp := &backuppb.CloudDynamic{
+			ProviderName: sftpProviderName,
+			Bucket:       &backuppb.Bucket{Endpoint: endpoint, Prefix: u.Path},
+		}
+		if options == nil {
+			options = &BackendOptions{}
+		}
+		ExtractQueryParameters(u, &options.SFTP)
+		if err := options.SFTP.apply(u, sftp); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &backuppb.StorageBackend{Backend: &backuppb.StorageBackend_CloudDynamic{CloudDynamic: sftp}}, nil
+
+	case "webdav", "webdavs":
+		if u.Host == "" {
+			return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "please specify the host for webdav in %s", rawURL)
+		}
+		scheme := "http"
+		if u.Scheme == "webdavs" {
+			scheme = "https"
+		}
+		prefix := strings.Trim(u.Path, "/")
+		webdav := &backuppb.CloudDynamic{
+			ProviderName: webdavProviderName,
+			Bucket:       &backuppb.Bucket{Endpoint: scheme + "://" + u.Host, Prefix: prefix},
+		}
+		if options == nil {
+			options = &BackendOptions{}
+		}
+		ExtractQueryParameters(u, &options.WebDAV)
+		options.WebDAV.apply(u, webdav)
+		return &backuppb.StorageBackend{Backend: &backuppb.StorageBackend_CloudDynamic{CloudDynamic: webdav}}, nil
 	default:
 		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "storage %s not support yet", u.Scheme)
 	}
@@ -206,6 +250,22 @@ func FormatBackendURL(backend *backuppb.StorageBackend) (u url.URL) {
 		u.Scheme = "azure"
 		u.Host = b.AzureBlobStorage.Bucket
 		u.Path = b.AzureBlobStorage.Prefix
+	case *backuppb.StorageBackend_CloudDynamic:
+		switch b.CloudDynamic.ProviderName {
+		case sftpProviderName:
+			u.Scheme = "sftp"
+			u.Host = b.CloudDynamic.Bucket.Endpoint
+			u.Path = b.CloudDynamic.Bucket.Prefix
+		case webdavProviderName:
+			if endpoint, err := url.Parse(b.CloudDynamic.Bucket.Endpoint); err == nil {
+				u.Scheme = "webdav"
+				if endpoint.Scheme == "https" {
+					u.Scheme = "webdavs"
+				}
+				u.Host = endpoint.Host
+				u.Path = b.CloudDynamic.Bucket.Prefix
+			}
+		}
 	}
 	return
 }
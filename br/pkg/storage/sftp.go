@@ -0,0 +1,670 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/log"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	sftpProviderName = "sftp"
+	sftpDefaultPort  = "22"
+
+	sftpUserAttr           = "user"
+	sftpPasswordAttr       = "password"
+	sftpPrivateKeyFileAttr = "private-key-file"
+	sftpKnownHostsFileAttr = "known-hosts-file"
+
+	sftpModeSymlink uint32 = 0o120000
+	// sftpWriterChunkSize is the size of the data written by one batch of
+	// pipelined WRITE requests.
+	sftpWriterChunkSize = sftpMaxInflight * sftpMaxDataSize
+)
+
+// SFTPBackendOptions is the options for SFTP storage.
+type SFTPBackendOptions struct {
+	User     string `json:"user" toml:"user"`
+	Password string `json:"password" toml:"password"`
+	// PrivateKeyFile is the path of the private key used to authenticate.
+	PrivateKeyFile string `json:"private-key-file" toml:"private-key-file"`
+	// KnownHostsFile is the path of the known_hosts file used to verify the
+	// host key of the server, ~/.ssh/known_hosts is used if it's empty.
+	KnownHostsFile string `json:"known-hosts-file" toml:"known-hosts-file"`
+}
+
+func (options *SFTPBackendOptions) apply(u *url.URL, sftp *backuppb.CloudDynamic) error {
+	if u.User != nil {
+		if options.User == "" {
+			options.User = u.User.Username()
+		}
+		if password, ok := u.User.Password(); ok && options.Password == "" {
+			options.Password = password
+		}
+	}
+	if options.User == "" {
+		return errors.Annotatef(berrors.ErrStorageInvalidConfig, "please specify the user for sftp")
+	}
+	if options.Password == "" && options.PrivateKeyFile == "" {
+		return errors.Annotatef(berrors.ErrStorageInvalidConfig, "please specify the password or the private key file for sftp")
+	}
+	sftp.Attrs = map[string]string{
+		sftpUserAttr:           options.User,
+		sftpPasswordAttr:       options.Password,
+		sftpPrivateKeyFileAttr: options.PrivateKeyFile,
+		sftpKnownHostsFileAttr: options.KnownHostsFile,
+	}
+	return nil
+}
+
+// sftpIdleTimeout is how long the connection to the server is kept after it's
+// no longer used. ExternalStorage has no Close method, so the connection is
+// closed when it's idle, and it's reconnected by the next operation.
+var sftpIdleTimeout = 30 * time.Second
+
+// SFTPStorage is the storage on a remote directory accessed by the SFTP
+// protocol. The operations and the opened files share one connection to the
+// server, which is closed after it has been idle for sftpIdleTimeout.
+type SFTPStorage struct {
+	backend *backuppb.CloudDynamic
+	config  *ssh.ClientConfig
+	// base is the absolute path of the storage directory on the server.
+	base string
+
+	mu        sync.Mutex
+	conn      *sftpConn
+	idleTimer *time.Timer
+}
+
+// sftpConn is an SSH connection running the sftp subsystem.
+type sftpConn struct {
+	ssh    *ssh.Client
+	client *sftpClient
+	// refs is the number of the running operations and the opened files using
+	// the connection.
+	refs int
+}
+
+func (c *sftpConn) close() error {
+	_ = c.client.Close()
+	return errors.Trace(c.ssh.Close())
+}
+
+// NewSFTPStorage connects to the SFTP server and creates the storage.
+func NewSFTPStorage(ctx context.Context, backend *backuppb.CloudDynamic, opts *ExternalStorageOptions) (*SFTPStorage, error) {
+	if backend.Bucket == nil || backend.Bucket.Endpoint == "" {
+		return nil, errors.Annotate(berrors.ErrStorageInvalidConfig, "sftp endpoint not found")
+	}
+	config, err := newSSHClientConfig(backend.Attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &SFTPStorage{backend: backend, config: config, base: path.Clean("/" + backend.Bucket.Prefix)}
+	conn, err := s.acquire(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	err = conn.client.mkdirAll(s.base)
+	s.release(conn)
+	if err != nil {
+		_ = s.Close()
+		return nil, errors.Annotatef(err, "failed to create the directory %s on the sftp server", s.base)
+	}
+	if !opts.SendCredentials {
+		delete(backend.Attrs, sftpPasswordAttr)
+	}
+	return s, nil
+}
+
+// dialSFTP connects to the server and starts the sftp subsystem.
+func dialSFTP(ctx context.Context, endpoint string, config *ssh.ClientConfig) (*sftpConn, error) {
+	var dialer net.Dialer
+	netConn, err := dialer.DialContext(ctx, "tcp", endpoint)
+	if err != nil {
+		return nil, errors.Annotatef(err, "failed to connect to the sftp server %s", endpoint)
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(netConn, endpoint, config)
+	if err != nil {
+		_ = netConn.Close()
+		return nil, errors.Annotatef(err, "failed to connect to the sftp server %s", endpoint)
+	}
+	conn := ssh.NewClient(sshConn, chans, reqs)
+	client, err := newSFTPSession(conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.Trace(err)
+	}
+	return &sftpConn{ssh: conn, client: client}, nil
+}
+
+// acquire returns the connection to the server, it connects to the server if
+// the storage isn't connected or the connection is broken. The connection must
+// be released after use.
+func (s *SFTPStorage) acquire(ctx context.Context) (*sftpConn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.idleTimer != nil {
+		s.idleTimer.Stop()
+		s.idleTimer = nil
+	}
+	if s.conn != nil && s.conn.client.broken() {
+		// the broken connection is closed when it's released by its users.
+		if s.conn.refs == 0 {
+			_ = s.conn.close()
+		}
+		s.conn = nil
+	}
+	if s.conn == nil {
+		conn, err := dialSFTP(ctx, s.backend.Bucket.Endpoint, s.config)
+		if err != nil {
+			return nil, err
+		}
+		s.conn = conn
+	}
+	s.conn.refs++
+	return s.conn, nil
+}
+
+// release releases the connection returned by acquire. The connection is
+// closed after sftpIdleTimeout if it isn't acquired again.
+func (s *SFTPStorage) release(conn *sftpConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn.refs--
+	if conn.refs > 0 {
+		return
+	}
+	if conn != s.conn {
+		_ = conn.close()
+		return
+	}
+	if s.idleTimer == nil {
+		s.idleTimer = time.AfterFunc(sftpIdleTimeout, s.closeIdle)
+	}
+}
+
+func (s *SFTPStorage) closeIdle() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil && s.conn.refs == 0 {
+		_ = s.conn.close()
+		s.conn = nil
+	}
+}
+
+func newSSHClientConfig(attrs map[string]string) (*ssh.ClientConfig, error) {
+	var auths []ssh.AuthMethod
+	if keyFile := attrs[sftpPrivateKeyFileAttr]; keyFile != "" {
+		key, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "failed to read the private key file: %v", err)
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "failed to parse the private key: %v", err)
+		}
+		auths = append(auths, ssh.PublicKeys(signer))
+	}
+	if password := attrs[sftpPasswordAttr]; password != "" {
+		auths = append(auths, ssh.Password(password))
+	}
+
+	knownHostsFile := attrs[sftpKnownHostsFileAttr]
+	if knownHostsFile == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "failed to find the known_hosts file: %v", err)
+		}
+		knownHostsFile = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	hostKeyCallback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "failed to load the known_hosts file: %v", err)
+	}
+	return &ssh.ClientConfig{
+		User:            attrs[sftpUserAttr],
+		Auth:            auths,
+		HostKeyCallback: hostKeyCallback,
+	}, nil
+}
+
+// newSFTPSession starts the sftp subsystem on the SSH connection.
+func newSFTPSession(conn *ssh.Client) (*sftpClient, error) {
+	session, err := conn.NewSession()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := session.StdinPipe()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r, err := session.StdoutPipe()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = session.RequestSubsystem("sftp"); err != nil {
+		return nil, errors.Annotate(err, "failed to start the sftp subsystem")
+	}
+	return newSFTPClient(r, w)
+}
+
+// mkdirAll creates the directory and all its parents if they don't exist.
+func (c *sftpClient) mkdirAll(dir string) error {
+	attrs, err := c.stat(dir)
+	if err == nil {
+		if !attrs.isDir() {
+			return errors.Errorf("%s is not a directory", dir)
+		}
+		return nil
+	}
+	if !isSFTPNotExist(err) {
+		return err
+	}
+	if parent := path.Dir(dir); parent != dir {
+		if err = c.mkdirAll(parent); err != nil {
+			return err
+		}
+	}
+	if err = c.mkdir(dir); err != nil {
+		// the directory may be created concurrently.
+		if attrs, statErr := c.stat(dir); statErr == nil && attrs.isDir() {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *SFTPStorage) fullPath(name string) string {
+	return path.Join(s.base, name)
+}
+
+// openForWrite opens the file for writing, the parent directories are created
+// if they don't exist.
+func openForWrite(client *sftpClient, fullPath string) (string, error) {
+	flags := sftpOpenWrite | sftpOpenCreate | sftpOpenTrunc
+	handle, err := client.open(fullPath, flags)
+	if err == nil || !isSFTPNotExist(err) {
+		return handle, err
+	}
+	dir := path.Dir(fullPath)
+	log.Info("failed to open file, try to mkdir the path", zap.String("path", dir))
+	if err = client.mkdirAll(dir); err != nil {
+		return "", err
+	}
+	return client.open(fullPath, flags)
+}
+
+// DeleteFile deletes the file.
+func (s *SFTPStorage) DeleteFile(ctx context.Context, name string) error {
+	conn, err := s.acquire(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer s.release(conn)
+	return errors.Trace(conn.client.remove(s.fullPath(name)))
+}
+
+// DeleteFiles deletes the files.
+func (s *SFTPStorage) DeleteFiles(ctx context.Context, names []string) error {
+	for _, name := range names {
+		if err := s.DeleteFile(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteFile writes data to a file to storage.
+func (s *SFTPStorage) WriteFile(ctx context.Context, name string, data []byte) error {
+	conn, err := s.acquire(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer s.release(conn)
+	client := conn.client
+	// write into a temporary file and rename it, so the readers won't see a
+	// partially written file.
+	fullPath := s.fullPath(name)
+	tmpPath := fullPath + ".tmp." + uuid.NewString()
+	handle, err := openForWrite(client, tmpPath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = client.writeAt(handle, data, 0)
+	if closeErr := client.close(handle); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = client.rename(tmpPath, fullPath)
+	}
+	if err != nil {
+		_ = client.remove(tmpPath)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ReadFile reads the file from the storage and returns the contents.
+func (s *SFTPStorage) ReadFile(ctx context.Context, name string) (_ []byte, err error) {
+	conn, err := s.acquire(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer s.release(conn)
+	client := conn.client
+	handle, err := client.open(s.fullPath(name), sftpOpenRead)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if closeErr := client.close(handle); err == nil {
+			err = errors.Trace(closeErr)
+		}
+	}()
+	attrs, err := client.fstat(handle)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data := make([]byte, attrs.size)
+	if err = client.readFull(handle, data, 0); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
+
+// FileExists implement ExternalStorage.FileExists.
+func (s *SFTPStorage) FileExists(ctx context.Context, name string) (bool, error) {
+	conn, err := s.acquire(ctx)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	defer s.release(conn)
+	_, err = conn.client.stat(s.fullPath(name))
+	if err != nil {
+		if isSFTPNotExist(err) {
+			return false, nil
+		}
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// WalkDir traverse all the files in a dir.
+//
+// fn is the function called for each regular file visited by WalkDir.
+// The first argument is the file path that can be used in `Open`
+// function; the second argument is the size in byte of the file determined
+// by path.
+func (s *SFTPStorage) WalkDir(ctx context.Context, opt *WalkOption, fn func(string, int64) error) error {
+	if opt == nil {
+		opt = &WalkOption{}
+	}
+	dir := path.Clean(opt.SubDir)
+	objPrefix := opt.ObjPrefix
+	if dir != "." {
+		objPrefix = dir + "/" + objPrefix
+	}
+	conn, err := s.acquire(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer s.release(conn)
+	return s.walkDir(ctx, conn.client, dir, opt.SkipSubDir, objPrefix, fn)
+}
+
+func (s *SFTPStorage) walkDir(
+	ctx context.Context,
+	client *sftpClient,
+	dir string,
+	skipSubDir bool,
+	objPrefix string,
+	fn func(string, int64) error,
+) error {
+	entries, err := client.readDir(s.fullPath(dir))
+	if err != nil {
+		if isSFTPNotExist(err) {
+			// if path not exists, we should return nil to continue.
+			return nil
+		}
+		return errors.Trace(err)
+	}
+	slices.SortFunc(entries, func(a, b sftpDirEntry) int {
+		return strings.Compare(a.name, b.name)
+	})
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return errors.Trace(err)
+		}
+		name := path.Join(dir, entry.name)
+		attrs := entry.attrs
+		if attrs.mode&sftpModeTypeMask == sftpModeSymlink {
+			if attrs, err = client.stat(s.fullPath(name)); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if attrs.isDir() {
+			if skipSubDir {
+				continue
+			}
+			if err := s.walkDir(ctx, client, name, skipSubDir, objPrefix, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if !strings.HasPrefix(name, objPrefix) {
+			continue
+		}
+		if err := fn(name, int64(attrs.size)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// URI returns the base path as an URI with a sftp:// prefix.
+func (s *SFTPStorage) URI() string {
+	return "sftp://" + s.backend.Bucket.Endpoint + s.base
+}
+
+// Open a Reader by file path, path is a relative path to base path. The
+// connection is kept until the reader is closed.
+func (s *SFTPStorage) Open(ctx context.Context, name string, o *ReaderOption) (ExternalFileReader, error) {
+	conn, err := s.acquire(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	handle, err := conn.client.open(s.fullPath(name), sftpOpenRead)
+	if err != nil {
+		s.release(conn)
+		return nil, errors.Trace(err)
+	}
+	attrs, err := conn.client.fstat(handle)
+	if err != nil {
+		_ = conn.client.close(handle)
+		s.release(conn)
+		return nil, errors.Trace(err)
+	}
+	totalSize := int64(attrs.size)
+	pos, endPos := int64(0), totalSize
+	if o != nil {
+		if o.StartOffset != nil {
+			pos = *o.StartOffset
+		}
+		if o.EndOffset != nil {
+			endPos = min(*o.EndOffset, totalSize)
+		}
+	}
+	return &sftpFileReader{
+		storage:   s,
+		conn:      conn,
+		client:    conn.client,
+		handle:    handle,
+		pos:       pos,
+		endPos:    endPos,
+		totalSize: totalSize,
+	}, nil
+}
+
+// Create implements ExternalStorage interface. The connection is kept until
+// the writer is closed.
+func (s *SFTPStorage) Create(ctx context.Context, name string, _ *WriterOption) (ExternalFileWriter, error) {
+	conn, err := s.acquire(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	handle, err := openForWrite(conn.client, s.fullPath(name))
+	if err != nil {
+		s.release(conn)
+		return nil, errors.Trace(err)
+	}
+	uploader := &sftpFileWriter{storage: s, conn: conn, client: conn.client, handle: handle}
+	return newBufferedWriter(uploader, sftpWriterChunkSize, NoCompression), nil
+}
+
+// Rename implements ExternalStorage interface.
+func (s *SFTPStorage) Rename(ctx context.Context, oldFileName, newFileName string) error {
+	conn, err := s.acquire(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer s.release(conn)
+	return errors.Trace(conn.client.rename(s.fullPath(oldFileName), s.fullPath(newFileName)))
+}
+
+// Close closes the connection to the server if it's idle, the connection used
+// by the opened files is closed after they're closed.
+func (s *SFTPStorage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.idleTimer != nil {
+		s.idleTimer.Stop()
+		s.idleTimer = nil
+	}
+	if s.conn == nil || s.conn.refs > 0 {
+		return nil
+	}
+	err := s.conn.close()
+	s.conn = nil
+	return err
+}
+
+type sftpFileReader struct {
+	storage *SFTPStorage
+	conn    *sftpConn
+	client  *sftpClient
+	handle  string
+
+	pos       int64
+	endPos    int64
+	totalSize int64
+}
+
+// Read implement the io.Reader interface.
+func (r *sftpFileReader) Read(p []byte) (int, error) {
+	maxCnt := r.endPos - r.pos
+	if maxCnt <= 0 {
+		return 0, io.EOF
+	}
+	if maxCnt < int64(len(p)) {
+		p = p[:maxCnt]
+	}
+	var (
+		n   int
+		err error
+	)
+	if len(p) > sftpMaxDataSize {
+		// read the large buffer with pipelined requests.
+		err = r.client.readFull(r.handle, p, r.pos)
+		if err == nil {
+			n = len(p)
+		}
+	} else {
+		n, err = r.client.readAt(r.handle, p, r.pos)
+	}
+	r.pos += int64(n)
+	if err != nil && err != io.EOF {
+		return n, errors.Annotatef(err, "failed to read data from sftp, data info: pos='%d', count='%d'", r.pos, len(p))
+	}
+	return n, err
+}
+
+// Seek implement the io.Seeker interface.
+func (r *sftpFileReader) Seek(offset int64, whence int) (int64, error) {
+	var realOffset int64
+	switch whence {
+	case io.SeekStart:
+		realOffset = offset
+	case io.SeekCurrent:
+		realOffset = r.pos + offset
+	case io.SeekEnd:
+		realOffset = r.totalSize + offset
+	default:
+		return 0, errors.Annotatef(berrors.ErrStorageUnknown, "Seek: invalid whence '%d'", whence)
+	}
+	if realOffset < 0 {
+		return 0, errors.Annotatef(berrors.ErrInvalidArgument, "Seek: offset is %d, but length of content is only %d", realOffset, r.totalSize)
+	}
+	r.pos = realOffset
+	return r.pos, nil
+}
+
+// Close implement the io.Closer interface.
+func (r *sftpFileReader) Close() error {
+	if r.conn == nil {
+		return nil
+	}
+	err := r.client.close(r.handle)
+	r.storage.release(r.conn)
+	r.conn = nil
+	return errors.Trace(err)
+}
+
+// GetFileSize implement the ExternalFileReader interface.
+func (r *sftpFileReader) GetFileSize() (int64, error) {
+	return r.totalSize, nil
+}
+
+type sftpFileWriter struct {
+	storage *SFTPStorage
+	conn    *sftpConn
+	client  *sftpClient
+	handle  string
+	offset  int64
+}
+
+func (w *sftpFileWriter) Write(_ context.Context, data []byte) (int, error) {
+	if err := w.client.writeAt(w.handle, data, w.offset); err != nil {
+		return 0, errors.Annotate(err, "failed to write data to sftp")
+	}
+	w.offset += int64(len(data))
+	return len(data), nil
+}
+
+func (w *sftpFileWriter) Close(_ context.Context) error {
+	if w.conn == nil {
+		return nil
+	}
+	err := w.client.close(w.handle)
+	w.storage.release(w.conn)
+	w.conn = nil
+	return errors.Trace(err)
+}
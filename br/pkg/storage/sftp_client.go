@@ -0,0 +1,613 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"encoding/binary"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/pingcap/errors"
+)
+
+// The packet types and constants of the SFTP protocol version 3, see
+// https://datatracker.ietf.org/doc/html/draft-ietf-secsh-filexfer-02.
+const (
+	sftpProtocolVersion = 3
+
+	sftpPacketInit     byte = 1
+	sftpPacketVersion  byte = 2
+	sftpPacketOpen     byte = 3
+	sftpPacketClose    byte = 4
+	sftpPacketRead     byte = 5
+	sftpPacketWrite    byte = 6
+	sftpPacketFstat    byte = 8
+	sftpPacketOpenDir  byte = 11
+	sftpPacketReadDir  byte = 12
+	sftpPacketRemove   byte = 13
+	sftpPacketMkdir    byte = 14
+	sftpPacketStat     byte = 17
+	sftpPacketRename   byte = 18
+	sftpPacketStatus   byte = 101
+	sftpPacketHandle   byte = 102
+	sftpPacketData     byte = 103
+	sftpPacketName     byte = 104
+	sftpPacketAttrs    byte = 105
+	sftpPacketExtended byte = 200
+
+	sftpOpenRead   uint32 = 0x01
+	sftpOpenWrite  uint32 = 0x02
+	sftpOpenCreate uint32 = 0x08
+	sftpOpenTrunc  uint32 = 0x10
+
+	sftpAttrSize        uint32 = 0x01
+	sftpAttrUIDGID      uint32 = 0x02
+	sftpAttrPermissions uint32 = 0x04
+	sftpAttrACModTime   uint32 = 0x08
+	sftpAttrExtended    uint32 = 0x80000000
+
+	sftpStatusOK         uint32 = 0
+	sftpStatusEOF        uint32 = 1
+	sftpStatusNoSuchFile uint32 = 2
+
+	sftpModeTypeMask uint32 = 0o170000
+	sftpModeDir      uint32 = 0o040000
+
+	// sftpPosixRename is the OpenSSH extension of RENAME which overwrites the
+	// target file atomically, the standard RENAME fails if the target exists.
+	sftpPosixRename = "posix-rename@openssh.com"
+
+	// sftpMaxDataSize is the max data size of a READ or WRITE request, the
+	// servers are only required to support packets up to 34000 bytes.
+	sftpMaxDataSize = 32 * 1024
+	// sftpMaxPacketSize is the max size of the packets we accept.
+	sftpMaxPacketSize = 256 * 1024
+	// sftpMaxInflight is the max number of pipelined READ or WRITE requests
+	// of a file.
+	sftpMaxInflight = 64
+)
+
+// sftpStatusError is the error returned by the SFTP server.
+type sftpStatusError struct {
+	code uint32
+	msg  string
+}
+
+func (e *sftpStatusError) Error() string {
+	return fmt.Sprintf("sftp: %s (status code %d)", e.msg, e.code)
+}
+
+// isSFTPNotExist returns whether the error means the file doesn't exist.
+func isSFTPNotExist(err error) bool {
+	var statusErr *sftpStatusError
+	return goerrors.As(err, &statusErr) && statusErr.code == sftpStatusNoSuchFile
+}
+
+type sftpPacket struct {
+	typ byte
+	// data is the payload after the request id.
+	data []byte
+}
+
+// sftpEncoder encodes the fields of an SFTP packet.
+type sftpEncoder []byte
+
+func (e sftpEncoder) u32(v uint32) sftpEncoder {
+	return binary.BigEndian.AppendUint32(e, v)
+}
+
+func (e sftpEncoder) u64(v uint64) sftpEncoder {
+	return binary.BigEndian.AppendUint64(e, v)
+}
+
+func (e sftpEncoder) str(s string) sftpEncoder {
+	return append(e.u32(uint32(len(s))), s...)
+}
+
+func (e sftpEncoder) bytes(b []byte) sftpEncoder {
+	return append(e.u32(uint32(len(b))), b...)
+}
+
+// sftpDecoder decodes the fields of an SFTP packet, the first error is kept
+// and the later calls are no-op.
+type sftpDecoder struct {
+	buf []byte
+	err error
+}
+
+func (d *sftpDecoder) u32() uint32 {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.buf) < 4 {
+		d.err = errors.New("sftp: packet too short")
+		return 0
+	}
+	v := binary.BigEndian.Uint32(d.buf)
+	d.buf = d.buf[4:]
+	return v
+}
+
+func (d *sftpDecoder) u64() uint64 {
+	hi := d.u32()
+	lo := d.u32()
+	return uint64(hi)<<32 | uint64(lo)
+}
+
+func (d *sftpDecoder) bytes() []byte {
+	n := d.u32()
+	if d.err != nil {
+		return nil
+	}
+	if uint32(len(d.buf)) < n {
+		d.err = errors.New("sftp: packet too short")
+		return nil
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *sftpDecoder) str() string {
+	return string(d.bytes())
+}
+
+// sftpAttrs is the file attributes we care about.
+type sftpAttrs struct {
+	size uint64
+	mode uint32
+}
+
+func (a sftpAttrs) isDir() bool {
+	return a.mode&sftpModeTypeMask == sftpModeDir
+}
+
+func (d *sftpDecoder) attrs() sftpAttrs {
+	var a sftpAttrs
+	flags := d.u32()
+	if flags&sftpAttrSize != 0 {
+		a.size = d.u64()
+	}
+	if flags&sftpAttrUIDGID != 0 {
+		d.u32()
+		d.u32()
+	}
+	if flags&sftpAttrPermissions != 0 {
+		a.mode = d.u32()
+	}
+	if flags&sftpAttrACModTime != 0 {
+		d.u32()
+		d.u32()
+	}
+	if flags&sftpAttrExtended != 0 {
+		n := d.u32()
+		for i := uint32(0); i < n && d.err == nil; i++ {
+			d.str()
+			d.str()
+		}
+	}
+	return a
+}
+
+func readSFTPPacket(r io.Reader) (byte, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	length := binary.BigEndian.Uint32(header[:4])
+	if length < 1 || length > sftpMaxPacketSize {
+		return 0, nil, errors.Errorf("sftp: invalid packet length %d", length)
+	}
+	data := make([]byte, length-1)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	return header[4], data, nil
+}
+
+func writeSFTPPacket(w io.Writer, typ byte, payload []byte) error {
+	pkt := make(sftpEncoder, 0, 5+len(payload))
+	pkt = append(pkt.u32(uint32(1+len(payload))), typ)
+	pkt = append(pkt, payload...)
+	_, err := w.Write(pkt)
+	return errors.Trace(err)
+}
+
+// sftpClient is a client of the SFTP protocol version 3. The requests can be
+// pipelined, the responses are dispatched by the request ids.
+type sftpClient struct {
+	w          io.WriteCloser
+	writeMu    sync.Mutex
+	extensions map[string]string
+
+	mu      sync.Mutex
+	nextID  uint32
+	pending map[uint32]chan sftpPacket
+	// err is set when the connection is broken.
+	err error
+}
+
+// newSFTPClient negotiates the protocol version with the server through the
+// reader and writer, which are usually the stdout and stdin of the sftp
+// subsystem of an SSH session.
+func newSFTPClient(r io.Reader, w io.WriteCloser) (*sftpClient, error) {
+	if err := writeSFTPPacket(w, sftpPacketInit, sftpEncoder(nil).u32(sftpProtocolVersion)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	typ, data, err := readSFTPPacket(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if typ != sftpPacketVersion {
+		return nil, errors.Errorf("sftp: expect the VERSION packet, got packet type %d", typ)
+	}
+	d := sftpDecoder{buf: data}
+	if version := d.u32(); version != sftpProtocolVersion {
+		return nil, errors.Errorf("sftp: unsupported protocol version %d", version)
+	}
+	c := &sftpClient{
+		w:          w,
+		extensions: make(map[string]string),
+		pending:    make(map[uint32]chan sftpPacket),
+	}
+	for len(d.buf) > 0 && d.err == nil {
+		name := d.str()
+		c.extensions[name] = d.str()
+	}
+	if d.err != nil {
+		return nil, errors.Trace(d.err)
+	}
+	go c.recvLoop(r)
+	return c, nil
+}
+
+func (c *sftpClient) recvLoop(r io.Reader) {
+	for {
+		typ, data, err := readSFTPPacket(r)
+		if err == nil && len(data) < 4 {
+			err = errors.New("sftp: packet too short")
+		}
+		if err != nil {
+			c.fail(err)
+			return
+		}
+		id := binary.BigEndian.Uint32(data)
+		c.mu.Lock()
+		ch, ok := c.pending[id]
+		delete(c.pending, id)
+		c.mu.Unlock()
+		if ok {
+			ch <- sftpPacket{typ: typ, data: data[4:]}
+		}
+	}
+}
+
+func (c *sftpClient) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil {
+		c.err = errors.Annotate(err, "sftp connection is broken")
+	}
+	for id, ch := range c.pending {
+		close(ch)
+		delete(c.pending, id)
+	}
+}
+
+// send sends a request and returns the channel to receive the response.
+func (c *sftpClient) send(typ byte, payload sftpEncoder) (<-chan sftpPacket, error) {
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return nil, c.err
+	}
+	c.nextID++
+	id := c.nextID
+	ch := make(chan sftpPacket, 1)
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	c.writeMu.Lock()
+	err := writeSFTPPacket(c.w, typ, append(sftpEncoder(nil).u32(id), payload...))
+	c.writeMu.Unlock()
+	if err != nil {
+		c.fail(err)
+		return nil, errors.Trace(err)
+	}
+	return ch, nil
+}
+
+func (c *sftpClient) wait(ch <-chan sftpPacket) (sftpPacket, error) {
+	pkt, ok := <-ch
+	if !ok {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return pkt, c.err
+	}
+	return pkt, nil
+}
+
+func (c *sftpClient) call(typ byte, payload sftpEncoder) (sftpPacket, error) {
+	ch, err := c.send(typ, payload)
+	if err != nil {
+		return sftpPacket{}, err
+	}
+	return c.wait(ch)
+}
+
+// statusError converts a STATUS response to error, it returns nil for the
+// status OK.
+func statusError(pkt sftpPacket) error {
+	if pkt.typ != sftpPacketStatus {
+		return errors.Errorf("sftp: unexpected packet type %d", pkt.typ)
+	}
+	d := sftpDecoder{buf: pkt.data}
+	code := d.u32()
+	msg := d.str()
+	if d.err != nil {
+		return errors.Trace(d.err)
+	}
+	if code == sftpStatusOK {
+		return nil
+	}
+	return &sftpStatusError{code: code, msg: msg}
+}
+
+func (c *sftpClient) callStatus(typ byte, payload sftpEncoder) error {
+	pkt, err := c.call(typ, payload)
+	if err != nil {
+		return err
+	}
+	return statusError(pkt)
+}
+
+func (c *sftpClient) callHandle(typ byte, payload sftpEncoder) (string, error) {
+	pkt, err := c.call(typ, payload)
+	if err != nil {
+		return "", err
+	}
+	if pkt.typ != sftpPacketHandle {
+		return "", statusError(pkt)
+	}
+	d := sftpDecoder{buf: pkt.data}
+	handle := d.str()
+	return handle, errors.Trace(d.err)
+}
+
+func (c *sftpClient) callAttrs(typ byte, payload sftpEncoder) (sftpAttrs, error) {
+	pkt, err := c.call(typ, payload)
+	if err != nil {
+		return sftpAttrs{}, err
+	}
+	if pkt.typ != sftpPacketAttrs {
+		return sftpAttrs{}, statusError(pkt)
+	}
+	d := sftpDecoder{buf: pkt.data}
+	attrs := d.attrs()
+	return attrs, errors.Trace(d.err)
+}
+
+func (c *sftpClient) open(path string, flags uint32) (string, error) {
+	// the empty attributes
+	return c.callHandle(sftpPacketOpen, sftpEncoder(nil).str(path).u32(flags).u32(0))
+}
+
+func (c *sftpClient) close(handle string) error {
+	return c.callStatus(sftpPacketClose, sftpEncoder(nil).str(handle))
+}
+
+func (c *sftpClient) stat(path string) (sftpAttrs, error) {
+	return c.callAttrs(sftpPacketStat, sftpEncoder(nil).str(path))
+}
+
+func (c *sftpClient) fstat(handle string) (sftpAttrs, error) {
+	return c.callAttrs(sftpPacketFstat, sftpEncoder(nil).str(handle))
+}
+
+func (c *sftpClient) remove(path string) error {
+	return c.callStatus(sftpPacketRemove, sftpEncoder(nil).str(path))
+}
+
+func (c *sftpClient) mkdir(path string) error {
+	return c.callStatus(sftpPacketMkdir, sftpEncoder(nil).str(path).u32(0))
+}
+
+// rename renames the file and overwrites the target if it exists.
+func (c *sftpClient) rename(oldPath, newPath string) error {
+	if _, ok := c.extensions[sftpPosixRename]; ok {
+		return c.callStatus(sftpPacketExtended, sftpEncoder(nil).str(sftpPosixRename).str(oldPath).str(newPath))
+	}
+	err := c.callStatus(sftpPacketRename, sftpEncoder(nil).str(oldPath).str(newPath))
+	if err == nil {
+		return nil
+	}
+	// the server may reject overwriting the target, it's not atomic but the
+	// best we can do.
+	if _, statErr := c.stat(newPath); statErr != nil {
+		return err
+	}
+	if err = c.remove(newPath); err != nil {
+		return err
+	}
+	return c.callStatus(sftpPacketRename, sftpEncoder(nil).str(oldPath).str(newPath))
+}
+
+// sftpDirEntry is an entry of a directory.
+type sftpDirEntry struct {
+	name  string
+	attrs sftpAttrs
+}
+
+func (c *sftpClient) readDir(path string) (_ []sftpDirEntry, err error) {
+	handle, err := c.callHandle(sftpPacketOpenDir, sftpEncoder(nil).str(path))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := c.close(handle); err == nil {
+			err = closeErr
+		}
+	}()
+	var entries []sftpDirEntry
+	for {
+		pkt, err := c.call(sftpPacketReadDir, sftpEncoder(nil).str(handle))
+		if err != nil {
+			return nil, err
+		}
+		if pkt.typ != sftpPacketName {
+			err = statusError(pkt)
+			var statusErr *sftpStatusError
+			if goerrors.As(err, &statusErr) && statusErr.code == sftpStatusEOF {
+				return entries, nil
+			}
+			return nil, err
+		}
+		d := sftpDecoder{buf: pkt.data}
+		count := d.u32()
+		for i := uint32(0); i < count && d.err == nil; i++ {
+			name := d.str()
+			// the long name like `ls -l`
+			d.str()
+			attrs := d.attrs()
+			if name != "." && name != ".." {
+				entries = append(entries, sftpDirEntry{name: name, attrs: attrs})
+			}
+		}
+		if d.err != nil {
+			return nil, errors.Trace(d.err)
+		}
+	}
+}
+
+// readAt reads up to len(p) bytes at the offset, it returns io.EOF if the
+// offset is at the end of the file.
+func (c *sftpClient) readAt(handle string, p []byte, offset int64) (int, error) {
+	if len(p) > sftpMaxDataSize {
+		p = p[:sftpMaxDataSize]
+	}
+	pkt, err := c.call(sftpPacketRead, sftpEncoder(nil).str(handle).u64(uint64(offset)).u32(uint32(len(p))))
+	if err != nil {
+		return 0, err
+	}
+	return copyReadResponse(pkt, p)
+}
+
+func copyReadResponse(pkt sftpPacket, p []byte) (int, error) {
+	if pkt.typ != sftpPacketData {
+		err := statusError(pkt)
+		var statusErr *sftpStatusError
+		if goerrors.As(err, &statusErr) && statusErr.code == sftpStatusEOF {
+			return 0, io.EOF
+		}
+		if err == nil {
+			err = errors.New("sftp: unexpected status OK of READ")
+		}
+		return 0, err
+	}
+	d := sftpDecoder{buf: pkt.data}
+	data := d.bytes()
+	if d.err != nil {
+		return 0, errors.Trace(d.err)
+	}
+	if len(data) > len(p) {
+		return 0, errors.Errorf("sftp: read %d bytes, more than requested %d bytes", len(data), len(p))
+	}
+	return copy(p, data), nil
+}
+
+// readFull reads the whole content in [offset, offset+len(p)) with pipelined
+// requests, it returns io.ErrUnexpectedEOF if the file is shorter.
+func (c *sftpClient) readFull(handle string, p []byte, offset int64) error {
+	type inflight struct {
+		ch     <-chan sftpPacket
+		buf    []byte
+		offset int64
+	}
+	queue := make([]inflight, 0, sftpMaxInflight)
+	sent := 0
+	for sent < len(p) || len(queue) > 0 {
+		for sent < len(p) && len(queue) < sftpMaxInflight {
+			size := min(len(p)-sent, sftpMaxDataSize)
+			off := offset + int64(sent)
+			ch, err := c.send(sftpPacketRead, sftpEncoder(nil).str(handle).u64(uint64(off)).u32(uint32(size)))
+			if err != nil {
+				return err
+			}
+			queue = append(queue, inflight{ch: ch, buf: p[sent : sent+size], offset: off})
+			sent += size
+		}
+		req := queue[0]
+		queue = queue[1:]
+		pkt, err := c.wait(req.ch)
+		if err != nil {
+			return err
+		}
+		n, err := copyReadResponse(pkt, req.buf)
+		// the server may return less data than requested, read the rest
+		// synchronously.
+		for err == nil && n < len(req.buf) {
+			var m int
+			m, err = c.readAt(handle, req.buf[n:], req.offset+int64(n))
+			n += m
+		}
+		if err != nil {
+			for _, q := range queue {
+				_, _ = c.wait(q.ch)
+			}
+			if errors.Cause(err) == io.EOF {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// writeAt writes the data at the offset with pipelined requests.
+func (c *sftpClient) writeAt(handle string, data []byte, offset int64) error {
+	queue := make([]<-chan sftpPacket, 0, sftpMaxInflight)
+	var firstErr error
+	waitOne := func() {
+		pkt, err := c.wait(queue[0])
+		queue = queue[1:]
+		if err == nil {
+			err = statusError(pkt)
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	for len(data) > 0 && firstErr == nil {
+		if len(queue) == sftpMaxInflight {
+			waitOne()
+			continue
+		}
+		size := min(len(data), sftpMaxDataSize)
+		ch, err := c.send(sftpPacketWrite, sftpEncoder(nil).str(handle).u64(uint64(offset)).bytes(data[:size]))
+		if err != nil {
+			firstErr = err
+			break
+		}
+		queue = append(queue, ch)
+		data = data[size:]
+		offset += int64(size)
+	}
+	for len(queue) > 0 {
+		waitOne()
+	}
+	return firstErr
+}
+
+// broken returns whether the connection is broken.
+func (c *sftpClient) broken() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err != nil
+}
+
+// Close closes the connection.
+func (c *sftpClient) Close() error {
+	return errors.Trace(c.w.Close())
+}
@@ -0,0 +1,490 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// testSFTPServer is a minimal SFTP server serving the files of a local
+// directory, the paths sent by the client are relative to the directory.
+type testSFTPServer struct {
+	root     string
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+func startTestSFTPServer(t *testing.T, root, password string) (addr, knownHostsFile string) {
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	require.NoError(t, err)
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(_ ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if string(pass) != password {
+				return nil, fmt.Errorf("wrong password")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &testSFTPServer{root: root, listener: listener}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.serveConn(conn, config)
+			}()
+		}
+	}()
+	t.Cleanup(func() {
+		_ = listener.Close()
+		s.wg.Wait()
+	})
+
+	addr = listener.Addr().String()
+	knownHostsFile = filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{addr}, signer.PublicKey())
+	require.NoError(t, os.WriteFile(knownHostsFile, []byte(line+"\n"), 0o600))
+	return addr, knownHostsFile
+}
+
+func (s *testSFTPServer) serveConn(conn net.Conn, config *ssh.ServerConfig) {
+	sshConn, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(reqs)
+	for newChan := range chans {
+		if newChan.ChannelType() != "session" {
+			_ = newChan.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		ch, reqs, err := newChan.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			for req := range reqs {
+				// the payload of the subsystem request is the subsystem name.
+				ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+				_ = req.Reply(ok, nil)
+				if ok {
+					s.serveSFTP(ch)
+					_ = ch.Close()
+				}
+			}
+		}()
+	}
+}
+
+func (s *testSFTPServer) serveSFTP(rw io.ReadWriter) {
+	typ, _, err := readSFTPPacket(rw)
+	if err != nil || typ != sftpPacketInit {
+		return
+	}
+	version := sftpEncoder(nil).u32(sftpProtocolVersion).str(sftpPosixRename).str("1")
+	if writeSFTPPacket(rw, sftpPacketVersion, version) != nil {
+		return
+	}
+	files := make(map[string]*os.File)
+	dirs := make(map[string][]fs.DirEntry)
+	nextHandle := 0
+	newHandle := func() string {
+		nextHandle++
+		return strconv.Itoa(nextHandle)
+	}
+	defer func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}()
+
+	for {
+		typ, data, err := readSFTPPacket(rw)
+		if err != nil {
+			return
+		}
+		d := sftpDecoder{buf: data}
+		id := d.u32()
+		reply := sftpEncoder(nil).u32(id)
+		status := func(err error) (byte, sftpEncoder) {
+			code := sftpStatusOK
+			switch {
+			case err == io.EOF:
+				code = sftpStatusEOF
+			case os.IsNotExist(err):
+				code = sftpStatusNoSuchFile
+			case err != nil:
+				// SSH_FX_FAILURE
+				code = 4
+			}
+			msg := ""
+			if err != nil {
+				msg = err.Error()
+			}
+			return sftpPacketStatus, reply.u32(code).str(msg).str("")
+		}
+		attrs := func(info fs.FileInfo) sftpEncoder {
+			mode := uint32(info.Mode().Perm()) | 0o100000
+			if info.IsDir() {
+				mode = uint32(info.Mode().Perm()) | sftpModeDir
+			}
+			return sftpEncoder(nil).u32(sftpAttrSize | sftpAttrPermissions).u64(uint64(info.Size())).u32(mode)
+		}
+		localPath := func(p string) string {
+			return filepath.Join(s.root, filepath.FromSlash(p))
+		}
+
+		var respType byte
+		var resp sftpEncoder
+		switch typ {
+		case sftpPacketOpen:
+			p := d.str()
+			pflags := d.u32()
+			flags := os.O_RDONLY
+			if pflags&sftpOpenWrite != 0 {
+				flags = os.O_WRONLY
+			}
+			if pflags&sftpOpenCreate != 0 {
+				flags |= os.O_CREATE
+			}
+			if pflags&sftpOpenTrunc != 0 {
+				flags |= os.O_TRUNC
+			}
+			f, err := os.OpenFile(localPath(p), flags, 0o644)
+			if err != nil {
+				respType, resp = status(err)
+				break
+			}
+			h := newHandle()
+			files[h] = f
+			respType, resp = sftpPacketHandle, reply.str(h)
+		case sftpPacketClose:
+			h := d.str()
+			if f, ok := files[h]; ok {
+				delete(files, h)
+				respType, resp = status(f.Close())
+			} else {
+				delete(dirs, h)
+				respType, resp = status(nil)
+			}
+		case sftpPacketRead:
+			h := d.str()
+			offset := d.u64()
+			length := d.u32()
+			buf := make([]byte, length)
+			n, err := files[h].ReadAt(buf, int64(offset))
+			if n > 0 {
+				respType, resp = sftpPacketData, reply.bytes(buf[:n])
+			} else {
+				respType, resp = status(err)
+			}
+		case sftpPacketWrite:
+			h := d.str()
+			offset := d.u64()
+			_, err := files[h].WriteAt(d.bytes(), int64(offset))
+			respType, resp = status(err)
+		case sftpPacketFstat:
+			info, err := files[d.str()].Stat()
+			if err != nil {
+				respType, resp = status(err)
+				break
+			}
+			respType, resp = sftpPacketAttrs, append(reply, attrs(info)...)
+		case sftpPacketStat:
+			info, err := os.Stat(localPath(d.str()))
+			if err != nil {
+				respType, resp = status(err)
+				break
+			}
+			respType, resp = sftpPacketAttrs, append(reply, attrs(info)...)
+		case sftpPacketOpenDir:
+			entries, err := os.ReadDir(localPath(d.str()))
+			if err != nil {
+				respType, resp = status(err)
+				break
+			}
+			h := newHandle()
+			dirs[h] = entries
+			respType, resp = sftpPacketHandle, reply.str(h)
+		case sftpPacketReadDir:
+			h := d.str()
+			entries := dirs[h]
+			if len(entries) == 0 {
+				respType, resp = status(io.EOF)
+				break
+			}
+			// return the entries in two batches to test the client.
+			batch := entries[:(len(entries)+1)/2]
+			dirs[h] = entries[len(batch):]
+			resp = reply.u32(uint32(len(batch)))
+			for _, entry := range batch {
+				info, err := entry.Info()
+				if err != nil {
+					break
+				}
+				resp = append(resp.str(entry.Name()).str(entry.Name()), attrs(info)...)
+			}
+			respType = sftpPacketName
+		case sftpPacketRemove:
+			respType, resp = status(os.Remove(localPath(d.str())))
+		case sftpPacketMkdir:
+			respType, resp = status(os.Mkdir(localPath(d.str()), 0o755))
+		case sftpPacketRename:
+			oldPath, newPath := localPath(d.str()), localPath(d.str())
+			if _, err := os.Stat(newPath); err == nil {
+				respType, resp = status(fmt.Errorf("%s already exists", newPath))
+				break
+			}
+			respType, resp = status(os.Rename(oldPath, newPath))
+		case sftpPacketExtended:
+			if d.str() != sftpPosixRename {
+				respType, resp = status(fmt.Errorf("unsupported extension"))
+				break
+			}
+			respType, resp = status(os.Rename(localPath(d.str()), localPath(d.str())))
+		default:
+			respType, resp = status(fmt.Errorf("unsupported packet type %d", typ))
+		}
+		if writeSFTPPacket(rw, respType, resp) != nil {
+			return
+		}
+	}
+}
+
+func TestSFTPStorage(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	addr, knownHostsFile := startTestSFTPServer(t, root, "passwd")
+
+	backend, err := ParseBackend("sftp://root:wrong@"+addr+"/backup?known-hosts-file="+knownHostsFile, nil)
+	require.NoError(t, err)
+	_, err = New(ctx, backend, &ExternalStorageOptions{})
+	require.ErrorContains(t, err, "unable to authenticate")
+
+	backend, err = ParseBackend("sftp://root:passwd@"+addr+"/backup?known-hosts-file="+knownHostsFile, nil)
+	require.NoError(t, err)
+	store, err := New(ctx, backend, &ExternalStorageOptions{})
+	require.NoError(t, err)
+	sftpStore := store.(*SFTPStorage)
+	defer sftpStore.Close()
+	require.Equal(t, "sftp://"+addr+"/backup", store.URI())
+	// the password isn't sent downstream.
+	require.Empty(t, backend.GetCloudDynamic().Attrs[sftpPasswordAttr])
+	testRemoteFileStorage(t, store)
+
+	// the files are stored in the directory.
+	data, err := os.ReadFile(filepath.Join(root, "backup", "dir", "a.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "aaa", string(data))
+
+	// a large file is read and written with pipelined requests.
+	large := make([]byte, 3*sftpWriterChunkSize+100)
+	_, err = rand.Read(large)
+	require.NoError(t, err)
+	w, err := store.Create(ctx, "large", nil)
+	require.NoError(t, err)
+	for i := 0; i < len(large); i += 1000 {
+		_, err = w.Write(ctx, large[i:min(i+1000, len(large))])
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close(ctx))
+	got, err := store.ReadFile(ctx, "large")
+	require.NoError(t, err)
+	require.Equal(t, large, got)
+	r, err := store.Open(ctx, "large", nil)
+	require.NoError(t, err)
+	_, err = r.Seek(100, io.SeekStart)
+	require.NoError(t, err)
+	got, err = io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, large[100:], got)
+	require.NoError(t, r.Close())
+}
+
+func TestSFTPStorageReconnect(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	addr, knownHostsFile := startTestSFTPServer(t, root, "passwd")
+	idleTimeout := sftpIdleTimeout
+	sftpIdleTimeout = 50 * time.Millisecond
+	defer func() {
+		sftpIdleTimeout = idleTimeout
+	}()
+
+	backend, err := ParseBackend("sftp://root:passwd@"+addr+"/backup?known-hosts-file="+knownHostsFile, nil)
+	require.NoError(t, err)
+	store, err := New(ctx, backend, &ExternalStorageOptions{})
+	require.NoError(t, err)
+	sftpStore := store.(*SFTPStorage)
+	defer sftpStore.Close()
+	connected := func() bool {
+		sftpStore.mu.Lock()
+		defer sftpStore.mu.Unlock()
+		return sftpStore.conn != nil
+	}
+	require.NoError(t, store.WriteFile(ctx, "a.txt", []byte("aaa")))
+
+	// the connection is kept while the file is opened.
+	r, err := store.Open(ctx, "a.txt", nil)
+	require.NoError(t, err)
+	time.Sleep(4 * sftpIdleTimeout)
+	require.True(t, connected())
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "aaa", string(data))
+	require.NoError(t, r.Close())
+	require.NoError(t, r.Close())
+
+	// the idle connection is closed, and the next operation reconnects.
+	require.Eventually(t, func() bool {
+		return !connected()
+	}, 5*time.Second, 10*time.Millisecond)
+	data, err = store.ReadFile(ctx, "a.txt")
+	require.NoError(t, err)
+	require.Equal(t, "aaa", string(data))
+
+	// the broken connection is replaced.
+	sftpStore.mu.Lock()
+	conn := sftpStore.conn
+	sftpStore.mu.Unlock()
+	require.NotNil(t, conn)
+	require.NoError(t, conn.ssh.Close())
+	require.Eventually(t, conn.client.broken, 5*time.Second, 10*time.Millisecond)
+	exists, err := store.FileExists(ctx, "a.txt")
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+// testRemoteFileStorage tests the common operations of the storages on the
+// remote file systems.
+func testRemoteFileStorage(t *testing.T, store ExternalStorage) {
+	ctx := context.Background()
+
+	exists, err := store.FileExists(ctx, "dir/a.txt")
+	require.NoError(t, err)
+	require.False(t, exists)
+	_, err = store.ReadFile(ctx, "dir/a.txt")
+	require.Error(t, err)
+
+	require.NoError(t, store.WriteFile(ctx, "dir/a.txt", []byte("aaa")))
+	require.NoError(t, store.WriteFile(ctx, "dir/sub/b.txt", []byte("bbbb")))
+	require.NoError(t, store.WriteFile(ctx, "c.txt", []byte("c")))
+	require.NoError(t, store.WriteFile(ctx, "empty", nil))
+	// overwrite
+	require.NoError(t, store.WriteFile(ctx, "c.txt", []byte("ccccc")))
+
+	exists, err = store.FileExists(ctx, "dir/a.txt")
+	require.NoError(t, err)
+	require.True(t, exists)
+	data, err := store.ReadFile(ctx, "c.txt")
+	require.NoError(t, err)
+	require.Equal(t, "ccccc", string(data))
+	data, err = store.ReadFile(ctx, "empty")
+	require.NoError(t, err)
+	require.Empty(t, data)
+
+	walk := func(opt *WalkOption) map[string]int64 {
+		files := make(map[string]int64)
+		require.NoError(t, store.WalkDir(ctx, opt, func(path string, size int64) error {
+			files[path] = size
+			return nil
+		}))
+		return files
+	}
+	require.Equal(t, map[string]int64{"dir/a.txt": 3, "dir/sub/b.txt": 4, "c.txt": 5, "empty": 0}, walk(nil))
+	require.Equal(t, map[string]int64{"dir/a.txt": 3, "dir/sub/b.txt": 4}, walk(&WalkOption{SubDir: "dir"}))
+	require.Equal(t, map[string]int64{"dir/a.txt": 3}, walk(&WalkOption{SubDir: "dir", SkipSubDir: true}))
+	require.Equal(t, map[string]int64{"dir/sub/b.txt": 4}, walk(&WalkOption{SubDir: "dir", ObjPrefix: "sub"}))
+	require.Equal(t, map[string]int64{"c.txt": 5}, walk(&WalkOption{ObjPrefix: "c"}))
+	require.Empty(t, walk(&WalkOption{SubDir: "not-exist"}))
+
+	// Open with seek and ranges
+	r, err := store.Open(ctx, "c.txt", nil)
+	require.NoError(t, err)
+	size, err := r.GetFileSize()
+	require.NoError(t, err)
+	require.EqualValues(t, 5, size)
+	_, err = r.Seek(-2, io.SeekEnd)
+	require.NoError(t, err)
+	data, err = io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "cc", string(data))
+	_, err = r.Seek(1, io.SeekStart)
+	require.NoError(t, err)
+	buf := make([]byte, 2)
+	_, err = io.ReadFull(r, buf)
+	require.NoError(t, err)
+	require.Equal(t, "cc", string(buf))
+	require.NoError(t, r.Close())
+
+	start, end := int64(1), int64(3)
+	r, err = store.Open(ctx, "dir/sub/b.txt", &ReaderOption{StartOffset: &start, EndOffset: &end})
+	require.NoError(t, err)
+	data, err = io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "bb", string(data))
+	require.NoError(t, r.Close())
+
+	// Create
+	w, err := store.Create(ctx, "new/d.txt", nil)
+	require.NoError(t, err)
+	_, err = w.Write(ctx, []byte("dd"))
+	require.NoError(t, err)
+	_, err = w.Write(ctx, []byte("dd"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close(ctx))
+	data, err = store.ReadFile(ctx, "new/d.txt")
+	require.NoError(t, err)
+	require.Equal(t, "dddd", string(data))
+
+	// Rename overwrites the target.
+	require.NoError(t, store.Rename(ctx, "new/d.txt", "c.txt"))
+	data, err = store.ReadFile(ctx, "c.txt")
+	require.NoError(t, err)
+	require.Equal(t, "dddd", string(data))
+	exists, err = store.FileExists(ctx, "new/d.txt")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// Delete
+	require.NoError(t, store.DeleteFiles(ctx, []string{"c.txt", "empty"}))
+	require.Equal(t, map[string]int64{"dir/a.txt": 3, "dir/sub/b.txt": 4}, walk(nil))
+}
+
+func TestSFTPDecoder(t *testing.T) {
+	d := sftpDecoder{buf: binary.BigEndian.AppendUint32(nil, 10)}
+	require.Equal(t, "", d.str())
+	require.ErrorContains(t, d.err, "packet too short")
+	// the later calls are no-op after the error.
+	require.Zero(t, d.u64())
+}
@@ -249,6 +249,18 @@ func New(ctx context.Context, backend *backuppb.StorageBackend, opts *ExternalSt
 		return NewGCSStorage(ctx, backend.Gcs, opts)
 	case *backuppb.StorageBackend_AzureBlobStorage:
 		return newAzureBlobStorage(ctx, backend.AzureBlobStorage, opts)
+	case *backuppb.StorageBackend_CloudDynamic:
+		if backend.CloudDynamic == nil || backend.CloudDynamic.Bucket == nil {
+			return nil, errors.Annotate(berrors.ErrStorageInvalidConfig, "cloud dynamic config not found")
+		}
+		switch backend.CloudDynamic.ProviderName {
+		case sftpProviderName:
+			return NewSFTPStorage(ctx, backend.CloudDynamic, opts)
+		case webdavProviderName:
+			return NewWebDAVStorage(ctx, backend.CloudDynamic, opts)
+		default:
+			return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "storage provider %s is not supported yet", backend.CloudDynamic.ProviderName)
+		}
 	default:
 		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "storage %T is not supported yet", backend)
 	}
@@ -0,0 +1,612 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+)
+
+const (
+	webdavProviderName = "webdav"
+
+	webdavUserAttr     = "user"
+	webdavPasswordAttr = "password"
+
+	// webdavChunkSize is the buffer size of the writer created by Create, the
+	// data is streamed to the server in one PUT request.
+	webdavChunkSize = 5 * 1024 * 1024
+
+	webdavPropfindBody = `<?xml version="1.0" encoding="utf-8"?>` +
+		`<D:propfind xmlns:D="DAV:"><D:prop><D:resourcetype/><D:getcontentlength/></D:prop></D:propfind>`
+)
+
+// WebDAVBackendOptions is the options for WebDAV storage.
+type WebDAVBackendOptions struct {
+	User     string `json:"user" toml:"user"`
+	Password string `json:"password" toml:"password"`
+}
+
+func (options *WebDAVBackendOptions) apply(u *url.URL, webdav *backuppb.CloudDynamic) {
+	if u.User != nil {
+		if options.User == "" {
+			options.User = u.User.Username()
+		}
+		if password, ok := u.User.Password(); ok && options.Password == "" {
+			options.Password = password
+		}
+	}
+	webdav.Attrs = map[string]string{
+		webdavUserAttr:     options.User,
+		webdavPasswordAttr: options.Password,
+	}
+}
+
+// WebDAVStorage is the storage on a collection of a WebDAV server.
+type WebDAVStorage struct {
+	backend  *backuppb.CloudDynamic
+	client   *http.Client
+	endpoint *url.URL
+	// base is the path of the storage collection on the server.
+	base     string
+	user     string
+	password string
+}
+
+// NewWebDAVStorage creates the storage on the WebDAV server.
+func NewWebDAVStorage(ctx context.Context, backend *backuppb.CloudDynamic, opts *ExternalStorageOptions) (*WebDAVStorage, error) {
+	if backend.Bucket == nil || backend.Bucket.Endpoint == "" {
+		return nil, errors.Annotate(berrors.ErrStorageInvalidConfig, "webdav endpoint not found")
+	}
+	endpoint, err := url.Parse(backend.Bucket.Endpoint)
+	if err != nil {
+		return nil, errors.Annotatef(berrors.ErrStorageInvalidConfig, "invalid webdav endpoint: %v", err)
+	}
+	client := opts.HTTPClient
+	if client == nil {
+		client = GetDefaultHttpClient(DefaultRequestConcurrency)
+	}
+	s := &WebDAVStorage{
+		backend:  backend,
+		client:   client,
+		endpoint: endpoint,
+		base:     path.Clean("/" + backend.Bucket.Prefix),
+		user:     backend.Attrs[webdavUserAttr],
+		password: backend.Attrs[webdavPasswordAttr],
+	}
+	if err = s.mkdirAll(ctx, s.base); err != nil {
+		return nil, errors.Annotatef(err, "failed to create the collection %s on the webdav server", s.base)
+	}
+	if !opts.SendCredentials {
+		delete(backend.Attrs, webdavPasswordAttr)
+	}
+	return s, nil
+}
+
+func (s *WebDAVStorage) fullPath(name string) string {
+	return path.Join(s.base, name)
+}
+
+func (s *WebDAVStorage) urlOf(fullPath string) string {
+	u := *s.endpoint
+	u.Path = fullPath
+	return u.String()
+}
+
+func (s *WebDAVStorage) do(
+	ctx context.Context,
+	method, fullPath string,
+	body io.Reader,
+	header http.Header,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.urlOf(fullPath), body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if s.user != "" {
+		req.SetBasicAuth(s.user, s.password)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Annotatef(err, "webdav %s %s failed", method, fullPath)
+	}
+	return resp, nil
+}
+
+// doAndClose sends the request and returns the status code of the response,
+// the response body is discarded.
+func (s *WebDAVStorage) doAndClose(
+	ctx context.Context,
+	method, fullPath string,
+	body io.Reader,
+	header http.Header,
+) (int, error) {
+	resp, err := s.do(ctx, method, fullPath, body, header)
+	if err != nil {
+		return 0, err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func webdavStatusError(method, fullPath string, statusCode int) error {
+	return errors.Errorf("webdav %s %s failed: %d %s", method, fullPath, statusCode, http.StatusText(statusCode))
+}
+
+func isWebDAVSuccess(statusCode int) bool {
+	return statusCode >= 200 && statusCode < 300
+}
+
+// mkdirAll creates the collection and all its parents if they don't exist.
+func (s *WebDAVStorage) mkdirAll(ctx context.Context, dir string) error {
+	if dir == "/" {
+		return nil
+	}
+	statusCode, err := s.doAndClose(ctx, "MKCOL", dir+"/", nil, nil)
+	if err != nil {
+		return err
+	}
+	switch statusCode {
+	case http.StatusCreated:
+		return nil
+	case http.StatusMethodNotAllowed:
+		// the collection already exists.
+		return nil
+	case http.StatusConflict:
+		// the parent collection doesn't exist.
+		if err = s.mkdirAll(ctx, path.Dir(dir)); err != nil {
+			return err
+		}
+		statusCode, err = s.doAndClose(ctx, "MKCOL", dir+"/", nil, nil)
+		if err != nil {
+			return err
+		}
+		if statusCode == http.StatusCreated || statusCode == http.StatusMethodNotAllowed {
+			return nil
+		}
+	}
+	return webdavStatusError("MKCOL", dir, statusCode)
+}
+
+// move moves the resource and overwrites the destination if it exists.
+func (s *WebDAVStorage) move(ctx context.Context, src, dst string) error {
+	header := http.Header{
+		"Destination": []string{s.urlOf(dst)},
+		"Overwrite":   []string{"T"},
+	}
+	statusCode, err := s.doAndClose(ctx, "MOVE", src, nil, header)
+	if err != nil {
+		return err
+	}
+	if statusCode == http.StatusConflict || statusCode == http.StatusNotFound {
+		// the parent collection of the destination may not exist.
+		if err = s.mkdirAll(ctx, path.Dir(dst)); err != nil {
+			return err
+		}
+		if statusCode, err = s.doAndClose(ctx, "MOVE", src, nil, header); err != nil {
+			return err
+		}
+	}
+	if !isWebDAVSuccess(statusCode) {
+		return webdavStatusError("MOVE", src, statusCode)
+	}
+	return nil
+}
+
+// DeleteFile deletes the file.
+func (s *WebDAVStorage) DeleteFile(ctx context.Context, name string) error {
+	fullPath := s.fullPath(name)
+	statusCode, err := s.doAndClose(ctx, http.MethodDelete, fullPath, nil, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isWebDAVSuccess(statusCode) {
+		return webdavStatusError(http.MethodDelete, fullPath, statusCode)
+	}
+	return nil
+}
+
+// DeleteFiles deletes the files.
+func (s *WebDAVStorage) DeleteFiles(ctx context.Context, names []string) error {
+	for _, name := range names {
+		if err := s.DeleteFile(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteFile writes data to a file to storage.
+func (s *WebDAVStorage) WriteFile(ctx context.Context, name string, data []byte) error {
+	// PUT into a temporary file and move it, so the readers won't see a
+	// partially written file.
+	fullPath := s.fullPath(name)
+	tmpPath := fullPath + ".tmp." + uuid.NewString()
+	statusCode, err := s.doAndClose(ctx, http.MethodPut, tmpPath, bytes.NewReader(data), nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if statusCode == http.StatusConflict || statusCode == http.StatusNotFound {
+		if err = s.mkdirAll(ctx, path.Dir(tmpPath)); err != nil {
+			return errors.Trace(err)
+		}
+		if statusCode, err = s.doAndClose(ctx, http.MethodPut, tmpPath, bytes.NewReader(data), nil); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if !isWebDAVSuccess(statusCode) {
+		return webdavStatusError(http.MethodPut, tmpPath, statusCode)
+	}
+	if err = s.move(ctx, tmpPath, fullPath); err != nil {
+		_, _ = s.doAndClose(ctx, http.MethodDelete, tmpPath, nil, nil)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ReadFile reads the file from the storage and returns the contents.
+func (s *WebDAVStorage) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	fullPath := s.fullPath(name)
+	resp, err := s.do(ctx, http.MethodGet, fullPath, nil, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, webdavStatusError(http.MethodGet, fullPath, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	return data, errors.Trace(err)
+}
+
+// FileExists implement ExternalStorage.FileExists.
+func (s *WebDAVStorage) FileExists(ctx context.Context, name string) (bool, error) {
+	fullPath := s.fullPath(name)
+	statusCode, err := s.doAndClose(ctx, http.MethodHead, fullPath, nil, nil)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	switch {
+	case statusCode == http.StatusNotFound:
+		return false, nil
+	case isWebDAVSuccess(statusCode):
+		return true, nil
+	default:
+		return false, webdavStatusError(http.MethodHead, fullPath, statusCode)
+	}
+}
+
+type webdavMultiStatus struct {
+	Responses []webdavResponse `xml:"DAV: response"`
+}
+
+type webdavResponse struct {
+	Href      string           `xml:"DAV: href"`
+	Propstats []webdavPropstat `xml:"DAV: propstat"`
+}
+
+type webdavPropstat struct {
+	Prop   webdavProp `xml:"DAV: prop"`
+	Status string     `xml:"DAV: status"`
+}
+
+type webdavProp struct {
+	ResourceType struct {
+		Collection *struct{} `xml:"DAV: collection"`
+	} `xml:"DAV: resourcetype"`
+	ContentLength string `xml:"DAV: getcontentlength"`
+}
+
+type webdavEntry struct {
+	name  string
+	isDir bool
+	size  int64
+}
+
+// readDir lists the members of the collection by PROPFIND.
+func (s *WebDAVStorage) readDir(ctx context.Context, dir string) ([]webdavEntry, bool, error) {
+	header := http.Header{
+		"Depth":        []string{"1"},
+		"Content-Type": []string{"application/xml; charset=utf-8"},
+	}
+	resp, err := s.do(ctx, "PROPFIND", dir+"/", strings.NewReader(webdavPropfindBody), header)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, false, webdavStatusError("PROPFIND", dir, resp.StatusCode)
+	}
+	var ms webdavMultiStatus
+	if err = xml.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, false, errors.Annotatef(err, "failed to parse the PROPFIND response of %s", dir)
+	}
+
+	entries := make([]webdavEntry, 0, len(ms.Responses))
+	for _, r := range ms.Responses {
+		href, err := url.Parse(r.Href)
+		if err != nil {
+			return nil, false, errors.Annotatef(err, "invalid href %s in the PROPFIND response", r.Href)
+		}
+		memberPath := path.Clean(href.Path)
+		if memberPath == dir {
+			continue
+		}
+		entry := webdavEntry{name: path.Base(memberPath)}
+		for _, ps := range r.Propstats {
+			if !strings.Contains(ps.Status, " 200 ") {
+				continue
+			}
+			entry.isDir = ps.Prop.ResourceType.Collection != nil
+			if ps.Prop.ContentLength != "" {
+				if entry.size, err = strconv.ParseInt(ps.Prop.ContentLength, 10, 64); err != nil {
+					return nil, false, errors.Annotatef(err, "invalid content length of %s", memberPath)
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b webdavEntry) int {
+		return strings.Compare(a.name, b.name)
+	})
+	return entries, true, nil
+}
+
+// WalkDir traverse all the files in a dir.
+//
+// fn is the function called for each regular file visited by WalkDir.
+// The first argument is the file path that can be used in `Open`
+// function; the second argument is the size in byte of the file determined
+// by path.
+func (s *WebDAVStorage) WalkDir(ctx context.Context, opt *WalkOption, fn func(string, int64) error) error {
+	if opt == nil {
+		opt = &WalkOption{}
+	}
+	dir := path.Clean(opt.SubDir)
+	objPrefix := opt.ObjPrefix
+	if dir != "." {
+		objPrefix = dir + "/" + objPrefix
+	}
+	return s.walkDir(ctx, dir, opt.SkipSubDir, objPrefix, fn)
+}
+
+func (s *WebDAVStorage) walkDir(
+	ctx context.Context,
+	dir string,
+	skipSubDir bool,
+	objPrefix string,
+	fn func(string, int64) error,
+) error {
+	entries, exists, err := s.readDir(ctx, s.fullPath(dir))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !exists {
+		// if path not exists, we should return nil to continue.
+		return nil
+	}
+	for _, entry := range entries {
+		name := path.Join(dir, entry.name)
+		if entry.isDir {
+			if skipSubDir {
+				continue
+			}
+			if err := s.walkDir(ctx, name, skipSubDir, objPrefix, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if !strings.HasPrefix(name, objPrefix) {
+			continue
+		}
+		if err := fn(name, entry.size); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// URI returns the base path as an URI with a webdav:// or webdavs:// prefix.
+func (s *WebDAVStorage) URI() string {
+	scheme := "webdav"
+	if s.endpoint.Scheme == "https" {
+		scheme = "webdavs"
+	}
+	return scheme + "://" + s.endpoint.Host + s.base
+}
+
+// Open a Reader by file path, path is a relative path to base path.
+func (s *WebDAVStorage) Open(ctx context.Context, name string, o *ReaderOption) (ExternalFileReader, error) {
+	fullPath := s.fullPath(name)
+	resp, err := s.do(ctx, http.MethodHead, fullPath, nil, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, webdavStatusError(http.MethodHead, fullPath, resp.StatusCode)
+	}
+	if resp.ContentLength < 0 {
+		return nil, errors.Errorf("webdav HEAD %s doesn't return the content length", fullPath)
+	}
+
+	totalSize := resp.ContentLength
+	pos, endPos := int64(0), totalSize
+	if o != nil {
+		if o.StartOffset != nil {
+			pos = *o.StartOffset
+		}
+		if o.EndOffset != nil {
+			endPos = min(*o.EndOffset, totalSize)
+		}
+	}
+	return &webdavFileReader{
+		ctx:       ctx,
+		storage:   s,
+		fullPath:  fullPath,
+		pos:       pos,
+		endPos:    endPos,
+		totalSize: totalSize,
+	}, nil
+}
+
+// Create implements ExternalStorage interface.
+func (s *WebDAVStorage) Create(ctx context.Context, name string, _ *WriterOption) (ExternalFileWriter, error) {
+	fullPath := s.fullPath(name)
+	if err := s.mkdirAll(ctx, path.Dir(fullPath)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	pr, pw := io.Pipe()
+	uploader := &webdavUploader{pw: pw, done: make(chan error, 1)}
+	go func() {
+		statusCode, err := s.doAndClose(ctx, http.MethodPut, fullPath, pr, nil)
+		if err == nil && !isWebDAVSuccess(statusCode) {
+			err = webdavStatusError(http.MethodPut, fullPath, statusCode)
+		}
+		// unblock the writer if the request fails before reading all data.
+		_ = pr.CloseWithError(err)
+		uploader.done <- err
+	}()
+	return newBufferedWriter(uploader, webdavChunkSize, NoCompression), nil
+}
+
+// Rename implements ExternalStorage interface.
+func (s *WebDAVStorage) Rename(ctx context.Context, oldFileName, newFileName string) error {
+	return errors.Trace(s.move(ctx, s.fullPath(oldFileName), s.fullPath(newFileName)))
+}
+
+type webdavFileReader struct {
+	ctx      context.Context
+	storage  *WebDAVStorage
+	fullPath string
+	// body is the body of the ranged GET response starting at pos, it's
+	// reopened after Seek.
+	body io.ReadCloser
+
+	pos       int64
+	endPos    int64
+	totalSize int64
+}
+
+func (r *webdavFileReader) open() error {
+	header := http.Header{
+		"Range": []string{fmt.Sprintf("bytes=%d-%d", r.pos, r.endPos-1)},
+	}
+	resp, err := r.storage.do(r.ctx, http.MethodGet, r.fullPath, nil, header)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// the server doesn't support range requests.
+		if _, err = io.CopyN(io.Discard, resp.Body, r.pos); err != nil {
+			_ = resp.Body.Close()
+			return errors.Trace(err)
+		}
+	default:
+		_ = resp.Body.Close()
+		return webdavStatusError(http.MethodGet, r.fullPath, resp.StatusCode)
+	}
+	r.body = resp.Body
+	return nil
+}
+
+// Read implement the io.Reader interface.
+func (r *webdavFileReader) Read(p []byte) (int, error) {
+	maxCnt := r.endPos - r.pos
+	if maxCnt <= 0 {
+		return 0, io.EOF
+	}
+	if maxCnt < int64(len(p)) {
+		p = p[:maxCnt]
+	}
+	if r.body == nil {
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.body.Read(p)
+	r.pos += int64(n)
+	if err == io.EOF && r.pos < r.endPos {
+		return n, errors.Annotatef(io.ErrUnexpectedEOF, "webdav GET %s, pos='%d', end='%d'", r.fullPath, r.pos, r.endPos)
+	}
+	if err != nil && err != io.EOF {
+		return n, errors.Annotatef(err, "failed to read data from webdav, data info: pos='%d', count='%d'", r.pos, len(p))
+	}
+	return n, nil
+}
+
+// Seek implement the io.Seeker interface.
+func (r *webdavFileReader) Seek(offset int64, whence int) (int64, error) {
+	var realOffset int64
+	switch whence {
+	case io.SeekStart:
+		realOffset = offset
+	case io.SeekCurrent:
+		realOffset = r.pos + offset
+	case io.SeekEnd:
+		realOffset = r.totalSize + offset
+	default:
+		return 0, errors.Annotatef(berrors.ErrStorageUnknown, "Seek: invalid whence '%d'", whence)
+	}
+	if realOffset < 0 {
+		return 0, errors.Annotatef(berrors.ErrInvalidArgument, "Seek: offset is %d, but length of content is only %d", realOffset, r.totalSize)
+	}
+	if realOffset != r.pos && r.body != nil {
+		_ = r.body.Close()
+		r.body = nil
+	}
+	r.pos = realOffset
+	return r.pos, nil
+}
+
+// Close implement the io.Closer interface.
+func (r *webdavFileReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	return errors.Trace(r.body.Close())
+}
+
+// GetFileSize implement the ExternalFileReader interface.
+func (r *webdavFileReader) GetFileSize() (int64, error) {
+	return r.totalSize, nil
+}
+
+// webdavUploader streams the data to the server by a single PUT request.
+type webdavUploader struct {
+	pw   *io.PipeWriter
+	done chan error
+}
+
+func (u *webdavUploader) Write(_ context.Context, data []byte) (int, error) {
+	n, err := u.pw.Write(data)
+	return n, errors.Annotate(err, "failed to write data to webdav")
+}
+
+func (u *webdavUploader) Close(_ context.Context) error {
+	_ = u.pw.Close()
+	return errors.Trace(<-u.done)
+}
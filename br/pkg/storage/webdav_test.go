@@ -0,0 +1,67 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/webdav"
+)
+
+func TestWebDAVStorage(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	handler := &webdav.Handler{
+		FileSystem: webdav.Dir(root),
+		LockSystem: webdav.NewMemLS(),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "root" || password != "passwd" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	backend, err := ParseBackend("webdav://root:wrong@"+host+"/backup", nil)
+	require.NoError(t, err)
+	_, err = New(ctx, backend, &ExternalStorageOptions{})
+	require.ErrorContains(t, err, "401 Unauthorized")
+
+	backend, err = ParseBackend("webdav://root:passwd@"+host+"/backup/some%20dir", nil)
+	require.NoError(t, err)
+	store, err := New(ctx, backend, &ExternalStorageOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "webdav://"+host+"/backup/some dir", store.URI())
+	// the password isn't sent downstream.
+	require.Empty(t, backend.GetCloudDynamic().Attrs[webdavPasswordAttr])
+	testRemoteFileStorage(t, store)
+
+	// the files are stored in the collection.
+	data, err := os.ReadFile(filepath.Join(root, "backup", "some dir", "dir", "a.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "aaa", string(data))
+
+	// the user and password can also be passed by the query parameters.
+	backend, err = ParseBackend("webdav://"+host+"/backup/some%20dir?user=root&password="+url.QueryEscape("passwd"), nil)
+	require.NoError(t, err)
+	store, err = New(ctx, backend, &ExternalStorageOptions{})
+	require.NoError(t, err)
+	r, err := store.Open(ctx, "dir/sub/b.txt", nil)
+	require.NoError(t, err)
+	data, err = io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "bbbb", string(data))
+	require.NoError(t, r.Close())
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/klauspost/compress/gzip"
 	"github.com/klauspost/compress/snappy"
 	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"go.uber.org/zap"
@@ -25,6 +26,8 @@ const (
 	Snappy
 	// Zstd will compress given bytes in zstd format.
 	Zstd
+	// LZ4 will compress given bytes in lz4 frame format.
+	LZ4
 )
 
 // DecompressConfig is the config used for decompression.
@@ -63,6 +66,8 @@ func createSuffixString(compressType CompressType) string {
 		txtSuffix += ".snappy"
 	case Zstd:
 		txtSuffix += ".zst"
+	case LZ4:
+		txtSuffix += ".lz4"
 	default:
 		return ""
 	}
@@ -88,6 +93,8 @@ func newCompressWriter(compressType CompressType, w io.Writer) simpleCompressWri
 			log.Warn("Met error when creating new writer for Zstd type file", zap.Error(err))
 		}
 		return newWriter
+	case LZ4:
+		return lz4.NewWriter(w)
 	default:
 		return nil
 	}
@@ -105,6 +112,8 @@ func newCompressReader(compressType CompressType, cfg DecompressConfig, r io.Rea
 			options = append(options, zstd.WithDecoderConcurrency(cfg.ZStdDecodeConcurrency))
 		}
 		return zstd.NewReader(r, options...)
+	case LZ4:
+		return lz4.NewReader(r), nil
 	default:
 		return nil, nil
 	}
@@ -137,7 +137,7 @@ func TestCompressReaderWriter(t *testing.T) {
 
 		require.Nil(t, file.Close())
 	}
-	compressTypeArr := []CompressType{Gzip, Snappy, Zstd}
+	compressTypeArr := []CompressType{Gzip, Snappy, Zstd, LZ4}
 
 	tests := []testcase{
 		{
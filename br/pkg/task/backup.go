@@ -49,6 +49,7 @@ const (
 	flagBackupTimeago    = "timeago"
 	flagBackupTS         = "backupts"
 	flagLastBackupTS     = "lastbackupts"
+	flagLastBackupStore  = "last-backup-storage"
 	flagCompressionType  = "compression"
 	flagCompressionLevel = "compression-level"
 	flagRemoveSchedulers = "remove-schedulers"
@@ -84,16 +85,17 @@ type CompressionConfig struct {
 type BackupConfig struct {
 	Config
 
-	TimeAgo          time.Duration     `json:"time-ago" toml:"time-ago"`
-	BackupTS         uint64            `json:"backup-ts" toml:"backup-ts"`
-	LastBackupTS     uint64            `json:"last-backup-ts" toml:"last-backup-ts"`
-	GCTTL            int64             `json:"gc-ttl" toml:"gc-ttl"`
-	RemoveSchedulers bool              `json:"remove-schedulers" toml:"remove-schedulers"`
-	IgnoreStats      bool              `json:"ignore-stats" toml:"ignore-stats"`
-	UseBackupMetaV2  bool              `json:"use-backupmeta-v2"`
-	UseCheckpoint    bool              `json:"use-checkpoint" toml:"use-checkpoint"`
-	ReplicaReadLabel map[string]string `json:"replica-read-label" toml:"replica-read-label"`
-	TableConcurrency uint              `json:"table-concurrency" toml:"table-concurrency"`
+	TimeAgo           time.Duration     `json:"time-ago" toml:"time-ago"`
+	BackupTS          uint64            `json:"backup-ts" toml:"backup-ts"`
+	LastBackupTS      uint64            `json:"last-backup-ts" toml:"last-backup-ts"`
+	LastBackupStorage string            `json:"last-backup-storage" toml:"last-backup-storage"`
+	GCTTL             int64             `json:"gc-ttl" toml:"gc-ttl"`
+	RemoveSchedulers  bool              `json:"remove-schedulers" toml:"remove-schedulers"`
+	IgnoreStats       bool              `json:"ignore-stats" toml:"ignore-stats"`
+	UseBackupMetaV2   bool              `json:"use-backupmeta-v2"`
+	UseCheckpoint     bool              `json:"use-checkpoint" toml:"use-checkpoint"`
+	ReplicaReadLabel  map[string]string `json:"replica-read-label" toml:"replica-read-label"`
+	TableConcurrency  uint              `json:"table-concurrency" toml:"table-concurrency"`
 	CompressionConfig
 
 	// for ebs-based backup
@@ -114,6 +116,9 @@ func DefineBackupFlags(flags *pflag.FlagSet) {
 	// TODO: remove experimental tag if it's stable
 	flags.Uint64(flagLastBackupTS, 0, "(experimental) the last time backup ts,"+
 		" use for incremental backup, support TSO only")
+	flags.String(flagLastBackupStore, "", "(experimental) the storage url of the last backup,"+
+		" use for incremental backup based on the end ts of the last backup,"+
+		" and the backup is checked to be able to follow the last backup in a restore chain")
 	flags.String(flagBackupTS, "", "the backup ts support TSO or datetime,"+
 		" e.g. '400036290571534337', '2018-05-11 01:42:23'")
 	flags.Int64(flagGCTTL, utils.DefaultBRGCSafePointTTL, "the TTL (in seconds) that PD holds for BR's GC safepoint")
@@ -172,6 +177,10 @@ func (cfg *BackupConfig) ParseFromFlags(flags *pflag.FlagSet) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	cfg.LastBackupStorage, err = flags.GetString(flagLastBackupStore)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	backupTS, err := flags.GetString(flagBackupTS)
 	if err != nil {
 		return errors.Trace(err)
@@ -188,7 +197,7 @@ func (cfg *BackupConfig) ParseFromFlags(flags *pflag.FlagSet) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if cfg.LastBackupTS > 0 {
+	if cfg.LastBackupTS > 0 || len(cfg.LastBackupStorage) > 0 {
 		// TODO: compatible with incremental backup
 		cfg.UseCheckpoint = false
 		log.Info("since incremental backup is used, turn off checkpoint mode")
@@ -268,6 +277,36 @@ func (cfg *BackupConfig) ParseFromFlags(flags *pflag.FlagSet) error {
 	return nil
 }
 
+// checkLastBackup reads the backupmeta of the last backup, and checks whether
+// the incremental backup to be taken can follow it in a backup chain. If the
+// LastBackupTS isn't specified, it's set to the end ts of the last backup.
+func (cfg *BackupConfig) checkLastBackup(
+	ctx context.Context,
+	clusterID uint64,
+	apiVersion kvrpcpb.APIVersion,
+	backupTS uint64,
+) error {
+	lastCfg := cfg.Config
+	lastCfg.Storage = cfg.LastBackupStorage
+	_, _, lastMeta, err := ReadBackupMeta(ctx, metautil.MetaFile, &lastCfg)
+	if err != nil {
+		return errors.Annotate(err, "failed to read the backupmeta of the last backup")
+	}
+	if cfg.LastBackupTS == 0 {
+		cfg.LastBackupTS = lastMeta.EndVersion
+	}
+	log.Info("take an incremental backup based on the last backup",
+		zap.Uint64("last-backup-start-ts", lastMeta.StartVersion),
+		zap.Uint64("last-backup-end-ts", lastMeta.EndVersion),
+		zap.Uint64("last-backup-ts", cfg.LastBackupTS))
+	return errors.Trace(metautil.CheckIncrementalBackup(lastMeta, &backuppb.BackupMeta{
+		ClusterId:    clusterID,
+		StartVersion: cfg.LastBackupTS,
+		EndVersion:   backupTS,
+		ApiVersion:   apiVersion,
+	}))
+}
+
 // parseCompressionFlags parses the backup-related flags from the flag set.
 func parseCompressionFlags(flags *pflag.FlagSet) (*CompressionConfig, error) {
 	compressionStr, err := flags.GetString(flagCompressionType)
@@ -475,6 +514,12 @@ func RunBackup(c context.Context, g glue.Glue, cmdName string, cfg *BackupConfig
 		return errors.Trace(err)
 	}
 	g.Record("BackupTS", backupTS)
+	if len(cfg.LastBackupStorage) > 0 {
+		apiVersion := mgr.GetStorage().GetCodec().GetAPIVersion()
+		if err = cfg.checkLastBackup(ctx, client.GetClusterID(), apiVersion, backupTS); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	safePointID := client.GetSafePointID()
 	sp := utils.BRServiceSafePoint{
 		BackupTS: backupTS,
@@ -212,13 +212,16 @@ func expectedDefaultRestoreConfig() RestoreConfig {
 			MergeSmallRegionKeyCount:  0xea600,
 			WithSysTable:              true,
 			ResetSysUsers:             []string{"cloud_admin", "root"}},
-		NoSchema:            false,
-		PDConcurrency:       0x1,
-		StatsConcurrency:    0xc,
-		BatchFlushInterval:  16000000000,
-		DdlBatchSize:        0x80,
-		WithPlacementPolicy: "STRICT",
-		UseCheckpoint:       true,
+		NoSchema:                  false,
+		PDConcurrency:             0x1,
+		StatsConcurrency:          0xc,
+		BatchFlushInterval:        16000000000,
+		DdlBatchSize:              0x80,
+		WithPlacementPolicy:       "STRICT",
+		IncrementalBackupStorages: []string{},
+		RenameRules:               []string{},
+		Partitions:                []string{},
+		UseCheckpoint:             true,
 	}
 }
 
@@ -79,6 +79,16 @@ const (
 
 	FlagResetSysUsers = "reset-sys-users"
 
+	// FlagIncrementalBackupStorage is used for snapshot restore, represents the
+	// incremental backups restored after the full backup.
+	FlagIncrementalBackupStorage = "incremental-backup-storage"
+	// FlagRename is used for snapshot restore, represents the rules to restore
+	// the tables into other databases or as other tables.
+	FlagRename = "rename"
+	// FlagPartition is used for snapshot restore, represents the partitions to
+	// restore of a partitioned table.
+	FlagPartition = "partition"
+
 	defaultPiTRBatchCount     = 8
 	defaultPiTRBatchSize      = 16 * 1024 * 1024
 	defaultRestoreConcurrency = 128
@@ -214,6 +224,17 @@ type RestoreConfig struct {
 
 	WithPlacementPolicy string `json:"with-tidb-placement-mode" toml:"with-tidb-placement-mode"`
 
+	// IncrementalBackupStorages are the incremental backups restored after the
+	// backup of `--storage`, they make up a backup chain with it.
+	IncrementalBackupStorages []string `json:"incremental-backup-storages" toml:"incremental-backup-storages"`
+
+	// RenameRules restore the matched tables into other databases or as other
+	// tables, see restore.ParseRenameRule for the format.
+	RenameRules []string `json:"rename-rules" toml:"rename-rules"`
+	// Partitions are the partitions to restore of the partitioned tables, each
+	// one is in the form of `db.table:p0,p1`.
+	Partitions []string `json:"partitions" toml:"partitions"`
+
 	// FullBackupStorage is used to  run `restore full` before `restore log`.
 	// if it is empty, directly take restoring log justly.
 	FullBackupStorage string `json:"full-backup-storage" toml:"full-backup-storage"`
@@ -260,6 +281,16 @@ func DefineRestoreFlags(flags *pflag.FlagSet) {
 
 	flags.Bool(FlagWaitTiFlashReady, false, "whether wait tiflash replica ready if tiflash exists")
 
+	flags.StringArray(FlagIncrementalBackupStorage, nil, "(experimental) the storage url of an incremental backup "+
+		"based on the backup of --storage, can be specified multiple times to restore a backup chain. "+
+		"The backups are restored in the order of their backup ts, the DDLs between them are replayed as well.")
+	flags.StringArray(FlagRename, nil, "(experimental) restore the tables matched by a table filter pattern into "+
+		"another database or as another table, in the form of `pattern:schema.table`, e.g. 'db.t:db_restored.t_20260101' "+
+		"or 'db.*:db_restored.*'. Can be specified multiple times, the first matched rule of a table wins.")
+	flags.StringArray(FlagPartition, nil, "(experimental) only restore the given partitions of a RANGE or LIST "+
+		"partitioned table, in the form of `db.table:p0,p1`. The other partitions aren't created or downloaded, "+
+		"and the checksum and statistics of the table are skipped. Can be specified multiple times.")
+
 	DefineRestoreCommonFlags(flags)
 }
 
@@ -371,6 +402,25 @@ func (cfg *RestoreConfig) ParseFromFlags(flags *pflag.FlagSet) error {
 		return errors.Annotatef(err, "failed to get flag %s", FlagWaitTiFlashReady)
 	}
 
+	cfg.IncrementalBackupStorages, err = flags.GetStringArray(FlagIncrementalBackupStorage)
+	if err != nil {
+		return errors.Annotatef(err, "failed to get flag %s", FlagIncrementalBackupStorage)
+	}
+	cfg.RenameRules, err = flags.GetStringArray(FlagRename)
+	if err != nil {
+		return errors.Annotatef(err, "failed to get flag %s", FlagRename)
+	}
+	if _, err = restore.ParseRenameRules(cfg.RenameRules); err != nil {
+		return errors.Trace(err)
+	}
+	cfg.Partitions, err = flags.GetStringArray(FlagPartition)
+	if err != nil {
+		return errors.Annotatef(err, "failed to get flag %s", FlagPartition)
+	}
+	if _, err = restore.ParsePartitionFilter(cfg.Partitions); err != nil {
+		return errors.Trace(err)
+	}
+
 	if flags.Lookup(flagFullBackupType) != nil {
 		// for restore full only
 		fullBackupType, err := flags.GetString(flagFullBackupType)
@@ -650,7 +700,21 @@ func RunRestore(c context.Context, g glue.Glue, cmdName string, cfg *RestoreConf
 
 	var restoreError error
 	if IsStreamRestore(cmdName) {
+		if len(cfg.IncrementalBackupStorages) > 0 {
+			return errors.Annotatef(berrors.ErrInvalidArgument,
+				"%s isn't supported by the log restore", FlagIncrementalBackupStorage)
+		}
+		if len(cfg.RenameRules) > 0 || len(cfg.Partitions) > 0 {
+			return errors.Annotatef(berrors.ErrInvalidArgument,
+				"%s and %s aren't supported by the log restore", FlagRename, FlagPartition)
+		}
 		restoreError = RunStreamRestore(c, g, cmdName, cfg)
+	} else if len(cfg.IncrementalBackupStorages) > 0 {
+		if len(cfg.RenameRules) > 0 || len(cfg.Partitions) > 0 {
+			return errors.Annotatef(berrors.ErrInvalidArgument,
+				"%s and %s aren't supported by the incremental restore", FlagRename, FlagPartition)
+		}
+		restoreError = runRestoreChain(c, g, cmdName, cfg)
 	} else {
 		restoreError = runRestore(c, g, cmdName, cfg)
 	}
@@ -684,6 +748,48 @@ func RunRestore(c context.Context, g glue.Glue, cmdName string, cfg *RestoreConf
 	return nil
 }
 
+// runRestoreChain restores a full backup and the incremental backups based on
+// it one by one. All the backupmetas are read and checked to make up a backup
+// chain before restoring anything.
+func runRestoreChain(c context.Context, g glue.Glue, cmdName string, cfg *RestoreConfig) error {
+	storages := append([]string{cfg.Storage}, cfg.IncrementalBackupStorages...)
+	backups := make([]restore.ChainedBackup, 0, len(storages))
+	for i, storage := range storages {
+		chainCfg := cfg.Config
+		chainCfg.Storage = storage
+		_, _, backupMeta, err := ReadBackupMeta(c, metautil.MetaFile, &chainCfg)
+		if err != nil {
+			return errors.Annotatef(err, "failed to read the backupmeta of the backup #%d", i)
+		}
+		backups = append(backups, restore.ChainedBackup{Storage: storage, Meta: backupMeta})
+	}
+	if err := restore.SortBackupChain(backups); err != nil {
+		return errors.Trace(err)
+	}
+
+	for i, backup := range backups {
+		log.Info("start to restore a backup of the backup chain",
+			zap.Int("index", i),
+			zap.Int("total", len(backups)),
+			zap.Uint64("start-ts", backup.Meta.StartVersion),
+			zap.Uint64("end-ts", backup.Meta.EndVersion))
+		backupCfg := cfg
+		if i == 0 {
+			// the checkpoint of the full backup is removed by `RunRestore` after
+			// the whole chain is restored.
+			cfg.Storage = backup.Storage
+		} else {
+			incrCfg := *cfg
+			incrCfg.Storage = backup.Storage
+			backupCfg = &incrCfg
+		}
+		if err := runRestore(c, g, cmdName, backupCfg); err != nil {
+			return errors.Annotatef(err, "failed to restore the backup #%d of the backup chain", i)
+		}
+	}
+	return nil
+}
+
 func runRestore(c context.Context, g glue.Glue, cmdName string, cfg *RestoreConfig) error {
 	cfg.Adjust()
 	defer summary.Summary(cmdName)
@@ -765,6 +871,16 @@ func runRestore(c context.Context, g glue.Glue, cmdName string, cfg *RestoreConf
 	if len(dbs) == 0 && len(tables) != 0 {
 		return errors.Annotate(berrors.ErrRestoreInvalidBackup, "contain tables but no databases")
 	}
+	if len(cfg.RenameRules) > 0 || len(cfg.Partitions) > 0 {
+		if client.IsIncremental() {
+			return errors.Annotatef(berrors.ErrInvalidArgument,
+				"%s and %s aren't supported by the incremental restore", FlagRename, FlagPartition)
+		}
+		files, tables, dbs, err = filterPartitionsAndRename(cfg, files, tables, dbs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	archiveSize := reader.ArchiveSize(ctx, files)
 	g.Record(summary.RestoreDataSize, archiveSize)
@@ -1148,6 +1264,37 @@ func filterRestoreFiles(
 	return
 }
 
+// filterPartitionsAndRename keeps only the partitions selected by cfg.Partitions
+// of the tables to restore, then applies cfg.RenameRules to them.
+func filterPartitionsAndRename(
+	cfg *RestoreConfig,
+	files []*backuppb.File,
+	tables []*metautil.Table,
+	dbs []*metautil.Database,
+) ([]*backuppb.File, []*metautil.Table, []*metautil.Database, error) {
+	if len(cfg.Partitions) > 0 {
+		partitions, err := restore.ParsePartitionFilter(cfg.Partitions)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		tables, files, err = restore.FilterPartitions(tables, partitions)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+	}
+	if len(cfg.RenameRules) > 0 {
+		rules, err := restore.ParseRenameRules(cfg.RenameRules)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		dbs, tables, err = restore.RenameTables(dbs, tables, rules)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+	}
+	return files, tables, dbs, nil
+}
+
 // restorePreWork executes some prepare work before restore.
 // TODO make this function returns a restore post work.
 func restorePreWork(ctx context.Context, client *restore.Client, mgr *conn.Mgr, switchToImport bool) (pdutil.UndoFunc, *pdutil.ClusterConfig, error) {
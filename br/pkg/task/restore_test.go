@@ -255,3 +255,31 @@ func mockBackupMeta(mockSchemas []*backuppb.Schema, mockFiles []*backuppb.File)
 		Schemas: mockSchemas,
 	}
 }
+
+func TestRunRestoreChainWithInvalidChain(t *testing.T) {
+	ctx := context.Background()
+	writeBackupMeta := func(meta *backuppb.BackupMeta) string {
+		dir := t.TempDir()
+		store, err := storage.NewLocalStorage(dir)
+		require.NoError(t, err)
+		data, err := proto.Marshal(meta)
+		require.NoError(t, err)
+		require.NoError(t, store.WriteFile(ctx, metautil.MetaFile, data))
+		return "local://" + dir
+	}
+	full := writeBackupMeta(&backuppb.BackupMeta{ClusterId: 1, EndVersion: 100})
+	incr := writeBackupMeta(&backuppb.BackupMeta{ClusterId: 1, StartVersion: 200, EndVersion: 300})
+
+	// the chain is checked before connecting to the cluster
+	cfg := &RestoreConfig{}
+	cfg.CipherInfo.CipherType = encryptionpb.EncryptionMethod_PLAINTEXT
+	cfg.Storage = full
+	cfg.IncrementalBackupStorages = []string{incr}
+	err := runRestoreChain(ctx, nil, FullRestoreCmd, cfg)
+	require.ErrorContains(t, err, "doesn't start at the end of the last backup 100")
+
+	cfg.Storage = incr
+	cfg.IncrementalBackupStorages = []string{t.TempDir()}
+	err = runRestoreChain(ctx, nil, FullRestoreCmd, cfg)
+	require.ErrorContains(t, err, "failed to read the backupmeta of the backup #1")
+}
@@ -0,0 +1,531 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package task
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/pingcap/errors"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+	"github.com/pingcap/log"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/glue"
+	"github.com/pingcap/tidb/br/pkg/logutil"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/summary"
+	"github.com/pingcap/tidb/br/pkg/utils"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	flagVerifyReportFile = "report-file"
+
+	defaultVerifyConcurrency = 4
+
+	writeCF   = "write"
+	defaultCF = "default"
+)
+
+// VerifyConfig is the configuration specific for verify tasks.
+type VerifyConfig struct {
+	Config
+
+	// ReportFile is the local path to write the report in JSON, empty means
+	// the report is only printed.
+	ReportFile string `json:"report-file" toml:"report-file"`
+}
+
+// DefineVerifyFlags defines flags for the verify command.
+func DefineVerifyFlags(flags *pflag.FlagSet) {
+	flags.Uint32(flagConcurrency, defaultVerifyConcurrency, "The number of data files verified concurrently")
+	flags.String(flagVerifyReportFile, "", "The local path to write the verify report in JSON")
+}
+
+// ParseFromFlags parses the verify-related flags from the flag set.
+func (cfg *VerifyConfig) ParseFromFlags(flags *pflag.FlagSet) error {
+	err := cfg.Config.ParseFromFlags(flags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cfg.Concurrency, err = flags.GetUint32(flagConcurrency)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cfg.ReportFile, err = flags.GetString(flagVerifyReportFile)
+	return errors.Trace(err)
+}
+
+// Adjust is use for BR(binary) and BR in TiDB.
+func (cfg *VerifyConfig) Adjust() {
+	if cfg.Concurrency == 0 {
+		cfg.Concurrency = defaultVerifyConcurrency
+	}
+}
+
+// VerifyFileIssue is a missing or corrupted data file found by a verify task.
+type VerifyFileIssue struct {
+	Table  string `json:"table"`
+	File   string `json:"file"`
+	Reason string `json:"reason"`
+}
+
+// VerifyTableIssue is a table whose recomputed checksum doesn't match the
+// checksum recorded in the backupmeta.
+type VerifyTableIssue struct {
+	Table    string       `json:"table"`
+	Expected FileChecksum `json:"expected"`
+	Actual   FileChecksum `json:"actual"`
+}
+
+// VerifyReport is the result of a verify task.
+type VerifyReport struct {
+	TotalFiles    int `json:"total_files"`
+	VerifiedFiles int `json:"verified_files"`
+	// UncheckedFiles are the files whose content can't be decoded by BR, such
+	// as the SST files compressed by lz4, only their sha256 are checked.
+	UncheckedFiles   []VerifyFileIssue  `json:"unchecked_files"`
+	MissingFiles     []VerifyFileIssue  `json:"missing_files"`
+	CorruptedFiles   []VerifyFileIssue  `json:"corrupted_files"`
+	MismatchedTables []VerifyTableIssue `json:"mismatched_tables"`
+}
+
+// Passed returns whether no missing or corrupted file is found.
+func (r *VerifyReport) Passed() bool {
+	return len(r.MissingFiles) == 0 && len(r.CorruptedFiles) == 0 && len(r.MismatchedTables) == 0
+}
+
+// Print prints the report in a human-readable form.
+func (r *VerifyReport) Print(w io.Writer) {
+	fmt.Fprintf(w, "verified %d of %d data files\n", r.VerifiedFiles, r.TotalFiles)
+	printIssues := func(title string, issues []VerifyFileIssue) {
+		if len(issues) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "%s (%d):\n", title, len(issues))
+		for _, issue := range issues {
+			fmt.Fprintf(w, "  %s %s: %s\n", issue.Table, issue.File, issue.Reason)
+		}
+	}
+	printIssues("missing files", r.MissingFiles)
+	printIssues("corrupted files", r.CorruptedFiles)
+	printIssues("unchecked files", r.UncheckedFiles)
+	if len(r.MismatchedTables) > 0 {
+		fmt.Fprintf(w, "checksum mismatched tables (%d):\n", len(r.MismatchedTables))
+		for _, issue := range r.MismatchedTables {
+			fmt.Fprintf(w, "  %s: expected crc64xor=%d kvs=%d bytes=%d, got crc64xor=%d kvs=%d bytes=%d\n",
+				issue.Table,
+				issue.Expected.Crc64Xor, issue.Expected.TotalKvs, issue.Expected.TotalBytes,
+				issue.Actual.Crc64Xor, issue.Actual.TotalKvs, issue.Actual.TotalBytes)
+		}
+	}
+	if r.Passed() {
+		fmt.Fprintln(w, "backup verify succeed!")
+	}
+}
+
+func (r *VerifyReport) sort() {
+	for _, issues := range [][]VerifyFileIssue{r.UncheckedFiles, r.MissingFiles, r.CorruptedFiles} {
+		sort.Slice(issues, func(i, j int) bool {
+			if issues[i].Table != issues[j].Table {
+				return issues[i].Table < issues[j].Table
+			}
+			return issues[i].File < issues[j].File
+		})
+	}
+	sort.Slice(r.MismatchedTables, func(i, j int) bool {
+		return r.MismatchedTables[i].Table < r.MismatchedTables[j].Table
+	})
+}
+
+// verifyTable is the state of verifying a table.
+type verifyTable struct {
+	name     string
+	expected FileChecksum
+	// skipChecksum is set if the table has no checksum, or any file of the
+	// table can't be decoded.
+	skipChecksum bool
+	actual       FileChecksum
+}
+
+// RunVerify re-reads the data files of a backup from the external storage. It
+// checks the sha256 of every file recorded in the backupmeta, and recomputes
+// the checksum of every table offline from the files, without any TiDB or
+// TiKV. The missing and corrupted files are collected into the report.
+func RunVerify(c context.Context, g glue.Glue, cmdName string, cfg *VerifyConfig) (*VerifyReport, error) {
+	cfg.Adjust()
+	defer summary.Summary(cmdName)
+	ctx, cancel := context.WithCancel(c)
+	defer cancel()
+
+	_, s, backupMeta, err := ReadBackupMeta(ctx, metautil.MetaFile, &cfg.Config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if backupMeta.IsRawKv || backupMeta.IsTxnKv {
+		return nil, errors.Annotate(berrors.ErrInvalidArgument, "only the backup of TiDB tables can be verified")
+	}
+	// the incremental backup contains the deleted rows, which isn't counted
+	// in the checksum.
+	checkFileChecksum := backupMeta.StartVersion == 0
+	reader := metautil.NewMetaReader(backupMeta, s, &cfg.CipherInfo)
+	dbs, err := metautil.LoadBackupTables(ctx, reader)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	report := &VerifyReport{}
+	tables := make([]*verifyTable, 0)
+	groups := make([][]*backuppb.File, 0)
+	groupTables := make([]*verifyTable, 0)
+	for _, db := range dbs {
+		dbName := db.Info.Name.O
+		if name, ok := utils.GetSysDBName(db.Info.Name); utils.IsSysDB(name) && ok {
+			dbName = name
+		}
+		for _, tbl := range db.Tables {
+			if tbl.Info == nil || !cfg.TableFilter.MatchTable(dbName, tbl.Info.Name.O) {
+				continue
+			}
+			vt := &verifyTable{
+				name: utils.EncloseDBAndTable(dbName, tbl.Info.Name.O),
+				expected: FileChecksum{
+					Crc64Xor:   tbl.Crc64Xor,
+					TotalKvs:   tbl.TotalKvs,
+					TotalBytes: tbl.TotalBytes,
+				},
+				skipChecksum: tbl.NoChecksum() || !checkFileChecksum,
+			}
+			tables = append(tables, vt)
+			for _, group := range groupFilesByRange(tbl.Files) {
+				groups = append(groups, group)
+				groupTables = append(groupTables, vt)
+			}
+			report.TotalFiles += len(tbl.Files)
+		}
+	}
+
+	progress := g.StartProgress(ctx, cmdName, int64(report.TotalFiles), !cfg.LogProgress)
+	defer progress.Close()
+
+	var mu sync.Mutex
+	pool := utils.NewWorkerPool(uint(cfg.Concurrency), "verify")
+	eg, ectx := errgroup.WithContext(ctx)
+	for i := range groups {
+		files, vt := groups[i], groupTables[i]
+		pool.ApplyOnErrorGroup(eg, func() error {
+			result, err := verifyFiles(ectx, s, &cfg.CipherInfo, files, checkFileChecksum)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if ectx.Err() != nil {
+				return errors.Trace(ectx.Err())
+			}
+			progress.IncBy(int64(len(files)))
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, issue := range result.missing {
+				report.MissingFiles = append(report.MissingFiles, VerifyFileIssue{Table: vt.name, File: issue.File, Reason: issue.Reason})
+			}
+			for _, issue := range result.corrupted {
+				report.CorruptedFiles = append(report.CorruptedFiles, VerifyFileIssue{Table: vt.name, File: issue.File, Reason: issue.Reason})
+			}
+			for _, issue := range result.unchecked {
+				report.UncheckedFiles = append(report.UncheckedFiles, VerifyFileIssue{Table: vt.name, File: issue.File, Reason: issue.Reason})
+			}
+			report.VerifiedFiles += result.verified
+			if result.checksum == nil {
+				vt.skipChecksum = true
+			} else {
+				vt.actual.Update(*result.checksum)
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	for _, vt := range tables {
+		if vt.skipChecksum || vt.actual == vt.expected {
+			continue
+		}
+		report.MismatchedTables = append(report.MismatchedTables, VerifyTableIssue{
+			Table:    vt.name,
+			Expected: vt.expected,
+			Actual:   vt.actual,
+		})
+	}
+	report.sort()
+	for _, issue := range report.MissingFiles {
+		log.Error("backup file is missing", zap.String("table", issue.Table), zap.String("file", issue.File))
+	}
+	for _, issue := range report.CorruptedFiles {
+		log.Error("backup file is corrupted", zap.String("table", issue.Table),
+			zap.String("file", issue.File), zap.String("reason", issue.Reason))
+	}
+	for _, issue := range report.MismatchedTables {
+		log.Error("table checksum mismatch", zap.String("table", issue.Table),
+			zap.Any("expected", issue.Expected), zap.Any("actual", issue.Actual))
+	}
+
+	if len(cfg.ReportFile) > 0 {
+		content, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := os.WriteFile(cfg.ReportFile, content, 0o644); err != nil {
+			return nil, errors.Annotatef(err, "failed to write the report file %s", cfg.ReportFile)
+		}
+	}
+	summary.CollectInt("verified files", report.VerifiedFiles)
+	summary.CollectInt("missing files", len(report.MissingFiles))
+	summary.CollectInt("corrupted files", len(report.CorruptedFiles))
+	summary.CollectInt("mismatched tables", len(report.MismatchedTables))
+	summary.SetSuccessStatus(report.Passed())
+	return report, nil
+}
+
+// groupFilesByRange groups the write CF file and the default CF file of the
+// same range together, since the values of the write CF file may be in the
+// default CF file.
+func groupFilesByRange(files []*backuppb.File) [][]*backuppb.File {
+	groups := make(map[string][]*backuppb.File, len(files))
+	keys := make([]string, 0, len(files))
+	for _, file := range files {
+		key := string(file.StartKey) + "\x00" + string(file.EndKey) + "\x00" +
+			strings.TrimSuffix(strings.TrimSuffix(file.Name, ".sst"), "_"+file.Cf)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], file)
+	}
+	result := make([][]*backuppb.File, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, groups[key])
+	}
+	return result
+}
+
+type verifyFilesResult struct {
+	verified  int
+	missing   []VerifyFileIssue
+	corrupted []VerifyFileIssue
+	unchecked []VerifyFileIssue
+	// checksum is nil if it can't be recomputed.
+	checksum *FileChecksum
+}
+
+// verifyFiles verifies the files of the same range. The files are spilled to
+// local temporary files one by one while they are checked, so that the
+// checksum can be recomputed without loading the whole files into memory.
+func verifyFiles(
+	ctx context.Context,
+	s storage.ExternalStorage,
+	cipher *backuppb.CipherInfo,
+	files []*backuppb.File,
+	checkFileChecksum bool,
+) (verifyFilesResult, error) {
+	var result verifyFilesResult
+	var writeFile *backuppb.File
+	var writeSST, defaultSST *os.File
+	defer func() {
+		for _, f := range []*os.File{writeSST, defaultSST} {
+			if f != nil {
+				_ = f.Close()
+				_ = os.Remove(f.Name())
+			}
+		}
+	}()
+	broken := false
+	for _, file := range files {
+		tmp, err := os.CreateTemp("", "br-verify-*.sst")
+		if err != nil {
+			return result, errors.Annotate(err, "failed to create the temporary file")
+		}
+		issue, missing, err := readAndCheckFile(ctx, s, cipher, file, tmp)
+		if err == nil && issue == nil {
+			switch file.Cf {
+			case writeCF:
+				writeFile, writeSST = file, tmp
+				continue
+			case defaultCF:
+				defaultSST = tmp
+				continue
+			}
+		}
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		if issue != nil {
+			if missing {
+				result.missing = append(result.missing, *issue)
+			} else {
+				result.corrupted = append(result.corrupted, *issue)
+			}
+			broken = true
+		}
+	}
+	if broken {
+		return result, nil
+	}
+	if writeFile == nil {
+		for _, file := range files {
+			result.corrupted = append(result.corrupted, VerifyFileIssue{File: file.Name, Reason: "the write CF file of the range is missing"})
+		}
+		return result, nil
+	}
+
+	// the files are closed by ComputeTxnFileChecksum, and removed when it returns.
+	var defaultFile sstable.ReadableFile
+	if defaultSST != nil {
+		defaultFile = defaultSST
+	}
+	fileChecksum, err := ComputeTxnFileChecksum(writeSST, defaultFile)
+	if err != nil {
+		if berrors.Is(err, berrors.ErrBackupUnsupportedCompression) {
+			log.Warn("the compression of the backup file isn't supported, only its sha256 is checked",
+				zap.String("file", writeFile.Name), zap.Error(err))
+			for _, file := range files {
+				result.unchecked = append(result.unchecked, VerifyFileIssue{File: file.Name, Reason: err.Error()})
+			}
+			result.verified = len(files)
+			return result, nil
+		}
+		for _, file := range files {
+			result.corrupted = append(result.corrupted, VerifyFileIssue{File: file.Name, Reason: err.Error()})
+		}
+		return result, nil
+	}
+	expected := FileChecksum{
+		Crc64Xor:   writeFile.Crc64Xor,
+		TotalKvs:   writeFile.TotalKvs,
+		TotalBytes: writeFile.TotalBytes,
+	}
+	if checkFileChecksum && fileChecksum != expected {
+		result.corrupted = append(result.corrupted, VerifyFileIssue{
+			File: writeFile.Name,
+			Reason: fmt.Sprintf("checksum mismatch, expected crc64xor=%d kvs=%d bytes=%d, got crc64xor=%d kvs=%d bytes=%d",
+				expected.Crc64Xor, expected.TotalKvs, expected.TotalBytes,
+				fileChecksum.Crc64Xor, fileChecksum.TotalKvs, fileChecksum.TotalBytes),
+		})
+		return result, nil
+	}
+	log.Debug("backup files verified", logutil.File(writeFile), zap.Any("checksum", fileChecksum))
+	result.verified = len(files)
+	result.checksum = &fileChecksum
+	return result, nil
+}
+
+// readAndCheckFile streams the decrypted content of a file into dst while
+// checking its size and sha256. The returned error is a local error which
+// aborts the verification, the problems of the file itself are returned as
+// the issue.
+func readAndCheckFile(
+	ctx context.Context,
+	s storage.ExternalStorage,
+	cipher *backuppb.CipherInfo,
+	file *backuppb.File,
+	dst io.Writer,
+) (issue *VerifyFileIssue, missing bool, err error) {
+	reader, err := s.Open(ctx, file.Name, nil)
+	if err != nil {
+		if exists, existErr := s.FileExists(ctx, file.Name); existErr == nil && !exists {
+			return &VerifyFileIssue{File: file.Name, Reason: "the file doesn't exist"}, true, nil
+		}
+		return &VerifyFileIssue{File: file.Name, Reason: fmt.Sprintf("failed to read the file: %v", err)}, false, nil
+	}
+	defer reader.Close()
+	stream, err := newDecryptStream(cipher, file.CipherIv)
+	if err != nil {
+		return &VerifyFileIssue{File: file.Name, Reason: fmt.Sprintf("failed to decrypt the file: %v", err)}, false, nil
+	}
+
+	rawHash, hash := sha256.New(), sha256.New()
+	buf := make([]byte, 256*1024)
+	size := uint64(0)
+	for {
+		n, readErr := reader.Read(buf)
+		if n > 0 {
+			size += uint64(n)
+			rawHash.Write(buf[:n])
+			if stream != nil {
+				stream.XORKeyStream(buf[:n], buf[:n])
+			}
+			hash.Write(buf[:n])
+			if _, err := dst.Write(buf[:n]); err != nil {
+				return nil, false, errors.Annotatef(err, "failed to write the temporary file of %s", file.Name)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			if ctx.Err() != nil {
+				return nil, false, errors.Trace(ctx.Err())
+			}
+			return &VerifyFileIssue{File: file.Name, Reason: fmt.Sprintf("failed to read the file: %v", readErr)}, false, nil
+		}
+	}
+	if file.Size_ > 0 && size != file.Size_ {
+		return &VerifyFileIssue{
+			File:   file.Name,
+			Reason: fmt.Sprintf("size mismatch, expected %d, got %d", file.Size_, size),
+		}, false, nil
+	}
+	if len(file.Sha256) > 0 {
+		sum := hash.Sum(nil)
+		if !bytes.Equal(sum, file.Sha256) && !bytes.Equal(rawHash.Sum(nil), file.Sha256) {
+			return &VerifyFileIssue{
+				File: file.Name,
+				Reason: fmt.Sprintf("sha256 mismatch, expected %s, got %s",
+					hex.EncodeToString(file.Sha256), hex.EncodeToString(sum)),
+			}, false, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// newDecryptStream returns the stream to decrypt a file, the same as
+// metautil.Decrypt does. It returns nil if the file isn't encrypted.
+func newDecryptStream(cipherInfo *backuppb.CipherInfo, iv []byte) (cipher.Stream, error) {
+	if cipherInfo == nil {
+		return nil, nil
+	}
+	switch cipherInfo.CipherType {
+	case encryptionpb.EncryptionMethod_PLAINTEXT:
+		return nil, nil
+	case encryptionpb.EncryptionMethod_AES128_CTR,
+		encryptionpb.EncryptionMethod_AES192_CTR,
+		encryptionpb.EncryptionMethod_AES256_CTR:
+		block, err := aes.NewCipher(cipherInfo.CipherKey)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(iv) != block.BlockSize() {
+			return nil, errors.Errorf("invalid iv length %d", len(iv))
+		}
+		return cipher.NewCTR(block, iv), nil
+	default:
+		return nil, errors.Annotate(berrors.ErrInvalidArgument, "cipher type invalid")
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package task
+
+import (
+	"bytes"
+	"hash/crc64"
+	"io"
+
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/pingcap/errors"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/stream"
+	"github.com/pingcap/tidb/pkg/util/codec"
+)
+
+var ecmaTable = crc64.MakeTable(crc64.ECMA)
+
+const (
+	tsLen = 8
+	// dataKeyPrefix is the prefix of the keys stored in TiKV.
+	dataKeyPrefix byte = 'z'
+)
+
+// FileChecksum is the checksum of the key-value pairs in backup files.
+type FileChecksum struct {
+	Crc64Xor   uint64
+	TotalKvs   uint64
+	TotalBytes uint64
+}
+
+// Update merges the checksum of other files into the checksum.
+func (c *FileChecksum) Update(other FileChecksum) {
+	c.Crc64Xor ^= other.Crc64Xor
+	c.TotalKvs += other.TotalKvs
+	c.TotalBytes += other.TotalBytes
+}
+
+// ComputeTxnFileChecksum recomputes the checksum of a transactional backup file
+// offline from its write CF SST and default CF SST, the default CF SST can be
+// nil if all the values are short values. Like TiKV, each committed row
+// contributes the CRC64 of its raw key and value. The SSTs are read block by
+// block instead of being loaded into memory, and both files are closed when it
+// returns.
+func ComputeTxnFileChecksum(writeSST, defaultSST sstable.ReadableFile) (_ FileChecksum, err error) {
+	var checksum FileChecksum
+	writeReader, err := openSST(writeSST)
+	if err != nil {
+		if defaultSST != nil {
+			_ = defaultSST.Close()
+		}
+		return checksum, errors.Annotate(err, "failed to read the write CF SST")
+	}
+	defer closeAndKeepError(writeReader, &err)
+	var defaultIter sstable.Iterator
+	if defaultSST != nil {
+		defaultReader, err := openSST(defaultSST)
+		if err != nil {
+			return checksum, errors.Annotate(err, "failed to read the default CF SST")
+		}
+		defer closeAndKeepError(defaultReader, &err)
+		if defaultIter, err = defaultReader.NewIter(nil, nil); err != nil {
+			return checksum, errors.Annotate(err, "failed to read the default CF SST")
+		}
+		defer closeAndKeepError(defaultIter, &err)
+	}
+
+	err = iterateSST(writeReader, func(key, value []byte) error {
+		if len(key) <= tsLen {
+			return errors.Errorf("invalid key %X in the write CF SST", key)
+		}
+		encodedKey := key[:len(key)-tsLen]
+		var write stream.RawWriteCFValue
+		if err := write.ParseFrom(value); err != nil {
+			return errors.Annotatef(err, "failed to parse the write of key %X", key)
+		}
+		if write.GetWriteType() != stream.WriteTypePut {
+			return nil
+		}
+		// an empty short value is not nil, the value is stored in the default CF only if there is no short value.
+		shortValue := write.GetShortValue()
+		if shortValue == nil {
+			defaultKey := codec.EncodeUintDesc(append([]byte{}, encodedKey...), write.GetStartTs())
+			if shortValue, err = seekDefault(defaultIter, defaultKey); err != nil {
+				return errors.Annotatef(err, "failed to read the value of key %X", key)
+			}
+			if shortValue == nil {
+				return errors.Errorf("the value of key %X isn't found in the default CF SST", key)
+			}
+		}
+		if len(encodedKey) > 0 && encodedKey[0] == dataKeyPrefix {
+			encodedKey = encodedKey[1:]
+		}
+		_, rawKey, err := codec.DecodeBytes(encodedKey, nil)
+		if err != nil {
+			return errors.Annotatef(err, "failed to decode key %X", key)
+		}
+		sum := crc64.Update(0, ecmaTable, rawKey)
+		sum = crc64.Update(sum, ecmaTable, shortValue)
+		checksum.Crc64Xor ^= sum
+		checksum.TotalKvs++
+		checksum.TotalBytes += uint64(len(rawKey) + len(shortValue))
+		return nil
+	})
+	if err != nil {
+		return checksum, errors.Annotate(err, "failed to read the write CF SST")
+	}
+	return checksum, nil
+}
+
+// openSST opens an SST and checks whether its blocks can be decoded, the file
+// is closed if it fails.
+func openSST(f sstable.ReadableFile) (*sstable.Reader, error) {
+	reader, err := sstable.NewReader(f, sstable.ReaderOptions{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch name := reader.Properties.CompressionName; name {
+	// the SSTs written by TiKV record the compression of the column family,
+	// an empty name means the property isn't recorded.
+	case "", sstable.NoCompression.String(), sstable.SnappyCompression.String(), sstable.ZstdCompression.String():
+	default:
+		_ = reader.Close()
+		return nil, errors.Trace(berrors.ErrBackupUnsupportedCompression.GenWithStackByArgs(name))
+	}
+	return reader, nil
+}
+
+func closeAndKeepError(c io.Closer, err *error) {
+	if closeErr := c.Close(); *err == nil {
+		*err = errors.Trace(closeErr)
+	}
+}
+
+// iterateSST iterates the key-value pairs of an SST.
+func iterateSST(reader *sstable.Reader, fn func(key, value []byte) error) (err error) {
+	iter, err := reader.NewIter(nil, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closeAndKeepError(iter, &err)
+	for k, v := iter.First(); k != nil; k, v = iter.Next() {
+		if err := fn(k.UserKey, v); err != nil {
+			return err
+		}
+	}
+	return errors.Trace(iter.Error())
+}
+
+// seekDefault returns the value of the key in the default CF, or nil if it
+// isn't found. Both CFs are sorted by the key and then the ts in descending
+// order, so the seeks follow the iteration of the write CF forward.
+func seekDefault(iter sstable.Iterator, key []byte) ([]byte, error) {
+	if iter == nil {
+		return nil, nil
+	}
+	k, v := iter.SeekGE(key, false)
+	if k == nil || !bytes.Equal(k.UserKey, key) {
+		return nil, errors.Trace(iter.Error())
+	}
+	return v, nil
+}
@@ -0,0 +1,286 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package task
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/golang/protobuf/proto"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/kvproto/pkg/encryptionpb"
+	"github.com/pingcap/tidb/br/pkg/glue"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/encrypt"
+	filter "github.com/pingcap/tidb/pkg/util/table-filter"
+	"github.com/stretchr/testify/require"
+)
+
+type verifyTestGlue struct {
+	glue.Glue
+}
+
+type verifyTestProgress struct{}
+
+func (verifyTestProgress) Inc()              {}
+func (verifyTestProgress) IncBy(int64)       {}
+func (verifyTestProgress) GetCurrent() int64 { return 0 }
+func (verifyTestProgress) Close()            {}
+
+func (verifyTestGlue) StartProgress(context.Context, string, int64, bool) glue.Progress {
+	return verifyTestProgress{}
+}
+
+func (verifyTestGlue) Record(string, uint64) {}
+
+func writeTestSST(t *testing.T, path string, kvs [][2][]byte) []byte {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w := sstable.NewWriter(f, sstable.WriterOptions{})
+	for _, kv := range kvs {
+		require.NoError(t, w.Set(kv[0], kv[1]))
+	}
+	require.NoError(t, w.Close())
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return content
+}
+
+// writeTestBackupFiles writes the write CF and default CF SSTs of the rows
+// [start, end) of a table, like TiKV does.
+func writeTestBackupFiles(t *testing.T, dir string, tableID int64, start, end int64) []*backuppb.File {
+	var writeKVs, defaultKVs [][2][]byte
+	var crc, totalKvs, totalBytes uint64
+	for i := start; i < end; i++ {
+		rawKey := tablecodec.EncodeRowKeyWithHandle(tableID, kv.IntHandle(i))
+		encodedKey := append([]byte{'z'}, codec.EncodeBytes(nil, rawKey)...)
+		value := []byte(strings.Repeat("v", int(i)))
+		// the timestamps allocated by PD are larger than 2^56
+		startTS, commitTS := uint64(450000000000000000+i), uint64(450000000000001000+i)
+		write := binary.AppendUvarint([]byte{'P'}, startTS)
+		if i%2 == 0 {
+			write = append(append(write, 'v', byte(len(value))), value...)
+		} else {
+			defaultKVs = append(defaultKVs, [2][]byte{codec.EncodeUintDesc(append([]byte{}, encodedKey...), startTS), value})
+		}
+		// the flags written by TiKV after the short value
+		if i%3 == 0 {
+			write = codec.EncodeUint(append(write, 'F'), commitTS+1)
+		}
+		if i%5 == 0 {
+			write = binary.AppendUvarint(append(write, 'S'), 1)
+		}
+		writeKVs = append(writeKVs, [2][]byte{codec.EncodeUintDesc(append([]byte{}, encodedKey...), commitTS), write})
+
+		sum := crc64.Update(0, crc64.MakeTable(crc64.ECMA), rawKey)
+		crc ^= crc64.Update(sum, crc64.MakeTable(crc64.ECMA), value)
+		totalKvs++
+		totalBytes += uint64(len(rawKey) + len(value))
+	}
+
+	startKey := tablecodec.EncodeRowKeyWithHandle(tableID, kv.IntHandle(start))
+	endKey := tablecodec.EncodeRowKeyWithHandle(tableID, kv.IntHandle(end))
+	files := make([]*backuppb.File, 0, 2)
+	for _, cf := range []string{"default", "write"} {
+		kvs := defaultKVs
+		if cf == "write" {
+			kvs = writeKVs
+		}
+		name := fmt.Sprintf("%d_%d_%s.sst", tableID, start, cf)
+		content := writeTestSST(t, filepath.Join(dir, name), kvs)
+		sum := sha256.Sum256(content)
+		file := &backuppb.File{
+			Name:     name,
+			Sha256:   sum[:],
+			StartKey: startKey,
+			EndKey:   endKey,
+			Cf:       cf,
+			Size_:    uint64(len(content)),
+		}
+		if cf == "write" {
+			file.Crc64Xor, file.TotalKvs, file.TotalBytes = crc, totalKvs, totalBytes
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+func writeTestBackupMeta(t *testing.T, dir string, files []*backuppb.File, tables ...*metautil.Table) {
+	meta := &backuppb.BackupMeta{ClusterId: 1, EndVersion: 1000, Files: files}
+	for _, tbl := range tables {
+		dbInfo, err := json.Marshal(tbl.DB)
+		require.NoError(t, err)
+		tableInfo, err := json.Marshal(tbl.Info)
+		require.NoError(t, err)
+		meta.Schemas = append(meta.Schemas, &backuppb.Schema{
+			Db:         dbInfo,
+			Table:      tableInfo,
+			Crc64Xor:   tbl.Crc64Xor,
+			TotalKvs:   tbl.TotalKvs,
+			TotalBytes: tbl.TotalBytes,
+		})
+	}
+	data, err := proto.Marshal(meta)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, metautil.MetaFile), data, 0o644))
+}
+
+func TestRunVerify(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dbInfo := &model.DBInfo{ID: 1, Name: model.NewCIStr("test")}
+	t1 := &metautil.Table{DB: dbInfo, Info: &model.TableInfo{ID: 100, Name: model.NewCIStr("t1")}}
+	t2 := &metautil.Table{DB: dbInfo, Info: &model.TableInfo{ID: 200, Name: model.NewCIStr("t2")}}
+	var files []*backuppb.File
+	for _, r := range []struct {
+		tbl        *metautil.Table
+		start, end int64
+	}{{t1, 1, 50}, {t1, 50, 100}, {t2, 1, 20}} {
+		rangeFiles := writeTestBackupFiles(t, dir, r.tbl.Info.ID, r.start, r.end)
+		write := rangeFiles[1]
+		r.tbl.Crc64Xor ^= write.Crc64Xor
+		r.tbl.TotalKvs += write.TotalKvs
+		r.tbl.TotalBytes += write.TotalBytes
+		files = append(files, rangeFiles...)
+	}
+	writeTestBackupMeta(t, dir, files, t1, t2)
+
+	cfg := &VerifyConfig{Config: Config{
+		Storage:     "local://" + dir,
+		TableFilter: filter.All(),
+		CipherInfo:  backuppb.CipherInfo{CipherType: encryptionpb.EncryptionMethod_PLAINTEXT},
+	}}
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+	cfg.ReportFile = reportFile
+	report, err := RunVerify(ctx, verifyTestGlue{}, "Verify", cfg)
+	require.NoError(t, err)
+	require.True(t, report.Passed(), "%+v", report)
+	require.Equal(t, 6, report.TotalFiles)
+	require.Equal(t, 6, report.VerifiedFiles)
+	var buf bytes.Buffer
+	report.Print(&buf)
+	require.Contains(t, buf.String(), "backup verify succeed!")
+	content, err := os.ReadFile(reportFile)
+	require.NoError(t, err)
+	require.Contains(t, string(content), `"verified_files": 6`)
+
+	// the table checksum in the backupmeta mismatches.
+	t2.Crc64Xor++
+	writeTestBackupMeta(t, dir, files, t1, t2)
+	report, err = RunVerify(ctx, verifyTestGlue{}, "Verify", cfg)
+	require.NoError(t, err)
+	require.False(t, report.Passed())
+	require.Len(t, report.MismatchedTables, 1)
+	require.Equal(t, "`test`.`t2`", report.MismatchedTables[0].Table)
+	require.Equal(t, t2.Crc64Xor-1, report.MismatchedTables[0].Actual.Crc64Xor)
+	t2.Crc64Xor--
+
+	// the file checksum mismatches, the table isn't checked any more.
+	files[5].TotalKvs++
+	writeTestBackupMeta(t, dir, files, t1, t2)
+	report, err = RunVerify(ctx, verifyTestGlue{}, "Verify", cfg)
+	require.NoError(t, err)
+	require.Len(t, report.CorruptedFiles, 1)
+	require.Equal(t, files[5].Name, report.CorruptedFiles[0].File)
+	require.Contains(t, report.CorruptedFiles[0].Reason, "checksum mismatch")
+	require.Empty(t, report.MismatchedTables)
+	require.Equal(t, 4, report.VerifiedFiles)
+	files[5].TotalKvs--
+	writeTestBackupMeta(t, dir, files, t1, t2)
+
+	// a file is corrupted and another file is missing.
+	path := filepath.Join(dir, files[0].Name)
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	content[len(content)/2] ^= 1
+	require.NoError(t, os.WriteFile(path, content, 0o644))
+	require.NoError(t, os.Remove(filepath.Join(dir, files[3].Name)))
+	cfg.ReportFile = ""
+	report, err = RunVerify(ctx, verifyTestGlue{}, "Verify", cfg)
+	require.NoError(t, err)
+	require.False(t, report.Passed())
+	require.Equal(t, 2, report.VerifiedFiles)
+	require.Len(t, report.CorruptedFiles, 1)
+	require.Equal(t, files[0].Name, report.CorruptedFiles[0].File)
+	require.Contains(t, report.CorruptedFiles[0].Reason, "sha256 mismatch")
+	require.Len(t, report.MissingFiles, 1)
+	require.Equal(t, files[3].Name, report.MissingFiles[0].File)
+	require.Equal(t, "`test`.`t1`", report.MissingFiles[0].Table)
+	buf.Reset()
+	report.Print(&buf)
+	require.Contains(t, buf.String(), "missing files (1)")
+	require.Contains(t, buf.String(), "corrupted files (1)")
+
+	// only the filtered tables are verified.
+	cfg.TableFilter, err = filter.Parse([]string{"test.t2"})
+	require.NoError(t, err)
+	report, err = RunVerify(ctx, verifyTestGlue{}, "Verify", cfg)
+	require.NoError(t, err)
+	require.True(t, report.Passed())
+	require.Equal(t, 2, report.TotalFiles)
+}
+
+func TestRunVerifyEncrypted(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	key := []byte("0123456789abcdef0123456789abcdef")
+	dbInfo := &model.DBInfo{ID: 1, Name: model.NewCIStr("test")}
+	tbl := &metautil.Table{DB: dbInfo, Info: &model.TableInfo{ID: 100, Name: model.NewCIStr("t")}}
+	files := writeTestBackupFiles(t, dir, tbl.Info.ID, 1, 50)
+	tbl.Crc64Xor, tbl.TotalKvs, tbl.TotalBytes = files[1].Crc64Xor, files[1].TotalKvs, files[1].TotalBytes
+	for i, file := range files {
+		path := filepath.Join(dir, file.Name)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		iv := bytes.Repeat([]byte{byte(i + 1)}, metautil.CrypterIvLen)
+		encrypted, err := encrypt.AESEncryptWithCTR(content, key, iv)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, encrypted, 0o644))
+		file.CipherIv = iv
+	}
+	writeTestBackupMeta(t, dir, files, tbl)
+	metaPath := filepath.Join(dir, metautil.MetaFile)
+	meta, err := os.ReadFile(metaPath)
+	require.NoError(t, err)
+	metaIV := bytes.Repeat([]byte{0xff}, metautil.CrypterIvLen)
+	encryptedMeta, err := encrypt.AESEncryptWithCTR(meta, key, metaIV)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(metaPath, append(metaIV, encryptedMeta...), 0o644))
+
+	cfg := &VerifyConfig{Config: Config{
+		Storage:     "local://" + dir,
+		TableFilter: filter.All(),
+		CipherInfo:  backuppb.CipherInfo{CipherType: encryptionpb.EncryptionMethod_AES256_CTR, CipherKey: key},
+	}}
+	report, err := RunVerify(ctx, verifyTestGlue{}, "Verify", cfg)
+	require.NoError(t, err)
+	require.True(t, report.Passed(), "%+v", report)
+	require.Equal(t, 2, report.VerifiedFiles)
+
+	// the files can't be decoded with a wrong iv.
+	files[1].CipherIv = bytes.Repeat([]byte{0xee}, metautil.CrypterIvLen)
+	writeTestBackupMeta(t, dir, files, tbl)
+	meta, err = os.ReadFile(metaPath)
+	require.NoError(t, err)
+	encryptedMeta, err = encrypt.AESEncryptWithCTR(meta, key, metaIV)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(metaPath, append(metaIV, encryptedMeta...), 0o644))
+	report, err = RunVerify(ctx, verifyTestGlue{}, "Verify", cfg)
+	require.NoError(t, err)
+	require.Len(t, report.CorruptedFiles, 1)
+	require.Contains(t, report.CorruptedFiles[0].Reason, "sha256 mismatch")
+}
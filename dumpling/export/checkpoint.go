@@ -0,0 +1,198 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"go.uber.org/zap"
+)
+
+const (
+	checkpointFileName      = "dumpling-checkpoint.json"
+	checkpointFlushInterval = 10 * time.Second
+)
+
+// checkpointChunk is a chunk of table data recorded in the checkpoint. The
+// queries of the chunk are recorded instead of how the table is split, because
+// the split (e.g. by TiDB regions) may change when the dump is resumed.
+type checkpointChunk struct {
+	Index       int      `json:"index"`
+	TotalChunks int      `json:"total-chunks"`
+	Queries     []string `json:"queries"`
+	ColLen      int      `json:"col-len"`
+	Finished    bool     `json:"finished"`
+}
+
+// checkpoint is the progress of a dump recorded in the output storage.
+type checkpoint struct {
+	Snapshot           string               `json:"snapshot"`
+	ServiceSafePointID string               `json:"service-safe-point-id,omitempty"`
+	FileType           string               `json:"file-type"`
+	CompressType       storage.CompressType `json:"compress-type"`
+	// Tables maps the quoted names of the tables whose chunks have been
+	// planned to their chunks.
+	Tables map[string][]*checkpointChunk `json:"tables"`
+}
+
+func checkpointTableKey(db, tbl string) string {
+	return fmt.Sprintf("`%s`.`%s`", escapeString(db), escapeString(tbl))
+}
+
+// readCheckpoint reads the checkpoint from the storage, it returns nil if the
+// checkpoint doesn't exist.
+func readCheckpoint(ctx context.Context, s storage.ExternalStorage) (*checkpoint, error) {
+	exists, err := s.FileExists(ctx, checkpointFileName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !exists {
+		return nil, nil
+	}
+	content, err := s.ReadFile(ctx, checkpointFileName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cp := &checkpoint{}
+	if err := json.Unmarshal(content, cp); err != nil {
+		return nil, errors.Annotatef(err, "failed to parse checkpoint file %s", checkpointFileName)
+	}
+	if cp.Tables == nil {
+		cp.Tables = make(map[string][]*checkpointChunk)
+	}
+	return cp, nil
+}
+
+// checkpointManager records the planned and finished chunks of table data, and
+// flushes them to the output storage.
+type checkpointManager struct {
+	storage storage.ExternalStorage
+
+	// flushMu makes sure that the checkpoint files are written in order.
+	flushMu sync.Mutex
+	mu      sync.Mutex
+	cp      *checkpoint
+	dirty   bool
+}
+
+func newCheckpointManager(s storage.ExternalStorage, cp *checkpoint) *checkpointManager {
+	if cp.Tables == nil {
+		cp.Tables = make(map[string][]*checkpointChunk)
+	}
+	return &checkpointManager{storage: s, cp: cp, dirty: true}
+}
+
+// tableTasks rebuilds the tasks of the table from the checkpoint, it returns
+// false if the chunks of the table haven't been planned.
+func (m *checkpointManager) tableTasks(d *Dumper, meta TableMeta) (tasks []*TaskTableData, finished []bool, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	chunks, ok := m.cp.Tables[checkpointTableKey(meta.DatabaseName(), meta.TableName())]
+	if !ok {
+		return nil, nil, false
+	}
+	tasks = make([]*TaskTableData, 0, len(chunks))
+	finished = make([]bool, 0, len(chunks))
+	for _, chunk := range chunks {
+		var data TableDataIR
+		if len(chunk.Queries) == 1 {
+			data = newTableData(chunk.Queries[0], chunk.ColLen, false)
+		} else {
+			data = newMultiQueriesChunk(chunk.Queries, chunk.ColLen)
+		}
+		tasks = append(tasks, d.newTaskTableData(meta, data, chunk.Index, chunk.TotalChunks))
+		finished = append(finished, chunk.Finished)
+	}
+	return tasks, finished, true
+}
+
+// recordTable records the planned chunks of the table and flushes the
+// checkpoint, so that no chunk is finished before its table is recorded. It
+// returns false if the chunks can't be recorded.
+func (m *checkpointManager) recordTable(ctx context.Context, meta TableMeta, tasks []*TaskTableData) (bool, error) {
+	chunks := make([]*checkpointChunk, 0, len(tasks))
+	for _, task := range tasks {
+		chunk := &checkpointChunk{Index: task.ChunkIndex, TotalChunks: task.TotalChunks}
+		switch data := task.Data.(type) {
+		case *tableData:
+			chunk.Queries, chunk.ColLen = []string{data.query}, data.colLen
+		case *multiQueriesChunk:
+			chunk.Queries, chunk.ColLen = data.queries, data.colLen
+		default:
+			return false, nil
+		}
+		chunks = append(chunks, chunk)
+	}
+	m.mu.Lock()
+	m.cp.Tables[checkpointTableKey(meta.DatabaseName(), meta.TableName())] = chunks
+	m.dirty = true
+	m.mu.Unlock()
+	return true, m.flush(ctx)
+}
+
+// finishChunk marks the chunk as finished, it will be flushed later.
+func (m *checkpointManager) finishChunk(db, tbl string, index int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, chunk := range m.cp.Tables[checkpointTableKey(db, tbl)] {
+		if chunk.Index == index {
+			chunk.Finished = true
+			m.dirty = true
+			return
+		}
+	}
+}
+
+// flush writes the checkpoint to the storage if it's changed.
+func (m *checkpointManager) flush(ctx context.Context) error {
+	m.flushMu.Lock()
+	defer m.flushMu.Unlock()
+	m.mu.Lock()
+	if !m.dirty {
+		m.mu.Unlock()
+		return nil
+	}
+	content, err := json.Marshal(m.cp)
+	m.dirty = false
+	m.mu.Unlock()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := m.storage.WriteFile(ctx, checkpointFileName, content); err != nil {
+		m.mu.Lock()
+		m.dirty = true
+		m.mu.Unlock()
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// run flushes the checkpoint periodically until the context is done.
+func (m *checkpointManager) run(tctx *tcontext.Context) {
+	ticker := time.NewTicker(checkpointFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-tctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.flush(tctx); err != nil {
+				tctx.L().Warn("failed to flush checkpoint", zap.Error(err))
+			}
+		}
+	}
+}
+
+// remove removes the checkpoint after the dump is finished.
+func (m *checkpointManager) remove(ctx context.Context) error {
+	m.flushMu.Lock()
+	defer m.flushMu.Unlock()
+	return errors.Trace(m.storage.DeleteFile(ctx, checkpointFileName))
+}
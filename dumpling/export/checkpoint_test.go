@@ -0,0 +1,168 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tidb/br/pkg/storage"
+	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"github.com/pingcap/tidb/pkg/util/promutil"
+	"github.com/stretchr/testify/require"
+	pd "github.com/tikv/pd/client"
+)
+
+func newCheckpointTestDumper(t *testing.T) *Dumper {
+	s, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	tctx, cancel := tcontext.Background().WithLogger(appLogger).WithCancel()
+	t.Cleanup(cancel)
+	conf := defaultConfigForTest(t)
+	conf.SortByPk = false
+	conf.Consistency = ConsistencyTypeSnapshot
+	return &Dumper{
+		tctx:      tctx,
+		conf:      conf,
+		cancelCtx: cancel,
+		extStore:  s,
+		metrics:   newMetrics(promutil.NewDefaultFactory(), nil),
+	}
+}
+
+func TestCheckpointManager(t *testing.T) {
+	d := newCheckpointTestDumper(t)
+	ctx := context.Background()
+	cp, err := readCheckpoint(ctx, d.extStore)
+	require.NoError(t, err)
+	require.Nil(t, cp)
+
+	mgr := newCheckpointManager(d.extStore, &checkpoint{Snapshot: "100", FileType: "sql"})
+	meta := &tableMeta{database: "test", table: "t`1", selectedLen: 2}
+	_, _, ok := mgr.tableTasks(d, meta)
+	require.False(t, ok)
+	tasks := []*TaskTableData{
+		NewTaskTableData(meta, newTableData("SELECT * FROM t WHERE a < 10", 2, false), 0, 2),
+		NewTaskTableData(meta, newMultiQueriesChunk([]string{"q1", "q2"}, 2), 1, 2),
+	}
+	recorded, err := mgr.recordTable(ctx, meta, tasks)
+	require.NoError(t, err)
+	require.True(t, recorded)
+	mgr.finishChunk("test", "t`1", 1)
+	mgr.finishChunk("test", "t2", 0)
+
+	// the finished chunk isn't flushed yet.
+	cp, err = readCheckpoint(ctx, d.extStore)
+	require.NoError(t, err)
+	require.Equal(t, "100", cp.Snapshot)
+	require.False(t, cp.Tables["`test`.`t``1`"][1].Finished)
+
+	require.NoError(t, mgr.flush(ctx))
+	cp, err = readCheckpoint(ctx, d.extStore)
+	require.NoError(t, err)
+	resumed := newCheckpointManager(d.extStore, cp)
+	tasks, finished, ok := resumed.tableTasks(d, meta)
+	require.True(t, ok)
+	require.Equal(t, []bool{false, true}, finished)
+	require.Len(t, tasks, 2)
+	require.Equal(t, newTableData("SELECT * FROM t WHERE a < 10", 2, false), tasks[0].Data)
+	require.Equal(t, 0, tasks[0].ChunkIndex)
+	require.Equal(t, newMultiQueriesChunk([]string{"q1", "q2"}, 2), tasks[1].Data)
+	require.Equal(t, 1, tasks[1].ChunkIndex)
+	require.Equal(t, 2, tasks[1].TotalChunks)
+
+	// the unknown table data can't be recorded.
+	recorded, err = mgr.recordTable(ctx, meta, []*TaskTableData{NewTaskTableData(meta, newMockTableIR("test", "t", nil, nil, nil), 0, 1)})
+	require.NoError(t, err)
+	require.False(t, recorded)
+
+	require.NoError(t, mgr.remove(ctx))
+	cp, err = readCheckpoint(ctx, d.extStore)
+	require.NoError(t, err)
+	require.Nil(t, cp)
+}
+
+func TestDumpTableDataWithCheckpoint(t *testing.T) {
+	d := newCheckpointTestDumper(t)
+	d.checkpointMgr = newCheckpointManager(d.extStore, &checkpoint{Snapshot: "100"})
+	meta := &tableMeta{database: "test", table: "t", selectedField: "*", selectedLen: 2}
+
+	// the table is dumped as a whole and recorded in the checkpoint.
+	taskChan := make(chan Task, 8)
+	require.NoError(t, d.dumpTableDataWithCheckpoint(d.tctx, nil, meta, taskChan))
+	require.Len(t, taskChan, 1)
+	task := (<-taskChan).(*TaskTableData)
+	require.Equal(t, "SELECT * FROM `test`.`t`", task.Data.(*tableData).query)
+	cp, err := readCheckpoint(d.tctx, d.extStore)
+	require.NoError(t, err)
+	require.Len(t, cp.Tables["`test`.`t`"], 1)
+
+	// the recorded chunks are used and the finished ones are skipped.
+	d.checkpointMgr.cp.Tables["`test`.`t`"] = []*checkpointChunk{
+		{Index: 0, TotalChunks: 3, Queries: []string{"q0"}, ColLen: 2, Finished: true},
+		{Index: 1, TotalChunks: 3, Queries: []string{"q1"}, ColLen: 2},
+		{Index: 2, TotalChunks: 3, Queries: []string{"q2", "q3"}, ColLen: 2, Finished: true},
+	}
+	require.NoError(t, d.dumpTableDataWithCheckpoint(d.tctx, nil, meta, taskChan))
+	require.Len(t, taskChan, 1)
+	task = (<-taskChan).(*TaskTableData)
+	require.Equal(t, 1, task.ChunkIndex)
+	require.Equal(t, "q1", task.Data.(*tableData).query)
+	d.checkpointMgr.finishChunk("test", "t", 1)
+	require.True(t, d.checkpointMgr.cp.Tables["`test`.`t`"][1].Finished)
+}
+
+func TestLoadCheckpoint(t *testing.T) {
+	d := newCheckpointTestDumper(t)
+	require.NoError(t, loadCheckpoint(d))
+	require.Nil(t, d.checkpointMgr)
+
+	// a new dump starts if there is no checkpoint.
+	d.conf.Resume = true
+	require.NoError(t, loadCheckpoint(d))
+	require.Nil(t, d.checkpointMgr)
+	d.conf.Snapshot = "100"
+	d.serviceSafePointID = "dumpling_1"
+	require.NoError(t, initCheckpoint(d))
+	require.NoError(t, d.checkpointMgr.flush(d.tctx))
+
+	d.checkpointMgr = nil
+	d.conf.Snapshot = ""
+	d.serviceSafePointID = ""
+	require.NoError(t, loadCheckpoint(d))
+	require.NotNil(t, d.checkpointMgr)
+	require.Equal(t, "100", d.conf.Snapshot)
+	require.Equal(t, "dumpling_1", d.checkpointMgr.cp.ServiceSafePointID)
+
+	d.conf.Snapshot = "200"
+	require.ErrorContains(t, loadCheckpoint(d), "snapshot 200 mismatches the snapshot 100 of the checkpoint")
+	d.conf.Snapshot = ""
+	d.conf.FileType = FileFormatCSVString
+	require.ErrorContains(t, loadCheckpoint(d), "file type csv mismatches the file type sql of the checkpoint")
+	d.conf.FileType = FileFormatSQLTextString
+	d.conf.Consistency = ConsistencyTypeFlush
+	require.ErrorContains(t, loadCheckpoint(d), "--resume is only supported")
+}
+
+type gcTestPDClient struct {
+	pd.Client
+	minSafePoint uint64
+	safePoints   map[string]uint64
+}
+
+func (c *gcTestPDClient) UpdateServiceGCSafePoint(_ context.Context, serviceID string, _ int64, safePoint uint64) (uint64, error) {
+	if safePoint >= c.minSafePoint {
+		c.safePoints[serviceID] = safePoint
+	}
+	return c.minSafePoint, nil
+}
+
+func TestCheckSnapshotNotGarbageCollected(t *testing.T) {
+	tctx := tcontext.Background().WithLogger(appLogger)
+	pdClient := &gcTestPDClient{minSafePoint: 100, safePoints: make(map[string]uint64)}
+	require.NoError(t, checkSnapshotNotGarbageCollected(tctx, pdClient, "dumpling_1", defaultDumpGCSafePointTTL, 100))
+	require.Equal(t, uint64(100), pdClient.safePoints["dumpling_1"])
+	err := checkSnapshotNotGarbageCollected(tctx, pdClient, "dumpling_2", defaultDumpGCSafePointTTL, 99)
+	require.ErrorContains(t, err, "the snapshot 99 of the checkpoint has been garbage collected")
+	require.NotContains(t, pdClient.safePoints, "dumpling_2")
+}
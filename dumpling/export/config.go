@@ -76,6 +76,8 @@ const (
 	flagTransactionalConsistency = "transactional-consistency"
 	flagCompress                 = "compress"
 	flagCsvOutputDialect         = "csv-output-dialect"
+	flagResume                   = "resume"
+	flagEncryptionMasterKeyFile  = "encryption-master-key-file"
 
 	// FlagHelp represents the help flag
 	FlagHelp = "help"
@@ -133,6 +135,7 @@ type Config struct {
 	EscapeBackslash          bool
 	DumpEmptyDatabase        bool
 	PosAfterConnect          bool
+	Resume                   bool
 	CompressType             storage.CompressType
 
 	Host     string
@@ -179,6 +182,9 @@ type Config struct {
 	Tables              DatabaseTables
 	CollationCompatible string
 	CsvOutputDialect    CSVDialect
+	// EncryptionMasterKeyFile is the file of the master key to encrypt the output files, the output files are not
+	// encrypted if it's empty.
+	EncryptionMasterKeyFile string
 
 	Labels       prometheus.Labels       `json:"-"`
 	PromFactory  promutil.Factory        `json:"-"`
@@ -325,7 +331,7 @@ func (*Config) DefineFlags(flags *pflag.FlagSet) {
 		"If not specified, dumpling will dump table without inner-concurrency which could be relatively slow. default unlimited")
 	flags.String(flagWhere, "", "Dump only selected records")
 	flags.Bool(flagEscapeBackslash, true, "use backslash to escape special characters")
-	flags.String(flagFiletype, "", "The type of export file (sql/csv)")
+	flags.String(flagFiletype, "", "The type of export file (sql/csv/parquet/jsonl)")
 	flags.Bool(flagNoHeader, false, "whether not to dump CSV table header")
 	flags.BoolP(flagNoSchemas, "m", false, "Do not dump table schemas with the data")
 	flags.BoolP(flagNoData, "d", false, "Do not dump table data")
@@ -350,8 +356,10 @@ func (*Config) DefineFlags(flags *pflag.FlagSet) {
 	_ = flags.MarkHidden(flagReadTimeout)
 	flags.Bool(flagTransactionalConsistency, true, "Only support transactional consistency")
 	_ = flags.MarkHidden(flagTransactionalConsistency)
-	flags.StringP(flagCompress, "c", "", "Compress output file type, support 'gzip', 'snappy', 'zstd', 'no-compression' now")
+	flags.StringP(flagCompress, "c", "", "Compress output file type, support 'gzip', 'snappy', 'zstd', 'lz4', 'no-compression' now")
 	flags.String(flagCsvOutputDialect, "", "The dialect of output CSV file, support 'snowflake', 'redshift', 'bigquery' now")
+	flags.Bool(flagResume, false, "Resume the dump from the checkpoint in the output directory with the same snapshot. Only support consistency snapshot")
+	flags.String(flagEncryptionMasterKeyFile, "", "The file of the 256 bits master key in hex to encrypt the output files, the files are not encrypted if it's empty")
 }
 
 // ParseFromFlags parses dumpling's export.Config from flags
@@ -510,6 +518,10 @@ func (conf *Config) ParseFromFlags(flags *pflag.FlagSet) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	conf.Resume, err = flags.GetBool(flagResume)
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	if conf.Threads <= 0 {
 		return errors.Errorf("--threads is set to %d. It should be greater than 0", conf.Threads)
@@ -576,6 +588,11 @@ func (conf *Config) ParseFromFlags(flags *pflag.FlagSet) error {
 	}
 	conf.OutputFileTemplate = tmpl
 
+	conf.EncryptionMasterKeyFile, err = flags.GetString(flagEncryptionMasterKeyFile)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	compressType, err := flags.GetString(flagCompress)
 	if err != nil {
 		return errors.Trace(err)
@@ -676,6 +693,8 @@ func ParseCompressType(compressType string) (storage.CompressType, error) {
 		return storage.Snappy, nil
 	case "zstd", "zst":
 		return storage.Zstd, nil
+	case "lz4":
+		return storage.LZ4, nil
 	default:
 		return storage.NoCompression, errors.Errorf("unknown compress type %s", compressType)
 	}
@@ -698,16 +717,26 @@ func ParseOutputDialect(outputDialect string) (CSVDialect, error) {
 }
 
 func (conf *Config) createExternalStorage(ctx context.Context) (storage.ExternalStorage, error) {
-	if conf.ExtStorage != nil {
-		return conf.ExtStorage, nil
+	s := conf.ExtStorage
+	if s == nil {
+		b, err := storage.ParseBackend(conf.OutputDirPath, &conf.BackendOptions)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// TODO: support setting httpClient with certification later
+		s, err = storage.New(ctx, b, &storage.ExternalStorageOptions{})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if conf.EncryptionMasterKeyFile == "" {
+		return s, nil
 	}
-	b, err := storage.ParseBackend(conf.OutputDirPath, &conf.BackendOptions)
+	masterKey, err := storage.NewFileMasterKey(conf.EncryptionMasterKeyFile)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-
-	// TODO: support setting httpClient with certification later
-	return storage.New(ctx, b, &storage.ExternalStorageOptions{})
+	return storage.WithEncryption(s, masterKey), nil
 }
 
 const (
@@ -782,7 +811,7 @@ func adjustFileFormat(conf *Config) error {
 		if conf.SQL != "" {
 			return errors.Errorf("unsupported config.FileType '%s' when we specify --sql, please unset --filetype or set it to 'csv'", conf.FileType)
 		}
-	case FileFormatCSVString:
+	case FileFormatCSVString, FileFormatParquetString, FileFormatJSONLString:
 	default:
 		return errors.Errorf("unknown config.FileType '%s'", conf.FileType)
 	}
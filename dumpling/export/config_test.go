@@ -4,6 +4,9 @@ package export
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pingcap/tidb/br/pkg/version"
@@ -18,6 +21,25 @@ func TestCreateExternalStorage(t *testing.T) {
 	require.Regexp(t, "^file:", loc.URI())
 }
 
+func TestCreateEncryptedExternalStorage(t *testing.T) {
+	mockConfig := defaultConfigForTest(t)
+	mockConfig.OutputDirPath = t.TempDir()
+	mockConfig.EncryptionMasterKeyFile = filepath.Join(t.TempDir(), "master.key")
+	_, err := mockConfig.createExternalStorage(tcontext.Background())
+	require.ErrorContains(t, err, "failed to read the master key file")
+
+	require.NoError(t, os.WriteFile(mockConfig.EncryptionMasterKeyFile, []byte(strings.Repeat("ab", 32)), 0o600))
+	s, err := mockConfig.createExternalStorage(tcontext.Background())
+	require.NoError(t, err)
+	require.NoError(t, s.WriteFile(tcontext.Background(), "test.t.000000000.sql", []byte("INSERT INTO t VALUES (1);\n")))
+	raw, err := os.ReadFile(filepath.Join(mockConfig.OutputDirPath, "test.t.000000000.sql"))
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), "INSERT INTO")
+	data, err := s.ReadFile(tcontext.Background(), "test.t.000000000.sql")
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO t VALUES (1);\n", string(data))
+}
+
 func TestMatchMysqlBugVersion(t *testing.T) {
 	cases := []struct {
 		serverInfo version.ServerInfo
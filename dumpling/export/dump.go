@@ -65,6 +65,8 @@ type Dumper struct {
 	selectTiDBTableRegionFunc     func(tctx *tcontext.Context, conn *BaseConn, meta TableMeta) (pkFields []string, pkVals [][]string, err error)
 	totalTables                   int64
 	charsetAndDefaultCollationMap map[string]string
+	serviceSafePointID            string
+	checkpointMgr                 *checkpointManager
 
 	speedRecorder *SpeedRecorder
 }
@@ -130,9 +132,11 @@ func NewDumper(ctx context.Context, conf *Config) (*Dumper, error) {
 		resolveAutoConsistency,
 
 		validateResolveAutoConsistency,
+		loadCheckpoint,
 		tidbSetPDClientForGC,
 		tidbGetSnapshot,
 		tidbStartGCSavepointUpdateService,
+		initCheckpoint,
 
 		setSessionParam)
 	return d, err
@@ -270,6 +274,27 @@ func (d *Dumper) Dump() (dumpErr error) {
 	}
 	defer tearDownWriters()
 
+	if d.checkpointMgr != nil {
+		if err = d.checkpointMgr.flush(tctx); err != nil {
+			return errors.Annotate(err, "failed to write checkpoint")
+		}
+		checkpointCtx, checkpointCancel := tctx.WithCancel()
+		go d.checkpointMgr.run(checkpointCtx)
+		defer func() {
+			checkpointCancel()
+			// the context may have been canceled, use a new one to save the progress.
+			if dumpErr != nil || tctx.Err() != nil {
+				if err := d.checkpointMgr.flush(context.Background()); err != nil {
+					tctx.L().Warn("failed to flush checkpoint", log.ShortError(err))
+				}
+				return
+			}
+			if err := d.checkpointMgr.remove(context.Background()); err != nil {
+				tctx.L().Warn("failed to remove checkpoint", log.ShortError(err))
+			}
+		}()
+	}
+
 	if conf.TransactionalConsistency {
 		if conf.Consistency == ConsistencyTypeFlush || conf.Consistency == ConsistencyTypeLock {
 			tctx.L().Info("All the dumping transactions have started. Start to unlock tables")
@@ -366,6 +391,9 @@ func (d *Dumper) startWriters(tctx *tcontext.Context, wg *errgroup.Group, taskCh
 			IncGauge(d.metrics.taskChannelCapacity)
 			if td, ok := task.(*TaskTableData); ok {
 				d.metrics.completedChunks.Add(1)
+				if d.checkpointMgr != nil {
+					d.checkpointMgr.finishChunk(td.Meta.DatabaseName(), td.Meta.TableName(), td.ChunkIndex)
+				}
 				tctx.L().Debug("finish dumping table data task",
 					zap.String("database", td.Meta.DatabaseName()),
 					zap.String("table", td.Meta.TableName()),
@@ -621,12 +649,63 @@ func (d *Dumper) dumpTableData(tctx *tcontext.Context, conn *BaseConn, meta Tabl
 	c := estimateCount(tctx, meta.DatabaseName(), meta.TableName(), conn, fieldName, conf)
 	AddCounter(d.metrics.estimateTotalRowsCounter, float64(c))
 
-	if conf.Rows == UnspecifiedSize {
+	if d.checkpointMgr != nil {
+		return d.dumpTableDataWithCheckpoint(tctx, conn, meta, taskChan)
+	}
+	return d.dumpTableChunks(tctx, conn, meta, taskChan)
+}
+
+func (d *Dumper) dumpTableChunks(tctx *tcontext.Context, conn *BaseConn, meta TableMeta, taskChan chan<- Task) error {
+	if d.conf.Rows == UnspecifiedSize {
 		return d.sequentialDumpTable(tctx, conn, meta, taskChan)
 	}
 	return d.concurrentDumpTable(tctx, conn, meta, taskChan)
 }
 
+// dumpTableDataWithCheckpoint records the chunks of the table in the checkpoint
+// before dumping them. If the table has been recorded by the dump to resume,
+// the recorded chunks are used and the finished ones are skipped.
+func (d *Dumper) dumpTableDataWithCheckpoint(tctx *tcontext.Context, conn *BaseConn, meta TableMeta, taskChan chan<- Task) error {
+	tasks, finished, ok := d.checkpointMgr.tableTasks(d, meta)
+	if !ok {
+		tableChan, tableTasks := infiniteChan[Task]()
+		err := d.dumpTableChunks(tctx, conn, meta, tableChan)
+		close(tableChan)
+		for task := range tableTasks {
+			// the task is sent to taskChan again later.
+			IncGauge(d.metrics.taskChannelCapacity)
+			tasks = append(tasks, task.(*TaskTableData))
+		}
+		if err != nil {
+			return err
+		}
+		recorded, err := d.checkpointMgr.recordTable(tctx, meta, tasks)
+		if err != nil {
+			return errors.Annotate(err, "failed to record table chunks in checkpoint")
+		}
+		if !recorded {
+			tctx.L().Warn("cannot record table chunks in checkpoint, the table will be dumped again when resuming",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()))
+		}
+		finished = make([]bool, len(tasks))
+	}
+	for i, task := range tasks {
+		if finished[i] {
+			d.metrics.completedChunks.Add(1)
+			if task.ChunkIndex+1 == task.TotalChunks {
+				IncCounter(d.metrics.finishedTablesCounter)
+			}
+			continue
+		}
+		ctxDone := d.sendTaskToChan(tctx, task, taskChan)
+		if ctxDone {
+			return tctx.Err()
+		}
+	}
+	return nil
+}
+
 func (d *Dumper) buildConcatTask(tctx *tcontext.Context, conn *BaseConn, meta TableMeta) (*TaskTableData, error) {
 	tableChan := make(chan Task, 128)
 	errCh := make(chan error, 1)
@@ -1492,6 +1571,62 @@ func tidbGetSnapshot(d *Dumper) error {
 	return nil
 }
 
+// loadCheckpoint is an initialization step of Dumper.
+func loadCheckpoint(d *Dumper) error {
+	conf, tctx := d.conf, d.tctx
+	if !conf.Resume {
+		return nil
+	}
+	if conf.Consistency != ConsistencyTypeSnapshot || conf.SQL != "" {
+		return errors.Errorf("--%s is only supported when dumping tables with consistency snapshot", flagResume)
+	}
+	cp, err := readCheckpoint(tctx, d.extStore)
+	if err != nil {
+		return err
+	}
+	if cp == nil {
+		tctx.L().Warn("checkpoint is not found, start a new dump", zap.String("file", checkpointFileName))
+		return nil
+	}
+	if conf.Snapshot != "" && conf.Snapshot != cp.Snapshot {
+		return errors.Errorf("snapshot %s mismatches the snapshot %s of the checkpoint", conf.Snapshot, cp.Snapshot)
+	}
+	if conf.FileType != cp.FileType {
+		return errors.Errorf("file type %s mismatches the file type %s of the checkpoint", conf.FileType, cp.FileType)
+	}
+	if conf.CompressType != cp.CompressType {
+		return errors.Errorf("compress type mismatches the compress type of the checkpoint")
+	}
+	conf.Snapshot = cp.Snapshot
+	d.checkpointMgr = newCheckpointManager(d.extStore, cp)
+	tctx.L().Info("resume dump from checkpoint",
+		zap.String("snapshot", cp.Snapshot),
+		zap.Int("recorded tables", len(cp.Tables)))
+	return nil
+}
+
+// initCheckpoint is an initialization step of Dumper.
+func initCheckpoint(d *Dumper) error {
+	conf := d.conf
+	if d.checkpointMgr != nil {
+		if d.serviceSafePointID != "" {
+			d.checkpointMgr.cp.ServiceSafePointID = d.serviceSafePointID
+		}
+		return nil
+	}
+	// only the dump with a consistent snapshot can be resumed.
+	if conf.Consistency != ConsistencyTypeSnapshot || conf.SQL != "" || conf.Snapshot == "" {
+		return nil
+	}
+	d.checkpointMgr = newCheckpointManager(d.extStore, &checkpoint{
+		Snapshot:           conf.Snapshot,
+		ServiceSafePointID: d.serviceSafePointID,
+		FileType:           conf.FileType,
+		CompressType:       conf.CompressType,
+	})
+	return nil
+}
+
 // tidbStartGCSavepointUpdateService is an initialization step of Dumper.
 func tidbStartGCSavepointUpdateService(d *Dumper) error {
 	tctx, pool, conf := d.tctx, d.dbHandle, d.conf
@@ -1501,7 +1636,17 @@ func tidbStartGCSavepointUpdateService(d *Dumper) error {
 		if err != nil {
 			return err
 		}
-		go updateServiceSafePoint(tctx, d.tidbPDClientForGC, defaultDumpGCSafePointTTL, snapshotTS)
+		d.serviceSafePointID = fmt.Sprintf("%s_%d", dumplingServiceSafePointPrefix, time.Now().UnixNano())
+		if d.checkpointMgr != nil {
+			// keep using the service safe point of the dump to resume.
+			if id := d.checkpointMgr.cp.ServiceSafePointID; id != "" {
+				d.serviceSafePointID = id
+			}
+			if err = checkSnapshotNotGarbageCollected(tctx, d.tidbPDClientForGC, d.serviceSafePointID, defaultDumpGCSafePointTTL, snapshotTS); err != nil {
+				return err
+			}
+		}
+		go updateServiceSafePoint(tctx, d.tidbPDClientForGC, defaultDumpGCSafePointTTL, snapshotTS, d.serviceSafePointID)
 	} else if si.ServerType == version.ServerTypeTiDB {
 		tctx.L().Warn("If the amount of data to dump is large, criteria: (data more than 60GB or dumped time more than 10 minutes)\n" +
 			"you'd better adjust the tikv_gc_life_time to avoid export failure due to TiDB GC during the dump process.\n" +
@@ -1511,10 +1656,24 @@ func tidbStartGCSavepointUpdateService(d *Dumper) error {
 	return nil
 }
 
-func updateServiceSafePoint(tctx *tcontext.Context, pdClient pd.Client, ttl int64, snapshotTS uint64) {
+// checkSnapshotNotGarbageCollected sets the service safe point to the snapshot
+// of the dump to resume, and makes sure that the snapshot is still protected.
+func checkSnapshotNotGarbageCollected(tctx *tcontext.Context, pdClient pd.Client, serviceSafePointID string, ttl int64, snapshotTS uint64) error {
+	minSafePoint, err := pdClient.UpdateServiceGCSafePoint(tctx, serviceSafePointID, ttl, snapshotTS)
+	if err != nil {
+		return errors.Annotate(err, "failed to update the service safe point of the checkpoint")
+	}
+	// PD returns the current min safe point without updating it if the snapshot is behind it.
+	if minSafePoint > snapshotTS {
+		return errors.Errorf("the snapshot %d of the checkpoint has been garbage collected, the GC safe point is %d, "+
+			"please dump again without --%s", snapshotTS, minSafePoint, flagResume)
+	}
+	return nil
+}
+
+func updateServiceSafePoint(tctx *tcontext.Context, pdClient pd.Client, ttl int64, snapshotTS uint64, dumplingServiceSafePointID string) {
 	updateInterval := time.Duration(ttl/2) * time.Second
 	tick := time.NewTicker(updateInterval)
-	dumplingServiceSafePointID := fmt.Sprintf("%s_%d", dumplingServiceSafePointPrefix, time.Now().UnixNano())
 	tctx.L().Info("generate dumpling gc safePoint id", zap.String("id", dumplingServiceSafePointID))
 
 	for {
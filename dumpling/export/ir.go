@@ -28,6 +28,7 @@ type TableMeta interface {
 	ColumnCount() uint
 	ColumnTypes() []string
 	ColumnNames() []string
+	ColumnDecimalSizes() (precisions, scales []int64)
 	SelectedField() string
 	SelectedLen() int
 	SpecialComments() StringIter
@@ -53,10 +54,11 @@ type RowReceiverStringer interface {
 	Stringer
 }
 
-// Stringer is an interface which represents sql types that support writing to buffer in sql/csv type
+// Stringer is an interface which represents sql types that support writing to buffer in sql/csv/jsonl type
 type Stringer interface {
 	WriteToBuffer(*bytes.Buffer, bool)
 	WriteToBufferInCsv(*bytes.Buffer, bool, *csvOption)
+	WriteToBufferInJSON(*bytes.Buffer)
 }
 
 // RowReceiver is an interface which represents sql types that support bind address for *sql.Rows
@@ -283,6 +283,16 @@ func (tm *tableMeta) ColumnNames() []string {
 	return colNames
 }
 
+func (tm *tableMeta) ColumnDecimalSizes() (precisions, scales []int64) {
+	precisions, scales = make([]int64, len(tm.colTypes)), make([]int64, len(tm.colTypes))
+	for i, ct := range tm.colTypes {
+		if precision, scale, ok := ct.DecimalSize(); ok {
+			precisions[i], scales[i] = precision, scale
+		}
+	}
+	return precisions, scales
+}
+
 func (tm *tableMeta) DatabaseName() string {
 	return tm.database
 }
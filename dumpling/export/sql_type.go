@@ -7,12 +7,14 @@ import (
 	"database/sql"
 	"encoding/base64"
 	"fmt"
+	"unicode/utf8"
 )
 
 var colTypeRowReceiverMap = map[string]func() RowReceiverStringer{}
 
 var (
 	nullValue         = "NULL"
+	jsonNullValue     = "null"
 	quotationMark     = []byte{'\''}
 	twoQuotationMarks = []byte{'\'', '\''}
 )
@@ -37,8 +39,11 @@ func initColTypeRowReceiverMap() {
 		"UNSIGNED INT", "UNSIGNED BIGINT", "UNSIGNED TINYINT", "UNSIGNED SMALLINT", // introduced in https://github.com/go-sql-driver/mysql/pull/1238
 	}
 
-	dataTypeNumArr := append(dataTypeIntArr, []string{
+	dataTypeFloatArr := []string{
 		"FLOAT", "REAL", "DOUBLE", "DOUBLE PRECISION",
+	}
+
+	dataTypeNumArr := append(append(dataTypeIntArr, dataTypeFloatArr...), []string{
 		"DECIMAL", "NUMERIC", "FIXED",
 		"BOOL", "BOOLEAN",
 	}...)
@@ -56,6 +61,9 @@ func initColTypeRowReceiverMap() {
 	for _, s := range dataTypeIntArr {
 		dataTypeInt[s] = struct{}{}
 	}
+	for _, s := range dataTypeFloatArr {
+		dataTypeFloat[s] = struct{}{}
+	}
 	for _, s := range dataTypeNumArr {
 		colTypeRowReceiverMap[s] = SQLTypeNumberMaker
 	}
@@ -67,6 +75,8 @@ func initColTypeRowReceiverMap() {
 
 var dataTypeString, dataTypeInt, dataTypeBin = make(map[string]struct{}), make(map[string]struct{}), make(map[string]struct{})
 
+var dataTypeFloat = make(map[string]struct{})
+
 func escapeBackslashSQL(s []byte, bf *bytes.Buffer) {
 	var (
 		escape byte
@@ -160,6 +170,51 @@ func escapeCSV(s []byte, bf *bytes.Buffer, escapeBackslash bool, opt *csvOption)
 	}
 }
 
+// escapeJSON writes s as a JSON string. Invalid UTF-8 bytes are replaced by U+FFFD like encoding/json does.
+func escapeJSON(s []byte, bf *bytes.Buffer) {
+	const hex = "0123456789abcdef"
+	bf.WriteByte('"')
+	last := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' {
+				i++
+				continue
+			}
+			bf.Write(s[last:i])
+			switch b {
+			case '"', '\\':
+				bf.WriteByte('\\')
+				bf.WriteByte(b)
+			case '\n':
+				bf.WriteString(`\n`)
+			case '\r':
+				bf.WriteString(`\r`)
+			case '\t':
+				bf.WriteString(`\t`)
+			default:
+				bf.WriteString(`\u00`)
+				bf.WriteByte(hex[b>>4])
+				bf.WriteByte(hex[b&0xF])
+			}
+			i++
+			last = i
+			continue
+		}
+		r, size := utf8.DecodeRune(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			bf.Write(s[last:i])
+			bf.WriteString(`\ufffd`)
+			i += size
+			last = i
+			continue
+		}
+		i += size
+	}
+	bf.Write(s[last:])
+	bf.WriteByte('"')
+}
+
 // SQLTypeStringMaker returns a SQLTypeString
 func SQLTypeStringMaker() RowReceiverStringer {
 	return &SQLTypeString{}
@@ -230,6 +285,20 @@ func (r *RowReceiverArr) WriteToBufferInCsv(bf *bytes.Buffer, escapeBackslash bo
 	}
 }
 
+// WriteToBufferInJSON writes the row as a JSON object, keys are the escaped column names.
+func (r *RowReceiverArr) WriteToBufferInJSON(bf *bytes.Buffer, keys [][]byte) {
+	bf.WriteByte('{')
+	for i, receiver := range r.receivers {
+		bf.Write(keys[i])
+		bf.WriteByte(':')
+		receiver.WriteToBufferInJSON(bf)
+		if i != len(r.receivers)-1 {
+			bf.WriteByte(',')
+		}
+	}
+	bf.WriteByte('}')
+}
+
 // SQLTypeNumber implements RowReceiverStringer which represents numeric type columns in database
 type SQLTypeNumber struct {
 	SQLTypeString
@@ -253,6 +322,15 @@ func (s SQLTypeNumber) WriteToBufferInCsv(bf *bytes.Buffer, _ bool, opt *csvOpti
 	}
 }
 
+// WriteToBufferInJSON implements Stringer.WriteToBufferInJSON
+func (s SQLTypeNumber) WriteToBufferInJSON(bf *bytes.Buffer) {
+	if s.RawBytes != nil {
+		bf.Write(s.RawBytes)
+	} else {
+		bf.WriteString(jsonNullValue)
+	}
+}
+
 // SQLTypeString implements RowReceiverStringer which represents string type columns in database
 type SQLTypeString struct {
 	sql.RawBytes
@@ -285,6 +363,15 @@ func (s *SQLTypeString) WriteToBufferInCsv(bf *bytes.Buffer, escapeBackslash boo
 	}
 }
 
+// WriteToBufferInJSON implements Stringer.WriteToBufferInJSON
+func (s *SQLTypeString) WriteToBufferInJSON(bf *bytes.Buffer) {
+	if s.RawBytes != nil {
+		escapeJSON(s.RawBytes, bf)
+	} else {
+		bf.WriteString(jsonNullValue)
+	}
+}
+
 // SQLTypeBytes implements RowReceiverStringer which represents bytes type columns in database
 type SQLTypeBytes struct {
 	sql.RawBytes
@@ -321,3 +408,14 @@ func (s *SQLTypeBytes) WriteToBufferInCsv(bf *bytes.Buffer, escapeBackslash bool
 		bf.WriteString(opt.nullValue)
 	}
 }
+
+// WriteToBufferInJSON implements Stringer.WriteToBufferInJSON, the bytes are encoded in base64 like encoding/json does.
+func (s *SQLTypeBytes) WriteToBufferInJSON(bf *bytes.Buffer) {
+	if s.RawBytes != nil {
+		bf.WriteByte('"')
+		bf.WriteString(base64.StdEncoding.EncodeToString(s.RawBytes))
+		bf.WriteByte('"')
+	} else {
+		bf.WriteString(jsonNullValue)
+	}
+}
@@ -66,6 +66,8 @@ type mockTableIR struct {
 	specCmt          []string
 	colTypes         []string
 	colNames         []string
+	colPrecisions    []int64
+	colScales        []int64
 	escapeBackSlash  bool
 	hasImplicitRowID bool
 	rowErr           error
@@ -121,6 +123,10 @@ func (m *mockTableIR) ColumnNames() []string {
 	return m.colNames
 }
 
+func (m *mockTableIR) ColumnDecimalSizes() (precisions, scales []int64) {
+	return m.colPrecisions, m.colScales
+}
+
 func (m *mockTableIR) SelectedField() string {
 	return m.selectedField
 }
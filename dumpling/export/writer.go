@@ -58,6 +58,10 @@ func NewWriter(
 		sw.fileFmt = FileFormatSQLText
 	case FileFormatCSVString:
 		sw.fileFmt = FileFormatCSV
+	case FileFormatParquetString:
+		sw.fileFmt = FileFormatParquet
+	case FileFormatJSONLString:
+		sw.fileFmt = FileFormatJSONL
 	}
 	return sw
 }
@@ -0,0 +1,347 @@
+// Copyright 2026 PingCAP, Inc. Licensed under Apache-2.0.
+
+package export
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/summary"
+	tcontext "github.com/pingcap/tidb/dumpling/context"
+	"github.com/pingcap/tidb/dumpling/log"
+	"github.com/xitongsys/parquet-go-source/writerfile"
+	"github.com/xitongsys/parquet-go/layout"
+	"github.com/xitongsys/parquet-go/marshal"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/schema"
+	"github.com/xitongsys/parquet-go/types"
+	"github.com/xitongsys/parquet-go/writer"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultParquetRowGroupSize is the row group size when --filesize is not specified or larger than it.
+	defaultParquetRowGroupSize = 128 * 1024 * 1024
+	parquetPageSize            = 8 * 1024
+	parquetRootName            = "schema"
+	parquetTimeLayout          = "2006-01-02 15:04:05.999999"
+)
+
+// parquetSchema derives the parquet schema from the column types. Integers and floats are stored in INT64 and DOUBLE,
+// DECIMAL, DATE, DATETIME and TIMESTAMP are stored in the DECIMAL, DATE and TIMESTAMP logical types, binary types are
+// stored in BYTE_ARRAY, and the other types are stored in UTF8 strings as they are in csv files.
+// The values of DATETIME and TIMESTAMP are dumped in the time zone of the session, so the timestamps are not adjusted
+// to UTC. The zero dates and the other dates that are invalid in parquet, such as 2024-00-00, are dumped as NULL.
+func parquetSchema(meta TableMeta) []*parquet.SchemaElement {
+	colTypes, colNames := meta.ColumnTypes(), meta.ColumnNames()
+	precisions, scales := meta.ColumnDecimalSizes()
+	root := parquet.NewSchemaElement()
+	root.Name = parquetRootName
+	numChildren := int32(len(colTypes))
+	root.NumChildren = &numChildren
+	rootRepetition := parquet.FieldRepetitionType_REQUIRED
+	root.RepetitionType = &rootRepetition
+
+	elements := make([]*parquet.SchemaElement, 0, len(colTypes)+1)
+	elements = append(elements, root)
+	for i, colType := range colTypes {
+		se := parquet.NewSchemaElement()
+		se.Name = fmt.Sprintf("col%d", i)
+		if i < len(colNames) {
+			se.Name = colNames[i]
+		}
+		repetition := parquet.FieldRepetitionType_OPTIONAL
+		se.RepetitionType = &repetition
+		var (
+			tp = parquet.Type_BYTE_ARRAY
+			ct *parquet.ConvertedType
+			lt *parquet.LogicalType
+		)
+		_, isInt := dataTypeInt[colType]
+		_, isFloat := dataTypeFloat[colType]
+		_, isBin := dataTypeBin[colType]
+		switch {
+		case isInt:
+			tp = parquet.Type_INT64
+			if colType == "UNSIGNED BIGINT" {
+				ct = parquet.ConvertedTypePtr(parquet.ConvertedType_UINT_64)
+			}
+		case isFloat:
+			tp = parquet.Type_DOUBLE
+		case colType == "DECIMAL" && i < len(precisions) && precisions[i] > 0:
+			// the unscaled value is stored in the big-endian two's complement binary
+			precision, scale := int32(precisions[i]), int32(scales[i])
+			se.Precision, se.Scale = &precision, &scale
+			ct = parquet.ConvertedTypePtr(parquet.ConvertedType_DECIMAL)
+			lt = &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: precision, Scale: scale}}
+		case colType == "DATE":
+			tp = parquet.Type_INT32
+			ct = parquet.ConvertedTypePtr(parquet.ConvertedType_DATE)
+			lt = &parquet.LogicalType{DATE: parquet.NewDateType()}
+		case colType == "DATETIME" || colType == "TIMESTAMP":
+			// the converted type TIMESTAMP_MICROS implies the values are adjusted to UTC, so only the logical type is set
+			tp = parquet.Type_INT64
+			lt = &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{
+				IsAdjustedToUTC: false,
+				Unit:            &parquet.TimeUnit{MICROS: parquet.NewMicroSeconds()},
+			}}
+		case isBin:
+		default:
+			ct = parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8)
+		}
+		se.Type = &tp
+		se.ConvertedType = ct
+		se.LogicalType = lt
+		elements = append(elements, se)
+	}
+	return elements
+}
+
+// parquetValue converts the value scanned from the database to the value of the parquet column. The date and time
+// values that can't be parsed, such as the MySQL zero dates, are converted to NULL.
+func parquetValue(raw sql.RawBytes, se *parquet.SchemaElement) (interface{}, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	switch se.GetType() {
+	case parquet.Type_INT32:
+		// DATE is stored as the days since the unix epoch
+		t, err := time.ParseInLocation(time.DateOnly, string(raw), time.UTC)
+		if err != nil {
+			return nil, nil
+		}
+		days := t.Unix() / 86400
+		if t.Unix()%86400 < 0 {
+			days--
+		}
+		return int32(days), nil
+	case parquet.Type_INT64:
+		if se.LogicalType != nil && se.LogicalType.IsSetTIMESTAMP() {
+			t, err := time.ParseInLocation(parquetTimeLayout, string(raw), time.UTC)
+			if err != nil {
+				return nil, nil
+			}
+			return t.UnixMicro(), nil
+		}
+		if se.ConvertedType != nil && se.GetConvertedType() == parquet.ConvertedType_UINT_64 {
+			v, err := strconv.ParseUint(string(raw), 10, 64)
+			return int64(v), errors.Trace(err)
+		}
+		v, err := strconv.ParseInt(string(raw), 10, 64)
+		return v, errors.Trace(err)
+	case parquet.Type_DOUBLE:
+		v, err := strconv.ParseFloat(string(raw), 64)
+		return v, errors.Trace(err)
+	default:
+		if se.ConvertedType != nil && se.GetConvertedType() == parquet.ConvertedType_DECIMAL {
+			return parquetDecimal(string(raw), int(se.GetScale()))
+		}
+		return string(raw), nil
+	}
+}
+
+// parquetDecimal converts the decimal string to the big-endian two's complement binary of the unscaled value.
+func parquetDecimal(v string, scale int) (string, error) {
+	intPart, fracPart, _ := strings.Cut(v, ".")
+	if len(fracPart) > scale {
+		return "", errors.Errorf("the scale of decimal %s exceeds %d", v, scale)
+	}
+	unscaled := intPart + fracPart + strings.Repeat("0", scale-len(fracPart))
+	if _, ok := new(big.Int).SetString(unscaled, 10); !ok {
+		return "", errors.Errorf("invalid decimal %s", v)
+	}
+	return types.StrIntToBinary(unscaled, "BigEndian", 0, true), nil
+}
+
+func receiverRawBytes(r RowReceiverStringer) sql.RawBytes {
+	switch x := r.(type) {
+	case *SQLTypeString:
+		return x.RawBytes
+	case *SQLTypeNumber:
+		return x.RawBytes
+	case *SQLTypeBytes:
+		return x.RawBytes
+	default:
+		return nil
+	}
+}
+
+// parquetPipeWriter passes the bytes encoded by the parquet writer to writerPipe.
+type parquetPipeWriter struct {
+	pCtx    *tcontext.Context
+	wp      *writerPipe
+	bf      *bytes.Buffer
+	flushed bool
+}
+
+// Write implements io.Writer.
+func (p *parquetPipeWriter) Write(b []byte) (int, error) {
+	p.bf.Write(b)
+	if p.bf.Len() >= lengthLimit {
+		if err := p.flush(); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (p *parquetPipeWriter) flush() error {
+	select {
+	case <-p.pCtx.Done():
+		return p.pCtx.Err()
+	case err := <-p.wp.errCh:
+		return err
+	case p.wp.input <- p.bf:
+		p.bf = pool.Get().(*bytes.Buffer)
+		if bfCap := p.bf.Cap(); bfCap < lengthLimit {
+			p.bf.Grow(lengthLimit - bfCap)
+		}
+		p.flushed = true
+		return nil
+	}
+}
+
+// WriteInsertInParquet writes TableDataIR to a storage.ExternalFileWriter in parquet type. The row group size is
+// limited by --filesize, so that every file contains at least one complete row group.
+func WriteInsertInParquet(
+	pCtx *tcontext.Context,
+	cfg *Config,
+	meta TableMeta,
+	tblIR TableDataIR,
+	w storage.ExternalFileWriter,
+	metrics *metrics,
+) (n uint64, err error) {
+	fileRowIter := tblIR.Rows()
+	if !fileRowIter.HasNext() {
+		return 0, fileRowIter.Error()
+	}
+	if meta.SelectedField() == "" {
+		return 0, errors.Errorf("can't dump table %s.%s without selected columns in parquet type",
+			meta.DatabaseName(), meta.TableName())
+	}
+
+	bf := pool.Get().(*bytes.Buffer)
+	if bfCap := bf.Cap(); bfCap < lengthLimit {
+		bf.Grow(lengthLimit - bfCap)
+	}
+
+	wp := newWriterPipe(w, cfg.FileSize, UnspecifiedSize, metrics, cfg.Labels)
+	pipe := &parquetPipeWriter{pCtx: pCtx, wp: wp, bf: bf}
+
+	// use context.Background here to make sure writerPipe can deplete all the chunks in pipeline
+	ctx, cancel := tcontext.Background().WithLogger(pCtx.L()).WithCancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		wp.Run(ctx)
+		wg.Done()
+	}()
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	var (
+		row         = MakeRowReceiver(meta.ColumnTypes())
+		counter     uint64
+		lastCounter uint64
+		elements    = parquetSchema(meta)
+	)
+
+	defer func() {
+		if err != nil {
+			pCtx.L().Warn("fail to dumping table(chunk), will revert some metrics and start a retry if possible",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", lastCounter),
+				zap.Uint64("finished size", wp.finishedFileSize),
+				log.ShortError(err))
+			SubGauge(metrics.finishedRowsGauge, float64(lastCounter))
+			SubGauge(metrics.finishedSizeGauge, float64(wp.finishedFileSize))
+		} else {
+			pCtx.L().Debug("finish dumping table(chunk)",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", counter),
+				zap.Uint64("finished size", wp.finishedFileSize))
+			summary.CollectSuccessUnit(summary.TotalBytes, 1, wp.finishedFileSize)
+			summary.CollectSuccessUnit("total rows", 1, counter)
+		}
+	}()
+
+	pw := &writer.ParquetWriter{
+		SchemaHandler:   schema.NewSchemaHandlerFromSchemaList(elements),
+		NP:              1,
+		Footer:          parquet.NewFileMetaData(),
+		PFile:           writerfile.NewWriterFile(pipe),
+		PageSize:        parquetPageSize,
+		RowGroupSize:    defaultParquetRowGroupSize,
+		CompressionType: parquet.CompressionCodec_SNAPPY,
+		Offset:          4,
+		PagesMapBuf:     make(map[string][]*layout.Page),
+		DictRecs:        make(map[string]*layout.DictRecType),
+		MarshalFunc:     marshal.MarshalCSV,
+	}
+	if cfg.FileSize != UnspecifiedSize && cfg.FileSize < defaultParquetRowGroupSize {
+		pw.RowGroupSize = int64(cfg.FileSize)
+	}
+	pw.Footer.Version = 1
+	pw.Footer.Schema = append(pw.Footer.Schema, elements...)
+	if _, err = pw.PFile.Write([]byte("PAR1")); err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	for fileRowIter.HasNext() {
+		if err = fileRowIter.Decode(row); err != nil {
+			return counter, errors.Trace(err)
+		}
+		rowSize := 0
+		values := make([]interface{}, len(row.receivers))
+		for i, receiver := range row.receivers {
+			raw := receiverRawBytes(receiver)
+			rowSize += len(raw)
+			if values[i], err = parquetValue(raw, elements[i+1]); err != nil {
+				return counter, err
+			}
+		}
+		if err = pw.Write(values); err != nil {
+			return counter, errors.Trace(err)
+		}
+		counter++
+		// the size of the parquet file is unknown until the row group is flushed, so the raw size is used instead
+		wp.currentFileSize += uint64(rowSize)
+		if pipe.flushed {
+			pipe.flushed = false
+			AddGauge(metrics.finishedRowsGauge, float64(counter-lastCounter))
+			lastCounter = counter
+		}
+
+		fileRowIter.Next()
+		if wp.ShouldSwitchFile() {
+			break
+		}
+	}
+
+	if err = pw.WriteStop(); err != nil {
+		return counter, errors.Trace(err)
+	}
+	if pipe.bf.Len() > 0 {
+		wp.input <- pipe.bf
+	}
+	close(wp.input)
+	<-wp.closed
+	AddGauge(metrics.finishedRowsGauge, float64(counter-lastCounter))
+	lastCounter = counter
+	if err = fileRowIter.Error(); err != nil {
+		return counter, errors.Trace(err)
+	}
+	return counter, wp.Error()
+}
@@ -13,6 +13,9 @@ import (
 	tcontext "github.com/pingcap/tidb/dumpling/context"
 	"github.com/pingcap/tidb/pkg/util/promutil"
 	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
 )
 
 func TestWriteMeta(t *testing.T) {
@@ -314,6 +317,163 @@ func TestWriteInsertInCsvWithDialect(t *testing.T) {
 	}
 }
 
+func TestWriteInsertInJSONL(t *testing.T) {
+	cfg := createMockConfig()
+
+	data := [][]driver.Value{
+		{"1", "male", "bob@mail.com", "1.50", nil},
+		{"2", "female", "sa\"rah\n@mail.com", "-2", "blob2"},
+	}
+	colTypes := []string{"INT", "SET", "VARCHAR", "DECIMAL", "BLOB"}
+	tableIR := newMockTableIR("test", "employee", data, nil, colTypes)
+	tableIR.colNames = []string{"id", "gender", "email", "score", "photo"}
+	bf := storage.NewBufferWriter()
+
+	conf := cloneConfigForTest(cfg)
+	m := newMetrics(conf.PromFactory, conf.Labels)
+	n, err := WriteInsertInJSONL(tcontext.Background(), conf, tableIR, tableIR, bf, m)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), n)
+
+	expected := `{"id":1,"gender":"male","email":"bob@mail.com","score":1.50,"photo":null}` + "\n" +
+		`{"id":2,"gender":"female","email":"sa\"rah\n@mail.com","score":-2,"photo":"YmxvYjI="}` + "\n"
+	require.Equal(t, expected, bf.String())
+	require.Equal(t, float64(len(data)), ReadGauge(m.finishedRowsGauge))
+	require.Equal(t, float64(len(expected)), ReadGauge(m.finishedSizeGauge))
+}
+
+func TestWriteInsertInParquet(t *testing.T) {
+	cfg := createMockConfig()
+
+	data := [][]driver.Value{
+		{"1", "18446744073709551615", "1.5", "12.30", "bob", "blob1", "2024-01-02", "2024-01-02 03:04:05.123456", "1970-01-01 00:00:01"},
+		{"2", nil, "-2", "-1", nil, "blob2", "1969-12-31", "1000-01-01 00:00:00", nil},
+		{"3", "3", "0", "0.00", "john", nil, nil, nil, "2038-01-19 03:14:07"},
+	}
+	colTypes := []string{"INT", "UNSIGNED BIGINT", "DOUBLE", "DECIMAL", "VARCHAR", "BLOB", "DATE", "DATETIME", "TIMESTAMP"}
+	tableIR := newMockTableIR("test", "employee", data, nil, colTypes)
+	tableIR.colNames = []string{"id", "u", "d", "dec", "name", "photo", "birthday", "created", "updated"}
+	tableIR.colPrecisions = []int64{0, 0, 0, 10, 0, 0, 0, 0, 0}
+	tableIR.colScales = []int64{0, 0, 0, 2, 0, 0, 0, 0, 0}
+	bf := storage.NewBufferWriter()
+
+	conf := cloneConfigForTest(cfg)
+	m := newMetrics(conf.PromFactory, conf.Labels)
+	n, err := WriteInsertInParquet(tcontext.Background(), conf, tableIR, tableIR, bf, m)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), n)
+	require.Equal(t, float64(len(data)), ReadGauge(m.finishedRowsGauge))
+	require.Equal(t, float64(len(bf.Bytes())), ReadGauge(m.finishedSizeGauge))
+
+	pf, err := buffer.NewBufferFile(bf.Bytes())
+	require.NoError(t, err)
+	pr, err := reader.NewParquetColumnReader(pf, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	require.Equal(t, int64(3), pr.GetNumRows())
+
+	schema := pr.Footer.Schema
+	require.Len(t, schema, len(colTypes)+1)
+	expectedTypes := []parquet.Type{parquet.Type_INT64, parquet.Type_INT64, parquet.Type_DOUBLE,
+		parquet.Type_BYTE_ARRAY, parquet.Type_BYTE_ARRAY, parquet.Type_BYTE_ARRAY,
+		parquet.Type_INT32, parquet.Type_INT64, parquet.Type_INT64}
+	for i, tp := range expectedTypes {
+		require.Equal(t, tableIR.colNames[i], pr.SchemaHandler.GetExName(i+1))
+		require.Equal(t, tp, schema[i+1].GetType())
+	}
+	require.Equal(t, parquet.ConvertedType_UINT_64, schema[2].GetConvertedType())
+	require.Equal(t, parquet.ConvertedType_DECIMAL, schema[4].GetConvertedType())
+	require.Equal(t, int32(10), schema[4].GetPrecision())
+	require.Equal(t, int32(2), schema[4].GetScale())
+	require.Equal(t, int32(10), schema[4].GetLogicalType().GetDECIMAL().GetPrecision())
+	require.Equal(t, int32(2), schema[4].GetLogicalType().GetDECIMAL().GetScale())
+	require.Equal(t, parquet.ConvertedType_UTF8, schema[5].GetConvertedType())
+	require.False(t, schema[6].IsSetConvertedType())
+	require.Equal(t, parquet.ConvertedType_DATE, schema[7].GetConvertedType())
+	require.True(t, schema[7].GetLogicalType().IsSetDATE())
+	for _, i := range []int{8, 9} {
+		require.False(t, schema[i].IsSetConvertedType())
+		require.True(t, schema[i].GetLogicalType().IsSetTIMESTAMP())
+		require.False(t, schema[i].GetLogicalType().GetTIMESTAMP().IsAdjustedToUTC)
+		require.True(t, schema[i].GetLogicalType().GetTIMESTAMP().GetUnit().IsSetMICROS())
+	}
+
+	expected := [][]interface{}{
+		{int64(1), int64(2), int64(3)},
+		{int64(-1), nil, int64(3)},
+		{1.5, float64(-2), float64(0)},
+		// the big-endian two's complement of 1230, -100 and 0
+		{"\x04\xce", "\x9c", "\x00"},
+		{"bob", nil, "john"},
+		{"blob1", "blob2", nil},
+		{int32(19724), int32(-1), nil},
+		{int64(1704164645123456), int64(-30610224000000000), nil},
+		{int64(1000000), nil, int64(2147483647000000)},
+	}
+	for i, col := range expected {
+		values, _, _, err := pr.ReadColumnByIndex(int64(i), 3)
+		require.NoError(t, err)
+		require.Equal(t, col, values, "column %d", i)
+	}
+}
+
+func TestWriteInsertInParquetWithZeroDate(t *testing.T) {
+	cfg := createMockConfig()
+
+	data := [][]driver.Value{
+		{"0000-00-00", "0000-00-00 00:00:00", "0000-00-00 00:00:00"},
+		{"2024-00-00", "2024-02-30 00:00:00", "2024-01-02 03:04:05"},
+	}
+	colTypes := []string{"DATE", "DATETIME", "TIMESTAMP"}
+	tableIR := newMockTableIR("test", "employee", data, nil, colTypes)
+	tableIR.colNames = []string{"birthday", "created", "updated"}
+	bf := storage.NewBufferWriter()
+
+	conf := cloneConfigForTest(cfg)
+	m := newMetrics(conf.PromFactory, conf.Labels)
+	n, err := WriteInsertInParquet(tcontext.Background(), conf, tableIR, tableIR, bf, m)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), n)
+
+	pf, err := buffer.NewBufferFile(bf.Bytes())
+	require.NoError(t, err)
+	pr, err := reader.NewParquetColumnReader(pf, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+
+	// the zero and invalid dates are dumped as NULL
+	expected := [][]interface{}{
+		{nil, nil},
+		{nil, nil},
+		{nil, int64(1704164645000000)},
+	}
+	for i, col := range expected {
+		values, _, _, err := pr.ReadColumnByIndex(int64(i), 2)
+		require.NoError(t, err)
+		require.Equal(t, col, values, "column %d", i)
+	}
+}
+
+func TestWriteInsertInParquetWithInvalidValue(t *testing.T) {
+	cfg := createMockConfig()
+	for _, tc := range []struct {
+		colType string
+		value   string
+	}{
+		{"DECIMAL", "1.234"},
+		{"INT", "abc"},
+	} {
+		tableIR := newMockTableIR("test", "employee", [][]driver.Value{{tc.value}}, nil, []string{tc.colType})
+		tableIR.colNames = []string{"c"}
+		tableIR.colPrecisions = []int64{10}
+		tableIR.colScales = []int64{2}
+		conf := cloneConfigForTest(cfg)
+		m := newMetrics(conf.PromFactory, conf.Labels)
+		_, err := WriteInsertInParquet(tcontext.Background(), conf, tableIR, tableIR, storage.NewBufferWriter(), m)
+		require.Error(t, err, tc.colType)
+	}
+}
+
 func TestSQLDataTypes(t *testing.T) {
 	cfg := createMockConfig()
 
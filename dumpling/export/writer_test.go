@@ -16,6 +16,8 @@ import (
 	tcontext "github.com/pingcap/tidb/dumpling/context"
 	"github.com/pingcap/tidb/pkg/util/promutil"
 	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
 )
 
 func TestWriteDatabaseMeta(t *testing.T) {
@@ -202,6 +204,44 @@ func TestWriteTableDataWithFileSize(t *testing.T) {
 	}
 }
 
+func TestWriteTableDataWithFileSizeInParquet(t *testing.T) {
+	dir := t.TempDir()
+	config := defaultConfigForTest(t)
+	config.OutputDirPath = dir
+	config.FileType = FileFormatParquetString
+	config.FileSize = 40
+
+	writer := createTestWriter(config, t)
+
+	data := [][]driver.Value{
+		{"1", "male", "bob@mail.com", "020-1234", nil},
+		{"2", "female", "sarah@mail.com", "020-1253", "healthy"},
+		{"3", "male", "john@mail.com", "020-1256", "healthy"},
+		{"4", "female", "sarah@mail.com", "020-1235", "healthy"},
+	}
+	colTypes := []string{"INT", "SET", "VARCHAR", "VARCHAR", "TEXT"}
+	tableIR := newMockTableIR("test", "employee", data, nil, colTypes)
+	err := writer.WriteTableData(tableIR, tableIR, 0)
+	require.NoError(t, err)
+
+	cases := map[string][]interface{}{
+		"test.employee.000000000.parquet": {int64(1), int64(2)},
+		"test.employee.000000001.parquet": {int64(3), int64(4)},
+	}
+	for p, expected := range cases {
+		pf, err := local.NewLocalFileReader(path.Join(dir, p))
+		require.NoError(t, err)
+		pr, err := reader.NewParquetColumnReader(pf, 1)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), pr.GetNumRows())
+		values, _, _, err := pr.ReadColumnByIndex(0, 2)
+		require.NoError(t, err)
+		require.Equal(t, expected, values)
+		pr.ReadStop()
+		require.NoError(t, pf.Close())
+	}
+}
+
 func TestWriteTableDataWithFileSizeAndRows(t *testing.T) {
 	dir := t.TempDir()
 	config := defaultConfigForTest(t)
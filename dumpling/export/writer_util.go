@@ -418,6 +418,137 @@ func WriteInsertInCsv(
 	return counter, wp.Error()
 }
 
+// WriteInsertInJSONL writes TableDataIR to a storage.ExternalFileWriter in JSON lines type, every row is written as
+// a JSON object keyed by the column names.
+func WriteInsertInJSONL(
+	pCtx *tcontext.Context,
+	cfg *Config,
+	meta TableMeta,
+	tblIR TableDataIR,
+	w storage.ExternalFileWriter,
+	metrics *metrics,
+) (n uint64, err error) {
+	fileRowIter := tblIR.Rows()
+	if !fileRowIter.HasNext() {
+		return 0, fileRowIter.Error()
+	}
+
+	bf := pool.Get().(*bytes.Buffer)
+	if bfCap := bf.Cap(); bfCap < lengthLimit {
+		bf.Grow(lengthLimit - bfCap)
+	}
+
+	wp := newWriterPipe(w, cfg.FileSize, UnspecifiedSize, metrics, cfg.Labels)
+
+	// use context.Background here to make sure writerPipe can deplete all the chunks in pipeline
+	ctx, cancel := tcontext.Background().WithLogger(pCtx.L()).WithCancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		wp.Run(ctx)
+		wg.Done()
+	}()
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	var (
+		row            = MakeRowReceiver(meta.ColumnTypes())
+		counter        uint64
+		lastCounter    uint64
+		selectedFields = meta.SelectedField()
+		keys           = makeJSONKeys(meta)
+	)
+
+	defer func() {
+		if err != nil {
+			pCtx.L().Warn("fail to dumping table(chunk), will revert some metrics and start a retry if possible",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", lastCounter),
+				zap.Uint64("finished size", wp.finishedFileSize),
+				log.ShortError(err))
+			SubGauge(metrics.finishedRowsGauge, float64(lastCounter))
+			SubGauge(metrics.finishedSizeGauge, float64(wp.finishedFileSize))
+		} else {
+			pCtx.L().Debug("finish dumping table(chunk)",
+				zap.String("database", meta.DatabaseName()),
+				zap.String("table", meta.TableName()),
+				zap.Uint64("finished rows", counter),
+				zap.Uint64("finished size", wp.finishedFileSize))
+			summary.CollectSuccessUnit(summary.TotalBytes, 1, wp.finishedFileSize)
+			summary.CollectSuccessUnit("total rows", 1, counter)
+		}
+	}()
+
+	for fileRowIter.HasNext() {
+		lastBfSize := bf.Len()
+		if selectedFields != "" {
+			if err = fileRowIter.Decode(row); err != nil {
+				return counter, errors.Trace(err)
+			}
+			row.WriteToBufferInJSON(bf, keys)
+		} else {
+			bf.WriteString("{}")
+		}
+		counter++
+		wp.currentFileSize += uint64(bf.Len()-lastBfSize) + 1 // 1 is for "\n"
+
+		bf.WriteByte('\n')
+		if bf.Len() >= lengthLimit {
+			select {
+			case <-pCtx.Done():
+				return counter, pCtx.Err()
+			case err = <-wp.errCh:
+				return counter, err
+			case wp.input <- bf:
+				bf = pool.Get().(*bytes.Buffer)
+				if bfCap := bf.Cap(); bfCap < lengthLimit {
+					bf.Grow(lengthLimit - bfCap)
+				}
+				AddGauge(metrics.finishedRowsGauge, float64(counter-lastCounter))
+				lastCounter = counter
+			}
+		}
+
+		fileRowIter.Next()
+		if wp.ShouldSwitchFile() {
+			break
+		}
+	}
+
+	if bf.Len() > 0 {
+		wp.input <- bf
+	}
+	close(wp.input)
+	<-wp.closed
+	AddGauge(metrics.finishedRowsGauge, float64(counter-lastCounter))
+	lastCounter = counter
+	if err = fileRowIter.Error(); err != nil {
+		return counter, errors.Trace(err)
+	}
+	return counter, wp.Error()
+}
+
+// makeJSONKeys returns the escaped column names used as the keys of JSON objects. The columns without names are
+// named by their positions.
+func makeJSONKeys(meta TableMeta) [][]byte {
+	colNames := meta.ColumnNames()
+	keys := make([][]byte, len(meta.ColumnTypes()))
+	var bf bytes.Buffer
+	for i := range keys {
+		name := fmt.Sprintf("col%d", i)
+		if i < len(colNames) {
+			name = colNames[i]
+		}
+		bf.Reset()
+		escapeJSON([]byte(name), &bf)
+		keys[i] = append([]byte(nil), bf.Bytes()...)
+	}
+	return keys
+}
+
 func write(tctx *tcontext.Context, writer storage.ExternalFileWriter, str string) error {
 	_, err := writer.Write(tctx, []byte(str))
 	if err != nil {
@@ -603,12 +734,14 @@ func compressFileSuffix(compressType storage.CompressType) string {
 		return ".snappy"
 	case storage.Zstd:
 		return ".zst"
+	case storage.LZ4:
+		return ".lz4"
 	default:
 		return ""
 	}
 }
 
-// FileFormat is the format that output to file. Currently we support SQL text and CSV file format.
+// FileFormat is the format that output to file. Currently we support SQL text, CSV, Parquet and JSON lines file format.
 type FileFormat int32
 
 const (
@@ -618,6 +751,10 @@ const (
 	FileFormatSQLText
 	// FileFormatCSV indicates the given file type is csv type
 	FileFormatCSV
+	// FileFormatParquet indicates the given file type is parquet type
+	FileFormatParquet
+	// FileFormatJSONL indicates the given file type is JSON lines type
+	FileFormatJSONL
 )
 
 const (
@@ -625,6 +762,10 @@ const (
 	FileFormatSQLTextString = "sql"
 	// FileFormatCSVString indicates the string/suffix of csv type file
 	FileFormatCSVString = "csv"
+	// FileFormatParquetString indicates the string/suffix of parquet type file
+	FileFormatParquetString = "parquet"
+	// FileFormatJSONLString indicates the string/suffix of JSON lines type file
+	FileFormatJSONLString = "jsonl"
 )
 
 // String implement Stringer.String method.
@@ -634,6 +775,10 @@ func (f FileFormat) String() string {
 		return strings.ToUpper(FileFormatSQLTextString)
 	case FileFormatCSV:
 		return strings.ToUpper(FileFormatCSVString)
+	case FileFormatParquet:
+		return strings.ToUpper(FileFormatParquetString)
+	case FileFormatJSONL:
+		return strings.ToUpper(FileFormatJSONLString)
 	default:
 		return "unknown"
 	}
@@ -641,20 +786,26 @@ func (f FileFormat) String() string {
 
 // Extension returns the extension for specific format.
 //
-//	text -> "sql"
-//	csv  -> "csv"
+//	text    -> "sql"
+//	csv     -> "csv"
+//	parquet -> "parquet"
+//	jsonl   -> "jsonl"
 func (f FileFormat) Extension() string {
 	switch f {
 	case FileFormatSQLText:
 		return FileFormatSQLTextString
 	case FileFormatCSV:
 		return FileFormatCSVString
+	case FileFormatParquet:
+		return FileFormatParquetString
+	case FileFormatJSONL:
+		return FileFormatJSONLString
 	default:
 		return "unknown_format"
 	}
 }
 
-// WriteInsert writes TableDataIR to a storage.ExternalFileWriter in sql/csv type
+// WriteInsert writes TableDataIR to a storage.ExternalFileWriter in sql/csv/parquet/jsonl type
 func (f FileFormat) WriteInsert(
 	pCtx *tcontext.Context,
 	cfg *Config,
@@ -668,6 +819,10 @@ func (f FileFormat) WriteInsert(
 		return WriteInsert(pCtx, cfg, meta, tblIR, w, metrics)
 	case FileFormatCSV:
 		return WriteInsertInCsv(pCtx, cfg, meta, tblIR, w, metrics)
+	case FileFormatParquet:
+		return WriteInsertInParquet(pCtx, cfg, meta, tblIR, w, metrics)
+	case FileFormatJSONL:
+		return WriteInsertInJSONL(pCtx, cfg, meta, tblIR, w, metrics)
 	default:
 		return 0, errors.Errorf("unknown file format")
 	}
@@ -41,6 +41,13 @@ const (
 	deleted = "deleted"
 	// Invalid is the bind info's invalid status.
 	Invalid = "invalid"
+	// PendingVerify means the binding is a candidate generated by baseline evolution, and it is waiting to be verified.
+	// An accepted candidate becomes enabled.
+	PendingVerify = "pending verify"
+	// Rejected means the candidate binding is not faster than the enabled binding. It is kept as evolution history.
+	Rejected = "rejected"
+	// Replaced means the binding has been replaced by an accepted candidate. It is kept as evolution history.
+	Replaced = "replaced"
 	// Manual indicates the binding is created by SQL like "create binding for ...".
 	Manual = "manual"
 	// Capture indicates the binding is captured by TiDB automatically.
@@ -49,6 +56,8 @@ const (
 	Builtin = "builtin"
 	// History indicate the binding is created from statement summary by plan digest
 	History = "history"
+	// Evolve indicates the binding is generated by baseline evolution.
+	Evolve = "evolve"
 )
 
 // Binding stores the basic bind hint info.
@@ -59,6 +68,8 @@ type Binding struct {
 	// Status represents the status of the binding. It can only be one of the following values:
 	// 1. deleted: Bindings is deleted, can not be used anymore.
 	// 2. enabled, using: Binding is in the normal active mode.
+	// 3. disabled: Binding is disabled by the user.
+	// 4. pending verify, rejected, replaced: Binding is generated or replaced by baseline evolution, can not be used.
 	Status     string
 	CreateTime types.Time
 	UpdateTime types.Time
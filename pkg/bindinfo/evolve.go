@@ -0,0 +1,294 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/hint"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	utilparser "github.com/pingcap/tidb/pkg/util/parser"
+	"github.com/pingcap/tidb/pkg/util/sqlkiller"
+	"go.uber.org/zap"
+)
+
+// EvolveGlobalBindings generates candidate bindings for the bound statements and verifies them.
+//
+// For every enabled global binding, the plan chosen by the optimizer without any binding is recorded as a candidate
+// binding in the 'pending verify' status. Then both the enabled binding and the candidate are executed, the candidate
+// is accepted only if it is much faster than the enabled one. The accepted candidate becomes enabled and the old
+// binding is kept in the 'replaced' status, otherwise the candidate is kept in the 'rejected' status. Either way, the
+// same plan is never verified again.
+// maxTime limits the total time of the task, it has no limit if maxTime <= 0.
+func (h *globalBindingHandle) EvolveGlobalBindings(maxTime time.Duration) (err error) {
+	var deadline time.Time
+	if maxTime > 0 {
+		deadline = time.Now().Add(maxTime)
+	}
+	defer func() {
+		if err == nil {
+			err = h.LoadFromStorageToCache(false)
+		}
+	}()
+
+	for _, bindings := range h.getCache().GetAllBindings() {
+		if err = h.generateCandidate(bindings); err != nil {
+			return err
+		}
+	}
+	if err = h.LoadFromStorageToCache(false); err != nil {
+		return err
+	}
+
+	for _, bindings := range h.getCache().GetAllBindings() {
+		accepted, candidates := pickBindingsToVerify(bindings)
+		for _, candidate := range candidates {
+			remaining := time.Duration(0)
+			if !deadline.IsZero() {
+				remaining = time.Until(deadline)
+				if remaining <= 0 {
+					return nil
+				}
+			}
+			if err = h.verifyCandidate(accepted, candidate, remaining); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pickBindingsToVerify returns the enabled binding and the candidates waiting to be verified against it.
+func pickBindingsToVerify(bindings Bindings) (accepted *Binding, candidates []*Binding) {
+	for i := range bindings {
+		if bindings[i].IsBindingEnabled() && accepted == nil {
+			accepted = &bindings[i]
+		} else if bindings[i].Status == PendingVerify {
+			candidates = append(candidates, &bindings[i])
+		}
+	}
+	if accepted == nil {
+		return nil, nil
+	}
+	return accepted, candidates
+}
+
+// isCandidateAccepted checks whether the candidate plan is fast enough to replace the enabled plan.
+// The candidate must be at least 1.5 times faster, so that the plans are not switched back and forth because of the
+// jitters of the execution time.
+func isCandidateAccepted(candidateTime, acceptedTime time.Duration) bool {
+	return candidateTime*3 <= acceptedTime*2
+}
+
+// generateCandidate records the plan chosen by the optimizer for the bound statement as a candidate binding.
+func (h *globalBindingHandle) generateCandidate(bindings Bindings) error {
+	accepted, _ := pickBindingsToVerify(bindings)
+	if accepted == nil {
+		return nil
+	}
+	stmt, err := parser.New().ParseOneStmt(accepted.BindSQL, accepted.Charset, accepted.Collation)
+	if err != nil {
+		return nil
+	}
+	// Only the read-only statements can be executed for verification.
+	if _, ok := stmt.(*ast.SelectStmt); !ok || isFuzzyBinding(stmt) {
+		return nil
+	}
+	paramChecker := &paramMarkerChecker{}
+	stmt.Accept(paramChecker)
+	if paramChecker.hasParamMarker {
+		return nil
+	}
+	hint.BindHint(stmt, &hint.HintsSet{})
+	sqlWithoutHints := utilparser.RestoreWithDefaultDB(stmt, accepted.Db, "")
+	if sqlWithoutHints == "" {
+		return nil
+	}
+
+	return h.callWithSCtx(true, func(sctx sessionctx.Context) error {
+		origDB := sctx.GetSessionVars().CurrentDB
+		sctx.GetSessionVars().CurrentDB = accepted.Db
+		planHint, err := getHintsForSQL(sctx, sqlWithoutHints)
+		sctx.GetSessionVars().CurrentDB = origDB
+		if err != nil {
+			logutil.BgLogger().Warn("generate candidate binding failed", zap.String("category", "sql-bind"),
+				zap.String("sql", sqlWithoutHints), zap.Error(err))
+			return nil
+		}
+		bindSQL := GenerateBindSQL(context.TODO(), stmt, planHint, false, accepted.Db)
+		if bindSQL == "" {
+			return nil
+		}
+		candidate := Binding{
+			OriginalSQL: accepted.OriginalSQL,
+			BindSQL:     bindSQL,
+			Db:          accepted.Db,
+			Status:      PendingVerify,
+			Charset:     accepted.Charset,
+			Collation:   accepted.Collation,
+			Source:      Evolve,
+			SQLDigest:   accepted.SQLDigest,
+		}
+		if err := prepareHints(nil, &candidate); err != nil {
+			return nil
+		}
+		// The plan has been the enabled one or has been verified before.
+		for i := range bindings {
+			if bindings[i].isSame(&candidate) {
+				return nil
+			}
+		}
+
+		// Lock mysql.bind_info to synchronize with CreateBindRecord / AddBindRecord / DropBindRecord on other tidb instances.
+		if err := lockBindInfoTable(sctx); err != nil {
+			return err
+		}
+		now := types.NewTime(types.FromGoTime(time.Now()), mysql.TypeTimestamp, 3).String()
+		_, err = exec(sctx, `INSERT INTO mysql.bind_info VALUES (%?,%?, %?, %?, %?, %?, %?, %?, %?, %?, %?)`,
+			candidate.OriginalSQL,
+			candidate.BindSQL,
+			strings.ToLower(candidate.Db),
+			candidate.Status,
+			now,
+			now,
+			candidate.Charset,
+			candidate.Collation,
+			candidate.Source,
+			candidate.SQLDigest,
+			candidate.PlanDigest,
+		)
+		return err
+	})
+}
+
+// verifyCandidate executes the enabled binding and the candidate, and accepts or rejects the candidate according to
+// their execution time. The candidate is left unverified if the enabled binding can't finish within maxTime.
+func (h *globalBindingHandle) verifyCandidate(accepted, candidate *Binding, maxTime time.Duration) error {
+	var newStatus string
+	err := h.callWithSCtx(false, func(sctx sessionctx.Context) error {
+		acceptedTime, timeout, err := runBindingForEvolve(sctx, accepted, maxTime)
+		if err != nil || timeout {
+			logutil.BgLogger().Warn("execute the enabled binding for evolution failed", zap.String("category", "sql-bind"),
+				zap.String("bindSQL", accepted.BindSQL), zap.Bool("timeout", timeout), zap.Error(err))
+			return nil
+		}
+		// The candidate is useless if it costs more time than this.
+		limit := acceptedTime * 2 / 3
+		if limit <= 0 {
+			newStatus = Rejected
+			return nil
+		}
+		limitedByTask := maxTime > 0 && maxTime-acceptedTime < limit
+		if limitedByTask {
+			limit = maxTime - acceptedTime
+		}
+		if limit <= 0 {
+			return nil
+		}
+		candidateTime, timeout, err := runBindingForEvolve(sctx, candidate, limit)
+		switch {
+		case timeout && limitedByTask:
+			// Leave it to the next task.
+			return nil
+		case err != nil:
+			logutil.BgLogger().Info("execute the candidate binding for evolution failed", zap.String("category", "sql-bind"),
+				zap.String("bindSQL", candidate.BindSQL), zap.Error(err))
+			newStatus = Rejected
+		case timeout || !isCandidateAccepted(candidateTime, acceptedTime):
+			newStatus = Rejected
+		default:
+			newStatus = Enabled
+		}
+		logutil.BgLogger().Info("verify candidate binding", zap.String("category", "sql-bind"),
+			zap.String("bindSQL", candidate.BindSQL), zap.Duration("acceptedTime", acceptedTime),
+			zap.Duration("candidateTime", candidateTime), zap.String("status", newStatus))
+		return nil
+	})
+	if err != nil || newStatus == "" {
+		return err
+	}
+
+	return h.callWithSCtx(true, func(sctx sessionctx.Context) error {
+		// Lock mysql.bind_info to synchronize with CreateBindRecord / AddBindRecord / DropBindRecord on other tidb instances.
+		if err = lockBindInfoTable(sctx); err != nil {
+			return err
+		}
+		updateTs := types.NewTime(types.FromGoTime(time.Now()), mysql.TypeTimestamp, 3).String()
+		if newStatus == Enabled {
+			_, err = exec(sctx, `UPDATE mysql.bind_info SET status = %?, update_time = %? WHERE original_sql = %? AND bind_sql = %? AND update_time < %? AND status IN (%?, %?)`,
+				Replaced, updateTs, accepted.OriginalSQL, accepted.BindSQL, updateTs, Enabled, Using)
+			if err != nil {
+				return err
+			}
+			// The enabled binding has been changed or dropped by others.
+			if sctx.GetSessionVars().StmtCtx.AffectedRows() == 0 {
+				newStatus = Rejected
+			}
+		}
+		_, err = exec(sctx, `UPDATE mysql.bind_info SET status = %?, update_time = %? WHERE original_sql = %? AND bind_sql = %? AND update_time < %? AND status = %?`,
+			newStatus, updateTs, candidate.OriginalSQL, candidate.BindSQL, updateTs, PendingVerify)
+		return err
+	})
+}
+
+// runBindingForEvolve executes the bind sql and returns the execution time. The execution is killed if it can't
+// finish within maxTime, it has no limit if maxTime <= 0.
+func runBindingForEvolve(sctx sessionctx.Context, binding *Binding, maxTime time.Duration) (elapsed time.Duration, timeout bool, err error) {
+	vars := sctx.GetSessionVars()
+	origDB, origUsePlanBaselines := vars.CurrentDB, vars.UsePlanBaselines
+	// The hints in the bind sql decide the plan, other bindings shouldn't take effect.
+	vars.CurrentDB, vars.UsePlanBaselines = binding.Db, false
+	defer func() {
+		vars.CurrentDB, vars.UsePlanBaselines = origDB, origUsePlanBaselines
+		vars.SQLKiller.Reset()
+	}()
+
+	start := time.Now()
+	rs, err := exec(sctx, binding.BindSQL)
+	if err != nil {
+		return 0, false, err
+	}
+	if rs == nil {
+		return time.Since(start), false, nil
+	}
+	defer terror.Call(rs.Close)
+	if maxTime > 0 {
+		timer := time.AfterFunc(maxTime-time.Since(start), func() {
+			vars.SQLKiller.SendKillSignal(sqlkiller.MaxExecTimeExceeded)
+		})
+		defer timer.Stop()
+	}
+	chk := rs.NewChunk(nil)
+	for {
+		if err = rs.Next(context.TODO(), chk); err != nil {
+			if maxTime > 0 && exeerrors.ErrMaxExecTimeExceeded.Equal(err) {
+				return time.Since(start), true, nil
+			}
+			return 0, false, err
+		}
+		if chk.NumRows() == 0 {
+			return time.Since(start), false, nil
+		}
+	}
+}
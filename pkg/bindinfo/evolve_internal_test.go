@@ -0,0 +1,64 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCandidateAccepted(t *testing.T) {
+	tests := []struct {
+		candidate time.Duration
+		accepted  time.Duration
+		expected  bool
+	}{
+		// Much faster than the enabled plan.
+		{time.Millisecond, 100 * time.Millisecond, true},
+		// Exactly 1.5 times faster.
+		{200 * time.Millisecond, 300 * time.Millisecond, true},
+		// Faster, but within the jitter range.
+		{201 * time.Millisecond, 300 * time.Millisecond, false},
+		{90 * time.Millisecond, 100 * time.Millisecond, false},
+		// As fast as or slower than the enabled plan.
+		{100 * time.Millisecond, 100 * time.Millisecond, false},
+		{time.Second, 100 * time.Millisecond, false},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, isCandidateAccepted(tt.candidate, tt.accepted), "candidate %v, accepted %v", tt.candidate, tt.accepted)
+	}
+}
+
+func TestPickBindingsToVerify(t *testing.T) {
+	bindings := Bindings{
+		{BindSQL: "a", Status: PendingVerify},
+		{BindSQL: "b", Status: Enabled},
+		{BindSQL: "c", Status: Rejected},
+		{BindSQL: "d", Status: PendingVerify},
+		{BindSQL: "e", Status: Enabled},
+	}
+	accepted, candidates := pickBindingsToVerify(bindings)
+	require.Equal(t, "b", accepted.BindSQL)
+	require.Len(t, candidates, 2)
+	require.Equal(t, "a", candidates[0].BindSQL)
+	require.Equal(t, "d", candidates[1].BindSQL)
+
+	// Without an enabled binding, there is nothing to compare with.
+	accepted, candidates = pickBindingsToVerify(bindings[:1])
+	require.Nil(t, accepted)
+	require.Nil(t, candidates)
+}
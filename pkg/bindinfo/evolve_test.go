@@ -0,0 +1,68 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/bindinfo"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvolveGlobalBindings(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b int, index idx_a(a))")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("set global tidb_evolve_plan_baselines = on")
+	defer tk.MustExec("set global tidb_evolve_plan_baselines = default")
+
+	tk.MustExec("create global binding for select * from t where a = 1 using select /*+ ignore_index(t, idx_a) */ * from t where a = 1")
+	tk.MustExec("admin evolve bindings")
+	rows := tk.MustQuery("select bind_sql, status, source from mysql.bind_info where source != 'builtin' order by create_time").Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, "SELECT /*+ ignore_index(`t` `idx_a`)*/ * FROM `test`.`t` WHERE `a` = 1", rows[0][0])
+	require.Equal(t, bindinfo.Manual, rows[0][2])
+	require.NotEqual(t, rows[0][0], rows[1][0])
+	require.Equal(t, bindinfo.Evolve, rows[1][2])
+	switch rows[1][1] {
+	case bindinfo.Enabled:
+		require.Equal(t, bindinfo.Replaced, rows[0][1])
+	case bindinfo.Rejected:
+		require.Equal(t, bindinfo.Enabled, rows[0][1])
+	default:
+		require.Failf(t, "unexpected status of the candidate binding", "%v", rows[1][1])
+	}
+
+	// Only the enabled binding takes effect.
+	tk.MustQuery("select * from t where a = 1").Check(testkit.Rows("1 1"))
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	bindings := tk.MustQuery("show global bindings").Rows()
+	require.Len(t, bindings, 2)
+
+	// The verified plan is not verified again.
+	tk.MustExec("admin evolve bindings")
+	tk.MustQuery("select count(*) from mysql.bind_info where source != 'builtin'").Check(testkit.Rows("2"))
+
+	// The disabled bindings are not evolved.
+	tk.MustExec("set binding disabled for select * from t where a = 1")
+	tk.MustExec("delete from mysql.bind_info where source = 'evolve'")
+	tk.MustExec("admin reload bindings")
+	tk.MustExec("admin evolve bindings")
+	tk.MustQuery("select count(*) from mysql.bind_info where source != 'builtin'").Check(testkit.Rows("1"))
+}
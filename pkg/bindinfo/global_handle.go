@@ -103,6 +103,11 @@ type GlobalBindingHandle interface {
 	// CaptureBaselines is used to automatically capture plan baselines.
 	CaptureBaselines()
 
+	// Methods for Baseline Evolution.
+
+	// EvolveGlobalBindings generates candidate bindings for the bound statements and verifies them.
+	EvolveGlobalBindings(maxTime time.Duration) error
+
 	variable.Statistics
 }
 
@@ -509,6 +514,10 @@ func (h *globalBindingHandle) MatchGlobalBinding(sctx sessionctx.Context, fuzzyD
 		sqlDigest := exactDigest
 		if bindings := bindingCache.GetBinding(sqlDigest); bindings != nil {
 			for _, binding := range bindings {
+				// Skip the bindings which can never be used, e.g. the candidates and history of baseline evolution.
+				if !binding.IsBindingAvailable() {
+					continue
+				}
 				numWildcards, matched := fuzzyMatchBindingTableName(sctx.GetSessionVars().CurrentDB, tableNames, binding.TableNames)
 				if matched && numWildcards > 0 && sctx != nil && !sctx.GetSessionVars().EnableFuzzyBinding {
 					continue // fuzzy binding is disabled, skip this binding
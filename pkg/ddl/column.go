@@ -979,6 +979,7 @@ func updateTTLInfoWhenModifyColumn(tblInfo *model.TableInfo, oldCol, newCol mode
 		if tblInfo.TTLInfo.ColumnName.L == oldCol.L {
 			tblInfo.TTLInfo.ColumnName = newCol
 		}
+		tblInfo.TTLInfo.Filter = renameColumnInTTLFilter(tblInfo.TTLInfo.Filter, oldCol, newCol)
 	}
 }
 
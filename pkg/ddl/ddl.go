@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	"github.com/pingcap/tidb/br/pkg/lightning/backend/external"
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/ddl/ingest"
 	sess "github.com/pingcap/tidb/pkg/ddl/internal/session"
@@ -750,6 +751,11 @@ func newDDL(ctx context.Context, options ...Option) *ddl {
 			return newLitBackfillScheduler(ctx, d, task, param)
 		})
 	scheduler.RegisterSchedulerCleanUpFactory(proto.Backfill, newBackfillCleanUpS3)
+	// each backfill worker buffers the index KVs in its writer before flushing
+	// them to the local engine or external storage.
+	proto.RegisterSubtaskResource(proto.Backfill, proto.SubtaskResource{
+		MemoryPerSlot: int64(external.DefaultMemSizeLimit),
+	})
 	// Register functions for enable/disable ddl when changing system variable `tidb_enable_ddl`.
 	variable.EnableDDL = d.EnableDDL
 	variable.DisableDDL = d.DisableDDL
@@ -3366,10 +3366,6 @@ func SetDirectResourceGroupSettings(groupInfo *model.ResourceGroupInfo, opt *ast
 			}
 		}
 	case ast.ResourceGroupBackground:
-		if groupInfo.Name.L != rg.DefaultResourceGroupName {
-			// FIXME: this is a temporary restriction, so we don't add a error-code for it.
-			return errors.New("unsupported operation. Currently, only the default resource group support change background settings")
-		}
 		if len(opt.BackgroundOptions) == 0 {
 			resourceGroupSettings.Background = nil
 		}
@@ -3494,7 +3490,9 @@ func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) err
 			tbInfo.PlacementPolicyRef = &model.PolicyRefInfo{
 				Name: model.NewCIStr(op.StrValue),
 			}
-		case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval:
+		case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval,
+			ast.TableOptionTTLArchive, ast.TableOptionTTLArchiveFormat, ast.TableOptionTTLPartitionLifecycle,
+			ast.TableOptionTTLFilter:
 			if ttlOptionsHandled {
 				continue
 			}
@@ -3503,6 +3501,12 @@ func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) err
 			if err != nil {
 				return err
 			}
+			ttlArchive, ttlArchiveFormat, err := getTTLArchiveInOptions(options)
+			if err != nil {
+				return err
+			}
+			ttlPartitionLifecycle := getTTLPartitionLifecycleInOptions(options)
+			ttlFilter := getTTLFilterInOptions(options)
 			// It's impossible that `ttlInfo` and `ttlEnable` are all nil, because we have met this option.
 			// After exclude the situation `ttlInfo == nil && ttlEnable != nil`, we could say `ttlInfo != nil`
 			if ttlInfo == nil {
@@ -3512,6 +3516,30 @@ func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) err
 				if ttlJobInterval != nil {
 					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_JOB_INTERVAL"))
 				}
+				if ttlArchive != nil {
+					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ARCHIVE"))
+				}
+				if ttlArchiveFormat != nil {
+					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ARCHIVE_FORMAT"))
+				}
+				if ttlPartitionLifecycle != nil {
+					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_PARTITION_LIFECYCLE"))
+				}
+				if ttlFilter != nil {
+					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_FILTER"))
+				}
+			}
+			if ttlArchive != nil {
+				ttlInfo.ArchiveURI = *ttlArchive
+			}
+			if ttlArchiveFormat != nil {
+				ttlInfo.ArchiveFormat = *ttlArchiveFormat
+			}
+			if ttlPartitionLifecycle != nil {
+				ttlInfo.PartitionLifecycle = *ttlPartitionLifecycle
+			}
+			if ttlFilter != nil {
+				ttlInfo.Filter = *ttlFilter
 			}
 
 			tbInfo.TTLInfo = ttlInfo
@@ -3878,10 +3906,15 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 					}
 				case ast.TableOptionEngine:
 				case ast.TableOptionRowFormat:
-				case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval:
+				case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval,
+					ast.TableOptionTTLArchive, ast.TableOptionTTLArchiveFormat, ast.TableOptionTTLPartitionLifecycle,
+					ast.TableOptionTTLFilter:
 					var ttlInfo *model.TTLInfo
 					var ttlEnable *bool
 					var ttlJobInterval *string
+					var ttlArchive, ttlArchiveFormat *string
+					var ttlPartitionLifecycle *bool
+					var ttlFilter *string
 
 					if ttlOptionsHandled {
 						continue
@@ -3890,7 +3923,14 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 					if err != nil {
 						return err
 					}
-					err = d.AlterTableTTLInfoOrEnable(sctx, ident, ttlInfo, ttlEnable, ttlJobInterval)
+					ttlArchive, ttlArchiveFormat, err = getTTLArchiveInOptions(spec.Options)
+					if err != nil {
+						return err
+					}
+					ttlPartitionLifecycle = getTTLPartitionLifecycleInOptions(spec.Options)
+					ttlFilter = getTTLFilterInOptions(spec.Options)
+					err = d.AlterTableTTLInfoOrEnable(sctx, ident, ttlInfo, ttlEnable, ttlJobInterval, ttlArchive, ttlArchiveFormat,
+						ttlPartitionLifecycle, ttlFilter)
 
 					ttlOptionsHandled = true
 				default:
@@ -6372,8 +6412,15 @@ func (d *ddl) AlterTableSetTiFlashReplica(ctx sessionctx.Context, ident ast.Iden
 // `.Enable`. If the `.TTLInfo` in the table info is empty, this function will return an error.
 // When `ttlInfo` is nil, and `ttlCronJobSchedule` is not, it will use the original `.TTLInfo` in the table info and modify the
 // `.JobInterval`. If the `.TTLInfo` in the table info is empty, this function will return an error.
+// When `ttlInfo` is nil, and `ttlArchive` or `ttlArchiveFormat` is not, it will use the original `.TTLInfo` in the table
+// info and modify the `.ArchiveURI` or `.ArchiveFormat`.
+// When `ttlInfo` is nil, and `ttlPartitionLifecycle` is not, it will use the original `.TTLInfo` in the table info and
+// modify the `.PartitionLifecycle`.
+// When `ttlInfo` is nil, and `ttlFilter` is not, it will use the original `.TTLInfo` in the table info and modify the
+// `.Filter`. The filter is validated and normalized before submitting the job.
 // When `ttlInfo` is not nil, it simply submits the job with the `ttlInfo` and ignore the `ttlEnable`.
-func (d *ddl) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident, ttlInfo *model.TTLInfo, ttlEnable *bool, ttlCronJobSchedule *string) error {
+func (d *ddl) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident, ttlInfo *model.TTLInfo, ttlEnable *bool,
+	ttlCronJobSchedule *string, ttlArchive *string, ttlArchiveFormat *string, ttlPartitionLifecycle *bool, ttlFilter *string) error {
 	is := d.infoCache.GetLatest()
 	schema, ok := is.SchemaByName(ident.Schema)
 	if !ok {
@@ -6391,11 +6438,20 @@ func (d *ddl) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident,
 
 	var job *model.Job
 	if ttlInfo != nil {
+		if ttlFilter != nil {
+			ttlInfo.Filter = *ttlFilter
+		} else if tblInfo.TTLInfo != nil {
+			// check the original filter with the new TTL config
+			ttlInfo.Filter = tblInfo.TTLInfo.Filter
+		}
 		tblInfo.TTLInfo = ttlInfo
 		err = checkTTLInfoValid(ctx, ident.Schema, tblInfo)
 		if err != nil {
 			return err
 		}
+		if ttlFilter != nil {
+			ttlFilter = &ttlInfo.Filter
+		}
 	} else {
 		if tblInfo.TTLInfo == nil {
 			if ttlEnable != nil {
@@ -6404,6 +6460,26 @@ func (d *ddl) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident,
 			if ttlCronJobSchedule != nil {
 				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_JOB_INTERVAL"))
 			}
+			if ttlArchive != nil {
+				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ARCHIVE"))
+			}
+			if ttlArchiveFormat != nil {
+				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ARCHIVE_FORMAT"))
+			}
+			if ttlPartitionLifecycle != nil {
+				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_PARTITION_LIFECYCLE"))
+			}
+			if ttlFilter != nil {
+				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_FILTER"))
+			}
+		}
+
+		if ttlFilter != nil {
+			filter, err := checkTTLFilter(ctx, tblInfo, *ttlFilter)
+			if err != nil {
+				return err
+			}
+			ttlFilter = &filter
 		}
 	}
 
@@ -6414,7 +6490,7 @@ func (d *ddl) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident,
 		TableName:      tableName,
 		Type:           model.ActionAlterTTLInfo,
 		BinlogInfo:     &model.HistoryInfo{},
-		Args:           []interface{}{ttlInfo, ttlEnable, ttlCronJobSchedule},
+		Args:           []interface{}{ttlInfo, ttlEnable, ttlCronJobSchedule, ttlArchive, ttlArchiveFormat, ttlPartitionLifecycle, ttlFilter},
 		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
 	}
 
@@ -8722,7 +8798,7 @@ func (d *ddl) AddResourceGroup(ctx sessionctx.Context, stmt *ast.CreateResourceG
 		return infoschema.ErrResourceGroupExists.GenWithStackByArgs(groupName)
 	}
 
-	if err := d.checkResourceGroupValidation(groupInfo); err != nil {
+	if err := d.checkResourceGroupValidation(d.GetInfoSchemaWithInterceptor(ctx), groupInfo); err != nil {
 		return err
 	}
 
@@ -8749,9 +8825,31 @@ func (d *ddl) AddResourceGroup(ctx sessionctx.Context, stmt *ast.CreateResourceG
 	return err
 }
 
-func (*ddl) checkResourceGroupValidation(groupInfo *model.ResourceGroupInfo) error {
+func (*ddl) checkResourceGroupValidation(is infoschema.InfoSchema, groupInfo *model.ResourceGroupInfo) error {
 	_, err := resourcegroup.NewGroupFromOptions(groupInfo.Name.L, groupInfo.ResourceGroupSettings)
-	return err
+	if err != nil {
+		return err
+	}
+	return checkResourceGroupBackgroundTaskTypes(is, groupInfo)
+}
+
+// checkResourceGroupBackgroundTaskTypes checks that each background task type
+// is assigned to at most one resource group other than the default one, see
+// rg.GetBackgroundGroupName.
+func checkResourceGroupBackgroundTaskTypes(is infoschema.InfoSchema, groupInfo *model.ResourceGroupInfo) error {
+	bg := groupInfo.Background
+	if groupInfo.Name.L == rg.DefaultResourceGroupName || bg == nil {
+		return nil
+	}
+	groups := is.AllResourceGroups()
+	for _, tp := range bg.JobTypes {
+		group := rg.GetBackgroundGroup(groups, tp)
+		if group != nil && group.Name.L != groupInfo.Name.L {
+			// FIXME: we don't add a error-code for it as the error of background task name.
+			return errors.Errorf("background task type '%s' is already assigned to resource group '%s'", tp, group.Name.O)
+		}
+	}
+	return nil
 }
 
 // DropResourceGroup implements the DDL interface.
@@ -8834,7 +8932,7 @@ func (d *ddl) AlterResourceGroup(ctx sessionctx.Context, stmt *ast.AlterResource
 		return errors.Trace(err)
 	}
 
-	if err := d.checkResourceGroupValidation(newGroupInfo); err != nil {
+	if err := d.checkResourceGroupValidation(is, newGroupInfo); err != nil {
 		return err
 	}
 
@@ -9245,7 +9343,19 @@ func NewDDLReorgMeta(ctx sessionctx.Context) *model.DDLReorgMeta {
 		Warnings:          make(map[errors.ErrorID]*terror.Error),
 		WarningsCount:     make(map[errors.ErrorID]int64),
 		Location:          &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
-		ResourceGroupName: ctx.GetSessionVars().StmtCtx.ResourceGroupName,
+		ResourceGroupName: getReorgResourceGroupName(ctx),
 		Version:           model.CurrentReorgMetaVersion,
 	}
 }
+
+// getReorgResourceGroupName gets the resource group of the reorg job, it's the
+// resource group which the DDL background tasks are assigned to if any, else
+// the resource group of the session.
+func getReorgResourceGroupName(ctx sessionctx.Context) string {
+	groupName := ctx.GetSessionVars().StmtCtx.ResourceGroupName
+	is, ok := ctx.GetDomainInfoSchema().(infoschema.InfoSchema)
+	if !ok {
+		return groupName
+	}
+	return rg.GetBackgroundGroupName(is.AllResourceGroups(), kvutil.ExplicitTypeDDL, groupName)
+}
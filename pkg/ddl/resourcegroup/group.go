@@ -16,6 +16,7 @@ package resourcegroup
 
 import (
 	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+	rg "github.com/pingcap/tidb/pkg/domain/resourcegroup"
 	"github.com/pingcap/tidb/pkg/parser/model"
 )
 
@@ -57,8 +58,12 @@ func NewGroupFromOptions(groupName string, options *model.ResourceGroupSettings)
 	}
 
 	if options.Background != nil {
-		group.BackgroundSettings = &rmpb.BackgroundSettings{
-			JobTypes: options.Background.JobTypes,
+		group.BackgroundSettings = &rmpb.BackgroundSettings{}
+		// The background task types of the other resource groups are handled
+		// by TiDB, see rg.GetBackgroundGroupName. They are not sent
+		// to PD so that the tasks are limited by the RU settings of the group.
+		if groupName == rg.DefaultResourceGroupName {
+			group.BackgroundSettings.JobTypes = options.Background.JobTypes
 		}
 	}
 
@@ -261,8 +261,20 @@ func TestResourceGroupBasic(t *testing.T) {
 	g = testResourceGroupNameFromIS(t, tk.Session(), "default")
 	require.EqualValues(t, g.Background.JobTypes, []string{"lightning", "br"})
 
-	tk.MustContainErrMsg("create resource group bg ru_per_sec = 1000 background = (task_types = 'lightning')", "unsupported operation")
-	tk.MustContainErrMsg("alter resource group x background=(task_types='')", "unsupported operation")
+	tk.MustExec("create resource group bg ru_per_sec = 1000 background = (task_types = 'ddl, stats')")
+	tk.MustQuery("select * from information_schema.resource_groups where name = 'bg'").Check(testkit.Rows("bg 1000 MEDIUM NO <nil> TASK_TYPES='ddl,stats'"))
+	tk.MustQuery("show create resource group bg").Check(testkit.Rows("bg CREATE RESOURCE GROUP `bg` RU_PER_SEC=1000, PRIORITY=MEDIUM, BACKGROUND=(TASK_TYPES='ddl,stats')"))
+	g = testResourceGroupNameFromIS(t, tk.Session(), "bg")
+	require.EqualValues(t, g.Background.JobTypes, []string{"ddl", "stats"})
+	tk.MustQuery("select name, task_type, rru, wru from information_schema.resource_group_background_usage").Check(testkit.Rows("bg ddl 0 0", "bg stats 0 0"))
+	tk.MustContainErrMsg("alter resource group x background=(task_types='stats')", "background task type 'stats' is already assigned to resource group 'bg'")
+	tk.MustContainErrMsg("create resource group bg2 ru_per_sec = 1000 background = (task_types = 'ddl')", "background task type 'ddl' is already assigned to resource group 'bg'")
+	tk.MustExec("alter resource group bg background=(task_types='ddl')")
+	tk.MustExec("alter resource group x background=(task_types='stats')")
+	tk.MustQuery("select name, task_type, rru, wru from information_schema.resource_group_background_usage").Check(testkit.Rows("bg ddl 0 0", "x stats 0 0"))
+	tk.MustExec("alter resource group x background=(task_types='')")
+	tk.MustExec("drop resource group bg")
+	tk.MustQuery("select name, task_type, rru, wru from information_schema.resource_group_background_usage").Check(testkit.Rows())
 	tk.MustGetErrCode("alter resource group default background=(task_types='a,b,c')", mysql.ErrResourceGroupInvalidBackgroundTaskName)
 }
 
@@ -449,6 +461,39 @@ func TestNewResourceGroupFromOptions(t *testing.T) {
 		err: resourcegroup.ErrInvalidResourceGroupDuplicatedMode,
 	})
 
+	tests = append(tests, TestCase{
+		name:      "normal case: background of default group",
+		groupName: "default",
+		input: &model.ResourceGroupSettings{
+			RURate:     1000,
+			Background: &model.ResourceGroupBackgroundSettings{JobTypes: []string{"lightning", "br"}},
+		},
+		output: &rmpb.ResourceGroup{
+			Name: "default",
+			Mode: rmpb.GroupMode_RUMode,
+			RUSettings: &rmpb.GroupRequestUnitSettings{
+				RU: &rmpb.TokenBucket{Settings: &rmpb.TokenLimitSettings{FillRate: 1000}},
+			},
+			BackgroundSettings: &rmpb.BackgroundSettings{JobTypes: []string{"lightning", "br"}},
+		},
+	})
+
+	tests = append(tests, TestCase{
+		name: "normal case: background of other group",
+		input: &model.ResourceGroupSettings{
+			RURate:     1000,
+			Background: &model.ResourceGroupBackgroundSettings{JobTypes: []string{"lightning", "br"}},
+		},
+		output: &rmpb.ResourceGroup{
+			Name: groupName,
+			Mode: rmpb.GroupMode_RUMode,
+			RUSettings: &rmpb.GroupRequestUnitSettings{
+				RU: &rmpb.TokenBucket{Settings: &rmpb.TokenLimitSettings{FillRate: 1000}},
+			},
+			BackgroundSettings: &rmpb.BackgroundSettings{},
+		},
+	})
+
 	tests = append(tests, TestCase{
 		name:      "error case: duplicated mode",
 		groupName: "test_group_too_looooooooooooooooooooooooooooooooooooooooooooooooong",
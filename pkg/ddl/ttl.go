@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/parser"
@@ -52,8 +53,12 @@ func onTTLInfoChange(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err er
 	var ttlInfo *model.TTLInfo
 	var ttlInfoEnable *bool
 	var ttlInfoJobInterval *string
+	var ttlArchive, ttlArchiveFormat *string
+	var ttlPartitionLifecycle *bool
+	var ttlFilter *string
 
-	if err := job.DecodeArgs(&ttlInfo, &ttlInfoEnable, &ttlInfoJobInterval); err != nil {
+	if err := job.DecodeArgs(&ttlInfo, &ttlInfoEnable, &ttlInfoJobInterval, &ttlArchive, &ttlArchiveFormat,
+		&ttlPartitionLifecycle, &ttlFilter); err != nil {
 		job.State = model.JobStateCancelled
 		return ver, errors.Trace(err)
 	}
@@ -71,6 +76,18 @@ func onTTLInfoChange(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err er
 		if ttlInfoJobInterval == nil && tblInfo.TTLInfo != nil {
 			ttlInfo.JobInterval = tblInfo.TTLInfo.JobInterval
 		}
+		if ttlArchive == nil && tblInfo.TTLInfo != nil {
+			ttlInfo.ArchiveURI = tblInfo.TTLInfo.ArchiveURI
+		}
+		if ttlArchiveFormat == nil && tblInfo.TTLInfo != nil {
+			ttlInfo.ArchiveFormat = tblInfo.TTLInfo.ArchiveFormat
+		}
+		if ttlPartitionLifecycle == nil && tblInfo.TTLInfo != nil {
+			ttlInfo.PartitionLifecycle = tblInfo.TTLInfo.PartitionLifecycle
+		}
+		if ttlFilter == nil && tblInfo.TTLInfo != nil {
+			ttlInfo.Filter = tblInfo.TTLInfo.Filter
+		}
 		tblInfo.TTLInfo = ttlInfo
 	}
 	if ttlInfoEnable != nil {
@@ -87,6 +104,34 @@ func onTTLInfoChange(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err er
 
 		tblInfo.TTLInfo.JobInterval = *ttlInfoJobInterval
 	}
+	if ttlArchive != nil {
+		if tblInfo.TTLInfo == nil {
+			return ver, errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ARCHIVE"))
+		}
+
+		tblInfo.TTLInfo.ArchiveURI = *ttlArchive
+	}
+	if ttlArchiveFormat != nil {
+		if tblInfo.TTLInfo == nil {
+			return ver, errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ARCHIVE_FORMAT"))
+		}
+
+		tblInfo.TTLInfo.ArchiveFormat = *ttlArchiveFormat
+	}
+	if ttlPartitionLifecycle != nil {
+		if tblInfo.TTLInfo == nil {
+			return ver, errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_PARTITION_LIFECYCLE"))
+		}
+
+		tblInfo.TTLInfo.PartitionLifecycle = *ttlPartitionLifecycle
+	}
+	if ttlFilter != nil {
+		if tblInfo.TTLInfo == nil {
+			return ver, errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_FILTER"))
+		}
+
+		tblInfo.TTLInfo.Filter = *ttlFilter
+	}
 
 	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
 	if err != nil {
@@ -105,7 +150,143 @@ func checkTTLInfoValid(ctx sessionctx.Context, schema model.CIStr, tblInfo *mode
 		return err
 	}
 
-	return checkTTLInfoColumnType(tblInfo)
+	if err := checkTTLInfoColumnType(tblInfo); err != nil {
+		return err
+	}
+
+	filter, err := checkTTLFilter(ctx, tblInfo, tblInfo.TTLInfo.Filter)
+	if err != nil {
+		return err
+	}
+	tblInfo.TTLInfo.Filter = filter
+	return nil
+}
+
+// checkTTLFilter checks the expression of TTL_FILTER and returns it in the normalized form. The expression should be
+// a deterministic expression which only references the columns of the table, because it's appended to the conditions
+// of the SQLs scanning and deleting the expired rows. An empty filter is always valid.
+func checkTTLFilter(ctx sessionctx.Context, tblInfo *model.TableInfo, filter string) (string, error) {
+	if strings.TrimSpace(filter) == "" {
+		return "", nil
+	}
+
+	expr, err := parseTTLFilter(filter)
+	if err != nil {
+		return "", dbterror.ErrInvalidTTLFilter.GenWithStackByArgs(filter, err.Error())
+	}
+
+	checker := &ttlFilterChecker{}
+	expr.Accept(checker)
+	if checker.err != nil {
+		return "", dbterror.ErrInvalidTTLFilter.GenWithStackByArgs(filter, checker.err.Error())
+	}
+
+	for _, col := range FindColumnNamesInExpr(expr) {
+		colInfo := findColumnByName(col.Name.L, tblInfo)
+		if colInfo == nil || colInfo.Hidden {
+			return "", dbterror.ErrBadField.GenWithStackByArgs(col.Name.O, "TTL_FILTER")
+		}
+	}
+
+	if _, err = expression.RewriteSimpleExprWithTableInfo(ctx, tblInfo, expr, false); err != nil {
+		return "", dbterror.ErrInvalidTTLFilter.GenWithStackByArgs(filter, err.Error())
+	}
+
+	return restoreTTLFilter(expr)
+}
+
+// parseTTLFilter parses the expression of TTL_FILTER. It's parsed as the only field of a SELECT statement, so that
+// the other clauses or statements are not allowed.
+func parseTTLFilter(filter string) (ast.ExprNode, error) {
+	stmts, _, err := parser.New().ParseSQL("SELECT " + filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stmts) != 1 {
+		return nil, errors.New("only one expression is allowed")
+	}
+
+	sel, ok := stmts[0].(*ast.SelectStmt)
+	if !ok || sel.Fields == nil || len(sel.Fields.Fields) != 1 || sel.From != nil || sel.Where != nil ||
+		sel.GroupBy != nil || sel.Having != nil || sel.WindowSpecs != nil || sel.OrderBy != nil || sel.Limit != nil ||
+		sel.LockInfo != nil || sel.SelectIntoOpt != nil {
+		return nil, errors.New("only one expression is allowed")
+	}
+
+	field := sel.Fields.Fields[0]
+	if field.WildCard != nil || field.AsName.L != "" {
+		return nil, errors.New("only one expression is allowed")
+	}
+	return field.Expr, nil
+}
+
+func restoreTTLFilter(expr ast.ExprNode) (string, error) {
+	var sb strings.Builder
+	if err := expr.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return "", errors.Trace(err)
+	}
+	return sb.String(), nil
+}
+
+// ttlFilterChecker checks whether an expression is allowed in TTL_FILTER
+type ttlFilterChecker struct {
+	err error
+}
+
+// Enter implements ast.Visitor interface.
+func (c *ttlFilterChecker) Enter(inNode ast.Node) (outNode ast.Node, skipChildren bool) {
+	switch node := inNode.(type) {
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr, *ast.CompareSubqueryExpr:
+		c.err = errors.New("subquery is not allowed")
+	case *ast.VariableExpr:
+		c.err = errors.New("variable is not allowed")
+	case ast.ParamMarkerExpr:
+		c.err = errors.New("param marker is not allowed")
+	case *ast.DefaultExpr:
+		c.err = errors.New("DEFAULT is not allowed")
+	case *ast.AggregateFuncExpr, *ast.WindowFuncExpr:
+		c.err = errors.New("aggregate or window function is not allowed")
+	case *ast.FuncCallExpr:
+		if _, ok := expression.IllegalFunctions4GeneratedColumns[node.FnName.L]; ok {
+			c.err = errors.Errorf("function %s is not allowed", node.FnName.O)
+		}
+	}
+	return inNode, c.err != nil
+}
+
+// Leave implements ast.Visitor interface.
+func (c *ttlFilterChecker) Leave(inNode ast.Node) (node ast.Node, ok bool) {
+	return inNode, c.err == nil
+}
+
+// renameColumnInTTLFilter renames the column referenced by the TTL_FILTER expression
+func renameColumnInTTLFilter(filter string, oldCol, newCol model.CIStr) string {
+	if filter == "" {
+		return filter
+	}
+
+	expr, err := parseTTLFilter(filter)
+	if err != nil {
+		return filter
+	}
+
+	renamed := false
+	for _, col := range FindColumnNamesInExpr(expr) {
+		if col.Name.L == oldCol.L {
+			col.Name = newCol
+			renamed = true
+		}
+	}
+	if !renamed {
+		return filter
+	}
+
+	newFilter, err := restoreTTLFilter(expr)
+	if err != nil {
+		return filter
+	}
+	return newFilter
 }
 
 func checkTTLIntervalExpr(ctx sessionctx.Context, ttlInfo *model.TTLInfo) error {
@@ -161,6 +342,18 @@ func checkDropColumnWithTTLConfig(tblInfo *model.TableInfo, colName string) erro
 		if tblInfo.TTLInfo.ColumnName.L == colName {
 			return dbterror.ErrTTLColumnCannotDrop.GenWithStackByArgs(colName)
 		}
+
+		if tblInfo.TTLInfo.Filter != "" {
+			expr, err := parseTTLFilter(tblInfo.TTLInfo.Filter)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			for _, col := range FindColumnNamesInExpr(expr) {
+				if col.Name.L == colName {
+					return dbterror.ErrTTLColumnCannotDrop.GenWithStackByArgs(colName)
+				}
+			}
+		}
 	}
 
 	return nil
@@ -232,3 +425,58 @@ func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, tt
 	}
 	return ttlInfo, ttlEnable, ttlCronJobSchedule, nil
 }
+
+// getTTLArchiveInOptions returns the TTL_ARCHIVE and TTL_ARCHIVE_FORMAT options,
+// the corresponding return value will be nil if it's not set.
+func getTTLArchiveInOptions(options []*ast.TableOption) (ttlArchive *string, ttlArchiveFormat *string, err error) {
+	for _, op := range options {
+		switch op.Tp {
+		case ast.TableOptionTTLArchive:
+			if err := checkTTLArchiveURI(op.StrValue); err != nil {
+				return nil, nil, err
+			}
+			ttlArchive = &op.StrValue
+		case ast.TableOptionTTLArchiveFormat:
+			ttlArchiveFormat = &op.StrValue
+		}
+	}
+	return ttlArchive, ttlArchiveFormat, nil
+}
+
+// getTTLPartitionLifecycleInOptions returns the TTL_PARTITION_LIFECYCLE option,
+// it returns nil if the option is not set.
+func getTTLPartitionLifecycleInOptions(options []*ast.TableOption) (ttlPartitionLifecycle *bool) {
+	for _, op := range options {
+		if op.Tp == ast.TableOptionTTLPartitionLifecycle {
+			ttlPartitionLifecycle = &op.BoolValue
+		}
+	}
+	return ttlPartitionLifecycle
+}
+
+// getTTLFilterInOptions returns the TTL_FILTER option, it returns nil if the option is not set.
+func getTTLFilterInOptions(options []*ast.TableOption) (ttlFilter *string) {
+	for _, op := range options {
+		if op.Tp == ast.TableOptionTTLFilter {
+			ttlFilter = &op.StrValue
+		}
+	}
+	return ttlFilter
+}
+
+// checkTTLArchiveURI checks whether the URI of TTL_ARCHIVE is a valid external
+// storage URI. An empty URI disables the archive. The local storage is rejected
+// because the rows would be written to the disk of whichever TiDB runs the job.
+func checkTTLArchiveURI(uri string) error {
+	if uri == "" {
+		return nil
+	}
+	b, err := storage.ParseBackend(uri, nil)
+	if err != nil {
+		return dbterror.ErrInvalidTTLArchiveURI.GenWithStackByArgs(ast.RedactURL(uri), err.Error())
+	}
+	if b.GetLocal() != nil {
+		return dbterror.ErrInvalidTTLArchiveURI.GenWithStackByArgs(ast.RedactURL(uri), "the local storage is not supported")
+	}
+	return nil
+}
@@ -19,6 +19,9 @@ import (
 
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/mock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -148,3 +151,115 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 		assert.Equal(t, c.err, err)
 	}
 }
+
+func Test_getTTLArchiveInOptions(t *testing.T) {
+	ttlArchive, ttlArchiveFormat, err := getTTLArchiveInOptions([]*ast.TableOption{})
+	assert.NoError(t, err)
+	assert.Nil(t, ttlArchive)
+	assert.Nil(t, ttlArchiveFormat)
+
+	ttlArchive, ttlArchiveFormat, err = getTTLArchiveInOptions([]*ast.TableOption{
+		{Tp: ast.TableOptionTTLArchive, StrValue: "s3://bucket/prefix"},
+		{Tp: ast.TableOptionTTLArchiveFormat, StrValue: "parquet"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "s3://bucket/prefix", *ttlArchive)
+	assert.Equal(t, "parquet", *ttlArchiveFormat)
+
+	// an empty uri disables the archive
+	ttlArchive, ttlArchiveFormat, err = getTTLArchiveInOptions([]*ast.TableOption{
+		{Tp: ast.TableOptionTTLArchive, StrValue: ""},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "", *ttlArchive)
+	assert.Nil(t, ttlArchiveFormat)
+
+	_, _, err = getTTLArchiveInOptions([]*ast.TableOption{
+		{Tp: ast.TableOptionTTLArchive, StrValue: "unknown://bucket/prefix"},
+	})
+	assert.ErrorContains(t, err, "The URI of TTL_ARCHIVE unknown://bucket/prefix is invalid")
+
+	for _, uri := range []string{"/tmp/archive", "file:///tmp/archive", "local:///tmp/archive"} {
+		_, _, err = getTTLArchiveInOptions([]*ast.TableOption{
+			{Tp: ast.TableOptionTTLArchive, StrValue: uri},
+		})
+		assert.ErrorContains(t, err, "the local storage is not supported", uri)
+	}
+}
+
+func Test_getTTLPartitionLifecycleInOptions(t *testing.T) {
+	assert.Nil(t, getTTLPartitionLifecycleInOptions([]*ast.TableOption{}))
+
+	ttlPartitionLifecycle := getTTLPartitionLifecycleInOptions([]*ast.TableOption{
+		{Tp: ast.TableOptionTTLPartitionLifecycle, BoolValue: true},
+	})
+	assert.True(t, *ttlPartitionLifecycle)
+
+	// the last option wins
+	ttlPartitionLifecycle = getTTLPartitionLifecycleInOptions([]*ast.TableOption{
+		{Tp: ast.TableOptionTTLPartitionLifecycle, BoolValue: true},
+		{Tp: ast.TableOptionTTLPartitionLifecycle, BoolValue: false},
+	})
+	assert.False(t, *ttlPartitionLifecycle)
+}
+
+func Test_getTTLFilterInOptions(t *testing.T) {
+	assert.Nil(t, getTTLFilterInOptions([]*ast.TableOption{}))
+
+	ttlFilter := getTTLFilterInOptions([]*ast.TableOption{
+		{Tp: ast.TableOptionTTLFilter, StrValue: "pinned = 0"},
+	})
+	assert.Equal(t, "pinned = 0", *ttlFilter)
+}
+
+func Test_checkTTLFilter(t *testing.T) {
+	tblInfo := &model.TableInfo{
+		Name: model.NewCIStr("t"),
+		Columns: []*model.ColumnInfo{
+			{ID: 1, Name: model.NewCIStr("created_at"), Offset: 0, State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeDatetime)},
+			{ID: 2, Name: model.NewCIStr("pinned"), Offset: 1, State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeLong)},
+			{ID: 3, Name: model.NewCIStr("status"), Offset: 2, State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeVarchar)},
+			{ID: 4, Name: model.NewCIStr("_hidden"), Offset: 3, State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeLong), Hidden: true},
+		},
+	}
+	ctx := mock.NewContext()
+
+	cases := []struct {
+		filter     string
+		normalized string
+		err        string
+	}{
+		{"", "", ""},
+		{"  ", "", ""},
+		{"pinned <> 1", "`pinned`!=1", ""},
+		{"Pinned = 0 and status in ('done', 'failed')", "`Pinned`=0 AND `status` IN (_UTF8MB4'done',_UTF8MB4'failed')", ""},
+		{"t.pinned is null", "`t`.`pinned` IS NULL", ""},
+		{"unknown = 1", "", "Unknown column 'unknown' in 'TTL_FILTER'"},
+		{"_hidden = 1", "", "Unknown column '_hidden' in 'TTL_FILTER'"},
+		{"pinned = 0 from t", "", "only one expression is allowed"},
+		{"pinned = 0, status = 'a'", "", "only one expression is allowed"},
+		{"pinned = 0; drop table t", "", "only one expression is allowed"},
+		{"pinned in (select 1)", "", "subquery is not allowed"},
+		{"pinned = @a", "", "variable is not allowed"},
+		{"created_at < now()", "", "function now is not allowed"},
+		{"rand() < 0.5", "", "function rand is not allowed"},
+		{"sum(pinned) > 0", "", "aggregate or window function is not allowed"},
+		{"pinned = ", "", "is invalid. Reason: line 1"},
+	}
+
+	for _, c := range cases {
+		normalized, err := checkTTLFilter(ctx, tblInfo, c.filter)
+		if c.err != "" {
+			assert.ErrorContains(t, err, c.err, c.filter)
+			continue
+		}
+		assert.NoError(t, err, c.filter)
+		assert.Equal(t, c.normalized, normalized, c.filter)
+	}
+}
+
+func Test_renameColumnInTTLFilter(t *testing.T) {
+	assert.Equal(t, "", renameColumnInTTLFilter("", model.NewCIStr("a"), model.NewCIStr("b")))
+	assert.Equal(t, "`c`=1", renameColumnInTTLFilter("`c`=1", model.NewCIStr("a"), model.NewCIStr("b")))
+	assert.Equal(t, "`b`=1 AND `c`=`b`", renameColumnInTTLFilter("`A`=1 AND `c`=`a`", model.NewCIStr("a"), model.NewCIStr("b")))
+}
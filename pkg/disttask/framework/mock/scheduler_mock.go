@@ -448,19 +448,19 @@ func (mr *MockTaskManagerMockRecorder) GetTopUnfinishedTasks(arg0 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopUnfinishedTasks", reflect.TypeOf((*MockTaskManager)(nil).GetTopUnfinishedTasks), arg0)
 }
 
-// GetUsedSlotsOnNodes mocks base method.
-func (m *MockTaskManager) GetUsedSlotsOnNodes(arg0 context.Context) (map[string]int, error) {
+// GetUsedResourcesOnNodes mocks base method.
+func (m *MockTaskManager) GetUsedResourcesOnNodes(arg0 context.Context) (map[string]proto.NodeResource, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsedSlotsOnNodes", arg0)
-	ret0, _ := ret[0].(map[string]int)
+	ret := m.ctrl.Call(m, "GetUsedResourcesOnNodes", arg0)
+	ret0, _ := ret[0].(map[string]proto.NodeResource)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUsedSlotsOnNodes indicates an expected call of GetUsedSlotsOnNodes.
-func (mr *MockTaskManagerMockRecorder) GetUsedSlotsOnNodes(arg0 any) *gomock.Call {
+// GetUsedResourcesOnNodes indicates an expected call of GetUsedResourcesOnNodes.
+func (mr *MockTaskManagerMockRecorder) GetUsedResourcesOnNodes(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsedSlotsOnNodes", reflect.TypeOf((*MockTaskManager)(nil).GetUsedSlotsOnNodes), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsedResourcesOnNodes", reflect.TypeOf((*MockTaskManager)(nil).GetUsedResourcesOnNodes), arg0)
 }
 
 // PauseTask mocks base method.
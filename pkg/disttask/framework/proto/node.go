@@ -22,4 +22,12 @@ type ManagedNode struct {
 	// all managed node should have the same role
 	Role     string
 	CPUCount int
+	// MemoryLimit is the memory limit of the node in bytes, it's the value of
+	// tidb_server_memory_limit. 0 means unknown or no limit.
+	MemoryLimit int64
+}
+
+// Capacity returns the resource capacity of the node.
+func (n *ManagedNode) Capacity() NodeResource {
+	return NodeResource{Slots: n.CPUCount, Memory: n.MemoryLimit}
 }
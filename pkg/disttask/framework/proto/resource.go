@@ -0,0 +1,121 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proto
+
+import "sync"
+
+// NodeResource is the resource of a node managed by the framework, it's used to
+// represent both the capacity of a node and the resource used on it.
+type NodeResource struct {
+	// Slots is the number of slots, each slot represents 1 cpu core.
+	Slots int
+	// Memory is the memory in bytes. For capacity, 0 means the memory of the
+	// node is unknown and it's not limited when scheduling.
+	Memory int64
+}
+
+// Add returns the sum of r and other.
+func (r NodeResource) Add(other NodeResource) NodeResource {
+	return NodeResource{Slots: r.Slots + other.Slots, Memory: r.Memory + other.Memory}
+}
+
+// Sub returns the difference of r and other.
+func (r NodeResource) Sub(other NodeResource) NodeResource {
+	return NodeResource{Slots: r.Slots - other.Slots, Memory: r.Memory - other.Memory}
+}
+
+// IsZero returns whether r has no resource.
+func (r NodeResource) IsZero() bool {
+	return r.Slots == 0 && r.Memory == 0
+}
+
+// Fits returns whether the resource r can be allocated from the capacity, as
+// the memory of capacity might be unknown, we only check slots in this case.
+func (r NodeResource) Fits(capacity NodeResource) bool {
+	if r.Slots > capacity.Slots {
+		return false
+	}
+	return capacity.Memory <= 0 || r.Memory <= capacity.Memory
+}
+
+// CanAlloc returns whether the required resource r can be allocated from the
+// capacity with the used resource. Memory is a scheduling preference, it's not
+// checked if r requires no memory, or more memory than the whole capacity which
+// can never be satisfied.
+func (r NodeResource) CanAlloc(used, capacity NodeResource) bool {
+	if used.Slots+r.Slots > capacity.Slots {
+		return false
+	}
+	if capacity.Memory <= 0 || r.Memory == 0 || r.Memory > capacity.Memory {
+		return true
+	}
+	return used.Memory+r.Memory <= capacity.Memory
+}
+
+// SubtaskResource is the resource required by the subtasks of a task type.
+// A subtask takes task.Concurrency slots on the node it runs, i.e. the cpu cores,
+// and the memory it requires is proportional to the slots too.
+type SubtaskResource struct {
+	// MemoryPerSlot is the memory in bytes required by each slot, 0 means the
+	// task type doesn't declare its memory requirement, and only the slots are
+	// considered when scheduling.
+	MemoryPerSlot int64
+}
+
+var subtaskResources = struct {
+	sync.RWMutex
+	m map[TaskType]SubtaskResource
+}{
+	m: make(map[TaskType]SubtaskResource),
+}
+
+// RegisterSubtaskResource declares the resource required by the subtasks of the
+// task type, both the scheduler and the task executor use it to check whether a
+// node has enough resource to run the subtasks.
+// it should be called in init() as RegisterSchedulerFactory.
+func RegisterSubtaskResource(taskType TaskType, resource SubtaskResource) {
+	subtaskResources.Lock()
+	defer subtaskResources.Unlock()
+	subtaskResources.m[taskType] = resource
+}
+
+// GetSubtaskResource gets the resource required by the subtasks of the task type.
+func GetSubtaskResource(taskType TaskType) SubtaskResource {
+	subtaskResources.RLock()
+	defer subtaskResources.RUnlock()
+	return subtaskResources.m[taskType]
+}
+
+// RequiredResource returns the resource required by each subtask of the task on
+// the node it runs.
+func (t *Task) RequiredResource() NodeResource {
+	return GetSubtaskResource(t.Type).ForConcurrency(t.Concurrency)
+}
+
+// ForConcurrency returns the resource required by a subtask of the concurrency.
+func (r SubtaskResource) ForConcurrency(concurrency int) NodeResource {
+	return NodeResource{Slots: concurrency, Memory: r.MemoryPerSlot * int64(concurrency)}
+}
+
+// MaxConcurrency returns the max concurrency of a subtask that fits the capacity.
+// As memory is a scheduling preference, it's at least 1 if the capacity has
+// slots, even if the memory isn't enough for a single slot.
+func (r SubtaskResource) MaxConcurrency(capacity NodeResource) int {
+	concurrency := capacity.Slots
+	if capacity.Memory > 0 && r.MemoryPerSlot > 0 {
+		concurrency = min(concurrency, int(capacity.Memory/r.MemoryPerSlot))
+	}
+	return max(concurrency, min(capacity.Slots, 1))
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeResource(t *testing.T) {
+	capacity := NodeResource{Slots: 8, Memory: 16 << 30}
+	require.True(t, NodeResource{Slots: 8, Memory: 16 << 30}.Fits(capacity))
+	require.False(t, NodeResource{Slots: 9}.Fits(capacity))
+	require.False(t, NodeResource{Slots: 1, Memory: 16<<30 + 1}.Fits(capacity))
+	// memory of the node is unknown.
+	require.True(t, NodeResource{Slots: 8, Memory: 32 << 30}.Fits(NodeResource{Slots: 8}))
+
+	used := NodeResource{Slots: 2, Memory: 4 << 30}.Add(NodeResource{Slots: 4, Memory: 8 << 30})
+	require.Equal(t, NodeResource{Slots: 6, Memory: 12 << 30}, used)
+	require.Equal(t, NodeResource{Slots: 2, Memory: 4 << 30}, capacity.Sub(used))
+	require.True(t, used.Sub(used).IsZero())
+
+	require.True(t, NodeResource{Slots: 2, Memory: 4 << 30}.CanAlloc(used, capacity))
+	require.False(t, NodeResource{Slots: 3}.CanAlloc(used, capacity))
+	require.False(t, NodeResource{Slots: 2, Memory: 4<<30 + 1}.CanAlloc(used, capacity))
+	// memory is a preference, it's not checked if it can never be satisfied.
+	require.True(t, NodeResource{Slots: 2, Memory: 32 << 30}.CanAlloc(used, capacity))
+	// memory isn't checked for the resource without memory, even if the used
+	// memory exceeds the capacity.
+	overUsed := NodeResource{Slots: 4, Memory: 32 << 30}
+	require.True(t, NodeResource{Slots: 4}.CanAlloc(overUsed, capacity))
+	require.False(t, NodeResource{Slots: 4, Memory: 1}.CanAlloc(overUsed, capacity))
+	require.True(t, NodeResource{Slots: 4, Memory: 32 << 30}.CanAlloc(overUsed, NodeResource{Slots: 8}))
+}
+
+func TestSubtaskResource(t *testing.T) {
+	tp := TaskType("resource-test")
+	task := &Task{Type: tp, Concurrency: 4}
+	require.Equal(t, NodeResource{Slots: 4}, task.RequiredResource())
+
+	RegisterSubtaskResource(tp, SubtaskResource{MemoryPerSlot: 1 << 30})
+	t.Cleanup(func() {
+		subtaskResources.Lock()
+		delete(subtaskResources.m, tp)
+		subtaskResources.Unlock()
+	})
+	require.Equal(t, SubtaskResource{MemoryPerSlot: 1 << 30}, GetSubtaskResource(tp))
+	require.Equal(t, NodeResource{Slots: 4, Memory: 4 << 30}, task.RequiredResource())
+
+	r := GetSubtaskResource(tp)
+	require.Equal(t, 8, r.MaxConcurrency(NodeResource{Slots: 8, Memory: 16 << 30}))
+	require.Equal(t, 3, r.MaxConcurrency(NodeResource{Slots: 8, Memory: 3<<30 + 1}))
+	require.Equal(t, 8, r.MaxConcurrency(NodeResource{Slots: 8}))
+	// memory isn't enough for a single slot.
+	require.Equal(t, 1, r.MaxConcurrency(NodeResource{Slots: 8, Memory: 1 << 20}))
+	require.Equal(t, 0, r.MaxConcurrency(NodeResource{}))
+	require.Equal(t, 8, SubtaskResource{}.MaxConcurrency(NodeResource{Slots: 8, Memory: 1 << 20}))
+}
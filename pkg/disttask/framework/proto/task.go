@@ -91,10 +91,6 @@ const (
 	NormalPriority = 512
 )
 
-// MaxConcurrentTask is the max concurrency of task.
-// TODO: remove this limit later.
-var MaxConcurrentTask = 4
-
 // Task represents the task of distributed framework.
 // tasks are run in the order of: priority asc, create_time asc, id asc.
 type Task struct {
@@ -21,6 +21,12 @@ const (
 	ImportInto TaskType = "ImportInto"
 	// Backfill is TaskType of add index Backfilling process.
 	Backfill TaskType = "backfill"
+	// Analyze is TaskType of collecting samples for ANALYZE.
+	Analyze TaskType = "Analyze"
+	// ChecksumTable is TaskType of ADMIN CHECKSUM TABLE.
+	ChecksumTable TaskType = "ChecksumTable"
+	// CheckTable is TaskType of ADMIN CHECK TABLE.
+	CheckTable TaskType = "CheckTable"
 )
 
 // Type2Int converts task type to int.
@@ -32,6 +38,12 @@ func Type2Int(t TaskType) int {
 		return 2
 	case Backfill:
 		return 3
+	case Analyze:
+		return 4
+	case ChecksumTable:
+		return 5
+	case CheckTable:
+		return 6
 	default:
 		return 0
 	}
@@ -46,6 +58,12 @@ func Int2Type(i int) TaskType {
 		return ImportInto
 	case 3:
 		return Backfill
+	case 4:
+		return Analyze
+	case 5:
+		return ChecksumTable
+	case 6:
+		return CheckTable
 	default:
 		return ""
 	}
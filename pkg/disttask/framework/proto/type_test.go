@@ -28,6 +28,9 @@ func TestTaskType(t *testing.T) {
 		{TaskTypeExample, 1},
 		{ImportInto, 2},
 		{Backfill, 3},
+		{Analyze, 4},
+		{ChecksumTable, 5},
+		{CheckTable, 6},
 		{"", 0},
 	}
 	for _, c := range cases {
@@ -43,13 +43,13 @@ type balancer struct {
 
 	// a helper temporary map to record the used slots of each node during balance
 	// to avoid passing it around.
-	currUsedSlots map[string]int
+	currUsedSlots map[string]proto.NodeResource
 }
 
 func newBalancer(param Param) *balancer {
 	return &balancer{
 		Param:         param,
-		currUsedSlots: make(map[string]int),
+		currUsedSlots: make(map[string]proto.NodeResource),
 	}
 }
 
@@ -69,9 +69,9 @@ func (b *balancer) balance(ctx context.Context, sm *Manager) {
 	// it's initial value depends on the managed nodes, to have a consistent view,
 	// DO NOT call getManagedNodes twice during 1 balance.
 	managedNodes := b.nodeMgr.getManagedNodes()
-	b.currUsedSlots = make(map[string]int, len(managedNodes))
+	b.currUsedSlots = make(map[string]proto.NodeResource, len(managedNodes))
 	for _, n := range managedNodes {
-		b.currUsedSlots[n] = 0
+		b.currUsedSlots[n] = proto.NodeResource{}
 	}
 
 	schedulers := sm.getSchedulers()
@@ -107,8 +107,8 @@ func (b *balancer) doBalanceSubtasks(ctx context.Context, taskID int64, eligible
 
 	// balance subtasks only to nodes with enough slots, from the view of all
 	// managed nodes, subtasks of task might not be balanced.
-	adjustedNodes := filterNodesWithEnoughSlots(b.currUsedSlots, b.slotMgr.getCapacity(),
-		eligibleNodes, subtasks[0].Concurrency)
+	adjustedNodes := b.slotMgr.filterNodesWithEnoughSlots(b.currUsedSlots, eligibleNodes,
+		proto.GetSubtaskResource(subtasks[0].Type).ForConcurrency(subtasks[0].Concurrency))
 	if len(adjustedNodes) == 0 {
 		// no node has enough slots to run the subtasks, skip balance and skip
 		// update used slots.
@@ -205,15 +205,15 @@ func (b *balancer) doBalanceSubtasks(ctx context.Context, taskID int64, eligible
 }
 
 func (b *balancer) updateUsedNodes(subtasks []*proto.Subtask) {
-	used := make(map[string]int, len(b.currUsedSlots))
+	used := make(map[string]proto.NodeResource, len(b.currUsedSlots))
 	// see slotManager.alloc in task executor.
 	for _, st := range subtasks {
 		if _, ok := used[st.ExecID]; !ok {
-			used[st.ExecID] = st.Concurrency
+			used[st.ExecID] = proto.GetSubtaskResource(st.Type).ForConcurrency(st.Concurrency)
 		}
 	}
 
 	for node, slots := range used {
-		b.currUsedSlots[node] += slots
+		b.currUsedSlots[node] = b.currUsedSlots[node].Add(slots)
 	}
 }
@@ -30,9 +30,9 @@ import (
 type balanceTestCase struct {
 	subtasks          []*proto.Subtask
 	eligibleNodes     []string
-	initUsedSlots     map[string]int
+	initUsedSlots     map[string]proto.NodeResource
 	expectedSubtasks  []*proto.Subtask
-	expectedUsedSlots map[string]int
+	expectedUsedSlots map[string]proto.NodeResource
 }
 
 func TestBalanceOneTask(t *testing.T) {
@@ -43,9 +43,9 @@ func TestBalanceOneTask(t *testing.T) {
 		{
 			subtasks:          []*proto.Subtask{},
 			eligibleNodes:     []string{"tidb1"},
-			initUsedSlots:     map[string]int{"tidb1": 0},
+			initUsedSlots:     map[string]proto.NodeResource{"tidb1": {}},
 			expectedSubtasks:  []*proto.Subtask{},
-			expectedUsedSlots: map[string]int{"tidb1": 0},
+			expectedUsedSlots: map[string]proto.NodeResource{"tidb1": {}},
 		},
 		// balanced, no need to do anything.
 		{
@@ -55,13 +55,13 @@ func TestBalanceOneTask(t *testing.T) {
 				{ID: 3, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
 			eligibleNodes: []string{"tidb1", "tidb2"},
-			initUsedSlots: map[string]int{"tidb1": 0, "tidb2": 0},
+			initUsedSlots: map[string]proto.NodeResource{"tidb1": {}, "tidb2": {}},
 			expectedSubtasks: []*proto.Subtask{
 				{ID: 1, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStateRunning},
 				{ID: 2, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStateRunning},
 				{ID: 3, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
-			expectedUsedSlots: map[string]int{"tidb1": 16, "tidb2": 16},
+			expectedUsedSlots: map[string]proto.NodeResource{"tidb1": {Slots: 16}, "tidb2": {Slots: 16}},
 		},
 		// balanced case 2, make sure the remainder calculate part is right, so we don't
 		// balance subtasks to 2:2:0
@@ -73,14 +73,14 @@ func TestBalanceOneTask(t *testing.T) {
 				{ID: 4, ExecID: "tidb3", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
 			eligibleNodes: []string{"tidb1", "tidb2", "tidb3"},
-			initUsedSlots: map[string]int{"tidb1": 0, "tidb2": 0, "tidb3": 0},
+			initUsedSlots: map[string]proto.NodeResource{"tidb1": {}, "tidb2": {}, "tidb3": {}},
 			expectedSubtasks: []*proto.Subtask{
 				{ID: 1, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStateRunning},
 				{ID: 2, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStatePending},
 				{ID: 3, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
 				{ID: 4, ExecID: "tidb3", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
-			expectedUsedSlots: map[string]int{"tidb1": 16, "tidb2": 16, "tidb3": 16},
+			expectedUsedSlots: map[string]proto.NodeResource{"tidb1": {Slots: 16}, "tidb2": {Slots: 16}, "tidb3": {Slots: 16}},
 		},
 		// no eligible nodes to run those subtasks, leave it unbalanced.
 		// used slots will not be changed.
@@ -90,12 +90,12 @@ func TestBalanceOneTask(t *testing.T) {
 				{ID: 2, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
 			eligibleNodes: []string{"tidb1", "tidb2"},
-			initUsedSlots: map[string]int{"tidb1": 8, "tidb2": 8},
+			initUsedSlots: map[string]proto.NodeResource{"tidb1": {Slots: 8}, "tidb2": {Slots: 8}},
 			expectedSubtasks: []*proto.Subtask{
 				{ID: 1, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStateRunning},
 				{ID: 2, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
-			expectedUsedSlots: map[string]int{"tidb1": 8, "tidb2": 8},
+			expectedUsedSlots: map[string]proto.NodeResource{"tidb1": {Slots: 8}, "tidb2": {Slots: 8}},
 		},
 		// balance subtasks to eligible nodes, tidb1 has 8 used slots cannot run target subtasks.
 		// all subtasks will be balanced to tidb2.
@@ -105,12 +105,12 @@ func TestBalanceOneTask(t *testing.T) {
 				{ID: 2, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
 			eligibleNodes: []string{"tidb1", "tidb2"},
-			initUsedSlots: map[string]int{"tidb1": 8, "tidb2": 0},
+			initUsedSlots: map[string]proto.NodeResource{"tidb1": {Slots: 8}, "tidb2": {}},
 			expectedSubtasks: []*proto.Subtask{
 				{ID: 1, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStateRunning},
 				{ID: 2, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
-			expectedUsedSlots: map[string]int{"tidb1": 8, "tidb2": 16},
+			expectedUsedSlots: map[string]proto.NodeResource{"tidb1": {Slots: 8}, "tidb2": {Slots: 16}},
 		},
 		// running subtasks are not re-scheduled if the node is eligible, we leave it un-balanced.
 		// task executor should mark those subtasks as pending, then we can balance them.
@@ -121,13 +121,13 @@ func TestBalanceOneTask(t *testing.T) {
 				{ID: 3, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStateRunning},
 			},
 			eligibleNodes: []string{"tidb1", "tidb2"},
-			initUsedSlots: map[string]int{"tidb1": 0, "tidb2": 0},
+			initUsedSlots: map[string]proto.NodeResource{"tidb1": {}, "tidb2": {}},
 			expectedSubtasks: []*proto.Subtask{
 				{ID: 1, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStateRunning},
 				{ID: 2, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStateRunning},
 				{ID: 3, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStateRunning},
 			},
-			expectedUsedSlots: map[string]int{"tidb1": 16, "tidb2": 0},
+			expectedUsedSlots: map[string]proto.NodeResource{"tidb1": {Slots: 16}, "tidb2": {}},
 		},
 		// balance from 1:4 to 2:3
 		{
@@ -139,7 +139,7 @@ func TestBalanceOneTask(t *testing.T) {
 				{ID: 5, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
 			eligibleNodes: []string{"tidb1", "tidb2"},
-			initUsedSlots: map[string]int{"tidb1": 0, "tidb2": 0},
+			initUsedSlots: map[string]proto.NodeResource{"tidb1": {}, "tidb2": {}},
 			expectedSubtasks: []*proto.Subtask{
 				{ID: 1, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStateRunning},
 				{ID: 2, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStateRunning},
@@ -147,7 +147,7 @@ func TestBalanceOneTask(t *testing.T) {
 				{ID: 4, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStatePending},
 				{ID: 5, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
-			expectedUsedSlots: map[string]int{"tidb1": 16, "tidb2": 16},
+			expectedUsedSlots: map[string]proto.NodeResource{"tidb1": {Slots: 16}, "tidb2": {Slots: 16}},
 		},
 		// scale out, balance from 5 to 2:2:1
 		{
@@ -159,7 +159,7 @@ func TestBalanceOneTask(t *testing.T) {
 				{ID: 5, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
 			eligibleNodes: []string{"tidb1", "tidb2", "tidb3"},
-			initUsedSlots: map[string]int{"tidb1": 0, "tidb2": 0, "tidb3": 0},
+			initUsedSlots: map[string]proto.NodeResource{"tidb1": {}, "tidb2": {}, "tidb3": {}},
 			expectedSubtasks: []*proto.Subtask{
 				{ID: 1, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStateRunning},
 				{ID: 2, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
@@ -167,7 +167,7 @@ func TestBalanceOneTask(t *testing.T) {
 				{ID: 4, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStatePending},
 				{ID: 5, ExecID: "tidb3", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
-			expectedUsedSlots: map[string]int{"tidb1": 16, "tidb2": 16, "tidb3": 16},
+			expectedUsedSlots: map[string]proto.NodeResource{"tidb1": {Slots: 16}, "tidb2": {Slots: 16}, "tidb3": {Slots: 16}},
 		},
 		// scale out case 2: balance from 4 to 2:1:1
 		// this case checks the remainder part is right, so we don't balance it as 2:2:0.
@@ -179,14 +179,14 @@ func TestBalanceOneTask(t *testing.T) {
 				{ID: 4, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
 			eligibleNodes: []string{"tidb1", "tidb2", "tidb3"},
-			initUsedSlots: map[string]int{"tidb1": 0, "tidb2": 0, "tidb3": 0},
+			initUsedSlots: map[string]proto.NodeResource{"tidb1": {}, "tidb2": {}, "tidb3": {}},
 			expectedSubtasks: []*proto.Subtask{
 				{ID: 1, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStateRunning},
 				{ID: 2, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStatePending},
 				{ID: 3, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
 				{ID: 4, ExecID: "tidb3", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
-			expectedUsedSlots: map[string]int{"tidb1": 16, "tidb2": 16, "tidb3": 16},
+			expectedUsedSlots: map[string]proto.NodeResource{"tidb1": {Slots: 16}, "tidb2": {Slots: 16}, "tidb3": {Slots: 16}},
 		},
 		// scale in, balance from 1:3:1 to 3:2
 		{
@@ -198,7 +198,7 @@ func TestBalanceOneTask(t *testing.T) {
 				{ID: 5, ExecID: "tidb3", Concurrency: 16, State: proto.SubtaskStateRunning},
 			},
 			eligibleNodes: []string{"tidb1", "tidb3"},
-			initUsedSlots: map[string]int{"tidb1": 0, "tidb3": 0},
+			initUsedSlots: map[string]proto.NodeResource{"tidb1": {}, "tidb3": {}},
 			expectedSubtasks: []*proto.Subtask{
 				{ID: 1, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStateRunning},
 				{ID: 2, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStateRunning},
@@ -206,7 +206,7 @@ func TestBalanceOneTask(t *testing.T) {
 				{ID: 4, ExecID: "tidb3", Concurrency: 16, State: proto.SubtaskStatePending},
 				{ID: 5, ExecID: "tidb3", Concurrency: 16, State: proto.SubtaskStateRunning},
 			},
-			expectedUsedSlots: map[string]int{"tidb1": 16, "tidb3": 16},
+			expectedUsedSlots: map[string]proto.NodeResource{"tidb1": {Slots: 16}, "tidb3": {Slots: 16}},
 		},
 		// scale in and out at the same time, balance from 2:1 to 2:1
 		{
@@ -216,13 +216,13 @@ func TestBalanceOneTask(t *testing.T) {
 				{ID: 3, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
 			eligibleNodes: []string{"tidb2", "tidb3"},
-			initUsedSlots: map[string]int{"tidb2": 0, "tidb3": 0},
+			initUsedSlots: map[string]proto.NodeResource{"tidb2": {}, "tidb3": {}},
 			expectedSubtasks: []*proto.Subtask{
 				{ID: 1, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStateRunning},
 				{ID: 2, ExecID: "tidb3", Concurrency: 16, State: proto.SubtaskStatePending},
 				{ID: 3, ExecID: "tidb2", Concurrency: 16, State: proto.SubtaskStatePending},
 			},
-			expectedUsedSlots: map[string]int{"tidb2": 16, "tidb3": 16},
+			expectedUsedSlots: map[string]proto.NodeResource{"tidb2": {Slots: 16}, "tidb3": {Slots: 16}},
 		},
 	}
 
@@ -239,7 +239,7 @@ func TestBalanceOneTask(t *testing.T) {
 			mockScheduler.EXPECT().GetEligibleInstances(gomock.Any(), gomock.Any()).Return(nil, nil)
 
 			slotMgr := newSlotManager()
-			slotMgr.updateCapacity(16)
+			slotMgr.updateCapacity([]proto.ManagedNode{{ID: "tidb1", CPUCount: 16}})
 			b := newBalancer(Param{
 				taskMgr: mockTaskMgr,
 				nodeMgr: newNodeManager(),
@@ -260,7 +260,7 @@ func TestBalanceOneTask(t *testing.T) {
 		mockScheduler.EXPECT().GetTask().Return(&proto.Task{ID: 1}).Times(2)
 		mockScheduler.EXPECT().GetEligibleInstances(gomock.Any(), gomock.Any()).Return(nil, errors.New("mock error"))
 		slotMgr := newSlotManager()
-		slotMgr.updateCapacity(16)
+		slotMgr.updateCapacity([]proto.ManagedNode{{ID: "tidb1", CPUCount: 16}})
 		b := newBalancer(Param{
 			taskMgr: mockTaskMgr,
 			nodeMgr: newNodeManager(),
@@ -283,7 +283,7 @@ func TestBalanceOneTask(t *testing.T) {
 		mockScheduler.EXPECT().GetEligibleInstances(gomock.Any(), gomock.Any()).Return([]string{"tidb1"}, nil)
 
 		slotMgr := newSlotManager()
-		slotMgr.updateCapacity(16)
+		slotMgr.updateCapacity([]proto.ManagedNode{{ID: "tidb1", CPUCount: 16}})
 		b := newBalancer(Param{
 			taskMgr: mockTaskMgr,
 			nodeMgr: newNodeManager(),
@@ -292,7 +292,7 @@ func TestBalanceOneTask(t *testing.T) {
 		require.ErrorContains(t, b.balanceSubtasks(ctx, mockScheduler, []string{"tidb1"}), "mock error")
 		require.True(t, ctrl.Satisfied())
 
-		b.currUsedSlots = map[string]int{"tidb1": 0, "tidb2": 0}
+		b.currUsedSlots = map[string]proto.NodeResource{"tidb1": {}, "tidb2": {}}
 		mockTaskMgr.EXPECT().GetActiveSubtasks(gomock.Any(), gomock.Any()).Return(
 			[]*proto.Subtask{
 				{ID: 1, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStateRunning},
@@ -303,7 +303,7 @@ func TestBalanceOneTask(t *testing.T) {
 		mockScheduler.EXPECT().GetEligibleInstances(gomock.Any(), gomock.Any()).Return(nil, nil)
 		require.ErrorContains(t, b.balanceSubtasks(ctx, mockScheduler, []string{"tidb1", "tidb2"}), "mock error2")
 		// not updated
-		require.Equal(t, map[string]int{"tidb1": 0, "tidb2": 0}, b.currUsedSlots)
+		require.Equal(t, map[string]proto.NodeResource{"tidb1": {}, "tidb2": {}}, b.currUsedSlots)
 		require.True(t, ctrl.Satisfied())
 	})
 }
@@ -370,7 +370,7 @@ func TestBalanceMultipleTasks(t *testing.T) {
 
 	manager, err := NewManager(ctx, mockTaskMgr, "1")
 	require.NoError(t, err)
-	manager.slotMgr.updateCapacity(16)
+	manager.slotMgr.updateCapacity([]proto.ManagedNode{{ID: "tidb1", CPUCount: 16}})
 	manager.nodeMgr.managedNodes.Store(&[]string{"tidb1", "tidb2", "tidb3"})
 	b := newBalancer(Param{
 		taskMgr: manager.taskMgr,
@@ -410,7 +410,7 @@ func TestBalanceMultipleTasks(t *testing.T) {
 		}
 	}
 	b.balance(ctx, manager)
-	require.Equal(t, map[string]int{"tidb1": 16, "tidb2": 16, "tidb3": 16}, b.currUsedSlots)
+	require.Equal(t, map[string]proto.NodeResource{"tidb1": {Slots: 16}, "tidb2": {Slots: 16}, "tidb3": {Slots: 16}}, b.currUsedSlots)
 	require.True(t, ctrl.Satisfied())
 	for _, c := range taskCases {
 		require.Equal(t, c.expectedSubtasks, c.subtasks)
@@ -423,11 +423,11 @@ func TestBalancerUpdateUsedNodes(t *testing.T) {
 		{ID: 1, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStateRunning},
 		{ID: 2, ExecID: "tidb1", Concurrency: 16, State: proto.SubtaskStatePending},
 	})
-	require.Equal(t, map[string]int{"tidb1": 16}, b.currUsedSlots)
+	require.Equal(t, map[string]proto.NodeResource{"tidb1": {Slots: 16}}, b.currUsedSlots)
 	b.updateUsedNodes([]*proto.Subtask{
 		{ID: 3, ExecID: "tidb1", Concurrency: 4, State: proto.SubtaskStateRunning},
 		{ID: 4, ExecID: "tidb2", Concurrency: 8, State: proto.SubtaskStatePending},
 		{ID: 5, ExecID: "tidb3", Concurrency: 12, State: proto.SubtaskStatePending},
 	})
-	require.Equal(t, map[string]int{"tidb1": 20, "tidb2": 8, "tidb3": 12}, b.currUsedSlots)
+	require.Equal(t, map[string]proto.NodeResource{"tidb1": {Slots: 20}, "tidb2": {Slots: 8}, "tidb3": {Slots: 12}}, b.currUsedSlots)
 }
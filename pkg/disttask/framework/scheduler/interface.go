@@ -25,7 +25,7 @@ import (
 
 // TaskManager defines the interface to access task table.
 type TaskManager interface {
-	// GetTopUnfinishedTasks returns unfinished tasks, limited by MaxConcurrentTask*2,
+	// GetTopUnfinishedTasks returns unfinished tasks, limited by storage.TopUnfinishedTasksLimit,
 	// to make sure lower priority tasks can be scheduled if resource is enough.
 	// The returned tasks are sorted by task order, see proto.Task, and only contains
 	// some fields, see row2TaskBasic.
@@ -61,12 +61,14 @@ type TaskManager interface {
 	// And each subtask of this step must be different, to handle the network
 	// partition or owner change.
 	SwitchTaskStepInBatch(ctx context.Context, task *proto.Task, nextState proto.TaskState, nextStep proto.Step, subtasks []*proto.Subtask) error
-	// GetUsedSlotsOnNodes returns the used slots on nodes that have subtask scheduled.
+	// GetUsedResourcesOnNodes returns the used resources on nodes that have subtask
+	// scheduled, the resource of a subtask is declared by its task type, see
+	// proto.SubtaskResource.
 	// subtasks of each task on one node is only accounted once as we don't support
 	// running them concurrently.
 	// we only consider pending/running subtasks, subtasks related to revert are
 	// not considered.
-	GetUsedSlotsOnNodes(ctx context.Context) (map[string]int, error)
+	GetUsedResourcesOnNodes(ctx context.Context) (map[string]proto.NodeResource, error)
 	// GetActiveSubtasks returns subtasks of the task that are in pending/running state.
 	// the returned subtasks only contains some fields, see row2SubtaskBasic.
 	GetActiveSubtasks(ctx context.Context, taskID int64) ([]*proto.Subtask, error)
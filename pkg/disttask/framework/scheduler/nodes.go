@@ -135,14 +135,10 @@ func (nm *NodeManager) refreshManagedNodes(ctx context.Context, taskMgr TaskMana
 		return
 	}
 	nodeIDs := make([]string, 0, len(newNodes))
-	var cpuCount int
 	for _, node := range newNodes {
 		nodeIDs = append(nodeIDs, node.ID)
-		if node.CPUCount > 0 {
-			cpuCount = node.CPUCount
-		}
 	}
-	slotMgr.updateCapacity(cpuCount)
+	slotMgr.updateCapacity(newNodes)
 	nm.managedNodes.Store(&nodeIDs)
 
 	failpoint.Inject("syncRefresh", func() {
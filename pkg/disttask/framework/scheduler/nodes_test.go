@@ -107,7 +107,7 @@ func TestMaintainManagedNodes(t *testing.T) {
 	slotMgr := newSlotManager()
 	mockTaskMgr.EXPECT().GetManagedNodes(gomock.Any()).Return(nil, errors.New("mock error"))
 	nodeMgr.refreshManagedNodes(ctx, mockTaskMgr, slotMgr)
-	require.Equal(t, cpu.GetCPUCount(), int(slotMgr.capacity.Load()))
+	require.Equal(t, proto.NodeResource{Slots: cpu.GetCPUCount()}, slotMgr.getCapacity())
 	require.Empty(t, nodeMgr.getManagedNodes())
 	require.True(t, ctrl.Satisfied())
 
@@ -117,12 +117,12 @@ func TestMaintainManagedNodes(t *testing.T) {
 	}, nil)
 	nodeMgr.refreshManagedNodes(ctx, mockTaskMgr, slotMgr)
 	require.Equal(t, []string{":4000", ":4001"}, nodeMgr.getManagedNodes())
-	require.Equal(t, 100, int(slotMgr.capacity.Load()))
+	require.Equal(t, proto.NodeResource{Slots: 100}, slotMgr.getCapacity())
 	require.True(t, ctrl.Satisfied())
 	mockTaskMgr.EXPECT().GetManagedNodes(gomock.Any()).Return(nil, nil)
 	nodeMgr.refreshManagedNodes(ctx, mockTaskMgr, slotMgr)
 	require.NotNil(t, nodeMgr.getManagedNodes())
 	require.Empty(t, nodeMgr.getManagedNodes())
-	require.Equal(t, 100, int(slotMgr.capacity.Load()))
+	require.Equal(t, proto.NodeResource{Slots: 100}, slotMgr.getCapacity())
 	require.True(t, ctrl.Satisfied())
 }
@@ -24,8 +24,6 @@ import (
 	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/metrics"
-	"github.com/pingcap/tidb/pkg/resourcemanager/pool/spool"
-	"github.com/pingcap/tidb/pkg/resourcemanager/util"
 	tidbutil "github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/syncutil"
@@ -97,13 +95,14 @@ func (sm *Manager) getSchedulers() []Scheduler {
 
 // Manager manage a bunch of schedulers.
 // Scheduler schedule and monitor tasks.
-// The scheduling task number is limited by size of gPool.
+// The scheduling tasks are admitted by the free resource of managed nodes in
+// task order, see SlotManager.canReserve.
 type Manager struct {
 	ctx         context.Context
 	cancel      context.CancelFunc
 	taskMgr     TaskManager
 	wg          tidbutil.WaitGroupWrapper
-	gPool       *spool.Pool
+	schedulerWG tidbutil.WaitGroupWrapper
 	slotMgr     *SlotManager
 	nodeMgr     *NodeManager
 	balancer    *balancer
@@ -129,14 +128,9 @@ func NewManager(ctx context.Context, taskMgr TaskManager, serverID string) (*Man
 		slotMgr:  newSlotManager(),
 		nodeMgr:  newNodeManager(),
 	}
-	gPool, err := spool.NewPool("schedule_pool", int32(proto.MaxConcurrentTask), util.DistTask, spool.WithBlocking(true))
-	if err != nil {
-		return nil, err
-	}
-	schedulerManager.gPool = gPool
 	schedulerManager.ctx, schedulerManager.cancel = context.WithCancel(ctx)
 	schedulerManager.mu.schedulerMap = make(map[int64]Scheduler)
-	schedulerManager.finishCh = make(chan struct{}, proto.MaxConcurrentTask)
+	schedulerManager.finishCh = make(chan struct{}, 1)
 	schedulerManager.balancer = newBalancer(Param{
 		taskMgr: taskMgr,
 		nodeMgr: schedulerManager.nodeMgr,
@@ -172,7 +166,7 @@ func (sm *Manager) Start() {
 // Stop the schedulerManager.
 func (sm *Manager) Stop() {
 	sm.cancel()
-	sm.gPool.ReleaseAndWait()
+	sm.schedulerWG.Wait()
 	sm.wg.Wait()
 	sm.clearSchedulers()
 	sm.initialized = false
@@ -198,13 +192,6 @@ func (sm *Manager) scheduleTaskLoop() {
 		case <-handle.TaskChangedCh:
 		}
 
-		taskCnt := sm.getSchedulerCount()
-		if taskCnt >= proto.MaxConcurrentTask {
-			logutil.BgLogger().Info("scheduled tasks reached limit",
-				zap.Int("current", taskCnt), zap.Int("max", proto.MaxConcurrentTask))
-			continue
-		}
-
 		tasks, err := sm.taskMgr.GetTopUnfinishedTasks(sm.ctx)
 		if err != nil {
 			logutil.BgLogger().Warn("get unfinished tasks failed", zap.Error(err))
@@ -237,10 +224,6 @@ func (sm *Manager) scheduleTaskLoop() {
 			continue
 		}
 		for _, task := range schedulableTasks {
-			taskCnt = sm.getSchedulerCount()
-			if taskCnt >= proto.MaxConcurrentTask {
-				break
-			}
 			reservedExecID, ok := sm.slotMgr.canReserve(task)
 			if !ok {
 				// task of lower priority might be able to be scheduled.
@@ -309,8 +292,7 @@ func (sm *Manager) startScheduler(basicTask *proto.Task, reservedExecID string)
 	}
 	sm.addScheduler(task.ID, scheduler)
 	sm.slotMgr.reserve(basicTask, reservedExecID)
-	// Using the pool with block, so it wouldn't return an error.
-	_ = sm.gPool.Run(func() {
+	sm.schedulerWG.Run(func() {
 		defer func() {
 			scheduler.Close()
 			sm.delScheduler(task.ID)
@@ -320,7 +302,12 @@ func (sm *Manager) startScheduler(basicTask *proto.Task, reservedExecID string)
 		metrics.UpdateMetricsForRunTask(task)
 		scheduler.ScheduleTask()
 		logutil.BgLogger().Info("task finished", zap.Int64("task-id", task.ID))
-		sm.finishCh <- struct{}{}
+		// cleanup is triggered by any finished task, no need to block here if
+		// there is already a pending one.
+		select {
+		case sm.finishCh <- struct{}{}:
+		default:
+		}
 	})
 }
 
@@ -251,13 +251,6 @@ func checkDispatch(t *testing.T, taskCnt int, isSucc, isCancel, isSubtaskCancel,
 		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/domain/MockDisableDistTask"))
 	}()
 	// test DispatchTaskLoop
-	// test parallelism control
-	var originalConcurrency int
-	if taskCnt == 1 {
-		originalConcurrency = proto.MaxConcurrentTask
-		proto.MaxConcurrentTask = 1
-	}
-
 	store := testkit.CreateMockStore(t)
 	gtk := testkit.NewTestKit(t, store)
 	pool := pools.NewResourcePool(func() (pools.Resource, error) {
@@ -273,13 +266,7 @@ func checkDispatch(t *testing.T, taskCnt int, isSucc, isCancel, isSubtaskCancel,
 	sch, mgr := MockSchedulerManager(t, ctrl, pool, getNumberExampleSchedulerExt(ctrl), nil)
 	require.NoError(t, mgr.InitMeta(ctx, ":4000", "background"))
 	sch.Start()
-	defer func() {
-		sch.Stop()
-		// make data race happy
-		if taskCnt == 1 {
-			proto.MaxConcurrentTask = originalConcurrency
-		}
-	}()
+	defer sch.Stop()
 
 	// 3s
 	cnt := 60
@@ -322,15 +309,6 @@ func checkDispatch(t *testing.T, taskCnt int, isSucc, isCancel, isSubtaskCancel,
 	checkGetRunningTaskCnt(taskCnt)
 	tasks := checkTaskRunningCnt()
 	checkSubtaskCnt(tasks, taskIDs)
-	// test parallelism control
-	if taskCnt == 1 {
-		taskID, err := mgr.CreateTask(ctx, fmt.Sprintf("%d", taskCnt), proto.TaskTypeExample, 0, nil)
-		require.NoError(t, err)
-		checkGetRunningTaskCnt(taskCnt)
-		// Clean the task.
-		deleteTasks(t, store, taskID)
-		sch.DelRunningTask(taskID)
-	}
 
 	// test DetectTaskLoop
 	checkGetTaskState := func(expectedState proto.TaskState) {
@@ -603,3 +581,119 @@ func TestManagerScheduleLoop(t *testing.T) {
 		return err == nil && len(taskKeys) == 0
 	}, time.Second*10, time.Millisecond*100)
 }
+
+func TestManagerAdmitTasksByResource(t *testing.T) {
+	// Mock 16 cpu node.
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/util/cpu/mockNumCpu", "return(16)"))
+	t.Cleanup(func() {
+		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/util/cpu/mockNumCpu"))
+	})
+	// each slot of the example task takes 1GiB memory.
+	proto.RegisterSubtaskResource(proto.TaskTypeExample, proto.SubtaskResource{MemoryPerSlot: 1 << 30})
+	t.Cleanup(func() {
+		proto.RegisterSubtaskResource(proto.TaskTypeExample, proto.SubtaskResource{})
+	})
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_ = testkit.CreateMockStore(t)
+	require.Eventually(t, func() bool {
+		taskMgr, err := storage.GetTaskManager()
+		return err == nil && taskMgr != nil
+	}, 10*time.Second, 100*time.Millisecond)
+
+	ctx := context.Background()
+	ctx = util.WithInternalSourceType(ctx, "scheduler")
+	taskMgr, err := storage.GetTaskManager()
+	require.NoError(t, err)
+	execSQL := func(sql string, args ...any) {
+		require.NoError(t, taskMgr.WithNewSession(func(se sessionctx.Context) error {
+			_, err := sqlexec.ExecSQL(ctx, se, sql, args...)
+			return err
+		}))
+	}
+
+	// the node has 16 slots and 8GiB memory, we add a subtask takes 16 slots to
+	// avoid reserve by slots, so the tasks are only admitted by the free resource
+	// of stripes.
+	execSQL("update mysql.dist_framework_meta set memory_limit = %?", 8<<30)
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/disttask/framework/scheduler/syncRefresh", "1*return()"))
+	<-scheduler.TestRefreshedChan
+	require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/disttask/framework/scheduler/syncRefresh"))
+	serverInfos, err := infosync.GetAllServerInfo(ctx)
+	require.NoError(t, err)
+	for _, s := range serverInfos {
+		execID := disttaskutil.GenerateExecID(s)
+		testutil.InsertSubtask(t, taskMgr, 1000000, proto.StepOne, execID, []byte(""), proto.SubtaskStatePending, proto.TaskTypeExample, 16)
+	}
+
+	concurrencies := []int{4, 4, 2, 4}
+	waitChannels := make(map[string](chan struct{}))
+	for i := 0; i < len(concurrencies); i++ {
+		waitChannels[fmt.Sprintf("key/%d", i)] = make(chan struct{})
+	}
+	scheduler.RegisterSchedulerFactory(proto.TaskTypeExample,
+		func(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
+			mockScheduler := mock.NewMockScheduler(ctrl)
+			mockScheduler.EXPECT().GetTask().Return(task).AnyTimes()
+			mockScheduler.EXPECT().GetEligibleInstances(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			mockScheduler.EXPECT().Init().Return(nil)
+			mockScheduler.EXPECT().ScheduleTask().Do(func() {
+				if task.IsDone() {
+					return
+				}
+				execSQL("update mysql.tidb_global_task set state=%?, step=%? where id=%?",
+					proto.TaskStateRunning, proto.StepOne, task.ID)
+				<-waitChannels[task.Key]
+				execSQL("update mysql.tidb_global_task set state=%?, step=%? where id=%?",
+					proto.TaskStateSucceed, proto.StepDone, task.ID)
+			})
+			mockScheduler.EXPECT().Close()
+			return mockScheduler
+		},
+	)
+	getRunningTaskKeys := func() []string {
+		tasks, err := taskMgr.GetTasksInStates(ctx, proto.TaskStateRunning)
+		require.NoError(t, err)
+		taskKeys := make([]string, len(tasks))
+		for i, task := range tasks {
+			taskKeys[i] = task.Key
+		}
+		slices.Sort(taskKeys)
+		return taskKeys
+	}
+	checkRunningTaskKeys := func(keys ...string) {
+		require.Eventually(t, func() bool {
+			return slices.Equal(keys, getRunningTaskKeys())
+		}, 10*time.Second, 100*time.Millisecond)
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := taskMgr.CreateTask(ctx, fmt.Sprintf("key/%d", i), proto.TaskTypeExample, concurrencies[i], []byte("{}"))
+		require.NoError(t, err)
+	}
+	// the first 2 tasks take all the memory, there are free slots but the
+	// admission stops.
+	checkRunningTaskKeys("key/0", "key/1")
+	require.Never(t, func() bool {
+		return slices.Contains(getRunningTaskKeys(), "key/2")
+	}, time.Second, 100*time.Millisecond)
+
+	// the task of higher priority is admitted before the earlier created one.
+	_, err = taskMgr.CreateTask(ctx, "key/3", proto.TaskTypeExample, concurrencies[3], []byte("{}"))
+	require.NoError(t, err)
+	execSQL("update mysql.tidb_global_task set priority = %? where task_key = 'key/3'", proto.NormalPriority-1)
+	checkRunningTaskKeys("key/0", "key/1", "key/3")
+	// the resource is reserved for the task of higher priority first.
+	close(waitChannels["key/0"])
+	checkRunningTaskKeys("key/1", "key/3")
+	require.Never(t, func() bool {
+		return slices.Contains(getRunningTaskKeys(), "key/2")
+	}, time.Second, 100*time.Millisecond)
+	close(waitChannels["key/1"])
+	checkRunningTaskKeys("key/2", "key/3")
+
+	close(waitChannels["key/2"])
+	close(waitChannels["key/3"])
+	checkRunningTaskKeys()
+}
@@ -27,19 +27,25 @@ import (
 )
 
 type taskStripes struct {
-	task    *proto.Task
-	stripes int
+	task *proto.Task
+	// stripes is the resource reserved by the task on each node.
+	stripes proto.NodeResource
 }
 
 // SlotManager is used to manage the resource slots and stripes.
 //
 // Slot is the resource unit of dist framework on each node, each slot represents
-// 1 cpu core, 1/total-core of memory, 1/total-core of disk, etc.
+// 1 cpu core, and the memory of the node is managed along with slots, task types
+// declare the memory required by each slot of their subtasks, see
+// proto.SubtaskResource. Memory is a scheduling preference, the nodes with enough
+// memory are preferred, but a task never waits for the memory which can't be
+// satisfied, see proto.NodeResource.CanAlloc.
 //
 // Stripe is the resource unit of dist framework, regardless of the node, each
 // stripe means 1 slot on all nodes managed by dist framework.
-// Number of stripes is equal to number of slots on each node, as we assume that
-// all nodes managed by dist framework are isomorphic.
+// Nodes managed by dist framework might have different resources, the capacity
+// of stripes is the minimum resource of all nodes, so a task reserved by stripes
+// can run on any node.
 // Stripes reserved for a task defines the maximum resource that a task can use
 // but the task might not use all the resources. To maximize the resource utilization,
 // we will try to schedule as many tasks as possible depends on the used slots
@@ -49,42 +55,47 @@ type taskStripes struct {
 // Dist framework will try to allocate resource by slots and stripes, and give
 // quota to subtask, but subtask can determine what to conform.
 type SlotManager struct {
-	// Capacity is the total number of slots and stripes.
-	capacity atomic.Int32
+	// capacity is the total resource of stripes.
+	capacity atomic.Pointer[proto.NodeResource]
+	// nodeCapacity is the resource capacity of each managed node, a node that is
+	// not in it is treated as having the capacity of stripes.
+	nodeCapacity atomic.Pointer[map[string]proto.NodeResource]
 
 	mu sync.RWMutex
-	// represents the number of stripes reserved by task, when we reserve by the
+	// represents the stripes reserved by task, when we reserve by the
 	// minimum resource required by the task, we still append into it, so it summed
 	// value might be larger than capacity
 	// this slice is in task order.
 	reservedStripes []taskStripes
 	// map of reservedStripes for fast delete
 	task2Index map[int64]int
-	// represents the number of slots reserved by task on each node, the execID
+	// represents the resource reserved by task on each node, the execID
 	// is only used for reserve minimum resource when starting scheduler, the
 	// subtasks may or may not be scheduled on this node.
-	reservedSlots map[string]int
-	// represents the number of slots taken by task on each node
+	reservedSlots map[string]proto.NodeResource
+	// represents the resource taken by task on each node
 	// on some cases it might be larger than capacity:
 	// 	current step of higher priority task A has little subtasks, so we start
 	// 	to schedule lower priority task, but next step of A has many subtasks.
 	// once initialized, the length of usedSlots should be equal to number of nodes
 	// managed by dist framework.
-	usedSlots atomic.Pointer[map[string]int]
+	usedSlots atomic.Pointer[map[string]proto.NodeResource]
 }
 
 // newSlotManager creates a new SlotManager.
 func newSlotManager() *SlotManager {
-	usedSlots := make(map[string]int)
+	usedSlots := make(map[string]proto.NodeResource)
+	nodeCapacity := make(map[string]proto.NodeResource)
 	s := &SlotManager{
 		task2Index:    make(map[int64]int),
-		reservedSlots: make(map[string]int),
+		reservedSlots: make(map[string]proto.NodeResource),
 	}
 	s.usedSlots.Store(&usedSlots)
+	s.nodeCapacity.Store(&nodeCapacity)
 	// this node might not be the managed node of the framework, but we initialize
 	// capacity with the cpu count of this node, it will be updated when node
 	// manager starts.
-	s.updateCapacity(cpu.GetCPUCount())
+	s.capacity.Store(&proto.NodeResource{Slots: cpu.GetCPUCount()})
 	return s
 }
 
@@ -92,11 +103,11 @@ func newSlotManager() *SlotManager {
 // TODO: on concurrent call, update once.
 func (sm *SlotManager) update(ctx context.Context, nodeMgr *NodeManager, taskMgr TaskManager) error {
 	nodes := nodeMgr.getManagedNodes()
-	slotsOnNodes, err := taskMgr.GetUsedSlotsOnNodes(ctx)
+	slotsOnNodes, err := taskMgr.GetUsedResourcesOnNodes(ctx)
 	if err != nil {
 		return err
 	}
-	newUsedSlots := make(map[string]int, len(nodes))
+	newUsedSlots := make(map[string]proto.NodeResource, len(nodes))
 	for _, node := range nodes {
 		newUsedSlots[node] = slotsOnNodes[node]
 	}
@@ -113,7 +124,8 @@ func (sm *SlotManager) update(ctx context.Context, nodeMgr *NodeManager, taskMgr
 // scheduled subtasks.
 func (sm *SlotManager) canReserve(task *proto.Task) (execID string, ok bool) {
 	usedSlots := *sm.usedSlots.Load()
-	capacity := int(sm.capacity.Load())
+	capacity := sm.getCapacity()
+	required := task.RequiredResource()
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 	if len(usedSlots) == 0 {
@@ -121,19 +133,26 @@ func (sm *SlotManager) canReserve(task *proto.Task) (execID string, ok bool) {
 		return "", false
 	}
 
-	reservedForHigherPriority := 0
+	var reservedForHigherPriority proto.NodeResource
 	for _, s := range sm.reservedStripes {
 		if s.task.Compare(task) >= 0 {
 			break
 		}
-		reservedForHigherPriority += s.stripes
+		reservedForHigherPriority = reservedForHigherPriority.Add(s.stripes)
 	}
-	if task.Concurrency+reservedForHigherPriority <= capacity {
+	if required.Add(reservedForHigherPriority).Fits(capacity) {
 		return "", true
 	}
 
-	for id, count := range usedSlots {
-		if count+sm.reservedSlots[id]+task.Concurrency <= capacity {
+	for id, used := range usedSlots {
+		if used.Add(sm.reservedSlots[id]).Add(required).Fits(sm.getNodeCapacity(id)) {
+			return id, true
+		}
+	}
+	// memory is a scheduling preference, reserve on the node with enough slots
+	// if no node has enough memory.
+	for id, used := range usedSlots {
+		if required.CanAlloc(used.Add(sm.reservedSlots[id]), sm.getNodeCapacity(id)) {
 			return id, true
 		}
 	}
@@ -144,10 +163,11 @@ func (sm *SlotManager) canReserve(task *proto.Task) (execID string, ok bool) {
 // Reserve and UnReserve should be called in pair with same parameters.
 func (sm *SlotManager) reserve(task *proto.Task, execID string) {
 	taskClone := *task
+	required := taskClone.RequiredResource()
 
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
-	sm.reservedStripes = append(sm.reservedStripes, taskStripes{&taskClone, taskClone.Concurrency})
+	sm.reservedStripes = append(sm.reservedStripes, taskStripes{&taskClone, required})
 	slices.SortFunc(sm.reservedStripes, func(a, b taskStripes) int {
 		return a.task.Compare(b.task)
 	})
@@ -156,7 +176,7 @@ func (sm *SlotManager) reserve(task *proto.Task, execID string) {
 	}
 
 	if execID != "" {
-		sm.reservedSlots[execID] += taskClone.Concurrency
+		sm.reservedSlots[execID] = sm.reservedSlots[execID].Add(required)
 	}
 }
 
@@ -168,6 +188,7 @@ func (sm *SlotManager) unReserve(task *proto.Task, execID string) {
 	if !ok {
 		return
 	}
+	stripes := sm.reservedStripes[idx].stripes
 	sm.reservedStripes = append(sm.reservedStripes[:idx], sm.reservedStripes[idx+1:]...)
 	delete(sm.task2Index, task.ID)
 	for i, s := range sm.reservedStripes {
@@ -175,52 +196,85 @@ func (sm *SlotManager) unReserve(task *proto.Task, execID string) {
 	}
 
 	if execID != "" {
-		sm.reservedSlots[execID] -= task.Concurrency
-		if sm.reservedSlots[execID] == 0 {
+		sm.reservedSlots[execID] = sm.reservedSlots[execID].Sub(stripes)
+		if sm.reservedSlots[execID].IsZero() {
 			delete(sm.reservedSlots, execID)
 		}
 	}
 }
 
-func (sm *SlotManager) getCapacity() int {
-	return int(sm.capacity.Load())
+func (sm *SlotManager) getCapacity() proto.NodeResource {
+	return *sm.capacity.Load()
+}
+
+func (sm *SlotManager) getNodeCapacity(node string) proto.NodeResource {
+	if c, ok := (*sm.nodeCapacity.Load())[node]; ok {
+		return c
+	}
+	return sm.getCapacity()
 }
 
-// we schedule subtasks to the nodes with enough slots first, if no such nodes,
-// schedule to all nodes.
-func (sm *SlotManager) adjustEligibleNodes(eligibleNodes []string, concurrency int) []string {
+// we schedule subtasks to the nodes with enough free resource first, if no such
+// nodes, schedule to the nodes that can run the subtasks when they're idle, i.e.
+// we never schedule subtasks to a node which is too small to run them, unless
+// there is no other choice.
+func (sm *SlotManager) adjustEligibleNodes(eligibleNodes []string, required proto.NodeResource) []string {
 	usedSlots := *sm.usedSlots.Load()
-	nodes := filterNodesWithEnoughSlots(usedSlots, sm.getCapacity(), eligibleNodes, concurrency)
+	nodes := sm.filterNodesWithEnoughSlots(usedSlots, eligibleNodes, required)
+	if len(nodes) > 0 {
+		return nodes
+	}
+	nodes = make([]string, 0, len(eligibleNodes))
+	for _, node := range eligibleNodes {
+		if required.Fits(sm.getNodeCapacity(node)) {
+			nodes = append(nodes, node)
+		}
+	}
 	if len(nodes) == 0 {
 		nodes = eligibleNodes
 	}
 	return nodes
 }
 
-func (sm *SlotManager) updateCapacity(cpuCount int) {
-	old := sm.capacity.Load()
-	if cpuCount > 0 && cpuCount != int(old) {
-		sm.capacity.Store(int32(cpuCount))
-		if old == 0 {
-			logutil.BgLogger().Info("initialize slot capacity", zap.Int("capacity", cpuCount))
-		} else {
-			logutil.BgLogger().Info("update slot capacity",
-				zap.Int("old", int(old)), zap.Int("new", cpuCount))
+// updateCapacity updates the capacity of each managed node and the capacity of
+// stripes, nodes which haven't reported their resource are skipped.
+func (sm *SlotManager) updateCapacity(nodes []proto.ManagedNode) {
+	nodeCapacity := make(map[string]proto.NodeResource, len(nodes))
+	var capacity proto.NodeResource
+	for _, node := range nodes {
+		c := node.Capacity()
+		if c.Slots <= 0 {
+			continue
 		}
-	}
-}
-
-func filterNodesWithEnoughSlots(usedSlots map[string]int, capacity int, eligibleNodes []string, concurrency int) []string {
-	nodesOfEnoughSlots := make(map[string]struct{}, len(usedSlots))
-	for node, slots := range usedSlots {
-		if slots+concurrency <= capacity {
-			nodesOfEnoughSlots[node] = struct{}{}
+		nodeCapacity[node.ID] = c
+		if capacity.Slots == 0 || c.Slots < capacity.Slots {
+			capacity.Slots = c.Slots
+		}
+		if c.Memory > 0 && (capacity.Memory == 0 || c.Memory < capacity.Memory) {
+			capacity.Memory = c.Memory
 		}
 	}
+	if len(nodeCapacity) == 0 {
+		return
+	}
+	old := sm.getCapacity()
+	sm.nodeCapacity.Store(&nodeCapacity)
+	if capacity != old {
+		sm.capacity.Store(&capacity)
+		logutil.BgLogger().Info("update slot capacity",
+			zap.Int("old-slots", old.Slots), zap.Int("new-slots", capacity.Slots),
+			zap.Int64("old-memory", old.Memory), zap.Int64("new-memory", capacity.Memory))
+	}
+}
 
+func (sm *SlotManager) filterNodesWithEnoughSlots(usedSlots map[string]proto.NodeResource, eligibleNodes []string, required proto.NodeResource) []string {
 	result := make([]string, 0, len(eligibleNodes))
 	for _, node := range eligibleNodes {
-		if _, ok := nodesOfEnoughSlots[node]; ok {
+		used, ok := usedSlots[node]
+		if !ok {
+			continue
+		}
+		if used.Add(required).Fits(sm.getNodeCapacity(node)) {
 			result = append(result, node)
 		}
 	}
@@ -28,14 +28,14 @@ import (
 
 func TestSlotManagerReserve(t *testing.T) {
 	sm := newSlotManager()
-	sm.updateCapacity(16)
+	sm.updateCapacity([]proto.ManagedNode{{ID: "tidb-1", CPUCount: 16}, {ID: "tidb-2", CPUCount: 16}})
 	// no node
 	_, ok := sm.canReserve(&proto.Task{Concurrency: 1})
 	require.False(t, ok)
 
 	// reserve by stripes
-	sm.usedSlots.Store(&map[string]int{
-		"tidb-1": 16,
+	sm.usedSlots.Store(&map[string]proto.NodeResource{
+		"tidb-1": {Slots: 16},
 	})
 	task := proto.Task{
 		Priority:    proto.NormalPriority,
@@ -65,8 +65,8 @@ func TestSlotManagerReserve(t *testing.T) {
 	require.Equal(t, "", execID30)
 	require.False(t, ok)
 	require.Len(t, sm.reservedStripes, 2)
-	require.Equal(t, 4, sm.reservedStripes[0].stripes)
-	require.Equal(t, 8, sm.reservedStripes[1].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 4}, sm.reservedStripes[0].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 8}, sm.reservedStripes[1].stripes)
 	require.Equal(t, map[int64]int{10: 0, 20: 1}, sm.task2Index)
 	require.Empty(t, sm.reservedSlots)
 	// higher priority task can preempt lower priority task
@@ -86,9 +86,9 @@ func TestSlotManagerReserve(t *testing.T) {
 	require.True(t, ok)
 
 	// reserve by slots
-	sm.usedSlots.Store(&map[string]int{
-		"tidb-1": 12,
-		"tidb-2": 8,
+	sm.usedSlots.Store(&map[string]proto.NodeResource{
+		"tidb-1": {Slots: 12},
+		"tidb-2": {Slots: 8},
 	})
 	task40 := task
 	task40.ID = 40
@@ -102,11 +102,11 @@ func TestSlotManagerReserve(t *testing.T) {
 	require.True(t, ok)
 	sm.reserve(&task40, execID40)
 	require.Len(t, sm.reservedStripes, 3)
-	require.Equal(t, 4, sm.reservedStripes[0].stripes)
-	require.Equal(t, 8, sm.reservedStripes[1].stripes)
-	require.Equal(t, 8, sm.reservedStripes[2].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 4}, sm.reservedStripes[0].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 8}, sm.reservedStripes[1].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 8}, sm.reservedStripes[2].stripes)
 	require.Equal(t, map[int64]int{10: 0, 20: 1, 40: 2}, sm.task2Index)
-	require.Equal(t, map[string]int{"tidb-2": 8}, sm.reservedSlots)
+	require.Equal(t, map[string]proto.NodeResource{"tidb-2": {Slots: 8}}, sm.reservedSlots)
 	// higher priority task stop task 15 to run
 	task15 := task
 	task15.ID = 15
@@ -117,21 +117,21 @@ func TestSlotManagerReserve(t *testing.T) {
 	// finish task of id 10
 	sm.unReserve(&task10, execID10)
 	require.Len(t, sm.reservedStripes, 2)
-	require.Equal(t, 8, sm.reservedStripes[0].stripes)
-	require.Equal(t, 8, sm.reservedStripes[1].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 8}, sm.reservedStripes[0].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 8}, sm.reservedStripes[1].stripes)
 	require.Equal(t, map[int64]int{20: 0, 40: 1}, sm.task2Index)
-	require.Equal(t, map[string]int{"tidb-2": 8}, sm.reservedSlots)
+	require.Equal(t, map[string]proto.NodeResource{"tidb-2": {Slots: 8}}, sm.reservedSlots)
 	// now task 15 can run
 	execID15, ok = sm.canReserve(&task15)
 	require.Equal(t, "", execID15)
 	require.True(t, ok)
 	sm.reserve(&task15, execID15)
 	require.Len(t, sm.reservedStripes, 3)
-	require.Equal(t, 16, sm.reservedStripes[0].stripes)
-	require.Equal(t, 8, sm.reservedStripes[1].stripes)
-	require.Equal(t, 8, sm.reservedStripes[2].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 16}, sm.reservedStripes[0].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 8}, sm.reservedStripes[1].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 8}, sm.reservedStripes[2].stripes)
 	require.Equal(t, map[int64]int{15: 0, 20: 1, 40: 2}, sm.task2Index)
-	require.Equal(t, map[string]int{"tidb-2": 8}, sm.reservedSlots)
+	require.Equal(t, map[string]proto.NodeResource{"tidb-2": {Slots: 8}}, sm.reservedSlots)
 	// task 50 cannot run
 	task50 := task
 	task50.ID = 50
@@ -141,8 +141,8 @@ func TestSlotManagerReserve(t *testing.T) {
 	// finish task 40
 	sm.unReserve(&task40, execID40)
 	require.Len(t, sm.reservedStripes, 2)
-	require.Equal(t, 16, sm.reservedStripes[0].stripes)
-	require.Equal(t, 8, sm.reservedStripes[1].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 16}, sm.reservedStripes[0].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 8}, sm.reservedStripes[1].stripes)
 	require.Equal(t, map[int64]int{15: 0, 20: 1}, sm.task2Index)
 	require.Empty(t, sm.reservedSlots)
 	// now task 50 can run
@@ -159,12 +159,12 @@ func TestSlotManagerReserve(t *testing.T) {
 	require.True(t, ok)
 	sm.reserve(&task60, execID60)
 	require.Len(t, sm.reservedStripes, 4)
-	require.Equal(t, 16, sm.reservedStripes[0].stripes)
-	require.Equal(t, 8, sm.reservedStripes[1].stripes)
-	require.Equal(t, 8, sm.reservedStripes[2].stripes)
-	require.Equal(t, 4, sm.reservedStripes[3].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 16}, sm.reservedStripes[0].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 8}, sm.reservedStripes[1].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 8}, sm.reservedStripes[2].stripes)
+	require.Equal(t, proto.NodeResource{Slots: 4}, sm.reservedStripes[3].stripes)
 	require.Equal(t, map[int64]int{15: 0, 20: 1, 50: 2, 60: 3}, sm.task2Index)
-	require.Equal(t, map[string]int{"tidb-1": 4, "tidb-2": 8}, sm.reservedSlots)
+	require.Equal(t, map[string]proto.NodeResource{"tidb-1": {Slots: 4}, "tidb-2": {Slots: 8}}, sm.reservedSlots)
 
 	// un-reserve all tasks
 	sm.unReserve(&task15, execID15)
@@ -184,66 +184,144 @@ func TestSlotManagerUpdate(t *testing.T) {
 	nodeMgr := newNodeManager()
 	taskMgr := mock.NewMockTaskManager(ctrl)
 	nodeMgr.managedNodes.Store(&[]string{"tidb-1", "tidb-2", "tidb-3"})
-	taskMgr.EXPECT().GetUsedSlotsOnNodes(gomock.Any()).Return(map[string]int{
-		"tidb-1": 12,
-		"tidb-2": 8,
+	taskMgr.EXPECT().GetUsedResourcesOnNodes(gomock.Any()).Return(map[string]proto.NodeResource{
+		"tidb-1": {Slots: 12},
+		"tidb-2": {Slots: 8},
 	}, nil)
 	sm := newSlotManager()
-	sm.updateCapacity(16)
+	sm.updateCapacity([]proto.ManagedNode{{ID: "tidb-1", CPUCount: 16}, {ID: "tidb-2", CPUCount: 16}, {ID: "tidb-3", CPUCount: 16}})
 	require.Empty(t, sm.usedSlots.Load())
 	require.Empty(t, sm.reservedSlots)
 	require.NoError(t, sm.update(ctx, nodeMgr, taskMgr))
 	require.Empty(t, sm.reservedSlots)
-	require.Equal(t, map[string]int{
-		"tidb-1": 12,
-		"tidb-2": 8,
-		"tidb-3": 0,
+	require.Equal(t, map[string]proto.NodeResource{
+		"tidb-1": {Slots: 12},
+		"tidb-2": {Slots: 8},
+		"tidb-3": {Slots: 0},
 	}, *sm.usedSlots.Load())
 	require.True(t, ctrl.Satisfied())
 
 	// some node scaled in, should be reflected
 	nodeMgr.managedNodes.Store(&[]string{"tidb-1"})
-	taskMgr.EXPECT().GetUsedSlotsOnNodes(gomock.Any()).Return(map[string]int{
-		"tidb-1": 12,
-		"tidb-2": 8,
+	taskMgr.EXPECT().GetUsedResourcesOnNodes(gomock.Any()).Return(map[string]proto.NodeResource{
+		"tidb-1": {Slots: 12},
+		"tidb-2": {Slots: 8},
 	}, nil)
 	require.NoError(t, sm.update(ctx, nodeMgr, taskMgr))
 	require.Empty(t, sm.reservedSlots)
-	require.Equal(t, map[string]int{
-		"tidb-1": 12,
+	require.Equal(t, map[string]proto.NodeResource{
+		"tidb-1": {Slots: 12},
 	}, *sm.usedSlots.Load())
 	require.True(t, ctrl.Satisfied())
 	// on error, the usedSlots should not be changed
-	taskMgr.EXPECT().GetUsedSlotsOnNodes(gomock.Any()).Return(nil, errors.New("mock err"))
+	taskMgr.EXPECT().GetUsedResourcesOnNodes(gomock.Any()).Return(nil, errors.New("mock err"))
 	require.ErrorContains(t, sm.update(ctx, nodeMgr, taskMgr), "mock err")
 	require.Empty(t, sm.reservedSlots)
-	require.Equal(t, map[string]int{
-		"tidb-1": 12,
+	require.Equal(t, map[string]proto.NodeResource{
+		"tidb-1": {Slots: 12},
 	}, *sm.usedSlots.Load())
 }
 
 func TestSchedulerAdjustEligibleNodes(t *testing.T) {
 	slotMgr := newSlotManager()
-	slotMgr.updateCapacity(16)
+	slotMgr.updateCapacity([]proto.ManagedNode{{ID: ":4000", CPUCount: 16}, {ID: ":4001", CPUCount: 16}, {ID: ":4002", CPUCount: 16}})
 
 	allNodes := []string{":4000", ":4001", ":4002"}
-	require.Equal(t, allNodes, slotMgr.adjustEligibleNodes(allNodes, 10))
+	require.Equal(t, allNodes, slotMgr.adjustEligibleNodes(allNodes, proto.NodeResource{Slots: 10}))
 
-	usedSlots := map[string]int{
-		":4000": 12,
-		":4001": 4,
-		":4003": 0, // stale node
+	usedSlots := map[string]proto.NodeResource{
+		":4000": {Slots: 12},
+		":4001": {Slots: 4},
+		":4003": {Slots: 0}, // stale node
 	}
 	slotMgr.usedSlots.Store(&usedSlots)
-	require.Equal(t, []string{":4001"}, slotMgr.adjustEligibleNodes(allNodes, 10))
+	require.Equal(t, []string{":4001"}, slotMgr.adjustEligibleNodes(allNodes, proto.NodeResource{Slots: 10}))
+
+	// nodes of different sizes, never schedule to the node which cannot run the
+	// subtask even when it's idle, unless there is no other choice.
+	slotMgr.updateCapacity([]proto.ManagedNode{
+		{ID: ":4000", CPUCount: 16, MemoryLimit: 64 << 30},
+		{ID: ":4001", CPUCount: 8, MemoryLimit: 64 << 30},
+		{ID: ":4002", CPUCount: 16, MemoryLimit: 16 << 30},
+	})
+	slotMgr.usedSlots.Store(&map[string]proto.NodeResource{
+		":4000": {Slots: 12, Memory: 48 << 30},
+		":4001": {},
+		":4002": {},
+	})
+	require.Equal(t, []string{":4002"}, slotMgr.adjustEligibleNodes(allNodes, proto.NodeResource{Slots: 10}))
+	require.Equal(t, []string{":4000"}, slotMgr.adjustEligibleNodes(allNodes, proto.NodeResource{Slots: 10, Memory: 20 << 30}))
+	require.Equal(t, allNodes, slotMgr.adjustEligibleNodes(allNodes, proto.NodeResource{Slots: 32}))
 }
 
 func TestSlotManagerUpdateCapacity(t *testing.T) {
 	sm := newSlotManager()
-	sm.updateCapacity(16)
-	require.Equal(t, 16, int(sm.capacity.Load()))
-	sm.updateCapacity(32)
-	require.Equal(t, 32, int(sm.capacity.Load()))
-	sm.updateCapacity(0)
-	require.Equal(t, 32, int(sm.capacity.Load()))
+	sm.updateCapacity([]proto.ManagedNode{{ID: ":4000", CPUCount: 16}})
+	require.Equal(t, proto.NodeResource{Slots: 16}, sm.getCapacity())
+	sm.updateCapacity([]proto.ManagedNode{{ID: ":4000", CPUCount: 32}})
+	require.Equal(t, proto.NodeResource{Slots: 32}, sm.getCapacity())
+	// nodes without resource info are skipped.
+	sm.updateCapacity([]proto.ManagedNode{{ID: ":4000"}})
+	require.Equal(t, proto.NodeResource{Slots: 32}, sm.getCapacity())
+	require.Equal(t, proto.NodeResource{Slots: 32}, sm.getNodeCapacity(":4000"))
+
+	// capacity of stripes is the minimum resource of all nodes.
+	sm.updateCapacity([]proto.ManagedNode{
+		{ID: ":4000", CPUCount: 16, MemoryLimit: 32 << 30},
+		{ID: ":4001", CPUCount: 8, MemoryLimit: 64 << 30},
+		{ID: ":4002", CPUCount: 32},
+	})
+	require.Equal(t, proto.NodeResource{Slots: 8, Memory: 32 << 30}, sm.getCapacity())
+	require.Equal(t, proto.NodeResource{Slots: 16, Memory: 32 << 30}, sm.getNodeCapacity(":4000"))
+	require.Equal(t, proto.NodeResource{Slots: 32}, sm.getNodeCapacity(":4002"))
+	// unknown node uses the capacity of stripes.
+	require.Equal(t, proto.NodeResource{Slots: 8, Memory: 32 << 30}, sm.getNodeCapacity(":4003"))
+}
+
+func TestSlotManagerReserveByMemory(t *testing.T) {
+	proto.RegisterSubtaskResource("memory-task", proto.SubtaskResource{MemoryPerSlot: 2 << 30})
+	sm := newSlotManager()
+	sm.updateCapacity([]proto.ManagedNode{
+		{ID: "tidb-1", CPUCount: 16, MemoryLimit: 16 << 30},
+		{ID: "tidb-2", CPUCount: 16, MemoryLimit: 64 << 30},
+	})
+	sm.usedSlots.Store(&map[string]proto.NodeResource{"tidb-1": {Slots: 2, Memory: 8 << 30}, "tidb-2": {}})
+	task := proto.Task{ID: 10, Type: "memory-task", Priority: proto.NormalPriority, Concurrency: 6, CreateTime: time.Now()}
+	execID, ok := sm.canReserve(&task)
+	require.True(t, ok)
+	require.Equal(t, "", execID)
+	sm.reserve(&task, execID)
+	require.Equal(t, proto.NodeResource{Slots: 6, Memory: 12 << 30}, sm.reservedStripes[0].stripes)
+
+	// slots of stripes are enough, but memory isn't, reserve on the large node.
+	task2 := task
+	task2.ID = 20
+	execID2, ok := sm.canReserve(&task2)
+	require.True(t, ok)
+	require.Equal(t, "tidb-2", execID2)
+	sm.reserve(&task2, execID2)
+	require.Equal(t, map[string]proto.NodeResource{"tidb-2": {Slots: 6, Memory: 12 << 30}}, sm.reservedSlots)
+
+	// no node has enough resource.
+	task3 := task
+	task3.ID = 30
+	task3.Concurrency = 15
+	_, ok = sm.canReserve(&task3)
+	require.False(t, ok)
+
+	// memory is a preference, the task requiring more memory than any node can
+	// be reserved by slots.
+	proto.RegisterSubtaskResource("huge-memory-task", proto.SubtaskResource{MemoryPerSlot: 32 << 30})
+	task4 := task
+	task4.ID = 40
+	task4.Type = "huge-memory-task"
+	task4.Concurrency = 4
+	execID4, ok := sm.canReserve(&task4)
+	require.True(t, ok)
+	require.NotEmpty(t, execID4)
+
+	sm.unReserve(&task2, execID2)
+	sm.unReserve(&task, execID)
+	require.Empty(t, sm.reservedStripes)
+	require.Empty(t, sm.reservedSlots)
 }
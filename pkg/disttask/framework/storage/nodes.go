@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/util/cpu"
+	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/pingcap/tidb/pkg/util/sqlescape"
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
 )
@@ -35,33 +36,39 @@ func (mgr *TaskManager) InitMeta(ctx context.Context, tidbID string, role string
 }
 
 // InitMetaSession insert the manager information into dist_framework_meta.
-// if the record exists, update the cpu_count and role.
+// if the record exists, update the cpu_count, memory_limit and role.
 func (*TaskManager) InitMetaSession(ctx context.Context, se sessionctx.Context, execID string, role string) error {
-	cpuCount := cpu.GetCPUCount()
+	cpuCount, memLimit := cpu.GetCPUCount(), GetMemoryLimit()
 	_, err := sqlexec.ExecSQL(ctx, se, `
-		insert into mysql.dist_framework_meta(host, role, cpu_count, keyspace_id)
-		values (%?, %?, %?, -1)
+		insert into mysql.dist_framework_meta(host, role, cpu_count, memory_limit, keyspace_id)
+		values (%?, %?, %?, %?, -1)
 		on duplicate key
-		update cpu_count = %?, role = %?`,
-		execID, role, cpuCount, cpuCount, role)
+		update cpu_count = %?, memory_limit = %?, role = %?`,
+		execID, role, cpuCount, memLimit, cpuCount, memLimit, role)
 	return err
 }
 
 // RecoverMeta insert the manager information into dist_framework_meta.
-// if the record exists, update the cpu_count.
+// if the record exists, update the cpu_count and memory_limit.
 // Don't update role for we only update it in `set global tidb_service_scope`.
 // if not there might has a data race.
 func (mgr *TaskManager) RecoverMeta(ctx context.Context, execID string, role string) error {
-	cpuCount := cpu.GetCPUCount()
+	cpuCount, memLimit := cpu.GetCPUCount(), GetMemoryLimit()
 	_, err := mgr.ExecuteSQLWithNewSession(ctx, `
-		insert into mysql.dist_framework_meta(host, role, cpu_count, keyspace_id)
-		values (%?, %?, %?, -1)
+		insert into mysql.dist_framework_meta(host, role, cpu_count, memory_limit, keyspace_id)
+		values (%?, %?, %?, %?, -1)
 		on duplicate key
-		update cpu_count = %?`,
-		execID, role, cpuCount, cpuCount)
+		update cpu_count = %?, memory_limit = %?`,
+		execID, role, cpuCount, memLimit, cpuCount, memLimit)
 	return err
 }
 
+// GetMemoryLimit gets the memory limit of this node, it's the value of
+// tidb_server_memory_limit in bytes, 0 means no limit.
+func GetMemoryLimit() int64 {
+	return int64(memory.ServerMemoryLimit.Load())
+}
+
 // DeleteDeadNodes deletes the dead nodes from mysql.dist_framework_meta.
 func (mgr *TaskManager) DeleteDeadNodes(ctx context.Context, nodes []string) error {
 	if len(nodes) == 0 {
@@ -123,7 +130,7 @@ func (mgr *TaskManager) GetAllNodes(ctx context.Context) ([]proto.ManagedNode, e
 
 func (*TaskManager) getAllNodesWithSession(ctx context.Context, se sessionctx.Context) ([]proto.ManagedNode, error) {
 	rs, err := sqlexec.ExecSQL(ctx, se, `
-		select host, role, cpu_count
+		select host, role, cpu_count, memory_limit
 		from mysql.dist_framework_meta
 		order by host`)
 	if err != nil {
@@ -132,40 +139,38 @@ func (*TaskManager) getAllNodesWithSession(ctx context.Context, se sessionctx.Co
 	nodes := make([]proto.ManagedNode, 0, len(rs))
 	for _, r := range rs {
 		nodes = append(nodes, proto.ManagedNode{
-			ID:       r.GetString(0),
-			Role:     r.GetString(1),
-			CPUCount: int(r.GetInt64(2)),
+			ID:          r.GetString(0),
+			Role:        r.GetString(1),
+			CPUCount:    int(r.GetInt64(2)),
+			MemoryLimit: r.GetInt64(3),
 		})
 	}
 	return nodes, nil
 }
 
-// GetUsedSlotsOnNodes implements the scheduler.TaskManager interface.
-func (mgr *TaskManager) GetUsedSlotsOnNodes(ctx context.Context) (map[string]int, error) {
-	// concurrency of subtasks of some step is the same, we use max(concurrency)
+// GetUsedResourcesOnNodes implements the scheduler.TaskManager interface.
+func (mgr *TaskManager) GetUsedResourcesOnNodes(ctx context.Context) (map[string]proto.NodeResource, error) {
+	// concurrency and type of subtasks of some step is the same, we use max()
 	// to make group by works.
 	rs, err := mgr.ExecuteSQLWithNewSession(ctx, `
-		select
-			exec_id, sum(concurrency)
-		from (
-			select exec_id, task_key, max(concurrency) concurrency
-			from mysql.tidb_background_subtask
-			where state in (%?, %?)
-			group by exec_id, task_key
-		) a
-		group by exec_id`,
+		select exec_id, max(type), max(concurrency)
+		from mysql.tidb_background_subtask
+		where state in (%?, %?)
+		group by exec_id, task_key`,
 		proto.SubtaskStatePending, proto.SubtaskStateRunning,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	slots := make(map[string]int, len(rs))
+	resources := make(map[string]proto.NodeResource, len(rs))
 	for _, r := range rs {
-		val, _ := r.GetMyDecimal(1).ToInt()
-		slots[r.GetString(0)] = int(val)
+		execID := r.GetString(0)
+		required := proto.GetSubtaskResource(proto.Int2Type(int(r.GetInt64(1)))).
+			ForConcurrency(int(r.GetInt64(2)))
+		resources[execID] = resources[execID].Add(required)
 	}
-	return slots, nil
+	return resources, nil
 }
 
 // GetCPUCountOfManagedNode gets the cpu count of managed node.
@@ -186,18 +191,32 @@ func (mgr *TaskManager) getCPUCountOfManagedNode(ctx context.Context, se session
 	if err != nil {
 		return 0, err
 	}
+	return getCPUCountOfNodes(nodes)
+}
+
+// getCPUCountOfNodes gets the max cpu count of the nodes. The nodes might have
+// different resources, the concurrency of a task is limited by the largest node,
+// and its subtasks are only scheduled to the nodes with enough resource.
+func getCPUCountOfNodes(nodes []proto.ManagedNode) (int, error) {
 	if len(nodes) == 0 {
 		return 0, errors.New("no managed nodes")
 	}
 	var cpuCount int
 	for _, n := range nodes {
-		if n.CPUCount > 0 {
-			cpuCount = n.CPUCount
-			break
-		}
+		cpuCount = max(cpuCount, n.CPUCount)
 	}
 	if cpuCount == 0 {
 		return 0, errors.New("no managed node have enough resource for dist task")
 	}
 	return cpuCount, nil
 }
+
+// getMaxConcurrencyOfNodes gets the max concurrency of the subtasks which fits
+// the capacity of some node.
+func getMaxConcurrencyOfNodes(nodes []proto.ManagedNode, resource proto.SubtaskResource) int {
+	var concurrency int
+	for _, n := range nodes {
+		concurrency = max(concurrency, resource.MaxConcurrency(n.Capacity()))
+	}
+	return concurrency
+}
@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/docker/go-units"
 	"github.com/ngaut/pools"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
@@ -32,6 +33,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/client-go/v2/util"
@@ -357,6 +359,11 @@ func TestSwitchTaskStepInBatch(t *testing.T) {
 
 func TestGetTopUnfinishedTasks(t *testing.T) {
 	_, gm, ctx := testutil.InitTableTest(t)
+	bak := storage.TopUnfinishedTasksLimit
+	storage.TopUnfinishedTasksLimit = 8
+	t.Cleanup(func() {
+		storage.TopUnfinishedTasksLimit = bak
+	})
 
 	require.NoError(t, gm.InitMeta(ctx, ":4000", ""))
 	taskStates := []proto.TaskState{
@@ -421,21 +428,109 @@ func TestGetTopUnfinishedTasks(t *testing.T) {
 	require.Equal(t, []string{"key/6", "key/5", "key/1", "key/2", "key/3", "key/4", "key/8", "key/9"}, taskKeys)
 }
 
-func TestGetUsedSlotsOnNodes(t *testing.T) {
+func TestCreateTaskClampConcurrency(t *testing.T) {
+	_, gm, ctx := testutil.InitTableTest(t)
+	proto.RegisterSubtaskResource(proto.TaskTypeExample, proto.SubtaskResource{MemoryPerSlot: units.GiB})
+	oldLimit := memory.ServerMemoryLimit.Load()
+	t.Cleanup(func() {
+		proto.RegisterSubtaskResource(proto.TaskTypeExample, proto.SubtaskResource{})
+		memory.ServerMemoryLimit.Store(oldLimit)
+	})
+	memory.ServerMemoryLimit.Store(2 * units.GiB)
+	require.NoError(t, gm.InitMeta(ctx, ":4000", ""))
+	_, err := gm.ExecuteSQLWithNewSession(ctx, "update mysql.dist_framework_meta set cpu_count = 8")
+	require.NoError(t, err)
+	// the memory limit of the node is tidb_server_memory_limit.
+	nodes, err := gm.GetAllNodes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []proto.ManagedNode{{ID: ":4000", CPUCount: 8, MemoryLimit: 2 * units.GiB}}, nodes)
+
+	// memory is a scheduling preference, the concurrency is clamped to what the
+	// node can run.
+	id, err := gm.CreateTask(ctx, "key1", proto.TaskTypeExample, 4, []byte("test"))
+	require.NoError(t, err)
+	task, err := gm.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 2, task.Concurrency)
+	// task type without memory requirement isn't clamped.
+	id, err = gm.CreateTask(ctx, "key2", "test", 4, []byte("test"))
+	require.NoError(t, err)
+	task, err = gm.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 4, task.Concurrency)
+	// at least 1 even if the memory isn't enough for a single slot.
+	_, err = gm.ExecuteSQLWithNewSession(ctx, "update mysql.dist_framework_meta set memory_limit = %?", units.MiB)
+	require.NoError(t, err)
+	id, err = gm.CreateTask(ctx, "key3", proto.TaskTypeExample, 4, []byte("test"))
+	require.NoError(t, err)
+	task, err = gm.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 1, task.Concurrency)
+}
+
+func TestModifyTaskClampConcurrency(t *testing.T) {
+	_, gm, ctx := testutil.InitTableTest(t)
+	proto.RegisterSubtaskResource(proto.TaskTypeExample, proto.SubtaskResource{MemoryPerSlot: units.GiB})
+	oldLimit := memory.ServerMemoryLimit.Load()
+	t.Cleanup(func() {
+		proto.RegisterSubtaskResource(proto.TaskTypeExample, proto.SubtaskResource{})
+		memory.ServerMemoryLimit.Store(oldLimit)
+	})
+	memory.ServerMemoryLimit.Store(4 * units.GiB)
+	require.NoError(t, gm.InitMeta(ctx, ":4000", ""))
+	_, err := gm.ExecuteSQLWithNewSession(ctx, "update mysql.dist_framework_meta set cpu_count = 8")
+	require.NoError(t, err)
+	id, err := gm.CreateTask(ctx, "key1", proto.TaskTypeExample, 2, []byte("test"))
+	require.NoError(t, err)
+	testutil.InsertSubtask(t, gm, id, proto.StepOne, "tidb1", nil, proto.SubtaskStatePaused, proto.TaskTypeExample, 2)
+	testutil.InsertSubtask(t, gm, id, proto.StepOne, "tidb1", nil, proto.SubtaskStatePending, proto.TaskTypeExample, 2)
+	testutil.InsertSubtask(t, gm, id, proto.StepOne, "tidb1", nil, proto.SubtaskStateSucceed, proto.TaskTypeExample, 2)
+
+	// the cpu count is checked like CreateTask.
+	_, err = gm.ModifyTask(ctx, id, 16, 10)
+	require.ErrorContains(t, err, "task concurrency(16) larger than cpu count(8) of managed node")
+	// the concurrency is clamped by memory, and the unfinished subtasks are
+	// updated too.
+	found, err := gm.ModifyTask(ctx, id, 8, 10)
+	require.NoError(t, err)
+	require.True(t, found)
+	task, err := gm.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 4, task.Concurrency)
+	require.Equal(t, 10, task.Priority)
+	rs, err := gm.ExecuteSQLWithNewSession(ctx, "select state, concurrency from mysql.tidb_background_subtask where task_key = %? order by id", id)
+	require.NoError(t, err)
+	require.Len(t, rs, 3)
+	for i, expected := range []struct {
+		state       proto.SubtaskState
+		concurrency int64
+	}{{proto.SubtaskStatePaused, 4}, {proto.SubtaskStatePending, 4}, {proto.SubtaskStateSucceed, 2}} {
+		require.Equal(t, string(expected.state), rs[i].GetString(0))
+		require.Equal(t, expected.concurrency, rs[i].GetInt64(1))
+	}
+}
+
+func TestGetUsedResourcesOnNodes(t *testing.T) {
 	_, sm, ctx := testutil.InitTableTest(t)
+	proto.RegisterSubtaskResource(proto.TaskTypeExample, proto.SubtaskResource{MemoryPerSlot: units.GiB})
+	t.Cleanup(func() {
+		proto.RegisterSubtaskResource(proto.TaskTypeExample, proto.SubtaskResource{})
+	})
 
 	testutil.InsertSubtask(t, sm, 1, proto.StepOne, "tidb-1", []byte(""), proto.SubtaskStateRunning, "test", 12)
 	testutil.InsertSubtask(t, sm, 1, proto.StepOne, "tidb-2", []byte(""), proto.SubtaskStatePending, "test", 12)
 	testutil.InsertSubtask(t, sm, 2, proto.StepOne, "tidb-2", []byte(""), proto.SubtaskStatePending, "test", 8)
 	testutil.InsertSubtask(t, sm, 3, proto.StepOne, "tidb-3", []byte(""), proto.SubtaskStatePending, "test", 8)
 	testutil.InsertSubtask(t, sm, 4, proto.StepOne, "tidb-3", []byte(""), proto.SubtaskStateFailed, "test", 8)
-	slotsOnNodes, err := sm.GetUsedSlotsOnNodes(ctx)
-	require.NoError(t, err)
-	require.Equal(t, map[string]int{
-		"tidb-1": 12,
-		"tidb-2": 20,
-		"tidb-3": 8,
-	}, slotsOnNodes)
+	testutil.InsertSubtask(t, sm, 5, proto.StepOne, "tidb-3", []byte(""), proto.SubtaskStatePending, proto.TaskTypeExample, 4)
+	testutil.InsertSubtask(t, sm, 5, proto.StepOne, "tidb-3", []byte(""), proto.SubtaskStatePending, proto.TaskTypeExample, 4)
+	resourcesOnNodes, err := sm.GetUsedResourcesOnNodes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]proto.NodeResource{
+		"tidb-1": {Slots: 12},
+		"tidb-2": {Slots: 20},
+		"tidb-3": {Slots: 12, Memory: 4 * units.GiB},
+	}, resourcesOnNodes)
 }
 
 func TestGetActiveSubtasks(t *testing.T) {
@@ -775,6 +870,7 @@ func TestGetSubtaskCntByStates(t *testing.T) {
 	testutil.InsertSubtask(t, sm, 1, proto.StepTwo, "tidb1", nil, proto.SubtaskStateFailed, "test", 1)
 	cntByStates, err := sm.GetSubtaskCntGroupByStates(ctx, 1, proto.StepOne)
 	require.NoError(t, err)
+	cntByStatesOfStepOne := cntByStates
 	require.Len(t, cntByStates, 4)
 	require.Equal(t, int64(2), cntByStates[proto.SubtaskStatePending])
 	require.Equal(t, int64(1), cntByStates[proto.SubtaskStateRunning])
@@ -784,11 +880,28 @@ func TestGetSubtaskCntByStates(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, cntByStates, 1)
 	require.Equal(t, int64(1), cntByStates[proto.SubtaskStateFailed])
+
+	// only the subtasks of the current step of the tasks are counted.
+	require.NoError(t, sm.InitMeta(ctx, ":4000", ""))
+	id, err := sm.CreateTask(ctx, "key1", "test", 1, []byte("test"))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), id)
+	_, err = sm.CreateTask(ctx, "key2", "test", 1, []byte("test"))
+	require.NoError(t, err)
+	cntByTasks, err := sm.GetSubtaskCntOfAllTasksGroupByStates(ctx)
+	require.NoError(t, err)
+	require.Empty(t, cntByTasks)
+	_, err = sm.ExecuteSQLWithNewSession(ctx, "update mysql.tidb_global_task set step = %? where id = 1", proto.StepOne)
+	require.NoError(t, err)
+	cntByTasks, err = sm.GetSubtaskCntOfAllTasksGroupByStates(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[int64]map[proto.SubtaskState]int64{1: cntByStatesOfStepOne}, cntByTasks)
 }
 
 func TestDistFrameworkMeta(t *testing.T) {
 	_, sm, ctx := testutil.InitTableTest(t)
 
+	memLimit := int64(memory.ServerMemoryLimit.Load())
 	// when no node
 	_, err := sm.GetCPUCountOfManagedNode(ctx)
 	require.ErrorContains(t, err, "no managed nodes")
@@ -805,9 +918,9 @@ func TestDistFrameworkMeta(t *testing.T) {
 	nodes, err := sm.GetAllNodes(ctx)
 	require.NoError(t, err)
 	require.Equal(t, []proto.ManagedNode{
-		{ID: ":4000", Role: "background", CPUCount: 100},
-		{ID: ":4001", Role: "", CPUCount: 8},
-		{ID: ":4002", Role: "background", CPUCount: 8},
+		{ID: ":4000", Role: "background", CPUCount: 100, MemoryLimit: memLimit},
+		{ID: ":4001", Role: "", CPUCount: 8, MemoryLimit: memLimit},
+		{ID: ":4002", Role: "background", CPUCount: 8, MemoryLimit: memLimit},
 	}, nodes)
 
 	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/util/cpu/mockNumCpu", "return(100)"))
@@ -817,10 +930,10 @@ func TestDistFrameworkMeta(t *testing.T) {
 	nodes, err = sm.GetAllNodes(ctx)
 	require.NoError(t, err)
 	require.Equal(t, []proto.ManagedNode{
-		{ID: ":4000", Role: "background", CPUCount: 100},
-		{ID: ":4001", Role: "", CPUCount: 8},
-		{ID: ":4002", Role: "", CPUCount: 100},
-		{ID: ":4003", Role: "background", CPUCount: 100},
+		{ID: ":4000", Role: "background", CPUCount: 100, MemoryLimit: memLimit},
+		{ID: ":4001", Role: "", CPUCount: 8, MemoryLimit: memLimit},
+		{ID: ":4002", Role: "", CPUCount: 100, MemoryLimit: memLimit},
+		{ID: ":4003", Role: "background", CPUCount: 100, MemoryLimit: memLimit},
 	}, nodes)
 	cpuCount, err := sm.GetCPUCountOfManagedNode(ctx)
 	require.NoError(t, err)
@@ -832,22 +945,22 @@ func TestDistFrameworkMeta(t *testing.T) {
 	nodes, err = sm.GetManagedNodes(ctx)
 	require.NoError(t, err)
 	require.Equal(t, []proto.ManagedNode{
-		{ID: ":4002", Role: "background", CPUCount: 100},
-		{ID: ":4003", Role: "background", CPUCount: 100},
+		{ID: ":4002", Role: "background", CPUCount: 100, MemoryLimit: memLimit},
+		{ID: ":4003", Role: "background", CPUCount: 100, MemoryLimit: memLimit},
 	}, nodes)
 
 	require.NoError(t, sm.DeleteDeadNodes(ctx, []string{":4003"}))
 	nodes, err = sm.GetManagedNodes(ctx)
 	require.NoError(t, err)
 	require.Equal(t, []proto.ManagedNode{
-		{ID: ":4002", Role: "background", CPUCount: 100},
+		{ID: ":4002", Role: "background", CPUCount: 100, MemoryLimit: memLimit},
 	}, nodes)
 
 	require.NoError(t, sm.DeleteDeadNodes(ctx, []string{":4002"}))
 	nodes, err = sm.GetManagedNodes(ctx)
 	require.NoError(t, err)
 	require.Equal(t, []proto.ManagedNode{
-		{ID: ":4001", Role: "", CPUCount: 8},
+		{ID: ":4001", Role: "", CPUCount: 8, MemoryLimit: memLimit},
 	}, nodes)
 	cpuCount, err = sm.GetCPUCountOfManagedNode(ctx)
 	require.NoError(t, err)
@@ -857,14 +970,14 @@ func TestDistFrameworkMeta(t *testing.T) {
 	nodes, err = sm.GetManagedNodes(ctx)
 	require.NoError(t, err)
 	require.Equal(t, []proto.ManagedNode{
-		{ID: ":4002", Role: "background", CPUCount: 100},
+		{ID: ":4002", Role: "background", CPUCount: 100, MemoryLimit: memLimit},
 	}, nodes)
 	// should not reset role
 	require.NoError(t, sm.RecoverMeta(ctx, ":4002", ""))
 	nodes, err = sm.GetManagedNodes(ctx)
 	require.NoError(t, err)
 	require.Equal(t, []proto.ManagedNode{
-		{ID: ":4002", Role: "background", CPUCount: 100},
+		{ID: ":4002", Role: "background", CPUCount: 100, MemoryLimit: memLimit},
 	}, nodes)
 }
 
@@ -135,6 +135,57 @@ func (mgr *TaskManager) ResumeTask(ctx context.Context, taskKey string) (bool, e
 	return found, nil
 }
 
+// ModifyTask updates the concurrency and priority of the task, only pending or
+// paused task can be modified, as the scheduler of the task is not running or
+// will be restarted on resume, so the new values will be used when scheduling it.
+// The concurrency is checked and clamped like CreateTask, and the unfinished
+// subtasks of a paused task are updated too, so they are balanced and run with
+// the new concurrency after resume.
+// It returns false if the task is not found or not in the above states.
+func (mgr *TaskManager) ModifyTask(ctx context.Context, taskID int64, concurrency, priority int) (bool, error) {
+	found := false
+	err := mgr.WithNewTxn(ctx, func(se sessionctx.Context) error {
+		rs, err := sqlexec.ExecSQL(ctx, se,
+			`select task_key, type from mysql.tidb_global_task
+			 where id = %? and state in (%?, %?) for update`,
+			taskID, proto.TaskStatePending, proto.TaskStatePaused,
+		)
+		if err != nil {
+			return err
+		}
+		if len(rs) == 0 {
+			return nil
+		}
+		concurrency, err = mgr.checkConcurrencyWithSession(ctx, se, rs[0].GetString(0), proto.TaskType(rs[0].GetString(1)), concurrency)
+		if err != nil {
+			return err
+		}
+		_, err = sqlexec.ExecSQL(ctx, se,
+			`update mysql.tidb_global_task
+			 set concurrency = %?,
+				 priority = %?,
+				 state_update_time = CURRENT_TIMESTAMP()
+			 where id = %?`,
+			concurrency, priority, taskID,
+		)
+		if err != nil {
+			return err
+		}
+		_, err = sqlexec.ExecSQL(ctx, se,
+			`update mysql.tidb_background_subtask
+			 set concurrency = %?
+			 where task_key = %? and state in (%?, %?)`,
+			concurrency, taskID, proto.SubtaskStatePending, proto.SubtaskStatePaused,
+		)
+		if err != nil {
+			return err
+		}
+		found = true
+		return nil
+	})
+	return found, err
+}
+
 // SucceedTask update task state from running to succeed.
 func (mgr *TaskManager) SucceedTask(ctx context.Context, taskID int64) error {
 	return mgr.WithNewSession(func(se sessionctx.Context) error {
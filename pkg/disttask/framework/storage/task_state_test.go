@@ -119,4 +119,39 @@ func TestTaskState(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, proto.TaskStateSucceed, task.State)
 	require.Equal(t, proto.StepDone, task.Step)
+
+	// 9. modify task, only pending and paused task can be modified.
+	found, err = gm.ModifyTask(ctx, 6, 8, 10)
+	require.NoError(t, err)
+	require.False(t, found)
+	id, err = gm.CreateTask(ctx, "key7", "test", 4, []byte("test"))
+	require.NoError(t, err)
+	found, err = gm.ModifyTask(ctx, id, 8, 10)
+	require.NoError(t, err)
+	require.True(t, found)
+	task, err = gm.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 8, task.Concurrency)
+	require.Equal(t, 10, task.Priority)
+	found, err = gm.PauseTask(ctx, "key7")
+	require.NoError(t, err)
+	require.True(t, found)
+	found, err = gm.ModifyTask(ctx, id, 2, 20)
+	require.NoError(t, err)
+	require.False(t, found)
+	require.NoError(t, gm.PausedTask(ctx, id))
+	found, err = gm.ModifyTask(ctx, id, 2, 20)
+	require.NoError(t, err)
+	require.True(t, found)
+	task, err = gm.GetTaskByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 2, task.Concurrency)
+	require.Equal(t, 20, task.Priority)
+
+	tasks, err := gm.GetAllTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 7)
+	for i, task := range tasks {
+		require.Equal(t, int64(i+1), task.ID)
+	}
 }
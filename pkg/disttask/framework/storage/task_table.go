@@ -30,9 +30,11 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/intest"
+	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/sqlescape"
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
 	"github.com/tikv/client-go/v2/util"
+	"go.uber.org/zap"
 )
 
 const (
@@ -54,6 +56,12 @@ const (
 var (
 	maxSubtaskBatchSize = 16 * units.MiB
 
+	// TopUnfinishedTasksLimit is the max number of tasks returned by GetTopUnfinishedTasks.
+	// tasks are admitted by the free resource of managed nodes, we only load some
+	// top tasks in each round of scheduling, as the resource is usually exhausted
+	// before all of them are scheduled.
+	TopUnfinishedTasksLimit = 64
+
 	// ErrUnstableSubtasks is the error when we detected that the subtasks are
 	// unstable, i.e. count, order and content of the subtasks are changed on
 	// different call.
@@ -192,15 +200,35 @@ func (mgr *TaskManager) CreateTask(ctx context.Context, key string, tp proto.Tas
 	return
 }
 
-// CreateTaskWithSession adds a new task to task table with session.
-func (mgr *TaskManager) CreateTaskWithSession(ctx context.Context, se sessionctx.Context, key string, tp proto.TaskType, concurrency int, meta []byte) (taskID int64, err error) {
-	cpuCount, err := mgr.getCPUCountOfManagedNode(ctx, se)
+// checkConcurrencyWithSession checks the concurrency of a task against the
+// managed nodes. Memory is a scheduling preference, so the concurrency is
+// clamped to what the largest node can run instead of failing the task.
+func (mgr *TaskManager) checkConcurrencyWithSession(ctx context.Context, se sessionctx.Context, key string, tp proto.TaskType, concurrency int) (int, error) {
+	nodes, err := mgr.getManagedNodesWithSession(ctx, se)
+	if err != nil {
+		return 0, err
+	}
+	cpuCount, err := getCPUCountOfNodes(nodes)
 	if err != nil {
 		return 0, err
 	}
 	if concurrency > cpuCount {
 		return 0, errors.Errorf("task concurrency(%d) larger than cpu count(%d) of managed node", concurrency, cpuCount)
 	}
+	if maxConcurrency := getMaxConcurrencyOfNodes(nodes, proto.GetSubtaskResource(tp)); concurrency > maxConcurrency {
+		logutil.Logger(ctx).Info("clamp task concurrency by the memory of managed nodes",
+			zap.String("task-key", key), zap.Int("concurrency", concurrency), zap.Int("new-concurrency", maxConcurrency))
+		concurrency = maxConcurrency
+	}
+	return concurrency, nil
+}
+
+// CreateTaskWithSession adds a new task to task table with session.
+func (mgr *TaskManager) CreateTaskWithSession(ctx context.Context, se sessionctx.Context, key string, tp proto.TaskType, concurrency int, meta []byte) (taskID int64, err error) {
+	concurrency, err = mgr.checkConcurrencyWithSession(ctx, se, key, tp, concurrency)
+	if err != nil {
+		return 0, err
+	}
 	_, err = sqlexec.ExecSQL(ctx, se, `
 			insert into mysql.tidb_global_task(`+InsertTaskColumns+`)
 			values (%?, %?, %?, %?, %?, %?, %?, CURRENT_TIMESTAMP())`,
@@ -233,7 +261,7 @@ func (mgr *TaskManager) GetTopUnfinishedTasks(ctx context.Context) (task []*prot
 		proto.TaskStateCancelling,
 		proto.TaskStatePausing,
 		proto.TaskStateResuming,
-		proto.MaxConcurrentTask*2,
+		TopUnfinishedTasksLimit,
 	)
 	if err != nil {
 		return task, err
@@ -265,6 +293,19 @@ func (mgr *TaskManager) GetTasksInStates(ctx context.Context, states ...interfac
 	return task, nil
 }
 
+// GetAllTasks gets all the tasks which are not moved to the history table, order by id asc.
+func (mgr *TaskManager) GetAllTasks(ctx context.Context) (task []*proto.Task, err error) {
+	rs, err := mgr.ExecuteSQLWithNewSession(ctx, "select "+TaskColumns+" from mysql.tidb_global_task order by id asc")
+	if err != nil {
+		return task, err
+	}
+
+	for _, r := range rs {
+		task = append(task, Row2Task(r))
+	}
+	return task, nil
+}
+
 // GetTaskByID gets the task by the task ID.
 func (mgr *TaskManager) GetTaskByID(ctx context.Context, taskID int64) (task *proto.Task, err error) {
 	rs, err := mgr.ExecuteSQLWithNewSession(ctx, "select "+TaskColumns+" from mysql.tidb_global_task where id = %?", taskID)
@@ -437,6 +478,30 @@ func (mgr *TaskManager) GetSubtaskCntGroupByStates(ctx context.Context, taskID i
 	return res, nil
 }
 
+// GetSubtaskCntOfAllTasksGroupByStates gets the subtask count of the current
+// step of all tasks by states in one query, the result is keyed by task ID.
+func (mgr *TaskManager) GetSubtaskCntOfAllTasksGroupByStates(ctx context.Context) (map[int64]map[proto.SubtaskState]int64, error) {
+	rs, err := mgr.ExecuteSQLWithNewSession(ctx, `
+		select t.id, s.state, count(*)
+		from mysql.tidb_global_task t join mysql.tidb_background_subtask s
+		on s.task_key = cast(t.id as char) and s.step = t.step
+		group by t.id, s.state`)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[int64]map[proto.SubtaskState]int64)
+	for _, r := range rs {
+		taskID := r.GetInt64(0)
+		if res[taskID] == nil {
+			res[taskID] = make(map[proto.SubtaskState]int64)
+		}
+		res[taskID][proto.SubtaskState(r.GetString(1))] = r.GetInt64(2)
+	}
+
+	return res, nil
+}
+
 // GetSubtaskErrors gets subtasks' errors.
 func (mgr *TaskManager) GetSubtaskErrors(ctx context.Context, taskID int64) ([]error, error) {
 	rs, err := mgr.ExecuteSQLWithNewSession(ctx,
@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/resourcemanager/pool/spool"
@@ -90,15 +91,11 @@ type Manager struct {
 // BuildManager builds a Manager.
 func (b *ManagerBuilder) BuildManager(ctx context.Context, id string, taskTable TaskTable) (*Manager, error) {
 	m := &Manager{
-		id:        id,
-		taskTable: taskTable,
-		logCtx:    logutil.WithFields(context.Background()),
-		newPool:   b.newPool,
-		slotManager: &slotManager{
-			taskID2Index:  make(map[int64]int),
-			executorTasks: make([]*proto.Task, 0),
-			available:     cpu.GetCPUCount(),
-		},
+		id:          id,
+		taskTable:   taskTable,
+		logCtx:      logutil.WithFields(context.Background()),
+		newPool:     b.newPool,
+		slotManager: newSlotManager(cpu.GetCPUCount(), storage.GetMemoryLimit),
 	}
 	m.ctx, m.cancel = context.WithCancel(ctx)
 	m.mu.handlingTasks = make(map[int64]context.CancelCauseFunc)
@@ -33,6 +33,22 @@ type slotManager struct {
 	// The number of slots that can be used by the executor.
 	// Its initial value is always equal to CPU cores of the instance.
 	available int
+	// usedMemory is the memory used by the running tasks, it's calculated by
+	// the resource declared by the task type, see proto.SubtaskResource.
+	usedMemory int64
+	// getMemoryLimit returns the memory limit of the instance, it's read on each
+	// allocation as it can be changed by tidb_server_memory_limit. 0 means no
+	// limit, and the memory isn't checked when allocating.
+	getMemoryLimit func() int64
+}
+
+func newSlotManager(slots int, getMemoryLimit func() int64) *slotManager {
+	return &slotManager{
+		taskID2Index:   make(map[int64]int),
+		executorTasks:  make([]*proto.Task, 0),
+		available:      slots,
+		getMemoryLimit: getMemoryLimit,
+	}
 }
 
 // subtasks inside a task will be run in serial, so they takes task.Concurrency slots.
@@ -47,7 +63,9 @@ func (sm *slotManager) alloc(task *proto.Task) {
 	for index, slotInfo := range sm.executorTasks {
 		sm.taskID2Index[slotInfo.ID] = index
 	}
-	sm.available -= task.Concurrency
+	required := task.RequiredResource()
+	sm.available -= required.Slots
+	sm.usedMemory += required.Memory
 }
 
 func (sm *slotManager) free(taskID int64) {
@@ -58,7 +76,9 @@ func (sm *slotManager) free(taskID int64) {
 	if !ok {
 		return
 	}
-	sm.available += sm.executorTasks[index].Concurrency
+	required := sm.executorTasks[index].RequiredResource()
+	sm.available += required.Slots
+	sm.usedMemory -= required.Memory
 	sm.executorTasks = append(sm.executorTasks[:index], sm.executorTasks[index+1:]...)
 
 	delete(sm.taskID2Index, taskID)
@@ -72,21 +92,30 @@ func (sm *slotManager) canAlloc(task *proto.Task) (canAlloc bool, tasksNeedFree
 	sm.RLock()
 	defer sm.RUnlock()
 
-	if sm.available >= task.Concurrency {
+	required := task.RequiredResource()
+	if sm.enough(proto.NodeResource{}, required) {
 		return true, nil
 	}
 
-	usedSlots := 0
+	var freed proto.NodeResource
 	for _, slotInfo := range sm.executorTasks {
 		if slotInfo.Compare(task) < 0 {
 			break
 		}
 		tasksNeedFree = append(tasksNeedFree, slotInfo)
-		usedSlots += slotInfo.Concurrency
-		if sm.available+usedSlots >= task.Concurrency {
+		freed = freed.Add(slotInfo.RequiredResource())
+		if sm.enough(freed, required) {
 			return true, tasksNeedFree
 		}
 	}
 
 	return false, nil
 }
+
+// enough checks whether the available resource is enough for the required
+// resource after freeing some resource, see proto.NodeResource.CanAlloc.
+func (sm *slotManager) enough(freed, required proto.NodeResource) bool {
+	used := proto.NodeResource{Memory: sm.usedMemory - freed.Memory}
+	capacity := proto.NodeResource{Slots: sm.available + freed.Slots, Memory: sm.getMemoryLimit()}
+	return required.CanAlloc(used, capacity)
+}
@@ -22,11 +22,7 @@ import (
 )
 
 func TestSlotManager(t *testing.T) {
-	sm := slotManager{
-		taskID2Index:  make(map[int64]int),
-		executorTasks: make([]*proto.Task, 0),
-		available:     10,
-	}
+	sm := newSlotManager(10, func() int64 { return 0 })
 
 	var (
 		task = &proto.Task{
@@ -135,3 +131,64 @@ func TestSlotManager(t *testing.T) {
 	require.Len(t, sm.executorTasks, 0)
 	require.Len(t, sm.taskID2Index, 0)
 }
+
+func TestSlotManagerWithMemory(t *testing.T) {
+	proto.RegisterSubtaskResource(proto.TaskTypeExample, proto.SubtaskResource{MemoryPerSlot: 1 << 30})
+	t.Cleanup(func() {
+		proto.RegisterSubtaskResource(proto.TaskTypeExample, proto.SubtaskResource{})
+	})
+	memoryLimit := int64(8 << 30)
+	sm := newSlotManager(16, func() int64 { return memoryLimit })
+
+	task := &proto.Task{ID: 1, Type: proto.TaskTypeExample, Priority: 2, Concurrency: 6}
+	canAlloc, tasksNeedFree := sm.canAlloc(task)
+	require.True(t, canAlloc)
+	require.Nil(t, tasksNeedFree)
+	sm.alloc(task)
+	require.Equal(t, 10, sm.available)
+	require.Equal(t, int64(6<<30), sm.usedMemory)
+
+	// slots are enough, but memory isn't.
+	task2 := &proto.Task{ID: 2, Type: proto.TaskTypeExample, Priority: 3, Concurrency: 4}
+	canAlloc, tasksNeedFree = sm.canAlloc(task2)
+	require.False(t, canAlloc)
+	require.Nil(t, tasksNeedFree)
+	// task without memory requirement can run.
+	task3 := &proto.Task{ID: 3, Type: "no-memory", Priority: 3, Concurrency: 4}
+	canAlloc, _ = sm.canAlloc(task3)
+	require.True(t, canAlloc)
+	// higher priority task can preempt.
+	task2.Priority = 1
+	canAlloc, tasksNeedFree = sm.canAlloc(task2)
+	require.True(t, canAlloc)
+	require.Equal(t, []*proto.Task{task}, tasksNeedFree)
+	// the memory limit is changed.
+	task2.Priority = 3
+	memoryLimit = 10 << 30
+	canAlloc, tasksNeedFree = sm.canAlloc(task2)
+	require.True(t, canAlloc)
+	require.Nil(t, tasksNeedFree)
+	memoryLimit = 8 << 30
+
+	sm.free(task.ID)
+	require.Equal(t, 16, sm.available)
+	require.Equal(t, int64(0), sm.usedMemory)
+
+	// memory is a preference, the task requiring more memory than the limit
+	// can run when the slots are enough.
+	task.Concurrency = 12
+	canAlloc, _ = sm.canAlloc(task)
+	require.True(t, canAlloc)
+	sm.alloc(task)
+	canAlloc, _ = sm.canAlloc(task3)
+	require.True(t, canAlloc)
+	canAlloc, _ = sm.canAlloc(task2)
+	require.False(t, canAlloc)
+	sm.free(task.ID)
+
+	// memory is not checked if there is no memory limit.
+	memoryLimit = 0
+	task.Concurrency = 16
+	canAlloc, _ = sm.canAlloc(task)
+	require.True(t, canAlloc)
+}
@@ -25,6 +25,7 @@ import (
 	dmysql "github.com/go-sql-driver/mysql"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/br/pkg/lightning/backend/external"
 	"github.com/pingcap/tidb/br/pkg/lightning/checkpoints"
 	"github.com/pingcap/tidb/br/pkg/lightning/common"
 	"github.com/pingcap/tidb/br/pkg/lightning/config"
@@ -712,4 +713,11 @@ func stepStr(step proto.Step) string {
 
 func init() {
 	scheduler.RegisterSchedulerFactory(proto.ImportInto, newImportScheduler)
+	// each encode worker buffers the data and index KVs before flushing them to
+	// external storage, see indexKVTotalBufSize. It's a scheduling preference,
+	// the thread count of the task is clamped when the nodes are smaller, see
+	// storage.TaskManager.CreateTaskWithSession.
+	proto.RegisterSubtaskResource(proto.ImportInto, proto.SubtaskResource{
+		MemoryPerSlot: int64(indexKVTotalBufSize + external.DefaultMemSizeLimit),
+	})
 }
@@ -388,6 +388,7 @@ func (e *writeAndIngestStepExecutor) RunSubtask(ctx context.Context, subtask *pr
 			TotalFileSize:   int64(sm.TotalKVSize),
 			TotalKVCount:    0,
 			CheckHotspot:    false,
+			MasterKeyFile:   e.taskMeta.Plan.MasterKeyFile,
 		},
 	}, engineUUID)
 	if err != nil {
@@ -498,6 +499,9 @@ func (*importExecutor) GetSubtaskExecutor(_ context.Context, task *proto.Task, _
 	if err := json.Unmarshal(task.Meta, &taskMeta); err != nil {
 		return nil, errors.Trace(err)
 	}
+	// the task concurrency might be clamped by the memory of the managed nodes
+	// when the task is created, the subtasks must not use more threads than it.
+	taskMeta.Plan.ThreadCnt = min(taskMeta.Plan.ThreadCnt, task.Concurrency)
 	logger := logutil.BgLogger().With(
 		zap.Stringer("type", proto.ImportInto),
 		zap.Int64("task-id", task.ID),
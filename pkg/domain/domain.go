@@ -47,6 +47,7 @@ import (
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/domain/resourcegroup"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	infoschema_metrics "github.com/pingcap/tidb/pkg/infoschema/metrics"
 	"github.com/pingcap/tidb/pkg/infoschema/perfschema"
@@ -89,6 +90,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
 	"github.com/pingcap/tidb/pkg/util/sqlkiller"
 	"github.com/pingcap/tidb/pkg/util/syncutil"
+	"github.com/pingcap/tidb/pkg/util/timeutil"
 	"github.com/tikv/client-go/v2/tikv"
 	"github.com/tikv/client-go/v2/txnkv/transaction"
 	pd "github.com/tikv/pd/client"
@@ -174,6 +176,7 @@ type Domain struct {
 	logBackupAdvancer        *daemon.OwnerDaemon
 	historicalStatsWorker    *HistoricalStatsWorker
 	ttlJobManager            atomic.Pointer[ttlworker.JobManager]
+	eventScheduler           atomic.Pointer[eventscheduler.Scheduler]
 	runawayManager           *resourcegroup.RunawayManager
 	runawaySyncer            *runawaySyncer
 	resourceGroupsController *rmclient.ResourceGroupsController
@@ -1022,6 +1025,11 @@ func (do *Domain) Close() {
 			logutil.BgLogger().Info("ttlJobManager exited.")
 		}
 	}
+	if eventScheduler := do.eventScheduler.Load(); eventScheduler != nil {
+		logutil.BgLogger().Info("stopping eventScheduler")
+		eventScheduler.Stop()
+		logutil.BgLogger().Info("eventScheduler exited.")
+	}
 	do.releaseServerID(context.Background())
 	close(do.exit)
 	if do.etcdClient != nil {
@@ -1866,6 +1874,7 @@ func (do *Domain) LoadBindInfoLoop(ctxForHandle sessionctx.Context, ctxForEvolve
 
 	owner := do.newOwnerManager(bindinfo.Prompt, bindinfo.OwnerKey)
 	do.globalBindHandleWorkerLoop(owner)
+	do.globalBindEvolveLoop(owner)
 	return nil
 }
 
@@ -1912,6 +1921,73 @@ func (do *Domain) globalBindHandleWorkerLoop(owner owner.Manager) {
 	}, "globalBindHandleWorkerLoop")
 }
 
+// globalBindEvolveLoop runs the baseline evolution task on the bind owner periodically.
+// It's separated from globalBindHandleWorkerLoop because an evolution task may run for a long time.
+func (do *Domain) globalBindEvolveLoop(owner owner.Manager) {
+	do.wg.Run(func() {
+		defer func() {
+			logutil.BgLogger().Info("globalBindEvolveLoop exited.")
+		}()
+		defer util.Recover(metrics.LabelDomain, "globalBindEvolveLoop", nil, false)
+
+		evolveBindTicker := time.NewTicker(100 * bindinfo.Lease)
+		defer evolveBindTicker.Stop()
+		for {
+			select {
+			case <-do.exit:
+				return
+			case <-evolveBindTicker.C:
+				if !owner.IsOwner() {
+					continue
+				}
+				maxTime, ok := do.shouldEvolveBindings()
+				if !ok {
+					continue
+				}
+				err := do.BindHandle().EvolveGlobalBindings(maxTime)
+				if err != nil {
+					logutil.BgLogger().Error("evolve bind record failed", zap.Error(err))
+				}
+			}
+		}
+	}, "globalBindEvolveLoop")
+}
+
+// shouldEvolveBindings checks whether the baseline evolution is enabled and now is within the evolution period.
+// It also returns the max time of the evolution task.
+func (do *Domain) shouldEvolveBindings() (time.Duration, bool) {
+	optVal, err := do.GetGlobalVar(variable.TiDBEvolvePlanBaselines)
+	if err != nil || !variable.TiDBOptOn(optVal) {
+		return 0, false
+	}
+	startVal, err := do.GetGlobalVar(variable.TiDBEvolvePlanTaskStartTime)
+	if err != nil {
+		return 0, false
+	}
+	endVal, err := do.GetGlobalVar(variable.TiDBEvolvePlanTaskEndTime)
+	if err != nil {
+		return 0, false
+	}
+	start, err := time.ParseInLocation(variable.FullDayTimeFormat, startVal, time.UTC)
+	if err != nil {
+		logutil.BgLogger().Warn("parse evolve plan task start time failed", zap.String("start", startVal), zap.Error(err))
+		return 0, false
+	}
+	end, err := time.ParseInLocation(variable.FullDayTimeFormat, endVal, time.UTC)
+	if err != nil {
+		logutil.BgLogger().Warn("parse evolve plan task end time failed", zap.String("end", endVal), zap.Error(err))
+		return 0, false
+	}
+	if !timeutil.WithinDayTimePeriod(start, end, time.Now()) {
+		return 0, false
+	}
+	maxTimeVal, err := do.GetGlobalVar(variable.TiDBEvolvePlanTaskMaxTime)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(variable.TidbOptInt64(maxTimeVal, variable.DefTiDBEvolvePlanTaskMaxTime)) * time.Second, true
+}
+
 // TelemetryReportLoop create a goroutine that reports usage data in a loop, it should be called only once
 // in BootstrapSession.
 func (do *Domain) TelemetryReportLoop(ctx sessionctx.Context) {
@@ -2996,6 +3072,18 @@ func (do *Domain) TTLJobManager() *ttlworker.JobManager {
 	return do.ttlJobManager.Load()
 }
 
+// StartEventScheduler creates and starts the scheduler of the events created by `CREATE EVENT`.
+func (do *Domain) StartEventScheduler(executor eventscheduler.BodyExecutor) {
+	eventScheduler := eventscheduler.NewScheduler(do.ddl.GetID(), do.sysSessionPool, do.etcdClient, do.ddl.OwnerManager().IsOwner, executor)
+	do.eventScheduler.Store(eventScheduler)
+	eventScheduler.Start()
+}
+
+// EventScheduler returns the event scheduler on this domain
+func (do *Domain) EventScheduler() *eventscheduler.Scheduler {
+	return do.eventScheduler.Load()
+}
+
 // StopAutoAnalyze stops (*Domain).autoAnalyzeWorker to launch new auto analyze jobs.
 func (do *Domain) StopAutoAnalyze() {
 	do.stopAutoAnalyze.Store(true)
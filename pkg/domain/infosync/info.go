@@ -551,13 +551,13 @@ func GetResourceGroup(ctx context.Context, name string) (*rmpb.ResourceGroup, er
 }
 
 // ListResourceGroups is used to get all resource groups from resource manager.
-func ListResourceGroups(ctx context.Context) ([]*rmpb.ResourceGroup, error) {
+func ListResourceGroups(ctx context.Context, opts ...pd.GetResourceGroupOption) ([]*rmpb.ResourceGroup, error) {
 	is, err := getGlobalInfoSyncer()
 	if err != nil {
 		return nil, err
 	}
 
-	return is.resourceManagerClient.ListResourceGroups(ctx)
+	return is.resourceManagerClient.ListResourceGroups(ctx, opts...)
 }
 
 // AddResourceGroup is used to create one specific resource group to resource manager.
@@ -0,0 +1,260 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/coprocessor"
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/tikv/client-go/v2/tikvrpc"
+	kvutil "github.com/tikv/client-go/v2/util"
+	rmclient "github.com/tikv/pd/client/resource_group/controller"
+)
+
+// Background task types of resource groups can be set on both the default
+// resource group and the other ones, but they have different meanings:
+//   - for the default resource group, the tasks of the types are run as
+//     background tasks in TiKV, and they are not limited by the RU settings.
+//   - for the other resource groups, the tasks of the types are assigned to the
+//     group, i.e. they run in the group whatever group the user who submits
+//     them belongs to, and they are limited by the RU settings of the group.
+//
+// a task type can be assigned to at most one resource group other than the
+// default one.
+
+// GetBackgroundGroupName returns the name of the resource group which the
+// background tasks of the task type are assigned to. If there is no such group,
+// groupName is returned, it's usually the resource group of the session which
+// submits the task.
+// taskType should be one of kvutil.ExplicitTypeList.
+func GetBackgroundGroupName(groups []*model.ResourceGroupInfo, taskType, groupName string) string {
+	if group := GetBackgroundGroup(groups, taskType); group != nil {
+		return group.Name.L
+	}
+	return groupName
+}
+
+// GetBackgroundGroup returns the resource group other than the default one
+// which the background tasks of the task type are assigned to, returns nil if
+// there is no such group.
+func GetBackgroundGroup(groups []*model.ResourceGroupInfo, taskType string) *model.ResourceGroupInfo {
+	for _, group := range groups {
+		if group.Name.L == DefaultResourceGroupName || group.ResourceGroupSettings == nil {
+			continue
+		}
+		if bg := group.Background; bg != nil && slices.Contains(bg.JobTypes, taskType) {
+			return group
+		}
+	}
+	return nil
+}
+
+// backgroundUsageTaskTypes are the background task types whose consumption is
+// collected by RecordBackgroundUsage.
+var backgroundUsageTaskTypes = []string{kvutil.ExplicitTypeDDL, kvutil.ExplicitTypeLightning, kvutil.ExplicitTypeStats}
+
+type backgroundUsageKey struct {
+	group    string
+	taskType string
+}
+
+// BackgroundUsage is the consumption of the background tasks of a type which
+// run in a resource group.
+type BackgroundUsage struct {
+	Group       string
+	TaskType    string
+	Consumption rmpb.Consumption
+}
+
+var backgroundUsage = struct {
+	sync.Mutex
+	calc  *rmclient.KVCalculator
+	usage map[backgroundUsageKey]*rmpb.Consumption
+}{
+	calc:  &rmclient.KVCalculator{RUConfig: rmclient.DefaultRUConfig()},
+	usage: make(map[backgroundUsageKey]*rmpb.Consumption),
+}
+
+// RecordBackgroundUsage records the consumption of a KV request sent by a
+// background task to the resource group other than the default one which the
+// request belongs to. resp is nil if the request failed.
+// The consumption is calculated by the default RU model like the resource
+// control of client-go does, the task type is the last part of the request
+// source like TiKV does.
+func RecordBackgroundUsage(req *tikvrpc.Request, resp *tikvrpc.Response) {
+	groupName := req.GetResourceControlContext().GetResourceGroupName()
+	if len(groupName) == 0 || groupName == DefaultResourceGroupName {
+		return
+	}
+	source := req.GetRequestSource()
+	taskType := source[strings.LastIndex(source, "_")+1:]
+	if !slices.Contains(backgroundUsageTaskTypes, taskType) {
+		return
+	}
+	reqInfo := makeBackgroundRequestInfo(req)
+	var consumption rmpb.Consumption
+	backgroundUsage.calc.BeforeKVRequest(&consumption, reqInfo)
+	if resp != nil {
+		backgroundUsage.calc.AfterKVRequest(&consumption, reqInfo, makeBackgroundResponseInfo(resp))
+	}
+
+	key := backgroundUsageKey{group: groupName, taskType: taskType}
+	backgroundUsage.Lock()
+	defer backgroundUsage.Unlock()
+	total, ok := backgroundUsage.usage[key]
+	if !ok {
+		total = &rmpb.Consumption{}
+		backgroundUsage.usage[key] = total
+	}
+	total.RRU += consumption.RRU
+	total.WRU += consumption.WRU
+	total.ReadBytes += consumption.ReadBytes
+	total.WriteBytes += consumption.WriteBytes
+	total.TotalCpuTimeMs += consumption.TotalCpuTimeMs
+	total.KvReadRpcCount += consumption.KvReadRpcCount
+	total.KvWriteRpcCount += consumption.KvWriteRpcCount
+}
+
+// GetBackgroundUsage returns the consumption of the background tasks recorded
+// by this TiDB since it started, ordered by the group name and the task type.
+func GetBackgroundUsage() []BackgroundUsage {
+	backgroundUsage.Lock()
+	usage := make([]BackgroundUsage, 0, len(backgroundUsage.usage))
+	for key, consumption := range backgroundUsage.usage {
+		usage = append(usage, BackgroundUsage{Group: key.group, TaskType: key.taskType, Consumption: *consumption})
+	}
+	backgroundUsage.Unlock()
+	slices.SortFunc(usage, func(a, b BackgroundUsage) int {
+		if c := cmp.Compare(a.Group, b.Group); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.TaskType, b.TaskType)
+	})
+	return usage
+}
+
+// backgroundRequestInfo implements rmclient.RequestInfo, it's the same as the
+// one used by the resource control of client-go, which is internal.
+type backgroundRequestInfo struct {
+	// writeBytes is -1 if it's a read request.
+	writeBytes    int64
+	storeID       uint64
+	replicaNumber int64
+}
+
+func makeBackgroundRequestInfo(req *tikvrpc.Request) *backgroundRequestInfo {
+	storeID := req.GetPeer().GetStoreId()
+	if !req.IsTxnWriteRequest() && !req.IsRawWriteRequest() {
+		return &backgroundRequestInfo{writeBytes: -1, storeID: storeID}
+	}
+	var writeBytes int64
+	switch r := req.Req.(type) {
+	case *kvrpcpb.PrewriteRequest:
+		for _, m := range r.Mutations {
+			writeBytes += int64(len(m.Key)) + int64(len(m.Value))
+		}
+		writeBytes += int64(len(r.PrimaryLock))
+		for _, l := range r.Secondaries {
+			writeBytes += int64(len(l))
+		}
+	case *kvrpcpb.CommitRequest:
+		for _, k := range r.Keys {
+			writeBytes += int64(len(k))
+		}
+	}
+	return &backgroundRequestInfo{writeBytes: writeBytes, storeID: storeID, replicaNumber: req.ReplicaNumber}
+}
+
+func (r *backgroundRequestInfo) IsWrite() bool {
+	return r.writeBytes > -1
+}
+
+func (r *backgroundRequestInfo) WriteBytes() uint64 {
+	if r.writeBytes > 0 {
+		return uint64(r.writeBytes)
+	}
+	return 0
+}
+
+func (r *backgroundRequestInfo) ReplicaNumber() int64 {
+	return r.replicaNumber
+}
+
+func (r *backgroundRequestInfo) StoreID() uint64 {
+	return r.storeID
+}
+
+// backgroundResponseInfo implements rmclient.ResponseInfo.
+type backgroundResponseInfo struct {
+	readBytes uint64
+	kvCPU     time.Duration
+}
+
+func makeBackgroundResponseInfo(resp *tikvrpc.Response) *backgroundResponseInfo {
+	var (
+		readBytes uint64
+		detailsV2 *kvrpcpb.ExecDetailsV2
+		details   *kvrpcpb.ExecDetails
+	)
+	switch r := resp.Resp.(type) {
+	case *coprocessor.Response:
+		detailsV2, details = r.GetExecDetailsV2(), r.GetExecDetails()
+		readBytes = uint64(r.Data.Size())
+	case *tikvrpc.CopStreamResponse:
+		if r.Response != nil {
+			detailsV2, details = r.Response.GetExecDetailsV2(), r.Response.GetExecDetails()
+		}
+		readBytes = uint64(r.Data.Size())
+	case *kvrpcpb.GetResponse:
+		detailsV2 = r.GetExecDetailsV2()
+	case *kvrpcpb.BatchGetResponse:
+		detailsV2 = r.GetExecDetailsV2()
+	case *kvrpcpb.ScanResponse:
+		readBytes = uint64(r.Size())
+	default:
+		return &backgroundResponseInfo{}
+	}
+	if scanDetail := detailsV2.GetScanDetailV2(); scanDetail != nil {
+		readBytes = scanDetail.GetProcessedVersionsSize()
+	}
+	var kvCPU time.Duration
+	if timeDetail := detailsV2.GetTimeDetailV2(); timeDetail != nil {
+		kvCPU = time.Duration(timeDetail.GetProcessWallTimeNs())
+	} else if timeDetail := detailsV2.GetTimeDetail(); timeDetail != nil {
+		kvCPU = time.Duration(timeDetail.GetProcessWallTimeMs()) * time.Millisecond
+	} else if timeDetail := details.GetTimeDetail(); timeDetail != nil {
+		kvCPU = time.Duration(timeDetail.GetProcessWallTimeMs()) * time.Millisecond
+	}
+	return &backgroundResponseInfo{readBytes: readBytes, kvCPU: kvCPU}
+}
+
+func (r *backgroundResponseInfo) ReadBytes() uint64 {
+	return r.readBytes
+}
+
+func (r *backgroundResponseInfo) KVCPU() time.Duration {
+	return r.kvCPU
+}
+
+func (*backgroundResponseInfo) Succeed() bool {
+	return true
+}
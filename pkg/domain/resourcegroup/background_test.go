@@ -0,0 +1,74 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"testing"
+
+	"github.com/pingcap/kvproto/pkg/coprocessor"
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/tikvrpc"
+)
+
+func TestRecordBackgroundUsage(t *testing.T) {
+	newRequest := func(groupName, source string, req any) *tikvrpc.Request {
+		return &tikvrpc.Request{
+			Req: req,
+			Context: kvrpcpb.Context{
+				RequestSource:          source,
+				ResourceControlContext: &kvrpcpb.ResourceControlContext{ResourceGroupName: groupName},
+			},
+		}
+	}
+	copResp := &tikvrpc.Response{Resp: &coprocessor.Response{
+		ExecDetailsV2: &kvrpcpb.ExecDetailsV2{
+			ScanDetailV2: &kvrpcpb.ScanDetailV2{ProcessedVersionsSize: 1024},
+			TimeDetailV2: &kvrpcpb.TimeDetailV2{ProcessWallTimeNs: 2000000},
+		},
+	}}
+
+	copReq := newRequest("rg1", "leader_internal_ddl_reorg_ddl", &coprocessor.Request{})
+	copReq.Type = tikvrpc.CmdCop
+	RecordBackgroundUsage(copReq, copResp)
+	RecordBackgroundUsage(copReq, nil)
+	prewriteReq := newRequest("rg1", "leader_internal_ImportInto_lightning", &kvrpcpb.PrewriteRequest{
+		Mutations:   []*kvrpcpb.Mutation{{Key: []byte("key"), Value: []byte("value")}},
+		PrimaryLock: []byte("key"),
+	})
+	prewriteReq.Type = tikvrpc.CmdPrewrite
+	RecordBackgroundUsage(prewriteReq, &tikvrpc.Response{Resp: &kvrpcpb.PrewriteResponse{}})
+	// not background tasks, or not assigned to a resource group.
+	RecordBackgroundUsage(newRequest("rg1", "leader_external_Select", &coprocessor.Request{}), copResp)
+	RecordBackgroundUsage(newRequest("rg1", "leader_internal_others", &coprocessor.Request{}), copResp)
+	RecordBackgroundUsage(newRequest("default", "leader_internal_ddl_reorg_ddl", &coprocessor.Request{}), copResp)
+	RecordBackgroundUsage(newRequest("", "leader_internal_stats_stats", &coprocessor.Request{}), copResp)
+
+	usage := GetBackgroundUsage()
+	require.Len(t, usage, 2)
+	require.Equal(t, "rg1", usage[0].Group)
+	require.Equal(t, "ddl", usage[0].TaskType)
+	require.EqualValues(t, 2, usage[0].Consumption.KvReadRpcCount)
+	require.Zero(t, usage[0].Consumption.KvWriteRpcCount)
+	require.EqualValues(t, 1024, usage[0].Consumption.ReadBytes)
+	require.EqualValues(t, 2, usage[0].Consumption.TotalCpuTimeMs)
+	require.Greater(t, usage[0].Consumption.RRU, 0.0)
+	require.Zero(t, usage[0].Consumption.WRU)
+	require.Equal(t, "rg1", usage[1].Group)
+	require.Equal(t, "lightning", usage[1].TaskType)
+	require.EqualValues(t, 1, usage[1].Consumption.KvWriteRpcCount)
+	require.EqualValues(t, len("key")+len("value")+len("key"), usage[1].Consumption.WriteBytes)
+	require.Greater(t, usage[1].Consumption.WRU, 0.0)
+}
@@ -16,6 +16,8 @@ package resourcegroup
 
 import (
 	"context"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -24,9 +26,11 @@ import (
 	"github.com/jellydator/ttlcache/v3"
 	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
 	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/pkg/util/generic"
 	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/stringutil"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/tikv"
 	"github.com/tikv/client-go/v2/tikvrpc"
@@ -53,6 +57,56 @@ const (
 // NullTime is a zero time.Time.
 var NullTime time.Time
 
+// The following watch types and action are only known by TiDB and not defined in kvproto,
+// so they can only be used by the watch items added by QUERY WATCH.
+const (
+	// RunawayWatchTypeTable watches the queries which access the table.
+	RunawayWatchTypeTable = rmpb.RunawayWatchType(model.WatchTable)
+	// RunawayWatchTypeUser watches the queries which are run by the user.
+	RunawayWatchTypeUser = rmpb.RunawayWatchType(model.WatchUser)
+	// RunawayWatchTypeLike watches the queries whose normalized SQL matches the LIKE pattern.
+	RunawayWatchTypeLike = rmpb.RunawayWatchType(model.WatchLike)
+	// RunawayWatchTypeScannedKeys watches the queries which scan more keys than the threshold.
+	RunawayWatchTypeScannedKeys = rmpb.RunawayWatchType(model.WatchScannedKeys)
+	// RunawayActionSwitchGroup switches the coprocessor requests of the query to another resource group.
+	RunawayActionSwitchGroup = rmpb.RunawayAction(model.RunawayActionSwitchGroup)
+)
+
+// RunawayWatchTypeName returns the name of the watch type.
+func RunawayWatchTypeName(tp rmpb.RunawayWatchType) string {
+	switch tp {
+	case RunawayWatchTypeTable:
+		return "Table"
+	case RunawayWatchTypeUser:
+		return "User"
+	case RunawayWatchTypeLike:
+		return "Like"
+	case RunawayWatchTypeScannedKeys:
+		return "ScannedKeys"
+	default:
+		return rmpb.RunawayWatchType_name[int32(tp)]
+	}
+}
+
+// RunawayActionName returns the name of the action.
+func RunawayActionName(action rmpb.RunawayAction) string {
+	if action == RunawayActionSwitchGroup {
+		return "SwitchGroup"
+	}
+	return rmpb.RunawayAction_name[int32(action)]
+}
+
+// isPatternWatch returns whether the watch items of the type can't be looked up
+// by the identifiers of the query, but have to be matched one by one.
+func isPatternWatch(tp rmpb.RunawayWatchType) bool {
+	switch tp {
+	case RunawayWatchTypeTable, RunawayWatchTypeUser, RunawayWatchTypeLike, RunawayWatchTypeScannedKeys:
+		return true
+	default:
+		return false
+	}
+}
+
 // RunawayMatchType is used to indicate whether query was interrupted by runaway identification or quarantine watch.
 type RunawayMatchType uint
 
@@ -116,13 +170,33 @@ type QuarantineRecord struct {
 	WatchText         string
 	Source            string
 	Action            rmpb.RunawayAction
+	// SwitchGroupName is the resource group to switch to when the action is RunawayActionSwitchGroup.
+	SwitchGroupName string
+
+	// the compiled WatchText of a LIKE watch, see likePattern.
+	likeOnce     sync.Once
+	likePatChars []rune
+	likePatTypes []byte
 }
 
 // GetRecordKey is used to get the key in ttl cache.
 func (r *QuarantineRecord) GetRecordKey() string {
+	if isPatternWatch(r.Watch) {
+		// the watch type is a part of the key to avoid conflicting with SQL texts and digests.
+		return r.ResourceGroupName + "/" + RunawayWatchTypeName(r.Watch) + ":" + r.WatchText
+	}
 	return r.ResourceGroupName + "/" + r.WatchText
 }
 
+// likePattern returns the compiled pattern of a LIKE watch, it's only compiled
+// once for the record.
+func (r *QuarantineRecord) likePattern() ([]rune, []byte) {
+	r.likeOnce.Do(func() {
+		r.likePatChars, r.likePatTypes = stringutil.CompilePattern(r.WatchText, '\\')
+	})
+	return r.likePatChars, r.likePatTypes
+}
+
 func writeInsert(builder *strings.Builder, tableName string) {
 	builder.WriteString("insert into ")
 	builder.WriteString(tableName)
@@ -132,9 +206,9 @@ func writeInsert(builder *strings.Builder, tableName string) {
 // GenInsertionStmt is used to generate insertion sql.
 func (r *QuarantineRecord) GenInsertionStmt() (string, []interface{}) {
 	var builder strings.Builder
-	params := make([]interface{}, 0, 6)
+	params := make([]interface{}, 0, 8)
 	writeInsert(&builder, RunawayWatchTableName)
-	builder.WriteString("(null, %?, %?, %?, %?, %?, %?, %?)")
+	builder.WriteString("(null, %?, %?, %?, %?, %?, %?, %?, %?)")
 	params = append(params, r.ResourceGroupName)
 	params = append(params, r.StartTime)
 	if r.EndTime.Equal(NullTime) {
@@ -146,15 +220,16 @@ func (r *QuarantineRecord) GenInsertionStmt() (string, []interface{}) {
 	params = append(params, r.WatchText)
 	params = append(params, r.Source)
 	params = append(params, r.Action)
+	params = append(params, r.SwitchGroupName)
 	return builder.String(), params
 }
 
 // GenInsertionDoneStmt is used to generate insertion sql for runaway watch done record.
 func (r *QuarantineRecord) GenInsertionDoneStmt() (string, []interface{}) {
 	var builder strings.Builder
-	params := make([]interface{}, 0, 9)
+	params := make([]interface{}, 0, 10)
 	writeInsert(&builder, RunawayWatchDoneTableName)
-	builder.WriteString("(null, %?, %?, %?, %?, %?, %?, %?, %?, %?)")
+	builder.WriteString("(null, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)")
 	params = append(params, r.ID)
 	params = append(params, r.ResourceGroupName)
 	params = append(params, r.StartTime)
@@ -168,6 +243,7 @@ func (r *QuarantineRecord) GenInsertionDoneStmt() (string, []interface{}) {
 	params = append(params, r.Source)
 	params = append(params, r.Action)
 	params = append(params, time.Now().UTC())
+	params = append(params, r.SwitchGroupName)
 	return builder.String(), params
 }
 
@@ -191,8 +267,11 @@ type RunawayManager struct {
 	watchList *ttlcache.Cache[string, *QuarantineRecord]
 	// activeGroup is used to manage the active runaway watches of resource group
 	activeGroup map[string]int64
-	activeLock  sync.RWMutex
-	metricsMap  generic.SyncMap[string, prometheus.Counter]
+	// patternWatches is used to manage the keys of the active pattern watches of resource group,
+	// the value is a counter as the insertion and eviction of the same key may be handled out of order.
+	patternWatches map[string]map[string]int
+	activeLock     sync.RWMutex
+	metricsMap     generic.SyncMap[string, prometheus.Counter]
 
 	resourceGroupCtl   *rmclient.ResourceGroupsController
 	serverID           string
@@ -226,16 +305,19 @@ func NewRunawayManager(resourceGroupCtl *rmclient.ResourceGroupsController, serv
 		quarantineChan:        make(chan *QuarantineRecord, maxWatchRecordChannelSize),
 		staleQuarantineRecord: staleQuarantineChan,
 		activeGroup:           make(map[string]int64),
+		patternWatches:        make(map[string]map[string]int),
 		metricsMap:            generic.NewSyncMap[string, prometheus.Counter](8),
 	}
 	m.insertionCancel = watchList.OnInsertion(func(ctx context.Context, i *ttlcache.Item[string, *QuarantineRecord]) {
 		m.activeLock.Lock()
 		m.activeGroup[i.Value().ResourceGroupName]++
+		m.updatePatternWatch(i.Value(), 1)
 		m.activeLock.Unlock()
 	})
 	m.evictionCancel = watchList.OnEviction(func(ctx context.Context, er ttlcache.EvictionReason, i *ttlcache.Item[string, *QuarantineRecord]) {
 		m.activeLock.Lock()
 		m.activeGroup[i.Value().ResourceGroupName]--
+		m.updatePatternWatch(i.Value(), -1)
 		m.activeLock.Unlock()
 		if i.Value().ID == 0 {
 			return
@@ -245,8 +327,49 @@ func NewRunawayManager(resourceGroupCtl *rmclient.ResourceGroupsController, serv
 	return m
 }
 
-// DeriveChecker derives a RunawayChecker from the given resource group
-func (rm *RunawayManager) DeriveChecker(resourceGroupName, originalSQL, sqlDigest, planDigest string) *RunawayChecker {
+// updatePatternWatch updates the counter of the key of the pattern watch, it must be called with activeLock held.
+func (rm *RunawayManager) updatePatternWatch(record *QuarantineRecord, delta int) {
+	if !isPatternWatch(record.Watch) {
+		return
+	}
+	keys, ok := rm.patternWatches[record.ResourceGroupName]
+	if !ok {
+		keys = make(map[string]int)
+		rm.patternWatches[record.ResourceGroupName] = keys
+	}
+	key := record.GetRecordKey()
+	keys[key] += delta
+	if keys[key] == 0 {
+		delete(keys, key)
+	}
+	if len(keys) == 0 {
+		delete(rm.patternWatches, record.ResourceGroupName)
+	}
+}
+
+// getPatternWatches returns the active pattern watches of the resource group.
+func (rm *RunawayManager) getPatternWatches(resourceGroupName string) []*QuarantineRecord {
+	rm.activeLock.RLock()
+	defer rm.activeLock.RUnlock()
+	keys := rm.patternWatches[resourceGroupName]
+	if len(keys) == 0 {
+		return nil
+	}
+	ret := make([]*QuarantineRecord, 0, len(keys))
+	for key, cnt := range keys {
+		if cnt <= 0 {
+			continue
+		}
+		if item := rm.getWatchFromWatchList(key); item != nil {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
+
+// DeriveChecker derives a RunawayChecker from the given resource group.
+// normalizedSQL, user and tables are used to match the pattern watches, tables are in the format of `db.table`.
+func (rm *RunawayManager) DeriveChecker(resourceGroupName, originalSQL, normalizedSQL, sqlDigest, planDigest, user string, tables []string) *RunawayChecker {
 	group, err := rm.resourceGroupCtl.GetResourceGroup(resourceGroupName)
 	if err != nil || group == nil {
 		logutil.BgLogger().Warn("cannot setup up runaway checker", zap.Error(err))
@@ -263,7 +386,11 @@ func (rm *RunawayManager) DeriveChecker(resourceGroupName, originalSQL, sqlDiges
 		rm.metricsMap.Store(resourceGroupName, counter)
 	}
 	counter.Inc()
-	return newRunawayChecker(rm, resourceGroupName, group.RunawaySettings, originalSQL, sqlDigest, planDigest)
+	c := newRunawayChecker(rm, resourceGroupName, group.RunawaySettings, originalSQL, sqlDigest, planDigest)
+	c.normalizedSQL = normalizedSQL
+	c.user = user
+	c.tables = tables
+	return c
 }
 
 func (rm *RunawayManager) markQuarantine(resourceGroupName, convict string, watchType rmpb.RunawayWatchType, action rmpb.RunawayAction, ttl time.Duration, now *time.Time) {
@@ -415,13 +542,9 @@ func (rm *RunawayManager) StaleQuarantineRecordChan() <-chan *QuarantineRecord {
 	return rm.staleQuarantineRecord
 }
 
-// examineWatchList check whether the query is in watch list.
-func (rm *RunawayManager) examineWatchList(resourceGroupName string, convict string) (bool, rmpb.RunawayAction) {
-	item := rm.getWatchFromWatchList(resourceGroupName + "/" + convict)
-	if item == nil {
-		return false, 0
-	}
-	return true, item.Action
+// examineWatchList check whether the query is in watch list, it returns the matched watch item.
+func (rm *RunawayManager) examineWatchList(resourceGroupName string, convict string) *QuarantineRecord {
+	return rm.getWatchFromWatchList(resourceGroupName + "/" + convict)
 }
 
 // Stop stops the watchList which is a ttlcache.
@@ -438,11 +561,25 @@ type RunawayChecker struct {
 	originalSQL       string
 	sqlDigest         string
 	planDigest        string
+	normalizedSQL     string
+	user              string
+	tables            []string
 
 	deadline time.Time
 	setting  *rmpb.RunawaySettings
 
-	marked atomic.Bool
+	// markedByWatch is set when the query matches a watch item.
+	markedByWatch atomic.Bool
+	// markedByRule is set when the query exceeds the QUERY_LIMIT of the resource group.
+	markedByRule atomic.Bool
+
+	// scannedKeysWatch is the SCANNED_KEYS watch item with the smallest threshold.
+	scannedKeysWatch     *QuarantineRecord
+	scannedKeysThreshold int64
+	scannedKeys          atomic.Int64
+	scannedKeysMatched   atomic.Bool
+	// watchAction is the matched watch item whose action takes effect on the coprocessor requests.
+	watchAction atomic.Pointer[QuarantineRecord]
 }
 
 func newRunawayChecker(manager *RunawayManager, resourceGroupName string, setting *rmpb.RunawaySettings, originalSQL, sqlDigest, planDigest string) *RunawayChecker {
@@ -453,7 +590,6 @@ func newRunawayChecker(manager *RunawayManager, resourceGroupName string, settin
 		sqlDigest:         sqlDigest,
 		planDigest:        planDigest,
 		setting:           setting,
-		marked:            atomic.Bool{},
 	}
 	if setting != nil {
 		c.deadline = time.Now().Add(time.Duration(setting.Rule.ExecElapsedTimeMs) * time.Millisecond)
@@ -467,36 +603,99 @@ func (r *RunawayChecker) BeforeExecutor() error {
 		return nil
 	}
 	for _, convict := range r.getConvictIdentifiers() {
-		watched, action := r.manager.examineWatchList(r.resourceGroupName, convict)
-		if watched {
-			if action == rmpb.RunawayAction_NoneAction && r.setting != nil {
-				action = r.setting.Action
-			}
-			if r.marked.CompareAndSwap(false, true) {
-				now := time.Now()
-				r.markRunaway(RunawayMatchTypeWatch, action, &now)
-			}
-			// If no match action, it will do nothing.
-			switch action {
-			case rmpb.RunawayAction_Kill:
-				return exeerrors.ErrResourceGroupQueryRunawayQuarantine
-			case rmpb.RunawayAction_CoolDown:
-				return nil
-			case rmpb.RunawayAction_DryRun:
-				return nil
-			default:
+		if item := r.manager.examineWatchList(r.resourceGroupName, convict); item != nil {
+			if done, err := r.handleWatchMatched(item); done {
+				return err
 			}
 		}
 	}
+	for _, item := range r.manager.getPatternWatches(r.resourceGroupName) {
+		if item.Watch == RunawayWatchTypeScannedKeys {
+			// it can only be checked during execution.
+			r.addScannedKeysWatch(item)
+			continue
+		}
+		if !r.matchPatternWatch(item) {
+			continue
+		}
+		if done, err := r.handleWatchMatched(item); done {
+			return err
+		}
+	}
 	return nil
 }
 
+// handleWatchMatched marks the query as runaway and performs the action of the matched watch item.
+// It returns true if the action is determined.
+func (r *RunawayChecker) handleWatchMatched(item *QuarantineRecord) (bool, error) {
+	action := item.Action
+	if action == rmpb.RunawayAction_NoneAction && r.setting != nil {
+		action = r.setting.Action
+	}
+	if r.markedByWatch.CompareAndSwap(false, true) {
+		now := time.Now()
+		r.markRunaway(RunawayMatchTypeWatch, action, &now)
+	}
+	// If no match action, it will do nothing.
+	switch action {
+	case rmpb.RunawayAction_Kill:
+		return true, exeerrors.ErrResourceGroupQueryRunawayQuarantine
+	case rmpb.RunawayAction_CoolDown, RunawayActionSwitchGroup:
+		r.watchAction.Store(item)
+		return true, nil
+	case rmpb.RunawayAction_DryRun:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// matchPatternWatch checks whether the query matches the pattern watch item.
+func (r *RunawayChecker) matchPatternWatch(item *QuarantineRecord) bool {
+	switch item.Watch {
+	case RunawayWatchTypeTable:
+		return slices.Contains(r.tables, item.WatchText)
+	case RunawayWatchTypeUser:
+		return r.user == item.WatchText
+	case RunawayWatchTypeLike:
+		patChars, patTypes := item.likePattern()
+		return stringutil.DoMatch(r.normalizedSQL, patChars, patTypes)
+	default:
+		return false
+	}
+}
+
+func (r *RunawayChecker) addScannedKeysWatch(item *QuarantineRecord) {
+	threshold, err := strconv.ParseInt(item.WatchText, 10, 64)
+	if err != nil {
+		logutil.BgLogger().Warn("invalid scanned keys watch", zap.String("watch-text", item.WatchText), zap.Error(err))
+		return
+	}
+	if r.scannedKeysWatch == nil || threshold < r.scannedKeysThreshold {
+		r.scannedKeysWatch = item
+		r.scannedKeysThreshold = threshold
+	}
+}
+
 // BeforeCopRequest checks runaway and modifies the request if necessary before sending coprocessor request.
 func (r *RunawayChecker) BeforeCopRequest(req *tikvrpc.Request) error {
+	// The actions of the matched watch items are applied first, and the QUERY_LIMIT
+	// of the resource group is still checked, so that a query matching a milder
+	// watch item can't escape the action of the resource group.
+	if item := r.watchAction.Load(); item != nil {
+		if err := r.applyWatchAction(req, item); err != nil {
+			return err
+		}
+	}
+	if r.scannedKeysMatched.Load() {
+		if err := r.applyWatchAction(req, r.scannedKeysWatch); err != nil {
+			return err
+		}
+	}
 	if r.setting == nil {
 		return nil
 	}
-	marked := r.marked.Load()
+	marked := r.markedByRule.Load()
 	if !marked {
 		// note: now we don't check whether query is in watch list again.
 		until := time.Until(r.deadline)
@@ -510,7 +709,7 @@ func (r *RunawayChecker) BeforeCopRequest(req *tikvrpc.Request) error {
 			return nil
 		}
 		// execution time exceeds the threshold, mark the query as runaway
-		if r.marked.CompareAndSwap(false, true) {
+		if r.markedByRule.CompareAndSwap(false, true) {
 			now := time.Now()
 			r.markRunaway(RunawayMatchTypeIdentify, r.setting.Action, &now)
 			r.markQuarantine(&now)
@@ -529,15 +728,41 @@ func (r *RunawayChecker) BeforeCopRequest(req *tikvrpc.Request) error {
 	}
 }
 
+// applyWatchAction performs the action of the matched watch item on the coprocessor request.
+func (r *RunawayChecker) applyWatchAction(req *tikvrpc.Request, item *QuarantineRecord) error {
+	action := item.Action
+	if action == rmpb.RunawayAction_NoneAction && r.setting != nil {
+		action = r.setting.Action
+	}
+	switch action {
+	case rmpb.RunawayAction_Kill:
+		return exeerrors.ErrResourceGroupQueryRunawayQuarantine
+	case rmpb.RunawayAction_CoolDown:
+		req.ResourceControlContext.OverridePriority = 1 // set priority to lowest
+	case RunawayActionSwitchGroup:
+		req.ResourceControlContext.ResourceGroupName = item.SwitchGroupName
+	default:
+	}
+	return nil
+}
+
 // AfterCopRequest checks runaway after receiving coprocessor response.
-func (r *RunawayChecker) AfterCopRequest() {
+// scannedKeys is the number of keys scanned by the coprocessor request.
+func (r *RunawayChecker) AfterCopRequest(scannedKeys int64) {
+	if r.scannedKeysWatch != nil && !r.scannedKeysMatched.Load() {
+		// The same as below, the action is performed in `BeforeCopRequest` of the next cop request.
+		if r.scannedKeys.Add(scannedKeys) > r.scannedKeysThreshold && r.scannedKeysMatched.CompareAndSwap(false, true) {
+			now := time.Now()
+			r.markRunaway(RunawayMatchTypeWatch, r.scannedKeysWatch.Action, &now)
+		}
+	}
 	if r.setting == nil {
 		return
 	}
 	// Do not perform action here as it may be the last cop request and just let it finish. If it's not the last cop request, action would be performed in `BeforeCopRequest` when handling the next cop request.
 	// Here only marks the query as runaway
-	if !r.marked.Load() && r.deadline.Before(time.Now()) {
-		if r.marked.CompareAndSwap(false, true) {
+	if !r.markedByRule.Load() && r.deadline.Before(time.Now()) {
+		if r.markedByRule.CompareAndSwap(false, true) {
 			now := time.Now()
 			r.markRunaway(RunawayMatchTypeIdentify, r.setting.Action, &now)
 			r.markQuarantine(&now)
@@ -555,7 +780,7 @@ func (r *RunawayChecker) markQuarantine(now *time.Time) {
 }
 
 func (r *RunawayChecker) markRunaway(matchType RunawayMatchType, action rmpb.RunawayAction, now *time.Time) {
-	actionStr := strings.ToLower(rmpb.RunawayAction_name[int32(action)])
+	actionStr := strings.ToLower(RunawayActionName(action))
 	metrics.RunawayCheckerCounter.WithLabelValues(r.resourceGroupName, matchType.String(), actionStr).Inc()
 	r.manager.markRunaway(r.resourceGroupName, r.originalSQL, r.planDigest, actionStr, matchType, now)
 }
@@ -0,0 +1,119 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/tikvrpc"
+)
+
+func newCopRequest(resourceGroupName string) *tikvrpc.Request {
+	return &tikvrpc.Request{
+		Context: kvrpcpb.Context{
+			ResourceControlContext: &kvrpcpb.ResourceControlContext{ResourceGroupName: resourceGroupName},
+		},
+	}
+}
+
+func TestRunawayCheckerScannedKeysSwitchGroup(t *testing.T) {
+	rm := NewRunawayManager(nil, "tidb-1")
+	defer rm.Stop()
+	rm.AddWatch(&QuarantineRecord{
+		ID:                1,
+		ResourceGroupName: "rg1",
+		StartTime:         time.Now(),
+		EndTime:           NullTime,
+		Watch:             RunawayWatchTypeScannedKeys,
+		WatchText:         "100",
+		Source:            ManualSource,
+		Action:            RunawayActionSwitchGroup,
+		SwitchGroupName:   "rg_low",
+	})
+	require.Eventually(t, func() bool {
+		return len(rm.getPatternWatches("rg1")) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	checker := newRunawayChecker(rm, "rg1", nil, "select * from t", "sql-digest", "plan-digest")
+	// the scanned keys can only be checked during execution.
+	require.NoError(t, checker.BeforeExecutor())
+	req := newCopRequest("rg1")
+	require.NoError(t, checker.BeforeCopRequest(req))
+	require.Equal(t, "rg1", req.ResourceControlContext.ResourceGroupName)
+
+	// the threshold isn't crossed yet.
+	checker.AfterCopRequest(60)
+	req = newCopRequest("rg1")
+	require.NoError(t, checker.BeforeCopRequest(req))
+	require.Equal(t, "rg1", req.ResourceControlContext.ResourceGroupName)
+	require.Len(t, rm.runawayQueriesChan, 0)
+
+	// the following cop requests are switched to the other resource group once the threshold is crossed.
+	checker.AfterCopRequest(60)
+	require.Len(t, rm.runawayQueriesChan, 1)
+	record := <-rm.runawayQueriesChan
+	require.Equal(t, "rg1", record.ResourceGroupName)
+	require.Equal(t, "watch", record.Match)
+	require.Equal(t, "switchgroup", record.Action)
+	for i := 0; i < 2; i++ {
+		req = newCopRequest("rg1")
+		require.NoError(t, checker.BeforeCopRequest(req))
+		require.Equal(t, "rg_low", req.ResourceControlContext.ResourceGroupName)
+		checker.AfterCopRequest(60)
+	}
+	// the query is marked only once.
+	require.Len(t, rm.runawayQueriesChan, 0)
+}
+
+func TestRunawayCheckerWatchActionWithQueryLimit(t *testing.T) {
+	rm := NewRunawayManager(nil, "tidb-1")
+	defer rm.Stop()
+	rm.AddWatch(&QuarantineRecord{
+		ID:                1,
+		ResourceGroupName: "rg1",
+		StartTime:         time.Now(),
+		EndTime:           NullTime,
+		Watch:             rmpb.RunawayWatchType_Exact,
+		WatchText:         "select * from t",
+		Source:            ManualSource,
+		Action:            rmpb.RunawayAction_CoolDown,
+	})
+	setting := &rmpb.RunawaySettings{
+		Rule:   &rmpb.RunawayRule{ExecElapsedTimeMs: 50},
+		Action: rmpb.RunawayAction_Kill,
+	}
+	checker := newRunawayChecker(rm, "rg1", setting, "select * from t", "sql-digest", "plan-digest")
+	require.NoError(t, checker.BeforeExecutor())
+	require.Len(t, rm.runawayQueriesChan, 1)
+
+	// the query is cooled down by the watch, and the QUERY_LIMIT of the resource group is still checked.
+	req := newCopRequest("rg1")
+	require.NoError(t, checker.BeforeCopRequest(req))
+	require.EqualValues(t, 1, req.ResourceControlContext.OverridePriority)
+	require.Positive(t, req.Context.MaxExecutionDurationMs)
+	require.LessOrEqual(t, req.Context.MaxExecutionDurationMs, uint64(50))
+
+	// the query is killed once it exceeds the EXEC_ELAPSED of the resource group.
+	checker.deadline = time.Now().Add(-time.Second)
+	checker.AfterCopRequest(1)
+	require.Len(t, rm.runawayQueriesChan, 2)
+	req = newCopRequest("rg1")
+	require.ErrorIs(t, checker.BeforeCopRequest(req), exeerrors.ErrResourceGroupQueryRunawayInterrupted)
+}
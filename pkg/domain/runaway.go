@@ -529,6 +529,9 @@ func getRunawayWatchRecord(exec sqlexec.RestrictedSQLExecutor, reader *SystemTab
 			Source:            r.GetString(6),
 			Action:            rmpb.RunawayAction(r.GetInt64(7)),
 		}
+		if r.Len() > 8 {
+			qr.SwitchGroupName = r.GetString(8)
+		}
 		// If a TiDB write record slow, it will occur that the record which has earlier start time is inserted later than others.
 		// So we start the scan a little earlier.
 		if push {
@@ -569,6 +572,9 @@ func getRunawayWatchDoneRecord(exec sqlexec.RestrictedSQLExecutor, reader *Syste
 			Source:            r.GetString(7),
 			Action:            rmpb.RunawayAction(r.GetInt64(8)),
 		}
+		if r.Len() > 10 {
+			qr.SwitchGroupName = r.GetString(10)
+		}
 		// Ditto as getRunawayWatchRecord.
 		if push {
 			reader.CheckPoint = now.Add(-3 * runawayWatchSyncInterval)
@@ -1137,6 +1137,9 @@ const (
 	ErrPausedDDLJob       = 8262
 	ErrBDRRestrictedDDL   = 8263
 
+	ErrInvalidTTLArchiveURI = 8264
+	ErrInvalidTTLFilter     = 8265
+
 	// Resource group errors.
 	ErrResourceGroupExists                    = 8248
 	ErrResourceGroupNotExists                 = 8249
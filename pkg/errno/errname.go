@@ -1165,4 +1165,7 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrCannotResumeDDLJob: mysql.Message("Job [%v] can't be resumed: %s", nil),
 	ErrPausedDDLJob:       mysql.Message("Job [%v] has already been paused", nil),
 	ErrBDRRestrictedDDL:   mysql.Message("The operation is not allowed while the bdr role of this cluster is set to %s.", nil),
+
+	ErrInvalidTTLArchiveURI: mysql.Message("The URI of TTL_ARCHIVE %s is invalid. Reason: %s", []int{0}),
+	ErrInvalidTTLFilter:     mysql.Message("The TTL_FILTER '%s' is invalid. Reason: %s", nil),
 }
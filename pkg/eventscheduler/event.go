@@ -0,0 +1,314 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+)
+
+const (
+	timerKeyPrefix = "/tidb/event/"
+	timerHookClass = "tidb.event"
+	// oneTimeEventInterval is the interval of the timer policy for the events which are executed only once.
+	// It only makes the timer to be triggered at `ExecuteAt`, and the timer will be dropped or disabled after that.
+	oneTimeEventInterval = time.Minute
+)
+
+// EventTimerData is the data of an event stored in the timer.
+type EventTimerData struct {
+	Schema      string `json:"schema"`
+	Name        string `json:"name"`
+	DefinerUser string `json:"definer_user"`
+	DefinerHost string `json:"definer_host"`
+	// Body is the statement to execute when the event is triggered.
+	Body      string `json:"body"`
+	SQLMode   string `json:"sql_mode"`
+	TimeZone  string `json:"time_zone"`
+	Charset   string `json:"charset"`
+	Collation string `json:"collation"`
+	Comment   string `json:"comment,omitempty"`
+	Preserve  bool   `json:"preserve"`
+	// ExecuteAt is not zero only when the event is executed only once.
+	ExecuteAt time.Time `json:"execute_at,omitempty"`
+	// IntervalValue and IntervalField are the `EVERY` clause specified by user, they are only used to show the event.
+	IntervalValue string `json:"interval_value,omitempty"`
+	IntervalField string `json:"interval_field,omitempty"`
+	// Interval is the duration between two executions of a recurring event.
+	Interval    time.Duration `json:"interval,omitempty"`
+	Starts      time.Time     `json:"starts,omitempty"`
+	Ends        time.Time     `json:"ends,omitempty"`
+	Created     time.Time     `json:"created"`
+	LastAltered time.Time     `json:"last_altered"`
+}
+
+// Event is an event with its timer.
+type Event struct {
+	*EventTimerData
+	Timer *timerapi.TimerRecord
+}
+
+// Enabled returns whether the event is enabled.
+func (e *Event) Enabled() bool {
+	return e.Timer.Enable
+}
+
+// LastExecuted returns the schedule time of the last execution, or zero time if the event has never been executed
+// since it was created or its schedule was changed.
+func (e *Event) LastExecuted() time.Time {
+	if e.Timer.Watermark.After(e.initialWatermark()) {
+		return e.Timer.Watermark
+	}
+	return time.Time{}
+}
+
+// Definer returns the definer of the event in the format of 'user@host'.
+func (d *EventTimerData) Definer() string {
+	return fmt.Sprintf("%s@%s", d.DefinerUser, d.DefinerHost)
+}
+
+// IsOneTime returns whether the event is executed only once.
+func (d *EventTimerData) IsOneTime() bool {
+	return !d.ExecuteAt.IsZero()
+}
+
+// Validate validates the schedule of the event.
+func (d *EventTimerData) Validate() error {
+	if d.Schema == "" || d.Name == "" {
+		return errors.New("event schema and name should not be empty")
+	}
+
+	if d.IsOneTime() {
+		return nil
+	}
+
+	if d.Interval <= 0 {
+		return errors.New("event interval should be positive")
+	}
+
+	if d.Starts.IsZero() {
+		return errors.New("event starts should not be empty")
+	}
+
+	if !d.Ends.IsZero() && d.Ends.Before(d.Starts) {
+		return errors.New("event ends should not be before starts")
+	}
+	return nil
+}
+
+// IsExpired returns whether the event will never be executed at or after `now`.
+func (d *EventTimerData) IsExpired(now time.Time) bool {
+	if d.IsOneTime() {
+		return d.ExecuteAt.Before(now)
+	}
+	return !d.Ends.IsZero() && d.Ends.Before(now)
+}
+
+// NextScheduleTime returns the first time the event should be executed after `watermark`.
+// The second return value is false if the event will never be executed after `watermark`.
+func (d *EventTimerData) NextScheduleTime(watermark time.Time) (time.Time, bool) {
+	if d.IsOneTime() {
+		if watermark.Before(d.ExecuteAt) {
+			return d.ExecuteAt, true
+		}
+		return time.Time{}, false
+	}
+
+	next := d.Starts
+	if !watermark.Before(d.Starts) {
+		n := watermark.Sub(d.Starts)/d.Interval + 1
+		next = d.Starts.Add(n * d.Interval)
+	}
+
+	if !d.Ends.IsZero() && next.After(d.Ends) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// lastScheduleTime returns the latest time not after `t` that the event should be executed.
+// It is used as the watermark of the timer after an execution.
+func (d *EventTimerData) lastScheduleTime(t time.Time) time.Time {
+	if d.IsOneTime() {
+		return d.ExecuteAt
+	}
+
+	if t.Before(d.Starts) {
+		return d.Starts
+	}
+	return d.Starts.Add(t.Sub(d.Starts) / d.Interval * d.Interval)
+}
+
+// initialWatermark returns the watermark of a new timer to make the first execution happen at the start time.
+func (d *EventTimerData) initialWatermark() time.Time {
+	if d.IsOneTime() {
+		return d.ExecuteAt.Add(-oneTimeEventInterval)
+	}
+	return d.Starts.Add(-d.Interval)
+}
+
+// schedPolicyExpr returns the expression of the interval policy of the timer.
+// The interval of the timer is only an approximate value when it is not in whole minutes, the hook will delay the
+// event to the exact schedule time computed by `NextScheduleTime`.
+func (d *EventTimerData) schedPolicyExpr() string {
+	interval := d.Interval
+	if d.IsOneTime() {
+		interval = oneTimeEventInterval
+	}
+
+	if interval%time.Minute == 0 {
+		return fmt.Sprintf("%dm", interval/time.Minute)
+	}
+	return strconv.FormatFloat(interval.Minutes(), 'f', -1, 64) + "m"
+}
+
+// BuildTimerKey returns the key of the timer for the specified event.
+func BuildTimerKey(schema, name string) string {
+	return buildTimerKeyPrefix(schema) + url.PathEscape(strings.ToLower(name))
+}
+
+func buildTimerKeyPrefix(schema string) string {
+	if schema == "" {
+		return timerKeyPrefix
+	}
+	return timerKeyPrefix + url.PathEscape(strings.ToLower(schema)) + "/"
+}
+
+// GetEvent returns the event with the specified schema and name. It returns nil if the event does not exist.
+func GetEvent(ctx context.Context, cli timerapi.TimerClient, schema, name string) (*Event, error) {
+	timer, err := cli.GetTimerByKey(ctx, BuildTimerKey(schema, name))
+	if err != nil {
+		if errors.ErrorEqual(err, timerapi.ErrTimerNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return newEvent(timer)
+}
+
+// ListEvents lists the events in the specified schema. If the schema is empty, all events are returned.
+func ListEvents(ctx context.Context, cli timerapi.TimerClient, schema string) ([]*Event, error) {
+	timers, err := cli.GetTimers(ctx, timerapi.WithKeyPrefix(buildTimerKeyPrefix(schema)))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*Event, 0, len(timers))
+	for _, timer := range timers {
+		event, err := newEvent(timer)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// CreateEvent creates a timer for the event.
+// It returns `timerapi.ErrTimerExists` if the event already exists.
+func CreateEvent(ctx context.Context, cli timerapi.TimerClient, data *EventTimerData, enable bool) (*Event, error) {
+	return createEventTimer(ctx, cli, data, enable, data.initialWatermark())
+}
+
+// RenameEvent moves the event to a new timer with the key of the new name in `data`, and drops the old one.
+// The progress of the schedule is kept unless `scheduleChanged` is true.
+func RenameEvent(ctx context.Context, cli timerapi.TimerClient, event *Event, data *EventTimerData, enable bool, scheduleChanged bool) error {
+	watermark := event.Timer.Watermark
+	if scheduleChanged {
+		watermark = data.initialWatermark()
+	}
+
+	if _, err := createEventTimer(ctx, cli, data, enable, watermark); err != nil {
+		return err
+	}
+	_, err := cli.DeleteTimer(ctx, event.Timer.ID)
+	return err
+}
+
+func createEventTimer(ctx context.Context, cli timerapi.TimerClient, data *EventTimerData, enable bool, watermark time.Time) (*Event, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+
+	bs, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	timer, err := cli.CreateTimer(ctx, timerapi.TimerSpec{
+		Key:             BuildTimerKey(data.Schema, data.Name),
+		Tags:            []string{fmt.Sprintf("db=%s", data.Schema)},
+		Data:            bs,
+		SchedPolicyType: timerapi.SchedEventInterval,
+		SchedPolicyExpr: data.schedPolicyExpr(),
+		HookClass:       timerHookClass,
+		Watermark:       watermark,
+		Enable:          enable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Event{EventTimerData: data, Timer: timer}, nil
+}
+
+// UpdateEvent updates the timer of the event. If `scheduleChanged` is true, the schedule of the timer is reset.
+func UpdateEvent(ctx context.Context, cli timerapi.TimerClient, event *Event, enable bool, scheduleChanged bool) error {
+	data := event.EventTimerData
+	if err := data.Validate(); err != nil {
+		return err
+	}
+
+	bs, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	opts := []timerapi.UpdateTimerOption{
+		timerapi.WithSetData(bs),
+		timerapi.WithSetEnable(enable),
+	}
+	if scheduleChanged {
+		opts = append(opts,
+			timerapi.WithSetSchedExpr(timerapi.SchedEventInterval, data.schedPolicyExpr()),
+			timerapi.WithSetWatermark(data.initialWatermark()),
+		)
+	}
+	return cli.UpdateTimer(ctx, event.Timer.ID, opts...)
+}
+
+// DropEvent drops the timer of the event. It returns false if the event does not exist.
+func DropEvent(ctx context.Context, cli timerapi.TimerClient, schema, name string) (bool, error) {
+	event, err := GetEvent(ctx, cli, schema, name)
+	if err != nil || event == nil {
+		return false, err
+	}
+	return cli.DeleteTimer(ctx, event.Timer.ID)
+}
+
+func newEvent(timer *timerapi.TimerRecord) (*Event, error) {
+	var data EventTimerData
+	if err := json.Unmarshal(timer.Data, &data); err != nil {
+		return nil, errors.Annotatef(err, "invalid event timer data, timer key: %s", timer.Key)
+	}
+	return &Event{EventTimerData: &data, Timer: timer}, nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSchedule(t *testing.T) {
+	starts := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	recurring := &EventTimerData{
+		Schema:   "test",
+		Name:     "e1",
+		Interval: 90 * time.Second,
+		Starts:   starts,
+		Ends:     starts.Add(time.Hour),
+	}
+	require.NoError(t, recurring.Validate())
+	require.False(t, recurring.IsOneTime())
+	require.Equal(t, "1.5m", recurring.schedPolicyExpr())
+	require.Equal(t, starts.Add(-90*time.Second), recurring.initialWatermark())
+
+	next, ok := recurring.NextScheduleTime(recurring.initialWatermark())
+	require.True(t, ok)
+	require.Equal(t, starts, next)
+	next, ok = recurring.NextScheduleTime(starts)
+	require.True(t, ok)
+	require.Equal(t, starts.Add(90*time.Second), next)
+	next, ok = recurring.NextScheduleTime(starts.Add(100 * time.Second))
+	require.True(t, ok)
+	require.Equal(t, starts.Add(180*time.Second), next)
+	_, ok = recurring.NextScheduleTime(starts.Add(time.Hour))
+	require.False(t, ok)
+
+	require.Equal(t, starts, recurring.lastScheduleTime(starts.Add(-time.Minute)))
+	require.Equal(t, starts.Add(90*time.Second), recurring.lastScheduleTime(starts.Add(179*time.Second)))
+	require.Equal(t, starts.Add(180*time.Second), recurring.lastScheduleTime(starts.Add(180*time.Second)))
+
+	require.False(t, recurring.IsExpired(starts.Add(time.Hour)))
+	require.True(t, recurring.IsExpired(starts.Add(time.Hour+time.Second)))
+
+	recurring.Interval = 2 * time.Hour
+	require.Equal(t, "120m", recurring.schedPolicyExpr())
+	recurring.Ends = starts.Add(-time.Second)
+	require.Error(t, recurring.Validate())
+
+	executeAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	oneTime := &EventTimerData{Schema: "test", Name: "e2", ExecuteAt: executeAt}
+	require.NoError(t, oneTime.Validate())
+	require.True(t, oneTime.IsOneTime())
+	require.Equal(t, "1m", oneTime.schedPolicyExpr())
+	next, ok = oneTime.NextScheduleTime(oneTime.initialWatermark())
+	require.True(t, ok)
+	require.Equal(t, executeAt, next)
+	_, ok = oneTime.NextScheduleTime(executeAt)
+	require.False(t, ok)
+	require.Equal(t, executeAt, oneTime.lastScheduleTime(executeAt.Add(time.Hour)))
+	require.False(t, oneTime.IsExpired(executeAt))
+	require.True(t, oneTime.IsExpired(executeAt.Add(time.Second)))
+}
+
+func TestEventTimerKey(t *testing.T) {
+	require.Equal(t, "/tidb/event/test/e1", BuildTimerKey("Test", "E1"))
+	require.Equal(t, "/tidb/event/a%2Fb/c%2Fd", BuildTimerKey("a/b", "c/d"))
+	require.Equal(t, "/tidb/event/", buildTimerKeyPrefix(""))
+	require.Equal(t, "/tidb/event/test/", buildTimerKeyPrefix("TEST"))
+}
+
+func TestEventCRUD(t *testing.T) {
+	ctx := context.Background()
+	store := timerapi.NewMemoryTimerStore()
+	defer store.Close()
+	cli := timerapi.NewDefaultTimerClient(store)
+
+	starts := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	data := &EventTimerData{
+		Schema:   "test",
+		Name:     "e1",
+		Body:     "insert into t values (1)",
+		Interval: time.Minute,
+		Starts:   starts,
+	}
+	event, err := CreateEvent(ctx, cli, data, true)
+	require.NoError(t, err)
+	require.True(t, event.Enabled())
+	require.Equal(t, starts.Add(-time.Minute), event.Timer.Watermark)
+	require.Equal(t, timerHookClass, event.Timer.HookClass)
+	require.Equal(t, []string{"db=test"}, event.Timer.Tags)
+	require.True(t, event.LastExecuted().IsZero())
+
+	_, err = CreateEvent(ctx, cli, data, true)
+	require.True(t, errors.ErrorEqual(err, timerapi.ErrTimerExists))
+
+	_, err = CreateEvent(ctx, cli, &EventTimerData{Schema: "test2", Name: "e2", ExecuteAt: starts}, false)
+	require.NoError(t, err)
+
+	events, err := ListEvents(ctx, cli, "test")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, *data, *events[0].EventTimerData)
+	events, err = ListEvents(ctx, cli, "")
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	// update the body only, the watermark should be kept
+	require.NoError(t, cli.UpdateTimer(ctx, event.Timer.ID, timerapi.WithSetWatermark(starts.Add(time.Minute))))
+	event, err = GetEvent(ctx, cli, "test", "E1")
+	require.NoError(t, err)
+	require.Equal(t, starts.Add(time.Minute), event.LastExecuted())
+	event.Body = "insert into t values (2)"
+	require.NoError(t, UpdateEvent(ctx, cli, event, false, false))
+	event, err = GetEvent(ctx, cli, "test", "e1")
+	require.NoError(t, err)
+	require.False(t, event.Enabled())
+	require.Equal(t, "insert into t values (2)", event.Body)
+	require.Equal(t, starts.Add(time.Minute), event.Timer.Watermark)
+
+	// change the schedule, the watermark should be reset
+	event.Interval = 2 * time.Hour
+	require.NoError(t, UpdateEvent(ctx, cli, event, true, true))
+	event, err = GetEvent(ctx, cli, "test", "e1")
+	require.NoError(t, err)
+	require.True(t, event.Enabled())
+	require.Equal(t, "120m", event.Timer.SchedPolicyExpr)
+	require.Equal(t, starts.Add(-2*time.Hour), event.Timer.Watermark)
+
+	// rename
+	renamed := *event.EventTimerData
+	renamed.Name = "e3"
+	require.NoError(t, RenameEvent(ctx, cli, event, &renamed, true, false))
+	event, err = GetEvent(ctx, cli, "test", "e1")
+	require.NoError(t, err)
+	require.Nil(t, event)
+	event, err = GetEvent(ctx, cli, "test", "e3")
+	require.NoError(t, err)
+	require.Equal(t, starts.Add(-2*time.Hour), event.Timer.Watermark)
+
+	dropped, err := DropEvent(ctx, cli, "test", "e3")
+	require.NoError(t, err)
+	require.True(t, dropped)
+	dropped, err = DropEvent(ctx, cli, "test", "e3")
+	require.NoError(t, err)
+	require.False(t, dropped)
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ngaut/pools"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+const (
+	// HistoryStatusRunning means the event is being executed.
+	HistoryStatusRunning = "running"
+	// HistoryStatusSuccess means the event is executed successfully.
+	HistoryStatusSuccess = "success"
+	// HistoryStatusFailed means the execution of the event failed or was interrupted.
+	HistoryStatusFailed = "failed"
+
+	// historyRetention is how long the execution history is kept.
+	historyRetention  = 30 * 24 * time.Hour
+	historyGCInterval = time.Hour
+	historyGCBatch    = 1024
+
+	insertHistorySQL = "INSERT INTO mysql.tidb_event_history " +
+		"(event_id, timer_id, event_schema, event_name, definer, instance, start_time, status) " +
+		"VALUES (%?, %?, %?, %?, %?, %?, NOW(6), %?)"
+	updateHistorySQL = "UPDATE mysql.tidb_event_history " +
+		"SET end_time = NOW(6), status = %?, affected_rows = %?, error_message = %? WHERE event_id = %?"
+	selectHistoryStatusSQL = "SELECT status FROM mysql.tidb_event_history WHERE event_id = %?"
+	gcHistorySQL           = "DELETE FROM mysql.tidb_event_history WHERE start_time < DATE_SUB(NOW(6), INTERVAL %? SECOND) LIMIT %?"
+)
+
+type sessionPool interface {
+	Get() (pools.Resource, error)
+	Put(pools.Resource)
+}
+
+func executeSQL(ctx context.Context, pool sessionPool, sql string, args ...any) ([]chunk.Row, error) {
+	r, err := pool.Get()
+	if err != nil {
+		return nil, err
+	}
+	defer pool.Put(r)
+
+	sqlExec, ok := r.(sqlexec.SQLExecutor)
+	if !ok {
+		return nil, errors.Errorf("%T cannot be casted to sqlexec.SQLExecutor", r)
+	}
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalEventScheduler)
+	rs, err := sqlExec.ExecuteInternal(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if rs == nil {
+		return nil, nil
+	}
+
+	defer terror.Call(rs.Close)
+	return sqlexec.DrainRecordSet(ctx, rs, 8)
+}
+
+func insertHistory(ctx context.Context, pool sessionPool, eventID string, event *Event, instance string) error {
+	_, err := executeSQL(ctx, pool, insertHistorySQL,
+		eventID, event.Timer.ID, event.Schema, event.Name, event.Definer(), instance, HistoryStatusRunning)
+	return err
+}
+
+func finishHistory(ctx context.Context, pool sessionPool, eventID string, affectedRows uint64, execErr error) error {
+	status, errMsg := HistoryStatusSuccess, ""
+	if execErr != nil {
+		status, errMsg = HistoryStatusFailed, execErr.Error()
+	}
+	_, err := executeSQL(ctx, pool, updateHistorySQL, status, affectedRows, errMsg, eventID)
+	return err
+}
+
+// getHistoryStatus returns the status of the execution with the specified id, or empty if it does not exist.
+func getHistoryStatus(ctx context.Context, pool sessionPool, eventID string) (string, error) {
+	rows, err := executeSQL(ctx, pool, selectHistoryStatusSQL, eventID)
+	if err != nil || len(rows) == 0 {
+		return "", err
+	}
+	return rows[0].GetString(0), nil
+}
+
+func gcHistory(ctx context.Context, pool sessionPool) error {
+	_, err := executeSQL(ctx, pool, gcHistorySQL, int64(historyRetention/time.Second), historyGCBatch)
+	return err
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultCheckInterval is the delay of an event when it cannot be executed for now, for example,
+	// the global variable `event_scheduler` is OFF.
+	defaultCheckInterval = 5 * time.Second
+)
+
+// BodyExecutor executes the body of an event as its definer, and returns the affected rows.
+type BodyExecutor func(ctx context.Context, data *EventTimerData) (uint64, error)
+
+type eventTimerHook struct {
+	cli      timerapi.TimerClient
+	pool     sessionPool
+	executor BodyExecutor
+	instance string
+	ctx      context.Context
+	cancel   func()
+	wg       sync.WaitGroup
+	nowFunc  func() time.Time
+}
+
+func newEventTimerHook(cli timerapi.TimerClient, pool sessionPool, executor BodyExecutor, instance string) *eventTimerHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &eventTimerHook{
+		cli:      cli,
+		pool:     pool,
+		executor: executor,
+		instance: instance,
+		ctx:      ctx,
+		cancel:   cancel,
+		nowFunc:  time.Now,
+	}
+}
+
+func (h *eventTimerHook) Start() {}
+
+func (h *eventTimerHook) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (h *eventTimerHook) OnPreSchedEvent(ctx context.Context, e timerapi.TimerShedEvent) (r timerapi.PreSchedEventResult, err error) {
+	if !variable.EnableEventScheduler.Load() {
+		r.Delay = defaultCheckInterval
+		return
+	}
+
+	timer := e.Timer()
+	event, err := newEvent(timer)
+	if err != nil {
+		logutil.BgLogger().Error("invalid event timer data",
+			zap.String("timerID", timer.ID),
+			zap.String("timerKey", timer.Key),
+			zap.ByteString("data", timer.Data),
+		)
+		r.Delay = time.Minute
+		return r, nil
+	}
+
+	next, ok := event.NextScheduleTime(timer.Watermark)
+	if !ok {
+		// the event is expired, for example, the time of `ENDS` has passed.
+		if err = h.finishEvent(ctx, event); err != nil {
+			return
+		}
+		r.Delay = time.Minute
+		return
+	}
+
+	// the interval of the timer may be an approximate value, make sure the event is executed at the exact time.
+	if now := h.nowFunc(); now.Before(next) {
+		r.Delay = next.Sub(now)
+	}
+	return
+}
+
+func (h *eventTimerHook) OnSchedEvent(ctx context.Context, e timerapi.TimerShedEvent) error {
+	timer := e.Timer()
+	eventID := e.EventID()
+	logger := logutil.BgLogger().With(
+		zap.String("key", timer.Key),
+		zap.String("eventID", eventID),
+		zap.Time("eventStart", timer.EventStart),
+	)
+
+	if err := h.ctx.Err(); err != nil {
+		return err
+	}
+
+	event, err := newEvent(timer)
+	if err != nil {
+		logger.Error("invalid event timer data", zap.ByteString("data", timer.Data))
+		return err
+	}
+
+	status, err := getHistoryStatus(ctx, h.pool, eventID)
+	if err != nil {
+		return err
+	}
+
+	switch status {
+	case "":
+		if err = insertHistory(ctx, h.pool, eventID, event, h.instance); err != nil {
+			return err
+		}
+	case HistoryStatusRunning:
+		// The execution was interrupted, for example, the owner changed during the execution.
+		// We do not retry it because the body may be not idempotent.
+		logger.Warn("event execution was interrupted")
+		if err = finishHistory(ctx, h.pool, eventID, 0, errors.New("event execution was interrupted")); err != nil {
+			return err
+		}
+		return h.closeEvent(ctx, event, eventID)
+	default:
+		return h.closeEvent(ctx, event, eventID)
+	}
+
+	logger.Info("start to execute event", zap.String("schema", event.Schema), zap.String("name", event.Name))
+	h.wg.Add(1)
+	go h.executeEvent(logger, event, eventID)
+	return nil
+}
+
+func (h *eventTimerHook) executeEvent(logger *zap.Logger, event *Event, eventID string) {
+	defer h.wg.Done()
+
+	affectedRows, execErr := h.executor(h.ctx, event.EventTimerData)
+	if h.ctx.Err() != nil {
+		// leave the history and the timer event to be handled by the next owner.
+		logger.Info("stop executing event because of context cancelled")
+		return
+	}
+
+	if execErr != nil {
+		logger.Warn("fail to execute event", zap.Error(execErr))
+	} else {
+		logger.Info("event executed", zap.Uint64("affectedRows", affectedRows))
+	}
+
+	if err := finishHistory(h.ctx, h.pool, eventID, affectedRows, execErr); err != nil {
+		logger.Warn("fail to update event history", zap.Error(err))
+	}
+
+	if err := h.closeEvent(h.ctx, event, eventID); err != nil {
+		logger.Warn("fail to close event", zap.Error(err))
+	}
+}
+
+// closeEvent closes the current timer event, and drops or disables the event if it will never be executed again.
+func (h *eventTimerHook) closeEvent(ctx context.Context, event *Event, eventID string) error {
+	timer := event.Timer
+	watermark := event.lastScheduleTime(timer.EventStart)
+	if watermark.Before(timer.Watermark) {
+		watermark = timer.Watermark
+	}
+
+	if err := h.cli.CloseTimerEvent(ctx, timer.ID, eventID, timerapi.WithSetWatermark(watermark)); err != nil {
+		return err
+	}
+
+	if _, ok := event.NextScheduleTime(watermark); !ok {
+		return h.finishEvent(ctx, event)
+	}
+	return nil
+}
+
+// finishEvent handles the event which will never be executed again according to its `ON COMPLETION` clause.
+func (h *eventTimerHook) finishEvent(ctx context.Context, event *Event) error {
+	if event.Preserve {
+		if !event.Timer.Enable {
+			return nil
+		}
+		return h.cli.UpdateTimer(ctx, event.Timer.ID, timerapi.WithSetEnable(false))
+	}
+	_, err := h.cli.DeleteTimer(ctx, event.Timer.ID)
+	return err
+}
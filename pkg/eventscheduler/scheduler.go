@@ -0,0 +1,139 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	timerrt "github.com/pingcap/tidb/pkg/timer/runtime"
+	"github.com/pingcap/tidb/pkg/timer/tablestore"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+// Scheduler schedules the events created by `CREATE EVENT`.
+// The events are stored as timers, and they are only triggered in the owner of the cluster.
+type Scheduler struct {
+	id         string
+	pool       sessionPool
+	executor   BodyExecutor
+	leaderFunc func() bool
+	store      *timerapi.TimerStore
+	cli        timerapi.TimerClient
+
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+	// rt is only accessed in the loop goroutine.
+	rt *timerrt.TimerGroupRuntime
+}
+
+// NewScheduler creates a new event scheduler.
+func NewScheduler(id string, pool sessionPool, etcdCli *clientv3.Client, leaderFunc func() bool, executor BodyExecutor) *Scheduler {
+	store := tablestore.NewTableTimerStore(1, pool, "mysql", "tidb_timers", etcdCli)
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		id:         id,
+		pool:       pool,
+		executor:   executor,
+		leaderFunc: leaderFunc,
+		store:      store,
+		cli:        timerapi.NewDefaultTimerClient(store),
+		ctx:        logutil.WithKeyValue(ctx, "event-scheduler", id),
+		cancel:     cancel,
+	}
+}
+
+// TimerClient returns the client to manage the timers of events.
+func (s *Scheduler) TimerClient() timerapi.TimerClient {
+	return s.cli
+}
+
+// Start starts the scheduler.
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// Stop stops the scheduler and waits for it to exit.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+	s.store.Close()
+}
+
+func (s *Scheduler) isLeader() bool {
+	return s.leaderFunc != nil && s.leaderFunc()
+}
+
+func (s *Scheduler) loop() {
+	defer func() {
+		s.pause()
+		s.wg.Done()
+		logutil.Logger(s.ctx).Info("event scheduler loop exited")
+	}()
+
+	leaderTicker := time.NewTicker(time.Second)
+	defer leaderTicker.Stop()
+	gcTicker := time.NewTicker(historyGCInterval)
+	defer gcTicker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-leaderTicker.C:
+			if s.isLeader() {
+				s.resume()
+			} else {
+				s.pause()
+			}
+		case <-gcTicker.C:
+			if !s.isLeader() {
+				continue
+			}
+			if err := gcHistory(s.ctx, s.pool); err != nil {
+				logutil.Logger(s.ctx).Warn("fail to gc event history", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *Scheduler) resume() {
+	if s.rt != nil {
+		return
+	}
+
+	logutil.Logger(s.ctx).Info("start event timer runtime")
+	s.rt = timerrt.NewTimerRuntimeBuilder("event", s.store).
+		SetCond(&timerapi.TimerCond{Key: timerapi.NewOptionalVal(timerKeyPrefix), KeyPrefix: true}).
+		RegisterHookFactory(timerHookClass, func(hookClass string, cli timerapi.TimerClient) timerapi.Hook {
+			return newEventTimerHook(cli, s.pool, s.executor, s.id)
+		}).
+		Build()
+	s.rt.Start()
+}
+
+func (s *Scheduler) pause() {
+	if rt := s.rt; rt != nil {
+		logutil.Logger(s.ctx).Info("stop event timer runtime")
+		s.rt = nil
+		rt.Stop()
+	}
+}
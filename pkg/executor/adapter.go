@@ -54,6 +54,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/sessiontxn"
 	"github.com/pingcap/tidb/pkg/sessiontxn/staleread"
+	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/types"
 	util2 "github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/breakpoint"
@@ -556,8 +557,16 @@ func (a *ExecStmt) Exec(ctx context.Context) (_ sqlexec.RecordSet, err error) {
 	if variable.EnableResourceControl.Load() && domain.GetDomain(sctx).RunawayManager() != nil {
 		stmtCtx := sctx.GetSessionVars().StmtCtx
 		_, planDigest := GetPlanDigest(stmtCtx)
-		_, digest := stmtCtx.SQLDigest()
-		stmtCtx.RunawayChecker = domain.GetDomain(sctx).RunawayManager().DeriveChecker(sctx.GetSessionVars().StmtCtx.ResourceGroupName, stmtCtx.OriginalSQL, digest.String(), planDigest.String())
+		normalizedSQL, digest := stmtCtx.SQLDigest()
+		var user string
+		if u := sctx.GetSessionVars().User; u != nil {
+			user = u.Username
+		}
+		tables := make([]string, 0, len(stmtCtx.Tables))
+		for _, tbl := range stmtCtx.Tables {
+			tables = append(tables, strings.ToLower(tbl.DB+"."+tbl.Table))
+		}
+		stmtCtx.RunawayChecker = domain.GetDomain(sctx).RunawayManager().DeriveChecker(sctx.GetSessionVars().StmtCtx.ResourceGroupName, stmtCtx.OriginalSQL, normalizedSQL, digest.String(), planDigest.String(), user, tables)
 		if err := stmtCtx.RunawayChecker.BeforeExecutor(); err != nil {
 			return nil, err
 		}
@@ -1402,6 +1411,7 @@ func (a *ExecStmt) FinishExecuteStmt(txnTS uint64, err error, hasMoreResults boo
 	// `LowSlowQuery` and `SummaryStmt` must be called before recording `PrevStmt`.
 	a.LogSlowQuery(txnTS, succ, hasMoreResults)
 	a.SummaryStmt(succ)
+	a.recordCardinalityFeedback(succ)
 	a.observeStmtFinishedForTopSQL()
 	if sessVars.StmtCtx.IsTiFlash.Load() {
 		if succ {
@@ -1505,6 +1515,29 @@ func (a *ExecStmt) checkPlanReplayerCapture(txnTS uint64) {
 	}
 }
 
+// recordCardinalityFeedback compares the estimated and the actual row counts of the executed plan, and records the
+// large deviations to correct the later estimations and trigger analyze.
+func (a *ExecStmt) recordCardinalityFeedback(succ bool) {
+	sessVars := a.Ctx.GetSessionVars()
+	stmtCtx := sessVars.StmtCtx
+	if !succ || !sessVars.EnableCardinalityFeedback || sessVars.InRestrictedSQL || stmtCtx.RuntimeStatsColl == nil {
+		return
+	}
+	flat := getFlatPlan(stmtCtx)
+	if flat == nil {
+		return
+	}
+	deviations, predicates := plannercore.CollectCardinalityFeedback(a.Ctx, flat, stmtCtx.RuntimeStatsColl)
+	if len(deviations) == 0 && len(predicates) == 0 {
+		return
+	}
+	var digest string
+	if _, planDigest := GetPlanDigest(stmtCtx); planDigest != nil {
+		digest = planDigest.String()
+	}
+	statistics.GlobalCardinalityFeedback.Record(digest, deviations, predicates)
+}
+
 // CloseRecordSet will finish the execution of current statement and do some record work
 func (a *ExecStmt) CloseRecordSet(txnStartTS uint64, lastErr error) {
 	a.FinishExecuteStmt(txnStartTS, lastErr, false)
@@ -1994,6 +2027,8 @@ func (a *ExecStmt) SummaryStmt(succ bool) {
 		KeyspaceID:          keyspaceID,
 		RUDetail:            ruDetail,
 		ResourceGroupName:   sessVars.StmtCtx.ResourceGroupName,
+
+		PlanCacheUnqualified: sessVars.StmtCtx.PlanCacheUnqualified,
 	}
 	if a.retryCount > 0 {
 		stmtExecInfo.ExecRetryTime = costTime - sessVars.DurationParse - sessVars.DurationCompile - time.Since(a.retryStartTime)
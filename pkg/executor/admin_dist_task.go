@@ -0,0 +1,195 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+const (
+	minDistTaskPriority = 1
+	maxDistTaskPriority = 1024
+)
+
+// subtaskStatesInSummary is the order of subtask states shown in the SUBTASKS
+// column of ADMIN SHOW DIST TASKS.
+var subtaskStatesInSummary = []proto.SubtaskState{
+	proto.SubtaskStatePending,
+	proto.SubtaskStateRunning,
+	proto.SubtaskStatePaused,
+	proto.SubtaskStateSucceed,
+	proto.SubtaskStateFailed,
+	proto.SubtaskStateCanceled,
+	proto.SubtaskStateRevertPending,
+	proto.SubtaskStateReverting,
+	proto.SubtaskStateReverted,
+	proto.SubtaskStateRevertFailed,
+}
+
+// AdminShowDistTasksExec is an executor for ADMIN SHOW DIST TASKS.
+type AdminShowDistTasksExec struct {
+	exec.BaseExecutor
+
+	done bool
+}
+
+// Next implements the Executor Next interface.
+func (e *AdminShowDistTasksExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	taskManager, err := storage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	tasks, err := taskManager.GetAllTasks(ctx)
+	if err != nil {
+		return err
+	}
+	cntByTasks, err := taskManager.GetSubtaskCntOfAllTasksGroupByStates(ctx)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		cntByStates := cntByTasks[task.ID]
+		req.AppendInt64(0, task.ID)
+		req.AppendString(1, task.Key)
+		req.AppendString(2, string(task.Type))
+		req.AppendString(3, task.State.String())
+		req.AppendInt64(4, int64(task.Step))
+		req.AppendInt64(5, int64(task.Priority))
+		req.AppendInt64(6, int64(task.Concurrency))
+		req.AppendString(7, subtaskSummary(cntByStates))
+		appendDistTaskTime(req, 8, task.CreateTime)
+		appendDistTaskTime(req, 9, task.StartTime)
+		appendDistTaskTime(req, 10, task.StateUpdateTime)
+		if task.Error != nil {
+			req.AppendString(11, task.Error.Error())
+		} else {
+			req.AppendNull(11)
+		}
+	}
+	return nil
+}
+
+func subtaskSummary(cntByStates map[proto.SubtaskState]int64) string {
+	parts := make([]string, 0, len(cntByStates))
+	for _, state := range subtaskStatesInSummary {
+		if cnt := cntByStates[state]; cnt > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", state, cnt))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func appendDistTaskTime(req *chunk.Chunk, colIdx int, t time.Time) {
+	if t.IsZero() {
+		req.AppendNull(colIdx)
+		return
+	}
+	req.AppendTime(colIdx, types.NewTime(types.FromGoTime(t), mysql.TypeDatetime, types.DefaultFsp))
+}
+
+func (e *SimpleExec) executeAdminDistTask(ctx context.Context, s *ast.AdminStmt) error {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	taskManager, err := storage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	task, err := taskManager.GetTaskByID(ctx, s.DistTaskID)
+	if err != nil {
+		if errors.ErrorEqual(err, storage.ErrTaskNotFound) {
+			return errors.Errorf("distributed task %d not found", s.DistTaskID)
+		}
+		return err
+	}
+
+	var found bool
+	switch s.Tp {
+	case ast.AdminPauseDistTask:
+		found, err = taskManager.PauseTask(ctx, task.Key)
+	case ast.AdminResumeDistTask:
+		found, err = taskManager.ResumeTask(ctx, task.Key)
+	case ast.AdminCancelDistTask:
+		// CancelTask doesn't report whether the task is changed, so check the
+		// state before it, the scheduler will ignore the cancel if the task
+		// moved to other states in between.
+		if task.State == proto.TaskStatePending || task.State == proto.TaskStateRunning {
+			found = true
+			err = taskManager.CancelTask(ctx, task.ID)
+		}
+	case ast.AdminAlterDistTask:
+		found, err = e.alterDistTask(ctx, taskManager, task, s.DistTaskOptions)
+	}
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.Errorf("cannot %s distributed task %d in state %s", distTaskAction(s.Tp), task.ID, task.State)
+	}
+	handle.NotifyTaskChange()
+	return nil
+}
+
+func (*SimpleExec) alterDistTask(ctx context.Context, taskManager *storage.TaskManager, task *proto.Task, opts []*ast.DistTaskOption) (bool, error) {
+	concurrency, priority := task.Concurrency, task.Priority
+	for _, opt := range opts {
+		switch opt.Tp {
+		case ast.DistTaskOptionConcurrency:
+			concurrency = int(opt.UintValue)
+		case ast.DistTaskOptionPriority:
+			priority = int(opt.UintValue)
+		}
+	}
+	if priority < minDistTaskPriority || priority > maxDistTaskPriority {
+		return false, errors.Errorf("distributed task priority should be in range [%d, %d]", minDistTaskPriority, maxDistTaskPriority)
+	}
+	if concurrency <= 0 {
+		return false, errors.New("distributed task concurrency should be positive")
+	}
+	// the concurrency is checked against the managed nodes by ModifyTask.
+	return taskManager.ModifyTask(ctx, task.ID, concurrency, priority)
+}
+
+func distTaskAction(tp ast.AdminStmtType) string {
+	switch tp {
+	case ast.AdminPauseDistTask:
+		return "pause"
+	case ast.AdminResumeDistTask:
+		return "resume"
+	case ast.AdminCancelDistTask:
+		return "cancel"
+	default:
+		return "alter"
+	}
+}
@@ -105,6 +105,10 @@ func (e *AnalyzeExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 		return nil
 	}
 
+	if err := e.collectSamplesInDist(ctx, tasks); err != nil {
+		return err
+	}
+
 	// Get the min number of goroutines for parallel execution.
 	concurrency, err := getBuildStatsConcurrency(e.Ctx())
 	if err != nil {
@@ -59,6 +59,11 @@ type AnalyzeColumnsExec struct {
 	baseCount               int64
 	baseModifyCnt           int64
 
+	// distSampleCollectors are the marshaled tipb.RowSampleCollector collected by the
+	// subtasks of the distributed ANALYZE task, it's nil when the samples are collected
+	// by this node.
+	distSampleCollectors [][]byte
+
 	memTracker *memory.Tracker
 }
 
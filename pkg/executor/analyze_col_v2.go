@@ -258,92 +258,18 @@ func (e *AnalyzeColumnsExecV2) buildSamplingStats(
 	extStats *statistics.ExtendedStatsColl,
 	err error,
 ) {
-	// Open memory tracker and resultHandler.
-	if err = e.open(ranges); err != nil {
-		return 0, nil, nil, nil, nil, err
-	}
-	defer func() {
-		if err1 := e.resultHandler.Close(); err1 != nil {
-			err = err1
-		}
-	}()
-
-	l := len(e.analyzePB.ColReq.ColumnsInfo) + len(e.analyzePB.ColReq.ColumnGroups)
-	rootRowCollector := statistics.NewRowSampleCollector(int(e.analyzePB.ColReq.SampleSize), e.analyzePB.ColReq.GetSampleRate(), l)
-	for i := 0; i < l; i++ {
-		rootRowCollector.Base().FMSketches = append(rootRowCollector.Base().FMSketches, statistics.NewFMSketch(maxSketchSize))
-	}
-
-	sc := e.ctx.GetSessionVars().StmtCtx
-
-	// Start workers to merge the result from collectors.
-	mergeResultCh := make(chan *samplingMergeResult, 1)
-	mergeTaskCh := make(chan []byte, 1)
-	var taskEg errgroup.Group
-	// Start read data from resultHandler and send them to mergeTaskCh.
-	taskEg.Go(func() (err error) {
-		defer func() {
-			if r := recover(); r != nil {
-				err = getAnalyzePanicErr(r)
-			}
-		}()
-		return readDataAndSendTask(e.ctx, e.resultHandler, mergeTaskCh, e.memTracker)
-	})
-	e.samplingMergeWg = &util.WaitGroupWrapper{}
-	e.samplingMergeWg.Add(samplingStatsConcurrency)
-	for i := 0; i < samplingStatsConcurrency; i++ {
-		id := i
-		gp.Go(func() {
-			e.subMergeWorker(mergeResultCh, mergeTaskCh, l, id)
-		})
-	}
-	// Merge the result from collectors.
-	mergeWorkerPanicCnt := 0
-	mergeEg, mergeCtx := errgroup.WithContext(context.Background())
-	mergeEg.Go(func() (err error) {
-		defer func() {
-			if r := recover(); r != nil {
-				err = getAnalyzePanicErr(r)
-			}
-		}()
-		for mergeWorkerPanicCnt < samplingStatsConcurrency {
-			mergeResult, ok := <-mergeResultCh
-			if !ok {
-				break
-			}
-			if mergeResult.err != nil {
-				err = mergeResult.err
-				if isAnalyzeWorkerPanic(mergeResult.err) {
-					mergeWorkerPanicCnt++
-				}
-				continue
-			}
-			oldRootCollectorSize := rootRowCollector.Base().MemSize
-			oldRootCollectorCount := rootRowCollector.Base().Count
-			// Merge the result from sub-collectors.
-			rootRowCollector.MergeCollector(mergeResult.collector)
-			newRootCollectorCount := rootRowCollector.Base().Count
-			printAnalyzeMergeCollectorLog(oldRootCollectorCount, newRootCollectorCount,
-				mergeResult.collector.Base().Count, e.tableID.TableID, e.tableID.PartitionID, e.tableID.IsPartitionTable(),
-				"merge subMergeWorker in AnalyzeColumnsExecV2", -1)
-			e.memTracker.Consume(rootRowCollector.Base().MemSize - oldRootCollectorSize - mergeResult.collector.Base().MemSize)
-			mergeResult.collector.DestroyAndPutToPool()
-		}
-		return err
-	})
-	err = taskEg.Wait()
-	if err != nil {
-		mergeCtx.Done()
-		if err1 := mergeEg.Wait(); err1 != nil {
-			err = stderrors.Join(err, err1)
-		}
-		return 0, nil, nil, nil, nil, getAnalyzePanicErr(err)
+	var rootRowCollector statistics.RowSampleCollector
+	if e.distSampleCollectors != nil {
+		rootRowCollector, err = e.mergeDistSampleCollectors()
+	} else {
+		rootRowCollector, err = e.collectSamples(gp, ranges, samplingStatsConcurrency)
 	}
-	err = mergeEg.Wait()
-	defer e.memTracker.Release(rootRowCollector.Base().MemSize)
 	if err != nil {
 		return 0, nil, nil, nil, nil, err
 	}
+	defer e.memTracker.Release(rootRowCollector.Base().MemSize)
+
+	sc := e.ctx.GetSessionVars().StmtCtx
 
 	// Decode the data from sample collectors.
 	virtualColIdx := buildVirtualColumnIndex(e.schemaForVirtualColEval, e.colsInfo)
@@ -477,6 +403,127 @@ func (e *AnalyzeColumnsExecV2) buildSamplingStats(
 	return
 }
 
+// collectSamples sends the analyze requests of the ranges to the storage, and merges the returned sample collectors.
+func (e *AnalyzeColumnsExecV2) collectSamples(gp *gp.Pool, ranges []*ranger.Range, samplingStatsConcurrency int) (_ statistics.RowSampleCollector, err error) {
+	// Open memory tracker and resultHandler.
+	if err = e.open(ranges); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err1 := e.resultHandler.Close(); err1 != nil {
+			err = err1
+		}
+	}()
+
+	l := len(e.analyzePB.ColReq.ColumnsInfo) + len(e.analyzePB.ColReq.ColumnGroups)
+	rootRowCollector := newRootRowSampleCollector(e.analyzePB.ColReq)
+
+	// Start workers to merge the result from collectors.
+	mergeResultCh := make(chan *samplingMergeResult, 1)
+	mergeTaskCh := make(chan []byte, 1)
+	var taskEg errgroup.Group
+	// Start read data from resultHandler and send them to mergeTaskCh.
+	taskEg.Go(func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = getAnalyzePanicErr(r)
+			}
+		}()
+		return readDataAndSendTask(e.ctx, e.resultHandler, mergeTaskCh, e.memTracker)
+	})
+	e.samplingMergeWg = &util.WaitGroupWrapper{}
+	e.samplingMergeWg.Add(samplingStatsConcurrency)
+	for i := 0; i < samplingStatsConcurrency; i++ {
+		id := i
+		gp.Go(func() {
+			e.subMergeWorker(mergeResultCh, mergeTaskCh, l, id)
+		})
+	}
+	// Merge the result from collectors.
+	mergeWorkerPanicCnt := 0
+	mergeEg, mergeCtx := errgroup.WithContext(context.Background())
+	mergeEg.Go(func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = getAnalyzePanicErr(r)
+			}
+		}()
+		for mergeWorkerPanicCnt < samplingStatsConcurrency {
+			mergeResult, ok := <-mergeResultCh
+			if !ok {
+				break
+			}
+			if mergeResult.err != nil {
+				err = mergeResult.err
+				if isAnalyzeWorkerPanic(mergeResult.err) {
+					mergeWorkerPanicCnt++
+				}
+				continue
+			}
+			oldRootCollectorSize := rootRowCollector.Base().MemSize
+			oldRootCollectorCount := rootRowCollector.Base().Count
+			// Merge the result from sub-collectors.
+			rootRowCollector.MergeCollector(mergeResult.collector)
+			newRootCollectorCount := rootRowCollector.Base().Count
+			printAnalyzeMergeCollectorLog(oldRootCollectorCount, newRootCollectorCount,
+				mergeResult.collector.Base().Count, e.tableID.TableID, e.tableID.PartitionID, e.tableID.IsPartitionTable(),
+				"merge subMergeWorker in AnalyzeColumnsExecV2", -1)
+			e.memTracker.Consume(rootRowCollector.Base().MemSize - oldRootCollectorSize - mergeResult.collector.Base().MemSize)
+			mergeResult.collector.DestroyAndPutToPool()
+		}
+		return err
+	})
+	err = taskEg.Wait()
+	if err != nil {
+		mergeCtx.Done()
+		if err1 := mergeEg.Wait(); err1 != nil {
+			err = stderrors.Join(err, err1)
+		}
+		return nil, getAnalyzePanicErr(err)
+	}
+	if err = mergeEg.Wait(); err != nil {
+		e.memTracker.Release(rootRowCollector.Base().MemSize)
+		return nil, err
+	}
+	return rootRowCollector, nil
+}
+
+// mergeDistSampleCollectors merges the sample collectors collected by the subtasks of the distributed ANALYZE task.
+func (e *AnalyzeColumnsExecV2) mergeDistSampleCollectors() (statistics.RowSampleCollector, error) {
+	e.memTracker = memory.NewTracker(int(e.ctx.GetSessionVars().PlanID.Load()), -1)
+	e.memTracker.AttachTo(e.ctx.GetSessionVars().StmtCtx.MemTracker)
+	colReq := e.analyzePB.ColReq
+	l := len(colReq.ColumnsInfo) + len(colReq.ColumnGroups)
+	rootRowCollector := newRootRowSampleCollector(colReq)
+	for _, data := range e.distSampleCollectors {
+		pbCollector := &tipb.RowSampleCollector{}
+		if err := pbCollector.Unmarshal(data); err != nil {
+			e.memTracker.Release(e.memTracker.BytesConsumed())
+			return nil, err
+		}
+		subCollector := statistics.NewRowSampleCollector(int(colReq.SampleSize), colReq.GetSampleRate(), l)
+		subCollector.Base().FromProto(pbCollector, e.memTracker)
+		UpdateAnalyzeJob(e.ctx, e.job, subCollector.Base().Count)
+
+		oldRootCollectorSize := rootRowCollector.Base().MemSize
+		rootRowCollector.MergeCollector(subCollector)
+		e.memTracker.Consume(rootRowCollector.Base().MemSize - oldRootCollectorSize - subCollector.Base().MemSize)
+		subCollector.DestroyAndPutToPool()
+	}
+	// the collectors are consumed, the retry of analyze will collect the samples locally.
+	e.distSampleCollectors = nil
+	return rootRowCollector, nil
+}
+
+func newRootRowSampleCollector(colReq *tipb.AnalyzeColumnsReq) statistics.RowSampleCollector {
+	l := len(colReq.ColumnsInfo) + len(colReq.ColumnGroups)
+	collector := statistics.NewRowSampleCollector(int(colReq.SampleSize), colReq.GetSampleRate(), l)
+	for i := 0; i < l; i++ {
+		collector.Base().FMSketches = append(collector.Base().FMSketches, statistics.NewFMSketch(maxSketchSize))
+	}
+	return collector
+}
+
 // handleNDVForSpecialIndexes deals with the logic to analyze the index containing the virtual column when the mode is full sampling.
 func (e *AnalyzeColumnsExecV2) handleNDVForSpecialIndexes(indexInfos []*model.IndexInfo, totalResultCh chan analyzeIndexNDVTotalResult, statsConcurrncy int) {
 	defer func() {
@@ -0,0 +1,219 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// The distributed ANALYZE only distributes the most expensive part of the full
+// sampling analyze, i.e. scanning the table to collect the samples, FMSketches,
+// null counts and total sizes of the columns and column groups:
+//  1. the node running ANALYZE submits a task, with the analyze request of each
+//     physical table in the task meta.
+//  2. the scheduler splits the tables or partitions by regions into subtasks.
+//  3. the task executors send the analyze request of the key range of the subtask
+//     to the storage, and merge the returned sample collectors into one, which is
+//     written to the cloud storage of tidb_cloud_storage_uri, only the file name
+//     is saved in the subtask meta because the samples may be too large for it.
+//  4. after the task succeed, the node running ANALYZE merges the collectors of
+//     each physical table, and builds the histograms and TopN from the merged
+//     samples as the local analyze does, then the stats are saved and merged into
+//     global stats by the analyze result handler.
+const (
+	// analyzeStepCollectSamples is the step of collecting samples of the key ranges.
+	analyzeStepCollectSamples proto.Step = 1
+)
+
+// analyzeTaskMeta is the dist task meta of collecting samples for ANALYZE.
+type analyzeTaskMeta struct {
+	PhysicalTables    []analyzePhysicalTableMeta `json:"physical_tables"`
+	IsolationLevel    kv.IsoLevel                `json:"isolation_level"`
+	ResourceGroupName string                     `json:"resource_group_name"`
+	// CloudStorageURI is the storage to save the collected samples.
+	CloudStorageURI string `json:"cloud_storage_uri"`
+}
+
+func (m *analyzeTaskMeta) getPhysicalTable(physicalID int64) *analyzePhysicalTableMeta {
+	for i := range m.PhysicalTables {
+		if m.PhysicalTables[i].PhysicalID == physicalID {
+			return &m.PhysicalTables[i]
+		}
+	}
+	return nil
+}
+
+// analyzePhysicalTableMeta is the meta of a table or partition to analyze.
+type analyzePhysicalTableMeta struct {
+	PhysicalID int64 `json:"physical_id"`
+	// AnalyzeReq is the marshaled tipb.AnalyzeReq of the full sampling analyze.
+	AnalyzeReq []byte `json:"analyze_req"`
+	StartTS    uint64 `json:"start_ts"`
+}
+
+// analyzeSubtaskMeta is the subtask meta of collecting samples for ANALYZE.
+type analyzeSubtaskMeta struct {
+	PhysicalID int64  `json:"physical_id"`
+	StartKey   kv.Key `json:"start_key"`
+	EndKey     kv.Key `json:"end_key"`
+	// RowCollectorFile is the file in the cloud storage of the marshaled
+	// tipb.RowSampleCollector of the rows in the key range, it's filled when the
+	// subtask is finished.
+	RowCollectorFile string `json:"row_collector_file,omitempty"`
+}
+
+// analyzeRowCollectorFile returns the file name of the samples collected by the
+// subtask, the files of a task are under the directory of the task key.
+func analyzeRowCollectorFile(taskKey string, subtaskID int64) string {
+	return path.Join(taskKey, strconv.FormatInt(subtaskID, 10))
+}
+
+// newAnalyzeCloudStorage opens the storage to save the collected samples.
+func newAnalyzeCloudStorage(ctx context.Context, uri string) (storage.ExternalStorage, error) {
+	backend, err := storage.ParseBackend(uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	return storage.NewWithDefaultOpt(ctx, backend)
+}
+
+// collectSamplesInDist collects the samples of the full sampling column tasks by
+// the distributed execution framework if it's enabled and tidb_cloud_storage_uri
+// is set, the collected samples are set into the column executors, and merged by
+// the analyze workers later.
+func (e *AnalyzeExec) collectSamplesInDist(ctx context.Context, tasks []*analyzeTask) error {
+	sessVars := e.Ctx().GetSessionVars()
+	if !sessVars.EnableDistAnalyze || !variable.EnableDistTask.Load() {
+		return nil
+	}
+	cloudStorageURI := variable.CloudStorageURI.Load()
+	if cloudStorageURI == "" {
+		return nil
+	}
+
+	taskMeta := &analyzeTaskMeta{
+		IsolationLevel:    kv.RC,
+		ResourceGroupName: sessVars.StmtCtx.ResourceGroupName,
+		CloudStorageURI:   cloudStorageURI,
+	}
+	if sessVars.EnableAnalyzeSnapshot {
+		taskMeta.IsolationLevel = kv.SI
+	}
+	colExecs := make(map[int64]*AnalyzeColumnsExec, len(tasks))
+	concurrency := 1
+	for _, task := range tasks {
+		colExec := task.colExec
+		if task.taskType != colTask || colExec.StatsVersion < statistics.Version2 || colExec.analyzePB.ColReq == nil {
+			continue
+		}
+		req, err := colExec.analyzePB.Marshal()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		startTS := uint64(math.MaxUint64)
+		if sessVars.EnableAnalyzeSnapshot {
+			startTS = colExec.snapshot
+		}
+		physicalID := colExec.tableID.GetStatisticsID()
+		taskMeta.PhysicalTables = append(taskMeta.PhysicalTables, analyzePhysicalTableMeta{
+			PhysicalID: physicalID,
+			AnalyzeReq: req,
+			StartTS:    startTS,
+		})
+		colExecs[physicalID] = colExec
+		concurrency = max(concurrency, colExec.concurrency)
+	}
+	if len(colExecs) == 0 {
+		return nil
+	}
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	metaBytes, err := json.Marshal(taskMeta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	extStore, err := newAnalyzeCloudStorage(ctx, cloudStorageURI)
+	if err != nil {
+		return err
+	}
+	first := taskMeta.PhysicalTables[0]
+	taskKey := fmt.Sprintf("analyze/%d/%d", first.PhysicalID, colExecs[first.PhysicalID].snapshot)
+	// the samples are read into memory after the task finishes, so the files
+	// are removed no matter whether the task succeeds.
+	defer cleanUpAnalyzeFiles(ctx, extStore, taskKey)
+	task, err := submitAndWaitDistTask(ctx, sessVars, taskKey, proto.Analyze, concurrency, metaBytes)
+	if err != nil {
+		return err
+	}
+	subtasks, err := getDistSubtasks(ctx, task.ID, analyzeStepCollectSamples)
+	if err != nil {
+		return err
+	}
+	for _, colExec := range colExecs {
+		// set it to non-nil, so the table without subtasks won't collect samples again.
+		colExec.distSampleCollectors = make([][]byte, 0, 1)
+	}
+	for _, subtask := range subtasks {
+		subtaskMeta := &analyzeSubtaskMeta{}
+		if err := json.Unmarshal(subtask.Meta, subtaskMeta); err != nil {
+			return errors.Trace(err)
+		}
+		colExec, ok := colExecs[subtaskMeta.PhysicalID]
+		if !ok {
+			return errors.Errorf("unknown physical table %d of analyze subtask %d", subtaskMeta.PhysicalID, subtask.ID)
+		}
+		data, err := extStore.ReadFile(ctx, subtaskMeta.RowCollectorFile)
+		if err != nil {
+			return err
+		}
+		colExec.distSampleCollectors = append(colExec.distSampleCollectors, data)
+	}
+	return nil
+}
+
+func cleanUpAnalyzeFiles(ctx context.Context, extStore storage.ExternalStorage, taskKey string) {
+	var files []string
+	err := extStore.WalkDir(ctx, &storage.WalkOption{SubDir: taskKey}, func(path string, _ int64) error {
+		files = append(files, path)
+		return nil
+	})
+	if err == nil {
+		err = extStore.DeleteFiles(ctx, files)
+	}
+	if err != nil {
+		logutil.Logger(ctx).Warn("clean up analyze sample files failed", zap.String("task-key", taskKey), zap.Error(err))
+	}
+}
+
+func init() {
+	scheduler.RegisterSchedulerFactory(proto.Analyze, newAnalyzeScheduler)
+	taskexecutor.RegisterTaskType(proto.Analyze, newAnalyzeDistExecutor)
+}
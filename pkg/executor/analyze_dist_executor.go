@@ -0,0 +1,211 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	brstorage "github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/distsql"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/execute"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	"github.com/pingcap/tipb/go-tipb"
+	"go.uber.org/zap"
+)
+
+// analyzeDistExecutor is the task executor of the distributed ANALYZE.
+type analyzeDistExecutor struct {
+	*taskexecutor.BaseTaskExecutor
+}
+
+var _ taskexecutor.Extension = (*analyzeDistExecutor)(nil)
+
+func newAnalyzeDistExecutor(ctx context.Context, id string, task *proto.Task, taskTable taskexecutor.TaskTable) taskexecutor.TaskExecutor {
+	e := &analyzeDistExecutor{
+		BaseTaskExecutor: taskexecutor.NewBaseTaskExecutor(ctx, id, task, taskTable),
+	}
+	e.BaseTaskExecutor.Extension = e
+	return e
+}
+
+// IsIdempotent implements taskexecutor.Extension interface.
+func (*analyzeDistExecutor) IsIdempotent(*proto.Subtask) bool {
+	return true
+}
+
+// GetSubtaskExecutor implements taskexecutor.Extension interface.
+func (*analyzeDistExecutor) GetSubtaskExecutor(ctx context.Context, task *proto.Task, _ *execute.Summary) (execute.SubtaskExecutor, error) {
+	if task.Step != analyzeStepCollectSamples {
+		return nil, errors.Errorf("unknown analyze step %d for task %d", task.Step, task.ID)
+	}
+	taskMeta := &analyzeTaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	extStore, err := newAnalyzeCloudStorage(ctx, taskMeta.CloudStorageURI)
+	if err != nil {
+		return nil, err
+	}
+	return &analyzeSampleExecutor{
+		taskMeta:    taskMeta,
+		taskKey:     task.Key,
+		concurrency: task.Concurrency,
+		extStore:    extStore,
+	}, nil
+}
+
+// IsRetryableError implements taskexecutor.Extension interface.
+func (*analyzeDistExecutor) IsRetryableError(err error) bool {
+	return isRetryableDistTaskError(err)
+}
+
+// analyzeSampleExecutor collects the samples of the key range of a subtask.
+type analyzeSampleExecutor struct {
+	taskexecutor.EmptySubtaskExecutor
+	taskMeta    *analyzeTaskMeta
+	taskKey     string
+	concurrency int
+	extStore    brstorage.ExternalStorage
+
+	// rowCollectorFile is the file of the samples of the last run subtask.
+	rowCollectorFile string
+}
+
+// RunSubtask implements execute.SubtaskExecutor interface.
+func (e *analyzeSampleExecutor) RunSubtask(ctx context.Context, subtask *proto.Subtask) error {
+	subtaskMeta := &analyzeSubtaskMeta{}
+	if err := json.Unmarshal(subtask.Meta, subtaskMeta); err != nil {
+		return errors.Trace(err)
+	}
+	tblMeta := e.taskMeta.getPhysicalTable(subtaskMeta.PhysicalID)
+	if tblMeta == nil {
+		return errors.Errorf("physical table %d of analyze subtask %d not found in task meta", subtaskMeta.PhysicalID, subtask.ID)
+	}
+	analyzeReq := &tipb.AnalyzeReq{}
+	if err := analyzeReq.Unmarshal(tblMeta.AnalyzeReq); err != nil {
+		return errors.Trace(err)
+	}
+	taskManager, err := storage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	return taskManager.WithNewSession(func(se sessionctx.Context) error {
+		collector, err := e.collectSamples(ctx, se, analyzeReq, tblMeta.StartTS, subtaskMeta)
+		if err != nil {
+			return err
+		}
+		defer collector.DestroyAndPutToPool()
+		pbCollector := collector.Base().ToProto()
+		data, err := pbCollector.Marshal()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// the file is named by the subtask, so a rerun subtask overwrites it.
+		fileName := analyzeRowCollectorFile(e.taskKey, subtask.ID)
+		if err := e.extStore.WriteFile(ctx, fileName, data); err != nil {
+			return err
+		}
+		e.rowCollectorFile = fileName
+		logutil.Logger(ctx).Info("analyze subtask collected samples",
+			zap.Int64("subtask-id", subtask.ID), zap.Int64("physical-id", subtaskMeta.PhysicalID),
+			zap.Int64("count", collector.Base().Count))
+		return nil
+	})
+}
+
+func (e *analyzeSampleExecutor) collectSamples(
+	ctx context.Context,
+	se sessionctx.Context,
+	analyzeReq *tipb.AnalyzeReq,
+	startTS uint64,
+	subtaskMeta *analyzeSubtaskMeta,
+) (statistics.RowSampleCollector, error) {
+	sessVars := se.GetSessionVars()
+	memTracker := memory.NewTracker(memory.LabelForAnalyzeMemory, -1)
+	var builder distsql.RequestBuilder
+	// Always set KeepOrder of the request to be true, in order to compute
+	// correct `correlation` of columns.
+	kvReq, err := builder.
+		SetKeyRanges([]kv.KeyRange{{StartKey: subtaskMeta.StartKey, EndKey: subtaskMeta.EndKey}}).
+		SetAnalyzeRequest(analyzeReq, e.taskMeta.IsolationLevel).
+		SetStartTS(startTS).
+		SetKeepOrder(true).
+		SetConcurrency(e.concurrency).
+		SetMemTracker(memTracker).
+		SetResourceGroupName(e.taskMeta.ResourceGroupName).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	result, err := distsql.Analyze(ctx, se.GetClient(), kvReq, sessVars.KVVars, true, sessVars.StmtCtx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err1 := result.Close(); err1 != nil {
+			logutil.Logger(ctx).Warn("close analyze result failed", zap.Error(err1))
+		}
+	}()
+
+	colReq := analyzeReq.ColReq
+	l := len(colReq.ColumnsInfo) + len(colReq.ColumnGroups)
+	rootCollector := newRootRowSampleCollector(colReq)
+	for {
+		data, err := result.NextRaw(ctx)
+		if err != nil {
+			rootCollector.DestroyAndPutToPool()
+			return nil, err
+		}
+		if data == nil {
+			break
+		}
+		colResp := &tipb.AnalyzeColumnsResp{}
+		if err := colResp.Unmarshal(data); err != nil {
+			rootCollector.DestroyAndPutToPool()
+			return nil, errors.Trace(err)
+		}
+		subCollector := statistics.NewRowSampleCollector(int(colReq.SampleSize), colReq.GetSampleRate(), l)
+		subCollector.Base().FromProto(colResp.RowCollector, memTracker)
+		rootCollector.MergeCollector(subCollector)
+		subCollector.DestroyAndPutToPool()
+	}
+	return rootCollector, nil
+}
+
+// OnFinished implements execute.SubtaskExecutor interface.
+func (e *analyzeSampleExecutor) OnFinished(_ context.Context, subtask *proto.Subtask) error {
+	subtaskMeta := &analyzeSubtaskMeta{}
+	if err := json.Unmarshal(subtask.Meta, subtaskMeta); err != nil {
+		return errors.Trace(err)
+	}
+	subtaskMeta.RowCollectorFile = e.rowCollectorFile
+	e.rowCollectorFile = ""
+	meta, err := json.Marshal(subtaskMeta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	subtask.Meta = meta
+	return nil
+}
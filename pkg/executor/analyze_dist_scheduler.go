@@ -0,0 +1,59 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+)
+
+func newAnalyzeScheduler(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
+	return newRegionSplitScheduler(ctx, task, param, &regionSplitSchedulerExt{
+		steps:     []proto.Step{analyzeStepCollectSamples},
+		getRanges: getAnalyzeRanges,
+	})
+}
+
+// getAnalyzeRanges gets the records of each physical table of the distributed
+// ANALYZE, which are split into subtasks by regions.
+func getAnalyzeRanges(task *proto.Task) ([]regionSplitRange, error) {
+	taskMeta := &analyzeTaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ranges := make([]regionSplitRange, 0, len(taskMeta.PhysicalTables))
+	for _, tbl := range taskMeta.PhysicalTables {
+		physicalID := tbl.PhysicalID
+		startKey := tablecodec.GenTableRecordPrefix(physicalID)
+		ranges = append(ranges, regionSplitRange{
+			startKey: startKey,
+			endKey:   startKey.PrefixNext(),
+			newSubtaskMeta: func(r kv.KeyRange) any {
+				return &analyzeSubtaskMeta{
+					PhysicalID: physicalID,
+					StartKey:   r.StartKey,
+					EndKey:     r.EndKey,
+				}
+			},
+		})
+	}
+	return ranges, nil
+}
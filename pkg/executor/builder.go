@@ -37,6 +37,7 @@ import (
 	"github.com/pingcap/tidb/pkg/ddl/placement"
 	"github.com/pingcap/tidb/pkg/distsql"
 	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/domain/resourcegroup"
 	"github.com/pingcap/tidb/pkg/executor/aggfuncs"
 	"github.com/pingcap/tidb/pkg/executor/aggregate"
 	"github.com/pingcap/tidb/pkg/executor/internal/builder"
@@ -84,6 +85,7 @@ import (
 	"github.com/tikv/client-go/v2/tikv"
 	"github.com/tikv/client-go/v2/txnkv"
 	"github.com/tikv/client-go/v2/txnkv/txnsnapshot"
+	kvutil "github.com/tikv/client-go/v2/util"
 )
 
 // executorBuilder builds an Executor from a Plan.
@@ -312,6 +314,8 @@ func (b *executorBuilder) build(p plannercore.Plan) exec.Executor {
 		return b.buildCompactTable(v)
 	case *plannercore.AdminShowBDRRole:
 		return b.buildAdminShowBDRRole(v)
+	case *plannercore.AdminShowDistTasks:
+		return b.buildAdminShowDistTasks(v)
 	default:
 		if mp, ok := p.(testutil.MockPhysicalPlan); ok {
 			return mp.GetExecutor()
@@ -499,6 +503,19 @@ func (b *executorBuilder) buildCheckTable(v *plannercore.CheckTable) exec.Execut
 			break
 		}
 	}
+	if !v.CheckIndex && distAdminCheckEnabled(b.ctx) && canCheckTableInDist(v.Table.Meta(), v.IndexInfos) {
+		startTS, err := b.getSnapshotTS()
+		if err != nil {
+			b.err = err
+			return nil
+		}
+		return &DistCheckTableExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+			table:        v.Table,
+			indexInfos:   v.IndexInfos,
+			startTS:      startTS,
+		}
+	}
 	if b.ctx.GetSessionVars().FastCheckTable && noMVIndexOrPrefixIndex {
 		e := &FastCheckTableExec{
 			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
@@ -2107,7 +2124,9 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.ClusterTableMemoryUsage),
 			strings.ToLower(infoschema.ClusterTableMemoryUsageOpsHistory),
 			strings.ToLower(infoschema.TableResourceGroups),
+			strings.ToLower(infoschema.TableResourceGroupBackgroundUsage),
 			strings.ToLower(infoschema.TableRunawayWatches),
+			strings.ToLower(infoschema.TableEvents),
 			strings.ToLower(infoschema.TableCheckConstraints),
 			strings.ToLower(infoschema.TableTiDBCheckConstraints),
 			strings.ToLower(infoschema.TableKeywords):
@@ -2909,6 +2928,9 @@ func (b *executorBuilder) buildAnalyze(v *plannercore.Analyze) exec.Executor {
 	if b.ctx.GetSessionVars().InRestrictedSQL {
 		autoAnalyze = "auto "
 	}
+	// run in the resource group which the stats background tasks are assigned to if any.
+	stmtCtx := b.ctx.GetSessionVars().StmtCtx
+	stmtCtx.ResourceGroupName = resourcegroup.GetBackgroundGroupName(b.is.AllResourceGroups(), kvutil.ExplicitTypeStats, stmtCtx.ResourceGroupName)
 	for _, task := range v.ColTasks {
 		columns, _, err := expression.ColumnInfos2ColumnsAndNames(
 			b.ctx,
@@ -5496,3 +5518,7 @@ func (b *executorBuilder) buildCompactTable(v *plannercore.CompactTable) exec.Ex
 func (b *executorBuilder) buildAdminShowBDRRole(v *plannercore.AdminShowBDRRole) exec.Executor {
 	return &AdminShowBDRRoleExec{BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID())}
 }
+
+func (b *executorBuilder) buildAdminShowDistTasks(v *plannercore.AdminShowDistTasks) exec.Executor {
+	return &AdminShowDistTasksExec{BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID())}
+}
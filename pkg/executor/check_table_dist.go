@@ -0,0 +1,288 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"hash/crc64"
+	"slices"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/logutil/consistency"
+	"go.uber.org/zap"
+)
+
+// The distributed ADMIN CHECK TABLE checks the consistency between the records
+// and the indexes in two steps:
+//  1. the records and each index of each physical table are split by regions
+//     into subtasks. For the records, the subtask generates the index keys of
+//     each row, and for the indexes, the subtask reads the index keys. The
+//     checksums of (index key, handle) are XOR-ed into buckets by the hash of
+//     the handle.
+//  2. the scheduler compares the buckets of the records and the indexes, if
+//     some buckets mismatch, the key ranges are scanned again, and only the
+//     rows and index entries in the mismatched buckets are checked by point
+//     getting the index entries and rows respectively.
+//
+// The node running the statement reports the first inconsistent handles found
+// by the second step.
+const (
+	// checkTableStepChecksum is the step of calculating the checksums of buckets.
+	checkTableStepChecksum proto.Step = 1
+	// checkTableStepDiff is the step of locating the inconsistent handles in the
+	// mismatched buckets.
+	checkTableStepDiff proto.Step = 2
+)
+
+const (
+	// checkTableBucketCnt is the count of buckets of the handles.
+	checkTableBucketCnt = 256
+	// maxCheckTableInconsistencies is the max count of inconsistent handles
+	// reported by a subtask and the statement.
+	maxCheckTableInconsistencies = 10
+)
+
+var checkTableCRCTable = crc64.MakeTable(crc64.ECMA)
+
+// checkTableTaskMeta is the dist task meta of ADMIN CHECK TABLE.
+type checkTableTaskMeta struct {
+	TableInfo         *model.TableInfo `json:"table_info"`
+	PhysicalIDs       []int64          `json:"physical_ids"`
+	IndexIDs          []int64          `json:"index_ids"`
+	StartTS           uint64           `json:"start_ts"`
+	ResourceGroupName string           `json:"resource_group_name"`
+}
+
+func (m *checkTableTaskMeta) indexInfos() ([]*model.IndexInfo, error) {
+	idxInfos := make([]*model.IndexInfo, 0, len(m.IndexIDs))
+	for _, id := range m.IndexIDs {
+		idxInfo := model.FindIndexInfoByID(m.TableInfo.Indices, id)
+		if idxInfo == nil {
+			return nil, errors.Errorf("index %d not found in table %s", id, m.TableInfo.Name.O)
+		}
+		idxInfos = append(idxInfos, idxInfo)
+	}
+	return idxInfos, nil
+}
+
+// checkTableBucket is the XOR-ed checksum and the count of the entries in a bucket.
+type checkTableBucket struct {
+	Checksum uint64 `json:"checksum"`
+	Count    int64  `json:"count"`
+}
+
+// checkTableSubtaskMeta is the subtask meta of ADMIN CHECK TABLE.
+type checkTableSubtaskMeta struct {
+	PhysicalID int64 `json:"physical_id"`
+	// IndexID is 0 when the subtask scans the records.
+	IndexID  int64  `json:"index_id"`
+	StartKey kv.Key `json:"start_key"`
+	EndKey   kv.Key `json:"end_key"`
+
+	// Buckets is the result of checkTableStepChecksum, it's the buckets of each
+	// index by index ID.
+	Buckets map[int64][]checkTableBucket `json:"buckets,omitempty"`
+	// MismatchedBuckets is the buckets to check of each index in checkTableStepDiff.
+	MismatchedBuckets map[int64][]int `json:"mismatched_buckets,omitempty"`
+	// Inconsistencies is the result of checkTableStepDiff.
+	Inconsistencies []checkTableInconsistency `json:"inconsistencies,omitempty"`
+}
+
+// checkTableInconsistency is an inconsistent handle found by checkTableStepDiff.
+type checkTableInconsistency struct {
+	IndexID int64 `json:"index_id"`
+	// EncodedHandle is used to order the inconsistencies.
+	EncodedHandle []byte `json:"encoded_handle"`
+	Handle        string `json:"handle"`
+	// IndexRow and RecordRow are empty when the index entry or the row is missing.
+	IndexRow  string `json:"index_row"`
+	RecordRow string `json:"record_row"`
+}
+
+func checkTableBucketOf(h kv.Handle) int {
+	return int(crc32.ChecksumIEEE(h.Encoded()) % checkTableBucketCnt)
+}
+
+func checkTableChecksumOf(indexKey kv.Key, h kv.Handle) uint64 {
+	return crc64.Update(crc64.Checksum(indexKey, checkTableCRCTable), checkTableCRCTable, h.Encoded())
+}
+
+// canCheckTableInDist returns whether the ADMIN CHECK TABLE can be run by the
+// distributed execution framework, the index keys are generated from the rows
+// directly, so the indexes on virtual generated columns, multi-valued indexes and
+// global indexes are not supported.
+func canCheckTableInDist(tblInfo *model.TableInfo, idxInfos []*model.IndexInfo) bool {
+	if tblInfo.TempTableType != model.TempTableNone || len(idxInfos) == 0 {
+		return false
+	}
+	for _, idxInfo := range idxInfos {
+		if idxInfo.MVIndex || idxInfo.Global || (idxInfo.Primary && tblInfo.IsCommonHandle) {
+			return false
+		}
+		for _, col := range idxInfo.Columns {
+			if tblInfo.Columns[col.Offset].IsVirtualGenerated() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// DistCheckTableExec represents a check table executor which checks the table
+// by the distributed execution framework.
+// It is built from the "admin check table" statement when tidb_enable_dist_admin_check
+// is on.
+type DistCheckTableExec struct {
+	exec.BaseExecutor
+
+	table      table.Table
+	indexInfos []*model.IndexInfo
+	startTS    uint64
+	done       bool
+}
+
+// Next implements the Executor Next interface.
+func (e *DistCheckTableExec) Next(ctx context.Context, _ *chunk.Chunk) error {
+	if e.done || len(e.indexInfos) == 0 {
+		return nil
+	}
+	e.done = true
+
+	sessVars := e.Ctx().GetSessionVars()
+	tblInfo := e.table.Meta()
+	taskMeta := &checkTableTaskMeta{
+		TableInfo:         tblInfo,
+		StartTS:           e.startTS,
+		ResourceGroupName: sessVars.StmtCtx.ResourceGroupName,
+	}
+	if pi := tblInfo.GetPartitionInfo(); pi != nil {
+		for _, def := range pi.Definitions {
+			taskMeta.PhysicalIDs = append(taskMeta.PhysicalIDs, def.ID)
+		}
+	} else {
+		taskMeta.PhysicalIDs = []int64{tblInfo.ID}
+	}
+	for _, idxInfo := range e.indexInfos {
+		taskMeta.IndexIDs = append(taskMeta.IndexIDs, idxInfo.ID)
+	}
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	metaBytes, err := json.Marshal(taskMeta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	taskKey := fmt.Sprintf("check_table/%d/%d", tblInfo.ID, e.startTS)
+	task, err := submitAndWaitDistTask(ctx, sessVars, taskKey, proto.CheckTable, sessVars.DistSQLScanConcurrency(), metaBytes)
+	if err != nil {
+		return err
+	}
+	subtasks, err := getDistSubtasks(ctx, task.ID, checkTableStepDiff)
+	if err != nil {
+		return err
+	}
+	return checkTableDiffResult(ctx, sessVars, tblInfo, task.ID, subtasks)
+}
+
+// checkTableDiffResult returns the error of the inconsistencies found by the
+// subtasks of checkTableStepDiff, the first inconsistency is returned as the
+// error, and the others are reported as warnings.
+func checkTableDiffResult(
+	ctx context.Context,
+	sessVars *variable.SessionVars,
+	tblInfo *model.TableInfo,
+	taskID int64,
+	subtasks []*proto.Subtask,
+) error {
+	if len(subtasks) == 0 {
+		return nil
+	}
+
+	// there are mismatched buckets if the diff step has subtasks.
+	var inconsistencies []checkTableInconsistency
+	for _, subtask := range subtasks {
+		subtaskMeta := &checkTableSubtaskMeta{}
+		if err := json.Unmarshal(subtask.Meta, subtaskMeta); err != nil {
+			return errors.Trace(err)
+		}
+		inconsistencies = mergeCheckTableInconsistencies(inconsistencies, subtaskMeta.Inconsistencies)
+	}
+	if len(inconsistencies) == 0 {
+		logutil.Logger(ctx).Warn("checksum mismatched but no inconsistent handle found", zap.Int64("task-id", taskID))
+		return exeerrors.ErrAdminCheckTable
+	}
+	slices.SortFunc(inconsistencies, func(a, b checkTableInconsistency) int {
+		if c := cmp.Compare(a.IndexID, b.IndexID); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.EncodedHandle, b.EncodedHandle)
+	})
+	inconsistencies = inconsistencies[:min(len(inconsistencies), maxCheckTableInconsistencies)]
+	errs := make([]error, 0, len(inconsistencies))
+	for _, inc := range inconsistencies {
+		idxName := ""
+		if idxInfo := model.FindIndexInfoByID(tblInfo.Indices, inc.IndexID); idxInfo != nil {
+			idxName = idxInfo.Name.O
+		}
+		err := consistency.ErrAdminCheckInconsistent.GenWithStackByArgs(tblInfo.Name.O, idxName, inc.Handle, inc.IndexRow, inc.RecordRow)
+		logutil.Logger(ctx).Error("admin check found data inconsistency", zap.Error(err))
+		errs = append(errs, err)
+	}
+	for _, err := range errs[1:] {
+		sessVars.StmtCtx.AppendWarning(err)
+	}
+	return errs[0]
+}
+
+// mergeCheckTableInconsistencies merges the inconsistencies of the same index and
+// handle, which might be found by both the subtasks of the records and the index.
+func mergeCheckTableInconsistencies(dst, src []checkTableInconsistency) []checkTableInconsistency {
+	for _, inc := range src {
+		idx := slices.IndexFunc(dst, func(d checkTableInconsistency) bool {
+			return d.IndexID == inc.IndexID && bytes.Equal(d.EncodedHandle, inc.EncodedHandle)
+		})
+		if idx < 0 {
+			dst = append(dst, inc)
+			continue
+		}
+		if dst[idx].IndexRow == "" {
+			dst[idx].IndexRow = inc.IndexRow
+		}
+		if dst[idx].RecordRow == "" {
+			dst[idx].RecordRow = inc.RecordRow
+		}
+	}
+	return dst
+}
+
+func init() {
+	scheduler.RegisterSchedulerFactory(proto.CheckTable, newCheckTableScheduler)
+	taskexecutor.RegisterTaskType(proto.CheckTable, newCheckTableDistExecutor)
+}
@@ -0,0 +1,451 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/execute"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta/autoid"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/table/tables"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/logutil/consistency"
+)
+
+// checkTableBatchGetSize is the count of keys to get in a batch when locating
+// the inconsistent handles.
+const checkTableBatchGetSize = 1024
+
+// checkTableDistExecutor is the task executor of the distributed ADMIN CHECK TABLE.
+type checkTableDistExecutor struct {
+	*taskexecutor.BaseTaskExecutor
+}
+
+var _ taskexecutor.Extension = (*checkTableDistExecutor)(nil)
+
+func newCheckTableDistExecutor(ctx context.Context, id string, task *proto.Task, taskTable taskexecutor.TaskTable) taskexecutor.TaskExecutor {
+	e := &checkTableDistExecutor{
+		BaseTaskExecutor: taskexecutor.NewBaseTaskExecutor(ctx, id, task, taskTable),
+	}
+	e.BaseTaskExecutor.Extension = e
+	return e
+}
+
+// IsIdempotent implements taskexecutor.Extension interface.
+func (*checkTableDistExecutor) IsIdempotent(*proto.Subtask) bool {
+	return true
+}
+
+// GetSubtaskExecutor implements taskexecutor.Extension interface.
+func (*checkTableDistExecutor) GetSubtaskExecutor(_ context.Context, task *proto.Task, _ *execute.Summary) (execute.SubtaskExecutor, error) {
+	if task.Step != checkTableStepChecksum && task.Step != checkTableStepDiff {
+		return nil, errors.Errorf("unknown check table step %d for task %d", task.Step, task.ID)
+	}
+	taskMeta := &checkTableTaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	idxInfos, err := taskMeta.indexInfos()
+	if err != nil {
+		return nil, err
+	}
+	tbl, err := tables.TableFromMeta(autoid.NewAllocators(false), taskMeta.TableInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &checkTableRangeExecutor{
+		taskMeta: taskMeta,
+		step:     task.Step,
+		cols:     tbl.Cols(),
+		idxInfos: idxInfos,
+	}, nil
+}
+
+// IsRetryableError implements taskexecutor.Extension interface.
+func (*checkTableDistExecutor) IsRetryableError(err error) bool {
+	return isRetryableDistTaskError(err)
+}
+
+// checkTableRangeExecutor runs the subtasks of the distributed ADMIN CHECK TABLE.
+type checkTableRangeExecutor struct {
+	taskexecutor.EmptySubtaskExecutor
+	taskMeta *checkTableTaskMeta
+	step     proto.Step
+	cols     []*table.Column
+	idxInfos []*model.IndexInfo
+
+	// result is the subtask meta with the result of the last run subtask.
+	result *checkTableSubtaskMeta
+}
+
+// RunSubtask implements execute.SubtaskExecutor interface.
+func (e *checkTableRangeExecutor) RunSubtask(ctx context.Context, subtask *proto.Subtask) error {
+	subtaskMeta := &checkTableSubtaskMeta{}
+	if err := json.Unmarshal(subtask.Meta, subtaskMeta); err != nil {
+		return errors.Trace(err)
+	}
+	taskManager, err := storage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	return taskManager.WithNewSession(func(se sessionctx.Context) error {
+		c := e.newRangeChecker(se, subtaskMeta)
+		var err error
+		switch {
+		case e.step == checkTableStepChecksum && subtaskMeta.IndexID == 0:
+			subtaskMeta.Buckets, err = c.checksumRecords(ctx)
+		case e.step == checkTableStepChecksum:
+			subtaskMeta.Buckets, err = c.checksumIndex(ctx)
+		case subtaskMeta.IndexID == 0:
+			subtaskMeta.Inconsistencies, err = c.diffRecords(ctx)
+		default:
+			subtaskMeta.Inconsistencies, err = c.diffIndex(ctx)
+		}
+		if err != nil {
+			return err
+		}
+		e.result = subtaskMeta
+		return nil
+	})
+}
+
+// OnFinished implements execute.SubtaskExecutor interface.
+func (e *checkTableRangeExecutor) OnFinished(_ context.Context, subtask *proto.Subtask) error {
+	if e.result == nil {
+		return nil
+	}
+	meta, err := json.Marshal(e.result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.result = nil
+	subtask.Meta = meta
+	return nil
+}
+
+func (e *checkTableRangeExecutor) newRangeChecker(se sessionctx.Context, subtaskMeta *checkTableSubtaskMeta) *checkTableRangeChecker {
+	snap := se.GetStore().GetSnapshot(kv.NewVersion(e.taskMeta.StartTS))
+	snap.SetOption(kv.RequestSourceInternal, true)
+	snap.SetOption(kv.RequestSourceType, kv.InternalDistTask)
+	snap.SetOption(kv.ResourceGroupName, e.taskMeta.ResourceGroupName)
+	c := &checkTableRangeChecker{
+		se:          se,
+		sc:          stmtctx.NewStmtCtxWithTimeZone(se.GetSessionVars().Location()),
+		snap:        snap,
+		tblInfo:     e.taskMeta.TableInfo,
+		cols:        e.cols,
+		subtaskMeta: subtaskMeta,
+	}
+	for _, idxInfo := range e.idxInfos {
+		if subtaskMeta.IndexID != 0 && subtaskMeta.IndexID != idxInfo.ID {
+			continue
+		}
+		if e.step == checkTableStepDiff && len(subtaskMeta.MismatchedBuckets[idxInfo.ID]) == 0 {
+			continue
+		}
+		c.indexes = append(c.indexes, tables.NewIndex(subtaskMeta.PhysicalID, e.taskMeta.TableInfo, idxInfo))
+	}
+	return c
+}
+
+// checkTableRangeChecker checks the records or an index in the key range of a subtask.
+type checkTableRangeChecker struct {
+	se          sessionctx.Context
+	sc          *stmtctx.StatementContext
+	snap        kv.Snapshot
+	tblInfo     *model.TableInfo
+	cols        []*table.Column
+	indexes     []table.Index
+	subtaskMeta *checkTableSubtaskMeta
+}
+
+func (c *checkTableRangeChecker) iterate(ctx context.Context, fn func(key kv.Key, value []byte) (more bool, err error)) error {
+	it, err := c.snap.Iter(c.subtaskMeta.StartKey, c.subtaskMeta.EndKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer it.Close()
+	for it.Valid() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		more, err := fn(it.Key(), it.Value())
+		if err != nil || !more {
+			return err
+		}
+		if err := it.Next(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (c *checkTableRangeChecker) decodeRow(key kv.Key, value []byte) (kv.Handle, []types.Datum, error) {
+	h, err := tablecodec.DecodeRowKey(key)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	row, _, err := tables.DecodeRawRowData(c.se, c.tblInfo, h, c.cols, value)
+	return h, row, err
+}
+
+func (c *checkTableRangeChecker) genIndexKey(idx table.Index, h kv.Handle, row []types.Datum) (kv.Key, []types.Datum, error) {
+	vals, err := idx.FetchValues(row, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, _, err := idx.GenIndexKey(c.sc, vals, h, nil)
+	return key, vals, err
+}
+
+func newCheckTableBuckets(indexes []table.Index) map[int64][]checkTableBucket {
+	buckets := make(map[int64][]checkTableBucket, len(indexes))
+	for _, idx := range indexes {
+		buckets[idx.Meta().ID] = make([]checkTableBucket, checkTableBucketCnt)
+	}
+	return buckets
+}
+
+// checksumRecords calculates the buckets of the index keys generated from the rows.
+func (c *checkTableRangeChecker) checksumRecords(ctx context.Context) (map[int64][]checkTableBucket, error) {
+	buckets := newCheckTableBuckets(c.indexes)
+	err := c.iterate(ctx, func(key kv.Key, value []byte) (bool, error) {
+		h, row, err := c.decodeRow(key, value)
+		if err != nil {
+			return false, err
+		}
+		bucket := checkTableBucketOf(h)
+		for _, idx := range c.indexes {
+			idxKey, _, err := c.genIndexKey(idx, h, row)
+			if err != nil {
+				return false, err
+			}
+			b := &buckets[idx.Meta().ID][bucket]
+			b.Checksum ^= checkTableChecksumOf(idxKey, h)
+			b.Count++
+		}
+		return true, nil
+	})
+	return buckets, err
+}
+
+// checksumIndex calculates the buckets of the index entries.
+func (c *checkTableRangeChecker) checksumIndex(ctx context.Context) (map[int64][]checkTableBucket, error) {
+	buckets := newCheckTableBuckets(c.indexes)
+	if len(c.indexes) == 0 {
+		return buckets, nil
+	}
+	idx := c.indexes[0]
+	colsLen := len(idx.Meta().Columns)
+	idxBuckets := buckets[idx.Meta().ID]
+	err := c.iterate(ctx, func(key kv.Key, value []byte) (bool, error) {
+		h, err := tablecodec.DecodeIndexHandle(key, value, colsLen)
+		if err != nil {
+			return false, err
+		}
+		b := &idxBuckets[checkTableBucketOf(h)]
+		b.Checksum ^= checkTableChecksumOf(key, h)
+		b.Count++
+		return true, nil
+	})
+	return buckets, err
+}
+
+type checkTablePendingEntry struct {
+	idx    table.Index
+	key    kv.Key
+	handle kv.Handle
+	values []types.Datum
+}
+
+func (c *checkTableRangeChecker) inMismatchedBucket(idx table.Index, h kv.Handle) bool {
+	return slices.Contains(c.subtaskMeta.MismatchedBuckets[idx.Meta().ID], checkTableBucketOf(h))
+}
+
+// diffRecords checks whether the index entries of the rows in the mismatched
+// buckets exist.
+func (c *checkTableRangeChecker) diffRecords(ctx context.Context) ([]checkTableInconsistency, error) {
+	var (
+		result  []checkTableInconsistency
+		pending []checkTablePendingEntry
+	)
+	flush := func() error {
+		keys := make([]kv.Key, 0, len(pending))
+		for _, p := range pending {
+			keys = append(keys, p.key)
+		}
+		values, err := c.snap.BatchGet(ctx, keys)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, p := range pending {
+			recordRow := &consistency.RecordData{Handle: p.handle, Values: p.values}
+			value, ok := values[string(p.key)]
+			if !ok {
+				result = append(result, newCheckTableInconsistency(p.idx, p.handle, nil, recordRow))
+				continue
+			}
+			h, err := tablecodec.DecodeIndexHandle(p.key, value, len(p.idx.Meta().Columns))
+			if err != nil {
+				return err
+			}
+			if !h.Equal(p.handle) {
+				indexRow := &consistency.RecordData{Handle: h, Values: p.values}
+				result = append(result, newCheckTableInconsistency(p.idx, p.handle, indexRow, recordRow))
+			}
+		}
+		pending = pending[:0]
+		return nil
+	}
+	err := c.iterate(ctx, func(key kv.Key, value []byte) (bool, error) {
+		h, row, err := c.decodeRow(key, value)
+		if err != nil {
+			return false, err
+		}
+		for _, idx := range c.indexes {
+			if !c.inMismatchedBucket(idx, h) {
+				continue
+			}
+			idxKey, vals, err := c.genIndexKey(idx, h, row)
+			if err != nil {
+				return false, err
+			}
+			pending = append(pending, checkTablePendingEntry{idx: idx, key: idxKey, handle: h, values: vals})
+		}
+		if len(pending) >= checkTableBatchGetSize {
+			if err := flush(); err != nil {
+				return false, err
+			}
+		}
+		return len(result) < maxCheckTableInconsistencies, nil
+	})
+	if err == nil && len(pending) > 0 {
+		err = flush()
+	}
+	return result[:min(len(result), maxCheckTableInconsistencies)], err
+}
+
+// diffIndex checks whether the rows of the index entries in the mismatched
+// buckets exist and generate the same index keys.
+func (c *checkTableRangeChecker) diffIndex(ctx context.Context) ([]checkTableInconsistency, error) {
+	if len(c.indexes) == 0 {
+		return nil, nil
+	}
+	idx := c.indexes[0]
+	colsLen := len(idx.Meta().Columns)
+	var (
+		result  []checkTableInconsistency
+		pending []checkTablePendingEntry
+	)
+	flush := func() error {
+		keys := make([]kv.Key, 0, len(pending))
+		for _, p := range pending {
+			keys = append(keys, tablecodec.EncodeRowKeyWithHandle(c.subtaskMeta.PhysicalID, p.handle))
+		}
+		values, err := c.snap.BatchGet(ctx, keys)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for i, p := range pending {
+			indexRow := &consistency.RecordData{Handle: p.handle, Values: p.values}
+			value, ok := values[string(keys[i])]
+			if !ok {
+				result = append(result, newCheckTableInconsistency(idx, p.handle, indexRow, nil))
+				continue
+			}
+			h, row, err := c.decodeRow(keys[i], value)
+			if err != nil {
+				return err
+			}
+			expectedKey, vals, err := c.genIndexKey(idx, h, row)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(expectedKey, p.key) {
+				recordRow := &consistency.RecordData{Handle: h, Values: vals}
+				result = append(result, newCheckTableInconsistency(idx, p.handle, indexRow, recordRow))
+			}
+		}
+		pending = pending[:0]
+		return nil
+	}
+	err := c.iterate(ctx, func(key kv.Key, value []byte) (bool, error) {
+		h, err := tablecodec.DecodeIndexHandle(key, value, colsLen)
+		if err != nil {
+			return false, err
+		}
+		if !c.inMismatchedBucket(idx, h) {
+			return true, nil
+		}
+		vals, err := decodeIndexKeyValues(key, colsLen)
+		if err != nil {
+			return false, err
+		}
+		pending = append(pending, checkTablePendingEntry{key: key.Clone(), handle: h, values: vals})
+		if len(pending) >= checkTableBatchGetSize {
+			if err := flush(); err != nil {
+				return false, err
+			}
+		}
+		return len(result) < maxCheckTableInconsistencies, nil
+	})
+	if err == nil && len(pending) > 0 {
+		err = flush()
+	}
+	return result[:min(len(result), maxCheckTableInconsistencies)], err
+}
+
+// decodeIndexKeyValues decodes the column values in the index key, it's only
+// used to report the inconsistency, so the values are not restored.
+func decodeIndexKeyValues(key kv.Key, colsLen int) ([]types.Datum, error) {
+	encodedVals, _, err := tablecodec.CutIndexKeyNew(key, colsLen)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	vals := make([]types.Datum, 0, len(encodedVals))
+	for _, encoded := range encodedVals {
+		_, d, err := codec.DecodeOne(encoded)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		vals = append(vals, d)
+	}
+	return vals, nil
+}
+
+func newCheckTableInconsistency(idx table.Index, h kv.Handle, indexRow, recordRow *consistency.RecordData) checkTableInconsistency {
+	return checkTableInconsistency{
+		IndexID:       idx.Meta().ID,
+		EncodedHandle: h.Encoded(),
+		Handle:        h.String(),
+		IndexRow:      indexRow.String(),
+		RecordRow:     recordRow.String(),
+	}
+}
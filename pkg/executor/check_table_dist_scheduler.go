@@ -0,0 +1,166 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
+	diststorage "github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+func newCheckTableScheduler(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
+	// the subtasks of the diff step are generated from the persisted results of
+	// the checksum step.
+	return newRegionSplitScheduler(ctx, task, param, &regionSplitSchedulerExt{
+		steps:     []proto.Step{checkTableStepChecksum, checkTableStepDiff},
+		getRanges: getCheckTableRanges,
+		generateNextSubtasks: func(ctx context.Context, taskHandle diststorage.TaskHandle, task *proto.Task, _ proto.Step) ([][]byte, error) {
+			return generateCheckTableDiffSubtasks(ctx, taskHandle, task)
+		},
+	})
+}
+
+// getCheckTableRanges gets the records and each index of the physical tables of
+// the distributed ADMIN CHECK TABLE, which are split into subtasks by regions.
+func getCheckTableRanges(task *proto.Task) ([]regionSplitRange, error) {
+	taskMeta := &checkTableTaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ranges := make([]regionSplitRange, 0, len(taskMeta.PhysicalIDs)*(len(taskMeta.IndexIDs)+1))
+	for _, physicalID := range taskMeta.PhysicalIDs {
+		// index ID 0 means the records.
+		for _, indexID := range append([]int64{0}, taskMeta.IndexIDs...) {
+			physicalID, indexID := physicalID, indexID
+			startKey := tablecodec.GenTableRecordPrefix(physicalID)
+			if indexID != 0 {
+				startKey = tablecodec.EncodeTableIndexPrefix(physicalID, indexID)
+			}
+			ranges = append(ranges, regionSplitRange{
+				startKey: startKey,
+				endKey:   startKey.PrefixNext(),
+				newSubtaskMeta: func(r kv.KeyRange) any {
+					return &checkTableSubtaskMeta{
+						PhysicalID: physicalID,
+						IndexID:    indexID,
+						StartKey:   r.StartKey,
+						EndKey:     r.EndKey,
+					}
+				},
+			})
+		}
+	}
+	return ranges, nil
+}
+
+// generateCheckTableDiffSubtasks compares the buckets of the records and the
+// indexes calculated by the checksum step, and generates the subtasks to scan the
+// key ranges with mismatched buckets again. No subtask is generated if all the
+// buckets match.
+func generateCheckTableDiffSubtasks(ctx context.Context, taskHandle diststorage.TaskHandle, task *proto.Task) ([][]byte, error) {
+	prevMetas, err := taskHandle.GetPreviousSubtaskMetas(task.ID, checkTableStepChecksum)
+	if err != nil {
+		return nil, err
+	}
+	type bucketsKey struct {
+		physicalID int64
+		indexID    int64
+	}
+	prevSubtasks := make([]*checkTableSubtaskMeta, 0, len(prevMetas))
+	recordBuckets := make(map[bucketsKey][]checkTableBucket)
+	indexBuckets := make(map[bucketsKey][]checkTableBucket)
+	mergeBuckets := func(all map[bucketsKey][]checkTableBucket, key bucketsKey, buckets []checkTableBucket) {
+		merged, ok := all[key]
+		if !ok {
+			merged = make([]checkTableBucket, checkTableBucketCnt)
+			all[key] = merged
+		}
+		for i, b := range buckets {
+			merged[i].Checksum ^= b.Checksum
+			merged[i].Count += b.Count
+		}
+	}
+	for _, metaBytes := range prevMetas {
+		subtaskMeta := &checkTableSubtaskMeta{}
+		if err := json.Unmarshal(metaBytes, subtaskMeta); err != nil {
+			return nil, errors.Trace(err)
+		}
+		prevSubtasks = append(prevSubtasks, subtaskMeta)
+		for indexID, buckets := range subtaskMeta.Buckets {
+			key := bucketsKey{physicalID: subtaskMeta.PhysicalID, indexID: indexID}
+			if subtaskMeta.IndexID == 0 {
+				mergeBuckets(recordBuckets, key, buckets)
+			} else {
+				mergeBuckets(indexBuckets, key, buckets)
+			}
+		}
+	}
+	// make sure the index missing in either side is compared.
+	empty := make([]checkTableBucket, checkTableBucketCnt)
+	for key := range recordBuckets {
+		mergeBuckets(indexBuckets, key, empty)
+	}
+	for key := range indexBuckets {
+		mergeBuckets(recordBuckets, key, empty)
+	}
+
+	mismatched := make(map[bucketsKey][]int)
+	for key, buckets := range recordBuckets {
+		for i, b := range buckets {
+			if b != indexBuckets[key][i] {
+				mismatched[key] = append(mismatched[key], i)
+			}
+		}
+	}
+	if len(mismatched) == 0 {
+		return nil, nil
+	}
+	logutil.Logger(ctx).Warn("check table found mismatched buckets",
+		zap.Int64("task-id", task.ID), zap.Int("indexes", len(mismatched)))
+
+	subtaskMetas := make([][]byte, 0, len(prevSubtasks))
+	for _, prev := range prevSubtasks {
+		subtaskMeta := &checkTableSubtaskMeta{
+			PhysicalID:        prev.PhysicalID,
+			IndexID:           prev.IndexID,
+			StartKey:          prev.StartKey,
+			EndKey:            prev.EndKey,
+			MismatchedBuckets: make(map[int64][]int),
+		}
+		for key, buckets := range mismatched {
+			if key.physicalID != prev.PhysicalID || (prev.IndexID != 0 && prev.IndexID != key.indexID) {
+				continue
+			}
+			subtaskMeta.MismatchedBuckets[key.indexID] = buckets
+		}
+		if len(subtaskMeta.MismatchedBuckets) == 0 {
+			continue
+		}
+		metaBytes, err := json.Marshal(subtaskMeta)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		subtaskMetas = append(subtaskMetas, metaBytes)
+	}
+	return subtaskMetas, nil
+}
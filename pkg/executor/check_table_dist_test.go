@@ -0,0 +1,109 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/logutil/consistency"
+	"github.com/pingcap/tidb/pkg/util/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTableDiffResult(t *testing.T) {
+	ctx := context.Background()
+	sessVars := mock.NewContext().GetSessionVars()
+	tblInfo := &model.TableInfo{
+		Name:    model.NewCIStr("t"),
+		Indices: []*model.IndexInfo{{ID: 1, Name: model.NewCIStr("idx_b")}},
+	}
+	newSubtask := func(inconsistencies ...checkTableInconsistency) *proto.Subtask {
+		meta, err := json.Marshal(&checkTableSubtaskMeta{Inconsistencies: inconsistencies})
+		require.NoError(t, err)
+		return &proto.Subtask{Meta: meta}
+	}
+
+	// no mismatched bucket.
+	require.NoError(t, checkTableDiffResult(ctx, sessVars, tblInfo, 1, nil))
+
+	// the checksums mismatched, but the rows and index entries in the
+	// mismatched buckets are consistent when they are checked again.
+	err := checkTableDiffResult(ctx, sessVars, tblInfo, 1, []*proto.Subtask{newSubtask(), newSubtask()})
+	require.True(t, exeerrors.ErrAdminCheckTable.Equal(err))
+
+	// the inconsistency found by both the subtasks of the records and the index
+	// is merged, and the inconsistencies are ordered by the handles.
+	handle := func(h int64) checkTableInconsistency {
+		return checkTableInconsistency{IndexID: 1, EncodedHandle: kv.IntHandle(h).Encoded(), Handle: kv.IntHandle(h).String()}
+	}
+	recordSide, indexSide := handle(23), handle(23)
+	recordSide.RecordRow = "handle: 23, values: [KindInt64 3]"
+	indexSide.IndexRow = "handle: 23, values: [KindInt64 4]"
+	missingIndex := handle(7)
+	missingIndex.RecordRow = "handle: 7, values: [KindInt64 7]"
+	sessVars.StmtCtx.SetWarnings(nil)
+	err = checkTableDiffResult(ctx, sessVars, tblInfo, 1, []*proto.Subtask{
+		newSubtask(recordSide),
+		newSubtask(indexSide, missingIndex),
+	})
+	require.True(t, consistency.ErrAdminCheckInconsistent.Equal(err))
+	require.EqualError(t, err, "[admin:8223]data inconsistency in table: t, index: idx_b, handle: 7, index-values:\"\" != record-values:\"handle: 7, values: [KindInt64 7]\"")
+	warnings := sessVars.StmtCtx.GetWarnings()
+	require.Len(t, warnings, 1)
+	require.EqualError(t, warnings[0].Err, "[admin:8223]data inconsistency in table: t, index: idx_b, handle: 23, index-values:\"handle: 23, values: [KindInt64 4]\" != record-values:\"handle: 23, values: [KindInt64 3]\"")
+}
+
+func TestCheckTableRanges(t *testing.T) {
+	metaBytes, err := json.Marshal(&checkTableTaskMeta{PhysicalIDs: []int64{10, 11}, IndexIDs: []int64{1}})
+	require.NoError(t, err)
+	task := &proto.Task{Step: proto.StepInit, Meta: metaBytes}
+	ranges, err := getCheckTableRanges(task)
+	require.NoError(t, err)
+	require.Len(t, ranges, 4)
+	for i, expected := range []struct {
+		physicalID int64
+		indexID    int64
+		startKey   kv.Key
+	}{
+		{10, 0, tablecodec.GenTableRecordPrefix(10)},
+		{10, 1, tablecodec.EncodeTableIndexPrefix(10, 1)},
+		{11, 0, tablecodec.GenTableRecordPrefix(11)},
+		{11, 1, tablecodec.EncodeTableIndexPrefix(11, 1)},
+	} {
+		require.Equal(t, expected.startKey, ranges[i].startKey)
+		require.Equal(t, expected.startKey.PrefixNext(), ranges[i].endKey)
+		r := kv.KeyRange{StartKey: kv.Key("a"), EndKey: kv.Key("b")}
+		require.Equal(t, &checkTableSubtaskMeta{
+			PhysicalID: expected.physicalID,
+			IndexID:    expected.indexID,
+			StartKey:   r.StartKey,
+			EndKey:     r.EndKey,
+		}, ranges[i].newSubtaskMeta(r))
+	}
+
+	ext := &regionSplitSchedulerExt{steps: []proto.Step{checkTableStepChecksum, checkTableStepDiff}}
+	require.Equal(t, checkTableStepChecksum, ext.GetNextStep(task))
+	task.Step = checkTableStepChecksum
+	require.Equal(t, checkTableStepDiff, ext.GetNextStep(task))
+	task.Step = checkTableStepDiff
+	require.Equal(t, proto.StepDone, ext.GetNextStep(task))
+}
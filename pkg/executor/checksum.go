@@ -47,6 +47,10 @@ func (e *ChecksumTableExec) Open(ctx context.Context) error {
 		return err
 	}
 
+	if distAdminCheckEnabled(e.Ctx()) {
+		return e.checksumInDist(ctx)
+	}
+
 	concurrency, err := getChecksumTableConcurrency(e.Ctx())
 	if err != nil {
 		return err
@@ -130,7 +134,11 @@ func (e *ChecksumTableExec) checksumWorker(taskCh <-chan *checksumTask, resultCh
 
 func (e *ChecksumTableExec) handleChecksumRequest(req *kv.Request) (resp *tipb.ChecksumResponse, err error) {
 	ctx := distsql.WithSQLKvExecCounterInterceptor(context.TODO(), e.Ctx().GetSessionVars().StmtCtx)
-	res, err := distsql.Checksum(ctx, e.Ctx().GetClient(), req, e.Ctx().GetSessionVars().KVVars)
+	return sendChecksumRequest(ctx, e.Ctx().GetClient(), req, e.Ctx().GetSessionVars().KVVars)
+}
+
+func sendChecksumRequest(ctx context.Context, client kv.Client, req *kv.Request, vars interface{}) (resp *tipb.ChecksumResponse, err error) {
+	res, err := distsql.Checksum(ctx, client, req, vars)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,153 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+// The distributed ADMIN CHECKSUM TABLE splits the records and the indexes of
+// each physical table by regions into subtasks, the task executors send the
+// checksum request of the key range of the subtask to the storage, and save the
+// result in the subtask meta. As the CRC64 checksum is XOR-ed, the node running
+// the statement gets the checksum of the tables by merging the subtask results.
+const (
+	// checksumStepChecksum is the step of calculating checksum of the key ranges.
+	checksumStepChecksum proto.Step = 1
+)
+
+// checksumTaskMeta is the dist task meta of ADMIN CHECKSUM TABLE.
+type checksumTaskMeta struct {
+	Units             []checksumUnitMeta `json:"units"`
+	StartTS           uint64             `json:"start_ts"`
+	ResourceGroupName string             `json:"resource_group_name"`
+}
+
+// checksumUnitMeta is the records or an index of a physical table to checksum.
+type checksumUnitMeta struct {
+	TableID    int64 `json:"table_id"`
+	PhysicalID int64 `json:"physical_id"`
+	// IndexID is 0 when checksum the records.
+	IndexID int64 `json:"index_id"`
+}
+
+func (u *checksumUnitMeta) keyRange() (startKey, endKey kv.Key) {
+	if u.IndexID == 0 {
+		startKey = tablecodec.GenTableRecordPrefix(u.PhysicalID)
+	} else {
+		startKey = tablecodec.EncodeTableIndexPrefix(u.PhysicalID, u.IndexID)
+	}
+	return startKey, startKey.PrefixNext()
+}
+
+// checksumSubtaskMeta is the subtask meta of ADMIN CHECKSUM TABLE.
+type checksumSubtaskMeta struct {
+	checksumUnitMeta
+	StartKey kv.Key `json:"start_key"`
+	EndKey   kv.Key `json:"end_key"`
+	// the result of the subtask, it's filled when the subtask is finished.
+	Checksum   uint64 `json:"checksum"`
+	TotalKvs   uint64 `json:"total_kvs"`
+	TotalBytes uint64 `json:"total_bytes"`
+}
+
+// distAdminCheckEnabled returns whether ADMIN CHECK TABLE and ADMIN CHECKSUM TABLE
+// run by the distributed execution framework.
+func distAdminCheckEnabled(sctx sessionctx.Context) bool {
+	return sctx.GetSessionVars().EnableDistAdminCheck && variable.EnableDistTask.Load()
+}
+
+func (e *ChecksumTableExec) checksumInDist(ctx context.Context) error {
+	sessVars := e.Ctx().GetSessionVars()
+	concurrency, err := getChecksumTableConcurrency(e.Ctx())
+	if err != nil {
+		return err
+	}
+	taskMeta := &checksumTaskMeta{ResourceGroupName: sessVars.StmtCtx.ResourceGroupName}
+	var firstTableID int64
+	for id, t := range e.tables {
+		taskMeta.StartTS = t.StartTs
+		if firstTableID == 0 || id < firstTableID {
+			firstTableID = id
+		}
+		physicalIDs := []int64{t.TableInfo.ID}
+		if part := t.TableInfo.Partition; part != nil {
+			for _, def := range part.Definitions {
+				physicalIDs = append(physicalIDs, def.ID)
+			}
+		}
+		for _, physicalID := range physicalIDs {
+			taskMeta.Units = append(taskMeta.Units, checksumUnitMeta{TableID: id, PhysicalID: physicalID})
+			for _, idx := range t.TableInfo.Indices {
+				if idx.State != model.StatePublic {
+					continue
+				}
+				taskMeta.Units = append(taskMeta.Units, checksumUnitMeta{TableID: id, PhysicalID: physicalID, IndexID: idx.ID})
+			}
+		}
+	}
+	if len(taskMeta.Units) == 0 {
+		return nil
+	}
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	metaBytes, err := json.Marshal(taskMeta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	taskKey := fmt.Sprintf("checksum/%d/%d", firstTableID, taskMeta.StartTS)
+	task, err := submitAndWaitDistTask(ctx, sessVars, taskKey, proto.ChecksumTable, concurrency, metaBytes)
+	if err != nil {
+		return err
+	}
+	subtasks, err := getDistSubtasks(ctx, task.ID, checksumStepChecksum)
+	if err != nil {
+		return err
+	}
+	for _, subtask := range subtasks {
+		subtaskMeta := &checksumSubtaskMeta{}
+		if err := json.Unmarshal(subtask.Meta, subtaskMeta); err != nil {
+			return errors.Trace(err)
+		}
+		t, ok := e.tables[subtaskMeta.TableID]
+		if !ok {
+			return errors.Errorf("unknown table %d of checksum subtask %d", subtaskMeta.TableID, subtask.ID)
+		}
+		t.HandleResponse(&tipb.ChecksumResponse{
+			Checksum:   subtaskMeta.Checksum,
+			TotalKvs:   subtaskMeta.TotalKvs,
+			TotalBytes: subtaskMeta.TotalBytes,
+		})
+	}
+	return nil
+}
+
+func init() {
+	scheduler.RegisterSchedulerFactory(proto.ChecksumTable, newChecksumScheduler)
+	taskexecutor.RegisterTaskType(proto.ChecksumTable, newChecksumDistExecutor)
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/distsql"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/execute"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+// checksumDistExecutor is the task executor of the distributed ADMIN CHECKSUM TABLE.
+type checksumDistExecutor struct {
+	*taskexecutor.BaseTaskExecutor
+}
+
+var _ taskexecutor.Extension = (*checksumDistExecutor)(nil)
+
+func newChecksumDistExecutor(ctx context.Context, id string, task *proto.Task, taskTable taskexecutor.TaskTable) taskexecutor.TaskExecutor {
+	e := &checksumDistExecutor{
+		BaseTaskExecutor: taskexecutor.NewBaseTaskExecutor(ctx, id, task, taskTable),
+	}
+	e.BaseTaskExecutor.Extension = e
+	return e
+}
+
+// IsIdempotent implements taskexecutor.Extension interface.
+func (*checksumDistExecutor) IsIdempotent(*proto.Subtask) bool {
+	return true
+}
+
+// GetSubtaskExecutor implements taskexecutor.Extension interface.
+func (*checksumDistExecutor) GetSubtaskExecutor(_ context.Context, task *proto.Task, _ *execute.Summary) (execute.SubtaskExecutor, error) {
+	if task.Step != checksumStepChecksum {
+		return nil, errors.Errorf("unknown checksum step %d for task %d", task.Step, task.ID)
+	}
+	taskMeta := &checksumTaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &checksumRangeExecutor{
+		taskMeta:    taskMeta,
+		concurrency: task.Concurrency,
+	}, nil
+}
+
+// IsRetryableError implements taskexecutor.Extension interface.
+func (*checksumDistExecutor) IsRetryableError(err error) bool {
+	return isRetryableDistTaskError(err)
+}
+
+// checksumRangeExecutor calculates the checksum of the key range of a subtask.
+type checksumRangeExecutor struct {
+	taskexecutor.EmptySubtaskExecutor
+	taskMeta    *checksumTaskMeta
+	concurrency int
+
+	// resp is the result of the last run subtask.
+	resp *tipb.ChecksumResponse
+}
+
+// RunSubtask implements execute.SubtaskExecutor interface.
+func (e *checksumRangeExecutor) RunSubtask(ctx context.Context, subtask *proto.Subtask) error {
+	subtaskMeta := &checksumSubtaskMeta{}
+	if err := json.Unmarshal(subtask.Meta, subtaskMeta); err != nil {
+		return errors.Trace(err)
+	}
+	scanOn := tipb.ChecksumScanOn_Table
+	if subtaskMeta.IndexID != 0 {
+		scanOn = tipb.ChecksumScanOn_Index
+	}
+	var builder distsql.RequestBuilder
+	kvReq, err := builder.SetKeyRanges([]kv.KeyRange{{StartKey: subtaskMeta.StartKey, EndKey: subtaskMeta.EndKey}}).
+		SetChecksumRequest(&tipb.ChecksumRequest{
+			ScanOn:    scanOn,
+			Algorithm: tipb.ChecksumAlgorithm_Crc64_Xor,
+		}).
+		SetStartTS(e.taskMeta.StartTS).
+		SetConcurrency(e.concurrency).
+		SetResourceGroupName(e.taskMeta.ResourceGroupName).
+		Build()
+	if err != nil {
+		return err
+	}
+	taskManager, err := storage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	return taskManager.WithNewSession(func(se sessionctx.Context) error {
+		e.resp, err = sendChecksumRequest(ctx, se.GetClient(), kvReq, se.GetSessionVars().KVVars)
+		return err
+	})
+}
+
+// OnFinished implements execute.SubtaskExecutor interface.
+func (e *checksumRangeExecutor) OnFinished(_ context.Context, subtask *proto.Subtask) error {
+	subtaskMeta := &checksumSubtaskMeta{}
+	if err := json.Unmarshal(subtask.Meta, subtaskMeta); err != nil {
+		return errors.Trace(err)
+	}
+	if e.resp != nil {
+		subtaskMeta.Checksum = e.resp.Checksum
+		subtaskMeta.TotalKvs = e.resp.TotalKvs
+		subtaskMeta.TotalBytes = e.resp.TotalBytes
+		e.resp = nil
+	}
+	meta, err := json.Marshal(subtaskMeta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	subtask.Meta = meta
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
+	"github.com/pingcap/tidb/pkg/kv"
+)
+
+func newChecksumScheduler(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
+	return newRegionSplitScheduler(ctx, task, param, &regionSplitSchedulerExt{
+		steps:     []proto.Step{checksumStepChecksum},
+		getRanges: getChecksumRanges,
+	})
+}
+
+// getChecksumRanges gets the records and indexes of each physical table of the
+// distributed ADMIN CHECKSUM TABLE, which are split into subtasks by regions.
+func getChecksumRanges(task *proto.Task) ([]regionSplitRange, error) {
+	taskMeta := &checksumTaskMeta{}
+	if err := json.Unmarshal(task.Meta, taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ranges := make([]regionSplitRange, 0, len(taskMeta.Units))
+	for _, unit := range taskMeta.Units {
+		unit := unit
+		startKey, endKey := unit.keyRange()
+		ranges = append(ranges, regionSplitRange{
+			startKey: startKey,
+			endKey:   endKey,
+			newSubtaskMeta: func(r kv.KeyRange) any {
+				return &checksumSubtaskMeta{
+					checksumUnitMeta: unit,
+					StartKey:         r.StartKey,
+					EndKey:           r.EndKey,
+				}
+			},
+		})
+	}
+	return ranges, nil
+}
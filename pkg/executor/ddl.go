@@ -329,6 +329,12 @@ func (e *DDLExec) executeDropDatabase(s *ast.DropDatabaseStmt) error {
 	}
 
 	err := domain.GetDomain(e.Ctx()).DDL().DropSchema(e.Ctx(), s)
+	if err == nil {
+		// The schema has been dropped, so only log the error if its events fail to be dropped.
+		if dropErr := dropSchemaEvents(e.Ctx(), dbName.O); dropErr != nil {
+			logutil.BgLogger().Warn("fail to drop events of the dropped schema", zap.String("schema", dbName.O), zap.Error(dropErr))
+		}
+	}
 	sessionVars := e.Ctx().GetSessionVars()
 	if err == nil && strings.ToLower(sessionVars.CurrentDB) == dbName.L {
 		sessionVars.CurrentDB = ""
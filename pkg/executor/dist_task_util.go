@@ -0,0 +1,248 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/common"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/store/helper"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/tikv/client-go/v2/tikv"
+	"go.uber.org/zap"
+)
+
+// maxRegionsPerDistSubtask is the max number of regions scanned by one subtask
+// of the tasks which split the key ranges by regions, such as the distributed
+// ANALYZE and ADMIN CHECK/CHECKSUM TABLE.
+const maxRegionsPerDistSubtask = 100
+
+// submitAndWaitDistTask submits a task to the distributed execution framework and
+// waits it to finish, the task is cancelled if the statement is killed. It returns
+// an error if the task isn't succeed.
+// As the progress of the task is persisted by the framework, the task continues
+// after the owner of the framework changes, it's only bound to the lifetime of
+// the statement which submits it.
+func submitAndWaitDistTask(
+	ctx context.Context,
+	sessVars *variable.SessionVars,
+	taskKey string,
+	taskType proto.TaskType,
+	concurrency int,
+	meta []byte,
+) (*proto.Task, error) {
+	cpuCount, err := handle.GetCPUCountOfManagedNode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	concurrency = max(min(concurrency, cpuCount), 1)
+	task, err := handle.SubmitTask(ctx, taskKey, taskType, concurrency, meta)
+	if err != nil {
+		return nil, err
+	}
+	logger := logutil.Logger(ctx).With(zap.Int64("task-id", task.ID), zap.String("task-key", taskKey))
+	logger.Info("distributed task submitted", zap.Stringer("task-type", taskType))
+
+	var killedErr error
+	found, err := handle.WaitTask(ctx, task.ID, func(t *proto.Task) bool {
+		killedErr = sessVars.SQLKiller.HandleSignal()
+		return killedErr != nil || t.IsDone()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if killedErr != nil {
+		if err := handle.CancelTask(ctx, taskKey); err != nil {
+			logger.Warn("cancel distributed task failed", zap.Error(err))
+		}
+		return nil, killedErr
+	}
+	if found.State != proto.TaskStateSucceed {
+		return nil, errors.Errorf("distributed task %d stopped with state %s, err %v", found.ID, found.State, found.Error)
+	}
+	logger.Info("distributed task finished")
+	return found, nil
+}
+
+// getDistSubtasks gets the subtasks of the step of a finished task.
+func getDistSubtasks(ctx context.Context, taskID int64, step proto.Step) ([]*proto.Subtask, error) {
+	taskManager, err := storage.GetTaskManager()
+	if err != nil {
+		return nil, err
+	}
+	return taskManager.GetSubtasksWithHistory(ctx, taskID, step)
+}
+
+// splitKeyRangeByRegions splits the key range into ranges by the regions, each
+// range contains at most maxRegionsPerDistSubtask regions, and the ranges are
+// spread to the nodes as evenly as possible.
+func splitKeyRangeByRegions(ctx context.Context, store kv.Storage, startKey, endKey kv.Key, nodeCnt int) ([]kv.KeyRange, error) {
+	helperStore, ok := store.(helper.Storage)
+	if !ok {
+		return nil, errors.New("the store doesn't support splitting key range by regions")
+	}
+	regions, err := helperStore.GetRegionCache().LoadRegionsInKeyRange(tikv.NewBackofferWithVars(ctx, 20000, nil), startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(regions) == 0 {
+		return nil, nil
+	}
+	sort.Slice(regions, func(i, j int) bool {
+		return bytes.Compare(regions[i].StartKey(), regions[j].StartKey()) < 0
+	})
+	regionBatch := max(min(maxRegionsPerDistSubtask, len(regions)/max(nodeCnt, 1)), 1)
+	ranges := make([]kv.KeyRange, 0, (len(regions)+regionBatch-1)/regionBatch)
+	for i := 0; i < len(regions); i += regionBatch {
+		end := min(i+regionBatch, len(regions))
+		r := kv.KeyRange{StartKey: regions[i].StartKey(), EndKey: regions[end-1].EndKey()}
+		if i == 0 {
+			r.StartKey = startKey
+		}
+		if end == len(regions) {
+			r.EndKey = endKey
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// getStoreOfTaskHandle gets the store from the session of the task handle.
+func getStoreOfTaskHandle(taskHandle storage.TaskHandle) (kv.Storage, error) {
+	var store kv.Storage
+	err := taskHandle.WithNewSession(func(se sessionctx.Context) error {
+		store = se.GetStore()
+		return nil
+	})
+	return store, err
+}
+
+// isRetryableDistTaskError checks whether the error of the distributed tasks
+// submitted by the executors is retryable, the subtask or task fails at once
+// on other errors, instead of retrying forever.
+func isRetryableDistTaskError(err error) bool {
+	return common.IsRetryableError(err)
+}
+
+// regionSplitRange is a key range of a task which is split into subtasks by
+// regions, newSubtaskMeta builds the subtask meta of each split range.
+type regionSplitRange struct {
+	startKey       kv.Key
+	endKey         kv.Key
+	newSubtaskMeta func(r kv.KeyRange) any
+}
+
+// regionSplitSchedulerExt is the scheduler extension of the tasks whose subtasks
+// of the first step are generated by splitting the key ranges by regions, such
+// as the distributed ANALYZE and ADMIN CHECK/CHECKSUM TABLE. It keeps no state
+// in memory, so the task can be resumed after the owner changes.
+type regionSplitSchedulerExt struct {
+	// steps are the steps of the task after proto.StepInit in order.
+	steps []proto.Step
+	// getRanges gets the key ranges to split from the task meta.
+	getRanges func(task *proto.Task) ([]regionSplitRange, error)
+	// generateNextSubtasks generates the subtasks of the steps after the first
+	// one, it's nil if the task has only one step.
+	generateNextSubtasks func(ctx context.Context, taskHandle storage.TaskHandle, task *proto.Task, nextStep proto.Step) ([][]byte, error)
+}
+
+var _ scheduler.Extension = (*regionSplitSchedulerExt)(nil)
+
+func newRegionSplitScheduler(ctx context.Context, task *proto.Task, param scheduler.Param, ext *regionSplitSchedulerExt) scheduler.Scheduler {
+	s := scheduler.NewBaseScheduler(ctx, task, param)
+	s.Extension = ext
+	return s
+}
+
+// OnTick implements scheduler.Extension interface.
+func (*regionSplitSchedulerExt) OnTick(_ context.Context, _ *proto.Task) {}
+
+// OnNextSubtasksBatch implements scheduler.Extension interface.
+func (ext *regionSplitSchedulerExt) OnNextSubtasksBatch(
+	ctx context.Context,
+	taskHandle storage.TaskHandle,
+	task *proto.Task,
+	execIDs []string,
+	nextStep proto.Step,
+) ([][]byte, error) {
+	if nextStep != ext.steps[0] {
+		if ext.generateNextSubtasks == nil {
+			return nil, nil
+		}
+		return ext.generateNextSubtasks(ctx, taskHandle, task, nextStep)
+	}
+	ranges, err := ext.getRanges(task)
+	if err != nil {
+		return nil, err
+	}
+	store, err := getStoreOfTaskHandle(taskHandle)
+	if err != nil {
+		return nil, err
+	}
+	subtaskMetas := make([][]byte, 0, len(ranges))
+	for _, rr := range ranges {
+		splitRanges, err := splitKeyRangeByRegions(ctx, store, rr.startKey, rr.endKey, len(execIDs))
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range splitRanges {
+			metaBytes, err := json.Marshal(rr.newSubtaskMeta(r))
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			subtaskMetas = append(subtaskMetas, metaBytes)
+		}
+	}
+	return subtaskMetas, nil
+}
+
+// OnDone implements scheduler.Extension interface.
+func (*regionSplitSchedulerExt) OnDone(_ context.Context, _ storage.TaskHandle, _ *proto.Task) error {
+	return nil
+}
+
+// GetEligibleInstances implements scheduler.Extension interface.
+func (*regionSplitSchedulerExt) GetEligibleInstances(_ context.Context, _ *proto.Task) ([]string, error) {
+	return nil, nil
+}
+
+// IsRetryableErr implements scheduler.Extension interface.
+func (*regionSplitSchedulerExt) IsRetryableErr(err error) bool {
+	return isRetryableDistTaskError(err)
+}
+
+// GetNextStep implements scheduler.Extension interface.
+func (ext *regionSplitSchedulerExt) GetNextStep(task *proto.Task) proto.Step {
+	if task.Step == proto.StepInit {
+		return ext.steps[0]
+	}
+	for i, step := range ext.steps[:len(ext.steps)-1] {
+		if step == task.Step {
+			return ext.steps[i+1]
+		}
+	}
+	return proto.StepDone
+}
@@ -0,0 +1,498 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+)
+
+func getEventTimerClient(sctx sessionctx.Context) (timerapi.TimerClient, error) {
+	scheduler := domain.GetDomain(sctx).EventScheduler()
+	if scheduler == nil {
+		return nil, errors.New("event scheduler is not started")
+	}
+	return scheduler.TimerClient(), nil
+}
+
+func (e *SimpleExec) executeCreateEvent(ctx context.Context, s *ast.CreateEventStmt) error {
+	cli, err := getEventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+
+	if err = e.checkEventSchemaExists(s.EventName.Schema); err != nil {
+		return err
+	}
+
+	sessVars := e.Ctx().GetSessionVars()
+	data := &eventscheduler.EventTimerData{
+		Schema:   s.EventName.Schema.O,
+		Name:     s.EventName.Name.O,
+		Comment:  s.Comment,
+		Preserve: s.Completion == ast.EventCompletionPreserve,
+	}
+	if err = e.setEventDefiner(data, s.Definer); err != nil {
+		return err
+	}
+	if err = e.setEventEnv(ctx, data, s.Body); err != nil {
+		return err
+	}
+
+	now := time.Now().Truncate(time.Second)
+	if err = e.evalEventSchedule(data, s.Schedule, now); err != nil {
+		return err
+	}
+	data.Created, data.LastAltered = now, now
+
+	enable := s.Status != ast.EventStatusDisable
+	if data.IsExpired(now) {
+		if !data.Preserve {
+			sessVars.StmtCtx.AppendNote(exeerrors.ErrEventCannotCreateInThePast.FastGenByArgs())
+			return nil
+		}
+		sessVars.StmtCtx.AppendNote(exeerrors.ErrEventExecTimeInThePast.FastGenByArgs())
+		enable = false
+	}
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalEventScheduler)
+	if _, err = eventscheduler.CreateEvent(ctx, cli, data, enable); err != nil {
+		if errors.ErrorEqual(err, timerapi.ErrTimerExists) {
+			err = exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(s.EventName.Name.O)
+			if s.IfNotExists {
+				sessVars.StmtCtx.AppendNote(err)
+				return nil
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func (e *SimpleExec) executeAlterEvent(ctx context.Context, s *ast.AlterEventStmt) error {
+	cli, err := getEventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalEventScheduler)
+	event, err := eventscheduler.GetEvent(ctx, cli, s.EventName.Schema.O, s.EventName.Name.O)
+	if err != nil {
+		return err
+	}
+	if event == nil {
+		return exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(s.EventName.Name.O)
+	}
+
+	data := *event.EventTimerData
+	if s.NewName != nil {
+		if s.NewName.Schema.L == s.EventName.Schema.L && s.NewName.Name.L == s.EventName.Name.L {
+			return exeerrors.ErrEventSameName.GenWithStackByArgs()
+		}
+		if err = e.checkEventSchemaExists(s.NewName.Schema); err != nil {
+			return err
+		}
+		data.Schema, data.Name = s.NewName.Schema.O, s.NewName.Name.O
+	}
+
+	// Like MySQL, the user altering the event becomes its definer unless the DEFINER clause specifies another one,
+	// which requires SUPER. Otherwise a user with the EVENT privilege could run a new body as the stored definer.
+	if err = e.setEventDefiner(&data, s.Definer); err != nil {
+		return err
+	}
+
+	if s.Body != nil {
+		if err = e.setEventEnv(ctx, &data, s.Body); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().Truncate(time.Second)
+	scheduleChanged := s.Schedule != nil
+	if scheduleChanged {
+		if err = e.evalEventSchedule(&data, s.Schedule, now); err != nil {
+			return err
+		}
+	}
+
+	switch s.Completion {
+	case ast.EventCompletionPreserve:
+		data.Preserve = true
+	case ast.EventCompletionNotPreserve:
+		data.Preserve = false
+	}
+
+	if s.Comment != nil {
+		data.Comment = *s.Comment
+	}
+	data.LastAltered = now
+
+	enable := event.Enabled()
+	switch s.Status {
+	case ast.EventStatusEnable:
+		enable = true
+	case ast.EventStatusDisable:
+		enable = false
+	}
+
+	if scheduleChanged && data.IsExpired(now) {
+		sessVars := e.Ctx().GetSessionVars()
+		if !data.Preserve {
+			sessVars.StmtCtx.AppendNote(exeerrors.ErrEventCannotAlterInThePast.FastGenByArgs())
+			return nil
+		}
+		sessVars.StmtCtx.AppendNote(exeerrors.ErrEventExecTimeInThePast.FastGenByArgs())
+		enable = false
+	}
+
+	if s.NewName != nil {
+		err = eventscheduler.RenameEvent(ctx, cli, event, &data, enable, scheduleChanged)
+		if errors.ErrorEqual(err, timerapi.ErrTimerExists) {
+			return exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(s.NewName.Name.O)
+		}
+		return err
+	}
+
+	event.EventTimerData = &data
+	return eventscheduler.UpdateEvent(ctx, cli, event, enable, scheduleChanged)
+}
+
+func (e *SimpleExec) executeDropEvent(ctx context.Context, s *ast.DropEventStmt) error {
+	cli, err := getEventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalEventScheduler)
+	dropped, err := eventscheduler.DropEvent(ctx, cli, s.EventName.Schema.O, s.EventName.Name.O)
+	if err != nil {
+		return err
+	}
+
+	if !dropped {
+		err = exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(s.EventName.Name.O)
+		if s.IfExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// dropSchemaEvents drops all the events in the schema, it is called after the schema is dropped.
+func dropSchemaEvents(sctx sessionctx.Context, schema string) error {
+	scheduler := domain.GetDomain(sctx).EventScheduler()
+	if scheduler == nil {
+		return nil
+	}
+
+	cli := scheduler.TimerClient()
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalEventScheduler)
+	events, err := eventscheduler.ListEvents(ctx, cli, schema)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if _, err = cli.DeleteTimer(ctx, event.Timer.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *SimpleExec) checkEventSchemaExists(schema model.CIStr) error {
+	is := e.Ctx().GetInfoSchema().(infoschema.InfoSchema)
+	if _, ok := is.SchemaByName(schema); !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema.O)
+	}
+	return nil
+}
+
+func (e *SimpleExec) setEventDefiner(data *eventscheduler.EventTimerData, definer *auth.UserIdentity) error {
+	if definer == nil || definer.CurrentUser {
+		definer = e.Ctx().GetSessionVars().User
+	}
+	if definer == nil {
+		return errors.New("the definer of the event is not specified")
+	}
+
+	data.DefinerUser, data.DefinerHost = definer.Username, definer.Hostname
+	if definer.AuthUsername != "" || definer.AuthHostname != "" {
+		data.DefinerUser, data.DefinerHost = definer.AuthUsername, definer.AuthHostname
+	}
+	return nil
+}
+
+// setEventEnv sets the body of the event, and the session environment to execute it.
+func (e *SimpleExec) setEventEnv(ctx context.Context, data *eventscheduler.EventTimerData, body ast.StmtNode) error {
+	sessVars := e.Ctx().GetSessionVars()
+	charset, collation := sessVars.GetCharsetInfo()
+
+	// The text of the body is the rest of the original SQL, which may contain the following statements.
+	// Parse it again to take the text of the first statement only.
+	p := parser.New()
+	p.SetSQLMode(sessVars.SQLMode)
+	p.SetParserConfig(sessVars.BuildParserConfig())
+	stmts, _, err := p.ParseSQL(body.Text(), parser.CharsetConnection(charset), parser.CollationConnection(collation))
+	if err != nil {
+		return err
+	}
+	if len(stmts) == 0 {
+		return errors.New("the body of the event should not be empty")
+	}
+
+	timeZone, err := sessVars.GetSessionOrGlobalSystemVar(ctx, variable.TimeZone)
+	if err != nil {
+		return err
+	}
+
+	data.Body = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(stmts[0].Text()), ";"))
+	data.SQLMode, _ = sessVars.GetSystemVar(variable.SQLModeVar)
+	data.TimeZone = timeZone
+	data.Charset, data.Collation = charset, collation
+	return nil
+}
+
+func (e *SimpleExec) evalEventSchedule(data *eventscheduler.EventTimerData, schedule *ast.EventSchedule, now time.Time) (err error) {
+	data.ExecuteAt, data.Starts, data.Ends = time.Time{}, time.Time{}, time.Time{}
+	data.IntervalValue, data.IntervalField, data.Interval = "", "", 0
+	if schedule.At != nil {
+		data.ExecuteAt, err = e.evalEventTime(schedule.At)
+		return err
+	}
+
+	switch schedule.EveryUnit {
+	case ast.TimeUnitMonth, ast.TimeUnitQuarter, ast.TimeUnitYear, ast.TimeUnitYearMonth:
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs("EVERY with the unit " + schedule.EveryUnit.String())
+	case ast.TimeUnitMicrosecond, ast.TimeUnitSecondMicrosecond, ast.TimeUnitMinuteMicrosecond,
+		ast.TimeUnitHourMicrosecond, ast.TimeUnitDayMicrosecond:
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs("EVERY with the unit " + schedule.EveryUnit.String())
+	}
+
+	val, err := expression.EvalAstExpr(e.Ctx(), schedule.Every)
+	if err != nil {
+		return err
+	}
+	if val.IsNull() {
+		return exeerrors.ErrEventIntervalNotPositiveOrTooBig.GenWithStackByArgs()
+	}
+	str, err := val.ToString()
+	if err != nil {
+		return err
+	}
+	d, err := types.ExtractDurationValue(schedule.EveryUnit.String(), str)
+	if err != nil || d.Duration <= 0 || d.Duration%time.Second != 0 {
+		return exeerrors.ErrEventIntervalNotPositiveOrTooBig.GenWithStackByArgs()
+	}
+	data.IntervalValue, data.IntervalField, data.Interval = str, schedule.EveryUnit.String(), d.Duration
+
+	data.Starts = now
+	if schedule.Starts != nil {
+		if data.Starts, err = e.evalEventTime(schedule.Starts); err != nil {
+			return err
+		}
+	}
+
+	if schedule.Ends != nil {
+		if data.Ends, err = e.evalEventTime(schedule.Ends); err != nil {
+			return err
+		}
+		if data.Ends.Before(data.Starts) {
+			return exeerrors.ErrEventEndsBeforeStarts.GenWithStackByArgs()
+		}
+	}
+	return nil
+}
+
+func (e *SimpleExec) evalEventTime(expr ast.ExprNode) (time.Time, error) {
+	sessVars := e.Ctx().GetSessionVars()
+	val, err := expression.EvalAstExpr(e.Ctx(), expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	str, _ := val.ToString()
+	val, err = val.ConvertTo(sessVars.StmtCtx.TypeCtx(), types.NewFieldType(mysql.TypeDatetime))
+	if err != nil || val.IsNull() {
+		return time.Time{}, types.ErrWrongValue.GenWithStackByArgs("DATETIME", str)
+	}
+
+	t, err := val.GetMysqlTime().GoTime(sessVars.Location())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t, nil
+}
+
+// eventDatetime returns the time in the location of the session, or nil if the time is zero.
+func eventDatetime(t time.Time, loc *time.Location) any {
+	if t.IsZero() {
+		return nil
+	}
+	return types.NewTime(types.FromGoTime(t.In(loc)), mysql.TypeDatetime, types.DefaultFsp)
+}
+
+func eventTypeString(event *eventscheduler.Event) string {
+	if event.IsOneTime() {
+		return "ONE TIME"
+	}
+	return "RECURRING"
+}
+
+func eventStatusString(event *eventscheduler.Event) string {
+	if event.Enabled() {
+		return "ENABLED"
+	}
+	return "DISABLED"
+}
+
+func eventOnCompletionString(event *eventscheduler.Event) string {
+	if event.Preserve {
+		return "PRESERVE"
+	}
+	return "NOT PRESERVE"
+}
+
+// listVisibleEvents lists the events in the schema which the current user has the EVENT privilege on.
+// If the schema is empty, the events in all schemas are listed.
+func listVisibleEvents(ctx context.Context, sctx sessionctx.Context, schema string) ([]*eventscheduler.Event, error) {
+	scheduler := domain.GetDomain(sctx).EventScheduler()
+	if scheduler == nil {
+		return nil, nil
+	}
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalEventScheduler)
+	events, err := eventscheduler.ListEvents(ctx, scheduler.TimerClient(), schema)
+	if err != nil {
+		return nil, err
+	}
+
+	checker := privilege.GetPrivilegeManager(sctx)
+	visible := events[:0]
+	for _, event := range events {
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, strings.ToLower(event.Schema), "", "", mysql.EventPriv) {
+			continue
+		}
+		visible = append(visible, event)
+	}
+	return visible, nil
+}
+
+func schemaCollation(is infoschema.InfoSchema, schema string) string {
+	if db, ok := is.SchemaByName(model.NewCIStr(schema)); ok && db.Collate != "" {
+		return db.Collate
+	}
+	return mysql.DefaultCollationName
+}
+
+func (e *ShowExec) fetchShowEvents(ctx context.Context) error {
+	events, err := listVisibleEvents(ctx, e.Ctx(), e.DBName.O)
+	if err != nil {
+		return err
+	}
+
+	loc := e.Ctx().GetSessionVars().Location()
+	for _, event := range events {
+		e.appendRow([]any{
+			event.Schema,
+			event.Name,
+			event.TimeZone,
+			event.Definer(),
+			eventTypeString(event),
+			eventDatetime(event.ExecuteAt, loc),
+			nullableString(event.IntervalValue),
+			nullableString(event.IntervalField),
+			eventDatetime(event.Starts, loc),
+			eventDatetime(event.Ends, loc),
+			eventStatusString(event),
+			0,
+			event.Charset,
+			event.Collation,
+			schemaCollation(e.is, event.Schema),
+		})
+	}
+	return nil
+}
+
+func nullableString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func (e *memtableRetriever) setDataFromEvents(ctx context.Context, sctx sessionctx.Context) error {
+	events, err := listVisibleEvents(ctx, sctx, "")
+	if err != nil {
+		return err
+	}
+
+	is := sctx.GetInfoSchema().(infoschema.InfoSchema)
+	loc := sctx.GetSessionVars().Location()
+	rows := make([][]types.Datum, 0, len(events))
+	for _, event := range events {
+		row := types.MakeDatums(
+			infoschema.CatalogVal,                    // EVENT_CATALOG
+			event.Schema,                             // EVENT_SCHEMA
+			event.Name,                               // EVENT_NAME
+			event.Definer(),                          // DEFINER
+			event.TimeZone,                           // TIME_ZONE
+			"SQL",                                    // EVENT_BODY
+			event.Body,                               // EVENT_DEFINITION
+			eventTypeString(event),                   // EVENT_TYPE
+			eventDatetime(event.ExecuteAt, loc),      // EXECUTE_AT
+			nullableString(event.IntervalValue),      // INTERVAL_VALUE
+			nullableString(event.IntervalField),      // INTERVAL_FIELD
+			event.SQLMode,                            // SQL_MODE
+			eventDatetime(event.Starts, loc),         // STARTS
+			eventDatetime(event.Ends, loc),           // ENDS
+			eventStatusString(event),                 // STATUS
+			eventOnCompletionString(event),           // ON_COMPLETION
+			eventDatetime(event.Created, loc),        // CREATED
+			eventDatetime(event.LastAltered, loc),    // LAST_ALTERED
+			eventDatetime(event.LastExecuted(), loc), // LAST_EXECUTED
+			event.Comment,                            // EVENT_COMMENT
+			0,                                        // ORIGINATOR
+			event.Charset,                            // CHARACTER_SET_CLIENT
+			event.Collation,                          // COLLATION_CONNECTION
+			schemaCollation(is, event.Schema),        // DATABASE_COLLATION
+		)
+		rows = append(rows, row)
+	}
+	e.rows = rows
+	return nil
+}
@@ -92,6 +92,7 @@ import (
 
 var (
 	_ exec.Executor = &CheckTableExec{}
+	_ exec.Executor = &DistCheckTableExec{}
 	_ exec.Executor = &aggregate.HashAggExec{}
 	_ exec.Executor = &HashJoinExec{}
 	_ exec.Executor = &IndexLookUpExecutor{}
@@ -115,6 +116,7 @@ var (
 	_ exec.Executor = &UnionExec{}
 	_ exec.Executor = &FastCheckTableExec{}
 	_ exec.Executor = &AdminShowBDRRoleExec{}
+	_ exec.Executor = &AdminShowDistTasksExec{}
 
 	// GlobalMemoryUsageTracker is the ancestor of all the Executors' memory tracker and GlobalMemory Tracker
 	GlobalMemoryUsageTracker *memory.Tracker
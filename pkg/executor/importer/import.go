@@ -38,7 +38,9 @@ import (
 	tidb "github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/ddl/util"
 	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/domain/resourcegroup"
 	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/infoschema"
 	tidbkv "github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
@@ -60,6 +62,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/stringutil"
 	kvconfig "github.com/tikv/client-go/v2/config"
+	kvutil "github.com/tikv/client-go/v2/util"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -73,6 +76,10 @@ const (
 	DataFormatSQL = "sql"
 	// DataFormatParquet represents the data source file of IMPORT INTO is parquet.
 	DataFormatParquet = "parquet"
+	// DataFormatJSONL represents the data source file of IMPORT INTO is JSON-lines.
+	DataFormatJSONL = "jsonl"
+	// DataFormatAvro represents the data source file of IMPORT INTO is avro object container file.
+	DataFormatAvro = "avro"
 
 	// DefaultDiskQuota is the default disk quota for IMPORT INTO
 	DefaultDiskQuota = config.ByteSize(50 << 30) // 50GiB
@@ -96,6 +103,7 @@ const (
 	detachedOption              = "detached"
 	disableTiKVImportModeOption = "disable_tikv_import_mode"
 	cloudStorageURIOption       = "cloud_storage_uri"
+	masterKeyFileOption         = "encryption_master_key_file"
 	// used for test
 	maxEngineSizeOption = "__max_engine_size"
 )
@@ -120,6 +128,7 @@ var (
 		disableTiKVImportModeOption: false,
 		maxEngineSizeOption:         true,
 		cloudStorageURIOption:       true,
+		masterKeyFileOption:         true,
 	}
 
 	csvOnlyOptions = map[string]struct{}{
@@ -137,10 +146,11 @@ var (
 	LoadDataReadBlockSize = int64(config.ReadBlockSize)
 
 	supportedSuffixForServerDisk = []string{
-		".csv", ".sql", ".parquet",
+		".csv", ".sql", ".parquet", ".jsonl", ".ndjson", ".avro",
 		".gz", ".gzip",
 		".zstd", ".zst",
 		".snappy",
+		".lz4",
 	}
 )
 
@@ -208,6 +218,9 @@ type Plan struct {
 	DisableTiKVImportMode bool
 	MaxEngineSize         config.ByteSize
 	CloudStorageURI       string
+	// MasterKeyFile is the file of the master key to encrypt the intermediate files of global sort, the file should
+	// be at the same path on all the TiDB nodes.
+	MasterKeyFile string
 
 	// used for checksum in physical mode
 	DistSQLScanConcurrency int
@@ -219,6 +232,10 @@ type Plan struct {
 	// the user who executes the statement, in the form of user@host
 	// only initialized for IMPORT INTO
 	User string `json:"-"`
+	// ResourceGroupName is the resource group which the import runs in, it's
+	// the resource group which the lightning background tasks are assigned to
+	// if any, else the resource group of the user session.
+	ResourceGroupName string
 
 	IsRaftKV2 bool
 	// total data file size in bytes.
@@ -347,6 +364,15 @@ func NewPlanFromLoadDataPlan(userSctx sessionctx.Context, plan *plannercore.Load
 	}, nil
 }
 
+func getImportResourceGroupName(userSctx sessionctx.Context) string {
+	groupName := userSctx.GetSessionVars().StmtCtx.ResourceGroupName
+	is, ok := userSctx.GetDomainInfoSchema().(infoschema.InfoSchema)
+	if !ok {
+		return groupName
+	}
+	return resourcegroup.GetBackgroundGroupName(is.AllResourceGroups(), kvutil.ExplicitTypeLightning, groupName)
+}
+
 // NewImportPlan creates a new import into plan.
 func NewImportPlan(ctx context.Context, userSctx sessionctx.Context, plan *plannercore.ImportInto, tbl table.Table) (*Plan, error) {
 	var format string
@@ -386,6 +412,7 @@ func NewImportPlan(ctx context.Context, userSctx sessionctx.Context, plan *plann
 		DistSQLScanConcurrency: userSctx.GetSessionVars().DistSQLScanConcurrency(),
 		InImportInto:           true,
 		User:                   userSctx.GetSessionVars().User.String(),
+		ResourceGroupName:      getImportResourceGroupName(userSctx),
 	}
 	if err := p.initOptions(ctx, userSctx, plan.Options); err != nil {
 		return nil, err
@@ -475,7 +502,8 @@ func (e *LoadDataController) checkFieldParams() error {
 		return exeerrors.ErrLoadDataEmptyPath
 	}
 	if e.InImportInto {
-		if e.Format != DataFormatCSV && e.Format != DataFormatParquet && e.Format != DataFormatSQL {
+		if e.Format != DataFormatCSV && e.Format != DataFormatParquet && e.Format != DataFormatSQL &&
+			e.Format != DataFormatJSONL && e.Format != DataFormatAvro {
 			return exeerrors.ErrLoadDataUnsupportedFormat.GenWithStackByArgs(e.Format)
 		}
 	} else {
@@ -691,6 +719,19 @@ func (p *Plan) initOptions(ctx context.Context, seCtx sessionctx.Context, option
 		}
 		p.CloudStorageURI = v
 	}
+	if opt, ok := specifiedOptions[masterKeyFileOption]; ok {
+		v, err := optAsString(opt)
+		if err != nil || v == "" {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		if p.CloudStorageURI == "" {
+			return exeerrors.ErrLoadDataUnsupportedOption.FastGenByArgs(opt.Name, "local sort")
+		}
+		if _, err = storage.NewFileMasterKey(v); err != nil {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		p.MasterKeyFile = v
+	}
 	if opt, ok := specifiedOptions[maxEngineSizeOption]; ok {
 		v, err := optAsString(opt)
 		if err != nil {
@@ -933,6 +974,13 @@ func (e *LoadDataController) InitDataStore(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		if e.Plan.MasterKeyFile != "" {
+			masterKey, err3 := storage.NewFileMasterKey(e.Plan.MasterKeyFile)
+			if err3 != nil {
+				return exeerrors.ErrInvalidOptionVal.FastGenByArgs(masterKeyFileOption)
+			}
+			s = storage.WithEncryption(s, masterKey)
+		}
 		e.GlobalSortStore = s
 	}
 	return nil
@@ -1085,6 +1133,10 @@ func (e *LoadDataController) getSourceType() mydump.SourceType {
 	switch e.Format {
 	case DataFormatParquet:
 		return mydump.SourceTypeParquet
+	case DataFormatJSONL:
+		return mydump.SourceTypeJSONL
+	case DataFormatAvro:
+		return mydump.SourceTypeAvro
 	case DataFormatDelimitedData, DataFormatCSV:
 		return mydump.SourceTypeCSV
 	default:
@@ -1163,15 +1215,45 @@ func (e *LoadDataController) GetParser(
 			reader,
 			dataFileInfo.Remote.Path,
 		)
+	case DataFormatJSONL:
+		parser = mydump.NewJSONLParser(
+			ctx,
+			reader,
+			LoadDataReadBlockSize,
+			nil,
+		)
+	case DataFormatAvro:
+		parser, err = mydump.NewAvroParser(ctx, reader)
 	}
 	if err != nil {
 		return nil, exeerrors.ErrLoadDataWrongFormatConfig.GenWithStack(err.Error())
 	}
+	if e.Format == DataFormatJSONL || e.Format == DataFormatAvro {
+		// the fields of JSON-lines and avro files are mapped by name
+		parser.SetColumns(e.fieldMappingNames())
+	}
 	parser.SetLogger(litlog.Logger{Logger: logutil.Logger(ctx)})
 
 	return parser, nil
 }
 
+// fieldMappingNames returns the names of the data file fields in the order of
+// FieldMappings, the name of a user variable is the field name it maps to.
+func (e *LoadDataController) fieldMappingNames() []string {
+	names := make([]string, 0, len(e.FieldMappings))
+	for _, m := range e.FieldMappings {
+		switch {
+		case m.Column != nil:
+			names = append(names, m.Column.Name.L)
+		case m.UserVar != nil:
+			names = append(names, strings.ToLower(m.UserVar.Name))
+		default:
+			names = append(names, "")
+		}
+	}
+	return names
+}
+
 // HandleSkipNRows skips the first N rows of the data file.
 func (e *LoadDataController) HandleSkipNRows(parser mydump.Parser) error {
 	// handle IGNORE N LINES
@@ -1280,6 +1362,8 @@ func (e *LoadDataController) getLocalBackendCfg(pdAddr, dataDir string) local.Ba
 		KeyspaceName:                tidb.GetGlobalKeyspaceName(),
 		PausePDSchedulerScope:       config.PausePDSchedulerScopeTable,
 		DisableAutomaticCompactions: true,
+		ResourceGroupName:           e.ResourceGroupName,
+		TaskType:                    kvutil.ExplicitTypeLightning,
 	}
 	if e.IsRaftKV2 {
 		backendConfig.RaftKV2SwitchModeDuration = config.DefaultSwitchTiKVModeInterval
@@ -23,6 +23,7 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -166,6 +167,31 @@ func TestInitOptionsPositiveCase(t *testing.T) {
 	err = plan.initOptions(ctx, sctx, convertOptions(stmt.(*ast.ImportIntoStmt).Options))
 	require.NoError(t, err, sql4)
 	require.Equal(t, "", plan.CloudStorageURI, sql4)
+
+	// encrypt the intermediate files of global sort
+	keyFile := filepath.Join(t.TempDir(), "master.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(strings.Repeat("ab", 32)), 0o600))
+	sql5 := sql + ", " + masterKeyFileOption + "='" + keyFile + "'"
+	stmt, err = p.ParseOneStmt(sql5, "", "")
+	require.NoError(t, err, sql5)
+	plan = &Plan{Format: DataFormatCSV}
+	err = plan.initOptions(ctx, sctx, convertOptions(stmt.(*ast.ImportIntoStmt).Options))
+	require.NoError(t, err, sql5)
+	require.Equal(t, keyFile, plan.MasterKeyFile, sql5)
+	// the master key file is only used by global sort
+	sql6 := sql4 + ", " + masterKeyFileOption + "='" + keyFile + "'"
+	stmt, err = p.ParseOneStmt(sql6, "", "")
+	require.NoError(t, err, sql6)
+	plan = &Plan{Format: DataFormatCSV}
+	err = plan.initOptions(ctx, sctx, convertOptions(stmt.(*ast.ImportIntoStmt).Options))
+	require.ErrorIs(t, err, exeerrors.ErrLoadDataUnsupportedOption, sql6)
+	// the master key file must be valid
+	sql7 := sql + ", " + masterKeyFileOption + "='" + keyFile + ".not-exist'"
+	stmt, err = p.ParseOneStmt(sql7, "", "")
+	require.NoError(t, err, sql7)
+	plan = &Plan{Format: DataFormatCSV}
+	err = plan.initOptions(ctx, sctx, convertOptions(stmt.(*ast.ImportIntoStmt).Options))
+	require.ErrorIs(t, err, exeerrors.ErrInvalidOptionVal, sql7)
 }
 
 func TestAdjustOptions(t *testing.T) {
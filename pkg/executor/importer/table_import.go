@@ -440,8 +440,8 @@ func (ti *TableImporter) getTotalRawFileSize(indexCnt int64) int64 {
 	var totalSize int64
 	for _, file := range ti.dataFiles {
 		size := file.RealSize
-		if file.Type == mydump.SourceTypeParquet {
-			// parquet file is compressed, thus estimates with a factor of 2
+		if file.Type == mydump.SourceTypeParquet || file.Type == mydump.SourceTypeAvro {
+			// parquet and avro files are compressed, thus estimates with a factor of 2
 			size *= 2
 		}
 		totalSize += size
@@ -746,7 +746,13 @@ func checksumTable(ctx context.Context, se sessionctx.Context, plan *Plan, logge
 				se.GetSessionVars().SetDistSQLScanConcurrency(distSQLScanConcurrency)
 			}()
 
-			// TODO: add resource group name
+			if plan.ResourceGroupName != "" {
+				groupName := se.GetSessionVars().ResourceGroupName
+				se.GetSessionVars().ResourceGroupName = plan.ResourceGroupName
+				defer func() {
+					se.GetSessionVars().ResourceGroupName = groupName
+				}()
+			}
 
 			rs, err := sqlexec.ExecSQL(ctx, se, sql)
 			if err != nil {
@@ -16,6 +16,7 @@ package executor
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/hex"
 	"encoding/json"
@@ -191,9 +192,13 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 		case infoschema.ClusterTableMemoryUsageOpsHistory:
 			err = e.setDataForClusterMemoryUsageOpsHistory(sctx)
 		case infoschema.TableResourceGroups:
-			err = e.setDataFromResourceGroups()
+			err = e.setDataFromResourceGroups(sctx)
+		case infoschema.TableResourceGroupBackgroundUsage:
+			err = e.setDataFromResourceGroupBackgroundUsage(sctx)
 		case infoschema.TableRunawayWatches:
 			err = e.setDataFromRunawayWatches(sctx)
+		case infoschema.TableEvents:
+			err = e.setDataFromEvents(ctx, sctx)
 		case infoschema.TableCheckConstraints:
 			err = e.setDataFromCheckConstraints(sctx, dbs)
 		case infoschema.TableTiDBCheckConstraints:
@@ -3336,16 +3341,19 @@ func (e *memtableRetriever) setDataFromRunawayWatches(sctx sessionctx.Context) e
 	watches := do.GetRunawayWatchList()
 	rows := make([][]types.Datum, 0, len(watches))
 	for _, watch := range watches {
-		action := watch.Action
+		action := resourcegroup.RunawayActionName(watch.Action)
+		if watch.Action == resourcegroup.RunawayActionSwitchGroup {
+			action = fmt.Sprintf("%s(%s)", action, watch.SwitchGroupName)
+		}
 		row := types.MakeDatums(
 			watch.ID,
 			watch.ResourceGroupName,
 			watch.StartTime.Local().Format(time.DateTime),
 			watch.EndTime.Local().Format(time.DateTime),
-			rmpb.RunawayWatchType_name[int32(watch.Watch)],
+			resourcegroup.RunawayWatchTypeName(watch.Watch),
 			watch.WatchText,
 			watch.Source,
-			rmpb.RunawayAction_name[int32(action)],
+			action,
 		)
 		if watch.EndTime.Equal(resourcegroup.NullTime) {
 			row[3].SetString("UNLIMITED", mysql.DefaultCollationName)
@@ -3363,11 +3371,12 @@ const (
 	unlimitedFillRate = "UNLIMITED"
 )
 
-func (e *memtableRetriever) setDataFromResourceGroups() error {
+func (e *memtableRetriever) setDataFromResourceGroups(sctx sessionctx.Context) error {
 	resourceGroups, err := infosync.ListResourceGroups(context.TODO())
 	if err != nil {
 		return errors.Errorf("failed to access resource group manager, error message is %s", err.Error())
 	}
+	is := sessiontxn.GetTxnManager(sctx).GetTxnInfoSchema()
 	rows := make([][]types.Datum, 0, len(resourceGroups))
 	for _, group := range resourceGroups {
 		//mode := ""
@@ -3401,7 +3410,13 @@ func (e *memtableRetriever) setDataFromResourceGroups() error {
 		// convert background settings
 		bgBuilder := new(strings.Builder)
 		if setting := group.BackgroundSettings; setting != nil {
-			fmt.Fprintf(bgBuilder, "TASK_TYPES='%s'", strings.Join(setting.JobTypes, ","))
+			jobTypes := setting.JobTypes
+			// the background task types of the resource groups other than the
+			// default one are only stored in TiDB.
+			if info, ok := is.ResourceGroupByName(model.NewCIStr(group.Name)); ok && info.Background != nil {
+				jobTypes = info.Background.JobTypes
+			}
+			fmt.Fprintf(bgBuilder, "TASK_TYPES='%s'", strings.Join(jobTypes, ","))
 		}
 		background := bgBuilder.String()
 
@@ -3442,6 +3457,50 @@ func (e *memtableRetriever) setDataFromResourceGroups() error {
 	return nil
 }
 
+// setDataFromResourceGroupBackgroundUsage reports the consumption of the
+// background tasks recorded by this TiDB, one row for each background task type
+// assigned to a resource group other than the default one.
+func (e *memtableRetriever) setDataFromResourceGroupBackgroundUsage(sctx sessionctx.Context) error {
+	is := sessiontxn.GetTxnManager(sctx).GetTxnInfoSchema()
+	groups := is.AllResourceGroups()
+	slices.SortFunc(groups, func(a, b *model.ResourceGroupInfo) int {
+		return cmp.Compare(a.Name.L, b.Name.L)
+	})
+	usage := make(map[string]map[string]*rmpb.Consumption)
+	for _, u := range resourcegroup.GetBackgroundUsage() {
+		if usage[u.Group] == nil {
+			usage[u.Group] = make(map[string]*rmpb.Consumption)
+		}
+		u := u
+		usage[u.Group][u.TaskType] = &u.Consumption
+	}
+	rows := make([][]types.Datum, 0, len(groups))
+	for _, group := range groups {
+		if group.Name.L == resourcegroup.DefaultResourceGroupName || group.ResourceGroupSettings == nil || group.Background == nil {
+			continue
+		}
+		for _, taskType := range group.Background.JobTypes {
+			consumption := usage[group.Name.L][taskType]
+			if consumption == nil {
+				consumption = &rmpb.Consumption{}
+			}
+			rows = append(rows, types.MakeDatums(
+				group.Name.O,
+				taskType,
+				consumption.RRU,
+				consumption.WRU,
+				consumption.ReadBytes,
+				consumption.WriteBytes,
+				consumption.TotalCpuTimeMs,
+				consumption.KvReadRpcCount,
+				consumption.KvWriteRpcCount,
+			))
+		}
+	}
+	e.rows = rows
+	return nil
+}
+
 func (e *memtableRetriever) setDataFromKeywords() error {
 	rows := make([][]types.Datum, 0, len(parser.Keywords))
 	for _, kw := range parser.Keywords {
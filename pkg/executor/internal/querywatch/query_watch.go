@@ -17,6 +17,8 @@ package querywatch
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -61,7 +63,31 @@ func setWatchOption(ctx context.Context,
 		}
 	case ast.QueryWatchAction:
 		record.Action = rmpb.RunawayAction(op.IntValue)
+		if record.Action == resourcegroup.RunawayActionSwitchGroup {
+			record.SwitchGroupName = op.StrValue.L
+		}
 	case ast.QueryWatchType:
+		record.Watch = rmpb.RunawayWatchType(op.IntValue)
+		switch model.RunawayWatchType(op.IntValue) {
+		case model.WatchTable:
+			// the schema has been filled by the preprocessor.
+			record.WatchText = op.TableName.Schema.L + "." + op.TableName.Name.L
+			return nil
+		case model.WatchScannedKeys:
+			expr, err := expression.RewriteAstExpr(sctx, op.ExprValue, nil, nil, false)
+			if err != nil {
+				return err
+			}
+			threshold, isNull, err := expr.EvalInt(sctx, chunk.Row{})
+			if err != nil {
+				return err
+			}
+			if isNull || threshold <= 0 {
+				return errors.Errorf("the threshold of scanned keys must be a positive integer")
+			}
+			record.WatchText = strconv.FormatInt(threshold, 10)
+			return nil
+		}
 		expr, err := expression.RewriteAstExpr(sctx, op.ExprValue, nil, nil, false)
 		if err != nil {
 			return err
@@ -73,7 +99,15 @@ func setWatchOption(ctx context.Context,
 		if isNull {
 			return errors.Errorf("invalid watch text expression")
 		}
-		record.Watch = rmpb.RunawayWatchType(op.IntValue)
+		switch model.RunawayWatchType(op.IntValue) {
+		case model.WatchUser:
+			record.WatchText = strval
+			return nil
+		case model.WatchLike:
+			// the pattern is matched with the normalized SQL, which is in lower case.
+			record.WatchText = strings.ToLower(strval)
+			return nil
+		}
 		if op.BoolValue {
 			p := parser.New()
 			stmts, _, err := p.ParseSQL(strval)
@@ -131,6 +165,7 @@ func fromQueryWatchOptionList(ctx context.Context, sctx, newSctx sessionctx.Cont
 // validateWatchRecord follows several designs:
 //  1. If no resource group is set, the default resource group is used
 //  2. If no action is specified, the action of the resource group is used. If no, an error message is displayed.
+//  3. If the action is SWITCH_GROUP, the resource group to switch to must exist and be different from the watched one.
 func validateWatchRecord(record *resourcegroup.QuarantineRecord, client *rmclient.ResourceGroupsController) error {
 	if len(record.ResourceGroupName) == 0 {
 		record.ResourceGroupName = resourcegroup.DefaultResourceGroupName
@@ -148,6 +183,18 @@ func validateWatchRecord(record *resourcegroup.QuarantineRecord, client *rmclien
 		}
 		record.Action = rg.RunawaySettings.Action
 	}
+	if record.Action == resourcegroup.RunawayActionSwitchGroup {
+		if record.SwitchGroupName == record.ResourceGroupName {
+			return errors.Errorf("cannot switch to the resource group `%s` itself", record.SwitchGroupName)
+		}
+		switchGroup, err := client.GetResourceGroup(record.SwitchGroupName)
+		if err != nil {
+			return err
+		}
+		if switchGroup == nil {
+			return infoschema.ErrResourceGroupNotExists.GenWithStackByArgs(record.SwitchGroupName)
+		}
+	}
 	if record.Watch == rmpb.RunawayWatchType_NoneWatch {
 		return errors.Errorf("must specify watch type")
 	}
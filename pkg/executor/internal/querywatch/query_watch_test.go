@@ -125,3 +125,60 @@ func TestQueryWatch(t *testing.T) {
 	time.Sleep(1 * time.Second)
 	tk.MustGetErrCode("select * from test.t1", mysql.ErrResourceGroupQueryRunawayQuarantine)
 }
+
+func TestQueryWatchPatternRules(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1(a int)")
+	tk.MustExec("insert into t1 values(1)")
+	tk.MustExec("create table t2(a int)")
+	tk.MustExec("insert into t2 values(1)")
+	tk.MustExec("create table t3(a int)")
+	tk.MustExec("insert into t3 values(1)")
+	tk.MustExec("create resource group rg1 RU_PER_SEC=1000")
+	tk.MustExec("create resource group rg_low RU_PER_SEC=100 PRIORITY=LOW")
+
+	err := tk.QueryToErr("query watch add action SWITCH_GROUP(rg2) table t1")
+	require.ErrorContains(t, err, "the group rg2 does not exist")
+	err = tk.QueryToErr("query watch add resource group rg1 action SWITCH_GROUP(rg1) table t1")
+	require.ErrorContains(t, err, "cannot switch to the resource group `rg1` itself")
+	err = tk.QueryToErr("query watch add action KILL scanned_keys 0")
+	require.ErrorContains(t, err, "the threshold of scanned keys must be a positive integer")
+	err = tk.ExecToErr("query watch add action KILL table t4")
+	require.ErrorContains(t, err, "Table 'test.t4' doesn't exist")
+
+	tk.MustQuery("query watch add action KILL table t1").Check(testkit.Rows("1"))
+	tk.MustQuery("query watch add action KILL sql like 'select * from `t2`%'").Check(testkit.Rows("2"))
+	tk.MustQuery("query watch add action SWITCH_GROUP(rg_low) table test.t3").Check(testkit.Rows("3"))
+	tk.MustQuery("query watch add resource group rg1 action COOLDOWN user 'u1'").Check(testkit.Rows("4"))
+	tk.MustQuery("query watch add resource group rg1 action DRYRUN scanned_keys = 10000").Check(testkit.Rows("5"))
+
+	tryInterval := time.Millisecond * 200
+	maxWaitDuration := time.Second * 5
+	tk.EventuallyMustQueryAndCheck("select SQL_NO_CACHE resource_group_name, watch_text, action, watch, switch_group_name from mysql.tidb_runaway_watch order by id", nil,
+		testkit.Rows("default test.t1 3 4 ",
+			"default select * from `t2`% 3 6 ",
+			"default test.t3 4 4 rg_low",
+			"rg1 u1 2 5 ",
+			"rg1 10000 1 7 ",
+		), maxWaitDuration, tryInterval)
+	tk.EventuallyMustQueryAndCheck("select SQL_NO_CACHE resource_group_name, watch_text, action, watch from information_schema.runaway_watches order by id", nil,
+		testkit.Rows("default test.t1 Kill Table",
+			"default select * from `t2`% Kill Like",
+			"default test.t3 SwitchGroup(rg_low) Table",
+			"rg1 u1 CoolDown User",
+			"rg1 10000 DryRun ScannedKeys",
+		), maxWaitDuration, tryInterval)
+
+	tk.MustGetErrCode("select * from t1", mysql.ErrResourceGroupQueryRunawayQuarantine)
+	tk.MustGetErrCode("select * from t2 where a = 1", mysql.ErrResourceGroupQueryRunawayQuarantine)
+	tk.MustQuery("select * from t3").Check(testkit.Rows("1"))
+	tk.MustQuery("select /*+ resource_group(rg1) */ * from t1").Check(testkit.Rows("1"))
+
+	// test remove
+	tk.MustExec("query watch remove 1")
+	tk.EventuallyMustQueryAndCheck("select SQL_NO_CACHE count(*) from information_schema.runaway_watches where watch = 'Table'", nil,
+		testkit.Rows("1"), maxWaitDuration, tryInterval)
+	tk.MustQuery("select * from t1").Check(testkit.Rows("1"))
+}
@@ -453,7 +453,7 @@ func (w *encodeWorker) readOneBatchRows(ctx context.Context, parser mydump.Parse
 			}
 			return exeerrors.ErrLoadDataCantRead.GenWithStackByArgs(
 				err.Error(),
-				"Only the following formats delimited text file (csv, tsv), parquet, sql are supported. Please provide the valid source file(s)",
+				"Only the following formats delimited text file (csv, tsv), parquet, sql, jsonl, avro are supported. Please provide the valid source file(s)",
 			)
 		}
 		// rowCount will be used in fillRow(), last insert ID will be assigned according to the rowCount = 1.
@@ -228,7 +228,7 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowProcessList:
 		return e.fetchShowProcessList()
 	case ast.ShowEvents:
-		// empty result
+		return e.fetchShowEvents(ctx)
 	case ast.ShowStatsExtended:
 		return e.fetchShowStatsExtended()
 	case ast.ShowStatsMeta:
@@ -1342,6 +1342,52 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 		if err != nil {
 			return err
 		}
+
+		if tableInfo.TTLInfo.ArchiveURI != "" {
+			restoreCtx.WritePlain(" ")
+			err = restoreCtx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+				restoreCtx.WriteKeyWord("TTL_ARCHIVE")
+				restoreCtx.WritePlain("=")
+				restoreCtx.WriteString(ast.RedactURL(tableInfo.TTLInfo.ArchiveURI))
+				restoreCtx.WritePlain(" ")
+				restoreCtx.WriteKeyWord("TTL_ARCHIVE_FORMAT")
+				restoreCtx.WritePlain("=")
+				restoreCtx.WriteString(tableInfo.TTLInfo.GetArchiveFormat())
+				return nil
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if tableInfo.TTLInfo.PartitionLifecycle {
+			restoreCtx.WritePlain(" ")
+			err = restoreCtx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+				restoreCtx.WriteKeyWord("TTL_PARTITION_LIFECYCLE")
+				restoreCtx.WritePlain("=")
+				restoreCtx.WriteString("ON")
+				return nil
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if tableInfo.TTLInfo.Filter != "" {
+			restoreCtx.WritePlain(" ")
+			err = restoreCtx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+				restoreCtx.WriteKeyWord("TTL_FILTER")
+				restoreCtx.WritePlain("=")
+				restoreCtx.WriteString(tableInfo.TTLInfo.Filter)
+				return nil
+			})
+
+			if err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
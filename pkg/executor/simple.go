@@ -191,13 +191,19 @@ func (e *SimpleExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 	case *ast.ShutdownStmt:
 		err = e.executeShutdown()
 	case *ast.AdminStmt:
-		err = e.executeAdmin(x)
+		err = e.executeAdmin(ctx, x)
 	case *ast.SetResourceGroupStmt:
 		err = e.executeSetResourceGroupName(x)
 	case *ast.AlterRangeStmt:
 		err = e.executeAlterRange(x)
 	case *ast.DropQueryWatchStmt:
 		err = e.executeDropQueryWatch(x)
+	case *ast.CreateEventStmt:
+		err = e.executeCreateEvent(ctx, x)
+	case *ast.AlterEventStmt:
+		err = e.executeAlterEvent(ctx, x)
+	case *ast.DropEventStmt:
+		err = e.executeDropEvent(ctx, x)
 	}
 	e.done = true
 	return err
@@ -2720,6 +2726,8 @@ func (e *SimpleExec) autoNewTxn() bool {
 	// Statements that implicitly use or modify tables in the mysql database.
 	case *ast.CreateUserStmt, *ast.AlterUserStmt, *ast.DropUserStmt, *ast.RenameUserStmt, *ast.RevokeRoleStmt, *ast.GrantRoleStmt:
 		return true
+	case *ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt:
+		return true
 	// Transaction-control and locking statements.  BEGIN, LOCK TABLES, SET autocommit = 1 (if the value is not already 1), START TRANSACTION, UNLOCK TABLES.
 	// (handled in other place)
 	// Data loading statements. LOAD DATA
@@ -2781,7 +2789,7 @@ func (e *SimpleExec) executeSetSessionStates(ctx context.Context, s *ast.SetSess
 	return e.Ctx().DecodeSessionStates(ctx, e.Ctx(), &sessionStates)
 }
 
-func (e *SimpleExec) executeAdmin(s *ast.AdminStmt) error {
+func (e *SimpleExec) executeAdmin(ctx context.Context, s *ast.AdminStmt) error {
 	switch s.Tp {
 	case ast.AdminReloadStatistics:
 		return e.executeAdminReloadStatistics(s)
@@ -2791,6 +2799,8 @@ func (e *SimpleExec) executeAdmin(s *ast.AdminStmt) error {
 		return e.executeAdminSetBDRRole(s)
 	case ast.AdminUnsetBDRRole:
 		return e.executeAdminUnsetBDRRole()
+	case ast.AdminPauseDistTask, ast.AdminResumeDistTask, ast.AdminCancelDistTask, ast.AdminAlterDistTask:
+		return e.executeAdminDistTask(ctx, s)
 	}
 	return nil
 }
@@ -2823,6 +2833,7 @@ func (e *SimpleExec) executeAdminFlushPlanCache(s *ast.AdminStmt) error {
 		// Record the timestamp. When other sessions want to use the plan cache,
 		// it will check the timestamp first to decide whether the plan cache should be flushed.
 		domain.GetDomain(e.Ctx()).SetExpiredTimeStamp4PC(now)
+		core.GetInstancePlanCache().DeleteAll()
 	}
 	return nil
 }
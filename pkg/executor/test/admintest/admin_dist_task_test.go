@@ -0,0 +1,182 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admintest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/testutil"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/table/tables"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/logutil/consistency"
+	"github.com/pingcap/tidb/pkg/util/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminDistTask(t *testing.T) {
+	store, gm, ctx := testutil.InitTableTest(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, gm.InitMeta(ctx, ":4000", ""))
+
+	checkTask := func(id int64, state proto.TaskState, priority, concurrency int, subtasks string) {
+		rows := tk.MustQuery("admin show dist tasks").Rows()
+		for _, row := range rows {
+			if row[0] != fmt.Sprint(id) {
+				continue
+			}
+			require.Equal(t, []any{fmt.Sprint(id), "test", string(state), fmt.Sprint(priority), fmt.Sprint(concurrency), subtasks},
+				[]any{row[0], row[2], row[3], row[5], row[6], row[7]})
+			return
+		}
+		require.FailNow(t, "task not found", "task %d", id)
+	}
+
+	id, err := gm.CreateTask(ctx, "key1", "test", 1, []byte("test"))
+	require.NoError(t, err)
+	testutil.InsertSubtask(t, gm, id, proto.StepInit, ":4000", []byte("m"), proto.SubtaskStatePending, "test", 1)
+	testutil.InsertSubtask(t, gm, id, proto.StepInit, ":4000", []byte("m"), proto.SubtaskStatePending, "test", 1)
+	testutil.InsertSubtask(t, gm, id, proto.StepInit, ":4000", []byte("m"), proto.SubtaskStateSucceed, "test", 1)
+	tk.MustQuery("admin show dist tasks").CheckAt([]int{1, 4}, testkit.Rows("key1 -1"))
+	checkTask(id, proto.TaskStatePending, proto.NormalPriority, 1, "pending: 2, succeed: 1")
+
+	// alter pending task.
+	tk.MustExec(fmt.Sprintf("admin alter dist task %d priority = 10", id))
+	checkTask(id, proto.TaskStatePending, 10, 1, "pending: 2, succeed: 1")
+	tk.MustGetErrMsg(fmt.Sprintf("admin alter dist task %d priority = 0", id),
+		"distributed task priority should be in range [1, 1024]")
+	tk.MustGetErrMsg(fmt.Sprintf("admin alter dist task %d priority = 1025", id),
+		"distributed task priority should be in range [1, 1024]")
+	tk.MustGetErrMsg(fmt.Sprintf("admin alter dist task %d concurrency = 0", id),
+		"distributed task concurrency should be positive")
+	tk.MustContainErrMsg(fmt.Sprintf("admin alter dist task %d concurrency = 9999", id),
+		"task concurrency(9999) larger than cpu count")
+
+	// pause and resume.
+	tk.MustExec(fmt.Sprintf("admin pause dist task %d", id))
+	checkTask(id, proto.TaskStatePausing, 10, 1, "pending: 2, succeed: 1")
+	tk.MustGetErrMsg(fmt.Sprintf("admin pause dist task %d", id),
+		fmt.Sprintf("cannot pause distributed task %d in state pausing", id))
+	tk.MustGetErrMsg(fmt.Sprintf("admin alter dist task %d priority = 20", id),
+		fmt.Sprintf("cannot alter distributed task %d in state pausing", id))
+	require.NoError(t, gm.PausedTask(ctx, id))
+	tk.MustGetErrMsg(fmt.Sprintf("admin cancel dist task %d", id),
+		fmt.Sprintf("cannot cancel distributed task %d in state paused", id))
+	tk.MustExec(fmt.Sprintf("admin alter dist task %d concurrency = 1, priority = 20", id))
+	checkTask(id, proto.TaskStatePaused, 20, 1, "pending: 2, succeed: 1")
+	tk.MustExec(fmt.Sprintf("admin resume dist task %d", id))
+	checkTask(id, proto.TaskStateResuming, 20, 1, "pending: 2, succeed: 1")
+	tk.MustGetErrMsg(fmt.Sprintf("admin resume dist task %d", id),
+		fmt.Sprintf("cannot resume distributed task %d in state resuming", id))
+
+	// cancel.
+	id2, err := gm.CreateTask(ctx, "key2", "test", 1, []byte("test"))
+	require.NoError(t, err)
+	tk.MustExec(fmt.Sprintf("admin cancel dist task %d", id2))
+	checkTask(id2, proto.TaskStateCancelling, proto.NormalPriority, 1, "")
+	tk.MustGetErrMsg(fmt.Sprintf("admin cancel dist task %d", id2),
+		fmt.Sprintf("cannot cancel distributed task %d in state cancelling", id2))
+
+	tk.MustGetErrMsg("admin pause dist task 100", "distributed task 100 not found")
+	tk.MustGetErrMsg("admin alter dist task 100 priority = 1", "distributed task 100 not found")
+
+	// only the users with SUPER privilege can manage the distributed tasks.
+	tk.MustExec("create user 'dist_task_user'@'%'")
+	userTk := testkit.NewTestKit(t, store)
+	require.NoError(t, userTk.Session().Auth(&auth.UserIdentity{Username: "dist_task_user", Hostname: "%"}, nil, nil, nil))
+	for _, sql := range []string{
+		"admin show dist tasks",
+		fmt.Sprintf("admin pause dist task %d", id2),
+		fmt.Sprintf("admin resume dist task %d", id2),
+		fmt.Sprintf("admin cancel dist task %d", id2),
+		fmt.Sprintf("admin alter dist task %d priority = 1", id2),
+	} {
+		err := userTk.ExecToErr(sql)
+		require.True(t, plannercore.ErrSpecificAccessDenied.Equal(err), "%s: %v", sql, err)
+	}
+	checkTask(id2, proto.TaskStateCancelling, proto.NormalPriority, 1, "")
+}
+
+func TestDistAdminCheckTable(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int, c varchar(10), index idx_b(b), unique index idx_c(c))")
+	tk.MustExec("create table pt (a int primary key, b int, index idx_b(b)) partition by hash(a) partitions 3")
+	insertStr := "values (0, 0, 'c0')"
+	for i := 1; i < 300; i++ {
+		insertStr += fmt.Sprintf(", (%d, %d, 'c%d')", i, i%10, i)
+	}
+	tk.MustExec("insert into t " + insertStr)
+	tk.MustExec("insert into pt select a, b from t")
+	tk.MustQuery("split table t by (100), (200)").Check(testkit.Rows("2 1"))
+	localChecksum := tk.MustQuery("admin checksum table t, pt").Sort().Rows()
+
+	tk.MustExec("set @@global.tidb_enable_dist_task = on")
+	defer tk.MustExec("set @@global.tidb_enable_dist_task = default")
+	tk.MustExec("set @@tidb_enable_dist_admin_check = on")
+	tk.MustQuery("admin checksum table t, pt").Sort().Check(localChecksum)
+	tk.MustExec("admin check table t")
+	tk.MustExec("admin check table pt")
+	// the finished tasks are moved to the history table asynchronously.
+	tk.MustQuery("select type, state from (select id, type, state from mysql.tidb_global_task union all " +
+		"select id, type, state from mysql.tidb_global_task_history) t order by id").Check(testkit.Rows(
+		"ChecksumTable succeed", "CheckTable succeed", "CheckTable succeed"))
+
+	// Remove some index entries to make the table inconsistent.
+	ctx := mock.NewContext()
+	ctx.Store = store
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tblInfo := tbl.Meta()
+	indexOpr := tables.NewIndex(tblInfo.ID, tblInfo, tblInfo.FindIndexByName("idx_b"))
+	sc := ctx.GetSessionVars().StmtCtx
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	require.NoError(t, indexOpr.Delete(sc, txn, types.MakeDatums(7), kv.IntHandle(157)))
+	require.NoError(t, indexOpr.Delete(sc, txn, types.MakeDatums(3), kv.IntHandle(23)))
+	require.NoError(t, txn.Commit(context.Background()))
+
+	err = tk.ExecToErr("admin check table t")
+	require.True(t, consistency.ErrAdminCheckInconsistent.Equal(err))
+	require.EqualError(t, err, "[admin:8223]data inconsistency in table: t, index: idx_b, handle: 23, index-values:\"\" != record-values:\"handle: 23, values: [KindInt64 3]\"")
+	warnings := tk.Session().GetSessionVars().StmtCtx.GetWarnings()
+	require.Len(t, warnings, 2)
+	require.EqualError(t, warnings[0].Err, "[admin:8223]data inconsistency in table: t, index: idx_b, handle: 157, index-values:\"\" != record-values:\"handle: 157, values: [KindInt64 7]\"")
+
+	// Remove an index entry of a partition, the other partitions are consistent.
+	ptbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("pt"))
+	require.NoError(t, err)
+	ptblInfo := ptbl.Meta()
+	// handle 4 is in partition p1 by hash(a).
+	pid := ptblInfo.Partition.Definitions[1].ID
+	indexOpr = tables.NewIndex(pid, ptblInfo, ptblInfo.FindIndexByName("idx_b"))
+	txn, err = store.Begin()
+	require.NoError(t, err)
+	require.NoError(t, indexOpr.Delete(sc, txn, types.MakeDatums(4), kv.IntHandle(4)))
+	require.NoError(t, txn.Commit(context.Background()))
+
+	err = tk.ExecToErr("admin check table pt")
+	require.True(t, consistency.ErrAdminCheckInconsistent.Equal(err))
+	require.EqualError(t, err, "[admin:8223]data inconsistency in table: pt, index: idx_b, handle: 4, index-values:\"\" != record-values:\"handle: 4, values: [KindInt64 4]\"")
+	require.Len(t, tk.Session().GetSessionVars().StmtCtx.GetWarnings(), 1)
+}
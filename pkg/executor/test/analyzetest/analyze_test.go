@@ -18,6 +18,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -3119,3 +3121,63 @@ func TestAnalyzePartitionVerify(t *testing.T) {
 		}
 	}
 }
+
+func TestDistAnalyze(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_analyze_version = 2")
+	tk.MustExec("set @@tidb_partition_prune_mode = 'dynamic'")
+	tk.MustExec("create table t (a int primary key, b int, c varchar(10), index idx(b))")
+	tk.MustExec("create table pt (a int primary key, b int, c varchar(10), index idx(b)) partition by hash(a) partitions 3")
+	insertStr := "values (0, 0, 'c0')"
+	for i := 1; i < 300; i++ {
+		insertStr += fmt.Sprintf(", (%d, %d, 'c%d')", i, i%10, i%50)
+	}
+	tk.MustExec("insert into t " + insertStr)
+	tk.MustExec("insert into pt " + insertStr)
+	tk.MustQuery("split table t by (60), (120), (180), (240)").Check(testkit.Rows("4 1"))
+
+	getStats := func() [][]any {
+		rows := tk.MustQuery("show stats_histograms where db_name = 'test'").Sort().Rows()
+		stats := make([][]any, 0, len(rows))
+		for _, row := range rows {
+			// table, partition, column, is_index, distinct_count, null_count, avg_col_size
+			stats = append(stats, []any{row[1], row[2], row[3], row[4], row[6], row[7], row[8]})
+		}
+		return stats
+	}
+	tk.MustExec("analyze table t, pt")
+	localStats := getStats()
+	require.NotEmpty(t, localStats)
+
+	tk.MustExec("set @@global.tidb_enable_dist_task = on")
+	defer tk.MustExec("set @@global.tidb_enable_dist_task = default")
+	tk.MustExec("set @@tidb_enable_dist_analyze = on")
+	// the samples are saved in the cloud storage, analyze locally without it.
+	tk.MustExec("analyze table t")
+	tk.MustQuery("select count(*) from mysql.tidb_global_task where task_key like 'analyze/%'").Check(testkit.Rows("0"))
+	require.Equal(t, localStats, getStats())
+
+	storeDir := t.TempDir()
+	tk.MustExec(fmt.Sprintf("set @@global.tidb_cloud_storage_uri = 'file://%s'", storeDir))
+	defer tk.MustExec("set @@global.tidb_cloud_storage_uri = ''")
+	tk.MustExec("analyze table t")
+	tk.MustExec("analyze table pt")
+	// the finished task may not be moved to the history table yet.
+	tk.MustQuery("select type, state from mysql.tidb_global_task where task_key like 'analyze/%' " +
+		"union all select type, state from mysql.tidb_global_task_history where task_key like 'analyze/%'").
+		Check(testkit.Rows("Analyze succeed", "Analyze succeed"))
+	require.Equal(t, localStats, getStats())
+	// the sample files are removed after the samples are merged.
+	var files []string
+	require.NoError(t, filepath.WalkDir(storeDir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, path)
+		}
+		return err
+	}))
+	require.Empty(t, files)
+	tk.MustQuery("select count(*) from t use index(idx) where b = 1").Check(testkit.Rows("30"))
+	tk.MustQuery("show stats_meta where db_name = 'test' and table_name = 't'").CheckAt([]int{5}, testkit.Rows("300"))
+}
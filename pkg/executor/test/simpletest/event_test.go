@@ -0,0 +1,147 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simpletest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDDL(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int)")
+
+	tk.MustExec("create event e1 on schedule every 1 hour starts '2036-01-01 00:00:00' comment 'c1' do insert into t values (1)")
+	tk.MustGetErrCode("create event e1 on schedule every 1 hour do insert into t values (1)", 1537)
+	tk.MustExec("create event if not exists e1 on schedule every 1 hour do insert into t values (1)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1537 Event 'e1' already exists"))
+	tk.MustExec("create event e2 on schedule at '2036-01-01 00:00:00' on completion preserve disable do delete from t")
+
+	tk.MustQuery("show events").Check(testkit.Rows(
+		"test e1 SYSTEM root@% RECURRING <nil> 1 HOUR 2036-01-01 00:00:00 <nil> ENABLED 0 utf8mb4 utf8mb4_bin utf8mb4_bin",
+		"test e2 SYSTEM root@% ONE TIME 2036-01-01 00:00:00 <nil> <nil> <nil> <nil> DISABLED 0 utf8mb4 utf8mb4_bin utf8mb4_bin",
+	))
+	tk.MustQuery("show events like 'e2'").Check(testkit.Rows(
+		"test e2 SYSTEM root@% ONE TIME 2036-01-01 00:00:00 <nil> <nil> <nil> <nil> DISABLED 0 utf8mb4 utf8mb4_bin utf8mb4_bin",
+	))
+	tk.MustQuery("select event_name, event_definition, event_type, status, on_completion, event_comment " +
+		"from information_schema.events where event_schema = 'test' order by event_name").Check(testkit.Rows(
+		"e1 insert into t values (1) RECURRING ENABLED NOT PRESERVE c1",
+		"e2 delete from t ONE TIME DISABLED PRESERVE ",
+	))
+
+	// invalid schedules
+	tk.MustGetErrCode("create event e3 on schedule every 0 second do delete from t", 1542)
+	tk.MustGetErrCode("create event e3 on schedule every 1 hour starts '2036-01-02' ends '2036-01-01' do delete from t", 1543)
+	tk.MustExec("create event e3 on schedule at '2000-01-01 00:00:00' do delete from t")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1588 Event execution time is in the past and ON COMPLETION NOT PRESERVE is set. The event was dropped immediately after creation."))
+	tk.MustQuery("show events like 'e3'").Check(testkit.Rows())
+
+	// alter and rename
+	tk.MustExec("alter event e1 on schedule every 30 minute disable comment 'c2' do insert into t values (2)")
+	tk.MustQuery("select interval_value, interval_field, status, event_comment, event_definition " +
+		"from information_schema.events where event_name = 'e1'").Check(testkit.Rows("30 MINUTE DISABLED c2 insert into t values (2)"))
+	tk.MustGetErrCode("alter event e1 rename to e1", 1551)
+	tk.MustGetErrCode("alter event e1 rename to e2", 1537)
+	tk.MustExec("alter event e1 rename to e4 enable")
+	tk.MustQuery("select event_name, status from information_schema.events where event_schema = 'test' order by event_name").
+		Check(testkit.Rows("e2 DISABLED", "e4 ENABLED"))
+	tk.MustGetErrCode("alter event e1 disable", 1539)
+
+	// drop
+	tk.MustExec("drop event e4")
+	tk.MustGetErrCode("drop event e4", 1539)
+	tk.MustExec("drop event if exists e4")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1539 Unknown event 'e4'"))
+
+	// events are dropped with the schema
+	tk.MustExec("create database db1")
+	tk.MustExec("create event db1.e1 on schedule every 1 day do select 1")
+	tk.MustQuery("show events from db1").CheckContain("e1")
+	tk.MustExec("drop database db1")
+	tk.MustQuery("select count(*) from information_schema.events where event_schema = 'db1'").Check(testkit.Rows("0"))
+
+	// privileges
+	tk.MustExec("create user u1")
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk2.MustGetErrCode("create event test.e5 on schedule every 1 hour do select 1", 1044)
+	tk2.MustQuery("select count(*) from information_schema.events").Check(testkit.Rows("0"))
+	tk.MustExec("grant event on test.* to u1")
+	tk2.MustGetErrCode("create definer = root event test.e5 on schedule every 1 hour do select 1", 1227)
+	tk2.MustExec("create event test.e5 on schedule every 1 hour do select 1")
+	tk2.MustQuery("select event_name, definer from information_schema.events order by event_name").
+		Check(testkit.Rows("e2 root@%", "e5 u1@%"))
+
+	// the user altering an event becomes its definer, so the new body never runs as another user
+	tk2.MustGetErrCode("alter definer = root event test.e2 do insert into t values (3)", 1227)
+	tk2.MustQuery("select definer, event_definition from information_schema.events where event_name = 'e2'").
+		Check(testkit.Rows("root@% delete from t"))
+	tk2.MustExec("alter event test.e2 do insert into t values (3)")
+	tk2.MustQuery("select definer, event_definition from information_schema.events where event_name = 'e2'").
+		Check(testkit.Rows("u1@% insert into t values (3)"))
+	tk.MustExec("alter event test.e2 comment 'c3'")
+	tk.MustQuery("select definer from information_schema.events where event_name = 'e2'").Check(testkit.Rows("root@%"))
+	tk.MustExec("alter definer = u1 event test.e2 do select 1")
+	tk.MustQuery("select definer from information_schema.events where event_name = 'e2'").Check(testkit.Rows("u1@%"))
+}
+
+func TestEventExecution(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int)")
+	tk.MustExec("set @@global.event_scheduler = ON")
+	defer tk.MustExec("set @@global.event_scheduler = OFF")
+
+	tk.MustExec("create event e1 on schedule at current_timestamp + interval 1 second do insert into t values (1)")
+	tk.MustExec("create event e2 on schedule at current_timestamp + interval 1 second on completion preserve do insert into t1 values (1)")
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from mysql.tidb_event_history where status != 'running'").Rows()) == 2
+	}, 30*time.Second, 100*time.Millisecond)
+
+	tk.MustQuery("select * from t").Check(testkit.Rows("1"))
+	tk.MustQuery("select event_name, status, affected_rows from mysql.tidb_event_history order by event_name").
+		Check(testkit.Rows("e1 success 1", "e2 failed 0"))
+	tk.MustQuery("select error_message from mysql.tidb_event_history where event_name = 'e2'").
+		Check(testkit.Rows("[schema:1146]Table 'test.t1' doesn't exist"))
+
+	// e1 is dropped after execution because it is NOT PRESERVE, and e2 is disabled.
+	require.Eventually(t, func() bool {
+		rows := tk.MustQuery("select event_name, status, last_executed is not null from information_schema.events").Rows()
+		return len(rows) == 1 && rows[0][0] == "e2" && rows[0][1] == "DISABLED" && rows[0][2] == "1"
+	}, 30*time.Second, 100*time.Millisecond)
+
+	// the definer should exist when executing the event
+	tk.MustExec("create user u1")
+	tk.MustExec("grant all on test.* to u1")
+	tk.MustExec("create definer = u1 event e3 on schedule at current_timestamp + interval 1 second do insert into t values (3)")
+	tk.MustExec("drop user u1")
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from mysql.tidb_event_history where event_name = 'e3' and status = 'failed'").Rows()) == 1
+	}, 30*time.Second, 100*time.Millisecond)
+	tk.MustQuery("select error_message from mysql.tidb_event_history where event_name = 'e3'").
+		Check(testkit.Rows(exeerrors.ErrNoSuchUser.GenWithStackByArgs("u1", "%").Error()))
+	tk.MustQuery("select * from t").Check(testkit.Rows("1"))
+}
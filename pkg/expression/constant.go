@@ -129,6 +129,27 @@ func (d *ParamMarker) GetUserVar() types.Datum {
 	return sessionVars.PlanCacheParams.GetParamValue(d.order)
 }
 
+// RebindParamMarkers binds the parameter markers in the expressions to the session, so that the expressions
+// cloned from another session's cached plan read the parameters of this session.
+// The markers are replaced in place, so the expressions must have been cloned and not be shared with others.
+func RebindParamMarkers(ctx sessionctx.Context, exprs ...Expression) {
+	for _, expr := range exprs {
+		switch x := expr.(type) {
+		case *Constant:
+			if x.ParamMarker != nil {
+				x.ParamMarker = &ParamMarker{ctx: ctx, order: x.ParamMarker.order}
+			}
+			if x.DeferredExpr != nil {
+				// Constant.Clone doesn't clone the deferred expression.
+				x.DeferredExpr = x.DeferredExpr.Clone()
+				RebindParamMarkers(ctx, x.DeferredExpr)
+			}
+		case *ScalarFunction:
+			RebindParamMarkers(ctx, x.GetArgs()...)
+		}
+	}
+}
+
 // String implements fmt.Stringer interface.
 func (c *Constant) String() string {
 	if c.ParamMarker != nil {
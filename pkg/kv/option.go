@@ -218,6 +218,8 @@ const (
 	InternalDistTask = "DistTask"
 	// InternalTimer is the type of internal timer
 	InternalTimer = "Timer"
+	// InternalEventScheduler is the type of event scheduler usage.
+	InternalEventScheduler = "EventScheduler"
 )
 
 // The bitmap:
@@ -2335,8 +2335,42 @@ const (
 	AdminSetBDRRole
 	AdminShowBDRRole
 	AdminUnsetBDRRole
+	AdminShowDistTasks
+	AdminPauseDistTask
+	AdminResumeDistTask
+	AdminCancelDistTask
+	AdminAlterDistTask
 )
 
+// DistTaskOptionType is the type of the option in `ADMIN ALTER DIST TASK`.
+type DistTaskOptionType int
+
+// DistTaskOption types.
+const (
+	DistTaskOptionConcurrency DistTaskOptionType = iota + 1
+	DistTaskOptionPriority
+)
+
+// DistTaskOption is the option in `ADMIN ALTER DIST TASK`.
+type DistTaskOption struct {
+	Tp        DistTaskOptionType
+	UintValue uint64
+}
+
+// Restore implements Node interface.
+func (n *DistTaskOption) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case DistTaskOptionConcurrency:
+		ctx.WriteKeyWord("CONCURRENCY ")
+	case DistTaskOptionPriority:
+		ctx.WriteKeyWord("PRIORITY ")
+	default:
+		return errors.Errorf("invalid DistTaskOption: %d", n.Tp)
+	}
+	ctx.WritePlainf("= %d", n.UintValue)
+	return nil
+}
+
 // HandleRange represents a range where handle value >= Begin and < End.
 type HandleRange struct {
 	Begin int64
@@ -2474,6 +2508,9 @@ type AdminStmt struct {
 	StatementScope StatementScope
 	LimitSimple    LimitSimple
 	BDRRole        BDRRole
+	// DistTaskID is the ID of the distributed task in `ADMIN PAUSE/RESUME/CANCEL/ALTER DIST TASK`.
+	DistTaskID      int64
+	DistTaskOptions []*DistTaskOption
 }
 
 // Restore implements Node interface.
@@ -2641,6 +2678,30 @@ func (n *AdminStmt) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("SHOW BDR ROLE")
 	case AdminUnsetBDRRole:
 		ctx.WriteKeyWord("UNSET BDR ROLE")
+	case AdminShowDistTasks:
+		ctx.WriteKeyWord("SHOW DIST TASKS")
+	case AdminPauseDistTask:
+		ctx.WriteKeyWord("PAUSE DIST TASK ")
+		ctx.WritePlainf("%d", n.DistTaskID)
+	case AdminResumeDistTask:
+		ctx.WriteKeyWord("RESUME DIST TASK ")
+		ctx.WritePlainf("%d", n.DistTaskID)
+	case AdminCancelDistTask:
+		ctx.WriteKeyWord("CANCEL DIST TASK ")
+		ctx.WritePlainf("%d", n.DistTaskID)
+	case AdminAlterDistTask:
+		ctx.WriteKeyWord("ALTER DIST TASK ")
+		ctx.WritePlainf("%d", n.DistTaskID)
+		for i, opt := range n.DistTaskOptions {
+			if i == 0 {
+				ctx.WritePlain(" ")
+			} else {
+				ctx.WritePlain(", ")
+			}
+			if err := opt.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore AdminStmt.DistTaskOptions[%d]", i)
+			}
+		}
 	default:
 		return errors.New("Unsupported AdminStmt type")
 	}
@@ -3548,7 +3609,7 @@ func (n *BRIEStmt) Restore(ctx *format.RestoreCtx) error {
 	return nil
 }
 
-// RedactURL redacts the secret tokens in the URL. only S3 url need redaction for now.
+// RedactURL redacts the secret tokens in the URL. only S3, SFTP and WebDAV url need redaction for now.
 // if the url is not a valid url, return the original string.
 func RedactURL(str string) string {
 	// FIXME: this solution is not scalable, and duplicates some logic from BR.
@@ -3572,6 +3633,17 @@ func RedactURL(str string) string {
 			}
 		}
 		u.RawQuery = values.Encode()
+	case "sftp", "webdav", "webdavs":
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), "xxxxxx")
+		}
+		values := u.Query()
+		for k := range values {
+			if strings.ToLower(k) == "password" {
+				values[k] = []string{"xxxxxx"}
+			}
+		}
+		u.RawQuery = values.Encode()
 	}
 	return u.String()
 }
@@ -4066,6 +4138,8 @@ type QueryWatchOption struct {
 	IntValue  int32
 	ExprValue ExprNode
 	BoolValue bool
+	// TableName is the table to watch when the watch type is model.WatchTable.
+	TableName *TableName
 }
 
 func (n *QueryWatchOption) Restore(ctx *format.RestoreCtx) error {
@@ -4083,6 +4157,11 @@ func (n *QueryWatchOption) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("ACTION ")
 		ctx.WritePlain("= ")
 		ctx.WriteKeyWord(model.RunawayActionType(n.IntValue).String())
+		if n.IntValue == int32(model.RunawayActionSwitchGroup) {
+			ctx.WritePlain("(")
+			ctx.WriteName(n.StrValue.O)
+			ctx.WritePlain(")")
+		}
 	case QueryWatchType:
 		if n.BoolValue {
 			ctx.WriteKeyWord("SQL TEXT ")
@@ -4094,6 +4173,19 @@ func (n *QueryWatchOption) Restore(ctx *format.RestoreCtx) error {
 				ctx.WriteKeyWord("SQL DIGEST ")
 			case int32(model.WatchPlan):
 				ctx.WriteKeyWord("PLAN DIGEST ")
+			case int32(model.WatchLike):
+				ctx.WriteKeyWord("SQL LIKE ")
+			case int32(model.WatchUser):
+				ctx.WriteKeyWord("USER ")
+			case int32(model.WatchScannedKeys):
+				ctx.WriteKeyWord("SCANNED_KEYS ")
+				ctx.WritePlain("= ")
+			case int32(model.WatchTable):
+				ctx.WriteKeyWord("TABLE ")
+				if err := n.TableName.Restore(ctx); err != nil {
+					return errors.Annotatef(err, "An error occurred while splicing TableName: [%v]", n.TableName)
+				}
+				return nil
 			}
 		}
 		if err := n.ExprValue.Restore(ctx); err != nil {
@@ -4117,6 +4209,13 @@ func (n *QueryWatchOption) Accept(v Visitor) (Node, bool) {
 		}
 		n.ExprValue = node.(ExprNode)
 	}
+	if n.TableName != nil {
+		node, ok := n.TableName.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.TableName = node.(*TableName)
+	}
 	return v.Leave(n)
 }
 
@@ -267,6 +267,7 @@ var Keywords = []KeywordsType{
 	{"ALWAYS", false, "unreserved"},
 	{"ANY", false, "unreserved"},
 	{"ASCII", false, "unreserved"},
+	{"AT", false, "unreserved"},
 	{"ATTRIBUTE", false, "unreserved"},
 	{"ATTRIBUTES", false, "unreserved"},
 	{"AUTO_ID_CACHE", false, "unreserved"},
@@ -315,6 +316,7 @@ var Keywords = []KeywordsType{
 	{"COMMIT", false, "unreserved"},
 	{"COMMITTED", false, "unreserved"},
 	{"COMPACT", false, "unreserved"},
+	{"COMPLETION", false, "unreserved"},
 	{"COMPRESSED", false, "unreserved"},
 	{"COMPRESSION", false, "unreserved"},
 	{"CONCURRENCY", false, "unreserved"},
@@ -354,6 +356,7 @@ var Keywords = []KeywordsType{
 	{"ENABLED", false, "unreserved"},
 	{"ENCRYPTION", false, "unreserved"},
 	{"END", false, "unreserved"},
+	{"ENDS", false, "unreserved"},
 	{"ENFORCED", false, "unreserved"},
 	{"ENGINE", false, "unreserved"},
 	{"ENGINES", false, "unreserved"},
@@ -363,6 +366,7 @@ var Keywords = []KeywordsType{
 	{"ESCAPE", false, "unreserved"},
 	{"EVENT", false, "unreserved"},
 	{"EVENTS", false, "unreserved"},
+	{"EVERY", false, "unreserved"},
 	{"EVOLVE", false, "unreserved"},
 	{"EXCHANGE", false, "unreserved"},
 	{"EXCLUSIVE", false, "unreserved"},
@@ -565,6 +569,7 @@ var Keywords = []KeywordsType{
 	{"SQL_TSI_WEEK", false, "unreserved"},
 	{"SQL_TSI_YEAR", false, "unreserved"},
 	{"START", false, "unreserved"},
+	{"STARTS", false, "unreserved"},
 	{"STATS_AUTO_RECALC", false, "unreserved"},
 	{"STATS_COL_CHOICE", false, "unreserved"},
 	{"STATS_COL_LIST", false, "unreserved"},
@@ -603,8 +608,12 @@ var Keywords = []KeywordsType{
 	{"TRUNCATE", false, "unreserved"},
 	{"TSO", false, "unreserved"},
 	{"TTL", false, "unreserved"},
+	{"TTL_ARCHIVE", false, "unreserved"},
+	{"TTL_ARCHIVE_FORMAT", false, "unreserved"},
 	{"TTL_ENABLE", false, "unreserved"},
+	{"TTL_FILTER", false, "unreserved"},
 	{"TTL_JOB_INTERVAL", false, "unreserved"},
+	{"TTL_PARTITION_LIFECYCLE", false, "unreserved"},
 	{"TYPE", false, "unreserved"},
 	{"UNBOUNDED", false, "unreserved"},
 	{"UNCOMMITTED", false, "unreserved"},
@@ -638,6 +647,7 @@ var Keywords = []KeywordsType{
 	{"DDL", false, "tidb"},
 	{"DEPENDENCY", false, "tidb"},
 	{"DEPTH", false, "tidb"},
+	{"DIST", false, "tidb"},
 	{"DRAINER", false, "tidb"},
 	{"DRY", false, "tidb"},
 	{"HISTOGRAMS_IN_FLIGHT", false, "tidb"},
@@ -664,6 +674,8 @@ var Keywords = []KeywordsType{
 	{"STATS_LOCKED", false, "tidb"},
 	{"STATS_META", false, "tidb"},
 	{"STATS_TOPN", false, "tidb"},
+	{"TASK", false, "tidb"},
+	{"TASKS", false, "tidb"},
 	{"TELEMETRY", false, "tidb"},
 	{"TELEMETRY_ID", false, "tidb"},
 	{"TIDB", false, "tidb"},
@@ -178,6 +178,7 @@ var tokenMap = map[string]int{
 	"AS":                       as,
 	"ASC":                      asc,
 	"ASCII":                    ascii,
+	"AT":                       at,
 	"ATTRIBUTE":                attribute,
 	"ATTRIBUTES":               attributes,
 	"BATCH":                    batch,
@@ -261,6 +262,7 @@ var tokenMap = map[string]int{
 	"COMMIT":                   commit,
 	"COMMITTED":                committed,
 	"COMPACT":                  compact,
+	"COMPLETION":               completion,
 	"COMPRESSED":               compressed,
 	"COMPRESSION":              compression,
 	"CONCURRENCY":              concurrency,
@@ -329,6 +331,7 @@ var tokenMap = map[string]int{
 	"DISABLED":                 disabled,
 	"DISCARD":                  discard,
 	"DISK":                     disk,
+	"DIST":                     dist,
 	"DISTINCT":                 distinct,
 	"DISTINCTROW":              distinct,
 	"DIV":                      div,
@@ -351,6 +354,7 @@ var tokenMap = map[string]int{
 	"ENCLOSED":                 enclosed,
 	"ENCRYPTION":               encryption,
 	"END":                      end,
+	"ENDS":                     ends,
 	"END_TIME":                 endTime,
 	"ENFORCED":                 enforced,
 	"ENGINE":                   engine,
@@ -362,6 +366,7 @@ var tokenMap = map[string]int{
 	"ESCAPED":                  escaped,
 	"EVENT":                    event,
 	"EVENTS":                   events,
+	"EVERY":                    every,
 	"EVOLVE":                   evolve,
 	"EXACT":                    exact,
 	"EXEC_ELAPSED":             execElapsed,
@@ -692,6 +697,7 @@ var tokenMap = map[string]int{
 	"SAMPLERATE":               sampleRate,
 	"SAN":                      san,
 	"SAVEPOINT":                savepoint,
+	"SCANNED_KEYS":             scannedKeys,
 	"SCHEDULE":                 schedule,
 	"SCHEMA":                   database,
 	"SCHEMAS":                  databases,
@@ -751,6 +757,7 @@ var tokenMap = map[string]int{
 	"SSL":                      ssl,
 	"STALENESS":                staleness,
 	"START":                    start,
+	"STARTS":                   starts,
 	"START_TIME":               startTime,
 	"START_TS":                 startTS,
 	"STARTING":                 starting,
@@ -790,9 +797,12 @@ var tokenMap = map[string]int{
 	"SURVIVAL_PREFERENCES":     survivalPreferences,
 	"SWAPS":                    swaps,
 	"SWITCHES":                 switchesSym,
+	"SWITCH_GROUP":             switchGroup,
 	"SYSTEM":                   system,
 	"SYSTEM_TIME":              systemTime,
 	"TARGET":                   target,
+	"TASK":                     task,
+	"TASKS":                    tasks,
 	"TASK_TYPES":               taskTypes,
 	"TABLE_CHECKSUM":           tableChecksum,
 	"TABLE":                    tableKwd,
@@ -846,8 +856,12 @@ var tokenMap = map[string]int{
 	"TRUE_CARD_COST":           trueCardCost,
 	"TSO":                      tsoType,
 	"TTL":                      ttl,
+	"TTL_ARCHIVE":              ttlArchive,
+	"TTL_ARCHIVE_FORMAT":       ttlArchiveFormat,
 	"TTL_ENABLE":               ttlEnable,
+	"TTL_FILTER":               ttlFilter,
 	"TTL_JOB_INTERVAL":         ttlJobInterval,
+	"TTL_PARTITION_LIFECYCLE":  ttlPartitionLifecycle,
 	"TYPE":                     tp,
 	"UNBOUNDED":                unbounded,
 	"UNCOMMITTED":              uncommitted,
@@ -1820,6 +1820,20 @@ type TTLInfo struct {
 	// JobInterval is the interval between two TTL scan jobs.
 	// It's suggested to get a duration with `(*TTLInfo).GetJobInterval`
 	JobInterval string `json:"job_interval"`
+	// ArchiveURI is the external storage URI to archive the expired rows
+	// before deleting them. The rows are not archived if it's empty.
+	ArchiveURI string `json:"archive_uri,omitempty"`
+	// ArchiveFormat is the file format of the archived rows, "csv" or "parquet".
+	// It's suggested to get it with `(*TTLInfo).GetArchiveFormat`.
+	ArchiveFormat string `json:"archive_format,omitempty"`
+	// PartitionLifecycle indicates that the expired rows are removed by dropping the
+	// partitions whose rows are all expired, and the future partitions are created
+	// in advance. It only takes effect on the tables partitioned by RANGE COLUMNS on
+	// the TTL column, the other partitions are still deleted row by row.
+	PartitionLifecycle bool `json:"partition_lifecycle,omitempty"`
+	// Filter is an extra boolean expression on the columns of the table. Only the
+	// expired rows matching it are deleted. All the expired rows are deleted if it's empty.
+	Filter string `json:"filter,omitempty"`
 }
 
 // Clone clones TTLInfo
@@ -1841,6 +1855,17 @@ func (t *TTLInfo) GetJobInterval() (time.Duration, error) {
 	return duration.ParseDuration(t.JobInterval)
 }
 
+// DefaultArchiveFormat is the default file format of the archived rows.
+const DefaultArchiveFormat = "csv"
+
+// GetArchiveFormat returns the file format of the archived rows.
+func (t *TTLInfo) GetArchiveFormat() string {
+	if len(t.ArchiveFormat) == 0 {
+		return DefaultArchiveFormat
+	}
+	return t.ArchiveFormat
+}
+
 func writeSettingItemToBuilder(sb *strings.Builder, item string, separatorFns ...func()) {
 	if sb.Len() != 0 {
 		for _, fn := range separatorFns {
@@ -1927,6 +1952,7 @@ const (
 	RunawayActionDryRun
 	RunawayActionCooldown
 	RunawayActionKill
+	RunawayActionSwitchGroup
 )
 
 // RunawayWatchType is the type of runaway watch.
@@ -1938,6 +1964,10 @@ const (
 	WatchExact
 	WatchSimilar
 	WatchPlan
+	WatchTable
+	WatchUser
+	WatchLike
+	WatchScannedKeys
 )
 
 func (t RunawayWatchType) String() string {
@@ -1948,6 +1978,14 @@ func (t RunawayWatchType) String() string {
 		return "SIMILAR"
 	case WatchPlan:
 		return "PLAN"
+	case WatchTable:
+		return "TABLE"
+	case WatchUser:
+		return "USER"
+	case WatchLike:
+		return "LIKE"
+	case WatchScannedKeys:
+		return "SCANNED_KEYS"
 	default:
 		return "NONE"
 	}
@@ -1971,6 +2009,8 @@ func (t RunawayActionType) String() string {
 		return "COOLDOWN"
 	case RunawayActionKill:
 		return "KILL"
+	case RunawayActionSwitchGroup:
+		return "SWITCH_GROUP"
 	default:
 		return "DRYRUN"
 	}
@@ -322,6 +322,7 @@ import (
 	always                "ALWAYS"
 	any                   "ANY"
 	ascii                 "ASCII"
+	at                    "AT"
 	attribute             "ATTRIBUTE"
 	attributes            "ATTRIBUTES"
 	autoIdCache           "AUTO_ID_CACHE"
@@ -370,6 +371,7 @@ import (
 	commit                "COMMIT"
 	committed             "COMMITTED"
 	compact               "COMPACT"
+	completion            "COMPLETION"
 	compressed            "COMPRESSED"
 	compression           "COMPRESSION"
 	concurrency           "CONCURRENCY"
@@ -409,6 +411,7 @@ import (
 	enabled               "ENABLED"
 	encryption            "ENCRYPTION"
 	end                   "END"
+	ends                  "ENDS"
 	enforced              "ENFORCED"
 	engine                "ENGINE"
 	engines               "ENGINES"
@@ -418,6 +421,7 @@ import (
 	escape                "ESCAPE"
 	event                 "EVENT"
 	events                "EVENTS"
+	every                 "EVERY"
 	evolve                "EVOLVE"
 	exchange              "EXCHANGE"
 	exclusive             "EXCLUSIVE"
@@ -621,6 +625,7 @@ import (
 	sqlTsiWeek            "SQL_TSI_WEEK"
 	sqlTsiYear            "SQL_TSI_YEAR"
 	start                 "START"
+	starts                "STARTS"
 	statsAutoRecalc       "STATS_AUTO_RECALC"
 	statsColChoice        "STATS_COL_CHOICE"
 	statsColList          "STATS_COL_LIST"
@@ -659,8 +664,12 @@ import (
 	truncate              "TRUNCATE"
 	tsoType               "TSO"
 	ttl                   "TTL"
+	ttlArchive            "TTL_ARCHIVE"
+	ttlArchiveFormat      "TTL_ARCHIVE_FORMAT"
 	ttlEnable             "TTL_ENABLE"
+	ttlFilter             "TTL_FILTER"
 	ttlJobInterval        "TTL_JOB_INTERVAL"
+	ttlPartitionLifecycle "TTL_PARTITION_LIFECYCLE"
 	tp                    "TYPE"
 	unbounded             "UNBOUNDED"
 	uncommitted           "UNCOMMITTED"
@@ -756,6 +765,7 @@ import (
 	running               "RUNNING"
 	ruRate                "RU_PER_SEC"
 	s3                    "S3"
+	scannedKeys           "SCANNED_KEYS"
 	schedule              "SCHEDULE"
 	similar               "SIMILAR"
 	staleness             "STALENESS"
@@ -772,6 +782,7 @@ import (
 	substring             "SUBSTRING"
 	sum                   "SUM"
 	survivalPreferences   "SURVIVAL_PREFERENCES"
+	switchGroup           "SWITCH_GROUP"
 	target                "TARGET"
 	taskTypes             "TASK_TYPES"
 	tidbJson              "TIDB_JSON"
@@ -842,6 +853,7 @@ import (
 	ddl                        "DDL"
 	dependency                 "DEPENDENCY"
 	depth                      "DEPTH"
+	dist                       "DIST"
 	drainer                    "DRAINER"
 	dry                        "DRY"
 	histogramsInFlight         "HISTOGRAMS_IN_FLIGHT"
@@ -868,6 +880,8 @@ import (
 	statsLocked                "STATS_LOCKED"
 	statsMeta                  "STATS_META"
 	statsTopN                  "STATS_TOPN"
+	task                       "TASK"
+	tasks                      "TASKS"
 	telemetry                  "TELEMETRY"
 	telemetryID                "TELEMETRY_ID"
 	tidb                       "TIDB"
@@ -950,6 +964,7 @@ import (
 	AlterPolicyStmt            "Alter Placement Policy statement"
 	AlterResourceGroupStmt     "Alter Resource Group statement"
 	AlterSequenceStmt          "Alter sequence statement"
+	AlterEventStmt             "ALTER EVENT statement"
 	AnalyzeTableStmt           "Analyze table statement"
 	BeginTransactionStmt       "BEGIN TRANSACTION statement"
 	BinlogStmt                 "Binlog base64 statement"
@@ -958,6 +973,7 @@ import (
 	CommitStmt                 "COMMIT statement"
 	CreateTableStmt            "CREATE TABLE statement"
 	CreateViewStmt             "CREATE VIEW  statement"
+	CreateEventStmt            "CREATE EVENT statement"
 	CreateUserStmt             "CREATE User statement"
 	CreateRoleStmt             "CREATE Role statement"
 	CreateDatabaseStmt         "Create Database Statement"
@@ -982,6 +998,8 @@ import (
 	DropUserStmt               "DROP USER"
 	DropRoleStmt               "DROP ROLE"
 	DropViewStmt               "DROP VIEW statement"
+	DropEventStmt              "DROP EVENT statement"
+	EventBodyStmt              "event body statement"
 	DropBindingStmt            "DROP BINDING  statement"
 	DropPolicyStmt             "DROP PLACEMENT POLICY statement"
 	DeallocateStmt             "Deallocate prepared statement"
@@ -1134,12 +1152,23 @@ import (
 	DatabaseOptionList                     "CREATE Database specification list"
 	DatabaseOptionListOpt                  "CREATE Database specification list opt"
 	DistinctOpt                            "Explicit distinct option"
+	DistTaskOption                         "Option of ADMIN ALTER DIST TASK"
+	DistTaskOptionList                     "Option list of ADMIN ALTER DIST TASK"
 	DefaultFalseDistinctOpt                "Distinct option which defaults to false"
 	DefaultTrueDistinctOpt                 "Distinct option which defaults to true"
 	BuggyDefaultFalseDistinctOpt           "Distinct option which accepts DISTINCT ALL and defaults to false"
 	RequireClause                          "Encrypted connections options"
 	RequireClauseOpt                       "optional Encrypted connections options"
 	EqOpt                                  "= or empty"
+	EventBodyOpt                           "optional event body"
+	EventCommentOpt                        "optional event comment"
+	EventCompletionOpt                     "optional ON COMPLETION clause of event"
+	EventEndsOpt                           "optional ENDS clause of event"
+	EventRenameOpt                         "optional RENAME TO clause of event"
+	EventSchedule                          "event schedule"
+	EventScheduleCompletionOpt             "optional ON SCHEDULE and ON COMPLETION clauses of event"
+	EventStartsOpt                         "optional STARTS clause of event"
+	EventStatusOpt                         "optional ENABLE or DISABLE clause of event"
 	EscapedTableRef                        "escaped table reference"
 	ExpressionList                         "expression list"
 	ExtendedPriv                           "Extended privileges like LOAD FROM S3 or dynamic privileges"
@@ -5021,6 +5050,176 @@ ViewCheckOption:
 		$$ = model.CheckOptionLocal
 	}
 
+/*******************************************************************
+ * Event statements
+ * See https://dev.mysql.com/doc/refman/8.0/en/create-event.html
+ *     https://dev.mysql.com/doc/refman/8.0/en/alter-event.html
+ *     https://dev.mysql.com/doc/refman/8.0/en/drop-event.html
+ *
+ * The prefix of CREATE EVENT is shared with CREATE VIEW to avoid the
+ * conflicts of the optional DEFINER clause, so the options which only
+ * belong to views are rejected here.
+ *******************************************************************/
+CreateEventStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner ViewSQLSecurity "EVENT" IfNotExists TableName "ON" "SCHEDULE" EventSchedule EventCompletionOpt EventStatusOpt EventCommentOpt "DO" EventBodyStmt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined || $5.(model.ViewSecurity) != model.SecurityDefiner {
+			yylex.AppendError(yylex.Errorf("OR REPLACE, ALGORITHM and SQL SECURITY are not allowed in CREATE EVENT"))
+			return 1
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		$16.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:]))
+		x := &ast.CreateEventStmt{
+			IfNotExists: $7.(bool),
+			Definer:     $4.(*auth.UserIdentity),
+			EventName:   $8.(*ast.TableName),
+			Schedule:    $11.(*ast.EventSchedule),
+			Completion:  $12.(ast.EventCompletionType),
+			Status:      $13.(ast.EventStatusType),
+			Body:        $16,
+		}
+		if $14 != nil {
+			x.Comment = $14.(string)
+		}
+		$$ = x
+	}
+
+AlterEventStmt:
+	"ALTER" ViewDefiner "EVENT" TableName EventScheduleCompletionOpt EventRenameOpt EventStatusOpt EventCommentOpt EventBodyOpt
+	{
+		x := $5.(*ast.AlterEventStmt)
+		x.Definer = $2.(*auth.UserIdentity)
+		x.EventName = $4.(*ast.TableName)
+		if $6 != nil {
+			x.NewName = $6.(*ast.TableName)
+		}
+		x.Status = $7.(ast.EventStatusType)
+		if $8 != nil {
+			comment := $8.(string)
+			x.Comment = &comment
+		}
+		if $9 != nil {
+			x.Body = $9.(ast.StmtNode)
+		}
+		if x.Schedule == nil && x.Completion == ast.EventCompletionUnspecified && x.NewName == nil &&
+			x.Status == ast.EventStatusUnspecified && x.Comment == nil && x.Body == nil {
+			yylex.AppendError(yylex.Errorf("ALTER EVENT requires at least one clause"))
+			return 1
+		}
+		$$ = x
+	}
+
+DropEventStmt:
+	"DROP" "EVENT" IfExists TableName
+	{
+		$$ = &ast.DropEventStmt{IfExists: $3.(bool), EventName: $4.(*ast.TableName)}
+	}
+
+EventSchedule:
+	"AT" Expression
+	{
+		$$ = &ast.EventSchedule{At: $2}
+	}
+|	"EVERY" Expression TimeUnit EventStartsOpt EventEndsOpt
+	{
+		x := &ast.EventSchedule{Every: $2, EveryUnit: $3.(ast.TimeUnitType)}
+		if $4 != nil {
+			x.Starts = $4.(ast.ExprNode)
+		}
+		if $5 != nil {
+			x.Ends = $5.(ast.ExprNode)
+		}
+		$$ = x
+	}
+
+EventStartsOpt:
+	{
+		$$ = nil
+	}
+|	"STARTS" Expression
+	{
+		$$ = $2
+	}
+
+EventEndsOpt:
+	{
+		$$ = nil
+	}
+|	"ENDS" Expression
+	{
+		$$ = $2
+	}
+
+EventScheduleCompletionOpt:
+	EventCompletionOpt
+	{
+		$$ = &ast.AlterEventStmt{Completion: $1.(ast.EventCompletionType)}
+	}
+|	"ON" "SCHEDULE" EventSchedule EventCompletionOpt
+	{
+		$$ = &ast.AlterEventStmt{Schedule: $3.(*ast.EventSchedule), Completion: $4.(ast.EventCompletionType)}
+	}
+
+EventCompletionOpt:
+	{
+		$$ = ast.EventCompletionUnspecified
+	}
+|	"ON" "COMPLETION" "PRESERVE"
+	{
+		$$ = ast.EventCompletionPreserve
+	}
+|	"ON" "COMPLETION" "NOT" "PRESERVE"
+	{
+		$$ = ast.EventCompletionNotPreserve
+	}
+
+EventStatusOpt:
+	{
+		$$ = ast.EventStatusUnspecified
+	}
+|	"ENABLE"
+	{
+		$$ = ast.EventStatusEnable
+	}
+|	"DISABLE"
+	{
+		$$ = ast.EventStatusDisable
+	}
+
+EventCommentOpt:
+	{
+		$$ = nil
+	}
+|	"COMMENT" stringLit
+	{
+		$$ = $2
+	}
+
+EventRenameOpt:
+	{
+		$$ = nil
+	}
+|	"RENAME" "TO" TableName
+	{
+		$$ = $3
+	}
+
+EventBodyOpt:
+	{
+		$$ = nil
+	}
+|	"DO" EventBodyStmt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		$2.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:]))
+		$$ = $2
+	}
+
+EventBodyStmt:
+	TraceableStmt
+|	DoStmt
+|	TruncateTableStmt
+
 /******************************************************************
  * Do statement
  * See https://dev.mysql.com/doc/refman/5.7/en/do.html
@@ -6889,6 +7088,15 @@ UnReservedKeyword:
 |	"TTL"
 |	"TTL_ENABLE"
 |	"TTL_JOB_INTERVAL"
+|	"TTL_ARCHIVE"
+|	"TTL_ARCHIVE_FORMAT"
+|	"TTL_FILTER"
+|	"TTL_PARTITION_LIFECYCLE"
+|	"AT"
+|	"COMPLETION"
+|	"ENDS"
+|	"EVERY"
+|	"STARTS"
 |	"FAILED_LOGIN_ATTEMPTS"
 |	"PASSWORD_LOCK_TIME"
 |	"DIGEST"
@@ -6949,6 +7157,9 @@ TiDBKeyword:
 |	"RESET"
 |	"DRY"
 |	"RUN"
+|	"DIST"
+|	"TASK"
+|	"TASKS"
 
 NotKeywordToken:
 	"ADDDATE"
@@ -7068,6 +7279,8 @@ NotKeywordToken:
 |	"BACKGROUND"
 |	"TASK_TYPES"
 |	"UNLIMITED"
+|	"SCANNED_KEYS"
+|	"SWITCH_GROUP"
 
 /************************************************************************************
  *
@@ -11170,6 +11383,61 @@ AdminStmt:
 			Tp: ast.AdminUnsetBDRRole,
 		}
 	}
+|	"ADMIN" "SHOW" "DIST" "TASKS"
+	{
+		$$ = &ast.AdminStmt{
+			Tp: ast.AdminShowDistTasks,
+		}
+	}
+|	"ADMIN" "PAUSE" "DIST" "TASK" Int64Num
+	{
+		$$ = &ast.AdminStmt{
+			Tp:         ast.AdminPauseDistTask,
+			DistTaskID: $5.(int64),
+		}
+	}
+|	"ADMIN" "RESUME" "DIST" "TASK" Int64Num
+	{
+		$$ = &ast.AdminStmt{
+			Tp:         ast.AdminResumeDistTask,
+			DistTaskID: $5.(int64),
+		}
+	}
+|	"ADMIN" "CANCEL" "DIST" "TASK" Int64Num
+	{
+		$$ = &ast.AdminStmt{
+			Tp:         ast.AdminCancelDistTask,
+			DistTaskID: $5.(int64),
+		}
+	}
+|	"ADMIN" "ALTER" "DIST" "TASK" Int64Num DistTaskOptionList
+	{
+		$$ = &ast.AdminStmt{
+			Tp:              ast.AdminAlterDistTask,
+			DistTaskID:      $5.(int64),
+			DistTaskOptions: $6.([]*ast.DistTaskOption),
+		}
+	}
+
+DistTaskOptionList:
+	DistTaskOption
+	{
+		$$ = []*ast.DistTaskOption{$1.(*ast.DistTaskOption)}
+	}
+|	DistTaskOptionList ',' DistTaskOption
+	{
+		$$ = append($1.([]*ast.DistTaskOption), $3.(*ast.DistTaskOption))
+	}
+
+DistTaskOption:
+	"CONCURRENCY" EqOpt LengthNum
+	{
+		$$ = &ast.DistTaskOption{Tp: ast.DistTaskOptionConcurrency, UintValue: $3.(uint64)}
+	}
+|	"PRIORITY" EqOpt LengthNum
+	{
+		$$ = &ast.DistTaskOption{Tp: ast.DistTaskOptionPriority, UintValue: $3.(uint64)}
+	}
 
 AdminShowSlow:
 	"RECENT" NUM
@@ -11991,6 +12259,7 @@ Statement:
 |	AlterInstanceStmt
 |	AlterRangeStmt
 |	AlterSequenceStmt
+|	AlterEventStmt
 |	AlterPolicyStmt
 |	AlterResourceGroupStmt
 |	AnalyzeTableStmt
@@ -12008,6 +12277,7 @@ Statement:
 |	CreateIndexStmt
 |	CreateTableStmt
 |	CreateViewStmt
+|	CreateEventStmt
 |	CreateUserStmt
 |	CreateRoleStmt
 |	CreateBindingStmt
@@ -12025,6 +12295,7 @@ Statement:
 |	DropPolicyStmt
 |	DropSequenceStmt
 |	DropViewStmt
+|	DropEventStmt
 |	DropUserStmt
 |	DropResourceGroupStmt
 |	DropQueryWatchStmt
@@ -12442,6 +12713,35 @@ TableOption:
 		}
 		$$ = &ast.TableOption{Tp: ast.TableOptionTTLJobInterval, StrValue: $3}
 	}
+|	"TTL_ARCHIVE" EqOpt stringLit
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLArchive, StrValue: $3}
+	}
+|	"TTL_ARCHIVE_FORMAT" EqOpt stringLit
+	{
+		format := strings.ToLower($3)
+		if format != "csv" && format != "parquet" {
+			yylex.AppendError(yylex.Errorf("The TTL_ARCHIVE_FORMAT option has to be set 'CSV' or 'PARQUET'"))
+			return 1
+		}
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLArchiveFormat, StrValue: format}
+	}
+|	"TTL_FILTER" EqOpt stringLit
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLFilter, StrValue: $3}
+	}
+|	"TTL_PARTITION_LIFECYCLE" EqOpt stringLit
+	{
+		onOrOff := strings.ToLower($3)
+		if onOrOff == "on" {
+			$$ = &ast.TableOption{Tp: ast.TableOptionTTLPartitionLifecycle, BoolValue: true}
+		} else if onOrOff == "off" {
+			$$ = &ast.TableOption{Tp: ast.TableOptionTTLPartitionLifecycle, BoolValue: false}
+		} else {
+			yylex.AppendError(yylex.Errorf("The TTL_PARTITION_LIFECYCLE option has to be set 'ON' or 'OFF'"))
+			return 1
+		}
+	}
 
 ForceOpt:
 	/* empty */
@@ -16168,6 +16468,10 @@ QueryWatchOption:
 	{
 		$$ = &ast.QueryWatchOption{Tp: ast.QueryWatchAction, IntValue: $3.(int32)}
 	}
+|	"ACTION" EqOpt "SWITCH_GROUP" '(' ResourceGroupName ')'
+	{
+		$$ = &ast.QueryWatchOption{Tp: ast.QueryWatchAction, IntValue: int32(model.RunawayActionSwitchGroup), StrValue: model.NewCIStr($5)}
+	}
 |	QueryWatchTextOption
 	{
 		$$ = $1.(*ast.QueryWatchOption)
@@ -16186,6 +16490,22 @@ QueryWatchTextOption:
 	{
 		$$ = &ast.QueryWatchOption{Tp: ast.QueryWatchType, IntValue: $3.(int32), ExprValue: $5, BoolValue: true}
 	}
+|	"SQL" "LIKE" SimpleExpr
+	{
+		$$ = &ast.QueryWatchOption{Tp: ast.QueryWatchType, IntValue: int32(model.WatchLike), ExprValue: $3}
+	}
+|	"TABLE" TableName
+	{
+		$$ = &ast.QueryWatchOption{Tp: ast.QueryWatchType, IntValue: int32(model.WatchTable), TableName: $2.(*ast.TableName)}
+	}
+|	"USER" SimpleExpr
+	{
+		$$ = &ast.QueryWatchOption{Tp: ast.QueryWatchType, IntValue: int32(model.WatchUser), ExprValue: $2}
+	}
+|	"SCANNED_KEYS" EqOpt SimpleExpr
+	{
+		$$ = &ast.QueryWatchOption{Tp: ast.QueryWatchType, IntValue: int32(model.WatchScannedKeys), ExprValue: $3}
+	}
 
 DropQueryWatchStmt:
 	"QUERY" "WATCH" "REMOVE" NUM
@@ -519,6 +519,16 @@ func TestAdminStmt(t *testing.T) {
 		{"admin set bdr role secondary", true, "ADMIN SET BDR ROLE SECONDARY"},
 		{"admin unset bdr role", true, "ADMIN UNSET BDR ROLE"},
 		{"admin show bdr role", true, "ADMIN SHOW BDR ROLE"},
+		{"admin show dist tasks", true, "ADMIN SHOW DIST TASKS"},
+		{"admin pause dist task 1", true, "ADMIN PAUSE DIST TASK 1"},
+		{"admin resume dist task 1", true, "ADMIN RESUME DIST TASK 1"},
+		{"admin cancel dist task 1", true, "ADMIN CANCEL DIST TASK 1"},
+		{"admin alter dist task 1 concurrency = 8", true, "ADMIN ALTER DIST TASK 1 CONCURRENCY = 8"},
+		{"admin alter dist task 1 concurrency 8, priority=100", true, "ADMIN ALTER DIST TASK 1 CONCURRENCY = 8, PRIORITY = 100"},
+		{"admin alter dist task 1", false, ""},
+		{"admin pause dist task", false, ""},
+		{"admin show dist task", false, ""},
+		{"create table task (task int, tasks int, dist int)", true, "CREATE TABLE `task` (`task` INT,`tasks` INT,`dist` INT)"},
 	}
 	RunTest(t, table, false)
 }
@@ -1148,6 +1158,17 @@ AAAAAAAAAAAA5gm5Mg==
 		{"query watch add resource group `default` resource group `rg1` SQL TEXT SIMILAR to 'select 1'", false, ""},
 		{"query watch add SQL SIMILAR to 'select 1'", false, ""},
 		{"query watch add SQL TEXT SIMILAR 'select 1'", false, ""},
+		{"query watch add SQL LIKE 'select % from `t`%'", true, "QUERY WATCH ADD SQL LIKE _UTF8MB4'select % from `t`%'"},
+		{"query watch add TABLE test.t1", true, "QUERY WATCH ADD TABLE `test`.`t1`"},
+		{"query watch add resource group rg1 TABLE t1 ACTION = KILL", true, "QUERY WATCH ADD RESOURCE GROUP `rg1` TABLE `t1` ACTION = KILL"},
+		{"query watch add USER 'u1'", true, "QUERY WATCH ADD USER _UTF8MB4'u1'"},
+		{"query watch add USER @u", true, "QUERY WATCH ADD USER @`u`"},
+		{"query watch add SCANNED_KEYS 10000", true, "QUERY WATCH ADD SCANNED_KEYS = 10000"},
+		{"query watch add SCANNED_KEYS = 10000 ACTION COOLDOWN", true, "QUERY WATCH ADD SCANNED_KEYS = 10000 ACTION = COOLDOWN"},
+		{"query watch add ACTION = SWITCH_GROUP(rg2) SQL TEXT SIMILAR to 'select 1'", true, "QUERY WATCH ADD ACTION = SWITCH_GROUP(`rg2`) SQL TEXT SIMILAR TO _UTF8MB4'select 1'"},
+		{"query watch add resource group rg1 ACTION SWITCH_GROUP(`low`) TABLE t1", true, "QUERY WATCH ADD RESOURCE GROUP `rg1` ACTION = SWITCH_GROUP(`low`) TABLE `t1`"},
+		{"query watch add ACTION = SWITCH_GROUP SQL TEXT SIMILAR to 'select 1'", false, ""},
+		{"query watch add TABLE t1 USER 'u1'", false, ""},
 		{"query watch remove 1", true, "QUERY WATCH REMOVE 1"},
 		{"query watch remove", false, ""},
 
@@ -5668,6 +5689,56 @@ func TestView(t *testing.T) {
 	require.Equal(t, model.CheckOptionCascaded, v.CheckOption)
 }
 
+func TestEvent(t *testing.T) {
+	table := []testCase{
+		{"create event e on schedule at '2024-01-01 00:00:00' do delete from t", true, "CREATE EVENT `e` ON SCHEDULE AT _UTF8MB4'2024-01-01 00:00:00' DO DELETE FROM `t`"},
+		{"create event if not exists test.e on schedule at current_timestamp + interval 1 hour do delete from t where a < now()", true, "CREATE EVENT IF NOT EXISTS `test`.`e` ON SCHEDULE AT DATE_ADD(CURRENT_TIMESTAMP(), INTERVAL 1 HOUR) DO DELETE FROM `t` WHERE `a`<NOW()"},
+		{"create definer = 'root'@'%' event e on schedule every 1 day starts '2024-01-01' ends '2025-01-01' on completion preserve disable comment 'cleanup' do insert into t select * from t1", true, "CREATE DEFINER = `root`@`%` EVENT `e` ON SCHEDULE EVERY 1 DAY STARTS _UTF8MB4'2024-01-01' ENDS _UTF8MB4'2025-01-01' ON COMPLETION PRESERVE DISABLE COMMENT 'cleanup' DO INSERT INTO `t` SELECT * FROM `t1`"},
+		{"create definer = current_user event e on schedule every '1:30' hour_minute on completion not preserve enable do update t set a = a + 1", true, "CREATE EVENT `e` ON SCHEDULE EVERY _UTF8MB4'1:30' HOUR_MINUTE ON COMPLETION NOT PRESERVE ENABLE DO UPDATE `t` SET `a`=`a`+1"},
+		{"create event e on schedule every 1 hour do analyze table t", true, "CREATE EVENT `e` ON SCHEDULE EVERY 1 HOUR DO ANALYZE TABLE `t`"},
+		{"create event e on schedule every 1 hour do truncate table t", true, "CREATE EVENT `e` ON SCHEDULE EVERY 1 HOUR DO TRUNCATE TABLE `t`"},
+		{"create event e on schedule every 1 hour do do sleep(1)", true, "CREATE EVENT `e` ON SCHEDULE EVERY 1 HOUR DO DO SLEEP(1)"},
+		{"create event e on schedule every 1 hour", false, ""},
+		{"create event e do delete from t", false, ""},
+		{"create event e on schedule every 1 hour do create table t(a int)", false, ""},
+		{"create or replace event e on schedule every 1 hour do delete from t", false, ""},
+		{"create algorithm = merge event e on schedule every 1 hour do delete from t", false, ""},
+		{"create sql security invoker event e on schedule every 1 hour do delete from t", false, ""},
+
+		{"alter event e on schedule every 2 hour", true, "ALTER EVENT `e` ON SCHEDULE EVERY 2 HOUR"},
+		{"alter event e on completion preserve", true, "ALTER EVENT `e` ON COMPLETION PRESERVE"},
+		{"alter definer = 'u'@'localhost' event test.e on schedule at '2024-01-01' on completion not preserve rename to test.e1 disable comment '' do delete from t", true, "ALTER DEFINER = `u`@`localhost` EVENT `test`.`e` ON SCHEDULE AT _UTF8MB4'2024-01-01' ON COMPLETION NOT PRESERVE RENAME TO `test`.`e1` DISABLE COMMENT '' DO DELETE FROM `t`"},
+		{"alter event e enable", true, "ALTER EVENT `e` ENABLE"},
+		{"alter event e do delete from t", true, "ALTER EVENT `e` DO DELETE FROM `t`"},
+		{"alter event e", false, ""},
+
+		{"drop event e", true, "DROP EVENT `e`"},
+		{"drop event if exists test.e", true, "DROP EVENT IF EXISTS `test`.`e`"},
+
+		// the new keywords are unreserved
+		{"create table at (at int, completion int, ends int, every int, starts int)", true, "CREATE TABLE `at` (`at` INT,`completion` INT,`ends` INT,`every` INT,`starts` INT)"},
+	}
+	RunTest(t, table, false)
+
+	p := parser.New()
+	stmts, _, err := p.Parse("create event e on schedule every 1 hour do delete from t where a > 1; select 1", "", "")
+	require.NoError(t, err)
+	require.Len(t, stmts, 2)
+	e, ok := stmts[0].(*ast.CreateEventStmt)
+	require.True(t, ok)
+	// the text of the body may contain the following statements, which should be trimmed by the caller
+	require.Equal(t, "delete from t where a > 1; select 1", e.Body.Text())
+	require.Equal(t, ast.TimeUnitHour, e.Schedule.EveryUnit)
+
+	st, err := p.ParseOneStmt("alter event e comment 'c' do delete from t", "", "")
+	require.NoError(t, err)
+	alter, ok := st.(*ast.AlterEventStmt)
+	require.True(t, ok)
+	require.True(t, alter.Definer.CurrentUser)
+	require.Equal(t, "c", *alter.Comment)
+	require.Equal(t, "delete from t", alter.Body.Text())
+}
+
 func TestTimestampDiffUnit(t *testing.T) {
 	// Test case for timestampdiff unit.
 	// TimeUnit should be unified to upper case.
@@ -7462,6 +7533,21 @@ func TestTTLTableOption(t *testing.T) {
 		{"create table t (created_at datetime) TTL_JOB_INTERVAL = '@monthly'", false, ""},
 		{"create table t (created_at datetime) TTL_JOB_INTERVAL = '10hourxx'", false, ""},
 		{"create table t (created_at datetime) TTL_JOB_INTERVAL = '10.10.255h'", false, ""},
+
+		// archive the expired rows
+		{"create table t (created_at datetime) TTL = created_at + INTERVAL 1 YEAR TTL_ARCHIVE = 's3://bucket/prefix' TTL_ARCHIVE_FORMAT = 'PARQUET'", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 1 YEAR TTL_ARCHIVE = 's3://bucket/prefix' TTL_ARCHIVE_FORMAT = 'parquet'"},
+		{"alter table t TTL_ARCHIVE 'local:///tmp/archive' TTL_ARCHIVE_FORMAT 'csv'", true, "ALTER TABLE `t` TTL_ARCHIVE = 'local:///tmp/archive' TTL_ARCHIVE_FORMAT = 'csv'"},
+		{"alter table t /*T![ttl] TTL_ARCHIVE = '' */", true, "ALTER TABLE `t` TTL_ARCHIVE = ''"},
+		{"create table t (created_at datetime) TTL_ARCHIVE_FORMAT = 'json'", false, ""},
+		{"create table t (created_at datetime) TTL = created_at + INTERVAL 1 MONTH TTL_PARTITION_LIFECYCLE = 'ON'", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 1 MONTH TTL_PARTITION_LIFECYCLE = 'ON'"},
+		{"alter table t TTL_PARTITION_LIFECYCLE 'off'", true, "ALTER TABLE `t` TTL_PARTITION_LIFECYCLE = 'OFF'"},
+		{"alter table t /*T![ttl] TTL_PARTITION_LIFECYCLE = 'ON' */", true, "ALTER TABLE `t` TTL_PARTITION_LIFECYCLE = 'ON'"},
+		{"create table t (created_at datetime) TTL_PARTITION_LIFECYCLE = 'yes'", false, ""},
+
+		// filter the expired rows
+		{"create table t (created_at datetime, pinned int) TTL = created_at + INTERVAL 30 DAY TTL_FILTER = 'pinned <> 1'", true, "CREATE TABLE `t` (`created_at` DATETIME,`pinned` INT) TTL = `created_at` + INTERVAL 30 DAY TTL_FILTER = 'pinned <> 1'"},
+		{"alter table t TTL_FILTER \"status = 'done'\"", true, "ALTER TABLE `t` TTL_FILTER = 'status = ''done'''"},
+		{"alter table t /*T![ttl] TTL_FILTER = '' */", true, "ALTER TABLE `t` TTL_FILTER = ''"},
 	}
 
 	RunTest(t, table, false)
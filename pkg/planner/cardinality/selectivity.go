@@ -75,6 +75,20 @@ func Selectivity(
 	ret := 1.0
 	sc := ctx.GetSessionVars().StmtCtx
 	tableID := coll.PhysicalID
+	// If the same conditions on this table have been executed before, trust the observed selectivity.
+	if ctx.GetSessionVars().EnableCardinalityFeedback {
+		conds := string(expression.SortedExplainExpressionList(ctx, exprs))
+		if sel, ok := statistics.GlobalCardinalityFeedback.CorrectedSelectivity(tableID, conds); ok {
+			if sc.EnableOptimizerCETrace {
+				ceTraceExpr(ctx, tableID, "Table Stats-Feedback-Expression",
+					expression.ComposeCNFCondition(ctx, exprs...), sel*float64(coll.RealtimeCount))
+			}
+			if sc.EnableOptimizerDebugTrace {
+				debugtrace.RecordAnyValuesWithNames(ctx, "Feedback Selectivity", sel)
+			}
+			return sel, nil, nil
+		}
+	}
 	// TODO: If len(exprs) is bigger than 63, we could use bitset structure to replace the int64.
 	// This will simplify some code and speed up if we use this rather than a boolean slice.
 	if len(exprs) > 63 || (len(coll.Columns) == 0 && len(coll.Indices) == 0) {
@@ -1170,3 +1170,55 @@ func TestIgnoreRealtimeStats(t *testing.T) {
 	testKit.MustExec("set @@tidb_opt_objective = 'determinate'")
 	testKit.MustQuery("explain select * from t where a = 1 and b > 2").Check(testkit.Rows(analyzedPlan...))
 }
+
+func TestCardinalityFeedback(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	testKit := testkit.NewTestKit(t, store)
+	h := dom.StatsHandle()
+	statistics.GlobalCardinalityFeedback.Reset()
+	defer statistics.GlobalCardinalityFeedback.Reset()
+
+	testKit.MustExec("use test")
+	testKit.MustExec("create table t(a int, b int)")
+	require.NoError(t, h.HandleDDLEvent(<-h.DDLEventCh()))
+	values := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		values = append(values, fmt.Sprintf("(%d, 1)", i))
+	}
+	testKit.MustExec("insert into t values " + strings.Join(values, ","))
+	require.Nil(t, h.DumpStatsDeltaToKV(true))
+	testKit.MustExec("analyze table t")
+	// The stats still says b = 1 for all the rows.
+	testKit.MustExec("update t set b = 2")
+	require.Nil(t, h.DumpStatsDeltaToKV(true))
+	require.Nil(t, h.Update(dom.InfoSchema()))
+
+	getSelectionEstRows := func() string {
+		rows := testKit.MustQuery("explain format = 'brief' select * from t where b = 2").Rows()
+		for _, row := range rows {
+			if strings.Contains(row[0].(string), "Selection") {
+				return row[1].(string)
+			}
+		}
+		require.FailNow(t, "selection not found")
+		return ""
+	}
+	badEstRows := getSelectionEstRows()
+	require.NotEqual(t, "1000.00", badEstRows)
+
+	// The feedback is not collected if it's disabled.
+	testKit.MustQuery("select count(*) from t where b = 2").Check(testkit.Rows("1000"))
+	testKit.MustQuery("select count(*) from t where b = 2").Check(testkit.Rows("1000"))
+	require.Equal(t, badEstRows, getSelectionEstRows())
+
+	testKit.MustExec("set @@tidb_enable_cardinality_feedback = 1")
+	testKit.MustQuery("select * from t where b = 2")
+	// The predicate must be observed more than once before correcting the estimation.
+	require.Equal(t, badEstRows, getSelectionEstRows())
+	testKit.MustQuery("select * from t where b = 2")
+	require.Equal(t, "1000.00", getSelectionEstRows())
+
+	// The observed selectivity is not used if the feedback is disabled.
+	testKit.MustExec("set @@tidb_enable_cardinality_feedback = 0")
+	require.Equal(t, badEstRows, getSelectionEstRows())
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
+)
+
+// CollectCardinalityFeedback compares the estimated and the actual row count of every operator in the executed plan.
+// It returns the operators which are badly estimated, and the observed selectivity of the filters pushed down onto
+// table scans and index scans, which are used to correct the later estimations of the same filters.
+func CollectCardinalityFeedback(
+	sctx sessionctx.Context,
+	flat *FlatPhysicalPlan,
+	runtimeStatsColl *execdetails.RuntimeStatsColl,
+) (deviations []statistics.OperatorDeviation, predicates []statistics.PredicateObservation) {
+	if flat == nil || runtimeStatsColl == nil {
+		return nil, nil
+	}
+	trees := make([]FlatPlanTree, 0, len(flat.CTEs)+1)
+	trees = append(trees, flat.Main)
+	trees = append(trees, flat.CTEs...)
+	for _, tree := range trees {
+		for _, op := range tree {
+			if !op.IsPhysicalPlan {
+				continue
+			}
+			p := op.Origin.(PhysicalPlan)
+			actRows, ok := getActRows(p, runtimeStatsColl)
+			if !ok {
+				continue
+			}
+			estRows := p.getEstRowCountForDisplay()
+			if statistics.IsLargeDeviation(estRows, actRows) {
+				deviations = append(deviations, statistics.OperatorDeviation{
+					OperatorID: p.ExplainID().String(),
+					EstRows:    estRows,
+					ActRows:    actRows,
+				})
+			}
+
+			// Only the selection directly above a scan in the coprocessor could tell the selectivity of its conditions.
+			sel, ok := p.(*PhysicalSelection)
+			if !ok || op.IsRoot || len(op.ChildrenIdx) != 1 {
+				continue
+			}
+			child := tree[op.ChildrenIdx[0]]
+			var physicalTableID int64
+			switch x := child.Origin.(type) {
+			case *PhysicalTableScan:
+				physicalTableID = x.physicalTableID
+			case *PhysicalIndexScan:
+				physicalTableID = x.physicalTableID
+			default:
+				continue
+			}
+			childPlan := child.Origin.(PhysicalPlan)
+			childActRows, ok := getActRows(childPlan, runtimeStatsColl)
+			childEstRows := childPlan.getEstRowCountForDisplay()
+			if !ok || childActRows < statistics.FeedbackMinRows || childEstRows <= 0 {
+				continue
+			}
+			predicates = append(predicates, statistics.PredicateObservation{
+				Conditions:      string(expression.SortedExplainExpressionList(sctx, sel.Conditions)),
+				PhysicalTableID: physicalTableID,
+				EstSelectivity:  estRows / childEstRows,
+				ActSelectivity:  actRows / childActRows,
+			})
+		}
+	}
+	return deviations, predicates
+}
+
+func getActRows(p PhysicalPlan, runtimeStatsColl *execdetails.RuntimeStatsColl) (float64, bool) {
+	id := p.ID()
+	if runtimeStatsColl.ExistsCopStats(id) {
+		return float64(runtimeStatsColl.GetCopStats(id).GetActRows()), true
+	}
+	if runtimeStatsColl.ExistsRootStats(id) {
+		return float64(runtimeStatsColl.GetRootStats(id).GetActRows()), true
+	}
+	return 0, false
+}
@@ -1450,3 +1450,8 @@ func IsAutoCommitTxn(ctx sessionctx.Context) bool {
 type AdminShowBDRRole struct {
 	baseSchemaProducer
 }
+
+// AdminShowDistTasks represents a show distributed tasks plan.
+type AdminShowDistTasks struct {
+	baseSchemaProducer
+}
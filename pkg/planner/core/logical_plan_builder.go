@@ -4590,10 +4590,16 @@ func getStatsTable(ctx sessionctx.Context, tblInfo *model.TableInfo, pid int64)
 	}
 
 	if pid == tblInfo.ID || ctx.GetSessionVars().StmtCtx.UseDynamicPartitionPrune() {
-		statsTbl = statsHandle.GetTableStats(tblInfo)
+		statsTbl = getOverriddenStatsTable(ctx, tblInfo.ID)
+		if statsTbl == nil {
+			statsTbl = statsHandle.GetTableStats(tblInfo)
+		}
 	} else {
 		usePartitionStats = true
-		statsTbl = statsHandle.GetPartitionStats(tblInfo, pid)
+		statsTbl = getOverriddenStatsTable(ctx, pid)
+		if statsTbl == nil {
+			statsTbl = statsHandle.GetPartitionStats(tblInfo, pid)
+		}
 	}
 
 	allowPseudoTblTriggerLoading := false
@@ -4644,6 +4650,15 @@ func getStatsTable(ctx sessionctx.Context, tblInfo *model.TableInfo, pid int64)
 	return statsTbl
 }
 
+// getOverriddenStatsTable returns the statistics table specified by SessionVars.OptimizerStatsOverride, or nil if
+// there is no such table.
+func getOverriddenStatsTable(ctx sessionctx.Context, physicalID int64) *statistics.Table {
+	if tbl, ok := ctx.GetSessionVars().OptimizerStatsOverride[physicalID]; ok {
+		return tbl.(*statistics.Table)
+	}
+	return nil
+}
+
 // getLatestVersionFromStatsTable gets statistics information for a table specified by "tableID", and get the max
 // LastUpdateVersion among all Columns and Indices in it.
 // Its overall logic is quite similar to getStatsTable(). During plan cache matching, only the latest version is needed.
@@ -4658,9 +4673,15 @@ func getLatestVersionFromStatsTable(ctx sessionctx.Context, tblInfo *model.Table
 
 	var statsTbl *statistics.Table
 	if pid == tblInfo.ID || ctx.GetSessionVars().StmtCtx.UseDynamicPartitionPrune() {
-		statsTbl = statsHandle.GetTableStats(tblInfo)
+		statsTbl = getOverriddenStatsTable(ctx, tblInfo.ID)
+		if statsTbl == nil {
+			statsTbl = statsHandle.GetTableStats(tblInfo)
+		}
 	} else {
-		statsTbl = statsHandle.GetPartitionStats(tblInfo, pid)
+		statsTbl = getOverriddenStatsTable(ctx, pid)
+		if statsTbl == nil {
+			statsTbl = statsHandle.GetPartitionStats(tblInfo, pid)
+		}
 	}
 
 	// 2. Table row count from statistics is zero. Pseudo stats table.
@@ -329,9 +329,8 @@ func (p *PhysicalIndexReader) Clone() (PhysicalPlan, error) {
 	if cloned.indexPlan, err = p.indexPlan.Clone(); err != nil {
 		return nil, err
 	}
-	if cloned.IndexPlans, err = clonePhysicalPlan(p.IndexPlans); err != nil {
-		return nil, err
-	}
+	// IndexPlans are actually the flattened plans in indexPlan, so can't copy them, just need to extract from indexPlan
+	cloned.IndexPlans = flattenPushDownPlan(cloned.indexPlan)
 	cloned.OutputColumns = util.CloneCols(p.OutputColumns)
 	return cloned, err
 }
@@ -459,18 +458,16 @@ func (p *PhysicalIndexLookUpReader) Clone() (PhysicalPlan, error) {
 		return nil, err
 	}
 	cloned.physicalSchemaProducer = *base
-	if cloned.IndexPlans, err = clonePhysicalPlan(p.IndexPlans); err != nil {
-		return nil, err
-	}
-	if cloned.TablePlans, err = clonePhysicalPlan(p.TablePlans); err != nil {
-		return nil, err
-	}
 	if cloned.indexPlan, err = p.indexPlan.Clone(); err != nil {
 		return nil, err
 	}
 	if cloned.tablePlan, err = p.tablePlan.Clone(); err != nil {
 		return nil, err
 	}
+	// IndexPlans and TablePlans are actually the flattened plans in indexPlan and tablePlan, so can't copy them,
+	// just need to extract from indexPlan and tablePlan.
+	cloned.IndexPlans = flattenPushDownPlan(cloned.indexPlan)
+	cloned.TablePlans = flattenPushDownPlan(cloned.tablePlan)
 	if p.ExtraHandleCol != nil {
 		cloned.ExtraHandleCol = p.ExtraHandleCol.Clone().(*expression.Column)
 	}
@@ -165,6 +165,9 @@ func GetPlanFromSessionPlanCache(ctx context.Context, sctx sessionctx.Context,
 	stmtCtx.UseCache = stmt.StmtCacheable && cacheEnabled
 	if !stmt.StmtCacheable && stmt.UncacheableReason != "" {
 		stmtCtx.SetSkipPlanCache(errors.New(stmt.UncacheableReason))
+		if cacheEnabled {
+			stmtCtx.PlanCacheUnqualified = stmt.UncacheableReason
+		}
 	}
 
 	var bindSQL string
@@ -264,11 +267,16 @@ func getCachedPlan(sctx sessionctx.Context, isNonPrepared bool, cacheKey kvcache
 	sessVars := sctx.GetSessionVars()
 	stmtCtx := sessVars.StmtCtx
 
-	candidate, exist := sctx.GetSessionPlanCache().Get(cacheKey, matchOpts)
-	if !exist {
+	var cachedVal *PlanCacheValue
+	fromInstanceCache := false
+	if candidate, exist := sctx.GetSessionPlanCache().Get(cacheKey, matchOpts); exist {
+		cachedVal = candidate.(*PlanCacheValue)
+	} else if variable.EnableInstancePlanCache.Load() {
+		cachedVal, fromInstanceCache = GetInstancePlanCache().Get(sctx, cacheKey, matchOpts)
+	}
+	if cachedVal == nil {
 		return nil, nil, false, nil
 	}
-	cachedVal := candidate.(*PlanCacheValue)
 	if err := CheckPreparedPriv(sctx, stmt, is); err != nil {
 		return nil, nil, false, err
 	}
@@ -276,7 +284,9 @@ func getCachedPlan(sctx sessionctx.Context, isNonPrepared bool, cacheKey kvcache
 		if !unionScan && tableHasDirtyContent(sctx, tblInfo) {
 			// TODO we can inject UnionScan into cached plan to avoid invalidating it, though
 			// rebuilding the filters in UnionScan is pretty trivial.
-			sctx.GetSessionPlanCache().Delete(cacheKey)
+			if !fromInstanceCache {
+				sctx.GetSessionPlanCache().Delete(cacheKey)
+			}
 			return nil, nil, false, nil
 		}
 	}
@@ -294,6 +304,10 @@ func getCachedPlan(sctx sessionctx.Context, isNonPrepared bool, cacheKey kvcache
 	} else {
 		core_metrics.GetPlanCacheHitCounter(isNonPrepared).Inc()
 	}
+	if fromInstanceCache && stmt.PlanDigest == nil {
+		// The plan is generated by another session.
+		stmt.NormalizedPlan, stmt.PlanDigest = NormalizePlan(cachedVal.Plan)
+	}
 	stmtCtx.SetPlanDigest(stmt.NormalizedPlan, stmt.PlanDigest)
 	stmtCtx.StmtHints = *cachedVal.stmtHints
 	return cachedVal.Plan, cachedVal.OutPutNames, true, nil
@@ -342,7 +356,11 @@ func generateNewPlan(ctx context.Context, sctx sessionctx.Context, isNonPrepared
 		stmt.NormalizedPlan, stmt.PlanDigest = NormalizePlan(p)
 		stmtCtx.SetPlan(p)
 		stmtCtx.SetPlanDigest(stmt.NormalizedPlan, stmt.PlanDigest)
-		sctx.GetSessionPlanCache().Put(cacheKey, cached, matchOpts)
+		// The plans which can be shared are cached in the instance plan cache only, to avoid keeping a copy in
+		// every session.
+		if !variable.EnableInstancePlanCache.Load() || !GetInstancePlanCache().Put(cacheKey, cached) {
+			sctx.GetSessionPlanCache().Put(cacheKey, cached, matchOpts)
+		}
 	}
 	sessVars.FoundInPlanCache = false
 	return p, names, err
@@ -0,0 +1,333 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/kv"
+	core_metrics "github.com/pingcap/tidb/pkg/planner/core/metrics"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util/kvcache"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	utilpc "github.com/pingcap/tidb/pkg/util/plancache"
+	"go.uber.org/zap"
+)
+
+// instancePlanCacheEntry is the value of list.Element in InstancePlanCache.
+type instancePlanCacheEntry struct {
+	hash          string
+	schemaVersion int64
+	value         *PlanCacheValue
+	memoryUsage   int64
+}
+
+// InstancePlanCache is the plan cache shared by all the sessions of the instance.
+// The cached plans are immutable, a plan is cloned and bound to the session for every execution,
+// so only the plans which support cloning can be cached, see walkPlanForInstanceCache.
+// The plans are evicted in the LRU order when the memory usage exceeds tidb_instance_plan_cache_max_mem_size.
+type InstancePlanCache struct {
+	mu sync.Mutex
+	// buckets maps the hash of the key to the plans of the same statement with different match options.
+	buckets map[string]map[*list.Element]struct{}
+	lruList *list.List
+
+	memoryUsage int64
+	// schemaVersion is the latest schema version of the cached plans. The plans built on an older schema version
+	// can never be hit again since the schema version is a part of the key, so they are evicted at once.
+	schemaVersion int64
+}
+
+// NewInstancePlanCache creates an InstancePlanCache.
+func NewInstancePlanCache() *InstancePlanCache {
+	return &InstancePlanCache{
+		buckets: make(map[string]map[*list.Element]struct{}),
+		lruList: list.New(),
+	}
+}
+
+var instancePlanCache = NewInstancePlanCache()
+
+// GetInstancePlanCache returns the instance plan cache.
+func GetInstancePlanCache() *InstancePlanCache {
+	return instancePlanCache
+}
+
+// Get returns a copy of the cached plan which is bound to the session.
+func (c *InstancePlanCache) Get(sctx sessionctx.Context, key kvcache.Key, opts *utilpc.PlanCacheMatchOpts) (*PlanCacheValue, bool) {
+	hash, schemaVersion, ok := instancePlanCacheKey(key)
+	if !ok {
+		return nil, false
+	}
+	vars := sctx.GetSessionVars()
+
+	var cached *PlanCacheValue
+	c.mu.Lock()
+	c.invalidateBySchemaVersion(schemaVersion)
+	for element := range c.buckets[hash] {
+		entry := element.Value.(*instancePlanCacheEntry)
+		if !matchPlanCacheOpts(vars, entry.value.matchOpts, opts) {
+			continue
+		}
+		if isPlanCacheStatsOutdated(vars, entry.value.matchOpts, opts) {
+			// The stats version only increases, the plan will never be hit again.
+			c.removeElement(element)
+			continue
+		}
+		c.lruList.MoveToFront(element)
+		cached = entry.value
+		break
+	}
+	c.mu.Unlock()
+	if cached == nil {
+		return nil, false
+	}
+
+	// The cached plan is immutable, so it's safe to clone it without holding the lock.
+	plan, err := clonePlanForSession(cached.Plan.(PhysicalPlan), sctx)
+	if err != nil {
+		logutil.BgLogger().Warn("clone the plan from instance plan cache failed", zap.Error(err))
+		return nil, false
+	}
+	return &PlanCacheValue{
+		Plan:              plan,
+		OutPutNames:       cached.OutPutNames,
+		TblInfo2UnionScan: cached.TblInfo2UnionScan,
+		matchOpts:         cached.matchOpts,
+		stmtHints:         cached.stmtHints,
+	}, true
+}
+
+// Put caches a copy of the plan, it does nothing if the plan can't be cached in the instance plan cache.
+func (c *InstancePlanCache) Put(key kvcache.Key, value *PlanCacheValue) bool {
+	hash, schemaVersion, ok := instancePlanCacheKey(key)
+	if !ok {
+		return false
+	}
+	p, ok := value.Plan.(PhysicalPlan)
+	if !ok || !IsInstancePlanCacheable(p) {
+		return false
+	}
+	// The session goes on using the original plan and may modify it, so cache a copy of it.
+	plan, err := p.Clone()
+	if err != nil {
+		return false
+	}
+	entry := &instancePlanCacheEntry{
+		hash:          hash,
+		schemaVersion: schemaVersion,
+		value: &PlanCacheValue{
+			Plan:              plan,
+			OutPutNames:       value.OutPutNames,
+			TblInfo2UnionScan: value.TblInfo2UnionScan,
+			matchOpts:         value.matchOpts,
+			stmtHints:         value.stmtHints,
+		},
+	}
+	entry.memoryUsage = entry.value.MemoryUsage() + int64(len(hash))
+	memLimit := variable.InstancePlanCacheMaxMemSize.Load()
+	if entry.memoryUsage > memLimit {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.invalidateBySchemaVersion(schemaVersion)
+	if schemaVersion < c.schemaVersion {
+		return false
+	}
+	bucket, ok := c.buckets[hash]
+	if !ok {
+		bucket = make(map[*list.Element]struct{}, 1)
+		c.buckets[hash] = bucket
+	}
+	// Replace the plan of the same match options, it's built on older stats or by another session concurrently.
+	for element := range bucket {
+		if matchPlanCacheOpts(nil, element.Value.(*instancePlanCacheEntry).value.matchOpts, value.matchOpts) {
+			c.removeElement(element)
+		}
+	}
+	element := c.lruList.PushFront(entry)
+	c.buckets[hash][element] = struct{}{}
+	c.memoryUsage += entry.memoryUsage
+	core_metrics.GetPlanCacheInstanceNumCounter().Add(1)
+	for c.memoryUsage > memLimit {
+		c.removeElement(c.lruList.Back())
+	}
+	return true
+}
+
+// DeleteAll evicts all the cached plans.
+func (c *InstancePlanCache) DeleteAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	core_metrics.GetPlanCacheInstanceNumCounter().Sub(float64(c.lruList.Len()))
+	c.buckets = make(map[string]map[*list.Element]struct{})
+	c.lruList = list.New()
+	c.memoryUsage = 0
+}
+
+// Size returns the number of the cached plans.
+func (c *InstancePlanCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lruList.Len()
+}
+
+// MemoryUsage returns the memory usage of the cached plans.
+func (c *InstancePlanCache) MemoryUsage() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.memoryUsage
+}
+
+// invalidateBySchemaVersion evicts the plans built on the schema versions older than the given one.
+func (c *InstancePlanCache) invalidateBySchemaVersion(schemaVersion int64) {
+	if schemaVersion <= c.schemaVersion {
+		return
+	}
+	c.schemaVersion = schemaVersion
+	for element := c.lruList.Front(); element != nil; {
+		next := element.Next()
+		if element.Value.(*instancePlanCacheEntry).schemaVersion < schemaVersion {
+			c.removeElement(element)
+		}
+		element = next
+	}
+}
+
+func (c *InstancePlanCache) removeElement(element *list.Element) {
+	entry := element.Value.(*instancePlanCacheEntry)
+	c.lruList.Remove(element)
+	bucket := c.buckets[entry.hash]
+	delete(bucket, element)
+	if len(bucket) == 0 {
+		delete(c.buckets, entry.hash)
+	}
+	c.memoryUsage -= entry.memoryUsage
+	core_metrics.GetPlanCacheInstanceNumCounter().Sub(1)
+}
+
+// IsInstancePlanCacheable checks whether the plan can be cached in the instance plan cache.
+func IsInstancePlanCacheable(p PhysicalPlan) bool {
+	return walkPlanForInstanceCache(p, func(PhysicalPlan, []expression.Expression) {})
+}
+
+// clonePlanForSession clones the cached plan and binds the copy to the session.
+func clonePlanForSession(p PhysicalPlan, sctx sessionctx.Context) (PhysicalPlan, error) {
+	cloned, err := p.Clone()
+	if err != nil {
+		return nil, err
+	}
+	walkPlanForInstanceCache(cloned, func(p PhysicalPlan, exprs []expression.Expression) {
+		p.(interface{ SetSCtx(sessionctx.Context) }).SetSCtx(sctx)
+		expression.RebindParamMarkers(sctx, exprs...)
+	})
+	return cloned, nil
+}
+
+// walkPlanForInstanceCache calls f with every operator in the plan and the expressions held by the operator.
+// It returns false if the plan contains any operator which can't be shared between sessions.
+func walkPlanForInstanceCache(p PhysicalPlan, f func(PhysicalPlan, []expression.Expression)) bool {
+	var exprs []expression.Expression
+	var pushedDown []PhysicalPlan
+	switch x := p.(type) {
+	case *PhysicalTableReader:
+		if x.StoreType != kv.TiKV {
+			return false
+		}
+		pushedDown = append(pushedDown, x.tablePlan)
+	case *PhysicalIndexReader:
+		pushedDown = append(pushedDown, x.indexPlan)
+	case *PhysicalIndexLookUpReader:
+		pushedDown = append(pushedDown, x.indexPlan, x.tablePlan)
+	case *PhysicalTableScan:
+		if len(x.runtimeFilterList) > 0 || len(x.LateMaterializationFilterCondition) > 0 ||
+			x.Table.GetPartitionInfo() != nil {
+			return false
+		}
+		exprs = append(exprs, x.AccessCondition...)
+		exprs = append(exprs, x.filterCondition...)
+	case *PhysicalIndexScan:
+		if x.Table.GetPartitionInfo() != nil {
+			return false
+		}
+		exprs = append(exprs, x.AccessCondition...)
+	case *PhysicalSelection:
+		exprs = append(exprs, x.Conditions...)
+	case *PhysicalProjection:
+		exprs = append(exprs, x.Exprs...)
+	case *PhysicalLimit, *PhysicalUnionAll:
+	case *PhysicalTopN:
+		for _, item := range x.ByItems {
+			exprs = append(exprs, item.Expr)
+		}
+	case *PhysicalSort:
+		for _, item := range x.ByItems {
+			exprs = append(exprs, item.Expr)
+		}
+	case *PhysicalHashAgg:
+		exprs = appendAggExprs(exprs, &x.basePhysicalAgg)
+	case *PhysicalStreamAgg:
+		exprs = appendAggExprs(exprs, &x.basePhysicalAgg)
+	case *PhysicalHashJoin:
+		if len(x.runtimeFilterList) > 0 {
+			return false
+		}
+		for _, cond := range x.EqualConditions {
+			exprs = append(exprs, cond)
+		}
+		for _, cond := range x.NAEqualConditions {
+			exprs = append(exprs, cond)
+		}
+		exprs = appendJoinExprs(exprs, &x.basePhysicalJoin)
+	case *PhysicalMergeJoin:
+		exprs = appendJoinExprs(exprs, &x.basePhysicalJoin)
+	default:
+		return false
+	}
+	f(p, exprs)
+	for _, child := range pushedDown {
+		if child == nil || !walkPlanForInstanceCache(child, f) {
+			return false
+		}
+	}
+	for _, child := range p.Children() {
+		if !walkPlanForInstanceCache(child, f) {
+			return false
+		}
+	}
+	return true
+}
+
+func appendAggExprs(exprs []expression.Expression, agg *basePhysicalAgg) []expression.Expression {
+	exprs = append(exprs, agg.GroupByItems...)
+	for _, aggFunc := range agg.AggFuncs {
+		exprs = append(exprs, aggFunc.Args...)
+		for _, item := range aggFunc.OrderByItems {
+			exprs = append(exprs, item.Expr)
+		}
+	}
+	return exprs
+}
+
+func appendJoinExprs(exprs []expression.Expression, join *basePhysicalJoin) []expression.Expression {
+	exprs = append(exprs, join.LeftConditions...)
+	exprs = append(exprs, join.RightConditions...)
+	return append(exprs, join.OtherConditions...)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/errors"
 	core_metrics "github.com/pingcap/tidb/pkg/planner/core/metrics"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/util/hack"
 	"github.com/pingcap/tidb/pkg/util/kvcache"
 	"github.com/pingcap/tidb/pkg/util/logutil"
@@ -252,36 +253,10 @@ func (l *LRUPlanCache) memoryControl() {
 
 // PickPlanFromBucket pick one plan from bucket
 func (l *LRUPlanCache) pickFromBucket(bucket map[*list.Element]struct{}, matchOpts *utilpc.PlanCacheMatchOpts) (*list.Element, bool) {
+	vars := l.sctx.GetSessionVars()
 	for k := range bucket {
 		plan := k.Value.(*planCacheEntry).PlanValue.(*PlanCacheValue)
-		// check param types' compatibility
-		ok1 := checkTypesCompatibility4PC(plan.matchOpts.ParamTypes, matchOpts.ParamTypes)
-		if !ok1 {
-			continue
-		}
-
-		// check limit offset and key if equal and check switch if enabled
-		ok2 := checkUint64SliceIfEqual(plan.matchOpts.LimitOffsetAndCount, matchOpts.LimitOffsetAndCount)
-		if !ok2 {
-			continue
-		}
-		if len(plan.matchOpts.LimitOffsetAndCount) > 0 && !l.sctx.GetSessionVars().EnablePlanCacheForParamLimit {
-			// offset and key slice matched, but it is a plan with param limit and the switch is disabled
-			continue
-		}
-		// check subquery switch state
-		if plan.matchOpts.HasSubQuery && !l.sctx.GetSessionVars().EnablePlanCacheForSubquery {
-			continue
-		}
-		// table stats has changed
-		// this check can be disabled by turning off system variable tidb_plan_cache_invalidation_on_fresh_stats
-		if l.sctx.GetSessionVars().PlanCacheInvalidationOnFreshStats &&
-			plan.matchOpts.StatsVersionHash != matchOpts.StatsVersionHash {
-			continue
-		}
-
-		// below are some SQL variables that can affect the plan
-		if plan.matchOpts.ForeignKeyChecks != matchOpts.ForeignKeyChecks {
+		if !matchPlanCacheOpts(vars, plan.matchOpts, matchOpts) || isPlanCacheStatsOutdated(vars, plan.matchOpts, matchOpts) {
 			continue
 		}
 		return k, true
@@ -289,6 +264,39 @@ func (l *LRUPlanCache) pickFromBucket(bucket map[*list.Element]struct{}, matchOp
 	return nil, false
 }
 
+// matchPlanCacheOpts checks whether the cached plan can be used by the current execution regardless of the stats.
+// The switches in the session variables are not checked if vars is nil.
+func matchPlanCacheOpts(vars *variable.SessionVars, cachedOpts, matchOpts *utilpc.PlanCacheMatchOpts) bool {
+	// check param types' compatibility
+	ok1 := checkTypesCompatibility4PC(cachedOpts.ParamTypes, matchOpts.ParamTypes)
+	if !ok1 {
+		return false
+	}
+
+	// check limit offset and key if equal and check switch if enabled
+	ok2 := checkUint64SliceIfEqual(cachedOpts.LimitOffsetAndCount, matchOpts.LimitOffsetAndCount)
+	if !ok2 {
+		return false
+	}
+	if vars != nil && len(cachedOpts.LimitOffsetAndCount) > 0 && !vars.EnablePlanCacheForParamLimit {
+		// offset and key slice matched, but it is a plan with param limit and the switch is disabled
+		return false
+	}
+	// check subquery switch state
+	if vars != nil && cachedOpts.HasSubQuery && !vars.EnablePlanCacheForSubquery {
+		return false
+	}
+
+	// below are some SQL variables that can affect the plan
+	return cachedOpts.ForeignKeyChecks == matchOpts.ForeignKeyChecks
+}
+
+// isPlanCacheStatsOutdated checks whether the table stats have changed since the plan was cached.
+// This check can be disabled by turning off system variable tidb_plan_cache_invalidation_on_fresh_stats.
+func isPlanCacheStatsOutdated(vars *variable.SessionVars, cachedOpts, matchOpts *utilpc.PlanCacheMatchOpts) bool {
+	return vars.PlanCacheInvalidationOnFreshStats && cachedOpts.StatsVersionHash != matchOpts.StatsVersionHash
+}
+
 func checkUint64SliceIfEqual(a, b []uint64) bool {
 	if (a == nil && b != nil) || (a != nil && b == nil) {
 		return false
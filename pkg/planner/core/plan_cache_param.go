@@ -49,9 +49,18 @@ var (
 	}}
 )
 
+// maxPaddedInListLen is the max length of the in-lists padded by paramReplacer.
+const maxPaddedInListLen = 128
+
 // paramReplacer is an ast.Visitor that replaces all values with `?` and collects them.
+// The in-lists of values are padded to the lengths of power of 2 by repeating the last value, e.g.
+// `a in (1, 2, 3)` --> `a in (?, ?, ?, ?)`, [1, 2, 3, 3], so that the queries with in-lists of similar lengths
+// can share the same cached plan.
 type paramReplacer struct {
 	params []*driver.ValueExpr
+
+	paddedInLists   []*ast.PatternInExpr
+	paddedInListLen []int // the original lengths of paddedInLists
 }
 
 func (pr *paramReplacer) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
@@ -82,12 +91,62 @@ func (pr *paramReplacer) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 	return in, false
 }
 
-func (*paramReplacer) Leave(in ast.Node) (out ast.Node, ok bool) {
+func (pr *paramReplacer) Leave(in ast.Node) (out ast.Node, ok bool) {
+	if n, isIn := in.(*ast.PatternInExpr); isIn && n.Sel == nil {
+		pr.padInList(n)
+	}
 	return in, true
 }
 
+func (pr *paramReplacer) padInList(in *ast.PatternInExpr) {
+	n := len(in.List)
+	padded := inListBucketLen(n)
+	if padded == n {
+		return
+	}
+	for _, expr := range in.List {
+		if _, ok := expr.(*driver.ParamMarkerExpr); !ok {
+			return
+		}
+	}
+	last := pr.params[in.List[n-1].(*driver.ParamMarkerExpr).Offset]
+	for len(in.List) < padded {
+		pr.params = append(pr.params, last)
+		param := paramMakerPool.Get().(*driver.ParamMarkerExpr)
+		param.Offset = len(pr.params) - 1
+		last.Datum.Copy(&param.Datum)
+		in.List = append(in.List, param)
+	}
+	pr.paddedInLists = append(pr.paddedInLists, in)
+	pr.paddedInListLen = append(pr.paddedInListLen, n)
+}
+
+// unpadInLists removes the values appended by padInList from the AST.
+func (pr *paramReplacer) unpadInLists() {
+	for i, in := range pr.paddedInLists {
+		n := pr.paddedInListLen[i]
+		for _, expr := range in.List[n:] {
+			paramMakerPool.Put(expr)
+		}
+		in.List = in.List[:n]
+	}
+}
+
+// inListBucketLen returns the length of the in-list after padding.
+func inListBucketLen(n int) int {
+	if n <= 2 || n > maxPaddedInListLen {
+		return n
+	}
+	bucket := 4
+	for bucket < n {
+		bucket *= 2
+	}
+	return bucket
+}
+
 func (pr *paramReplacer) Reset() {
 	pr.params = make([]*driver.ValueExpr, 0, 4)
+	pr.paddedInLists, pr.paddedInListLen = nil, nil
 }
 
 // GetParamSQLFromAST returns the parameterized SQL of this AST.
@@ -121,7 +180,9 @@ func ParameterizeAST(stmt ast.StmtNode) (paramSQL string, params []*driver.Value
 		paramCtxPool.Put(pCtx)
 	}()
 	stmt.Accept(pr)
-	if err := stmt.Restore(pCtx); err != nil {
+	err = stmt.Restore(pCtx)
+	pr.unpadInLists()
+	if err != nil {
 		return "", nil, err
 	}
 	paramSQL, params = pCtx.In.(*bytes.Buffer).String(), pr.params
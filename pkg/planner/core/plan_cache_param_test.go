@@ -17,12 +17,14 @@ package core
 import (
 	"fmt"
 	"math/rand"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/stretchr/testify/require"
 )
 
@@ -104,6 +106,23 @@ func TestParameterize(t *testing.T) {
 			"SELECT * FROM `t` LIMIT 10,20",
 			[]interface{}{},
 		},
+
+		// pad the in-lists to share the cached plans
+		{
+			"select * from t where a in (1, 2, 3)",
+			"SELECT * FROM `t` WHERE `a` IN (?,?,?,?)",
+			[]interface{}{int64(1), int64(2), int64(3), int64(3)},
+		},
+		{
+			"select * from t where a in (1, 2) and b not in (1, 2, 3, 4, 5)",
+			"SELECT * FROM `t` WHERE `a` IN (?,?) AND `b` NOT IN (?,?,?,?,?,?,?,?)",
+			[]interface{}{int64(1), int64(2), int64(1), int64(2), int64(3), int64(4), int64(5), int64(5), int64(5), int64(5)},
+		},
+		{
+			"select * from t where a in (1, b, 3)",
+			"SELECT * FROM `t` WHERE `a` IN (?,`b`,?)",
+			[]interface{}{int64(1), int64(3)},
+		},
 		// TODO: more test cases
 	}
 
@@ -118,6 +137,17 @@ func TestParameterize(t *testing.T) {
 			require.Equal(t, c.params[i], params[i].Datum.GetValue())
 		}
 	}
+
+	// The padded values are removed from the original AST.
+	stmt, err := parser.New().ParseOneStmt("select * from t where a in (1, 2, 3)", "", "")
+	require.Nil(t, err)
+	paramSQL, params, err := GetParamSQLFromAST(stmt)
+	require.Nil(t, err)
+	require.Equal(t, "SELECT * FROM `t` WHERE `a` IN (?,?,?,?)", paramSQL)
+	require.Len(t, params, 4)
+	var sb strings.Builder
+	require.Nil(t, stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)))
+	require.Equal(t, "SELECT * FROM `t` WHERE `a` IN (1,2,3)", sb.String())
 }
 
 func TestGetParamSQLFromASTConcurrently(t *testing.T) {
@@ -212,6 +212,23 @@ func TestNonPreparedPlanCacheBasically(t *testing.T) {
 	}
 }
 
+func TestNonPreparedPlanCacheTables(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t (a int, b int, key(b))`)
+	tk.MustExec(`set tidb_enable_non_prepared_plan_cache=1`)
+
+	for _, a := range []int{1, 2} {
+		tk.MustQuery(fmt.Sprintf("select * from t where a<%d", a))
+		tables := tk.Session().GetSessionVars().StmtCtx.Tables
+		require.Len(t, tables, 1)
+		require.Equal(t, "test", tables[0].DB)
+		require.Equal(t, "t", tables[0].Table)
+	}
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+}
+
 func TestNonPreparedPlanCacheInternalSQL(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -391,19 +408,65 @@ func TestIssue49736Partition(t *testing.T) {
 	tk.MustExec("use test")
 	tk.MustExec("create table t (a int) partition by hash(a) partitions 4")
 	tk.MustExec(`analyze table t`)
+	tk.MustExec(`set @@tidb_partition_prune_mode = 'static'`)
 	tk.MustExec(`prepare st from 'select * from t where a=?'`)
-	tk.MustQuery(`show warnings`).Check(testkit.Rows("Warning 1105 skip prepared plan-cache: query accesses partitioned tables is un-cacheable"))
+	tk.MustQuery(`show warnings`).Check(testkit.Rows("Warning 1105 skip prepared plan-cache: query accesses partitioned tables is un-cacheable in static prune mode"))
 
 	tk.MustExec(`set @@tidb_opt_fix_control = "49736:ON"`)
 	tk.MustExec(`prepare st from 'select * from t where a=?'`)
-	tk.MustQuery(`show warnings`).Check(testkit.Rows("Warning 1105 force plan-cache: may use risky cached plan: query accesses partitioned tables is un-cacheable"))
+	tk.MustQuery(`show warnings`).Check(testkit.Rows("Warning 1105 force plan-cache: may use risky cached plan: query accesses partitioned tables is un-cacheable in static prune mode"))
 	tk.MustExec(`set @a=1`)
 	tk.MustExec(`execute st using @a`)
-	tk.MustQuery(`show warnings`).Check(testkit.Rows())
+	tk.MustQuery(`show warnings`).Check(testkit.Rows("Warning 1105 force plan-cache: may use risky cached plan: the plan with partitions pruned in static prune mode is un-cacheable"))
 	tk.MustExec(`execute st using @a`)
 	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
 }
 
+func TestPlanCacheDynamicPartition(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, key(b)) partition by range(a) (partition p0 values less than (10), partition p1 values less than (20), partition p2 values less than (30))")
+	tk.MustExec("insert into t values (1, 1), (11, 1), (21, 1), (2, 2), (12, 2)")
+	tk.MustExec(`analyze table t`)
+	tk.MustExec(`set @@tidb_partition_prune_mode = 'dynamic'`)
+
+	tk.MustExec(`prepare st from 'select a from t where a<? and b=?'`)
+	tk.MustQuery(`show warnings`).Check(testkit.Rows())
+	tk.MustExec(`set @a=10, @b=1`)
+	tk.MustQuery(`execute st using @a, @b`).Sort().Check(testkit.Rows("1"))
+	tk.MustExec(`set @a=30, @b=1`)
+	tk.MustQuery(`execute st using @a, @b`).Sort().Check(testkit.Rows("1", "11", "21"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+	tk.MustExec(`set @a=20, @b=2`)
+	tk.MustQuery(`execute st using @a, @b`).Sort().Check(testkit.Rows("12", "2"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+
+	// plans generated in different prune modes are not shared
+	tk.MustExec(`set @@tidb_partition_prune_mode = 'static'`)
+	tk.MustQuery(`execute st using @a, @b`).Sort().Check(testkit.Rows("12", "2"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("0"))
+}
+
+func TestNonPreparedPlanCacheInListBucket(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, key(a))")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3), (4, 4), (5, 5)")
+	tk.MustExec("set @@tidb_enable_non_prepared_plan_cache=1")
+
+	tk.MustQuery("select b from t where a in (1, 2, 3)").Sort().Check(testkit.Rows("1", "2", "3"))
+	tk.MustQuery("select b from t where a in (2, 3, 4, 5)").Sort().Check(testkit.Rows("2", "3", "4", "5"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustQuery("select b from t where a in (5, 4, 1)").Sort().Check(testkit.Rows("1", "4", "5"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// lists in different buckets use different plans
+	tk.MustQuery("select b from t where a in (1, 2, 3, 4, 5)").Sort().Check(testkit.Rows("1", "2", "3", "4", "5"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+}
+
 func TestIssue40224(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -511,17 +574,17 @@ func TestPlanCacheWithSubquery(t *testing.T) {
 	tk.MustExec("create table t(a int, b int)")
 
 	testCases := []struct {
-		sql            string
-		params         []int
-		cacheAble      string
-		isDecorrelated bool
+		sql       string
+		params    []int
+		cacheAble string
 	}{
-		{"select * from t t1 where exists (select 1 from t t2 where t2.b < t1.b and t2.b < ?)", []int{1}, "1", false},      // exist
-		{"select * from t t1 where t1.a in (select a from t t2 where t2.b < ?)", []int{1}, "1", false},                     // in
-		{"select * from t t1 where t1.a > (select max(a) from t t2 where t2.b < t1.b and t2.b < ?)", []int{1}, "0", false}, // scala
-		{"select * from t t1 where t1.a > (select 1 from t t2 where t2.b<?)", []int{1}, "0", true},                         // uncorrelated
-		{"select * from t t1 where exists (select b from t t2 where t1.a = t2.a and t2.b<? limit 1)", []int{1}, "1", false},
-		{"select * from t t1 where exists (select b from t t2 where t1.a = t2.a and t2.b<? limit ?)", []int{1, 1}, "1", false},
+		{"select * from t t1 where exists (select 1 from t t2 where t2.b < t1.b and t2.b < ?)", []int{1}, "1"},      // exist
+		{"select * from t t1 where t1.a in (select a from t t2 where t2.b < ?)", []int{1}, "1"},                     // in
+		{"select * from t t1 where t1.a > (select max(a) from t t2 where t2.b < t1.b and t2.b < ?)", []int{1}, "0"}, // scala
+		{"select * from t t1 where t1.a > (select 1 from t t2 where t2.b<?)", []int{1}, "1"},                        // uncorrelated
+		{"select * from t t1 where exists (select 1 from t t2 where t2.b<?)", []int{1}, "1"},                        // uncorrelated exist
+		{"select * from t t1 where exists (select b from t t2 where t1.a = t2.a and t2.b<? limit 1)", []int{1}, "1"},
+		{"select * from t t1 where exists (select b from t t2 where t1.a = t2.a and t2.b<? limit ?)", []int{1, 1}, "1"},
 	}
 
 	// switch on
@@ -538,11 +601,7 @@ func TestPlanCacheWithSubquery(t *testing.T) {
 		tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows(testCase.cacheAble))
 		if testCase.cacheAble == "0" {
 			tk.MustExec("execute stmt using " + strings.Join(using, ", "))
-			if testCase.isDecorrelated {
-				tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 skip prepared plan-cache: query has uncorrelated sub-queries is un-cacheable"))
-			} else {
-				tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 skip prepared plan-cache: PhysicalApply plan is un-cacheable"))
-			}
+			tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 skip prepared plan-cache: PhysicalApply plan is un-cacheable"))
 		}
 	}
 	// switch off
@@ -1032,8 +1091,8 @@ func TestNonPreparedPlanExplainWarning(t *testing.T) {
 		"skip non-prepared plan-cache: queries that have hints, having-clause, window-function are not supported",
 		"skip non-prepared plan-cache: queries that have hints, having-clause, window-function are not supported",
 		"skip non-prepared plan-cache: queries that have sub-queries are not supported",
-		"skip non-prepared plan-cache: query accesses partitioned tables is un-cacheable",
-		"skip non-prepared plan-cache: query accesses partitioned tables is un-cacheable",
+		"skip non-prepared plan-cache: query has some unsupported Node",
+		"skip non-prepared plan-cache: query has some unsupported Node",
 		"skip non-prepared plan-cache: query has some filters with JSON, Enum, Set or Bit columns",
 		"skip non-prepared plan-cache: query has some filters with JSON, Enum, Set or Bit columns",
 		"skip non-prepared plan-cache: query has some filters with JSON, Enum, Set or Bit columns",
@@ -1315,3 +1374,92 @@ func BenchmarkNonPreparedPlanCacheDML(b *testing.B) {
 		tk.MustExec("delete from t where a = 2")
 	}
 }
+
+func TestInstancePlanCache(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk1 := testkit.NewTestKit(t, store)
+	tk2 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	tk2.MustExec("use test")
+	tk1.MustExec("create table t1 (a int, b int, c int, key(a), key(b, c))")
+	tk1.MustExec("create table t2 (a int, b int, key(a))")
+	tk1.MustExec("insert into t1 values (1, 1, 1), (2, 2, 2), (3, 3, 3)")
+	tk1.MustExec("insert into t2 values (1, 1), (2, 2), (3, 3)")
+	tk1.MustExec("set global tidb_enable_instance_plan_cache = 1")
+	defer tk1.MustExec("set global tidb_enable_instance_plan_cache = default")
+	plannercore.GetInstancePlanCache().DeleteAll()
+
+	queries := []string{
+		"select * from t1 where a = ?",
+		"select b, c from t1 where b > ? and c < 10",
+		"select * from t1 where c > ?",
+		"select count(*), sum(a) from t1 where a < ? group by b",
+		"select * from t1 where a > ? order by c limit 2",
+		"select t1.a, t2.b from t1 join t2 on t1.a = t2.a where t1.b > ?",
+	}
+	results := [][]string{
+		{"2 2 2"},
+		{"3 3"},
+		{"3 3 3"},
+		{"1 1"},
+		{"3 3 3"},
+		{"3 3"},
+	}
+	for i, q := range queries {
+		tk1.MustExec(fmt.Sprintf("prepare st%d from '%s'", i, q))
+		tk2.MustExec(fmt.Sprintf("prepare st%d from '%s'", i, q))
+		tk1.MustExec("set @p = 1")
+		tk1.MustQuery(fmt.Sprintf("execute st%d using @p", i))
+		tk1.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+		// The plan generated by tk1 is used by tk2 with its own parameters.
+		tk2.MustExec("set @p = 2")
+		tk2.MustQuery(fmt.Sprintf("execute st%d using @p", i)).Sort().Check(testkit.Rows(results[i]...))
+		tk2.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	}
+	require.Equal(t, len(queries), plannercore.GetInstancePlanCache().Size())
+	// The plans are not kept in the session plan cache.
+	require.Equal(t, 0, tk1.Session().GetSessionPlanCache().Size())
+
+	// The plans are invalidated by schema changes.
+	tk1.MustExec("alter table t2 add column c int")
+	tk2.MustExec("set @p = 2")
+	tk2.MustQuery("execute st0 using @p").Check(testkit.Rows("2 2 2"))
+	tk2.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	require.Equal(t, 1, plannercore.GetInstancePlanCache().Size())
+
+	// The plans are evicted in the LRU order when the memory usage exceeds the limit.
+	for i := 1; i < len(queries); i++ {
+		tk1.MustQuery(fmt.Sprintf("execute st%d using @p", i))
+	}
+	require.Equal(t, len(queries), plannercore.GetInstancePlanCache().Size())
+	memLimit := plannercore.GetInstancePlanCache().MemoryUsage() / 2
+	tk1.MustExec(fmt.Sprintf("set global tidb_instance_plan_cache_max_mem_size = %d", memLimit))
+	defer tk1.MustExec("set global tidb_instance_plan_cache_max_mem_size = default")
+	tk1.MustExec("prepare st_new from 'select a from t1 where b = ?'")
+	tk1.MustQuery("execute st_new using @p")
+	tk1.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	size := plannercore.GetInstancePlanCache().Size()
+	require.Greater(t, size, 0)
+	require.Less(t, size, len(queries))
+	require.LessOrEqual(t, plannercore.GetInstancePlanCache().MemoryUsage(), memLimit)
+	// The newest plan is kept while the least recently used one is evicted.
+	tk2.MustExec("prepare st_new from 'select a from t1 where b = ?'")
+	tk2.MustQuery("execute st_new using @p")
+	tk2.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk2.MustQuery("execute st0 using @p")
+	tk2.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	// The plans which exceed the limit by themselves are not cached.
+	tk1.MustExec("set global tidb_instance_plan_cache_max_mem_size = 1")
+	tk1.MustExec("prepare st_big from 'select * from t1 where a < ?'")
+	tk1.MustQuery("execute st_big using @p")
+	tk1.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk2.MustExec("prepare st_big from 'select * from t1 where a < ?'")
+	tk2.MustQuery("execute st_big using @p")
+	tk2.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk1.MustExec("set global tidb_instance_plan_cache_max_mem_size = default")
+
+	tk1.MustExec("admin flush instance plan_cache")
+	require.Equal(t, 0, plannercore.GetInstancePlanCache().Size())
+}
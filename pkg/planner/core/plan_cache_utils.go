@@ -220,6 +220,7 @@ type planCacheKey struct {
 	restrictedReadOnly       bool
 	TiDBSuperReadOnly        bool
 	exprBlacklistTS          int64 // expr-pushdown-blacklist can affect query optimization, so we need to consider it in plan cache.
+	partitionPruneMode       string
 
 	memoryUsage int64 // Do not include in hash
 	hash        []byte
@@ -254,6 +255,7 @@ func (key *planCacheKey) Hash() []byte {
 		key.hash = append(key.hash, hack.Slice(strconv.FormatBool(key.restrictedReadOnly))...)
 		key.hash = append(key.hash, hack.Slice(strconv.FormatBool(key.TiDBSuperReadOnly))...)
 		key.hash = codec.EncodeInt(key.hash, key.exprBlacklistTS)
+		key.hash = append(key.hash, hack.Slice(key.partitionPruneMode)...)
 	}
 	return key.hash
 }
@@ -269,7 +271,7 @@ func (key *planCacheKey) MemoryUsage() (sum int64) {
 	if key.memoryUsage > 0 {
 		return key.memoryUsage
 	}
-	sum = emptyPlanCacheKeySize + int64(len(key.database)+len(key.stmtText)+len(key.bindSQL)+len(key.connCollation)) +
+	sum = emptyPlanCacheKeySize + int64(len(key.database)+len(key.stmtText)+len(key.bindSQL)+len(key.connCollation)+len(key.partitionPruneMode)) +
 		int64(len(key.isolationReadEngines))*size.SizeOfUint8 + int64(cap(key.hash))
 	key.memoryUsage = sum
 	return
@@ -291,6 +293,19 @@ func SetPstmtIDSchemaVersion(key kvcache.Key, stmtText string, schemaVersion int
 	psStmtKey.hash = psStmtKey.hash[:0]
 }
 
+// instancePlanCacheKey returns the hash of the key for the instance plan cache, which ignores the connection,
+// and the schema version of the key.
+func instancePlanCacheKey(key kvcache.Key) (hash string, schemaVersion int64, ok bool) {
+	psStmtKey, isPsStmtKey := key.(*planCacheKey)
+	if !isPsStmtKey {
+		return "", 0, false
+	}
+	instanceKey := *psStmtKey
+	instanceKey.connID = 0
+	instanceKey.hash = nil
+	return string(instanceKey.Hash()), psStmtKey.schemaVersion, true
+}
+
 // NewPlanCacheKey creates a new planCacheKey object.
 // Note: lastUpdatedSchemaVersion will only be set in the case of rc or for update read in order to
 // differentiate the cache key. In other cases, it will be 0.
@@ -327,6 +342,7 @@ func NewPlanCacheKey(sessionVars *variable.SessionVars, stmtText, stmtDB string,
 		restrictedReadOnly:       variable.RestrictedReadOnly.Load(),
 		TiDBSuperReadOnly:        variable.VarTiDBSuperReadOnly.Load(),
 		exprBlacklistTS:          exprBlacklistTS,
+		partitionPruneMode:       sessionVars.PartitionPruneMode.Load(),
 	}
 	for k, v := range sessionVars.IsolationReadEngines {
 		key.isolationReadEngines[k] = v
@@ -573,6 +573,11 @@ func isPhysicalPlanCacheable(sctx sessionctx.Context, p PhysicalPlan, paramNum,
 		if x.StoreType == kv.TiFlash {
 			return false, "TiFlash plan is un-cacheable"
 		}
+		subPlans = append(subPlans, x.tablePlan)
+	case *PhysicalIndexReader:
+		subPlans = append(subPlans, x.indexPlan)
+	case *PhysicalIndexLookUpReader:
+		subPlans = append(subPlans, x.indexPlan, x.tablePlan)
 	case *PhysicalShuffle, *PhysicalShuffleReceiverStub:
 		return false, "get a Shuffle plan"
 	case *PhysicalMemTable:
@@ -587,16 +592,33 @@ func isPhysicalPlanCacheable(sctx sessionctx.Context, p PhysicalPlan, paramNum,
 		if underIndexMerge && x.isFullScan() {
 			return false, "IndexMerge plan with full-scan is un-cacheable"
 		}
+		if x.isPartition {
+			return false, "the plan with partitions pruned in static prune mode is un-cacheable"
+		}
 	case *PhysicalTableScan:
 		if underIndexMerge && x.isFullScan() {
 			return false, "IndexMerge plan with full-scan is un-cacheable"
 		}
+		if x.isPartition {
+			return false, "the plan with partitions pruned in static prune mode is un-cacheable"
+		}
 	case *PhysicalApply:
 		return false, "PhysicalApply plan is un-cacheable"
+	case *PointGetPlan:
+		if x.PartitionDef != nil {
+			return false, "PointGet plan accessing partitioned tables is un-cacheable"
+		}
+	case *BatchPointGetPlan:
+		if x.TblInfo.GetPartitionInfo() != nil {
+			return false, "BatchPointGet plan accessing partitioned tables is un-cacheable"
+		}
 	}
 
 	subPlans = append(subPlans, p.Children()...)
 	for _, c := range subPlans {
+		if c == nil {
+			continue
+		}
 		if cacheable, reason = isPhysicalPlanCacheable(sctx, c, paramNum, limitParamNum, underIndexMerge); !cacheable {
 			return cacheable, reason
 		}
@@ -650,15 +672,10 @@ func checkTableCacheable(ctx context.Context, sctx sessionctx.Context, schema in
 		return false, fmt.Sprintf("find table %s.%s failed: %s", tableSchema, node.Name, err.Error())
 	}
 
-	if tb.Meta().GetPartitionInfo() != nil {
-		// Temporary disable prepared plan cache until https://github.com/pingcap/tidb/issues/33031
-		// is fixed and additional tests with dynamic partition prune mode has been added.
-		/*
-			if checker.sctx != nil && checker.sctx.GetSessionVars().UseDynamicPartitionPrune() {
-				return in, false // dynamic-mode for partition tables can use plan-cache
-			}
-		*/
-		return false, "query accesses partitioned tables is un-cacheable"
+	if tb.Meta().GetPartitionInfo() != nil && (sctx == nil || !sctx.GetSessionVars().IsDynamicPartitionPruneEnabled()) {
+		// In dynamic prune mode, the partitions are pruned again by the executors with the current parameters,
+		// see PhysPlanPartInfo. In static prune mode, the pruned partitions are a part of the plan.
+		return false, "query accesses partitioned tables is un-cacheable in static prune mode"
 	}
 
 	if !enablePlanCacheForGeneratedCols(sctx) {
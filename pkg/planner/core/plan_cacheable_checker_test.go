@@ -396,6 +396,11 @@ func TestNonPreparedPlanCacheable(t *testing.T) {
 		"select mod(a, 10) from test.t where a<13",
 		"select * from test.t limit 1", // limit
 
+		// partitioned tables in dynamic prune mode
+		"select distinct a from test.t1 where a > 1 and b < 2",          // distinct
+		"select count(*) from test.t1 where a > 1 and b < 2 group by a", // group by
+		"select * from test.t1 order by a",                              // order by
+
 		// 2-way joins
 		"select * from test.t inner join test.t3 on test.t.a=test.t3.a",
 		"select * from test.t inner join test.t3 on test.t.a=test.t3.a where test.t.a<10",
@@ -407,10 +412,7 @@ func TestNonPreparedPlanCacheable(t *testing.T) {
 
 	unsupported := []string{
 		"select /*+ use_index(t1, idx_b) */ * from t1 where a > 1 and b < 2",                    // hint
-		"select distinct a from test.t1 where a > 1 and b < 2",                                  // distinct
-		"select count(*) from test.t1 where a > 1 and b < 2 group by a",                         // group by
 		"select a, sum(b) as c from test.t1 where a > 1 and b < 2 group by a having sum(b) > 1", // having
-		"select * from test.t1 order by a",                                                      // order by
 		"select * from (select * from test.t1) t",                                               // sub-query
 		"insert into test.t1 values(1, 1)",                                                      // insert
 		"insert into t1(a, b) select a, b from test.t1",                                         // insert into select
@@ -435,6 +437,13 @@ func TestNonPreparedPlanCacheable(t *testing.T) {
 		ok, _ := core.NonPreparedPlanCacheableWithCtx(sctx, stmt, is)
 		require.True(t, ok)
 	}
+
+	tk.MustExec("set @@tidb_partition_prune_mode = 'static'")
+	stmt, err := p.ParseOneStmt("select * from test.t1 where a < 1", charset, collation)
+	require.NoError(t, err)
+	ok, reason := core.NonPreparedPlanCacheableWithCtx(sctx, stmt, is)
+	require.False(t, ok)
+	require.Equal(t, "query accesses partitioned tables is un-cacheable in static prune mode", reason)
 }
 
 func BenchmarkNonPreparedPlanCacheableChecker(b *testing.B) {
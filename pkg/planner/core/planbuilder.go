@@ -403,6 +403,17 @@ func (b *PlanBuilder) popSelectOffset() {
 	b.qbOffset = b.qbOffset[:len(b.qbOffset)-1]
 }
 
+// skipSubQueryPreprocessing checks whether the uncorrelated sub-queries should be kept in the plan rather than be
+// evaluated in rewriting stage. The plan to be put into plan cache can't depend on the results of the sub-queries,
+// so they are executed as a part of the plan every time.
+func (b *PlanBuilder) skipSubQueryPreprocessing() bool {
+	if b.disableSubQueryPreprocessing {
+		return true
+	}
+	stmtCtx := b.ctx.GetSessionVars().StmtCtx
+	return stmtCtx.InPreparedPlanBuilding && stmtCtx.UseCache && b.ctx.GetSessionVars().EnablePlanCacheForSubquery
+}
+
 // PlanBuilderOpt is used to adjust the plan builder.
 type PlanBuilderOpt interface {
 	Apply(builder *PlanBuilder)
@@ -554,7 +565,8 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.AlterRangeStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
 		*ast.RenameUserStmt, *ast.NonTransactionalDMLStmt, *ast.SetSessionStatesStmt, *ast.SetResourceGroupStmt,
-		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt:
+		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt,
+		*ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt:
 		return b.buildSimple(ctx, node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -1364,7 +1376,7 @@ func (b *PlanBuilder) buildPrepare(x *ast.PrepareStmt) Plan {
 
 func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan, error) {
 	var ret Plan
-	var err error
+	var err, superErr error
 	switch as.Tp {
 	case ast.AdminCheckTable, ast.AdminCheckIndex:
 		ret, err = b.buildAdminCheckTable(ctx, as)
@@ -1450,12 +1462,7 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan,
 	case ast.AdminCaptureBindings:
 		return &SQLBindPlan{SQLBindOp: OpCaptureBindings}, nil
 	case ast.AdminEvolveBindings:
-		var err error
-		// The 'baseline evolution' only work in the test environment before the feature is GA.
-		if !config.CheckTableBeforeDrop {
-			err = errors.Errorf("Cannot enable baseline evolution feature, it is not generally available now")
-		}
-		return &SQLBindPlan{SQLBindOp: OpEvolveBindings}, err
+		return &SQLBindPlan{SQLBindOp: OpEvolveBindings}, nil
 	case ast.AdminReloadBindings:
 		return &SQLBindPlan{SQLBindOp: OpReloadBindings}, nil
 	case ast.AdminShowTelemetry:
@@ -1474,12 +1481,20 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan,
 		p := &AdminShowBDRRole{}
 		p.setSchemaAndNames(buildAdminShowBDRRoleFields())
 		ret = p
+	case ast.AdminShowDistTasks:
+		p := &AdminShowDistTasks{}
+		p.setSchemaAndNames(buildAdminShowDistTasksFields())
+		ret = p
+		superErr = ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+	case ast.AdminPauseDistTask, ast.AdminResumeDistTask, ast.AdminCancelDistTask, ast.AdminAlterDistTask:
+		ret = &Simple{Statement: as}
+		superErr = ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
 	default:
 		return nil, ErrUnsupportedType.GenWithStack("Unsupported ast.AdminStmt(%T) for buildAdmin", as)
 	}
 
 	// Admin command can only be executed by administrator.
-	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", nil)
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", superErr)
 	return ret, nil
 }
 
@@ -2998,6 +3013,27 @@ func buildAdminShowBDRRoleFields() (*expression.Schema, types.NameSlice) {
 	return schema.col2Schema(), schema.names
 }
 
+func buildAdminShowDistTasksFields() (*expression.Schema, types.NameSlice) {
+	datetimeSize, _ := mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeDatetime)
+	schema := newColumnsWithNames(12)
+	schema.Append(buildColumnWithName("", "TASK_ID", mysql.TypeLonglong, 4))
+	schema.Append(buildColumnWithName("", "TASK_KEY", mysql.TypeVarchar, 256))
+	schema.Append(buildColumnWithName("", "TYPE", mysql.TypeVarchar, 64))
+	schema.Append(buildColumnWithName("", "STATE", mysql.TypeVarchar, 64))
+	// step might be negative, such as StepInit and StepDone.
+	stepCol, stepName := buildColumnWithName("", "STEP", mysql.TypeLonglong, 4)
+	stepCol.RetType.DelFlag(mysql.UnsignedFlag)
+	schema.Append(stepCol, stepName)
+	schema.Append(buildColumnWithName("", "PRIORITY", mysql.TypeLonglong, 4))
+	schema.Append(buildColumnWithName("", "CONCURRENCY", mysql.TypeLonglong, 4))
+	schema.Append(buildColumnWithName("", "SUBTASKS", mysql.TypeVarchar, 256))
+	schema.Append(buildColumnWithName("", "CREATE_TIME", mysql.TypeDatetime, datetimeSize))
+	schema.Append(buildColumnWithName("", "START_TIME", mysql.TypeDatetime, datetimeSize))
+	schema.Append(buildColumnWithName("", "STATE_UPDATE_TIME", mysql.TypeDatetime, datetimeSize))
+	schema.Append(buildColumnWithName("", "ERROR", mysql.TypeVarchar, 1024))
+	return schema.col2Schema(), schema.names
+}
+
 func buildShowBackupMetaSchema() (*expression.Schema, types.NameSlice) {
 	names := []string{"Database", "Table", "Total_kvs", "Total_bytes", "Time_range_start", "Time_range_end"}
 	ftypes := []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong, mysql.TypeLonglong, mysql.TypeDatetime, mysql.TypeDatetime}
@@ -3241,6 +3277,15 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 		}
 	case ast.ShowReplicaStatus:
 		return nil, dbterror.ErrNotSupportedYet.GenWithStackByArgs("SHOW {REPLICA | SLAVE} STATUS")
+	case ast.ShowEvents:
+		if p.DBName == "" {
+			return nil, ErrNoDB
+		}
+		var err error
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			err = ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, p.DBName)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.EventPriv, strings.ToLower(p.DBName), "", "", err)
 	}
 
 	schema, names := buildShowSchema(show, isView, isSequence)
@@ -3254,8 +3299,13 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 	np = p
 	// If we have ShowPredicateExtractor, we do not buildSelection with Pattern
 	if show.Pattern != nil && buildPattern {
+		patternCol := p.OutputNames()[0]
+		if show.Tp == ast.ShowEvents {
+			// The pattern of `SHOW EVENTS` matches the name of events rather than the schema.
+			patternCol = p.OutputNames()[1]
+		}
 		show.Pattern.Expr = &ast.ColumnNameExpr{
-			Name: &ast.ColumnName{Name: p.OutputNames()[0].ColName},
+			Name: &ast.ColumnName{Name: patternCol.ColName},
 		}
 		np, err = b.buildSelection(ctx, np, show.Pattern, nil)
 		if err != nil {
@@ -3376,6 +3426,16 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 				b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "CONNECTION_ADMIN", false, err)
 			}
 		}
+	case *ast.CreateEventStmt:
+		raw.Definer = b.resolveEventDefiner(raw.Definer)
+		b.appendEventVisitInfo(raw.EventName)
+	case *ast.AlterEventStmt:
+		if raw.Definer != nil {
+			raw.Definer = b.resolveEventDefiner(raw.Definer)
+		}
+		b.appendEventVisitInfo(raw.EventName, raw.NewName)
+	case *ast.DropEventStmt:
+		b.appendEventVisitInfo(raw.EventName)
 	case *ast.UseStmt:
 		if raw.DBName == "" {
 			return nil, ErrNoDB
@@ -3472,6 +3532,37 @@ func collectVisitInfoFromRevokeStmt(sctx sessionctx.Context, vi []visitInfo, stm
 	return vi, nil
 }
 
+// resolveEventDefiner replaces `CURRENT_USER` with the current user, and requires the SUPER privilege
+// if the definer is not the current user, which is the same as `CREATE VIEW`.
+func (b *PlanBuilder) resolveEventDefiner(definer *auth.UserIdentity) *auth.UserIdentity {
+	user := b.ctx.GetSessionVars().User
+	if user == nil {
+		return definer
+	}
+	if definer == nil || definer.CurrentUser {
+		return user
+	}
+	if definer.String() != user.String() {
+		err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+	}
+	return definer
+}
+
+// appendEventVisitInfo requires the EVENT privilege on the schemas of the events.
+func (b *PlanBuilder) appendEventVisitInfo(names ...*ast.TableName) {
+	for _, name := range names {
+		if name == nil {
+			continue
+		}
+		var err error
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			err = ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, name.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.EventPriv, name.Schema.L, "", "", err)
+	}
+}
+
 // appendVisitInfoIsRestrictedUser appends additional visitInfo if the user has a
 // special privilege called "RESTRICTED_USER_ADMIN". It only applies when SEM is enabled.
 func appendVisitInfoIsRestrictedUser(visitInfo []visitInfo, sctx sessionctx.Context, user *auth.UserIdentity, priv string) []visitInfo {
@@ -4544,6 +4635,17 @@ func convertValueListToData(valueList []ast.ExprNode, handleColInfos []*model.Co
 	return data, nil
 }
 
+// hasTTLArchiveOption returns whether the options set TTL_ARCHIVE to an external storage. Writing the expired rows to
+// the storage requires the FILE privilege, like IMPORT INTO.
+func hasTTLArchiveOption(options []*ast.TableOption) bool {
+	for _, op := range options {
+		if op.Tp == ast.TableOptionTTLArchive && op.StrValue != "" {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, error) {
 	var authErr error
 	switch v := node.(type) {
@@ -4622,6 +4724,8 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 				}
 				b.visitInfo = appendVisitInfo(b.visitInfo, mysql.UpdatePriv, mysql.SystemDB,
 					"stats_extended", "", authErr)
+			} else if spec.Tp == ast.AlterTableOption && hasTTLArchiveOption(spec.Options) {
+				b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
 			} else if spec.Tp == ast.AlterTableAddConstraint {
 				if b.ctx.GetSessionVars().User != nil && spec.Constraint != nil &&
 					spec.Constraint.Tp == ast.ConstraintForeignKey && spec.Constraint.Refer != nil {
@@ -4682,6 +4786,9 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 		}
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.Table.Schema.L,
 			v.Table.Name.L, "", authErr)
+		if hasTTLArchiveOption(v.Options) {
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
+		}
 		if v.ReferTable != nil {
 			if b.ctx.GetSessionVars().User != nil {
 				authErr = ErrTableaccessDenied.GenWithStackByArgs("CREATE", b.ctx.GetSessionVars().User.AuthUsername,
@@ -351,6 +351,19 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.stmtTp = TypeDrop
 		p.flag |= inCreateOrDropTable
 		p.checkDropSequenceGrammar(node)
+	case *ast.CreateEventStmt:
+		p.stmtTp = TypeCreate
+		// The body of an event is executed by the event scheduler later, so skip resolving it here.
+		p.resolveEventNames(node.EventName)
+		return in, true
+	case *ast.AlterEventStmt:
+		p.stmtTp = TypeAlter
+		p.resolveEventNames(node.EventName, node.NewName)
+		return in, true
+	case *ast.DropEventStmt:
+		p.stmtTp = TypeDrop
+		p.resolveEventNames(node.EventName)
+		return in, true
 	case *ast.FuncCastExpr:
 		p.checkFuncCastExpr(node)
 	case *ast.FuncCallExpr:
@@ -1687,6 +1700,21 @@ func (p *preprocessor) resolveCreateSequenceStmt(stmt *ast.CreateSequenceStmt) {
 	}
 }
 
+// resolveEventNames fills the schema of the event names with the current database if it is not specified.
+func (p *preprocessor) resolveEventNames(names ...*ast.TableName) {
+	for _, name := range names {
+		if name == nil || name.Schema.L != "" {
+			continue
+		}
+		currentDB := p.sctx.GetSessionVars().CurrentDB
+		if currentDB == "" {
+			p.err = errors.Trace(ErrNoDB)
+			return
+		}
+		name.Schema = model.NewCIStr(currentDB)
+	}
+}
+
 func (p *preprocessor) checkFuncCastExpr(node *ast.FuncCastExpr) {
 	if node.Tp.EvalType() == types.ETDecimal {
 		if node.Tp.GetFlen() >= node.Tp.GetDecimal() && node.Tp.GetFlen() <= mysql.MaxDecimalWidth && node.Tp.GetDecimal() <= mysql.MaxDecimalScale {
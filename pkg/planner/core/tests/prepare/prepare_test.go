@@ -1098,11 +1098,8 @@ func TestPartitionWithVariedDataSources(t *testing.T) {
 		var expectedFromPlanCache string
 		for id, tbl := range []string{"trangeIdx", "thashIdx", "tnormalIdx"} {
 			scan := tk.MustQuery(fmt.Sprintf(`execute stmt%v_indexscan using @mina, @maxa`, tbl)).Sort()
-			if id == 2 {
-				expectedFromPlanCache = "1"
-			} else {
-				expectedFromPlanCache = "0"
-			}
+			// The partitions are pruned again by the executors in dynamic prune mode.
+			expectedFromPlanCache = "1"
 			tblStr := ` table: ` + tbl + " i :" + strconv.FormatInt(int64(i), 10) + " */"
 			if i > 0 {
 				missedPlanCache = helperCheckPlanCache(t, tk, `select @@last_plan_from_cache /* indexscan table: `+tblStr, expectedFromPlanCache, missedPlanCache)
@@ -1136,6 +1133,10 @@ func TestPartitionWithVariedDataSources(t *testing.T) {
 			}
 
 			batch := tk.MustQuery(fmt.Sprintf(`execute stmt%v_batchget_idx using @a0, @a1, @a2`, tbl)).Sort()
+			if id != 2 {
+				// BatchPointGet locates the partitions when building the plan.
+				expectedFromPlanCache = "0"
+			}
 			if i > 0 {
 				missedPlanCache = helperCheckPlanCache(t, tk, `select @@last_plan_from_cache /* batchget table: `+tblStr, expectedFromPlanCache, missedPlanCache)
 			}
@@ -76,6 +76,10 @@ func getPlanFromNonPreparedPlanCache(ctx context.Context, sctx sessionctx.Contex
 	}
 	ok, reason := core.NonPreparedPlanCacheableWithCtx(sctx, stmt, is)
 	if !ok {
+		switch stmt.(type) {
+		case *ast.SelectStmt, *ast.InsertStmt, *ast.UpdateStmt, *ast.DeleteStmt:
+			stmtCtx.PlanCacheUnqualified = reason // recorded in statement summary
+		}
 		if !isExplain && stmtCtx.InExplainStmt && stmtCtx.ExplainFormat == types.ExplainFormatPlanCache {
 			stmtCtx.AppendWarning(errors.NewNoStackErrorf("skip non-prepared plan-cache: %s", reason))
 		}
@@ -118,6 +122,8 @@ func getPlanFromNonPreparedPlanCache(ctx context.Context, sctx sessionctx.Contex
 	if err != nil {
 		return nil, nil, false, err
 	}
+	// the tables are used by the runaway watches, set them like buildLogicalPlan does.
+	stmtCtx.Tables = core.GetDBTableInfo(cachedStmt.VisitInfos)
 
 	if intest.InTest && ctx.Value(core.PlanCacheKeyTestIssue47133{}) != nil {
 		ctx.Value(core.PlanCacheKeyTestIssue47133{}).(func(names []*types.FieldName))(names)
@@ -0,0 +1,465 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package optimizor
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	servererr "github.com/pingcap/tidb/pkg/server/err"
+	"github.com/pingcap/tidb/pkg/server/handler"
+	"github.com/pingcap/tidb/pkg/session"
+	sessiontypes "github.com/pingcap/tidb/pkg/session/types"
+	"github.com/pingcap/tidb/pkg/statistics/handle/storage"
+	"github.com/pingcap/tidb/pkg/statistics/handle/util"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/fastrand"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// The kinds of changes of an operator in PlanDiff.
+const (
+	OperatorUnchanged = "unchanged"
+	OperatorModified  = "modified"
+	OperatorAdded     = "added"
+	OperatorRemoved   = "removed"
+)
+
+// PlanDiffHandler is the handler for comparing the plans of a statement optimized with the statistics of two snapshots.
+type PlanDiffHandler struct {
+	do *domain.Domain
+}
+
+// NewPlanDiffHandler creates a new PlanDiffHandler.
+func NewPlanDiffHandler(do *domain.Domain) *PlanDiffHandler {
+	return &PlanDiffHandler{do: do}
+}
+
+// PlanOperator is an operator of the plan, which is a row of `EXPLAIN FORMAT='verbose'`.
+type PlanOperator struct {
+	ID           string `json:"id"`
+	Depth        int    `json:"depth"`
+	EstRows      string `json:"est_rows"`
+	EstCost      string `json:"est_cost"`
+	Task         string `json:"task"`
+	AccessObject string `json:"access_object"`
+	OperatorInfo string `json:"operator_info"`
+}
+
+// PlanSnapshot is the plan optimized with the statistics of a snapshot.
+type PlanSnapshot struct {
+	Snapshot uint64 `json:"snapshot"`
+	// FallbackTables are the tables without historical statistics at the snapshot, the latest statistics are used.
+	FallbackTables []string        `json:"fallback_tables,omitempty"`
+	Plan           []*PlanOperator `json:"plan"`
+}
+
+// OperatorDiff is the difference of an operator between two plans.
+type OperatorDiff struct {
+	Change string        `json:"change"`
+	From   *PlanOperator `json:"from,omitempty"`
+	To     *PlanOperator `json:"to,omitempty"`
+}
+
+// PlanDiff is the difference between the plans of a statement optimized with the statistics of two snapshots.
+type PlanDiff struct {
+	SQL         string          `json:"sql"`
+	From        *PlanSnapshot   `json:"from"`
+	To          *PlanSnapshot   `json:"to"`
+	PlanChanged bool            `json:"plan_changed"`
+	Operators   []*OperatorDiff `json:"operators"`
+}
+
+// ServeHTTP replays the optimization of the statement with the historical statistics of the two snapshots, and
+// writes the difference of the chosen operators, costs and estimates. The caller is authenticated as a TiDB user by
+// the HTTP basic authentication, and the statement is explained with the privileges of the user.
+func (h PlanDiffHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	query := req.URL.Query()
+	sql := query.Get(handler.SQLQuery)
+	if len(sql) == 0 {
+		handler.WriteError(w, errors.New("sql is required"))
+		return
+	}
+	se, err := session.CreateSession(h.do.Store())
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	defer se.Close()
+	if err := authenticate(se, req); err != nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="tidb"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, err = w.Write([]byte(err.Error()))
+		terror.Log(errors.Trace(err))
+		return
+	}
+	se.GetSessionVars().CurrentDB = query.Get(handler.DBName)
+
+	from, err := parseSnapshot(se, query.Get(handler.FromSnapshot))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	to, err := parseSnapshot(se, query.Get(handler.ToSnapshot))
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	diff, err := h.diffPlan(se, sql, from, to)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	handler.WriteData(w, diff)
+}
+
+// authenticate logs in the session as the TiDB user of the HTTP basic authentication.
+func authenticate(se sessiontypes.Session, req *http.Request) error {
+	user, password, ok := req.BasicAuth()
+	if !ok {
+		return errors.New("the user and password of the HTTP basic authentication are required")
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return err
+	}
+	hasPassword := "YES"
+	if len(password) == 0 {
+		hasPassword = "NO"
+	}
+	identity, err := se.MatchIdentity(user, host)
+	if err != nil {
+		return servererr.ErrAccessDenied.FastGenByArgs(user, host, hasPassword)
+	}
+	plugin, err := se.AuthPluginForUser(identity)
+	if err != nil {
+		return err
+	}
+	var authentication, salt []byte
+	if len(password) > 0 {
+		switch plugin {
+		case mysql.AuthNativePassword:
+			salt = fastrand.Buf(20)
+			authentication = scramblePassword(salt, password)
+		default:
+			// the other password plugins check the clear-text password.
+			authentication = []byte(password)
+		}
+	}
+	return se.Auth(&auth.UserIdentity{Username: user, Hostname: host}, authentication, salt, nil)
+}
+
+// scramblePassword scrambles the password with the salt as a MySQL client does for mysql_native_password.
+func scramblePassword(salt []byte, password string) []byte {
+	stage1 := auth.Sha1Hash([]byte(password))
+	stage2 := auth.Sha1Hash(stage1)
+	scramble := auth.Sha1Hash(append(slices.Clone(salt), stage2...))
+	for i := range scramble {
+		scramble[i] ^= stage1[i]
+	}
+	return scramble
+}
+
+func (h PlanDiffHandler) diffPlan(se sessiontypes.Session, sql string, from, to uint64) (*PlanDiff, error) {
+	stmt, err := parser.New().ParseOneStmt(sql, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPlanDiffStmt(stmt); err != nil {
+		return nil, err
+	}
+	tables := collectTables(h.do.InfoSchema(), stmt, se.GetSessionVars().CurrentDB)
+	diff := &PlanDiff{SQL: sql}
+	if diff.From, err = h.explainWithSnapshotStats(se, sql, tables, from); err != nil {
+		return nil, err
+	}
+	if diff.To, err = h.explainWithSnapshotStats(se, sql, tables, to); err != nil {
+		return nil, err
+	}
+	diff.Operators = DiffPlanOperators(diff.From.Plan, diff.To.Plan)
+	for _, op := range diff.Operators {
+		if op.Change == OperatorAdded || op.Change == OperatorRemoved {
+			diff.PlanChanged = true
+			break
+		}
+	}
+	return diff, nil
+}
+
+// explainWithSnapshotStats explains the statement with the historical statistics of the snapshot.
+func (h PlanDiffHandler) explainWithSnapshotStats(se sessiontypes.Session, sql string, tables []accessedTable, snapshot uint64) (*PlanSnapshot, error) {
+	result := &PlanSnapshot{Snapshot: snapshot}
+	override := make(map[int64]interface{}, len(tables))
+	for _, tbl := range tables {
+		jsonTbl, fallbackTbls, err := h.do.StatsHandle().DumpHistoricalStatsBySnapshot(tbl.dbName, tbl.tblInfo, snapshot)
+		if err != nil {
+			return nil, err
+		}
+		result.FallbackTables = append(result.FallbackTables, fallbackTbls...)
+		if err := addStatsOverride(override, tbl.tblInfo, jsonTbl); err != nil {
+			return nil, err
+		}
+	}
+
+	vars := se.GetSessionVars()
+	vars.OptimizerStatsOverride = override
+	vars.EnableNonPreparedPlanCache = false
+	defer func() {
+		vars.OptimizerStatsOverride = nil
+	}()
+	stmt, err := parser.New().ParseOneStmt(sql, "", "")
+	if err != nil {
+		return nil, err
+	}
+	explain := &ast.ExplainStmt{Stmt: stmt, Format: types.ExplainFormatVerbose}
+	explain.SetText(nil, "explain format = 'verbose' "+sql)
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+	rs, err := se.ExecuteStmt(ctx, explain)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := sqlexec.DrainRecordSet(ctx, rs, 64)
+	if closeErr := rs.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Plan = make([]*PlanOperator, 0, len(rows))
+	for _, row := range rows {
+		id := row.GetString(0)
+		name := strings.TrimLeft(id, "└├│─ ")
+		result.Plan = append(result.Plan, &PlanOperator{
+			ID:           name,
+			Depth:        len([]rune(id[:len(id)-len(name)])) / 2,
+			EstRows:      row.GetString(1),
+			EstCost:      row.GetString(2),
+			Task:         row.GetString(3),
+			AccessObject: row.GetString(4),
+			OperatorInfo: row.GetString(5),
+		})
+	}
+	return result, nil
+}
+
+// addStatsOverride converts the statistics of the table and its partitions and puts them into the override map.
+func addStatsOverride(override map[int64]interface{}, tblInfo *model.TableInfo, jsonTbl *util.JSONTable) error {
+	if jsonTbl == nil {
+		return nil
+	}
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		tbl, err := storage.TableStatsFromJSON(tblInfo, tblInfo.ID, jsonTbl)
+		if err != nil {
+			return err
+		}
+		tbl.Version = jsonTbl.Version
+		override[tblInfo.ID] = tbl
+		return nil
+	}
+	for name, partJSONTbl := range jsonTbl.Partitions {
+		if partJSONTbl == nil {
+			continue
+		}
+		physicalID := tblInfo.ID
+		if name != util.TiDBGlobalStats {
+			physicalID = pi.GetPartitionIDByName(name)
+			if physicalID == -1 {
+				continue
+			}
+		}
+		tbl, err := storage.TableStatsFromJSON(tblInfo, physicalID, partJSONTbl)
+		if err != nil {
+			return err
+		}
+		tbl.Version = partJSONTbl.Version
+		override[physicalID] = tbl
+	}
+	return nil
+}
+
+// checkPlanDiffStmt checks whether the statement can be explained by the API. The statement must not read any data
+// when it's explained. Only the queries and DML statements are allowed, and the subqueries, derived tables and CTEs
+// are rejected because they may be evaluated during the optimization.
+func checkPlanDiffStmt(stmt ast.StmtNode) error {
+	switch stmt.(type) {
+	case *ast.SelectStmt, *ast.SetOprStmt, *ast.InsertStmt, *ast.UpdateStmt, *ast.DeleteStmt:
+	default:
+		return errors.New("only the queries and DML statements are supported")
+	}
+	checker := &subqueryChecker{}
+	stmt.Accept(checker)
+	if checker.found {
+		return errors.New("the statements with subqueries, derived tables or CTEs are not supported")
+	}
+	return nil
+}
+
+type subqueryChecker struct {
+	found bool
+}
+
+func (c *subqueryChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr, *ast.CompareSubqueryExpr, *ast.WithClause:
+		c.found = true
+		return in, true
+	case *ast.TableSource:
+		if _, ok := x.Source.(*ast.TableName); !ok {
+			c.found = true
+			return in, true
+		}
+	}
+	return in, false
+}
+
+func (*subqueryChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+type accessedTable struct {
+	dbName  string
+	tblInfo *model.TableInfo
+}
+
+// collectTables returns the tables accessed by the statement.
+func collectTables(is infoschema.InfoSchema, stmt ast.StmtNode, currentDB string) []accessedTable {
+	collector := &tableNameCollector{}
+	stmt.Accept(collector)
+	tables := make([]accessedTable, 0, len(collector.names))
+	visited := make(map[int64]struct{}, len(collector.names))
+	for _, tn := range collector.names {
+		schema := tn.Schema
+		if schema.L == "" {
+			schema = model.NewCIStr(currentDB)
+		}
+		// CTEs and the tables which don't exist are skipped, they are reported when explaining the statement.
+		tbl, err := is.TableByName(schema, tn.Name)
+		if err != nil {
+			continue
+		}
+		if _, ok := visited[tbl.Meta().ID]; ok {
+			continue
+		}
+		visited[tbl.Meta().ID] = struct{}{}
+		tables = append(tables, accessedTable{dbName: schema.O, tblInfo: tbl.Meta()})
+	}
+	return tables
+}
+
+type tableNameCollector struct {
+	names []*ast.TableName
+}
+
+func (c *tableNameCollector) Enter(in ast.Node) (ast.Node, bool) {
+	if tn, ok := in.(*ast.TableName); ok {
+		c.names = append(c.names, tn)
+	}
+	return in, false
+}
+
+func (*tableNameCollector) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// DiffPlanOperators aligns the operators of the two plans by their types, depths and access objects, and reports
+// the added, removed and modified operators.
+func DiffPlanOperators(from, to []*PlanOperator) []*OperatorDiff {
+	// lcs[i][j] is the length of the longest common subsequence of from[i:] and to[j:].
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if sameOperator(from[i], to[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	diffs := make([]*OperatorDiff, 0, max(len(from), len(to)))
+	i, j := 0, 0
+	for i < len(from) || j < len(to) {
+		switch {
+		case i < len(from) && j < len(to) && sameOperator(from[i], to[j]):
+			change := OperatorUnchanged
+			if from[i].EstRows != to[j].EstRows || from[i].EstCost != to[j].EstCost || from[i].OperatorInfo != to[j].OperatorInfo {
+				change = OperatorModified
+			}
+			diffs = append(diffs, &OperatorDiff{Change: change, From: from[i], To: to[j]})
+			i++
+			j++
+		case j == len(to) || (i < len(from) && lcs[i+1][j] >= lcs[i][j+1]):
+			diffs = append(diffs, &OperatorDiff{Change: OperatorRemoved, From: from[i]})
+			i++
+		default:
+			diffs = append(diffs, &OperatorDiff{Change: OperatorAdded, To: to[j]})
+			j++
+		}
+	}
+	return diffs
+}
+
+func sameOperator(a, b *PlanOperator) bool {
+	return a.Depth == b.Depth && a.Task == b.Task && a.AccessObject == b.AccessObject &&
+		operatorType(a.ID) == operatorType(b.ID)
+}
+
+// operatorType removes the plan ID from the operator ID, e.g. "IndexRangeScan_8(Build)" -> "IndexRangeScan(Build)".
+func operatorType(id string) string {
+	pos := strings.IndexByte(id, '_')
+	if pos < 0 {
+		return id
+	}
+	end := pos + 1
+	for end < len(id) && id[end] >= '0' && id[end] <= '9' {
+		end++
+	}
+	return id[:pos] + id[end:]
+}
+
+// parseSnapshot parses a time like "2006-01-02 15:04:05" or "20060102150405" to a TSO.
+func parseSnapshot(se sessiontypes.Session, snapshot string) (uint64, error) {
+	se.GetSessionVars().StmtCtx.SetTimeZone(time.Local)
+	t, err := types.ParseTime(se.GetSessionVars().StmtCtx.TypeCtx(), snapshot, mysql.TypeTimestamp, 6)
+	if err != nil {
+		return 0, err
+	}
+	t1, err := t.GoTime(time.Local)
+	if err != nil {
+		return 0, err
+	}
+	return oracle.GoTimeToTS(t1), nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package optimizor_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/model"
+	server2 "github.com/pingcap/tidb/pkg/server"
+	"github.com/pingcap/tidb/pkg/server/handler/optimizor"
+	"github.com/pingcap/tidb/pkg/server/internal/testserverclient"
+	"github.com/pingcap/tidb/pkg/server/internal/testutil"
+	"github.com/pingcap/tidb/pkg/server/internal/util"
+	"github.com/pingcap/tidb/pkg/session"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffPlanOperators(t *testing.T) {
+	op := func(id string, depth int, estRows, accessObject string) *optimizor.PlanOperator {
+		return &optimizor.PlanOperator{ID: id, Depth: depth, EstRows: estRows, Task: "root", AccessObject: accessObject}
+	}
+	from := []*optimizor.PlanOperator{
+		op("IndexLookUp_7", 0, "10.00", ""),
+		op("IndexRangeScan_5(Build)", 1, "10.00", "table:t, index:ia(a)"),
+		op("TableRowIDScan_6(Probe)", 1, "10.00", "table:t"),
+	}
+	to := []*optimizor.PlanOperator{
+		op("TableReader_7", 0, "900.00", ""),
+		op("Selection_6", 1, "900.00", ""),
+		op("TableFullScan_5", 2, "1000.00", "table:t"),
+	}
+	diffs := optimizor.DiffPlanOperators(from, to)
+	changes := make([]string, 0, len(diffs))
+	for _, d := range diffs {
+		changes = append(changes, d.Change)
+	}
+	require.Equal(t, []string{"removed", "removed", "removed", "added", "added", "added"}, changes)
+
+	// the plan IDs are ignored when matching operators
+	to = []*optimizor.PlanOperator{
+		op("IndexLookUp_9", 0, "20.00", ""),
+		op("IndexRangeScan_7(Build)", 1, "20.00", "table:t, index:ia(a)"),
+		op("TableRowIDScan_8(Probe)", 1, "10.00", "table:t"),
+	}
+	diffs = optimizor.DiffPlanOperators(from, to)
+	require.Len(t, diffs, 3)
+	require.Equal(t, optimizor.OperatorModified, diffs[0].Change)
+	require.Equal(t, optimizor.OperatorModified, diffs[1].Change)
+	require.Equal(t, optimizor.OperatorUnchanged, diffs[2].Change)
+	require.Equal(t, "10.00", diffs[1].From.EstRows)
+	require.Equal(t, "20.00", diffs[1].To.EstRows)
+}
+
+func fetchPlanDiff(t *testing.T, client *testserverclient.TestServerClient, query url.Values, user, password string) int {
+	req, err := http.NewRequest(http.MethodGet, client.StatusURL("/optimize_trace/diff?"+query.Encode()), nil)
+	require.NoError(t, err)
+	if user != "" {
+		req.SetBasicAuth(user, password)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp.StatusCode
+}
+
+func TestPlanDiffAPI(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+
+	driver := server2.NewTiDBDriver(store)
+	client := testserverclient.NewTestServerClient()
+	cfg := util.NewTestConfig()
+	cfg.Port = client.Port
+	cfg.Status.StatusPort = client.StatusPort
+	cfg.Status.ReportStatus = true
+	cfg.Socket = fmt.Sprintf("/tmp/tidb-mock-%d.sock", time.Now().UnixNano())
+
+	server, err := server2.NewServer(cfg, driver)
+	require.NoError(t, err)
+	defer server.Close()
+
+	dom, err := session.GetDomain(store)
+	require.NoError(t, err)
+	server.SetDomain(dom)
+
+	client.Port = testutil.GetPortFromTCPAddr(server.ListenAddr())
+	client.StatusPort = testutil.GetPortFromTCPAddr(server.StatusListenerAddr())
+	go func() {
+		err := server.Run()
+		require.NoError(t, err)
+	}()
+	client.WaitUntilServerOnline()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set global tidb_enable_historical_stats = 1")
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, index ia(a))")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3), (4, 4), (5, 5), (6, 6), (7, 7), (8, 8), (9, 9), (10, 10)")
+	tk.MustExec("analyze table t")
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	require.NoError(t, dom.GetHistoricalStatsWorker().DumpHistoricalStats(tbl.Meta().ID, dom.StatsHandle()))
+	time.Sleep(10 * time.Millisecond)
+	from := time.Now().Format("2006-01-02 15:04:05.000000")
+	time.Sleep(10 * time.Millisecond)
+
+	for i := 0; i < 6; i++ {
+		tk.MustExec("insert into t select 1, b from t")
+	}
+	tk.MustExec("analyze table t")
+	require.NoError(t, dom.GetHistoricalStatsWorker().DumpHistoricalStats(tbl.Meta().ID, dom.StatsHandle()))
+	time.Sleep(10 * time.Millisecond)
+	to := time.Now().Format("2006-01-02 15:04:05.000000")
+
+	query := url.Values{}
+	query.Set("db", "test")
+	query.Set("sql", "select * from t where a = 1")
+	query.Set("from", from)
+	query.Set("to", to)
+	req, err := http.NewRequest(http.MethodGet, client.StatusURL("/optimize_trace/diff?"+query.Encode()), nil)
+	require.NoError(t, err)
+	req.SetBasicAuth("root", "")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var diff optimizor.PlanDiff
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&diff))
+	require.Equal(t, "select * from t where a = 1", diff.SQL)
+	require.NotEmpty(t, diff.From.Plan)
+	require.NotEmpty(t, diff.To.Plan)
+	require.Empty(t, diff.From.FallbackTables)
+	require.Empty(t, diff.To.FallbackTables)
+	// a=1 matches 1 row in the old stats and most rows in the new stats
+	require.NotEqual(t, diff.From.Plan[0].EstRows, diff.To.Plan[0].EstRows)
+	require.NotEmpty(t, diff.Operators)
+	changed := diff.PlanChanged
+	for _, op := range diff.Operators {
+		changed = changed || op.Change == optimizor.OperatorModified
+	}
+	require.True(t, changed)
+
+	// the caller must be authenticated as a user who has the privileges to explain the statement
+	require.Equal(t, http.StatusUnauthorized, fetchPlanDiff(t, client, query, "", ""))
+	require.Equal(t, http.StatusUnauthorized, fetchPlanDiff(t, client, query, "root", "wrong"))
+	tk.MustExec("create user 'u1'@'%' identified by 'pwd'")
+	require.Equal(t, http.StatusUnauthorized, fetchPlanDiff(t, client, query, "u1", "wrong"))
+	require.Equal(t, http.StatusBadRequest, fetchPlanDiff(t, client, query, "u1", "pwd"))
+	tk.MustExec("grant select on test.t to 'u1'@'%'")
+	require.Equal(t, http.StatusOK, fetchPlanDiff(t, client, query, "u1", "pwd"))
+
+	// the statement is required
+	query.Del("sql")
+	require.Equal(t, http.StatusBadRequest, fetchPlanDiff(t, client, query, "root", ""))
+
+	// the statements which may read data when they are explained are rejected
+	for _, sql := range []string{
+		"select * from t where a = (select max(b) from t)",
+		"select * from t where exists (select 1 from t where b = 1)",
+		"select * from (select * from t) t1",
+		"with cte as (select * from t) select * from cte",
+		"insert into t select * from t where a in (select b from t)",
+		"set @a = 1",
+		"drop table t",
+	} {
+		query.Set("sql", sql)
+		require.Equal(t, http.StatusBadRequest, fetchPlanDiff(t, client, query, "root", ""), sql)
+	}
+
+	// the stats cache is not changed by the API
+	rows := tk.MustQuery("explain format = 'brief' select * from t where a = 1").Rows()
+	require.True(t, strings.Contains(fmt.Sprint(rows), "TableFullScan"), fmt.Sprint(rows))
+}
@@ -62,6 +62,9 @@ const (
 	JobID        = "start_job_id"
 	Operation    = "op"
 	Seconds      = "seconds"
+	SQLQuery     = "sql"
+	FromSnapshot = "from"
+	ToSnapshot   = "to"
 )
 
 const (
@@ -216,6 +216,7 @@ func (s *Server) startHTTPServer() {
 	router.Handle("/extract_task/dump", s.newExtractServeHandler()).Name("ExtractTaskDump")
 
 	router.Handle("/optimize_trace/dump/{filename}", s.newOptimizeTraceHandler()).Name("OptimizeTraceDump")
+	router.Handle("/optimize_trace/diff", s.newPlanDiffHandler()).Name("OptimizeTraceDiff")
 
 	tikvHandlerTool := s.NewTikvHandlerTool()
 	router.Handle("/settings", tikvhandler.NewSettingsHandler(tikvHandlerTool)).Name("Settings")
@@ -593,6 +594,19 @@ func (s *Server) newStatsHandler() *optimizor.StatsHandler {
 	return optimizor.NewStatsHandler(do)
 }
 
+func (s *Server) newPlanDiffHandler() *optimizor.PlanDiffHandler {
+	store, ok := s.driver.(*TiDBDriver)
+	if !ok {
+		panic("Illegal driver")
+	}
+
+	do, err := session.GetDomain(store.store)
+	if err != nil {
+		panic("Failed to get domain")
+	}
+	return optimizor.NewPlanDiffHandler(do)
+}
+
 func (s *Server) newStatsHistoryHandler() *optimizor.StatsHistoryHandler {
 	store, ok := s.driver.(*TiDBDriver)
 	if !ok {
@@ -612,6 +612,7 @@ const (
         host VARCHAR(100) NOT NULL PRIMARY KEY,
         role VARCHAR(64),
         cpu_count int default 0,
+        memory_limit bigint default 0,
         keyspace_id bigint(8) NOT NULL DEFAULT -1
     );`
 
@@ -658,6 +659,7 @@ const (
 		watch_text TEXT NOT NULL,
 		source varchar(512) NOT NULL,
 		action bigint(10),
+		switch_group_name varchar(32) NOT NULL DEFAULT '',
 		INDEX sql_index(resource_group_name,watch_text(700)) COMMENT "accelerate the speed when select quarantined query",
 		INDEX time_index(end_time) COMMENT "accelerate the speed when querying with active watch"
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
@@ -673,7 +675,8 @@ const (
 		watch_text TEXT NOT NULL,
 		source varchar(512) NOT NULL,
 		action bigint(10),
-		done_time TIMESTAMP(6) NOT NULL
+		done_time TIMESTAMP(6) NOT NULL,
+		switch_group_name varchar(32) NOT NULL DEFAULT ''
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
 
 	// CreateRequestUnitByGroupTable stores the historical RU consumption by resource group.
@@ -706,6 +709,23 @@ const (
 		PRIMARY KEY (id),
 		KEY (created_by),
 		KEY (status));`
+
+	// CreateEventHistory stores the execution history of the events created by `CREATE EVENT`.
+	CreateEventHistory = `CREATE TABLE IF NOT EXISTS mysql.tidb_event_history (
+		event_id VARCHAR(64) NOT NULL,
+		timer_id VARCHAR(64) NOT NULL,
+		event_schema VARCHAR(64) NOT NULL,
+		event_name VARCHAR(64) NOT NULL,
+		definer VARCHAR(288) NOT NULL,
+		instance VARCHAR(512) NOT NULL DEFAULT '',
+		start_time TIMESTAMP(6) NOT NULL,
+		end_time TIMESTAMP(6) NULL DEFAULT NULL,
+		status VARCHAR(16) NOT NULL,
+		affected_rows BIGINT(64) NOT NULL DEFAULT 0,
+		error_message TEXT DEFAULT NULL,
+		PRIMARY KEY (event_id),
+		KEY (event_schema, event_name, start_time),
+		KEY (start_time));`
 )
 
 // CreateTimers is a table to store all timers for tidb
@@ -1063,11 +1083,23 @@ const (
 	// version 183
 	//   replace `mysql.tidb_mdl_view` table
 	version183 = 183
+
+	// version 184
+	//   add new system table `mysql.tidb_event_history` to store the execution history of events.
+	version184 = 184
+
+	// version 185
+	//   add memory_limit to mysql.dist_framework_meta
+	version185 = 185
+
+	// version 186
+	//   add switch_group_name to `mysql.tidb_runaway_watch` and `mysql.tidb_runaway_watch_done`
+	version186 = 186
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version183
+var currentBootstrapVersion int64 = version186
 
 // DDL owner key's expired time is ManagerSessionTTL seconds, we should wait the time and give more time to have a chance to finish it.
 var internalSQLTimeout = owner.ManagerSessionTTL + 15
@@ -1226,6 +1258,9 @@ var (
 		upgradeToVer181,
 		upgradeToVer182,
 		upgradeToVer183,
+		upgradeToVer184,
+		upgradeToVer185,
+		upgradeToVer186,
 	}
 )
 
@@ -2989,6 +3024,28 @@ func upgradeToVer183(s sessiontypes.Session, ver int64) {
 	doReentrantDDL(s, CreateMDLView)
 }
 
+func upgradeToVer184(s sessiontypes.Session, ver int64) {
+	if ver >= version184 {
+		return
+	}
+	doReentrantDDL(s, CreateEventHistory)
+}
+
+func upgradeToVer185(s sessiontypes.Session, ver int64) {
+	if ver >= version185 {
+		return
+	}
+	doReentrantDDL(s, "ALTER TABLE mysql.dist_framework_meta ADD COLUMN `memory_limit` BIGINT DEFAULT 0 AFTER `cpu_count`", infoschema.ErrColumnExists)
+}
+
+func upgradeToVer186(s sessiontypes.Session, ver int64) {
+	if ver >= version186 {
+		return
+	}
+	doReentrantDDL(s, "ALTER TABLE mysql.tidb_runaway_watch ADD COLUMN `switch_group_name` VARCHAR(32) NOT NULL DEFAULT '' AFTER `action`", infoschema.ErrColumnExists)
+	doReentrantDDL(s, "ALTER TABLE mysql.tidb_runaway_watch_done ADD COLUMN `switch_group_name` VARCHAR(32) NOT NULL DEFAULT '' AFTER `done_time`", infoschema.ErrColumnExists)
+}
+
 func writeOOMAction(s sessiontypes.Session) {
 	comment := "oom-action is `log` by default in v3.0.x, `cancel` by default in v4.0.11+"
 	mustExecute(s, `INSERT HIGH_PRIORITY INTO %n.%n VALUES (%?, %?, %?) ON DUPLICATE KEY UPDATE VARIABLE_VALUE= %?`,
@@ -3120,6 +3177,8 @@ func doDDLWorks(s sessiontypes.Session) {
 	mustExecute(s, CreateDistFrameworkMeta)
 	// create request_unit_by_group
 	mustExecute(s, CreateRequestUnitByGroupTable)
+	// create tidb_event_history
+	mustExecute(s, CreateEventHistory)
 }
 
 // doBootstrapSQLFile executes SQL commands in a file as the last stage of bootstrap.
@@ -0,0 +1,84 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/eventscheduler"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"github.com/pingcap/tidb/pkg/util/sqlkiller"
+)
+
+// executeEventBody executes the body of an event in a new session as the definer of the event.
+// The session is killed if `ctx` is done before the execution finishes.
+func executeEventBody(ctx context.Context, store kv.Storage, data *eventscheduler.EventTimerData) (uint64, error) {
+	se, err := CreateSession(store)
+	if err != nil {
+		return 0, err
+	}
+	defer se.Close()
+
+	if !se.AuthWithoutVerification(&auth.UserIdentity{Username: data.DefinerUser, Hostname: data.DefinerHost}) {
+		return 0, exeerrors.ErrNoSuchUser.GenWithStackByArgs(data.DefinerUser, data.DefinerHost)
+	}
+
+	sessVars := se.GetSessionVars()
+	sessVars.CurrentDB = data.Schema
+	sysVars := []struct {
+		name  string
+		value string
+	}{
+		{variable.SQLModeVar, data.SQLMode},
+		{variable.TimeZone, data.TimeZone},
+		{variable.CharacterSetClient, data.Charset},
+		{variable.CollationConnection, data.Collation},
+	}
+	for _, v := range sysVars {
+		if v.value == "" {
+			continue
+		}
+		if err = sessVars.SetSystemVar(v.name, v.value); err != nil {
+			return 0, err
+		}
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			sessVars.SQLKiller.SendKillSignal(sqlkiller.QueryInterrupted)
+		case <-done:
+		}
+	}()
+
+	rss, err := se.Execute(ctx, data.Body)
+	for _, rs := range rss {
+		if err == nil {
+			_, err = sqlexec.DrainRecordSet(ctx, rs, 1024)
+		}
+		terror.Call(rs.Close)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return sessVars.StmtCtx.AffectedRows(), nil
+}
@@ -189,6 +189,7 @@ type StatementContext struct {
 	UseCache               bool
 	ForcePlanCache         bool // force the optimizer to use plan cache even if there is risky optimization, see #49736.
 	CacheType              PlanCacheType
+	PlanCacheUnqualified   string // the reason why the statement can't use plan cache, recorded in statement summary.
 	BatchCheck             bool
 	InNullRejectCheck      bool
 	IgnoreExplainIDSuffix  bool
@@ -819,6 +820,7 @@ func (sc *StatementContext) SetSkipPlanCache(reason error) {
 	}
 
 	sc.UseCache = false
+	sc.PlanCacheUnqualified = reason.Error()
 	switch sc.CacheType {
 	case DefaultNoCache:
 		sc.AppendWarning(errors.NewNoStackError("unknown cache type"))
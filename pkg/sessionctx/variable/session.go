@@ -778,6 +778,10 @@ type SessionVars struct {
 	// version, we load an old version schema for query.
 	SnapshotInfoschema interface{}
 
+	// OptimizerStatsOverride maps physical table IDs to the statistics tables (*statistics.Table) which are used by
+	// the optimizer instead of the ones in the stats cache. It's used to replay optimization with historical stats.
+	OptimizerStatsOverride map[int64]interface{}
+
 	// BinlogClient is used to write binlog.
 	BinlogClient *pumpcli.PumpsClient
 
@@ -1568,6 +1572,17 @@ type SessionVars struct {
 	// TxnEntrySizeLimit indicates indicates the max size of a entry in membuf. The default limit (from config) will be
 	// overwritten if this value is not 0.
 	TxnEntrySizeLimit uint64
+
+	// EnableCardinalityFeedback indicates whether to record the deviation between estimated and actual row counts
+	// after execution, and use the observed selectivity of recurring predicates in cardinality estimation.
+	EnableCardinalityFeedback bool
+
+	// EnableDistAnalyze indicates whether to collect the samples of ANALYZE by the distributed execution framework.
+	EnableDistAnalyze bool
+
+	// EnableDistAdminCheck indicates whether to run ADMIN CHECK TABLE and ADMIN CHECKSUM TABLE by the distributed
+	// execution framework.
+	EnableDistAdminCheck bool
 }
 
 // GetOptimizerFixControlMap returns the specified value of the optimizer fix control.
@@ -836,6 +836,10 @@ const (
 	TiDBPlanCacheInvalidationOnFreshStats = "tidb_plan_cache_invalidation_on_fresh_stats"
 	// TiDBSessionPlanCacheSize controls the size of session plan cache.
 	TiDBSessionPlanCacheSize = "tidb_session_plan_cache_size"
+	// TiDBEnableInstancePlanCache indicates whether to enable the instance plan cache shared by all sessions.
+	TiDBEnableInstancePlanCache = "tidb_enable_instance_plan_cache"
+	// TiDBInstancePlanCacheMaxMemSize controls the max memory usage of the instance plan cache.
+	TiDBInstancePlanCacheMaxMemSize = "tidb_instance_plan_cache_max_mem_size"
 
 	// TiDBConstraintCheckInPlacePessimistic controls whether to skip certain kinds of pessimistic locks.
 	TiDBConstraintCheckInPlacePessimistic = "tidb_constraint_check_in_place_pessimistic"
@@ -936,6 +940,19 @@ const (
 
 	// TiDBTxnEntrySizeLimit indicates the max size of a entry in membuf.
 	TiDBTxnEntrySizeLimit = "tidb_txn_entry_size_limit"
+
+	// TiDBEnableCardinalityFeedback indicates whether to compare the estimated and actual row counts of executed plans,
+	// and use the observed selectivity of recurring predicates to correct later estimations.
+	TiDBEnableCardinalityFeedback = "tidb_enable_cardinality_feedback"
+
+	// TiDBEnableDistAnalyze indicates whether to split the sample collecting of ANALYZE into subtasks, and run them
+	// on the nodes of the distributed execution framework. It only takes effect when tidb_enable_dist_task is ON.
+	TiDBEnableDistAnalyze = "tidb_enable_dist_analyze"
+
+	// TiDBEnableDistAdminCheck indicates whether to split ADMIN CHECK TABLE and ADMIN CHECKSUM TABLE into subtasks
+	// by key ranges, and run them on the nodes of the distributed execution framework. It only takes effect when
+	// tidb_enable_dist_task is ON.
+	TiDBEnableDistAdminCheck = "tidb_enable_dist_admin_check"
 )
 
 // TiDB vars that have only global scope
@@ -1347,6 +1364,8 @@ const (
 	DefTiDBEnablePrepPlanCache                     = true
 	DefTiDBPrepPlanCacheSize                       = 100
 	DefTiDBSessionPlanCacheSize                    = 100
+	DefTiDBEnableInstancePlanCache                 = false
+	DefTiDBInstancePlanCacheMaxMemSize             = 100 * 1024 * 1024
 	DefTiDBEnablePrepPlanCacheMemoryMonitor        = true
 	DefTiDBPrepPlanCacheMemoryGuardRatio           = 0.1
 	DefTiDBEnableDistTask                          = disttask.TiDBEnableDistTask
@@ -1455,6 +1474,9 @@ const (
 	DefTiDBSchemaVersionCacheLimit                    = 16
 	DefTiDBIdleTransactionTimeout                     = 0
 	DefTiDBTxnEntrySizeLimit                          = 0
+	DefTiDBEnableCardinalityFeedback                  = false
+	DefTiDBEnableDistAnalyze                          = false
+	DefTiDBEnableDistAdminCheck                       = false
 )
 
 // Process global variables.
@@ -1498,6 +1520,8 @@ var (
 	MaxAutoAnalyzeTime                   = atomic.NewInt64(DefTiDBMaxAutoAnalyzeTime)
 	// variables for plan cache
 	PreparedPlanCacheMemoryGuardRatio = atomic.NewFloat64(DefTiDBPrepPlanCacheMemoryGuardRatio)
+	EnableInstancePlanCache           = atomic.NewBool(DefTiDBEnableInstancePlanCache)
+	InstancePlanCacheMaxMemSize       = atomic.NewInt64(DefTiDBInstancePlanCacheMaxMemSize)
 	EnableDistTask                    = atomic.NewBool(DefTiDBEnableDistTask)
 	DDLVersion                        = atomic.NewInt64(model.TiDBDDLV1)
 	DDLForce2Queue                    = atomic.NewBool(false)
@@ -1523,6 +1547,7 @@ var (
 	PasswordValidtaionNumberCount      = atomic.NewInt32(1)
 	PasswordValidationSpecialCharCount = atomic.NewInt32(1)
 	EnableTTLJob                       = atomic.NewBool(DefTiDBTTLJobEnable)
+	EnableEventScheduler               = atomic.NewBool(false)
 	TTLScanBatchSize                   = atomic.NewInt64(DefTiDBTTLScanBatchSize)
 	TTLDeleteBatchSize                 = atomic.NewInt64(DefTiDBTTLDeleteBatchSize)
 	TTLDeleteRateLimit                 = atomic.NewInt64(DefTiDBTTLDeleteRateLimit)
@@ -0,0 +1,227 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/tidb/pkg/util/kvcache"
+)
+
+var (
+	// FeedbackDeviationThreshold is the ratio between the estimated and the actual row count (in either direction)
+	// above which an estimation is regarded as badly off.
+	// Exported for testing.
+	FeedbackDeviationThreshold = 10.0
+	// FeedbackMinRows is the minimum row count (estimated or actual) of an operator to be considered by the feedback.
+	// Deviations on tiny operators are too noisy to learn from.
+	// Exported for testing.
+	FeedbackMinRows = 100.0
+	// FeedbackMinObservations is the number of times a predicate must be observed before its observed selectivity is
+	// used to correct the estimation.
+	// Exported for testing.
+	FeedbackMinObservations = 2
+	// FeedbackAnalyzeThreshold is the number of badly estimated predicates observed on a table before the table is
+	// queued for analyze.
+	// Exported for testing.
+	FeedbackAnalyzeThreshold = 3
+)
+
+const (
+	// feedbackMaxPredicates is the max number of predicates remembered by the feedback.
+	feedbackMaxPredicates = 4096
+	// feedbackMaxPlans is the max number of plan digests remembered by the feedback.
+	feedbackMaxPlans = 1024
+	// feedbackMaxDeviationsPerPlan is the max number of operators recorded for one plan digest.
+	feedbackMaxDeviationsPerPlan = 16
+	// feedbackSmoothFactor is the weight of the latest observation when updating the observed selectivity.
+	feedbackSmoothFactor = 0.5
+)
+
+// OperatorDeviation records an operator whose estimated row count is far from the actual one.
+type OperatorDeviation struct {
+	OperatorID string
+	EstRows    float64
+	ActRows    float64
+}
+
+// PredicateObservation is the estimated and the actual selectivity of the filter conditions on a table observed from
+// one execution.
+type PredicateObservation struct {
+	// Conditions is the normalized text of the conditions, see expression.SortedExplainExpressionList.
+	Conditions      string
+	PhysicalTableID int64
+	EstSelectivity  float64
+	ActSelectivity  float64
+}
+
+// IsLargeDeviation checks whether the estimated row count is badly off from the actual one.
+func IsLargeDeviation(estRows, actRows float64) bool {
+	if math.Max(estRows, actRows) < FeedbackMinRows {
+		return false
+	}
+	// Avoid dividing by zero, one row more or less doesn't matter here.
+	estRows, actRows = math.Max(estRows, 1), math.Max(actRows, 1)
+	return estRows/actRows >= FeedbackDeviationThreshold || actRows/estRows >= FeedbackDeviationThreshold
+}
+
+type feedbackKey string
+
+// Hash implements the kvcache.Key interface.
+func (k feedbackKey) Hash() []byte {
+	return []byte(k)
+}
+
+func predicateKey(physicalTableID int64, conditions string) feedbackKey {
+	return feedbackKey(strconv.FormatInt(physicalTableID, 10) + ":" + conditions)
+}
+
+type predicateFeedback struct {
+	selectivity  float64
+	observations int
+}
+
+// CardinalityFeedback collects the deviations between the estimated and the actual row counts of executed plans.
+// It is kept in the memory of the current instance and shared by all sessions.
+type CardinalityFeedback struct {
+	mu sync.Mutex
+	// predicates maps the (physical table, conditions) to the observed selectivity.
+	predicates *kvcache.SimpleLRUCache
+	// plans maps the plan digest to the badly estimated operators of its latest execution.
+	plans *kvcache.SimpleLRUCache
+	// deviatedTables counts the badly estimated predicates observed on each physical table since it was last queued
+	// for analyze.
+	deviatedTables map[int64]int
+	// tablesToAnalyze is the weight of the physical tables waiting to be analyzed.
+	tablesToAnalyze map[int64]float64
+}
+
+// NewCardinalityFeedback creates a new CardinalityFeedback.
+func NewCardinalityFeedback() *CardinalityFeedback {
+	return &CardinalityFeedback{
+		predicates:      kvcache.NewSimpleLRUCache(feedbackMaxPredicates, 0, 0),
+		plans:           kvcache.NewSimpleLRUCache(feedbackMaxPlans, 0, 0),
+		deviatedTables:  make(map[int64]int),
+		tablesToAnalyze: make(map[int64]float64),
+	}
+}
+
+// GlobalCardinalityFeedback is the cardinality feedback of the current instance.
+var GlobalCardinalityFeedback = NewCardinalityFeedback()
+
+// Record records the observations of one execution of the plan.
+func (f *CardinalityFeedback) Record(planDigest string, deviations []OperatorDeviation, predicates []PredicateObservation) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(deviations) > 0 && planDigest != "" {
+		if len(deviations) > feedbackMaxDeviationsPerPlan {
+			deviations = deviations[:feedbackMaxDeviationsPerPlan]
+		}
+		f.plans.Put(feedbackKey(planDigest), deviations)
+	}
+	for _, p := range predicates {
+		key := predicateKey(p.PhysicalTableID, p.Conditions)
+		if v, ok := f.predicates.Get(key); ok {
+			fb := v.(*predicateFeedback)
+			fb.selectivity = fb.selectivity*(1-feedbackSmoothFactor) + p.ActSelectivity*feedbackSmoothFactor
+			fb.observations++
+		} else {
+			f.predicates.Put(key, &predicateFeedback{selectivity: p.ActSelectivity, observations: 1})
+		}
+		deviation := math.Max(p.EstSelectivity, p.ActSelectivity) / math.Max(math.Min(p.EstSelectivity, p.ActSelectivity), 1e-9)
+		if deviation < FeedbackDeviationThreshold {
+			continue
+		}
+		f.deviatedTables[p.PhysicalTableID]++
+		if f.deviatedTables[p.PhysicalTableID] >= FeedbackAnalyzeThreshold {
+			delete(f.deviatedTables, p.PhysicalTableID)
+			f.tablesToAnalyze[p.PhysicalTableID] += math.Log10(deviation)
+		}
+	}
+}
+
+// CorrectedSelectivity returns the observed selectivity of the conditions on the physical table if the conditions
+// have been observed enough times.
+func (f *CardinalityFeedback) CorrectedSelectivity(physicalTableID int64, conditions string) (float64, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v, ok := f.predicates.Get(predicateKey(physicalTableID, conditions))
+	if !ok {
+		return 0, false
+	}
+	fb := v.(*predicateFeedback)
+	if fb.observations < FeedbackMinObservations {
+		return 0, false
+	}
+	return fb.selectivity, true
+}
+
+// PlanDeviations returns the badly estimated operators of the latest execution of the plan.
+func (f *CardinalityFeedback) PlanDeviations(planDigest string) []OperatorDeviation {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v, ok := f.plans.Get(feedbackKey(planDigest))
+	if !ok {
+		return nil
+	}
+	return v.([]OperatorDeviation)
+}
+
+// PopTablesToAnalyze returns the physical tables which should be analyzed because of bad estimations and their
+// weights, and clears them.
+func (f *CardinalityFeedback) PopTablesToAnalyze() map[int64]float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.tablesToAnalyze) == 0 {
+		return nil
+	}
+	tables := f.tablesToAnalyze
+	f.tablesToAnalyze = make(map[int64]float64)
+	return tables
+}
+
+// AddTableToAnalyze queues the physical table to be analyzed with the given weight.
+func (f *CardinalityFeedback) AddTableToAnalyze(physicalTableID int64, weight float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tablesToAnalyze[physicalTableID] += weight
+}
+
+// ForgetTable drops all the feedback of the physical table, it's called after the table is analyzed.
+func (f *CardinalityFeedback) ForgetTable(physicalTableID int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	prefix := strconv.FormatInt(physicalTableID, 10) + ":"
+	for _, key := range f.predicates.Keys() {
+		if strings.HasPrefix(string(key.(feedbackKey)), prefix) {
+			f.predicates.Delete(key)
+		}
+	}
+	delete(f.deviatedTables, physicalTableID)
+	delete(f.tablesToAnalyze, physicalTableID)
+}
+
+// Reset clears all the feedback.
+func (f *CardinalityFeedback) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.predicates.DeleteAll()
+	f.plans.DeleteAll()
+	f.deviatedTables = make(map[int64]int)
+	f.tablesToAnalyze = make(map[int64]float64)
+}
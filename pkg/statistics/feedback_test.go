@@ -0,0 +1,66 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsLargeDeviation(t *testing.T) {
+	require.False(t, IsLargeDeviation(1, 50))
+	require.False(t, IsLargeDeviation(100, 500))
+	require.True(t, IsLargeDeviation(10, 1000))
+	require.True(t, IsLargeDeviation(1000, 0))
+}
+
+func TestCardinalityFeedback(t *testing.T) {
+	f := NewCardinalityFeedback()
+	obs := PredicateObservation{
+		Conditions:      "eq(test.t.a, 1)",
+		PhysicalTableID: 100,
+		EstSelectivity:  0.001,
+		ActSelectivity:  0.5,
+	}
+	deviations := []OperatorDeviation{{OperatorID: "Selection_6", EstRows: 1, ActRows: 500}}
+
+	f.Record("digest", deviations, []PredicateObservation{obs})
+	require.Equal(t, deviations, f.PlanDeviations("digest"))
+	require.Nil(t, f.PlanDeviations("other"))
+	// The predicate is only observed once.
+	_, ok := f.CorrectedSelectivity(100, "eq(test.t.a, 1)")
+	require.False(t, ok)
+
+	obs.ActSelectivity = 0.3
+	f.Record("digest", deviations, []PredicateObservation{obs})
+	sel, ok := f.CorrectedSelectivity(100, "eq(test.t.a, 1)")
+	require.True(t, ok)
+	require.InDelta(t, 0.4, sel, 1e-9)
+	_, ok = f.CorrectedSelectivity(101, "eq(test.t.a, 1)")
+	require.False(t, ok)
+	require.Nil(t, f.PopTablesToAnalyze())
+
+	// The table is queued for analyze after being badly estimated for several times.
+	f.Record("digest", deviations, []PredicateObservation{obs})
+	tables := f.PopTablesToAnalyze()
+	require.Len(t, tables, 1)
+	require.Contains(t, tables, int64(100))
+	require.Nil(t, f.PopTablesToAnalyze())
+
+	f.ForgetTable(100)
+	_, ok = f.CorrectedSelectivity(100, "eq(test.t.a, 1)")
+	require.False(t, ok)
+}
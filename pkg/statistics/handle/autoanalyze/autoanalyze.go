@@ -15,6 +15,7 @@
 package autoanalyze
 
 import (
+	"container/heap"
 	"context"
 	"fmt"
 	"math"
@@ -33,6 +34,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/statistics/handle/autoanalyze/exec"
+	"github.com/pingcap/tidb/pkg/statistics/handle/autoanalyze/priorityqueue"
 	"github.com/pingcap/tidb/pkg/statistics/handle/lockstats"
 	statslogutil "github.com/pingcap/tidb/pkg/statistics/handle/logutil"
 	statstypes "github.com/pingcap/tidb/pkg/statistics/handle/types"
@@ -332,6 +334,10 @@ func HandleAutoAnalyze(
 	}
 	pruneMode := variable.PartitionPruneMode(sctx.GetSessionVars().PartitionPruneMode.Load())
 
+	if tryAutoAnalyzeTableFromFeedback(sctx, statsHandle, sysProcTracker) {
+		return true
+	}
+
 	return RandomPickOneTableAndTryAutoAnalyze(
 		sctx,
 		statsHandle,
@@ -343,6 +349,91 @@ func HandleAutoAnalyze(
 	)
 }
 
+// tryAutoAnalyzeTableFromFeedback analyzes the table whose estimations are badly off according to the cardinality
+// feedback. The tables are picked by a priority queue weighted by how badly they are estimated.
+// NOTE: only the feedback collected by the current instance is considered.
+func tryAutoAnalyzeTableFromFeedback(
+	sctx sessionctx.Context,
+	statsHandle statstypes.StatsHandle,
+	sysProcTracker sessionctx.SysProcTracker,
+) bool {
+	tables := statistics.GlobalCardinalityFeedback.PopTablesToAnalyze()
+	if len(tables) == 0 {
+		return false
+	}
+	queue := make(priorityqueue.AnalysisQueue, 0, len(tables))
+	for id, weight := range tables {
+		queue = append(queue, &priorityqueue.TableAnalysisJob{TableID: id, Weight: weight})
+	}
+	heap.Init(&queue)
+	// Put the tables which are not analyzed in this round back to the feedback.
+	defer func() {
+		for _, job := range queue {
+			statistics.GlobalCardinalityFeedback.AddTableToAnalyze(job.TableID, job.Weight)
+		}
+	}()
+
+	lockedTables, err := lockstats.QueryLockedTables(sctx)
+	if err != nil {
+		statslogutil.StatsLogger().Error(
+			"check table lock failed",
+			zap.Error(err),
+		)
+		return false
+	}
+	is := sctx.GetDomainInfoSchema().(infoschema.InfoSchema)
+	for queue.Len() > 0 {
+		job := heap.Pop(&queue).(*priorityqueue.TableAnalysisJob)
+		if _, ok := lockedTables[job.TableID]; ok {
+			continue
+		}
+		sql := "analyze table %n.%n"
+		var (
+			tblInfo *model.TableInfo
+			params  []interface{}
+		)
+		if tbl, ok := is.TableByID(job.TableID); ok {
+			db, ok := is.SchemaByTable(tbl.Meta())
+			if !ok {
+				continue
+			}
+			tblInfo = tbl.Meta()
+			params = []interface{}{db.Name.O, tblInfo.Name.O}
+		} else if tbl, db, def := is.FindTableByPartitionID(job.TableID); tbl != nil {
+			tblInfo = tbl.Meta()
+			sql += " partition %n"
+			params = []interface{}{db.Name.O, tblInfo.Name.O, def.Name.O}
+		} else {
+			// The table has been dropped.
+			continue
+		}
+		if util.IsMemOrSysDB(strings.ToLower(params[0].(string))) {
+			continue
+		}
+		statsTbl := statsHandle.GetPartitionStats(tblInfo, job.TableID)
+		if statsTbl == nil || statsTbl.Pseudo {
+			continue
+		}
+		escaped, err := sqlescape.EscapeSQL(sql, params...)
+		if err != nil {
+			continue
+		}
+		statslogutil.StatsLogger().Info(
+			"auto analyze triggered",
+			zap.String("sql", escaped),
+			zap.String("reason", "cardinality estimation is badly off"),
+			zap.Float64("weight", job.Weight),
+		)
+		tableStatsVer := sctx.GetSessionVars().AnalyzeVersion
+		statistics.CheckAnalyzeVerOnTable(statsTbl, &tableStatsVer)
+		exec.AutoAnalyze(sctx, statsHandle, sysProcTracker, tableStatsVer, sql, params...)
+		// The observed selectivity is outdated once the table is analyzed.
+		statistics.GlobalCardinalityFeedback.ForgetTable(job.TableID)
+		return true
+	}
+	return false
+}
+
 // RandomPickOneTableAndTryAutoAnalyze randomly picks one table and tries to analyze it.
 // 1. If the table is not analyzed, analyze it.
 // 2. If the table is analyzed, analyze it when "tbl.ModifyCount/tbl.Count > autoAnalyzeRatio".
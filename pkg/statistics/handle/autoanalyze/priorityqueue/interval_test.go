@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package priorityqueue
+package priorityqueue_test
 
 import (
 	"testing"
@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/tidb/pkg/session"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/statistics/handle/autoanalyze/priorityqueue"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/stretchr/testify/require"
 )
@@ -32,7 +33,7 @@ func TestGetAverageAnalysisDuration(t *testing.T) {
 	// Empty table.
 	se := tk.Session()
 	sctx := se.(sessionctx.Context)
-	avgDuration, err := getAverageAnalysisDuration(
+	avgDuration, err := priorityqueue.GetAverageAnalysisDurationForTest(
 		sctx,
 		"example_schema", "example_table", "example_partition",
 	)
@@ -44,14 +45,14 @@ func TestGetAverageAnalysisDuration(t *testing.T) {
 	// Partitioned table.
 	insertMultipleFinishedJobs(tk, "example_table", "example_partition")
 	// Only one partition.
-	avgDuration, err = getAverageAnalysisDuration(
+	avgDuration, err = priorityqueue.GetAverageAnalysisDurationForTest(
 		sctx,
 		"example_schema", "example_table", "example_partition",
 	)
 	require.NoError(t, err)
 	require.Equal(t, time.Duration(3600)*time.Second, avgDuration)
 	// Multiple partitions.
-	avgDuration, err = getAverageAnalysisDuration(
+	avgDuration, err = priorityqueue.GetAverageAnalysisDurationForTest(
 		sctx,
 		"example_schema", "example_table", "example_partition", "example_partition1",
 	)
@@ -59,7 +60,7 @@ func TestGetAverageAnalysisDuration(t *testing.T) {
 	require.Equal(t, time.Duration(3600)*time.Second, avgDuration)
 	// Non-partitioned table.
 	insertMultipleFinishedJobs(tk, "example_table1", "")
-	avgDuration, err = getAverageAnalysisDuration(sctx, "example_schema", "example_table1")
+	avgDuration, err = priorityqueue.GetAverageAnalysisDurationForTest(sctx, "example_schema", "example_table1")
 	require.NoError(t, err)
 	require.Equal(t, time.Duration(3600)*time.Second, avgDuration)
 }
@@ -97,7 +98,7 @@ func TestGetLastFailedAnalysisDuration(t *testing.T) {
 	// Empty table.
 	se := tk.Session()
 	sctx := se.(sessionctx.Context)
-	lastFailedDuration, err := getLastFailedAnalysisDuration(
+	lastFailedDuration, err := priorityqueue.GetLastFailedAnalysisDurationForTest(
 		sctx,
 		"example_schema", "example_table", "example_partition",
 	)
@@ -109,14 +110,14 @@ func TestGetLastFailedAnalysisDuration(t *testing.T) {
 	insertFailedJob(tk, "example_schema", "example_table", "example_partition")
 	insertFailedJob(tk, "example_schema", "example_table", "example_partition1")
 	// Only one partition.
-	lastFailedDuration, err = getLastFailedAnalysisDuration(
+	lastFailedDuration, err = priorityqueue.GetLastFailedAnalysisDurationForTest(
 		sctx,
 		"example_schema", "example_table", "example_partition",
 	)
 	require.NoError(t, err)
 	require.GreaterOrEqual(t, lastFailedDuration, time.Duration(24)*time.Hour)
 	// Multiple partitions.
-	lastFailedDuration, err = getLastFailedAnalysisDuration(
+	lastFailedDuration, err = priorityqueue.GetLastFailedAnalysisDurationForTest(
 		sctx,
 		"example_schema", "example_table", "example_partition", "example_partition1",
 	)
@@ -124,7 +125,7 @@ func TestGetLastFailedAnalysisDuration(t *testing.T) {
 	require.GreaterOrEqual(t, lastFailedDuration, time.Duration(24)*time.Hour)
 	// Non-partitioned table.
 	insertFailedJob(tk, "example_schema", "example_table1", "")
-	lastFailedDuration, err = getLastFailedAnalysisDuration(sctx, "example_schema", "example_table1")
+	lastFailedDuration, err = priorityqueue.GetLastFailedAnalysisDurationForTest(sctx, "example_schema", "example_table1")
 	require.NoError(t, err)
 	require.GreaterOrEqual(t, lastFailedDuration, time.Duration(24)*time.Hour)
 }
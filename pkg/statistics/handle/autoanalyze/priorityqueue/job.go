@@ -17,5 +17,7 @@ package priorityqueue
 // TableAnalysisJob defines the structure for table analysis job information.
 type TableAnalysisJob struct {
 	// TODO: add more information about the job.
-	Weight float64
+	// TableID is the physical ID of the table or partition to analyze.
+	TableID int64
+	Weight  float64
 }
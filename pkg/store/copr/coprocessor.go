@@ -1263,7 +1263,8 @@ func (worker *copIteratorWorker) handleTaskOnce(bo *Backoffer, task *copTask, ch
 		worker.logTimeCopTask(costTime, task, bo, copResp)
 	}
 	if worker.req.RunawayChecker != nil {
-		worker.req.RunawayChecker.AfterCopRequest()
+		scannedKeys := int64(copResp.GetExecDetailsV2().GetScanDetailV2().GetTotalVersions())
+		worker.req.RunawayChecker.AfterCopRequest(scannedKeys)
 	}
 
 	storeID := strconv.FormatUint(req.Context.GetPeer().GetStoreId(), 10)
@@ -26,6 +26,7 @@ import (
 	"github.com/pingcap/errors"
 	deadlockpb "github.com/pingcap/kvproto/pkg/deadlock"
 	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	"github.com/pingcap/tidb/pkg/domain/resourcegroup"
 	"github.com/pingcap/tidb/pkg/executor/importer"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/metrics"
@@ -214,7 +215,7 @@ func (d TiKVDriver) OpenWithOptions(path string, options ...Option) (resStore kv
 		tikv.WithCodec(codec),
 	)
 
-	s, err = tikv.NewKVStore(uuid, pdClient, spkv, &injectTraceClient{Client: rpcClient},
+	s, err = tikv.NewKVStore(uuid, pdClient, spkv, &injectTraceClient{Client: &backgroundUsageClient{Client: rpcClient}},
 		tikv.WithPDHTTPClient("tikv-driver", etcdAddrs, pdhttp.WithTLSConfig(tlsConfig), pdhttp.WithMetrics(metrics.PDAPIRequestCounter, metrics.PDAPIExecutionHistogram)))
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -427,3 +428,16 @@ func (c *injectTraceClient) SendRequest(ctx context.Context, addr string, req *t
 	}
 	return c.Client.SendRequest(ctx, addr, req, timeout)
 }
+
+// backgroundUsageClient records the consumption of the requests sent by the
+// background tasks which are assigned to resource groups.
+type backgroundUsageClient struct {
+	tikv.Client
+}
+
+// SendRequest sends Request.
+func (c *backgroundUsageClient) SendRequest(ctx context.Context, addr string, req *tikvrpc.Request, timeout time.Duration) (*tikvrpc.Response, error) {
+	resp, err := c.Client.SendRequest(ctx, addr, req, timeout)
+	resourcegroup.RecordBackgroundUsage(req, resp)
+	return resp, err
+}
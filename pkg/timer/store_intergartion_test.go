@@ -163,6 +163,10 @@ func runTimerStoreInsertAndGet(ctx context.Context, t *testing.T, store *api.Tim
 	require.NoError(t, err)
 	require.Equal(t, recordTpl, *record)
 
+	// key exists
+	_, err = store.Create(ctx, &api.TimerRecord{TimerSpec: recordTpl.TimerSpec})
+	require.True(t, errors.ErrorEqual(err, api.ErrTimerExists))
+
 	// key not exist
 	_, err = store.GetByKey(ctx, "n1", "noexist")
 	require.True(t, errors.ErrorEqual(err, api.ErrTimerNotExist))
@@ -102,6 +102,9 @@ func (s *tableTimerStoreCore) Create(ctx context.Context, record *api.TimerRecor
 
 	_, err = executeSQL(ctx, sctx, sql, args...)
 	if err != nil {
+		if kv.ErrKeyExists.Equal(err) {
+			return "", errors.Trace(api.ErrTimerExists)
+		}
 		return "", err
 	}
 
@@ -114,6 +114,8 @@ type TTLTaskState struct {
 	TotalRows   uint64 `json:"total_rows"`
 	SuccessRows uint64 `json:"success_rows"`
 	ErrorRows   uint64 `json:"error_rows"`
+	// ArchivedRows is the count of the rows which have been archived to the external storage before deleted
+	ArchivedRows uint64 `json:"archived_rows,omitempty"`
 
 	ScanTaskErr string `json:"scan_task_err"`
 }
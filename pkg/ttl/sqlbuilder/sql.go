@@ -111,11 +111,16 @@ func (b *SQLBuilder) Build() (string, error) {
 
 // WriteSelect writes a select statement to select key columns without any condition
 func (b *SQLBuilder) WriteSelect() error {
+	return b.WriteSelectColumns(b.tbl.KeyColumns)
+}
+
+// WriteSelectColumns writes a select statement to select the specified columns without any condition
+func (b *SQLBuilder) WriteSelectColumns(cols []*model.ColumnInfo) error {
 	if b.state != writeBegin {
 		return errors.Errorf("invalid state: %v", b.state)
 	}
 	b.restoreCtx.WritePlain("SELECT LOW_PRIORITY SQL_NO_CACHE ")
-	b.writeColNames(b.tbl.KeyColumns, false)
+	b.writeColNames(cols, false)
 	b.restoreCtx.WritePlain(" FROM ")
 	if err := b.writeTblName(); err != nil {
 		return err
@@ -167,7 +172,8 @@ func (b *SQLBuilder) WriteCommonCondition(cols []*model.ColumnInfo, op string, d
 	return b.writeDataPoint(cols, dp)
 }
 
-// WriteExpireCondition writes a condition with the time column
+// WriteExpireCondition writes a condition with the time column. If the table has a TTL_FILTER, the filter is also
+// written, so that only the expired rows matching the filter are scanned or deleted.
 func (b *SQLBuilder) WriteExpireCondition(expire time.Time) error {
 	switch b.state {
 	case writeSelOrDel:
@@ -184,6 +190,11 @@ func (b *SQLBuilder) WriteExpireCondition(expire time.Time) error {
 	b.restoreCtx.WritePlain("FROM_UNIXTIME(")
 	b.restoreCtx.WritePlain(strconv.FormatInt(expire.Unix(), 10))
 	b.restoreCtx.WritePlain(")")
+	if ttlInfo := b.tbl.TTLInfo; ttlInfo != nil && ttlInfo.Filter != "" {
+		b.restoreCtx.WritePlain(" AND (")
+		b.restoreCtx.WritePlain(ttlInfo.Filter)
+		b.restoreCtx.WritePlain(")")
+	}
 	b.hasWriteExpireCond = true
 	return nil
 }
@@ -455,6 +466,29 @@ func (g *ScanQueryGenerator) buildSQL() (string, error) {
 	return b.Build()
 }
 
+// BuildArchiveSelectSQL builds a select SQL to read the specified columns of the rows which will be deleted by the
+// delete SQL built by `BuildDeleteSQL` with the same arguments
+func BuildArchiveSelectSQL(tbl *cache.PhysicalTable, cols []*model.ColumnInfo, rows [][]types.Datum, expire time.Time) (string, error) {
+	if len(rows) == 0 {
+		return "", errors.New("Cannot build archive select SQL with empty rows")
+	}
+
+	b := NewSQLBuilder(tbl)
+	if err := b.WriteSelectColumns(cols); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteInCondition(tbl.KeyColumns, rows...); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteExpireCondition(expire); err != nil {
+		return "", err
+	}
+
+	return b.Build()
+}
+
 // BuildDeleteSQL builds a delete SQL
 func BuildDeleteSQL(tbl *cache.PhysicalTable, rows [][]types.Datum, expire time.Time) (string, error) {
 	if len(rows) == 0 {
@@ -480,3 +514,40 @@ func BuildDeleteSQL(tbl *cache.PhysicalTable, rows [][]types.Datum, expire time.
 
 	return b.Build()
 }
+
+// BuildDropPartitionSQL builds a DDL to drop the partition of the physical table
+func BuildDropPartitionSQL(tbl *cache.PhysicalTable) (string, error) {
+	if tbl.Partition.L == "" {
+		return "", errors.Errorf("table '%s.%s' is not a partition", tbl.Schema.O, tbl.Name.O)
+	}
+	return sqlescape.EscapeSQL("ALTER TABLE %n.%n DROP PARTITION %n", tbl.Schema.O, tbl.Name.O, tbl.Partition.O)
+}
+
+// BuildAddPartitionsSQL builds a DDL to add the RANGE partitions to the table, `lessThans` are the values of the
+// `VALUES LESS THAN` clauses of the partitions named by `names`
+func BuildAddPartitionsSQL(tbl *cache.PhysicalTable, names []string, lessThans []string) (string, error) {
+	if len(names) == 0 || len(names) != len(lessThans) {
+		return "", errors.New("Cannot build add partitions SQL with empty or mismatched partitions")
+	}
+
+	var sb strings.Builder
+	sqlescape.MustFormatSQL(&sb, "ALTER TABLE %n.%n ADD PARTITION (", tbl.Schema.O, tbl.Name.O)
+	for i, name := range names {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sqlescape.MustFormatSQL(&sb, "PARTITION %n VALUES LESS THAN (%?)", name, lessThans[i])
+	}
+	sb.WriteString(")")
+	return sb.String(), nil
+}
+
+// BuildSelectNullTimeSQL builds a select SQL to check whether there are rows in the partition whose time column is
+// NULL, these rows are never expired
+func BuildSelectNullTimeSQL(tbl *cache.PhysicalTable) (string, error) {
+	if tbl.Partition.L == "" {
+		return "", errors.Errorf("table '%s.%s' is not a partition", tbl.Schema.O, tbl.Name.O)
+	}
+	return sqlescape.EscapeSQL("SELECT 1 FROM %n.%n PARTITION(%n) WHERE %n IS NULL LIMIT 1",
+		tbl.Schema.O, tbl.Name.O, tbl.Partition.O, tbl.TimeColumn.Name.O)
+}
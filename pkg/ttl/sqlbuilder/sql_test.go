@@ -931,6 +931,93 @@ func TestBuildDeleteSQL(t *testing.T) {
 	}
 }
 
+func TestBuildArchiveSelectSQL(t *testing.T) {
+	id := &model.ColumnInfo{Name: model.NewCIStr("id"), FieldType: *types.NewFieldType(mysql.TypeInt24)}
+	v := &model.ColumnInfo{Name: model.NewCIStr("v"), FieldType: *types.NewFieldType(mysql.TypeVarchar)}
+	tm := &model.ColumnInfo{Name: model.NewCIStr("time"), FieldType: *types.NewFieldType(mysql.TypeDatetime)}
+	tbl := &cache.PhysicalTable{
+		Schema: model.NewCIStr("test"),
+		TableInfo: &model.TableInfo{
+			Name: model.NewCIStr("t1"),
+		},
+		KeyColumns: []*model.ColumnInfo{id},
+		TimeColumn: tm,
+	}
+
+	_, err := sqlbuilder.BuildArchiveSelectSQL(tbl, []*model.ColumnInfo{id, v, tm}, nil, time.UnixMilli(0).In(time.UTC))
+	require.Error(t, err)
+
+	sql, err := sqlbuilder.BuildArchiveSelectSQL(tbl, []*model.ColumnInfo{id, v, tm}, [][]types.Datum{d(1), d(2)}, time.UnixMilli(0).In(time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, "SELECT LOW_PRIORITY SQL_NO_CACHE `id`, `v`, `time` FROM `test`.`t1` WHERE `id` IN (1, 2) AND `time` < FROM_UNIXTIME(0)", sql)
+}
+
+func TestBuildSQLWithTTLFilter(t *testing.T) {
+	id := &model.ColumnInfo{Name: model.NewCIStr("id"), FieldType: *types.NewFieldType(mysql.TypeInt24)}
+	tm := &model.ColumnInfo{Name: model.NewCIStr("time"), FieldType: *types.NewFieldType(mysql.TypeDatetime)}
+	tbl := &cache.PhysicalTable{
+		Schema: model.NewCIStr("test"),
+		TableInfo: &model.TableInfo{
+			Name: model.NewCIStr("t1"),
+			TTLInfo: &model.TTLInfo{
+				ColumnName: model.NewCIStr("time"),
+				Filter:     "`pinned`!=1 OR `pinned` IS NULL",
+			},
+		},
+		KeyColumns: []*model.ColumnInfo{id},
+		TimeColumn: tm,
+	}
+	expire := time.UnixMilli(0).In(time.UTC)
+
+	g, err := sqlbuilder.NewScanQueryGenerator(tbl, expire, nil, nil)
+	require.NoError(t, err)
+	sql, err := g.NextSQL(nil, 32)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT LOW_PRIORITY SQL_NO_CACHE `id` FROM `test`.`t1` WHERE `time` < FROM_UNIXTIME(0) AND (`pinned`!=1 OR `pinned` IS NULL) ORDER BY `id` ASC LIMIT 32", sql)
+
+	// the filter is checked again when deleting the rows, in case they are updated after being scanned
+	sql, err = sqlbuilder.BuildDeleteSQL(tbl, [][]types.Datum{d(1), d(2)}, expire)
+	require.NoError(t, err)
+	require.Equal(t, "DELETE LOW_PRIORITY FROM `test`.`t1` WHERE `id` IN (1, 2) AND `time` < FROM_UNIXTIME(0) AND (`pinned`!=1 OR `pinned` IS NULL) LIMIT 2", sql)
+
+	sql, err = sqlbuilder.BuildArchiveSelectSQL(tbl, []*model.ColumnInfo{id, tm}, [][]types.Datum{d(1)}, expire)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT LOW_PRIORITY SQL_NO_CACHE `id`, `time` FROM `test`.`t1` WHERE `id` IN (1) AND `time` < FROM_UNIXTIME(0) AND (`pinned`!=1 OR `pinned` IS NULL)", sql)
+}
+
+func TestBuildPartitionLifecycleSQL(t *testing.T) {
+	tm := &model.ColumnInfo{Name: model.NewCIStr("time"), FieldType: *types.NewFieldType(mysql.TypeDatetime)}
+	tbl := &cache.PhysicalTable{
+		Schema: model.NewCIStr("test"),
+		TableInfo: &model.TableInfo{
+			Name: model.NewCIStr("t1"),
+		},
+		TimeColumn: tm,
+	}
+
+	_, err := sqlbuilder.BuildDropPartitionSQL(tbl)
+	require.Error(t, err)
+	_, err = sqlbuilder.BuildSelectNullTimeSQL(tbl)
+	require.Error(t, err)
+	_, err = sqlbuilder.BuildAddPartitionsSQL(tbl, nil, nil)
+	require.Error(t, err)
+	_, err = sqlbuilder.BuildAddPartitionsSQL(tbl, []string{"p1"}, nil)
+	require.Error(t, err)
+
+	sql, err := sqlbuilder.BuildAddPartitionsSQL(tbl, []string{"P_LT_2024-02-01", "P_LT_2024-03-01"}, []string{"2024-02-01", "2024-03-01"})
+	require.NoError(t, err)
+	require.Equal(t, "ALTER TABLE `test`.`t1` ADD PARTITION (PARTITION `P_LT_2024-02-01` VALUES LESS THAN ('2024-02-01'), PARTITION `P_LT_2024-03-01` VALUES LESS THAN ('2024-03-01'))", sql)
+
+	tbl.Partition = model.NewCIStr("p0")
+	sql, err = sqlbuilder.BuildDropPartitionSQL(tbl)
+	require.NoError(t, err)
+	require.Equal(t, "ALTER TABLE `test`.`t1` DROP PARTITION `p0`", sql)
+
+	sql, err = sqlbuilder.BuildSelectNullTimeSQL(tbl)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT 1 FROM `test`.`t1` PARTITION(`p0`) WHERE `time` IS NULL LIMIT 1", sql)
+}
+
 func d(vs ...interface{}) []types.Datum {
 	datums := make([]types.Datum, len(vs))
 	for i, v := range vs {
@@ -0,0 +1,316 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttlworker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/ttl/cache"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/xitongsys/parquet-go-source/writerfile"
+	"github.com/xitongsys/parquet-go/layout"
+	"github.com/xitongsys/parquet-go/marshal"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/schema"
+	"github.com/xitongsys/parquet-go/writer"
+	"go.uber.org/zap"
+)
+
+const (
+	archiveFormatCSV     = "csv"
+	archiveFormatParquet = "parquet"
+
+	archiveCSVNull         = `\N`
+	archiveParquetPageSize = 8 * 1024
+	archiveParquetRootName = "schema"
+)
+
+var archiveStorages = struct {
+	sync.Mutex
+	m map[string]storage.ExternalStorage
+}{m: make(map[string]storage.ExternalStorage)}
+
+// getArchiveStorage returns the external storage of the uri. The storages are cached to avoid creating a new client
+// for every delete batch.
+func getArchiveStorage(ctx context.Context, uri string) (storage.ExternalStorage, error) {
+	archiveStorages.Lock()
+	defer archiveStorages.Unlock()
+	if s, ok := archiveStorages.m[uri]; ok {
+		return s, nil
+	}
+
+	s, err := storage.NewFromURL(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	archiveStorages.m[uri] = s
+	return s, nil
+}
+
+// closeArchiveStorages releases the cached archive storages. It's called when the TTL job manager exits, and the
+// storages will be created again if they are needed later.
+func closeArchiveStorages() {
+	archiveStorages.Lock()
+	defer archiveStorages.Unlock()
+	for uri, s := range archiveStorages.m {
+		if closer, ok := s.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				logutil.BgLogger().Warn("close TTL archive storage failed", zap.String("uri", ast.RedactURL(uri)), zap.Error(err))
+			}
+		}
+		delete(archiveStorages.m, uri)
+	}
+}
+
+// getArchiveColumns returns the columns to be archived, which are all the visible columns of the table.
+func getArchiveColumns(tbl *cache.PhysicalTable) []*model.ColumnInfo {
+	cols := make([]*model.ColumnInfo, 0, len(tbl.Columns))
+	for _, col := range tbl.Cols() {
+		if col.Hidden {
+			continue
+		}
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+// ttlArchiver writes the expired rows of a table to the external storage.
+type ttlArchiver struct {
+	store  storage.ExternalStorage
+	format string
+	cols   []*model.ColumnInfo
+}
+
+func newTTLArchiver(ctx context.Context, tbl *cache.PhysicalTable) (*ttlArchiver, error) {
+	store, err := getArchiveStorage(ctx, tbl.TTLInfo.ArchiveURI)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ttlArchiver{
+		store:  store,
+		format: tbl.TTLInfo.GetArchiveFormat(),
+		cols:   getArchiveColumns(tbl),
+	}, nil
+}
+
+// archiveFileName returns the file name of a delete batch. The name is determined by the physical table and the key
+// range of the batch only, so that the batch archived again by a retry or a new job overwrites the same file.
+func archiveFileName(tbl *cache.PhysicalTable, format string, batch [][]types.Datum) (string, error) {
+	var first, last uint64
+	if len(batch) > 0 {
+		var err error
+		if first, err = hashArchiveKey(batch[0]); err != nil {
+			return "", err
+		}
+		if last, err = hashArchiveKey(batch[len(batch)-1]); err != nil {
+			return "", err
+		}
+	}
+
+	dir := path.Join(tbl.Schema.O, tbl.Name.O)
+	if tbl.Partition.O != "" {
+		dir = path.Join(dir, tbl.Partition.O)
+	}
+	return path.Join(dir, fmt.Sprintf("%d_%016x_%016x.%s", tbl.ID, first, last, format)), nil
+}
+
+func hashArchiveKey(key []types.Datum) (uint64, error) {
+	encoded, err := codec.EncodeKey(time.UTC, nil, key...)
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(encoded)
+	return h.Sum64(), nil
+}
+
+// Write encodes the rows in the archive format and writes them to the file.
+func (a *ttlArchiver) Write(ctx context.Context, name string, rows []chunk.Row) error {
+	var (
+		data []byte
+		err  error
+	)
+	switch a.format {
+	case archiveFormatCSV:
+		data, err = a.encodeCSV(rows)
+	case archiveFormatParquet:
+		data, err = a.encodeParquet(rows)
+	default:
+		err = errors.Errorf("unknown TTL archive format '%s'", a.format)
+	}
+	if err != nil {
+		return err
+	}
+	return a.store.WriteFile(ctx, name, data)
+}
+
+func (a *ttlArchiver) encodeCSV(rows []chunk.Row) ([]byte, error) {
+	var buf bytes.Buffer
+	for i, col := range a.cols {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeCSVField(&buf, col.Name.O)
+	}
+	buf.WriteByte('\n')
+
+	for _, row := range rows {
+		for i, col := range a.cols {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if row.IsNull(i) {
+				buf.WriteString(archiveCSVNull)
+				continue
+			}
+			d := row.GetDatum(i, &col.FieldType)
+			s, err := d.ToString()
+			if err != nil {
+				return nil, err
+			}
+			writeCSVField(&buf, s)
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+func writeCSVField(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	buf.WriteString(strings.ReplaceAll(s, `"`, `""`))
+	buf.WriteByte('"')
+}
+
+// parquetSchema returns the parquet schema of the archived columns. Integers and floats are stored in INT64 and
+// DOUBLE, binary strings are stored in BYTE_ARRAY, and the other types are stored in UTF8 strings as they are in
+// the csv files.
+func (a *ttlArchiver) parquetSchema() []*parquet.SchemaElement {
+	root := parquet.NewSchemaElement()
+	root.Name = archiveParquetRootName
+	numChildren := int32(len(a.cols))
+	root.NumChildren = &numChildren
+	rootRepetition := parquet.FieldRepetitionType_REQUIRED
+	root.RepetitionType = &rootRepetition
+
+	elements := make([]*parquet.SchemaElement, 0, len(a.cols)+1)
+	elements = append(elements, root)
+	for _, col := range a.cols {
+		se := parquet.NewSchemaElement()
+		se.Name = col.Name.O
+		repetition := parquet.FieldRepetitionType_OPTIONAL
+		se.RepetitionType = &repetition
+		var (
+			tp = parquet.Type_BYTE_ARRAY
+			ct *parquet.ConvertedType
+		)
+		switch col.GetType() {
+		case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeYear:
+			tp = parquet.Type_INT64
+			if mysql.HasUnsignedFlag(col.GetFlag()) && col.GetType() == mysql.TypeLonglong {
+				ct = parquet.ConvertedTypePtr(parquet.ConvertedType_UINT_64)
+			}
+		case mysql.TypeFloat, mysql.TypeDouble:
+			tp = parquet.Type_DOUBLE
+		default:
+			if !types.IsBinaryStr(&col.FieldType) {
+				ct = parquet.ConvertedTypePtr(parquet.ConvertedType_UTF8)
+			}
+		}
+		se.Type = &tp
+		se.ConvertedType = ct
+		elements = append(elements, se)
+	}
+	return elements
+}
+
+func (a *ttlArchiver) encodeParquet(rows []chunk.Row) ([]byte, error) {
+	var buf bytes.Buffer
+	elements := a.parquetSchema()
+	pw := &writer.ParquetWriter{
+		SchemaHandler:   schema.NewSchemaHandlerFromSchemaList(elements),
+		NP:              1,
+		Footer:          parquet.NewFileMetaData(),
+		PFile:           writerfile.NewWriterFile(&buf),
+		PageSize:        archiveParquetPageSize,
+		RowGroupSize:    128 * 1024 * 1024,
+		CompressionType: parquet.CompressionCodec_SNAPPY,
+		Offset:          4,
+		PagesMapBuf:     make(map[string][]*layout.Page),
+		DictRecs:        make(map[string]*layout.DictRecType),
+		MarshalFunc:     marshal.MarshalCSV,
+	}
+	pw.Footer.Version = 1
+	pw.Footer.Schema = append(pw.Footer.Schema, elements...)
+	if _, err := pw.PFile.Write([]byte("PAR1")); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	for _, row := range rows {
+		values := make([]any, len(a.cols))
+		for i, col := range a.cols {
+			if row.IsNull(i) {
+				continue
+			}
+			d := row.GetDatum(i, &col.FieldType)
+			v, err := parquetValue(d, elements[i+1])
+			if err != nil {
+				return nil, err
+			}
+			values[i] = v
+		}
+		if err := pw.Write(values); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	if err := pw.WriteStop(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return buf.Bytes(), nil
+}
+
+func parquetValue(d types.Datum, se *parquet.SchemaElement) (any, error) {
+	switch se.GetType() {
+	case parquet.Type_INT64:
+		if d.Kind() == types.KindUint64 {
+			return int64(d.GetUint64()), nil
+		}
+		return d.GetInt64(), nil
+	case parquet.Type_DOUBLE:
+		if d.Kind() == types.KindFloat32 {
+			return float64(d.GetFloat32()), nil
+		}
+		return d.GetFloat64(), nil
+	default:
+		return d.ToString()
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttlworker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func newArchiveTestData() ([]*model.ColumnInfo, []chunk.Row) {
+	blob := types.NewFieldType(mysql.TypeBlob)
+	blob.SetCharset("binary")
+	blob.SetCollate("binary")
+	cols := []*model.ColumnInfo{
+		{Name: model.NewCIStr("id"), FieldType: *types.NewFieldType(mysql.TypeLonglong)},
+		{Name: model.NewCIStr("score"), FieldType: *types.NewFieldType(mysql.TypeDouble)},
+		{Name: model.NewCIStr("name"), FieldType: *types.NewFieldType(mysql.TypeVarchar)},
+		{Name: model.NewCIStr("photo"), FieldType: *blob},
+	}
+
+	fts := make([]*types.FieldType, 0, len(cols))
+	for _, col := range cols {
+		fts = append(fts, &col.FieldType)
+	}
+	chk := chunk.NewChunkWithCapacity(fts, 2)
+	chk.AppendInt64(0, 1)
+	chk.AppendFloat64(1, 1.5)
+	chk.AppendString(2, `a "quoted", name`)
+	chk.AppendBytes(3, []byte("blob"))
+	chk.AppendInt64(0, 2)
+	chk.AppendNull(1)
+	chk.AppendString(2, "bob")
+	chk.AppendNull(3)
+	return cols, []chunk.Row{chk.GetRow(0), chk.GetRow(1)}
+}
+
+func TestArchiveEncodeCSV(t *testing.T) {
+	cols, rows := newArchiveTestData()
+	a := &ttlArchiver{format: archiveFormatCSV, cols: cols}
+	data, err := a.encodeCSV(rows)
+	require.NoError(t, err)
+	require.Equal(t, "\"id\",\"score\",\"name\",\"photo\"\n"+
+		"\"1\",\"1.5\",\"a \"\"quoted\"\", name\",\"blob\"\n"+
+		"\"2\",\\N,\"bob\",\\N\n", string(data))
+}
+
+func TestArchiveEncodeParquet(t *testing.T) {
+	cols, rows := newArchiveTestData()
+	a := &ttlArchiver{format: archiveFormatParquet, cols: cols}
+	data, err := a.encodeParquet(rows)
+	require.NoError(t, err)
+
+	pf, err := buffer.NewBufferFile(data)
+	require.NoError(t, err)
+	pr, err := reader.NewParquetColumnReader(pf, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	require.Equal(t, int64(2), pr.GetNumRows())
+
+	schema := pr.Footer.Schema
+	require.Len(t, schema, len(cols)+1)
+	expectedTypes := []parquet.Type{parquet.Type_INT64, parquet.Type_DOUBLE, parquet.Type_BYTE_ARRAY, parquet.Type_BYTE_ARRAY}
+	for i, tp := range expectedTypes {
+		require.Equal(t, cols[i].Name.O, pr.SchemaHandler.GetExName(i+1))
+		require.Equal(t, tp, schema[i+1].GetType())
+	}
+	require.Equal(t, parquet.ConvertedType_UTF8, schema[3].GetConvertedType())
+	require.False(t, schema[4].IsSetConvertedType())
+
+	expected := [][]any{
+		{int64(1), int64(2)},
+		{1.5, nil},
+		{`a "quoted", name`, "bob"},
+		{"blob", nil},
+	}
+	for i, col := range expected {
+		values, _, _, err := pr.ReadColumnByIndex(int64(i), 2)
+		require.NoError(t, err)
+		require.Equal(t, col, values, "column %d", i)
+	}
+}
+
+func TestArchiveFileName(t *testing.T) {
+	tbl := newMockTTLTbl(t, "t1")
+	batch1 := [][]types.Datum{{types.NewIntDatum(1)}, {types.NewIntDatum(2)}}
+	batch2 := [][]types.Datum{{types.NewIntDatum(1)}, {types.NewIntDatum(3)}}
+
+	name1, err := archiveFileName(tbl, archiveFormatCSV, batch1)
+	require.NoError(t, err)
+	require.Regexp(t, fmt.Sprintf(`^test/t1/%d_[0-9a-f]{16}_[0-9a-f]{16}\.csv$`, tbl.ID), name1)
+
+	// the name is stable for the same key range, so that the batch archived again overwrites the same file
+	name, err := archiveFileName(tbl, archiveFormatCSV, batch1)
+	require.NoError(t, err)
+	require.Equal(t, name1, name)
+
+	name, err = archiveFileName(tbl, archiveFormatCSV, batch2)
+	require.NoError(t, err)
+	require.NotEqual(t, name1, name)
+
+	name, err = archiveFileName(tbl, archiveFormatParquet, batch1)
+	require.NoError(t, err)
+	require.Equal(t, strings.TrimSuffix(name1, ".csv")+".parquet", name)
+}
+
+func TestCloseArchiveStorages(t *testing.T) {
+	uri := "file://" + t.TempDir()
+	s1, err := getArchiveStorage(context.Background(), uri)
+	require.NoError(t, err)
+	s2, err := getArchiveStorage(context.Background(), uri)
+	require.NoError(t, err)
+	require.Same(t, s1, s2)
+
+	closeArchiveStorages()
+	archiveStorages.Lock()
+	require.Empty(t, archiveStorages.m)
+	archiveStorages.Unlock()
+}
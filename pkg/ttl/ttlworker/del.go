@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/tidb/pkg/ttl/session"
 	"github.com/pingcap/tidb/pkg/ttl/sqlbuilder"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"go.uber.org/zap"
 	"golang.org/x/time/rate"
@@ -92,6 +93,21 @@ func (t *ttlDeleteTask) doDelete(ctx context.Context, rawSe session.Session) (re
 
 	leftRows := t.rows
 	se := newTableSession(rawSe, t.tbl, t.expire)
+
+	var archiver *ttlArchiver
+	if t.tbl.TTLInfo.ArchiveURI != "" {
+		var err error
+		if archiver, err = newTTLArchiver(ctx, t.tbl); err != nil {
+			t.statistics.IncErrorRows(len(leftRows))
+			logutil.BgLogger().Warn(
+				"open archive storage in TTL failed",
+				zap.Error(err),
+				zap.String("table", t.tbl.Schema.O+"."+t.tbl.Name.O),
+			)
+			return
+		}
+	}
+
 	for len(leftRows) > 0 {
 		maxBatch := variable.TTLDeleteBatchSize.Load()
 		var delBatch [][]types.Datum
@@ -122,7 +138,15 @@ func (t *ttlDeleteTask) doDelete(ctx context.Context, rawSe session.Session) (re
 		tracer.EnterPhase(metrics.PhaseOther)
 
 		sqlStart := time.Now()
-		_, needRetry, err := se.ExecuteSQLWithCheck(ctx, sql)
+		var (
+			needRetry bool
+			archived  int
+		)
+		if archiver != nil {
+			archived, needRetry, err = t.archiveAndDelete(ctx, se, archiver, delBatch, sql)
+		} else {
+			_, needRetry, err = se.ExecuteSQLWithCheck(ctx, sql)
+		}
 		sqlInterval := time.Since(sqlStart)
 		if err != nil {
 			metrics.DeleteErrorDuration.Observe(sqlInterval.Seconds())
@@ -147,10 +171,50 @@ func (t *ttlDeleteTask) doDelete(ctx context.Context, rawSe session.Session) (re
 
 		metrics.DeleteSuccessDuration.Observe(sqlInterval.Seconds())
 		t.statistics.IncSuccessRows(len(delBatch))
+		t.statistics.IncArchivedRows(archived)
 	}
 	return retryRows
 }
 
+// archiveAndDelete writes the expired rows of the batch to the archive storage and deletes them in one transaction,
+// the rows are kept in the table if they fail to be archived. It returns the number of the archived rows, which may
+// be less than the batch if some rows are no longer expired.
+func (t *ttlDeleteTask) archiveAndDelete(
+	ctx context.Context,
+	se *ttlTableSession,
+	archiver *ttlArchiver,
+	delBatch [][]types.Datum,
+	deleteSQL string,
+) (int, bool, error) {
+	selectSQL, err := sqlbuilder.BuildArchiveSelectSQL(t.tbl, archiver.cols, delBatch, t.expire)
+	if err != nil {
+		return 0, false, err
+	}
+
+	name, err := archiveFileName(t.tbl, archiver.format, delBatch)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// the transaction may be retried, only the rows archived by the last attempt are committed.
+	archived := 0
+	needRetry, err := se.ArchiveAndDeleteWithCheck(ctx, selectSQL, deleteSQL, func(rows []chunk.Row) error {
+		archived = 0
+		if len(rows) == 0 {
+			return nil
+		}
+		if err := archiver.Write(ctx, name, rows); err != nil {
+			return err
+		}
+		archived = len(rows)
+		return nil
+	})
+	if err != nil {
+		return 0, needRetry, err
+	}
+	return archived, false, nil
+}
+
 type ttlDelRetryItem struct {
 	task     *ttlDeleteTask
 	retryCnt int
@@ -17,10 +17,14 @@ package ttlworker
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/ttl/cache"
 	"github.com/pingcap/tidb/pkg/types"
@@ -278,6 +282,69 @@ func TestTTLDeleteTaskDoDelete(t *testing.T) {
 	}
 }
 
+func TestTTLDeleteTaskDoDeleteWithArchive(t *testing.T) {
+	origBatchSize := variable.TTLDeleteBatchSize.Load()
+	variable.TTLDeleteBatchSize.Store(2)
+	defer variable.TTLDeleteBatchSize.Store(origBatchSize)
+
+	dir := t.TempDir()
+	tbl := newMockTTLTbl(t, "t1")
+	tbl.TTLInfo.ArchiveURI = "file://" + dir
+	s := newMockSession(t)
+	var sqls []string
+	s.executeSQL = func(ctx context.Context, sql string, args ...interface{}) ([]chunk.Row, error) {
+		sqls = append(sqls, sql)
+		s.sessionInfoSchema = newMockInfoSchema(tbl.TableInfo)
+		if strings.HasPrefix(sql, "SELECT") {
+			// only the first row of each batch is still expired
+			rows := newMockRows(t, types.NewFieldType(mysql.TypeDatetime))
+			rows.Append(time.UnixMilli(0).UTC())
+			return rows.Rows(), nil
+		}
+		return nil, nil
+	}
+
+	task := &ttlDeleteTask{
+		tbl:        tbl,
+		expire:     time.UnixMilli(0),
+		rows:       [][]types.Datum{{types.NewIntDatum(1)}, {types.NewIntDatum(2)}, {types.NewIntDatum(3)}},
+		statistics: &ttlStatistics{},
+	}
+	task.statistics.TotalRows.Add(3)
+	require.Nil(t, task.doDelete(context.Background(), s))
+	require.Equal(t, []string{
+		"SELECT LOW_PRIORITY SQL_NO_CACHE `time` FROM `test`.`t1` WHERE `_tidb_rowid` IN (1, 2) AND `time` < FROM_UNIXTIME(0)",
+		"DELETE LOW_PRIORITY FROM `test`.`t1` WHERE `_tidb_rowid` IN (1, 2) AND `time` < FROM_UNIXTIME(0) LIMIT 2",
+		"SELECT LOW_PRIORITY SQL_NO_CACHE `time` FROM `test`.`t1` WHERE `_tidb_rowid` IN (3) AND `time` < FROM_UNIXTIME(0)",
+		"DELETE LOW_PRIORITY FROM `test`.`t1` WHERE `_tidb_rowid` IN (3) AND `time` < FROM_UNIXTIME(0) LIMIT 1",
+	}, sqls)
+	require.Equal(t, uint64(3), task.statistics.SuccessRows.Load())
+	require.Equal(t, uint64(2), task.statistics.ArchivedRows.Load())
+	require.Equal(t, uint64(0), task.statistics.ErrorRows.Load())
+
+	files, err := filepath.Glob(filepath.Join(dir, "test", "t1", fmt.Sprintf("%d_*.csv", tbl.ID)))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	for _, f := range files {
+		content, err := os.ReadFile(f)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(content), "\"time\"\n\"1970-01-01 00:00:00\"\n"))
+	}
+
+	// the rows should not be deleted if they fail to be archived
+	tbl.TTLInfo.ArchiveFormat = "unknown"
+	sqls = nil
+	task.statistics.Reset()
+	task.statistics.TotalRows.Add(3)
+	retryRows := task.doDelete(context.Background(), s)
+	require.Len(t, retryRows, 3)
+	for _, sql := range sqls {
+		require.True(t, strings.HasPrefix(sql, "SELECT"), sql)
+	}
+	require.Equal(t, uint64(0), task.statistics.SuccessRows.Load())
+	require.Equal(t, uint64(0), task.statistics.ArchivedRows.Load())
+}
+
 func TestTTLDeleteRateLimiter(t *testing.T) {
 	origDeleteLimit := variable.TTLDeleteRateLimit.Load()
 	defer func() {
@@ -17,6 +17,7 @@ package ttlworker
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -119,6 +120,10 @@ type ttlJob struct {
 
 	tbl *cache.PhysicalTable
 
+	// maintainingPartitions is true while the partitions of the table are being created or dropped in the background.
+	// The job is not finished until then, because the scan tasks are created if the partition fails to be dropped.
+	maintainingPartitions atomic.Bool
+
 	// status is the only field which should be protected by a mutex, as `Cancel` may be called at any time, and will
 	// change the status
 	statusMutex sync.Mutex
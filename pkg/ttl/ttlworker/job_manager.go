@@ -156,6 +156,7 @@ func (m *JobManager) jobLoop() error {
 		timerRT.Pause()
 		timerStore.Close()
 		err = multierr.Combine(err, multierr.Combine(m.taskManager.resizeScanWorkers(0), m.taskManager.resizeDelWorkers(0)))
+		closeArchiveStorages()
 		se.Close()
 		logutil.Logger(m.ctx).Info("ttlJobManager loop exited.")
 	}()
@@ -506,6 +507,10 @@ func (m *JobManager) checkNotOwnJob() {
 func (m *JobManager) checkFinishedJob(se session.Session) {
 j:
 	for _, job := range m.runningJobs {
+		if job.maintainingPartitions.Load() {
+			continue
+		}
+
 		timeoutJobCtx, cancel := context.WithTimeout(m.ctx, ttlInternalSQLTimeout)
 
 		sql, args := cache.SelectFromTTLTaskWithJobID(job.id)
@@ -691,7 +696,20 @@ func (m *JobManager) lockHBTimeoutJob(ctx context.Context, se session.Session, t
 
 // lockNewJob locks a new job
 func (m *JobManager) lockNewJob(ctx context.Context, se session.Session, table *cache.PhysicalTable, now time.Time, jobID string, checkScheduleInterval bool) (*ttlJob, error) {
+	var lifecycleSe session.Session
+	var lifecycle *ttlPartitionLifecycle
+	if table.TTLInfo.PartitionLifecycle {
+		lifecycleSe, lifecycle = m.preparePartitionLifecycle(ctx, table)
+	}
+	defer func() {
+		// the session is handed over to the background maintenance once the job is locked
+		if lifecycleSe != nil {
+			lifecycleSe.Close()
+		}
+	}()
+
 	var expireTime time.Time
+	dropPartition := false
 	err := se.RunInTxn(ctx, func() error {
 		tableStatus, err := m.getTableStatusForUpdateNotWait(ctx, se, table.ID, table.TableInfo.ID, true)
 		if err != nil {
@@ -719,28 +737,133 @@ func (m *JobManager) lockNewJob(ctx context.Context, se session.Session, table *
 			return errors.Wrapf(err, "execute sql: %s", sql)
 		}
 
-		ranges, err := table.SplitScanRanges(ctx, m.store, splitScanCount)
+		// the partition will be dropped after the job is locked, so no scan task is needed
+		dropPartition = lifecycle != nil && lifecycle.isExpired(expireTime)
+		if dropPartition {
+			return nil
+		}
+
+		return m.createScanTasks(ctx, se, jobID, table, expireTime, now)
+	}, session.TxnModePessimistic)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := m.appendLockedJob(jobID, se, now, expireTime, table)
+	if err != nil {
+		return nil, err
+	}
+
+	if lifecycle != nil {
+		// the partition DDLs may take a long time, so they are run in the background to avoid blocking the job loop
+		job.maintainingPartitions.Store(true)
+		maintainSe := lifecycleSe
+		lifecycleSe = nil
+		m.wg.Run(func() {
+			defer func() {
+				maintainSe.Close()
+				job.maintainingPartitions.Store(false)
+			}()
+			m.maintainPartitions(m.ctx, maintainSe, lifecycle, job, dropPartition, now)
+		})
+	}
+	return job, nil
+}
+
+func (m *JobManager) createScanTasks(ctx context.Context, se session.Session, jobID string, table *cache.PhysicalTable, expireTime time.Time, now time.Time) error {
+	ranges, err := table.SplitScanRanges(ctx, m.store, splitScanCount)
+	if err != nil {
+		return errors.Wrap(err, "split scan ranges")
+	}
+	for scanID, r := range ranges {
+		sql, args, err := cache.InsertIntoTTLTask(se, jobID, table.ID, scanID, r.Start, r.End, expireTime, now)
 		if err != nil {
-			return errors.Wrap(err, "split scan ranges")
+			return errors.Wrap(err, "encode scan task")
 		}
-		for scanID, r := range ranges {
-			sql, args, err = cache.InsertIntoTTLTask(se, jobID, table.ID, scanID, r.Start, r.End, expireTime, now)
-			if err != nil {
-				return errors.Wrap(err, "encode scan task")
-			}
-			_, err = se.ExecuteSQL(ctx, sql, args...)
-			if err != nil {
-				return errors.Wrapf(err, "execute sql: %s", sql)
-			}
+		_, err = se.ExecuteSQL(ctx, sql, args...)
+		if err != nil {
+			return errors.Wrapf(err, "execute sql: %s", sql)
 		}
+	}
+	return nil
+}
 
-		return nil
+// preparePartitionLifecycle gets a new session with the global time zone, which is the same as the one deleting the
+// expired rows, to parse the partition bounds and run the partition DDLs. It returns nil if the lifecycle cannot be
+// applied to the table.
+func (m *JobManager) preparePartitionLifecycle(ctx context.Context, table *cache.PhysicalTable) (session.Session, *ttlPartitionLifecycle) {
+	logger := logutil.Logger(m.ctx).With(zap.Int64("tableID", table.ID))
+	se, err := getSession(m.sessPool)
+	if err != nil {
+		logger.Warn("fail to get session for TTL partition lifecycle", zap.Error(err))
+		return nil, nil
+	}
+
+	if err = se.ResetWithGlobalTimeZone(ctx); err != nil {
+		logger.Warn("fail to reset time zone for TTL partition lifecycle", zap.Error(err))
+		se.Close()
+		return nil, nil
+	}
+
+	lifecycle, err := newTTLPartitionLifecycle(table, se.GetSessionVars().Location())
+	if err != nil {
+		logger.Warn("fail to parse partitions for TTL partition lifecycle", zap.Error(err))
+	}
+	if lifecycle == nil {
+		se.Close()
+		return nil, nil
+	}
+	return se, lifecycle
+}
+
+// maintainPartitions creates the future partitions in advance, and drops the partition of the job if all its rows
+// are expired. If the partition fails to be dropped, the scan tasks are created to delete the expired rows one by one.
+// The future partitions are only created by the job of the last partition, or the job dropping a partition, because
+// the width of the partitions may not be known after the partition is dropped. It runs outside the job loop, so it
+// only uses the session of the lifecycle.
+func (m *JobManager) maintainPartitions(ctx context.Context, se session.Session,
+	lifecycle *ttlPartitionLifecycle, job *ttlJob, dropPartition bool, now time.Time) {
+	logger := logutil.Logger(m.ctx).With(zap.String("jobID", job.id), zap.Int64("tableID", job.tbl.ID))
+	var (
+		sql string
+		ok  bool
+		err error
+	)
+	if dropPartition || lifecycle.isLast() {
+		sql, ok, err = lifecycle.buildPrecreateSQL(now)
+	}
+	if err != nil {
+		logger.Warn("fail to build SQL to create partitions in advance", zap.Error(err))
+	} else if ok {
+		if _, err = se.ExecuteSQL(ctx, sql); err != nil {
+			logger.Warn("fail to create partitions in advance", zap.String("sql", sql), zap.Error(err))
+		} else {
+			logger.Info("create partitions in advance", zap.String("sql", sql))
+		}
+	}
+
+	if !dropPartition {
+		return
+	}
+
+	err = lifecycle.dropPartition(ctx, se)
+	if err == nil {
+		logger.Info("drop expired partition", zap.String("partition", job.tbl.Partition.O))
+		return
+	}
+
+	logger.Warn("fail to drop expired partition, fall back to delete the expired rows", zap.Error(err))
+	err = se.RunInTxn(ctx, func() error {
+		return m.createScanTasks(ctx, se, job.id, job.tbl, job.ttlExpireTime, now)
 	}, session.TxnModePessimistic)
 	if err != nil {
-		return nil, err
+		logger.Warn("fail to create scan tasks", zap.Error(err))
+		return
 	}
 
-	return m.appendLockedJob(jobID, se, now, expireTime, table)
+	if err = m.notificationCli.Notify(m.ctx, scanTaskNotificationType, job.id); err != nil {
+		logger.Warn("fail to trigger scan tasks", zap.Error(err))
+	}
 }
 
 func (m *JobManager) getTableStatusForUpdateNotWait(ctx context.Context, se session.Session, physicalID int64, parentTableID int64, createIfNotExist bool) (*cache.TableStatus, error) {
@@ -882,6 +1005,8 @@ type TTLSummary struct {
 	TotalRows   uint64 `json:"total_rows"`
 	SuccessRows uint64 `json:"success_rows"`
 	ErrorRows   uint64 `json:"error_rows"`
+	// ArchivedRows is the count of the rows which have been archived to the external storage before deleted
+	ArchivedRows uint64 `json:"archived_rows,omitempty"`
 
 	TotalScanTask     int `json:"total_scan_task"`
 	ScheduledScanTask int `json:"scheduled_scan_task"`
@@ -912,6 +1037,7 @@ func summarizeTaskResult(tasks []*cache.TTLTask) (*TTLSummary, error) {
 			summary.TotalRows += t.State.TotalRows
 			summary.SuccessRows += t.State.SuccessRows
 			summary.ErrorRows += t.State.ErrorRows
+			summary.ArchivedRows += t.State.ArchivedRows
 			if len(t.State.ScanTaskErr) > 0 {
 				allErr = multierr.Append(allErr, errors.New(t.State.ScanTaskErr))
 			}
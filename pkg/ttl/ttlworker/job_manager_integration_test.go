@@ -18,18 +18,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/fsouza/fake-gcs-server/fakestorage"
 	"github.com/google/uuid"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	dbsession "github.com/pingcap/tidb/pkg/session"
 	"github.com/pingcap/tidb/pkg/statistics/handle/autoanalyze"
@@ -279,6 +282,228 @@ func TestTriggerTTLJob(t *testing.T) {
 	tk.MustQuery("select id from t order by id asc").Check(testkit.Rows("2", "4"))
 }
 
+func TestTTLArchive(t *testing.T) {
+	failpoint.Enable("github.com/pingcap/tidb/pkg/ttl/ttlworker/task-manager-loop-interval", fmt.Sprintf("return(%d)", time.Second))
+	defer failpoint.Disable("github.com/pingcap/tidb/pkg/ttl/ttlworker/task-manager-loop-interval")
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Minute)
+	defer cancel()
+
+	gcsServer, err := fakestorage.NewServerWithOptions(fakestorage.Options{
+		Scheme:     "http",
+		Host:       "127.0.0.1",
+		PublicHost: "127.0.0.1",
+	})
+	require.NoError(t, err)
+	defer gcsServer.Stop()
+	gcsServer.CreateBucketWithOpts(fakestorage.CreateBucketOpts{Name: "archive"})
+	// for fake gcs server, the endpoint must end with '/'
+	uri := fmt.Sprintf("gs://archive/ttl?endpoint=%s/storage/v1/", gcsServer.URL())
+
+	dir := t.TempDir()
+	store, do := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustGetErrMsg("create table t0(id int primary key, t timestamp) TTL_ARCHIVE='"+uri+"'",
+		"[ddl:8150]Cannot set TTL_ARCHIVE on a table without TTL config")
+	tk.MustGetErrMsg("create table t0(id int primary key, t timestamp) TTL=`t` + INTERVAL 1 DAY TTL_ARCHIVE='unknown://bucket'",
+		"[ddl:8264]The URI of TTL_ARCHIVE unknown://bucket is invalid. Reason: storage unknown not support yet: [BR:ExternalStorage:ErrStorageInvalidConfig]invalid external storage config")
+	tk.MustGetErrMsg("create table t0(id int primary key, t timestamp) TTL=`t` + INTERVAL 1 DAY TTL_ARCHIVE='file://"+dir+"'",
+		"[ddl:8264]The URI of TTL_ARCHIVE file://"+dir+" is invalid. Reason: the local storage is not supported")
+
+	// setting TTL_ARCHIVE requires the FILE privilege
+	tk.MustExec("create user 'ttl_archive'@'%'")
+	tk.MustExec("grant create, alter on test.* to 'ttl_archive'@'%'")
+	userTk := testkit.NewTestKit(t, store)
+	require.NoError(t, userTk.Session().Auth(&auth.UserIdentity{Username: "ttl_archive", Hostname: "%"}, nil, nil, nil))
+	userTk.MustExec("use test")
+	userTk.MustGetErrMsg("create table t0(id int primary key, t timestamp) TTL=`t` + INTERVAL 1 DAY TTL_ARCHIVE='"+uri+"'",
+		"[planner:1227]Access denied; you need (at least one of) the FILE privilege(s) for this operation")
+	userTk.MustExec("create table t0(id int primary key, t timestamp) TTL=`t` + INTERVAL 1 DAY")
+	userTk.MustGetErrMsg("alter table t0 TTL_ARCHIVE='"+uri+"'",
+		"[planner:1227]Access denied; you need (at least one of) the FILE privilege(s) for this operation")
+	userTk.MustExec("alter table t0 TTL_ARCHIVE_FORMAT='parquet'")
+	tk.MustExec("drop table t0")
+
+	tk.MustExec("create table t(id int primary key, t timestamp, v varchar(16)) TTL=`t` + INTERVAL 1 DAY TTL_ARCHIVE='" + uri + "'")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `t` timestamp NULL DEFAULT NULL,\n" +
+		"  `v` varchar(16) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`t` + INTERVAL 1 DAY */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */ /*T![ttl] TTL_ARCHIVE='" + uri + "' TTL_ARCHIVE_FORMAT='csv' */"))
+	tbl, err := do.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tblID := tbl.Meta().ID
+
+	timerStore := timertable.NewTableTimerStore(0, do.SysSessionPool(), "mysql", "tidb_timers", nil)
+	defer timerStore.Close()
+	timerCli := timerapi.NewDefaultTimerClient(timerStore)
+
+	// make sure the table had run a job one time to make the test stable
+	cli := do.TTLJobManager().GetCommandCli()
+	_, _ = client.TriggerNewTTLJob(ctx, cli, "test", "t")
+	waitTTLJobFinished(t, tk, tblID, timerCli)
+
+	now := time.Now()
+	nowDateStr := now.Format("2006-01-02 15:04:05")
+	expireDateStr := now.Add(-time.Hour * 25).Format("2006-01-02 15:04:05")
+	tk.MustExec("insert into t values(1, ?, 'a'), (2, ?, 'b'), (3, ?, NULL)", expireDateStr, nowDateStr, expireDateStr)
+
+	res, err := client.TriggerNewTTLJob(ctx, cli, "test", "t")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.TableResult))
+	jobID := res.TableResult[0].JobID
+	waitTTLJobFinished(t, tk, tblID, timerCli)
+	tk.MustQuery("select id from t order by id asc").Check(testkit.Rows("2"))
+
+	objects, _, err := gcsServer.ListObjects("archive", "ttl/test/t/", "", false)
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	require.True(t, strings.HasSuffix(objects[0].Name, ".csv"), objects[0].Name)
+	obj, err := gcsServer.GetObject("archive", objects[0].Name)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("\"id\",\"t\",\"v\"\n\"1\",\"%s\",\"a\"\n\"3\",\"%s\",\\N\n", expireDateStr, expireDateStr), string(obj.Content))
+
+	rows := tk.MustQuery("select summary_text from mysql.tidb_ttl_job_history where job_id=?", jobID).Rows()
+	require.Len(t, rows, 1)
+	var summary ttlworker.TTLSummary
+	require.NoError(t, json.Unmarshal([]byte(rows[0][0].(string)), &summary))
+	require.Equal(t, uint64(2), summary.SuccessRows)
+	require.Equal(t, uint64(2), summary.ArchivedRows)
+
+	tk.MustExec("alter table t TTL_ARCHIVE_FORMAT='parquet'")
+	tk.MustQuery("show create table t").CheckContain("TTL_ARCHIVE_FORMAT='parquet'")
+	tk.MustExec("alter table t TTL_ARCHIVE=''")
+	tk.MustQuery("show create table t").CheckContain("TTL_JOB_INTERVAL='1h' */")
+	require.NotContains(t, tk.MustQuery("show create table t").Rows()[0][1], "TTL_ARCHIVE")
+	tk.MustExec("alter table t remove ttl")
+	tk.MustGetErrMsg("alter table t TTL_ARCHIVE='"+uri+"'", "[ddl:8150]Cannot set TTL_ARCHIVE on a table without TTL config")
+}
+
+func TestTTLFilter(t *testing.T) {
+	failpoint.Enable("github.com/pingcap/tidb/pkg/ttl/ttlworker/task-manager-loop-interval", fmt.Sprintf("return(%d)", time.Second))
+	defer failpoint.Disable("github.com/pingcap/tidb/pkg/ttl/ttlworker/task-manager-loop-interval")
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Minute)
+	defer cancel()
+
+	store, do := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustGetErrMsg("create table t0(id int primary key, t timestamp) TTL_FILTER='id > 0'",
+		"[ddl:8150]Cannot set TTL_FILTER on a table without TTL config")
+	tk.MustGetErrMsg("create table t0(id int primary key, t timestamp) TTL=`t` + INTERVAL 1 DAY TTL_FILTER='unknown = 1'",
+		"[ddl:1054]Unknown column 'unknown' in 'TTL_FILTER'")
+	tk.MustGetErrMsg("create table t0(id int primary key, t timestamp) TTL=`t` + INTERVAL 1 DAY TTL_FILTER='t < now()'",
+		"[ddl:8265]The TTL_FILTER 't < now()' is invalid. Reason: function now is not allowed")
+	tk.MustExec("create table t(id int primary key, t timestamp, pinned int) TTL=`t` + INTERVAL 1 DAY TTL_FILTER='pinned <> 1 or pinned is null'")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `t` timestamp NULL DEFAULT NULL,\n" +
+		"  `pinned` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`t` + INTERVAL 1 DAY */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */ /*T![ttl] TTL_FILTER='`pinned`!=1 OR `pinned` IS NULL' */"))
+	tbl, err := do.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tblID := tbl.Meta().ID
+
+	timerStore := timertable.NewTableTimerStore(0, do.SysSessionPool(), "mysql", "tidb_timers", nil)
+	defer timerStore.Close()
+	timerCli := timerapi.NewDefaultTimerClient(timerStore)
+
+	// make sure the table had run a job one time to make the test stable
+	cli := do.TTLJobManager().GetCommandCli()
+	_, _ = client.TriggerNewTTLJob(ctx, cli, "test", "t")
+	waitTTLJobFinished(t, tk, tblID, timerCli)
+
+	now := time.Now()
+	nowDateStr := now.Format("2006-01-02 15:04:05")
+	expireDateStr := now.Add(-time.Hour * 25).Format("2006-01-02 15:04:05")
+	tk.MustExec("insert into t values(1, ?, 0), (2, ?, 1), (3, ?, NULL), (4, ?, 0)", expireDateStr, expireDateStr, expireDateStr, nowDateStr)
+
+	_, err = client.TriggerNewTTLJob(ctx, cli, "test", "t")
+	require.NoError(t, err)
+	waitTTLJobFinished(t, tk, tblID, timerCli)
+	tk.MustQuery("select id from t order by id asc").Check(testkit.Rows("2", "4"))
+
+	// the filter is kept when the TTL config is changed, and follows the renamed column
+	tk.MustExec("alter table t TTL=`t` + INTERVAL 2 DAY")
+	tk.MustQuery("show create table t").CheckContain("TTL_FILTER='`pinned`!=1 OR `pinned` IS NULL'")
+	tk.MustExec("alter table t rename column pinned to is_pinned")
+	tk.MustQuery("show create table t").CheckContain("TTL_FILTER='`is_pinned`!=1 OR `is_pinned` IS NULL'")
+	tk.MustGetErrMsg("alter table t drop column is_pinned", "[ddl:8149]Cannot drop column 'is_pinned': needed in TTL config")
+	tk.MustGetErrMsg("alter table t TTL_FILTER='(select 1) = 1'", "[ddl:8265]The TTL_FILTER '(select 1) = 1' is invalid. Reason: subquery is not allowed")
+
+	// an empty filter removes the filter
+	tk.MustExec("alter table t TTL_FILTER=''")
+	require.NotContains(t, tk.MustQuery("show create table t").Rows()[0][1], "TTL_FILTER")
+	tk.MustExec("alter table t drop column is_pinned")
+	tk.MustExec("alter table t remove ttl")
+	tk.MustGetErrMsg("alter table t TTL_FILTER='id > 0'", "[ddl:8150]Cannot set TTL_FILTER on a table without TTL config")
+}
+
+func TestTTLPartitionLifecycle(t *testing.T) {
+	failpoint.Enable("github.com/pingcap/tidb/pkg/ttl/ttlworker/task-manager-loop-interval", fmt.Sprintf("return(%d)", time.Second))
+	defer failpoint.Disable("github.com/pingcap/tidb/pkg/ttl/ttlworker/task-manager-loop-interval")
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Minute)
+	defer cancel()
+
+	store, do := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	day := func(n int) string {
+		return today.AddDate(0, 0, n).Format("2006-01-02")
+	}
+
+	tk.MustGetErrMsg("create table t0(id int, t datetime) TTL_PARTITION_LIFECYCLE='ON'",
+		"[ddl:8150]Cannot set TTL_PARTITION_LIFECYCLE on a table without TTL config")
+	createSQL := "create table %s(id int, t datetime) TTL=`t` + INTERVAL 1 DAY TTL_PARTITION_LIFECYCLE='ON' " +
+		"partition by range columns(t) (partition p0 values less than ('%s'), partition p1 values less than ('%s'))"
+	tk.MustExec(fmt.Sprintf(createSQL, "t", day(-2), day(1)))
+	tk.MustQuery("show create table t").CheckContain("/*T![ttl] TTL_JOB_INTERVAL='1h' */ /*T![ttl] TTL_PARTITION_LIFECYCLE='ON' */")
+	tk.MustExec(fmt.Sprintf(createSQL, "t2", day(-2), day(1)))
+
+	expireDateStr := today.AddDate(0, 0, -3).Format("2006-01-02 15:04:05")
+	nowDateStr := now.Format("2006-01-02 15:04:05")
+	tk.MustExec("insert into t values(1, ?), (2, ?)", expireDateStr, nowDateStr)
+	// the rows whose TTL column is NULL are in the first partition, so it should not be dropped
+	tk.MustExec("insert into t2 values(1, ?), (2, ?), (3, NULL)", expireDateStr, nowDateStr)
+
+	cli := do.TTLJobManager().GetCommandCli()
+	_, _ = client.TriggerNewTTLJob(ctx, cli, "test", "t")
+	_, _ = client.TriggerNewTTLJob(ctx, cli, "test", "t2")
+
+	partitionsOf := func(table string) []string {
+		rows := tk.MustQuery("select partition_name from information_schema.partitions where table_schema='test' and table_name=? order by partition_ordinal_position", table).Rows()
+		names := make([]string, 0, len(rows))
+		for _, row := range rows {
+			names = append(names, row[0].(string))
+		}
+		return names
+	}
+	expected := []string{"p1", "P_LT_" + day(4), "P_LT_" + day(7)}
+	require.Eventually(t, func() bool {
+		return reflect.DeepEqual(expected, partitionsOf("t"))
+	}, 2*time.Minute, time.Second)
+	tk.MustQuery("select id from t order by id").Check(testkit.Rows("2"))
+
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select id from t2 where id = 1").Rows()) == 0
+	}, 2*time.Minute, time.Second)
+	tk.MustQuery("select id from t2 order by id").Check(testkit.Rows("2", "3"))
+	require.Equal(t, "p0", partitionsOf("t2")[0])
+
+	tk.MustExec("alter table t TTL_PARTITION_LIFECYCLE='OFF'")
+	require.NotContains(t, tk.MustQuery("show create table t").Rows()[0][1], "TTL_PARTITION_LIFECYCLE")
+	tk.MustExec("alter table t remove ttl")
+	tk.MustGetErrMsg("alter table t TTL_PARTITION_LIFECYCLE='ON'", "[ddl:8150]Cannot set TTL_PARTITION_LIFECYCLE on a table without TTL config")
+}
+
 func TestTTLDeleteWithTimeZoneChange(t *testing.T) {
 	failpoint.Enable("github.com/pingcap/tidb/pkg/ttl/ttlworker/task-manager-loop-interval", fmt.Sprintf("return(%d)", time.Second))
 	defer failpoint.Disable("github.com/pingcap/tidb/pkg/ttl/ttlworker/task-manager-loop-interval")
@@ -0,0 +1,244 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttlworker
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/ttl/cache"
+	"github.com/pingcap/tidb/pkg/ttl/session"
+	"github.com/pingcap/tidb/pkg/ttl/sqlbuilder"
+	"github.com/pingcap/tidb/pkg/types"
+	driver "github.com/pingcap/tidb/pkg/types/parser_driver"
+)
+
+const (
+	// ttlPartitionPrecreateCount is the count of the partitions kept after the one holding the current time
+	ttlPartitionPrecreateCount = 2
+	// ttlPartitionPrecreateLimit limits the partitions created by one job, in case the table has not been maintained
+	// for a long time
+	ttlPartitionPrecreateLimit = 32
+
+	partitionMaxValue   = "MAXVALUE"
+	partitionNamePrefix = "P_LT_"
+	partitionDateLayout = "2006-01-02"
+	partitionTimeLayout = "2006-01-02 15:04:05"
+)
+
+// partitionStep is the width of a RANGE partition. Months and days are counted by the calendar, because they don't
+// have a fixed duration.
+type partitionStep struct {
+	months int
+	days   int
+	d      time.Duration
+}
+
+func (s partitionStep) valid() bool {
+	return s.months > 0 || s.days > 0 || s.d > 0
+}
+
+func (s partitionStep) add(t time.Time, n int) time.Time {
+	if s.months > 0 || s.days > 0 {
+		return t.AddDate(0, s.months*n, s.days*n)
+	}
+	return t.Add(s.d * time.Duration(n))
+}
+
+// getPartitionStep guesses the width of the partitions by the last two bounds.
+func getPartitionStep(prev, last time.Time) partitionStep {
+	if !last.After(prev) {
+		return partitionStep{}
+	}
+
+	isMidnight := func(t time.Time) bool {
+		return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+	}
+	if isMidnight(prev) && isMidnight(last) {
+		if prev.Day() == 1 && last.Day() == 1 {
+			months := (last.Year()-prev.Year())*12 + int(last.Month()) - int(prev.Month())
+			return partitionStep{months: months}
+		}
+		prevDate := time.Date(prev.Year(), prev.Month(), prev.Day(), 0, 0, 0, 0, time.UTC)
+		lastDate := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.UTC)
+		return partitionStep{days: int(lastDate.Sub(prevDate) / (24 * time.Hour))}
+	}
+	return partitionStep{d: last.Sub(prev)}
+}
+
+// ttlPartitionLifecycle removes the expired rows of a table with `TTL_PARTITION_LIFECYCLE = 'ON'` by dropping the
+// partitions whose rows are all expired, and creates the future partitions in advance. It only works for the
+// tables partitioned by RANGE COLUMNS on the TTL column.
+type ttlPartitionLifecycle struct {
+	tbl *cache.PhysicalTable
+	loc *time.Location
+	// bounds are the upper bounds of the partitions, the MAXVALUE partition is not included
+	bounds      []time.Time
+	hasMaxValue bool
+	// idx is the index of the partition of `tbl` in the definitions
+	idx int
+}
+
+// newTTLPartitionLifecycle returns nil if the lifecycle cannot be applied to the table, then the expired rows should
+// be deleted row by row.
+func newTTLPartitionLifecycle(tbl *cache.PhysicalTable, loc *time.Location) (*ttlPartitionLifecycle, error) {
+	if !tbl.TTLInfo.PartitionLifecycle || tbl.PartitionDef == nil {
+		return nil, nil
+	}
+
+	pi := tbl.TableInfo.Partition
+	if pi.Type != model.PartitionTypeRange || len(pi.Columns) != 1 || pi.Columns[0].L != tbl.TimeColumn.Name.L {
+		return nil, nil
+	}
+
+	switch tbl.TimeColumn.GetType() {
+	case mysql.TypeDate, mysql.TypeDatetime:
+	default:
+		return nil, nil
+	}
+
+	lc := &ttlPartitionLifecycle{tbl: tbl, loc: loc, idx: -1}
+	for i, def := range pi.Definitions {
+		if def.ID == tbl.PartitionDef.ID {
+			lc.idx = i
+		}
+
+		if len(def.LessThan) != 1 {
+			return nil, errors.Errorf("invalid LESS THAN values of partition '%s'", def.Name.O)
+		}
+
+		lessThan := driver.UnwrapFromSingleQuotes(def.LessThan[0])
+		if strings.EqualFold(lessThan, partitionMaxValue) {
+			lc.hasMaxValue = true
+			continue
+		}
+
+		tm, err := types.ParseTime(types.DefaultStmtNoWarningContext, lessThan, tbl.TimeColumn.GetType(), types.MaxFsp)
+		if err != nil {
+			return nil, err
+		}
+
+		bound, err := tm.GoTime(loc)
+		if err != nil {
+			return nil, err
+		}
+		lc.bounds = append(lc.bounds, bound)
+	}
+
+	if lc.idx < 0 {
+		return nil, errors.Errorf("partition '%s' is not found", tbl.Partition.O)
+	}
+	return lc, nil
+}
+
+// isExpired returns whether all the rows in the partition are expired. The last partition is never dropped because
+// a table must have at least one partition. The partition is not dropped either if the table has a TTL_FILTER, because
+// the expired rows not matching the filter should be kept, or if the table has a TTL_ARCHIVE, because the expired rows
+// should be archived before they are deleted.
+func (lc *ttlPartitionLifecycle) isExpired(expire time.Time) bool {
+	if lc.tbl.TTLInfo.Filter != "" || lc.tbl.TTLInfo.ArchiveURI != "" {
+		return false
+	}
+	if len(lc.tbl.TableInfo.Partition.Definitions) <= 1 || lc.idx >= len(lc.bounds) {
+		return false
+	}
+	return !lc.bounds[lc.idx].After(expire)
+}
+
+// dropPartition drops the partition. The rows whose time column is NULL are put in the first partition and never
+// expire, so the first partition is not dropped if it has these rows.
+func (lc *ttlPartitionLifecycle) dropPartition(ctx context.Context, se session.Session) error {
+	if lc.idx == 0 && !mysql.HasNotNullFlag(lc.tbl.TimeColumn.GetFlag()) {
+		sql, err := sqlbuilder.BuildSelectNullTimeSQL(lc.tbl)
+		if err != nil {
+			return err
+		}
+
+		rows, err := se.ExecuteSQL(ctx, sql)
+		if err != nil {
+			return errors.Wrapf(err, "execute sql: %s", sql)
+		}
+
+		if len(rows) > 0 {
+			return errors.New("the partition contains rows whose TTL column is NULL")
+		}
+	}
+
+	sql, err := sqlbuilder.BuildDropPartitionSQL(lc.tbl)
+	if err != nil {
+		return err
+	}
+
+	if _, err = se.ExecuteSQL(ctx, sql); err != nil {
+		return errors.Wrapf(err, "execute sql: %s", sql)
+	}
+	return nil
+}
+
+// isLast returns whether the partition is the last one of the table
+func (lc *ttlPartitionLifecycle) isLast() bool {
+	return lc.idx == len(lc.tbl.TableInfo.Partition.Definitions)-1
+}
+
+// buildPrecreateSQL returns the DDL to create the partitions after the last one until there are
+// `ttlPartitionPrecreateCount` partitions after the one holding `now`. The width of the new partitions is the same as
+// the last partition.
+func (lc *ttlPartitionLifecycle) buildPrecreateSQL(now time.Time) (string, bool, error) {
+	n := len(lc.bounds)
+	if lc.hasMaxValue || n < 2 {
+		return "", false, nil
+	}
+
+	last := lc.bounds[n-1].In(lc.loc)
+	step := getPartitionStep(lc.bounds[n-2].In(lc.loc), last)
+	if !step.valid() {
+		return "", false, nil
+	}
+
+	layout := partitionTimeLayout
+	if lc.tbl.TimeColumn.GetType() == mysql.TypeDate || step.months > 0 || step.days > 0 {
+		layout = partitionDateLayout
+	}
+
+	existNames := make(map[string]struct{}, len(lc.tbl.TableInfo.Partition.Definitions))
+	for _, def := range lc.tbl.TableInfo.Partition.Definitions {
+		existNames[def.Name.L] = struct{}{}
+	}
+
+	target := step.add(now.In(lc.loc), ttlPartitionPrecreateCount)
+	names := make([]string, 0, ttlPartitionPrecreateCount)
+	lessThans := make([]string, 0, ttlPartitionPrecreateCount)
+	for i := 1; i <= ttlPartitionPrecreateLimit && !last.After(target); i++ {
+		last = step.add(lc.bounds[n-1].In(lc.loc), i)
+		lessThan := last.Format(layout)
+		name := partitionNamePrefix + lessThan
+		if _, ok := existNames[strings.ToLower(name)]; ok {
+			return "", false, errors.Errorf("partition '%s' already exists", name)
+		}
+		names = append(names, name)
+		lessThans = append(lessThans, lessThan)
+	}
+
+	if len(names) == 0 {
+		return "", false, nil
+	}
+
+	sql, err := sqlbuilder.BuildAddPartitionsSQL(lc.tbl, names, lessThans)
+	return sql, err == nil, err
+}
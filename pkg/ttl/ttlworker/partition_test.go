@@ -0,0 +1,225 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttlworker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/ttl/cache"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockPartitionLifecycleTbl(t *testing.T, tp byte, partition string, lessThans ...string) *cache.PhysicalTable {
+	defs := make([]model.PartitionDefinition, 0, len(lessThans))
+	for i, lessThan := range lessThans {
+		defs = append(defs, model.PartitionDefinition{
+			ID:       int64(100 + i),
+			Name:     model.NewCIStr("p" + string(rune('0'+i))),
+			LessThan: []string{lessThan},
+		})
+	}
+
+	tblInfo := &model.TableInfo{
+		ID:   1,
+		Name: model.NewCIStr("t1"),
+		Columns: []*model.ColumnInfo{
+			{
+				ID:        1,
+				Name:      model.NewCIStr("time"),
+				Offset:    0,
+				FieldType: *types.NewFieldType(tp),
+				State:     model.StatePublic,
+			},
+		},
+		TTLInfo: &model.TTLInfo{
+			ColumnName:         model.NewCIStr("time"),
+			IntervalExprStr:    "1",
+			IntervalTimeUnit:   int(ast.TimeUnitMonth),
+			Enable:             true,
+			JobInterval:        "1h",
+			PartitionLifecycle: true,
+		},
+		Partition: &model.PartitionInfo{
+			Type:        model.PartitionTypeRange,
+			Columns:     []model.CIStr{model.NewCIStr("time")},
+			Definitions: defs,
+			Enable:      true,
+		},
+		State: model.StatePublic,
+	}
+
+	tbl, err := cache.NewPhysicalTable(model.NewCIStr("test"), tblInfo, model.NewCIStr(partition))
+	require.NoError(t, err)
+	return tbl
+}
+
+func TestGetPartitionStep(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	require.False(t, getPartitionStep(date(2024, 2, 1), date(2024, 2, 1)).valid())
+	require.False(t, getPartitionStep(date(2024, 3, 1), date(2024, 2, 1)).valid())
+
+	step := getPartitionStep(date(2023, 12, 1), date(2024, 1, 1))
+	require.Equal(t, partitionStep{months: 1}, step)
+	require.Equal(t, date(2024, 3, 1), step.add(date(2024, 1, 1), 2))
+
+	step = getPartitionStep(date(2024, 1, 1), date(2024, 4, 1))
+	require.Equal(t, partitionStep{months: 3}, step)
+
+	step = getPartitionStep(date(2024, 2, 27), date(2024, 3, 5))
+	require.Equal(t, partitionStep{days: 7}, step)
+	require.Equal(t, date(2024, 3, 12), step.add(date(2024, 3, 5), 1))
+
+	step = getPartitionStep(date(2024, 1, 1), date(2024, 1, 1).Add(6*time.Hour))
+	require.Equal(t, partitionStep{d: 6 * time.Hour}, step)
+	require.Equal(t, date(2024, 1, 2), step.add(date(2024, 1, 1), 4))
+}
+
+func TestNewTTLPartitionLifecycle(t *testing.T) {
+	tbl := newMockPartitionLifecycleTbl(t, mysql.TypeDatetime, "p1", "'2024-01-01 00:00:00'", "'2024-02-01 00:00:00'", "MAXVALUE")
+	lc, err := newTTLPartitionLifecycle(tbl, time.UTC)
+	require.NoError(t, err)
+	require.NotNil(t, lc)
+	require.Equal(t, 1, lc.idx)
+	require.True(t, lc.hasMaxValue)
+	require.Equal(t, []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}, lc.bounds)
+
+	require.False(t, lc.isExpired(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)))
+	require.True(t, lc.isExpired(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)))
+	require.True(t, lc.isExpired(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+
+	// the partition is not dropped if some expired rows should be kept by the filter
+	tbl.TTLInfo.Filter = "`pinned`=0"
+	require.False(t, lc.isExpired(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+
+	// the partition is not dropped if the expired rows should be archived
+	tbl.TTLInfo.Filter = ""
+	tbl.TTLInfo.ArchiveURI = "s3://bucket/prefix"
+	require.False(t, lc.isExpired(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+
+	// the MAXVALUE partition never expires
+	tbl = newMockPartitionLifecycleTbl(t, mysql.TypeDatetime, "p2", "'2024-01-01 00:00:00'", "'2024-02-01 00:00:00'", "MAXVALUE")
+	lc, err = newTTLPartitionLifecycle(tbl, time.UTC)
+	require.NoError(t, err)
+	require.False(t, lc.isExpired(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	// the only partition is never dropped
+	tbl = newMockPartitionLifecycleTbl(t, mysql.TypeDate, "p0", "'2024-01-01'")
+	lc, err = newTTLPartitionLifecycle(tbl, time.UTC)
+	require.NoError(t, err)
+	require.False(t, lc.isExpired(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	// the bounds are in the time zone of the session
+	loc := time.FixedZone("UTC+8", 8*3600)
+	tbl = newMockPartitionLifecycleTbl(t, mysql.TypeDate, "p0", "'2024-01-01'", "'2024-02-01'")
+	lc, err = newTTLPartitionLifecycle(tbl, loc)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 12, 31, 16, 0, 0, 0, time.UTC), lc.bounds[0].UTC())
+
+	// the lifecycle is not applied to the tables not partitioned by the TTL column
+	tbl = newMockPartitionLifecycleTbl(t, mysql.TypeDate, "p0", "'2024-01-01'", "'2024-02-01'")
+	tbl.TableInfo.Partition.Columns = nil
+	lc, err = newTTLPartitionLifecycle(tbl, time.UTC)
+	require.NoError(t, err)
+	require.Nil(t, lc)
+
+	tbl = newMockPartitionLifecycleTbl(t, mysql.TypeTimestamp, "p0", "'2024-01-01'", "'2024-02-01'")
+	lc, err = newTTLPartitionLifecycle(tbl, time.UTC)
+	require.NoError(t, err)
+	require.Nil(t, lc)
+
+	tbl = newMockPartitionLifecycleTbl(t, mysql.TypeDate, "p0", "'2024-01-01'", "'2024-02-01'")
+	tbl.TTLInfo.PartitionLifecycle = false
+	lc, err = newTTLPartitionLifecycle(tbl, time.UTC)
+	require.NoError(t, err)
+	require.Nil(t, lc)
+
+	tbl = newMockPartitionLifecycleTbl(t, mysql.TypeDate, "p0", "'invalid'", "'2024-02-01'")
+	_, err = newTTLPartitionLifecycle(tbl, time.UTC)
+	require.Error(t, err)
+}
+
+func TestTTLPartitionLifecyclePrecreateSQL(t *testing.T) {
+	now := time.Date(2024, 2, 15, 10, 0, 0, 0, time.UTC)
+
+	tbl := newMockPartitionLifecycleTbl(t, mysql.TypeDate, "p0", "'2024-01-01'", "'2024-02-01'")
+	lc, err := newTTLPartitionLifecycle(tbl, time.UTC)
+	require.NoError(t, err)
+	require.False(t, lc.isLast())
+
+	tbl = newMockPartitionLifecycleTbl(t, mysql.TypeDate, "p1", "'2024-01-01'", "'2024-02-01'")
+	lc, err = newTTLPartitionLifecycle(tbl, time.UTC)
+	require.NoError(t, err)
+	require.True(t, lc.isLast())
+	sql, ok, err := lc.buildPrecreateSQL(now)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "ALTER TABLE `test`.`t1` ADD PARTITION ("+
+		"PARTITION `P_LT_2024-03-01` VALUES LESS THAN ('2024-03-01'), "+
+		"PARTITION `P_LT_2024-04-01` VALUES LESS THAN ('2024-04-01'), "+
+		"PARTITION `P_LT_2024-05-01` VALUES LESS THAN ('2024-05-01'))", sql)
+
+	// enough partitions have been created
+	_, ok, err = lc.buildPrecreateSQL(time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// the partitions of days are named by the date
+	tbl = newMockPartitionLifecycleTbl(t, mysql.TypeDatetime, "p1", "'2024-02-13 00:00:00'", "'2024-02-16 00:00:00'")
+	lc, err = newTTLPartitionLifecycle(tbl, time.UTC)
+	require.NoError(t, err)
+	sql, ok, err = lc.buildPrecreateSQL(now)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "ALTER TABLE `test`.`t1` ADD PARTITION ("+
+		"PARTITION `P_LT_2024-02-19` VALUES LESS THAN ('2024-02-19'), "+
+		"PARTITION `P_LT_2024-02-22` VALUES LESS THAN ('2024-02-22'))", sql)
+
+	// the count of the partitions created by one job is limited
+	tbl = newMockPartitionLifecycleTbl(t, mysql.TypeDatetime, "p1", "'2024-01-01 00:00:00'", "'2024-01-01 01:00:00'")
+	lc, err = newTTLPartitionLifecycle(tbl, time.UTC)
+	require.NoError(t, err)
+	sql, ok, err = lc.buildPrecreateSQL(now)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Contains(t, sql, "PARTITION `P_LT_2024-01-01 02:00:00` VALUES LESS THAN ('2024-01-01 02:00:00')")
+	require.Contains(t, sql, "PARTITION `P_LT_2024-01-02 09:00:00` VALUES LESS THAN ('2024-01-02 09:00:00'))")
+
+	// the table with a MAXVALUE partition doesn't need new partitions
+	tbl = newMockPartitionLifecycleTbl(t, mysql.TypeDate, "p2", "'2024-01-01'", "'2024-02-01'", "MAXVALUE")
+	lc, err = newTTLPartitionLifecycle(tbl, time.UTC)
+	require.NoError(t, err)
+	_, ok, err = lc.buildPrecreateSQL(now)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// the name of the new partition has been used
+	tbl = newMockPartitionLifecycleTbl(t, mysql.TypeDate, "p1", "'2024-01-01'", "'2024-02-01'")
+	tbl.TableInfo.Partition.Definitions[0].Name = model.NewCIStr("P_LT_2024-03-01")
+	lc, err = newTTLPartitionLifecycle(tbl, time.UTC)
+	require.NoError(t, err)
+	_, _, err = lc.buildPrecreateSQL(now)
+	require.ErrorContains(t, err, "already exists")
+}
@@ -44,6 +44,8 @@ type ttlStatistics struct {
 	TotalRows   atomic.Uint64
 	SuccessRows atomic.Uint64
 	ErrorRows   atomic.Uint64
+	// ArchivedRows is a part of SuccessRows which have been archived before deleted
+	ArchivedRows atomic.Uint64
 }
 
 func (s *ttlStatistics) IncTotalRows(cnt int) {
@@ -61,10 +63,15 @@ func (s *ttlStatistics) IncErrorRows(cnt int) {
 	s.ErrorRows.Add(uint64(cnt))
 }
 
+func (s *ttlStatistics) IncArchivedRows(cnt int) {
+	s.ArchivedRows.Add(uint64(cnt))
+}
+
 func (s *ttlStatistics) Reset() {
 	s.SuccessRows.Store(0)
 	s.ErrorRows.Store(0)
 	s.TotalRows.Store(0)
+	s.ArchivedRows.Store(0)
 }
 
 func (s *ttlStatistics) String() string {
@@ -153,19 +153,54 @@ type ttlTableSession struct {
 }
 
 func (s *ttlTableSession) ExecuteSQLWithCheck(ctx context.Context, sql string) ([]chunk.Row, bool, error) {
+	var result []chunk.Row
+	shouldRetry, err := s.executeInTxnWithCheck(ctx, sql, func(rows []chunk.Row) error {
+		result = rows
+		return nil
+	})
+
+	if err != nil {
+		return nil, shouldRetry, err
+	}
+
+	return result, false, nil
+}
+
+// ArchiveAndDeleteWithCheck selects the rows by `selectSQL`, passes them to `archive` and then deletes them by
+// `deleteSQL` in one transaction. The rows will not be deleted if `archive` returns an error.
+func (s *ttlTableSession) ArchiveAndDeleteWithCheck(
+	ctx context.Context,
+	selectSQL, deleteSQL string,
+	archive func([]chunk.Row) error,
+) (bool, error) {
+	tracer := metrics.PhaseTracerFromCtx(ctx)
+	return s.executeInTxnWithCheck(ctx, selectSQL, func(rows []chunk.Row) error {
+		tracer.EnterPhase(metrics.PhaseOther)
+		if err := archive(rows); err != nil {
+			return err
+		}
+
+		tracer.EnterPhase(metrics.PhaseQuery)
+		_, err := s.ExecuteSQL(ctx, deleteSQL)
+		return err
+	})
+}
+
+// executeInTxnWithCheck executes the sql in an optimistic transaction and checks whether the TTL configuration of the
+// table has been changed after it. If the check passes, `then` is called with the result in the same transaction.
+func (s *ttlTableSession) executeInTxnWithCheck(ctx context.Context, sql string, then func([]chunk.Row) error) (bool, error) {
 	tracer := metrics.PhaseTracerFromCtx(ctx)
 	defer tracer.EnterPhase(tracer.Phase())
 
 	tracer.EnterPhase(metrics.PhaseOther)
 	if !variable.EnableTTLJob.Load() {
-		return nil, false, errors.New("global TTL job is disabled")
+		return false, errors.New("global TTL job is disabled")
 	}
 
 	if err := s.ResetWithGlobalTimeZone(ctx); err != nil {
-		return nil, false, err
+		return false, err
 	}
 
-	var result []chunk.Row
 	shouldRetry := true
 	err := s.RunInTxn(ctx, func() error {
 		tracer.EnterPhase(metrics.PhaseQuery)
@@ -183,15 +218,14 @@ func (s *ttlTableSession) ExecuteSQLWithCheck(ctx context.Context, sql string) (
 			return err
 		}
 
-		result = rows
-		return nil
+		return then(rows)
 	}, session.TxnModeOptimistic)
 
 	if err != nil {
-		return nil, shouldRetry, err
+		return shouldRetry, err
 	}
 
-	return result, false, nil
+	return false, nil
 }
 
 func validateTTLWork(ctx context.Context, s session.Session, tbl *cache.PhysicalTable, expire time.Time) error {
@@ -232,6 +266,15 @@ func validateTTLWork(ctx context.Context, s session.Session, tbl *cache.Physical
 		return errors.New("time column name changed")
 	}
 
+	if newTblInfo.TTLInfo.ArchiveURI != tbl.TTLInfo.ArchiveURI ||
+		newTblInfo.TTLInfo.GetArchiveFormat() != tbl.TTLInfo.GetArchiveFormat() {
+		return errors.New("TTL archive changed")
+	}
+
+	if newTblInfo.TTLInfo.Filter != tbl.TTLInfo.Filter {
+		return errors.New("TTL filter changed")
+	}
+
 	if newTblInfo.TTLInfo.IntervalExprStr != tbl.TTLInfo.IntervalExprStr ||
 		newTblInfo.TTLInfo.IntervalTimeUnit != tbl.TTLInfo.IntervalTimeUnit {
 		newExpireTime, err := newTTLTbl.EvalExpireTime(ctx, s, s.Now())
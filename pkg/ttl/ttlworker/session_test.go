@@ -306,6 +306,13 @@ func TestValidateTTLWork(t *testing.T) {
 	err = validateTTLWork(ctx, s, tbl, expire)
 	require.EqualError(t, err, "time column name changed")
 
+	// test filter changed
+	tbl2 = tbl.TableInfo.Clone()
+	tbl2.TTLInfo.Filter = "`time`>0"
+	s.sessionInfoSchema = newMockInfoSchema(tbl2)
+	err = validateTTLWork(ctx, s, tbl, expire)
+	require.EqualError(t, err, "TTL filter changed")
+
 	// test interval changed and expire time before previous
 	tbl2 = tbl.TableInfo.Clone()
 	tbl2.TTLInfo.IntervalExprStr = "10"
@@ -432,9 +432,10 @@ func (m *taskManager) syncTaskFromTable(se session.Session, jobID string, scanID
 func (m *taskManager) updateHeartBeat(ctx context.Context, se session.Session, now time.Time) error {
 	for _, task := range m.runningTasks {
 		state := &cache.TTLTaskState{
-			TotalRows:   task.statistics.TotalRows.Load(),
-			SuccessRows: task.statistics.SuccessRows.Load(),
-			ErrorRows:   task.statistics.ErrorRows.Load(),
+			TotalRows:    task.statistics.TotalRows.Load(),
+			SuccessRows:  task.statistics.SuccessRows.Load(),
+			ErrorRows:    task.statistics.ErrorRows.Load(),
+			ArchivedRows: task.statistics.ArchivedRows.Load(),
 		}
 		if task.result != nil && task.result.err != nil {
 			state.ScanTaskErr = task.result.err.Error()
@@ -474,9 +475,10 @@ func (m *taskManager) checkFinishedTask(se session.Session, now time.Time) {
 
 func (m *taskManager) reportTaskFinished(se session.Session, now time.Time, task *runningScanTask) error {
 	state := &cache.TTLTaskState{
-		TotalRows:   task.statistics.TotalRows.Load(),
-		SuccessRows: task.statistics.SuccessRows.Load(),
-		ErrorRows:   task.statistics.ErrorRows.Load(),
+		TotalRows:    task.statistics.TotalRows.Load(),
+		SuccessRows:  task.statistics.SuccessRows.Load(),
+		ErrorRows:    task.statistics.ErrorRows.Load(),
+		ArchivedRows: task.statistics.ArchivedRows.Load(),
 	}
 	if task.result.err != nil {
 		state.ScanTaskErr = task.result.err.Error()
@@ -448,6 +448,10 @@ var (
 	ErrUnsupportedTTLReferencedByFK = ClassDDL.NewStd(mysql.ErrUnsupportedTTLReferencedByFK)
 	// ErrUnsupportedPrimaryKeyTypeWithTTL returns when create or alter a table with TTL options but the primary key is not supported
 	ErrUnsupportedPrimaryKeyTypeWithTTL = ClassDDL.NewStd(mysql.ErrUnsupportedPrimaryKeyTypeWithTTL)
+	// ErrInvalidTTLArchiveURI returns when the URI of the `TTL_ARCHIVE` option is invalid
+	ErrInvalidTTLArchiveURI = ClassDDL.NewStd(mysql.ErrInvalidTTLArchiveURI)
+	// ErrInvalidTTLFilter returns when the expression of the `TTL_FILTER` option is invalid
+	ErrInvalidTTLFilter = ClassDDL.NewStd(mysql.ErrInvalidTTLFilter)
 
 	// ErrNotSupportedYet returns when tidb does not support this feature.
 	ErrNotSupportedYet = ClassDDL.NewStd(mysql.ErrNotSupportedYet)
@@ -356,6 +356,10 @@ func addInfo(addTo *stmtSummaryByDigestElement, addWith *stmtSummaryByDigestElem
 
 	// plan cache
 	addTo.planCacheHits += addWith.planCacheHits
+	addTo.planCacheUnqualifiedCount += addWith.planCacheUnqualifiedCount
+	for reason, count := range addWith.planCacheUnqualifiedReasons {
+		addTo.planCacheUnqualifiedReasons = AddPlanCacheUnqualifiedReason(addTo.planCacheUnqualifiedReasons, reason, count)
+	}
 
 	// other
 	addTo.sumAffectedRows += addWith.sumAffectedRows
@@ -211,6 +211,9 @@ type stmtSummaryByDigestElement struct {
 	planInCache   bool
 	planCacheHits int64
 	planInBinding bool
+	// planCacheUnqualifiedReasons counts the executions which can't use plan cache by the reason.
+	planCacheUnqualifiedCount   int64
+	planCacheUnqualifiedReasons map[string]int
 	// pessimistic execution retry information.
 	execRetryCount uint
 	execRetryTime  time.Duration
@@ -257,6 +260,9 @@ type StmtExecInfo struct {
 	KeyspaceID        uint32
 	ResourceGroupName string
 	RUDetail          *util.RUDetails
+	// PlanCacheUnqualified is the reason why the statement can't use plan cache, it's empty if plan cache isn't
+	// enabled or the statement is cacheable.
+	PlanCacheUnqualified string
 }
 
 // newStmtSummaryByDigestMap creates an empty stmtSummaryByDigestMap.
@@ -855,6 +861,10 @@ func (ssElement *stmtSummaryByDigestElement) add(sei *StmtExecInfo, intervalSeco
 	} else {
 		ssElement.planInCache = false
 	}
+	if sei.PlanCacheUnqualified != "" {
+		ssElement.planCacheUnqualifiedCount++
+		ssElement.planCacheUnqualifiedReasons = AddPlanCacheUnqualifiedReason(ssElement.planCacheUnqualifiedReasons, sei.PlanCacheUnqualified, 1)
+	}
 
 	// SPM
 	if sei.PlanInBinding {
@@ -916,6 +926,25 @@ func formatSQL(sql string) string {
 	return sql
 }
 
+// maxPlanCacheUnqualifiedReasons is the max number of distinct reasons recorded for a kind of statements. Some reasons
+// contain the values in the statement, limit them to save memory.
+const maxPlanCacheUnqualifiedReasons = 16
+
+// planCacheUnqualifiedOthers is the reason counting the executions whose reasons are not recorded.
+const planCacheUnqualifiedOthers = "others"
+
+// AddPlanCacheUnqualifiedReason adds count to the reason why the statement can't use plan cache.
+func AddPlanCacheUnqualifiedReason(reasons map[string]int, reason string, count int) map[string]int {
+	if reasons == nil {
+		reasons = make(map[string]int)
+	}
+	if _, ok := reasons[reason]; !ok && len(reasons) >= maxPlanCacheUnqualifiedReasons {
+		reason = planCacheUnqualifiedOthers
+	}
+	reasons[reason] += count
+	return reasons
+}
+
 // Format the backoffType map to a string or nil.
 func formatBackoffTypes(backoffMap map[string]int) interface{} {
 	type backoffStat struct {
@@ -119,6 +119,8 @@ const (
 	LastSeenStr                       = "LAST_SEEN"
 	PlanInCacheStr                    = "PLAN_IN_CACHE"
 	PlanCacheHitsStr                  = "PLAN_CACHE_HITS"
+	PlanCacheUnqualifiedStr           = "PLAN_CACHE_UNQUALIFIED"
+	PlanCacheUnqualifiedReasonsStr    = "PLAN_CACHE_UNQUALIFIED_REASONS"
 	PlanInBindingStr                  = "PLAN_IN_BINDING"
 	QuerySampleTextStr                = "QUERY_SAMPLE_TEXT"
 	PrevSampleTextStr                 = "PREV_SAMPLE_TEXT"
@@ -427,6 +429,12 @@ var columnFactoryMap = map[string]columnFactory{
 	PlanCacheHitsStr: func(info columnInfo, record *StmtRecord) interface{} {
 		return record.PlanCacheHits
 	},
+	PlanCacheUnqualifiedStr: func(info columnInfo, record *StmtRecord) interface{} {
+		return record.PlanCacheUnqualifiedCount
+	},
+	PlanCacheUnqualifiedReasonsStr: func(info columnInfo, record *StmtRecord) interface{} {
+		return formatBackoffTypes(record.PlanCacheUnqualifiedReasons)
+	},
 	PlanInBindingStr: func(info columnInfo, record *StmtRecord) interface{} {
 		return record.PlanInBinding
 	},
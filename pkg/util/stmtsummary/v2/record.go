@@ -141,6 +141,9 @@ type StmtRecord struct {
 	PlanInCache   bool  `json:"plan_in_cache"`
 	PlanCacheHits int64 `json:"plan_cache_hits"`
 	PlanInBinding bool  `json:"plan_in_binding"`
+	// PlanCacheUnqualifiedReasons counts the executions which can't use plan cache by the reason.
+	PlanCacheUnqualifiedCount   int64          `json:"plan_cache_unqualified_count"`
+	PlanCacheUnqualifiedReasons map[string]int `json:"plan_cache_unqualified_reasons,omitempty"`
 	// Pessimistic execution retry information.
 	ExecRetryCount uint          `json:"exec_retry_count"`
 	ExecRetryTime  time.Duration `json:"exec_retry_time"`
@@ -368,6 +371,10 @@ func (r *StmtRecord) Add(info *stmtsummary.StmtExecInfo) {
 	} else {
 		r.PlanInCache = false
 	}
+	if info.PlanCacheUnqualified != "" {
+		r.PlanCacheUnqualifiedCount++
+		r.PlanCacheUnqualifiedReasons = stmtsummary.AddPlanCacheUnqualifiedReason(r.PlanCacheUnqualifiedReasons, info.PlanCacheUnqualified, 1)
+	}
 	// SPM
 	if info.PlanInBinding {
 		r.PlanInBinding = true
@@ -542,6 +549,10 @@ func (r *StmtRecord) Merge(other *StmtRecord) {
 	}
 	// Plan cache
 	r.PlanCacheHits += other.PlanCacheHits
+	r.PlanCacheUnqualifiedCount += other.PlanCacheUnqualifiedCount
+	for reason, count := range other.PlanCacheUnqualifiedReasons {
+		r.PlanCacheUnqualifiedReasons = stmtsummary.AddPlanCacheUnqualifiedReason(r.PlanCacheUnqualifiedReasons, reason, count)
+	}
 	// Other
 	r.SumAffectedRows += other.SumAffectedRows
 	r.SumMem += other.SumMem
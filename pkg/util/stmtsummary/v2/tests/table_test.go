@@ -527,6 +527,37 @@ func TestPerformanceSchemaforPlanCache(t *testing.T) {
 		testkit.Rows("3 1"))
 }
 
+func TestPerformanceSchemaforPlanCacheUnqualified(t *testing.T) {
+	setupStmtSummary()
+	defer closeStmtSummary()
+
+	store := testkit.CreateMockStore(t)
+	tmp := testkit.NewTestKit(t, store)
+	tmp.MustExec("set tidb_enable_prepared_plan_cache=ON")
+	tk := newTestKitWithPlanCache(t, store)
+
+	// Clear summaries.
+	tk.MustExec("set global tidb_enable_stmt_summary = 0")
+	tk.MustExec("set global tidb_enable_stmt_summary = 1")
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int)")
+	tk.MustExec("set @v = 1")
+	tk.MustExec("prepare stmt from 'select * from t where a < @v'")
+	tk.MustExec("execute stmt")
+	tk.MustExec("execute stmt")
+	tk.MustQuery("select plan_cache_hits, plan_cache_unqualified, plan_cache_unqualified_reasons from information_schema.statements_summary " +
+		"where digest_text='select * from `t` where `a` < @v'").Check(
+		testkit.Rows("0 2 query has user-defined variables is un-cacheable:2"))
+
+	tk.MustExec("prepare stmt from 'select * from t where a < ?'")
+	tk.MustExec("execute stmt using @v")
+	tk.MustExec("execute stmt using @v")
+	tk.MustQuery("select plan_cache_hits, plan_cache_unqualified, plan_cache_unqualified_reasons from information_schema.statements_summary " +
+		"where digest_text='select * from `t` where `a` < ?'").Check(
+		testkit.Rows("1 0 <nil>"))
+}
+
 func newTestKit(t *testing.T, store kv.Storage) *testkit.TestKit {
 	tk := testkit.NewTestKit(t, store)
 	tk.MustExec("use test")